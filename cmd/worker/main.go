@@ -0,0 +1,22 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"backend/internal/di"
+)
+
+// The worker runs the background jobs and the task queue apart from the
+// server, it stops on SIGINT or SIGTERM once the runs in flight return
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	w := di.InitializeWorker(ctx)
+	err := w.Run(ctx)
+	if err != nil {
+		log.Fatal("Worker run error", err)
+	}
+}
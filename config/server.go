@@ -2,6 +2,8 @@ package config
 
 import (
 	"log"
+	"os"
+	"time"
 
 	govnpayhelper "github.com/electricilies/govnpay/helper"
 
@@ -35,6 +37,85 @@ const (
 	VNPHashAlgo         = "VNP_HASH_ALGO"
 	VNPTMNCode          = "VNP_TMN_CODE"
 	AllowOrigins        = "ALLOW_ORIGINS"
+
+	ProductViewDedupTTL                       = "PRODUCT_VIEW_DEDUP_TTL"
+	ProductViewFlushInterval                  = "PRODUCT_VIEW_FLUSH_INTERVAL"
+	ProductTrendingSchedule                   = "PRODUCT_TRENDING_SCHEDULE"
+	ProductTrendingWindow                     = "PRODUCT_TRENDING_WINDOW"
+	ProductTrendingHalfLife                   = "PRODUCT_TRENDING_HALF_LIFE"
+	ProductTrendingViewWeight                 = "PRODUCT_TRENDING_VIEW_WEIGHT"
+	ProductTrendingPurchaseWeight             = "PRODUCT_TRENDING_PURCHASE_WEIGHT"
+	ProductRecommendationSchedule             = "PRODUCT_RECOMMENDATION_SCHEDULE"
+	ProductRecommendationLimit                = "PRODUCT_RECOMMENDATION_LIMIT"
+	ProductRecommendationCategoryWeight       = "PRODUCT_RECOMMENDATION_CATEGORY_WEIGHT"
+	ProductRecommendationAttributeValueWeight = "PRODUCT_RECOMMENDATION_ATTRIBUTE_VALUE_WEIGHT"
+	ProductRecommendationMinOrders            = "PRODUCT_RECOMMENDATION_MIN_ORDERS"
+	ProductStockReconcileSchedule             = "PRODUCT_STOCK_RECONCILE_SCHEDULE"
+	ProductPublishApplySchedule               = "PRODUCT_PUBLISH_APPLY_SCHEDULE"
+	ProductPriceSyncSchedule                  = "PRODUCT_PRICE_SYNC_SCHEDULE"
+	ProductImageMaxBytes                      = "PRODUCT_IMAGE_MAX_BYTES"
+	ProductImageMaxPixels                     = "PRODUCT_IMAGE_MAX_PIXELS"
+	ProductImageCleanupSchedule               = "PRODUCT_IMAGE_CLEANUP_SCHEDULE"
+	ProductImageCleanupMinAge                 = "PRODUCT_IMAGE_CLEANUP_MIN_AGE"
+	ProductImageCleanupDryRun                 = "PRODUCT_IMAGE_CLEANUP_DRY_RUN"
+	ProductCacheTTL                           = "PRODUCT_CACHE_TTL"
+	ProductCacheStaleTTL                      = "PRODUCT_CACHE_STALE_TTL"
+	ProductCacheTTLJitter                     = "PRODUCT_CACHE_TTL_JITTER"
+	ProductSuggestCacheTTL                    = "PRODUCT_SUGGEST_CACHE_TTL"
+	CacheLocalSize                            = "CACHE_LOCAL_SIZE"
+	CacheLocalTTL                             = "CACHE_LOCAL_TTL"
+	OutboxRelayInterval                       = "OUTBOX_RELAY_INTERVAL"
+	OutboxRelayBatchSize                      = "OUTBOX_RELAY_BATCH_SIZE"
+	OutboxRelayLease                          = "OUTBOX_RELAY_LEASE"
+	OutboxRetryBaseDelay                      = "OUTBOX_RETRY_BASE_DELAY"
+	OutboxRetryMaxDelay                       = "OUTBOX_RETRY_MAX_DELAY"
+	OutboxRetention                           = "OUTBOX_RETENTION"
+	OutboxPurgeInterval                       = "OUTBOX_PURGE_INTERVAL"
+	EventStream                               = "EVENT_STREAM"
+	EventStreamMaxLen                         = "EVENT_STREAM_MAX_LEN"
+	WebhookEnqueueInterval                    = "WEBHOOK_ENQUEUE_INTERVAL"
+	WebhookDeliveryInterval                   = "WEBHOOK_DELIVERY_INTERVAL"
+	WebhookDeliveryBatchSize                  = "WEBHOOK_DELIVERY_BATCH_SIZE"
+	WebhookDeliveryLease                      = "WEBHOOK_DELIVERY_LEASE"
+	WebhookTimeout                            = "WEBHOOK_TIMEOUT"
+	WebhookRetryBaseDelay                     = "WEBHOOK_RETRY_BASE_DELAY"
+	WebhookRetryMaxDelay                      = "WEBHOOK_RETRY_MAX_DELAY"
+	WebhookMaxAttempts                        = "WEBHOOK_MAX_ATTEMPTS"
+	WebhookEventGroup                         = "WEBHOOK_EVENT_GROUP"
+	EventConsumerName                         = "EVENT_CONSUMER_NAME"
+	EventConsumerBatchSize                    = "EVENT_CONSUMER_BATCH_SIZE"
+	EventConsumerMinIdle                      = "EVENT_CONSUMER_MIN_IDLE"
+	EmailEnqueueInterval                      = "EMAIL_ENQUEUE_INTERVAL"
+	EmailSendInterval                         = "EMAIL_SEND_INTERVAL"
+	EmailSendBatchSize                        = "EMAIL_SEND_BATCH_SIZE"
+	EmailSendLease                            = "EMAIL_SEND_LEASE"
+	EmailRetryBaseDelay                       = "EMAIL_RETRY_BASE_DELAY"
+	EmailRetryMaxDelay                        = "EMAIL_RETRY_MAX_DELAY"
+	EmailMaxAttempts                          = "EMAIL_MAX_ATTEMPTS"
+	EmailEventGroup                           = "EMAIL_EVENT_GROUP"
+	EmailDefaultLocale                        = "EMAIL_DEFAULT_LOCALE"
+	SMTPHost                                  = "SMTP_HOST"
+	SMTPPort                                  = "SMTP_PORT"
+	SMTPUsername                              = "SMTP_USERNAME"
+	SMTPPassword                              = "SMTP_PASSWORD"
+	SMTPFrom                                  = "SMTP_FROM"
+	SMTPTimeout                               = "SMTP_TIMEOUT"
+	WorkerMetricsPort                         = "WORKER_METRICS_PORT"
+	TaskRunInterval                           = "TASK_RUN_INTERVAL"
+	TaskRunBatchSize                          = "TASK_RUN_BATCH_SIZE"
+	TaskLease                                 = "TASK_LEASE"
+	TaskRetryBaseDelay                        = "TASK_RETRY_BASE_DELAY"
+	TaskRetryMaxDelay                         = "TASK_RETRY_MAX_DELAY"
+	TaskMaxAttempts                           = "TASK_MAX_ATTEMPTS"
+	TaskScheduleInterval                      = "TASK_SCHEDULE_INTERVAL"
+	TaskRetention                             = "TASK_RETENTION"
+	TaskPurgeInterval                         = "TASK_PURGE_INTERVAL"
+	OrderPaymentDeadlineVNPay                 = "ORDER_PAYMENT_DEADLINE_VNPAY"
+	OrderPaymentDeadlineMoMo                  = "ORDER_PAYMENT_DEADLINE_MOMO"
+	OrderPaymentDeadlineZaloPay               = "ORDER_PAYMENT_DEADLINE_ZALOPAY"
+	OrderExpireSchedule                       = "ORDER_EXPIRE_SCHEDULE"
+	OrderExpireBatchSize                      = "ORDER_EXPIRE_BATCH_SIZE"
+	OrderExpireGracePeriod                    = "ORDER_EXPIRE_GRACE_PERIOD"
 )
 
 type Server struct {
@@ -64,16 +145,175 @@ type Server struct {
 	VNPHashAlgo         string
 	VNPTMNCode          string
 	AllowOrigins        []string
+
+	ProductViewDedupTTL                       time.Duration
+	ProductViewFlushInterval                  time.Duration
+	ProductTrendingSchedule                   string
+	ProductTrendingWindow                     time.Duration
+	ProductTrendingHalfLife                   time.Duration
+	ProductTrendingViewWeight                 float64
+	ProductTrendingPurchaseWeight             float64
+	ProductRecommendationSchedule             string
+	ProductRecommendationLimit                int
+	ProductRecommendationCategoryWeight       float64
+	ProductRecommendationAttributeValueWeight float64
+	ProductRecommendationMinOrders            int
+	ProductStockReconcileSchedule             string
+	ProductPublishApplySchedule               string
+	ProductPriceSyncSchedule                  string
+	ProductImageMaxBytes                      int64
+	ProductImageMaxPixels                     int
+	ProductImageCleanupSchedule               string
+	ProductImageCleanupMinAge                 time.Duration
+	ProductImageCleanupDryRun                 bool
+	ProductCacheTTL                           time.Duration
+	ProductCacheStaleTTL                      time.Duration
+	ProductCacheTTLJitter                     float64
+	ProductSuggestCacheTTL                    time.Duration
+	CacheLocalSize                            int
+	CacheLocalTTL                             time.Duration
+	OutboxRelayInterval                       time.Duration
+	OutboxRelayBatchSize                      int
+	OutboxRelayLease                          time.Duration
+	OutboxRetryBaseDelay                      time.Duration
+	OutboxRetryMaxDelay                       time.Duration
+	OutboxRetention                           time.Duration
+	OutboxPurgeInterval                       time.Duration
+	EventStream                               string
+	EventStreamMaxLen                         int64
+	WebhookEnqueueInterval                    time.Duration
+	WebhookDeliveryInterval                   time.Duration
+	WebhookDeliveryBatchSize                  int
+	WebhookDeliveryLease                      time.Duration
+	WebhookTimeout                            time.Duration
+	WebhookRetryBaseDelay                     time.Duration
+	WebhookRetryMaxDelay                      time.Duration
+	WebhookMaxAttempts                        int
+	WebhookEventGroup                         string
+	EventConsumerName                         string
+	EventConsumerBatchSize                    int
+	EventConsumerMinIdle                      time.Duration
+	EmailEnqueueInterval                      time.Duration
+	EmailSendInterval                         time.Duration
+	EmailSendBatchSize                        int
+	EmailSendLease                            time.Duration
+	EmailRetryBaseDelay                       time.Duration
+	EmailRetryMaxDelay                        time.Duration
+	EmailMaxAttempts                          int
+	EmailEventGroup                           string
+	EmailDefaultLocale                        string
+	SMTPHost                                  string
+	SMTPPort                                  int
+	SMTPUsername                              string
+	SMTPPassword                              string
+	SMTPFrom                                  string
+	SMTPTimeout                               time.Duration
+	WorkerMetricsPort                         int
+	TaskRunInterval                           time.Duration
+	TaskRunBatchSize                          int
+	TaskLease                                 time.Duration
+	TaskRetryBaseDelay                        time.Duration
+	TaskRetryMaxDelay                         time.Duration
+	TaskMaxAttempts                           int
+	TaskScheduleInterval                      time.Duration
+	TaskRetention                             time.Duration
+	TaskPurgeInterval                         time.Duration
+	OrderPaymentDeadlineVNPay                 time.Duration
+	OrderPaymentDeadlineMoMo                  time.Duration
+	OrderPaymentDeadlineZaloPay               time.Duration
+	OrderExpireSchedule                       string
+	OrderExpireBatchSize                      int
+	OrderExpireGracePeriod                    time.Duration
 }
 
 func NewServer() *Server {
 	viper.AutomaticEnv()
 
+	// Every instance consumes events under its own name
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Print("Failed to get hostname for EVENT_CONSUMER_NAME: ", err)
+	}
+
 	viper.SetDefault(DBPort, 5432)
 	viper.SetDefault(LogStdout, true)
 	viper.SetDefault(LogFile, false)
 	viper.SetDefault(AllowOrigins, []string{"*"})
 	viper.SetDefault(VNPHashAlgo, govnpayhelper.Sha256)
+	viper.SetDefault(ProductViewDedupTTL, 30*time.Minute)
+	viper.SetDefault(ProductViewFlushInterval, time.Minute)
+	viper.SetDefault(ProductTrendingSchedule, "0 * * * *")
+	viper.SetDefault(ProductTrendingWindow, 30*24*time.Hour)
+	viper.SetDefault(ProductTrendingHalfLife, 3*24*time.Hour)
+	viper.SetDefault(ProductTrendingViewWeight, 1)
+	viper.SetDefault(ProductTrendingPurchaseWeight, 10)
+	viper.SetDefault(ProductRecommendationSchedule, "0 */6 * * *")
+	viper.SetDefault(ProductRecommendationLimit, 20)
+	viper.SetDefault(ProductRecommendationCategoryWeight, 1)
+	viper.SetDefault(ProductRecommendationAttributeValueWeight, 2)
+	viper.SetDefault(ProductRecommendationMinOrders, 1)
+	viper.SetDefault(ProductStockReconcileSchedule, "30 * * * *")
+	viper.SetDefault(ProductPublishApplySchedule, "* * * * *")
+	viper.SetDefault(ProductPriceSyncSchedule, "* * * * *")
+	viper.SetDefault(ProductImageMaxBytes, 10<<20)
+	viper.SetDefault(ProductImageMaxPixels, 40_000_000)
+	viper.SetDefault(ProductImageCleanupSchedule, "0 3 * * *")
+	viper.SetDefault(ProductImageCleanupMinAge, 24*time.Hour)
+	viper.SetDefault(ProductImageCleanupDryRun, false)
+	viper.SetDefault(ProductCacheTTL, time.Hour)
+	viper.SetDefault(ProductCacheStaleTTL, 5*time.Minute)
+	viper.SetDefault(ProductCacheTTLJitter, 0.1)
+	viper.SetDefault(ProductSuggestCacheTTL, 5*time.Minute)
+	viper.SetDefault(CacheLocalSize, 1000)
+	viper.SetDefault(CacheLocalTTL, 30*time.Second)
+	viper.SetDefault(OutboxRelayInterval, time.Second)
+	viper.SetDefault(OutboxRelayBatchSize, 100)
+	viper.SetDefault(OutboxRelayLease, 30*time.Second)
+	viper.SetDefault(OutboxRetryBaseDelay, time.Second)
+	viper.SetDefault(OutboxRetryMaxDelay, 5*time.Minute)
+	viper.SetDefault(OutboxRetention, 7*24*time.Hour)
+	viper.SetDefault(OutboxPurgeInterval, time.Hour)
+	viper.SetDefault(EventStream, "events")
+	viper.SetDefault(EventStreamMaxLen, 100_000)
+	viper.SetDefault(WebhookEnqueueInterval, time.Second)
+	viper.SetDefault(WebhookDeliveryInterval, time.Second)
+	viper.SetDefault(WebhookDeliveryBatchSize, 50)
+	viper.SetDefault(WebhookDeliveryLease, time.Minute)
+	viper.SetDefault(WebhookTimeout, 10*time.Second)
+	viper.SetDefault(WebhookRetryBaseDelay, 30*time.Second)
+	viper.SetDefault(WebhookRetryMaxDelay, 6*time.Hour)
+	viper.SetDefault(WebhookMaxAttempts, 10)
+	viper.SetDefault(WebhookEventGroup, "webhooks")
+	viper.SetDefault(EventConsumerName, hostname)
+	viper.SetDefault(EventConsumerBatchSize, 100)
+	viper.SetDefault(EventConsumerMinIdle, time.Minute)
+	viper.SetDefault(EmailEnqueueInterval, time.Second)
+	viper.SetDefault(EmailSendInterval, time.Second)
+	viper.SetDefault(EmailSendBatchSize, 50)
+	viper.SetDefault(EmailSendLease, time.Minute)
+	viper.SetDefault(EmailRetryBaseDelay, time.Minute)
+	viper.SetDefault(EmailRetryMaxDelay, 6*time.Hour)
+	viper.SetDefault(EmailMaxAttempts, 8)
+	viper.SetDefault(EmailEventGroup, "emails")
+	viper.SetDefault(EmailDefaultLocale, "vi")
+	viper.SetDefault(SMTPPort, 587)
+	viper.SetDefault(SMTPTimeout, 10*time.Second)
+	viper.SetDefault(WorkerMetricsPort, 9090)
+	viper.SetDefault(TaskRunInterval, time.Second)
+	viper.SetDefault(TaskRunBatchSize, 20)
+	viper.SetDefault(TaskLease, 5*time.Minute)
+	viper.SetDefault(TaskRetryBaseDelay, 10*time.Second)
+	viper.SetDefault(TaskRetryMaxDelay, time.Hour)
+	viper.SetDefault(TaskMaxAttempts, 5)
+	viper.SetDefault(TaskScheduleInterval, 15*time.Second)
+	viper.SetDefault(TaskRetention, 7*24*time.Hour)
+	viper.SetDefault(TaskPurgeInterval, time.Hour)
+	viper.SetDefault(OrderPaymentDeadlineVNPay, 15*time.Minute)
+	viper.SetDefault(OrderPaymentDeadlineMoMo, 15*time.Minute)
+	viper.SetDefault(OrderPaymentDeadlineZaloPay, 15*time.Minute)
+	viper.SetDefault(OrderExpireSchedule, "* * * * *")
+	viper.SetDefault(OrderExpireBatchSize, 100)
+	viper.SetDefault(OrderExpireGracePeriod, 5*time.Minute)
 
 	viper.SetDefault(TimeZone, "Asia/Ho_Chi_Minh")
 	if viper.GetString(S3Bucket) == "" {
@@ -107,5 +347,84 @@ func NewServer() *Server {
 		VNPHashAlgo:         viper.GetString(VNPHashAlgo),
 		VNPTMNCode:          viper.GetString(VNPTMNCode),
 		AllowOrigins:        viper.GetStringSlice(AllowOrigins),
+
+		ProductViewDedupTTL:                       viper.GetDuration(ProductViewDedupTTL),
+		ProductViewFlushInterval:                  viper.GetDuration(ProductViewFlushInterval),
+		ProductTrendingSchedule:                   viper.GetString(ProductTrendingSchedule),
+		ProductTrendingWindow:                     viper.GetDuration(ProductTrendingWindow),
+		ProductTrendingHalfLife:                   viper.GetDuration(ProductTrendingHalfLife),
+		ProductTrendingViewWeight:                 viper.GetFloat64(ProductTrendingViewWeight),
+		ProductTrendingPurchaseWeight:             viper.GetFloat64(ProductTrendingPurchaseWeight),
+		ProductRecommendationSchedule:             viper.GetString(ProductRecommendationSchedule),
+		ProductRecommendationLimit:                viper.GetInt(ProductRecommendationLimit),
+		ProductRecommendationCategoryWeight:       viper.GetFloat64(ProductRecommendationCategoryWeight),
+		ProductRecommendationAttributeValueWeight: viper.GetFloat64(ProductRecommendationAttributeValueWeight),
+		ProductRecommendationMinOrders:            viper.GetInt(ProductRecommendationMinOrders),
+		ProductStockReconcileSchedule:             viper.GetString(ProductStockReconcileSchedule),
+		ProductPublishApplySchedule:               viper.GetString(ProductPublishApplySchedule),
+		ProductPriceSyncSchedule:                  viper.GetString(ProductPriceSyncSchedule),
+		ProductImageMaxBytes:                      viper.GetInt64(ProductImageMaxBytes),
+		ProductImageMaxPixels:                     viper.GetInt(ProductImageMaxPixels),
+		ProductImageCleanupSchedule:               viper.GetString(ProductImageCleanupSchedule),
+		ProductImageCleanupMinAge:                 viper.GetDuration(ProductImageCleanupMinAge),
+		ProductImageCleanupDryRun:                 viper.GetBool(ProductImageCleanupDryRun),
+		ProductCacheTTL:                           viper.GetDuration(ProductCacheTTL),
+		ProductCacheStaleTTL:                      viper.GetDuration(ProductCacheStaleTTL),
+		ProductCacheTTLJitter:                     viper.GetFloat64(ProductCacheTTLJitter),
+		ProductSuggestCacheTTL:                    viper.GetDuration(ProductSuggestCacheTTL),
+		CacheLocalSize:                            viper.GetInt(CacheLocalSize),
+		CacheLocalTTL:                             viper.GetDuration(CacheLocalTTL),
+		OutboxRelayInterval:                       viper.GetDuration(OutboxRelayInterval),
+		OutboxRelayBatchSize:                      viper.GetInt(OutboxRelayBatchSize),
+		OutboxRelayLease:                          viper.GetDuration(OutboxRelayLease),
+		OutboxRetryBaseDelay:                      viper.GetDuration(OutboxRetryBaseDelay),
+		OutboxRetryMaxDelay:                       viper.GetDuration(OutboxRetryMaxDelay),
+		OutboxRetention:                           viper.GetDuration(OutboxRetention),
+		OutboxPurgeInterval:                       viper.GetDuration(OutboxPurgeInterval),
+		EventStream:                               viper.GetString(EventStream),
+		EventStreamMaxLen:                         viper.GetInt64(EventStreamMaxLen),
+		WebhookEnqueueInterval:                    viper.GetDuration(WebhookEnqueueInterval),
+		WebhookDeliveryInterval:                   viper.GetDuration(WebhookDeliveryInterval),
+		WebhookDeliveryBatchSize:                  viper.GetInt(WebhookDeliveryBatchSize),
+		WebhookDeliveryLease:                      viper.GetDuration(WebhookDeliveryLease),
+		WebhookTimeout:                            viper.GetDuration(WebhookTimeout),
+		WebhookRetryBaseDelay:                     viper.GetDuration(WebhookRetryBaseDelay),
+		WebhookRetryMaxDelay:                      viper.GetDuration(WebhookRetryMaxDelay),
+		WebhookMaxAttempts:                        viper.GetInt(WebhookMaxAttempts),
+		WebhookEventGroup:                         viper.GetString(WebhookEventGroup),
+		EventConsumerName:                         viper.GetString(EventConsumerName),
+		EventConsumerBatchSize:                    viper.GetInt(EventConsumerBatchSize),
+		EventConsumerMinIdle:                      viper.GetDuration(EventConsumerMinIdle),
+		EmailEnqueueInterval:                      viper.GetDuration(EmailEnqueueInterval),
+		EmailSendInterval:                         viper.GetDuration(EmailSendInterval),
+		EmailSendBatchSize:                        viper.GetInt(EmailSendBatchSize),
+		EmailSendLease:                            viper.GetDuration(EmailSendLease),
+		EmailRetryBaseDelay:                       viper.GetDuration(EmailRetryBaseDelay),
+		EmailRetryMaxDelay:                        viper.GetDuration(EmailRetryMaxDelay),
+		EmailMaxAttempts:                          viper.GetInt(EmailMaxAttempts),
+		EmailEventGroup:                           viper.GetString(EmailEventGroup),
+		EmailDefaultLocale:                        viper.GetString(EmailDefaultLocale),
+		SMTPHost:                                  viper.GetString(SMTPHost),
+		SMTPPort:                                  viper.GetInt(SMTPPort),
+		SMTPUsername:                              viper.GetString(SMTPUsername),
+		SMTPPassword:                              viper.GetString(SMTPPassword),
+		SMTPFrom:                                  viper.GetString(SMTPFrom),
+		SMTPTimeout:                               viper.GetDuration(SMTPTimeout),
+		WorkerMetricsPort:                         viper.GetInt(WorkerMetricsPort),
+		TaskRunInterval:                           viper.GetDuration(TaskRunInterval),
+		TaskRunBatchSize:                          viper.GetInt(TaskRunBatchSize),
+		TaskLease:                                 viper.GetDuration(TaskLease),
+		TaskRetryBaseDelay:                        viper.GetDuration(TaskRetryBaseDelay),
+		TaskRetryMaxDelay:                         viper.GetDuration(TaskRetryMaxDelay),
+		TaskMaxAttempts:                           viper.GetInt(TaskMaxAttempts),
+		TaskScheduleInterval:                      viper.GetDuration(TaskScheduleInterval),
+		TaskRetention:                             viper.GetDuration(TaskRetention),
+		TaskPurgeInterval:                         viper.GetDuration(TaskPurgeInterval),
+		OrderPaymentDeadlineVNPay:                 viper.GetDuration(OrderPaymentDeadlineVNPay),
+		OrderPaymentDeadlineMoMo:                  viper.GetDuration(OrderPaymentDeadlineMoMo),
+		OrderPaymentDeadlineZaloPay:               viper.GetDuration(OrderPaymentDeadlineZaloPay),
+		OrderExpireSchedule:                       viper.GetString(OrderExpireSchedule),
+		OrderExpireBatchSize:                      viper.GetInt(OrderExpireBatchSize),
+		OrderExpireGracePeriod:                    viper.GetDuration(OrderExpireGracePeriod),
 	}
 }
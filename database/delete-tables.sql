@@ -5,17 +5,25 @@ DROP TABLE public.attributes CASCADE;
 DROP TABLE public.cart_items CASCADE;
 DROP TABLE public.carts CASCADE;
 DROP TABLE public.categories CASCADE;
+DROP TABLE public.emails CASCADE;
+DROP TABLE public.notifications CASCADE;
 DROP TABLE public.option_values CASCADE;
 DROP TABLE public.option_values_product_variants CASCADE;
 DROP TABLE public.options CASCADE;
 DROP TABLE public.order_items CASCADE;
 DROP TABLE public.order_statuses CASCADE;
 DROP TABLE public.orders CASCADE;
+DROP TABLE public.outbox CASCADE;
 DROP TABLE public.payment_methods CASCADE;
 DROP TABLE public.payment_providers CASCADE;
 DROP TABLE public.payment_statuses CASCADE;
 DROP TABLE public.payments CASCADE;
+DROP TABLE public.product_daily_stats CASCADE;
+DROP TABLE public.product_image_renditions CASCADE;
 DROP TABLE public.product_images CASCADE;
+DROP TABLE public.product_recommendations CASCADE;
+DROP TABLE public.product_specs CASCADE;
+DROP TABLE public.product_variant_components CASCADE;
 DROP TABLE public.product_variants CASCADE;
 DROP TABLE public.products CASCADE;
 DROP TABLE public.products_attribute_values CASCADE;
@@ -25,6 +33,15 @@ DROP TABLE public.refunds CASCADE;
 DROP TABLE public.return_request_statuses CASCADE;
 DROP TABLE public.return_requests CASCADE;
 DROP TABLE public.reviews CASCADE;
+DROP TABLE public.spec_groups CASCADE;
+DROP TABLE public.specs CASCADE;
+DROP TABLE public.stock_movements CASCADE;
+DROP TABLE public.stock_subscriptions CASCADE;
+DROP TABLE public.tasks CASCADE;
 DROP TABLE public.users CASCADE;
+DROP TABLE public.warehouse_stocks CASCADE;
+DROP TABLE public.warehouses CASCADE;
+DROP TABLE public.webhook_deliveries CASCADE;
+DROP TABLE public.webhooks CASCADE;
 
 COMMIT;
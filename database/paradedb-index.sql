@@ -1,15 +1,36 @@
+-- ascii_folding strips Vietnamese diacritics (e.g. "điện thoại" -> "dien thoai")
+-- so both search and suggestion match regardless of accents
 CREATE INDEX IF NOT EXISTS attributes_search_idx ON attributes
-USING bm25 (id, code, name)
+USING bm25 (
+  id,
+  (code::pdb.simple('ascii_folding=true')),
+  (name::pdb.simple('ascii_folding=true'))
+)
 WITH (key_field = 'id');
 
 CREATE INDEX IF NOT EXISTS attribute_values_search_idx ON attribute_values
-USING bm25 (id, value)
+USING bm25 (
+  id,
+  (value::pdb.simple('ascii_folding=true'))
+)
 WITH (key_field = 'id');
 
 CREATE INDEX IF NOT EXISTS categories_search_idx ON categories
-USING bm25 (id, name)
+USING bm25 (
+  id,
+  (name::pdb.simple('ascii_folding=true'))
+)
 WITH (key_field = 'id');
 
+-- search_* columns are denormalized from variants, options and attribute values
+-- by triggers; SKUs use the whitespace tokenizer to keep codes like "SM-G991B" whole
 CREATE INDEX IF NOT EXISTS products_search_idx ON products
-USING bm25 (id, name)
+USING bm25 (
+  id,
+  (name::pdb.simple('ascii_folding=true')),
+  (description::pdb.simple('ascii_folding=true')),
+  (search_skus::pdb.whitespace),
+  (search_option_values::pdb.simple('ascii_folding=true')),
+  (search_attribute_values::pdb.simple('ascii_folding=true'))
+)
 WITH (key_field = 'id');
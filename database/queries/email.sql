@@ -0,0 +1,102 @@
+-- An event gets at most one email of a kind, the relay publishes events at
+-- least once
+-- name: InsertEmails :exec
+INSERT INTO emails (
+  id,
+  kind,
+  event_id,
+  user_id,
+  recipient,
+  locale,
+  subject,
+  text_body,
+  html_body,
+  next_attempt_at,
+  created_at,
+  updated_at
+)
+SELECT
+  unnest(sqlc.arg('ids')::uuid[]),
+  unnest(sqlc.arg('kinds')::text[]),
+  unnest(sqlc.arg('event_ids')::uuid[]),
+  unnest(sqlc.arg('user_ids')::uuid[]),
+  unnest(sqlc.arg('recipients')::text[]),
+  unnest(sqlc.arg('locales')::text[]),
+  unnest(sqlc.arg('subjects')::text[]),
+  unnest(sqlc.arg('text_bodies')::text[]),
+  unnest(sqlc.arg('html_bodies')::text[]),
+  unnest(sqlc.arg('next_attempt_ats')::timestamptz[]),
+  unnest(sqlc.arg('created_ats')::timestamptz[]),
+  unnest(sqlc.arg('updated_ats')::timestamptz[])
+ON CONFLICT (event_id, kind) DO NOTHING;
+
+-- name: UpdateEmail :exec
+UPDATE emails
+SET
+  status = sqlc.arg('status'),
+  attempts = sqlc.arg('attempts'),
+  last_error = sqlc.arg('last_error'),
+  next_attempt_at = sqlc.arg('next_attempt_at'),
+  updated_at = sqlc.arg('updated_at'),
+  sent_at = NULLIF(sqlc.arg('sent_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+WHERE
+  id = sqlc.arg('id');
+
+-- name: ListEmails :many
+SELECT
+  *
+FROM
+  emails
+WHERE
+  CASE
+    WHEN sqlc.arg('user_ids')::uuid[] IS NULL THEN TRUE
+    ELSE user_id = ANY (sqlc.arg('user_ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('status')::text = '' THEN TRUE
+    ELSE status = sqlc.arg('status')::text
+  END
+ORDER BY
+  created_at DESC,
+  id DESC
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountEmails :one
+SELECT
+  COUNT(*) AS count
+FROM
+  emails
+WHERE
+  CASE
+    WHEN sqlc.arg('user_ids')::uuid[] IS NULL THEN TRUE
+    ELSE user_id = ANY (sqlc.arg('user_ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('status')::text = '' THEN TRUE
+    ELSE status = sqlc.arg('status')::text
+  END;
+
+-- ClaimEmails leases the oldest due pending emails by pushing their next
+-- attempt after the lease, like ClaimWebhookDeliveries
+-- name: ClaimEmails :many
+UPDATE emails
+SET
+  next_attempt_at = sqlc.arg('lease_until')::timestamptz
+WHERE
+  id IN (
+    SELECT
+      id
+    FROM
+      emails
+    WHERE
+      status = 'pending'
+      AND next_attempt_at <= sqlc.arg('now')::timestamptz
+    ORDER BY
+      next_attempt_at,
+      id
+    LIMIT sqlc.arg('limit')::integer
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING
+  *;
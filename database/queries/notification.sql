@@ -0,0 +1,65 @@
+-- name: UpsertNotification :exec
+INSERT INTO notifications (
+  id,
+  user_id,
+  kind,
+  product_id,
+  product_variant_id,
+  quantity,
+  created_at,
+  read_at
+) VALUES (
+  sqlc.arg('id'),
+  sqlc.arg('user_id'),
+  sqlc.arg('kind'),
+  sqlc.arg('product_id'),
+  sqlc.arg('product_variant_id'),
+  sqlc.arg('quantity'),
+  sqlc.arg('created_at'),
+  sqlc.arg('read_at')
+)
+ON CONFLICT (id) DO UPDATE SET
+  read_at = EXCLUDED.read_at;
+
+-- name: ListNotifications :many
+SELECT
+  *
+FROM
+  notifications
+WHERE
+  CASE
+    WHEN sqlc.arg('staff')::boolean THEN user_id IS NULL
+    ELSE user_id = sqlc.arg('user_id')::uuid
+  END
+  AND CASE
+    WHEN sqlc.arg('unread')::boolean THEN read_at IS NULL
+    ELSE TRUE
+  END
+ORDER BY
+  created_at DESC,
+  id DESC
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountNotifications :one
+SELECT
+  COUNT(*) AS count
+FROM
+  notifications
+WHERE
+  CASE
+    WHEN sqlc.arg('staff')::boolean THEN user_id IS NULL
+    ELSE user_id = sqlc.arg('user_id')::uuid
+  END
+  AND CASE
+    WHEN sqlc.arg('unread')::boolean THEN read_at IS NULL
+    ELSE TRUE
+  END;
+
+-- name: GetNotification :one
+SELECT
+  *
+FROM
+  notifications
+WHERE
+  id = sqlc.arg('id');
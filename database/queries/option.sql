@@ -125,7 +125,7 @@ WHEN MATCHED THEN
   UPDATE SET
     name = source.name,
     product_id = source.product_id,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -140,7 +140,8 @@ WHEN NOT MATCHED THEN
     NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
   )
 WHEN NOT MATCHED BY SOURCE
-  AND target.product_id = ANY (SELECT DISTINCT product_id FROM temp_options) THEN
+  AND target.product_id = ANY (SELECT DISTINCT product_id FROM temp_options)
+  AND target.deleted_at IS NULL THEN
   DELETE;
 
 -- name: CreateTempTableOptionValues :exec
@@ -172,7 +173,7 @@ WHEN MATCHED THEN
   UPDATE SET
     value = source.value,
     option_id = source.option_id,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -187,5 +188,6 @@ WHEN NOT MATCHED THEN
     NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
   )
 WHEN NOT MATCHED BY SOURCE
-  AND target.option_id = ANY (SELECT DISTINCT option_id FROM temp_option_values) THEN
+  AND target.option_id = ANY (SELECT DISTINCT option_id FROM temp_option_values)
+  AND target.deleted_at IS NULL THEN
   DELETE;
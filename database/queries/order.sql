@@ -88,6 +88,11 @@ WHERE
     WHEN sqlc.arg('status_name')::text = '' THEN TRUE
     ELSE orders_with_statuses.status_name IS NOT NULL
   END
+  AND CASE
+    WHEN sqlc.arg('after_id')::uuid IS NULL THEN TRUE
+    WHEN sqlc.arg('after_id')::uuid = '00000000-0000-0000-0000-000000000000'::uuid THEN TRUE
+    ELSE orders.id > sqlc.arg('after_id')::uuid
+  END
 ORDER BY
   orders.id ASC
 OFFSET sqlc.arg('offset')::integer
@@ -146,6 +151,39 @@ WHERE
     ELSE orders_with_statuses.status_name IS NOT NULL
   END;
 
+-- name: ListUnpaidOrderIDs :many
+SELECT
+  orders.id
+FROM
+  orders
+INNER JOIN
+  order_statuses ON orders.status_id = order_statuses.id
+INNER JOIN
+  order_providers ON orders.provider_id = order_providers.id
+WHERE
+  NOT orders.is_paid
+  AND order_statuses.name = sqlc.arg('status_name')::text
+  AND order_providers.name = sqlc.arg('provider_name')::text
+  AND orders.created_at < sqlc.arg('created_before')::timestamptz
+ORDER BY
+  orders.created_at ASC,
+  orders.id ASC
+LIMIT sqlc.arg('limit')::integer;
+
+-- LockOrderPaymentState locks an order until the end of the transaction, the
+-- state read can not change before it is written
+-- name: LockOrderPaymentState :one
+SELECT
+  order_statuses.name AS status_name,
+  orders.is_paid
+FROM
+  orders
+INNER JOIN
+  order_statuses ON orders.status_id = order_statuses.id
+WHERE
+  orders.id = sqlc.arg('id')
+FOR UPDATE OF orders;
+
 -- name: GetOrder :one
 SELECT
   *
@@ -245,7 +283,8 @@ CREATE TEMPORARY TABLE temp_order_items (
   quantity INTEGER NOT NULL,
   order_id UUID NOT NULL,
   price NUMERIC NOT NULL,
-  product_variant_id UUID NOT NULL
+  product_variant_id UUID NOT NULL,
+  warehouse_id UUID
 ) ON COMMIT DROP;
 
 -- name: InsertTempTableOrderItems :copyfrom
@@ -254,13 +293,15 @@ INSERT INTO temp_order_items (
   quantity,
   order_id,
   price,
-  product_variant_id
+  product_variant_id,
+  warehouse_id
 ) VALUES (
   @id,
   @quantity,
   @order_id,
   @price,
-  @product_variant_id
+  @product_variant_id,
+  @warehouse_id
 );
 
 -- name: MergeOrderItemsFromTemp :exec
@@ -272,21 +313,24 @@ WHEN MATCHED THEN
     quantity = source.quantity,
     order_id = source.order_id,
     price = source.price,
-    product_variant_id = source.product_variant_id
+    product_variant_id = source.product_variant_id,
+    warehouse_id = source.warehouse_id
 WHEN NOT MATCHED THEN
   INSERT (
     id,
     quantity,
     order_id,
     price,
-    product_variant_id
+    product_variant_id,
+    warehouse_id
   )
   VALUES (
     source.id,
     source.quantity,
     source.order_id,
     source.price,
-    source.product_variant_id
+    source.product_variant_id,
+    source.warehouse_id
   )
 WHEN NOT MATCHED BY SOURCE
   AND target.order_id = ANY (SELECT DISTINCT order_id FROM temp_order_items) THEN
@@ -0,0 +1,62 @@
+-- name: InsertOutboxEvents :copyfrom
+INSERT INTO outbox (
+  id,
+  aggregate_type,
+  aggregate_id,
+  type,
+  payload,
+  occurred_at
+) VALUES (
+  @id,
+  @aggregate_type,
+  @aggregate_id,
+  @type,
+  @payload,
+  @occurred_at
+);
+
+-- ClaimOutboxEvents leases the oldest due events by pushing their next attempt
+-- after the lease, concurrent relays skip the locked rows instead of waiting
+-- name: ClaimOutboxEvents :many
+UPDATE outbox
+SET
+  next_attempt_at = sqlc.arg('lease_until')::timestamptz
+WHERE
+  id IN (
+    SELECT
+      id
+    FROM
+      outbox
+    WHERE
+      published_at IS NULL
+      AND next_attempt_at <= sqlc.arg('now')::timestamptz
+    ORDER BY
+      next_attempt_at,
+      occurred_at,
+      id
+    LIMIT sqlc.arg('limit')::integer
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING
+  *;
+
+-- name: MarkOutboxEventsPublished :exec
+UPDATE outbox
+SET
+  published_at = sqlc.arg('published_at')::timestamptz
+WHERE
+  id = ANY (sqlc.arg('ids')::uuid[]);
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox
+SET
+  attempts = attempts + 1,
+  next_attempt_at = sqlc.arg('next_attempt_at')::timestamptz,
+  last_error = sqlc.arg('last_error')::text
+WHERE
+  id = sqlc.arg('id');
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox
+WHERE
+  published_at < sqlc.arg('before')::timestamptz;
@@ -11,7 +11,12 @@ INSERT INTO products (
   category_id,
   created_at,
   updated_at,
-  deleted_at
+  deleted_at,
+  status,
+  publish_at,
+  unpublish_at,
+  discount,
+  type
 )
 VALUES (
   sqlc.arg('id'),
@@ -25,7 +30,12 @@ VALUES (
   sqlc.arg('category_id'),
   sqlc.arg('created_at'),
   sqlc.arg('updated_at'),
-  NULLIF(sqlc.arg('deleted_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+  NULLIF(sqlc.arg('deleted_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz),
+  sqlc.arg('status'),
+  NULLIF(sqlc.arg('publish_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz),
+  NULLIF(sqlc.arg('unpublish_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz),
+  sqlc.arg('discount'),
+  sqlc.arg('type')
 )
 ON CONFLICT (id) DO UPDATE SET
   name = EXCLUDED.name,
@@ -38,9 +48,16 @@ ON CONFLICT (id) DO UPDATE SET
   category_id = EXCLUDED.category_id,
   created_at = EXCLUDED.created_at,
   updated_at = EXCLUDED.updated_at,
-  deleted_at = COALESCE(EXCLUDED.deleted_at, products.deleted_at);
+  deleted_at = EXCLUDED.deleted_at,
+  status = EXCLUDED.status,
+  publish_at = EXCLUDED.publish_at,
+  unpublish_at = EXCLUDED.unpublish_at,
+  discount = EXCLUDED.discount,
+  type = EXCLUDED.type;
 
--- This is used for list, search (with filter, order), suggest
+-- This is used for list, search (with filter, order)
+-- cursor_* is the last row of the previous page for keyset pagination,
+-- products.id breaks ties so the order is total
 -- name: ListProducts :many
 SELECT
   products.*
@@ -62,8 +79,12 @@ WHERE
   AND CASE
     WHEN sqlc.arg('search')::text = '' THEN TRUE
     ELSE (
-      products.name ||| sqlc.arg('search')::text
-      OR categories.name ||| sqlc.arg('search')::text
+      products.name ||| sqlc.arg('search')::text::pdb.boost(3)
+      OR products.description ||| sqlc.arg('search')::text
+      OR products.search_skus ||| sqlc.arg('search')::text::pdb.boost(4)
+      OR products.search_option_values ||| sqlc.arg('search')::text
+      OR products.search_attribute_values ||| sqlc.arg('search')::text::pdb.boost(2)
+      OR categories.name ||| sqlc.arg('search')::text::pdb.boost(2)
     )
   END
   AND CASE
@@ -99,10 +120,73 @@ WHERE
     WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
     ELSE products.deleted_at IS NULL
   END
+  AND CASE
+    WHEN sqlc.arg('statuses')::text[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('statuses')::text[]) = 0 THEN TRUE
+    ELSE products.status = ANY (sqlc.arg('statuses')::text[])
+  END
+  AND CASE
+    WHEN sqlc.arg('on_sale')::boolean THEN EXISTS (
+      SELECT 1
+      FROM product_variants
+      WHERE product_variants.product_id = products.id
+        AND product_variants.deleted_at IS NULL
+        AND product_variants.sale_price > 0
+        AND product_variants.sale_price < GREATEST(product_variants.price, product_variants.compare_at_price)
+        AND (product_variants.sale_starts_at IS NULL OR product_variants.sale_starts_at <= NOW())
+        AND (product_variants.sale_ends_at IS NULL OR product_variants.sale_ends_at > NOW())
+    )
+    ELSE TRUE
+  END
+  AND CASE
+    WHEN sqlc.arg('cursor_id')::uuid IS NULL THEN TRUE
+    WHEN sqlc.arg('cursor_id')::uuid = '00000000-0000-0000-0000-000000000000'::uuid THEN TRUE
+    WHEN sqlc.arg('sort_trending')::boolean THEN (
+      products.trending_score < sqlc.arg('cursor_trending_score')::real
+      OR (products.trending_score = sqlc.arg('cursor_trending_score')::real AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    WHEN sqlc.arg('sort_rating')::text = 'asc' THEN (
+      products.rating > sqlc.arg('cursor_rating')::real
+      OR (products.rating = sqlc.arg('cursor_rating')::real AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    WHEN sqlc.arg('sort_rating')::text = 'desc' THEN (
+      products.rating < sqlc.arg('cursor_rating')::real
+      OR (products.rating = sqlc.arg('cursor_rating')::real AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    WHEN sqlc.arg('sort_price')::text = 'asc' THEN (
+      products.price > sqlc.arg('cursor_price')::decimal
+      OR (products.price = sqlc.arg('cursor_price')::decimal AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    WHEN sqlc.arg('sort_price')::text = 'desc' THEN (
+      products.price < sqlc.arg('cursor_price')::decimal
+      OR (products.price = sqlc.arg('cursor_price')::decimal AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    WHEN sqlc.arg('sort_discount')::text = 'asc' THEN (
+      products.discount > sqlc.arg('cursor_discount')::integer
+      OR (products.discount = sqlc.arg('cursor_discount')::integer AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    WHEN sqlc.arg('sort_discount')::text = 'desc' THEN (
+      products.discount < sqlc.arg('cursor_discount')::integer
+      OR (products.discount = sqlc.arg('cursor_discount')::integer AND products.id < sqlc.arg('cursor_id')::uuid)
+    )
+    ELSE products.id < sqlc.arg('cursor_id')::uuid
+  END
 ORDER BY
+  CASE WHEN
+    sqlc.arg('search')::text <> '' THEN EXISTS (
+      SELECT 1
+      FROM product_variants
+      WHERE product_variants.product_id = products.id
+        AND product_variants.deleted_at IS NULL
+        AND lower(product_variants.sku) = lower(sqlc.arg('search')::text)
+    )
+  END DESC,
   CASE WHEN
     sqlc.arg('search')::text <> '' THEN pdb.score(products.id) + pdb.score(categories.id) + products.trending_score
   END DESC,
+  CASE WHEN
+    sqlc.arg('sort_trending')::boolean THEN products.trending_score
+  END DESC,
   CASE WHEN
     sqlc.arg('sort_rating')::text = 'asc' THEN products.rating
   END ASC,
@@ -114,7 +198,14 @@ ORDER BY
   END ASC,
   CASE WHEN
     sqlc.arg('sort_price')::text = 'desc' THEN products.price
-  END DESC
+  END DESC,
+  CASE WHEN
+    sqlc.arg('sort_discount')::text = 'asc' THEN products.discount
+  END ASC,
+  CASE WHEN
+    sqlc.arg('sort_discount')::text = 'desc' THEN products.discount
+  END DESC,
+  products.id DESC
 OFFSET sqlc.arg('offset')::integer
 LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
 
@@ -139,8 +230,12 @@ WHERE
   AND CASE
     WHEN sqlc.arg('search')::text = '' THEN TRUE
     ELSE (
-      products.name ||| sqlc.arg('search')::text
-      OR categories.name ||| sqlc.arg('search')::text
+      products.name ||| sqlc.arg('search')::text::pdb.boost(3)
+      OR products.description ||| sqlc.arg('search')::text
+      OR products.search_skus ||| sqlc.arg('search')::text::pdb.boost(4)
+      OR products.search_option_values ||| sqlc.arg('search')::text
+      OR products.search_attribute_values ||| sqlc.arg('search')::text::pdb.boost(2)
+      OR categories.name ||| sqlc.arg('search')::text::pdb.boost(2)
     )
   END
   AND CASE
@@ -175,8 +270,500 @@ WHERE
     WHEN sqlc.arg('deleted')::text = 'only' THEN products.deleted_at IS NOT NULL
     WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
     ELSE products.deleted_at IS NULL
+  END
+  AND CASE
+    WHEN sqlc.arg('statuses')::text[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('statuses')::text[]) = 0 THEN TRUE
+    ELSE products.status = ANY (sqlc.arg('statuses')::text[])
+  END
+  AND CASE
+    WHEN sqlc.arg('on_sale')::boolean THEN EXISTS (
+      SELECT 1
+      FROM product_variants
+      WHERE product_variants.product_id = products.id
+        AND product_variants.deleted_at IS NULL
+        AND product_variants.sale_price > 0
+        AND product_variants.sale_price < GREATEST(product_variants.price, product_variants.compare_at_price)
+        AND (product_variants.sale_starts_at IS NULL OR product_variants.sale_starts_at <= NOW())
+        AND (product_variants.sale_ends_at IS NULL OR product_variants.sale_ends_at > NOW())
+    )
+    ELSE TRUE
   END;
 
+-- name: ListProductSuggestions :many
+(
+  SELECT
+    'product'::text AS type,
+    products.id,
+    products.name AS text,
+    pdb.score(products.id) AS score
+  FROM
+    products
+  WHERE
+    products.name ||| sqlc.arg('search')::text::pdb.fuzzy(1, t)
+    AND products.deleted_at IS NULL
+    AND products.status = 'published'
+  ORDER BY
+    score DESC,
+    products.trending_score DESC
+  LIMIT sqlc.arg('limit')::integer
+)
+UNION ALL
+(
+  SELECT
+    'category'::text AS type,
+    categories.id,
+    categories.name AS text,
+    pdb.score(categories.id) AS score
+  FROM
+    categories
+  WHERE
+    categories.name ||| sqlc.arg('search')::text::pdb.fuzzy(1, t)
+    AND categories.deleted_at IS NULL
+  ORDER BY
+    score DESC
+  LIMIT sqlc.arg('limit')::integer
+)
+UNION ALL
+(
+  SELECT
+    'attribute_value'::text AS type,
+    attribute_values.id,
+    attribute_values.value AS text,
+    pdb.score(attribute_values.id) AS score
+  FROM
+    attribute_values
+  INNER JOIN attributes
+    ON attribute_values.attribute_id = attributes.id
+  WHERE
+    attribute_values.value ||| sqlc.arg('search')::text::pdb.fuzzy(1, t)
+    AND attribute_values.deleted_at IS NULL
+    AND attributes.deleted_at IS NULL
+  ORDER BY
+    score DESC
+  LIMIT sqlc.arg('limit')::integer
+);
+
+-- name: IncreaseProductViewsCounts :exec
+WITH views AS (
+  SELECT
+    unnest(sqlc.arg('product_ids')::uuid[]) AS product_id,
+    unnest(sqlc.arg('views')::integer[]) AS views
+), updated_products AS (
+  UPDATE products
+  SET views_count = products.views_count + views.views
+  FROM views
+  WHERE products.id = views.product_id
+  RETURNING products.id, views.views
+)
+INSERT INTO product_daily_stats (
+  product_id,
+  day,
+  views
+)
+SELECT
+  updated_products.id,
+  CURRENT_DATE,
+  updated_products.views
+FROM
+  updated_products
+ON CONFLICT (product_id, day) DO UPDATE SET
+  views = product_daily_stats.views + EXCLUDED.views;
+
+-- Each day in the window weighs 0.5 ^ (age / half_life), so a view today counts
+-- twice as much as a view half_life days ago
+-- name: UpdateProductTrendingScores :exec
+WITH scores AS (
+  SELECT
+    products.id,
+    COALESCE(SUM(
+      (
+        product_daily_stats.views * sqlc.arg('view_weight')::real
+        + product_daily_stats.purchases * sqlc.arg('purchase_weight')::real
+      ) * power(0.5::real, (CURRENT_DATE - product_daily_stats.day) / sqlc.arg('half_life_days')::real)
+    ), 0)::real AS trending_score
+  FROM
+    products
+  LEFT JOIN product_daily_stats
+    ON products.id = product_daily_stats.product_id
+    AND product_daily_stats.day > CURRENT_DATE - sqlc.arg('window_days')::integer
+  WHERE
+    products.deleted_at IS NULL
+  GROUP BY
+    products.id
+)
+UPDATE products
+SET trending_score = scores.trending_score
+FROM scores
+WHERE products.id = scores.id
+  AND products.trending_score IS DISTINCT FROM scores.trending_score;
+
+-- Drafts due are published and published products due are archived, the
+-- schedule which fired is cleared. The products changed are returned
+-- name: ApplyProductPublishSchedules :many
+UPDATE products
+SET
+  status = CASE
+    WHEN products.status = 'draft' THEN 'published'
+    ELSE 'archived'
+  END,
+  publish_at = CASE
+    WHEN products.status = 'draft' THEN NULL
+    ELSE products.publish_at
+  END,
+  unpublish_at = CASE
+    WHEN products.status = 'published' THEN NULL
+    ELSE products.unpublish_at
+  END,
+  updated_at = sqlc.arg('now')::timestamptz
+WHERE
+  products.deleted_at IS NULL
+  AND (
+    (products.status = 'draft' AND products.publish_at <= sqlc.arg('now')::timestamptz)
+    OR (products.status = 'published' AND products.unpublish_at <= sqlc.arg('now')::timestamptz)
+  )
+RETURNING
+  products.id,
+  products.status;
+
+-- SyncProductPrices refreshes the min price and the discount of products whose
+-- sale started or ended since their variants were last written, the products
+-- changed are returned
+-- name: SyncProductPrices :many
+WITH variant_prices AS (
+  SELECT
+    product_variants.product_id,
+    CASE
+      WHEN product_variants.sale_price > 0
+        AND (product_variants.sale_starts_at IS NULL OR product_variants.sale_starts_at <= sqlc.arg('now')::timestamptz)
+        AND (product_variants.sale_ends_at IS NULL OR product_variants.sale_ends_at > sqlc.arg('now')::timestamptz)
+        THEN product_variants.sale_price
+      ELSE product_variants.price
+    END AS effective_price,
+    CASE
+      WHEN product_variants.compare_at_price > 0 THEN product_variants.compare_at_price
+      ELSE product_variants.price
+    END AS reference_price
+  FROM
+    product_variants
+  WHERE
+    product_variants.deleted_at IS NULL
+),
+product_prices AS (
+  SELECT
+    variant_prices.product_id,
+    MIN(variant_prices.effective_price) AS price,
+    MAX(
+      CASE
+        WHEN variant_prices.reference_price > variant_prices.effective_price
+          THEN FLOOR((variant_prices.reference_price - variant_prices.effective_price) * 100 / variant_prices.reference_price)
+        ELSE 0
+      END
+    )::integer AS discount
+  FROM
+    variant_prices
+  GROUP BY
+    variant_prices.product_id
+)
+UPDATE products
+SET
+  price = product_prices.price,
+  discount = product_prices.discount
+FROM
+  product_prices
+WHERE
+  products.id = product_prices.product_id
+  AND (products.price <> product_prices.price OR products.discount <> product_prices.discount)
+RETURNING
+  products.id,
+  products.status;
+
+-- name: ListProductRecommendations :many
+SELECT
+  product_recommendations.recommended_product_id
+FROM
+  product_recommendations
+INNER JOIN products
+  ON product_recommendations.recommended_product_id = products.id
+WHERE
+  product_recommendations.product_id = sqlc.arg('product_id')
+  AND product_recommendations.kind = sqlc.arg('kind')::text
+  AND products.deleted_at IS NULL
+  AND products.status = 'published'
+ORDER BY
+  product_recommendations.score DESC,
+  product_recommendations.recommended_product_id DESC
+LIMIT sqlc.arg('limit')::integer;
+
+-- name: DeleteProductRecommendations :exec
+DELETE FROM product_recommendations
+WHERE kind = sqlc.arg('kind')::text;
+
+-- name: UpsertStockSubscription :exec
+INSERT INTO stock_subscriptions (
+  product_variant_id,
+  user_id
+) VALUES (
+  sqlc.arg('product_variant_id'),
+  sqlc.arg('user_id')
+)
+ON CONFLICT (product_variant_id, user_id) DO UPDATE SET
+  created_at = NOW(),
+  notified_at = NULL;
+
+-- name: DeleteStockSubscription :exec
+DELETE FROM stock_subscriptions
+WHERE
+  product_variant_id = sqlc.arg('product_variant_id')
+  AND user_id = sqlc.arg('user_id');
+
+-- name: CountProductOrderItems :one
+SELECT
+  COUNT(*)
+FROM
+  order_items
+INNER JOIN product_variants
+  ON order_items.product_variant_id = product_variants.id
+WHERE
+  product_variants.product_id = sqlc.arg('product_id');
+
+-- The stock_movements ledger is append-only, its trigger only lets the
+-- movements of a purged product be deleted once this is set. The setting is
+-- local to the transaction, so it must run in the one of PurgeProduct
+-- name: EnableProductPurge :exec
+SELECT set_config('ele.purging_product', 'on', TRUE);
+
+-- Every row referencing the product is deleted in the same statement, the
+-- foreign keys are only checked once it ends. Nothing is deleted unless the
+-- product is removed, none of its variants were ordered and none of them is a
+-- component of another bundle
+-- name: PurgeProduct :execrows
+WITH purged_products AS (
+  SELECT
+    products.id
+  FROM
+    products
+  WHERE
+    products.id = sqlc.arg('product_id')
+    AND products.deleted_at IS NOT NULL
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        order_items
+      INNER JOIN product_variants
+        ON order_items.product_variant_id = product_variants.id
+      WHERE
+        product_variants.product_id = products.id
+    )
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        product_variant_components
+      INNER JOIN product_variants AS component_variants
+        ON product_variant_components.component_variant_id = component_variants.id
+      INNER JOIN product_variants AS bundle_variants
+        ON product_variant_components.bundle_variant_id = bundle_variants.id
+      WHERE
+        component_variants.product_id = products.id
+        AND bundle_variants.product_id <> products.id
+    )
+), purged_variants AS (
+  SELECT
+    product_variants.id
+  FROM
+    product_variants
+  WHERE
+    product_variants.product_id IN (SELECT id FROM purged_products)
+), purged_options AS (
+  SELECT
+    options.id
+  FROM
+    options
+  WHERE
+    options.product_id IN (SELECT id FROM purged_products)
+), deleted_stock_subscriptions AS (
+  DELETE FROM stock_subscriptions
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_stock_movements AS (
+  DELETE FROM stock_movements
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_warehouse_stocks AS (
+  DELETE FROM warehouse_stocks
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_cart_items AS (
+  DELETE FROM cart_items
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_notifications AS (
+  DELETE FROM notifications
+  WHERE
+    product_id IN (SELECT id FROM purged_products)
+    OR product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_product_variant_components AS (
+  DELETE FROM product_variant_components
+  WHERE bundle_variant_id IN (SELECT id FROM purged_variants)
+), deleted_option_values_product_variants AS (
+  DELETE FROM option_values_product_variants
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_option_values AS (
+  DELETE FROM option_values
+  WHERE option_id IN (SELECT id FROM purged_options)
+), deleted_options AS (
+  DELETE FROM options
+  WHERE id IN (SELECT id FROM purged_options)
+), deleted_product_images AS (
+  DELETE FROM product_images
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_products_attribute_values AS (
+  DELETE FROM products_attribute_values
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_product_specs AS (
+  DELETE FROM product_specs
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_product_daily_stats AS (
+  DELETE FROM product_daily_stats
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_product_recommendations AS (
+  DELETE FROM product_recommendations
+  WHERE
+    product_id IN (SELECT id FROM purged_products)
+    OR recommended_product_id IN (SELECT id FROM purged_products)
+), deleted_product_variants AS (
+  DELETE FROM product_variants
+  WHERE id IN (SELECT id FROM purged_variants)
+)
+DELETE FROM products
+WHERE id IN (SELECT id FROM purged_products);
+
+-- Related products share the category and attribute values, each pair is
+-- scored by weight and only the top limit per product are kept
+-- name: InsertRelatedProductRecommendations :exec
+WITH active_products AS (
+  SELECT
+    products.id,
+    products.category_id
+  FROM
+    products
+  WHERE
+    products.deleted_at IS NULL
+), shared_attribute_values AS (
+  SELECT
+    source.product_id,
+    target.product_id AS recommended_product_id,
+    COUNT(*) * sqlc.arg('attribute_value_weight')::real AS score
+  FROM
+    products_attribute_values AS source
+  INNER JOIN products_attribute_values AS target
+    ON source.attribute_value_id = target.attribute_value_id
+    AND source.product_id <> target.product_id
+  GROUP BY
+    source.product_id,
+    target.product_id
+), same_category AS (
+  SELECT
+    source.id AS product_id,
+    target.id AS recommended_product_id,
+    sqlc.arg('category_weight')::real AS score
+  FROM
+    active_products AS source
+  INNER JOIN active_products AS target
+    ON source.category_id = target.category_id
+    AND source.id <> target.id
+), ranked AS (
+  SELECT
+    candidates.product_id,
+    candidates.recommended_product_id,
+    SUM(candidates.score)::real AS score,
+    row_number() OVER (
+      PARTITION BY candidates.product_id
+      ORDER BY SUM(candidates.score) DESC, candidates.recommended_product_id DESC
+    ) AS rank
+  FROM (
+    SELECT * FROM shared_attribute_values
+    UNION ALL
+    SELECT * FROM same_category
+  ) AS candidates
+  INNER JOIN active_products AS source
+    ON candidates.product_id = source.id
+  INNER JOIN active_products AS target
+    ON candidates.recommended_product_id = target.id
+  GROUP BY
+    candidates.product_id,
+    candidates.recommended_product_id
+)
+INSERT INTO product_recommendations (
+  product_id,
+  recommended_product_id,
+  kind,
+  score
+)
+SELECT
+  ranked.product_id,
+  ranked.recommended_product_id,
+  'related',
+  ranked.score
+FROM
+  ranked
+WHERE
+  ranked.rank <= sqlc.arg('limit')::integer;
+
+-- Bought together products appear in the same non cancelled orders at least
+-- min_orders times, scored by the number of such orders
+-- name: InsertBoughtTogetherProductRecommendations :exec
+WITH order_products AS (
+  SELECT DISTINCT
+    order_items.order_id,
+    product_variants.product_id
+  FROM
+    order_items
+  INNER JOIN product_variants
+    ON order_items.product_variant_id = product_variants.id
+  INNER JOIN products
+    ON product_variants.product_id = products.id
+  INNER JOIN orders
+    ON order_items.order_id = orders.id
+  INNER JOIN order_statuses
+    ON orders.status_id = order_statuses.id
+  WHERE
+    order_statuses.name <> 'Cancelled'
+    AND products.deleted_at IS NULL
+), ranked AS (
+  SELECT
+    source.product_id,
+    target.product_id AS recommended_product_id,
+    COUNT(*)::real AS score,
+    row_number() OVER (
+      PARTITION BY source.product_id
+      ORDER BY COUNT(*) DESC, target.product_id DESC
+    ) AS rank
+  FROM
+    order_products AS source
+  INNER JOIN order_products AS target
+    ON source.order_id = target.order_id
+    AND source.product_id <> target.product_id
+  GROUP BY
+    source.product_id,
+    target.product_id
+  HAVING
+    COUNT(*) >= sqlc.arg('min_orders')::integer
+)
+INSERT INTO product_recommendations (
+  product_id,
+  recommended_product_id,
+  kind,
+  score
+)
+SELECT
+  ranked.product_id,
+  ranked.recommended_product_id,
+  'bought_together',
+  ranked.score
+FROM
+  ranked
+WHERE
+  ranked.rank <= sqlc.arg('limit')::integer;
+
 -- name: GetProduct :one
 SELECT
   *
@@ -244,6 +831,27 @@ WHERE
     ELSE deleted_at IS NULL
   END;
 
+-- Components come with their own quantity so the stock of a bundle can be
+-- derived, a removed component holds nothing
+-- name: ListProductVariantComponents :many
+SELECT
+  product_variant_components.bundle_variant_id,
+  product_variant_components.component_variant_id,
+  product_variant_components.quantity,
+  CASE
+    WHEN product_variants.deleted_at IS NULL THEN product_variants.quantity
+    ELSE 0
+  END::integer AS component_quantity
+FROM
+  product_variant_components
+INNER JOIN product_variants
+  ON product_variant_components.component_variant_id = product_variants.id
+WHERE
+  product_variant_components.bundle_variant_id = ANY (sqlc.arg('bundle_variant_ids')::uuid[])
+ORDER BY
+  product_variant_components.bundle_variant_id,
+  product_variant_components.component_variant_id;
+
 -- name: ListProductsAttributeValues :many
 SELECT
   *
@@ -268,6 +876,17 @@ ORDER BY
   product_id ASC,
   attribute_value_id ASC;
 
+-- name: ListProductSpecs :many
+SELECT
+  *
+FROM
+  product_specs
+WHERE
+  product_id = ANY (sqlc.arg('product_ids')::uuid[])
+ORDER BY
+  product_id ASC,
+  spec_id ASC;
+
 -- name: ListProductImages :many
 SELECT
   *
@@ -296,6 +915,22 @@ WHERE
 ORDER BY
   id ASC;
 
+-- ListProductImageIDsInUse keeps the images a product shows or gets back when
+-- it is restored, those removed along with the product
+-- name: ListProductImageIDsInUse :many
+SELECT
+  product_images.id
+FROM
+  product_images
+INNER JOIN
+  products ON product_images.product_id = products.id
+WHERE
+  product_images.id = ANY (sqlc.arg('ids')::uuid[])
+  AND (
+    product_images.deleted_at IS NULL
+    OR product_images.deleted_at >= products.deleted_at
+  );
+
 -- name: GetProductImage :one
 SELECT
   *
@@ -304,6 +939,17 @@ FROM
 WHERE
   id = sqlc.arg('id');
 
+-- name: ListProductImageRenditions :many
+SELECT
+  *
+FROM
+  product_image_renditions
+WHERE
+  product_image_id = ANY (sqlc.arg('product_image_ids')::uuid[])
+ORDER BY
+  product_image_id ASC,
+  width ASC;
+
 -- name: CreateTempTableProductVariants :exec
 CREATE TEMPORARY TABLE temp_product_variants (
   id UUID PRIMARY KEY,
@@ -311,10 +957,15 @@ CREATE TEMPORARY TABLE temp_product_variants (
   price DECIMAL(12, 0) NOT NULL,
   quantity INTEGER NOT NULL,
   purchase_count INTEGER NOT NULL,
+  low_stock_threshold INTEGER NOT NULL,
   product_id UUID NOT NULL,
   created_at TIMESTAMPTZ NOT NULL,
   updated_at TIMESTAMPTZ NOT NULL,
-  deleted_at TIMESTAMPTZ
+  deleted_at TIMESTAMPTZ,
+  compare_at_price DECIMAL(12, 0) NOT NULL,
+  sale_price DECIMAL(12, 0) NOT NULL,
+  sale_starts_at TIMESTAMPTZ,
+  sale_ends_at TIMESTAMPTZ
 ) ON COMMIT DROP;
 
 -- name: InsertTempTableProductVariants :copyfrom
@@ -324,20 +975,30 @@ INSERT INTO temp_product_variants (
   price,
   quantity,
   purchase_count,
+  low_stock_threshold,
   product_id,
   created_at,
   updated_at,
-  deleted_at
+  deleted_at,
+  compare_at_price,
+  sale_price,
+  sale_starts_at,
+  sale_ends_at
 ) VALUES (
   @id,
   @sku,
   @price,
   @quantity,
   @purchase_count,
+  @low_stock_threshold,
   @product_id,
   @created_at,
   @updated_at,
-  @deleted_at
+  @deleted_at,
+  @compare_at_price,
+  @sale_price,
+  @sale_starts_at,
+  @sale_ends_at
 );
 
 -- name: MergeProductVariantsFromTemp :exec
@@ -350,10 +1011,15 @@ WHEN MATCHED THEN
     price = source.price,
     quantity = source.quantity,
     purchase_count = source.purchase_count,
+    low_stock_threshold = source.low_stock_threshold,
     product_id = source.product_id,
     created_at = source.created_at,
     updated_at = source.updated_at,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz),
+    compare_at_price = source.compare_at_price,
+    sale_price = source.sale_price,
+    sale_starts_at = source.sale_starts_at,
+    sale_ends_at = source.sale_ends_at
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -361,10 +1027,15 @@ WHEN NOT MATCHED THEN
     price,
     quantity,
     purchase_count,
+    low_stock_threshold,
     product_id,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    compare_at_price,
+    sale_price,
+    sale_starts_at,
+    sale_ends_at
   )
   VALUES (
     source.id,
@@ -372,10 +1043,15 @@ WHEN NOT MATCHED THEN
     source.price,
     source.quantity,
     source.purchase_count,
+    source.low_stock_threshold,
     source.product_id,
     source.created_at,
     source.updated_at,
-    NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
+    NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz),
+    source.compare_at_price,
+    source.sale_price,
+    source.sale_starts_at,
+    source.sale_ends_at
   )
 WHEN NOT MATCHED BY SOURCE
   AND target.product_id = ANY (SELECT DISTINCT id FROM temp_product_variants) THEN
@@ -422,7 +1098,7 @@ WHEN MATCHED THEN
     product_id = source.product_id,
     product_variant_id = source.product_variant_id,
     created_at = source.created_at,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -446,6 +1122,53 @@ WHEN NOT MATCHED BY SOURCE
   AND target.product_id = ANY (SELECT DISTINCT product_id FROM temp_product_images) THEN
   DELETE;
 
+-- name: CreateTempTableProductImageRenditions :exec
+CREATE TEMPORARY TABLE temp_product_image_renditions (
+  product_image_id UUID NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  PRIMARY KEY (product_image_id, width)
+) ON COMMIT DROP;
+
+-- name: InsertTempTableProductImageRenditions :copyfrom
+INSERT INTO temp_product_image_renditions (
+  product_image_id,
+  width,
+  height,
+  url
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4
+);
+
+-- Renditions are made once when an image is persisted, they go away with
+-- their image
+-- name: MergeProductImageRenditionsFromTemp :exec
+MERGE INTO product_image_renditions AS target
+USING temp_product_image_renditions AS source
+  ON target.product_image_id = source.product_image_id
+  AND target.width = source.width
+WHEN MATCHED THEN
+  UPDATE SET
+    height = source.height,
+    url = source.url
+WHEN NOT MATCHED THEN
+  INSERT (
+    product_image_id,
+    width,
+    height,
+    url
+  )
+  VALUES (
+    source.product_image_id,
+    source.width,
+    source.height,
+    source.url
+  );
+
 -- name: CreateTempTableProductsAttributeValues :exec
 CREATE TEMPORARY TABLE temp_products_attribute_values (
   product_id UUID NOT NULL,
@@ -480,6 +1203,61 @@ WHEN NOT MATCHED BY SOURCE
   AND target.product_id = ANY (SELECT DISTINCT product_id FROM temp_products_attribute_values) THEN
   DELETE;
 
+-- name: CreateTempTableProductSpecs :exec
+CREATE TEMPORARY TABLE temp_product_specs (
+  product_id UUID NOT NULL,
+  spec_id UUID NOT NULL,
+  number_value DOUBLE PRECISION,
+  boolean_value BOOLEAN,
+  enum_value TEXT,
+  PRIMARY KEY (product_id, spec_id)
+) ON COMMIT DROP;
+
+-- name: InsertTempTableProductSpecs :copyfrom
+INSERT INTO temp_product_specs (
+  product_id,
+  spec_id,
+  number_value,
+  boolean_value,
+  enum_value
+) VALUES (
+  @product_id,
+  @spec_id,
+  @number_value,
+  @boolean_value,
+  @enum_value
+);
+
+-- The product is passed on its own so clearing every spec value also works
+-- name: MergeProductSpecsFromTemp :exec
+MERGE INTO product_specs AS target
+USING temp_product_specs AS source
+  ON target.product_id = source.product_id
+    AND target.spec_id = source.spec_id
+WHEN MATCHED THEN
+  UPDATE SET
+    number_value = source.number_value,
+    boolean_value = source.boolean_value,
+    enum_value = source.enum_value
+WHEN NOT MATCHED THEN
+  INSERT (
+    product_id,
+    spec_id,
+    number_value,
+    boolean_value,
+    enum_value
+  )
+  VALUES (
+    source.product_id,
+    source.spec_id,
+    source.number_value,
+    source.boolean_value,
+    source.enum_value
+  )
+WHEN NOT MATCHED BY SOURCE
+  AND target.product_id = sqlc.arg('product_id') THEN
+  DELETE;
+
 -- name: CreateTempTableOptionValuesProductVariants :exec
 CREATE TEMPORARY TABLE temp_option_values_product_variants (
   product_variant_id UUID NOT NULL,
@@ -511,5 +1289,63 @@ WHEN NOT MATCHED THEN
     source.option_value_id
   )
 WHEN NOT MATCHED BY SOURCE
-  AND target.option_value_id = ANY (SELECT id FROM temp_option_values) THEN
+  AND target.option_value_id = ANY (SELECT id FROM temp_option_values)
+  AND target.product_variant_id = ANY (SELECT id FROM temp_product_variants) THEN
+  DELETE;
+
+-- name: CreateTempTableProductVariantComponents :exec
+CREATE TEMPORARY TABLE temp_product_variant_components (
+  bundle_variant_id UUID NOT NULL,
+  component_variant_id UUID NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (bundle_variant_id, component_variant_id)
+) ON COMMIT DROP;
+
+-- name: InsertTempTableProductVariantComponents :copyfrom
+INSERT INTO temp_product_variant_components (
+  bundle_variant_id,
+  component_variant_id,
+  quantity
+) VALUES (
+  @bundle_variant_id,
+  @component_variant_id,
+  @quantity
+);
+
+-- name: MergeProductVariantComponentsFromTemp :exec
+MERGE INTO product_variant_components AS target
+USING temp_product_variant_components AS source
+  ON target.bundle_variant_id = source.bundle_variant_id
+  AND target.component_variant_id = source.component_variant_id
+WHEN MATCHED THEN
+  UPDATE SET
+    quantity = source.quantity
+WHEN NOT MATCHED THEN
+  INSERT (
+    bundle_variant_id,
+    component_variant_id,
+    quantity
+  )
+  VALUES (
+    source.bundle_variant_id,
+    source.component_variant_id,
+    source.quantity
+  )
+WHEN NOT MATCHED BY SOURCE
+  AND target.bundle_variant_id = ANY (SELECT id FROM temp_product_variants) THEN
   DELETE;
+
+-- DropTempTablesProduct lets several products be saved in one transaction,
+-- the temporary tables are otherwise only dropped on commit
+-- name: DropTempTablesProduct :exec
+DROP TABLE IF EXISTS
+  temp_products_attribute_values,
+  temp_product_specs,
+  temp_options,
+  temp_option_values,
+  temp_product_variants,
+  temp_option_values_product_variants,
+  temp_warehouse_stocks,
+  temp_product_variant_components,
+  temp_product_images,
+  temp_product_image_renditions;
@@ -0,0 +1,202 @@
+-- name: UpsertSpecGroup :exec
+INSERT INTO spec_groups (
+  id,
+  name,
+  "order",
+  created_at,
+  updated_at,
+  deleted_at
+)
+VALUES (
+  sqlc.arg('id'),
+  sqlc.arg('name'),
+  sqlc.arg('order'),
+  sqlc.arg('created_at'),
+  sqlc.arg('updated_at'),
+  NULLIF(sqlc.arg('deleted_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+)
+ON CONFLICT (id) DO UPDATE SET
+  name = EXCLUDED.name,
+  "order" = EXCLUDED."order",
+  created_at = EXCLUDED.created_at,
+  updated_at = EXCLUDED.updated_at,
+  deleted_at = COALESCE(EXCLUDED.deleted_at, spec_groups.deleted_at);
+
+-- name: ListSpecGroups :many
+SELECT
+  spec_groups.*
+FROM
+  spec_groups
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE spec_groups.id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('spec_ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('spec_ids')::uuid[]) = 0 THEN TRUE
+    ELSE EXISTS (
+      SELECT 1
+      FROM specs
+      WHERE
+        specs.spec_group_id = spec_groups.id
+        AND specs.id = ANY (sqlc.arg('spec_ids')::uuid[])
+    )
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN spec_groups.deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN spec_groups.deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE spec_groups.deleted_at IS NULL
+  END
+ORDER BY
+  spec_groups."order",
+  spec_groups.id
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountSpecGroups :one
+SELECT
+  COUNT(*) AS count
+FROM
+  spec_groups
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE spec_groups.id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('spec_ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('spec_ids')::uuid[]) = 0 THEN TRUE
+    ELSE EXISTS (
+      SELECT 1
+      FROM specs
+      WHERE
+        specs.spec_group_id = spec_groups.id
+        AND specs.id = ANY (sqlc.arg('spec_ids')::uuid[])
+    )
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN spec_groups.deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN spec_groups.deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE spec_groups.deleted_at IS NULL
+  END;
+
+-- name: GetSpecGroup :one
+SELECT
+  *
+FROM
+  spec_groups
+WHERE
+  id = sqlc.arg('id')
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END;
+
+-- name: ListSpecs :many
+SELECT
+  *
+FROM
+  specs
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('spec_group_ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('spec_group_ids')::uuid[]) = 0 THEN TRUE
+    ELSE spec_group_id = ANY (sqlc.arg('spec_group_ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END
+ORDER BY
+  spec_group_id,
+  "order",
+  id;
+
+-- name: CreateTempTableSpecs :exec
+CREATE TEMPORARY TABLE temp_specs (
+  id UUID PRIMARY KEY,
+  code VARCHAR(100) NOT NULL,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL,
+  unit TEXT NOT NULL,
+  choices TEXT[] NOT NULL,
+  "order" INTEGER NOT NULL,
+  spec_group_id UUID NOT NULL,
+  deleted_at TIMESTAMPTZ
+) ON COMMIT DROP;
+
+-- name: InsertTempTableSpecs :copyfrom
+INSERT INTO temp_specs (
+  id,
+  code,
+  name,
+  type,
+  unit,
+  choices,
+  "order",
+  spec_group_id,
+  deleted_at
+) VALUES (
+  @id,
+  @code,
+  @name,
+  @type,
+  @unit,
+  @choices,
+  @order,
+  @spec_group_id,
+  @deleted_at
+);
+
+-- Specs are only ever soft deleted, products keep the values they had
+-- name: MergeSpecsFromTemp :exec
+MERGE INTO specs AS target
+USING temp_specs AS source
+  ON target.id = source.id
+WHEN MATCHED THEN
+  UPDATE SET
+    code = source.code,
+    name = source.name,
+    type = source.type,
+    unit = source.unit,
+    choices = source.choices,
+    "order" = source."order",
+    spec_group_id = source.spec_group_id,
+    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+WHEN NOT MATCHED THEN
+  INSERT (
+    id,
+    code,
+    name,
+    type,
+    unit,
+    choices,
+    "order",
+    spec_group_id,
+    deleted_at
+  )
+  VALUES (
+    source.id,
+    source.code,
+    source.name,
+    source.type,
+    source.unit,
+    source.choices,
+    source."order",
+    source.spec_group_id,
+    NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
+  );
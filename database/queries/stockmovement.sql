@@ -0,0 +1,178 @@
+-- name: InsertStockMovements :copyfrom
+INSERT INTO stock_movements (
+  id,
+  product_variant_id,
+  kind,
+  quantity,
+  quantity_after,
+  actor_id,
+  order_id,
+  note,
+  warehouse_id,
+  created_at
+) VALUES (
+  @id,
+  @product_variant_id,
+  @kind,
+  @quantity,
+  @quantity_after,
+  @actor_id,
+  @order_id,
+  @note,
+  @warehouse_id,
+  @created_at
+);
+
+-- name: ListStockMovements :many
+SELECT
+  *
+FROM
+  stock_movements
+WHERE
+  CASE
+    WHEN sqlc.arg('product_variant_id')::uuid = '00000000-0000-0000-0000-000000000000'::uuid THEN TRUE
+    ELSE product_variant_id = sqlc.arg('product_variant_id')::uuid
+  END
+  AND CASE
+    WHEN sqlc.arg('order_id')::uuid = '00000000-0000-0000-0000-000000000000'::uuid THEN TRUE
+    ELSE order_id = sqlc.arg('order_id')::uuid
+  END
+  AND CASE
+    WHEN sqlc.arg('kinds')::text[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('kinds')::text[]) = 0 THEN TRUE
+    ELSE kind = ANY (sqlc.arg('kinds')::text[])
+  END
+ORDER BY
+  created_at DESC,
+  id DESC
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountStockMovements :one
+SELECT
+  COUNT(*) AS count
+FROM
+  stock_movements
+WHERE
+  product_variant_id = sqlc.arg('product_variant_id')
+  AND CASE
+    WHEN sqlc.arg('kinds')::text[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('kinds')::text[]) = 0 THEN TRUE
+    ELSE kind = ANY (sqlc.arg('kinds')::text[])
+  END;
+
+-- Products are saved with the quantity they were loaded with, so concurrent
+-- writers can lose each other's changes. The ledger is append-only and is the
+-- source of truth, variants without any movement are left alone. The stock of
+-- each warehouse is reset in the same statement, only for variants whose every
+-- movement names its warehouse; the others hold stock not at any warehouse.
+-- The variants which drifted are counted
+-- name: ReconcileProductVariantQuantities :one
+WITH ledger AS (
+  SELECT
+    stock_movements.product_variant_id,
+    GREATEST(SUM(stock_movements.quantity), 0)::integer AS quantity
+  FROM
+    stock_movements
+  GROUP BY
+    stock_movements.product_variant_id
+), warehouse_ledger AS (
+  SELECT
+    stock_movements.product_variant_id,
+    stock_movements.warehouse_id,
+    GREATEST(SUM(stock_movements.quantity), 0)::integer AS quantity
+  FROM
+    stock_movements
+  WHERE
+    NOT EXISTS (
+      SELECT
+        1
+      FROM
+        stock_movements AS unassigned
+      WHERE
+        unassigned.product_variant_id = stock_movements.product_variant_id
+        AND unassigned.warehouse_id IS NULL
+    )
+  GROUP BY
+    stock_movements.product_variant_id,
+    stock_movements.warehouse_id
+), updated_variants AS (
+  UPDATE
+    product_variants
+  SET
+    quantity = ledger.quantity,
+    updated_at = NOW()
+  FROM
+    ledger
+  WHERE
+    product_variants.id = ledger.product_variant_id
+    AND product_variants.quantity <> ledger.quantity
+  RETURNING
+    product_variants.id
+), upserted_stocks AS (
+  INSERT INTO warehouse_stocks (
+    warehouse_id,
+    product_variant_id,
+    quantity
+  )
+  SELECT
+    warehouse_ledger.warehouse_id,
+    warehouse_ledger.product_variant_id,
+    warehouse_ledger.quantity
+  FROM
+    warehouse_ledger
+  WHERE
+    warehouse_ledger.quantity > 0
+  ON CONFLICT (warehouse_id, product_variant_id) DO UPDATE
+  SET
+    quantity = EXCLUDED.quantity,
+    updated_at = NOW()
+  WHERE
+    warehouse_stocks.quantity <> EXCLUDED.quantity
+  RETURNING
+    warehouse_stocks.product_variant_id
+), emptied_stocks AS (
+  UPDATE
+    warehouse_stocks
+  SET
+    quantity = 0,
+    updated_at = NOW()
+  WHERE
+    warehouse_stocks.quantity <> 0
+    AND warehouse_stocks.product_variant_id IN (
+      SELECT
+        warehouse_ledger.product_variant_id
+      FROM
+        warehouse_ledger
+    )
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        warehouse_ledger
+      WHERE
+        warehouse_ledger.product_variant_id = warehouse_stocks.product_variant_id
+        AND warehouse_ledger.warehouse_id = warehouse_stocks.warehouse_id
+        AND warehouse_ledger.quantity > 0
+    )
+  RETURNING
+    warehouse_stocks.product_variant_id
+)
+SELECT
+  COUNT(*) AS count
+FROM (
+  SELECT
+    updated_variants.id
+  FROM
+    updated_variants
+  UNION
+  SELECT
+    upserted_stocks.product_variant_id
+  FROM
+    upserted_stocks
+  UNION
+  SELECT
+    emptied_stocks.product_variant_id
+  FROM
+    emptied_stocks
+) AS drifted;
@@ -0,0 +1,118 @@
+-- A task with a key is queued at most once per kind, the key of a scheduled
+-- run names its fire time so the workers enqueue it only once
+-- name: InsertTasks :exec
+INSERT INTO tasks (
+  id,
+  kind,
+  key,
+  payload,
+  run_at,
+  created_at,
+  updated_at
+)
+SELECT
+  t.id,
+  t.kind,
+  NULLIF(t.key, ''),
+  t.payload,
+  t.run_at,
+  t.created_at,
+  t.updated_at
+FROM
+  unnest(
+    sqlc.arg('ids')::uuid[],
+    sqlc.arg('kinds')::text[],
+    sqlc.arg('keys')::text[],
+    sqlc.arg('payloads')::jsonb[],
+    sqlc.arg('run_ats')::timestamptz[],
+    sqlc.arg('created_ats')::timestamptz[],
+    sqlc.arg('updated_ats')::timestamptz[]
+  ) AS t (id, kind, key, payload, run_at, created_at, updated_at)
+ON CONFLICT (kind, key) WHERE key IS NOT NULL DO NOTHING;
+
+-- name: UpdateTask :exec
+UPDATE tasks
+SET
+  status = sqlc.arg('status'),
+  attempts = sqlc.arg('attempts'),
+  last_error = sqlc.arg('last_error'),
+  run_at = sqlc.arg('run_at'),
+  updated_at = sqlc.arg('updated_at'),
+  finished_at = NULLIF(sqlc.arg('finished_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+WHERE
+  id = sqlc.arg('id');
+
+-- name: GetTask :one
+SELECT
+  *
+FROM
+  tasks
+WHERE
+  id = sqlc.arg('id');
+
+-- name: ListTasks :many
+SELECT
+  *
+FROM
+  tasks
+WHERE
+  CASE
+    WHEN sqlc.arg('kinds')::text[] IS NULL THEN TRUE
+    ELSE kind = ANY (sqlc.arg('kinds')::text[])
+  END
+  AND CASE
+    WHEN sqlc.arg('status')::text = '' THEN TRUE
+    ELSE status = sqlc.arg('status')::text
+  END
+ORDER BY
+  created_at DESC,
+  id DESC
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountTasks :one
+SELECT
+  COUNT(*) AS count
+FROM
+  tasks
+WHERE
+  CASE
+    WHEN sqlc.arg('kinds')::text[] IS NULL THEN TRUE
+    ELSE kind = ANY (sqlc.arg('kinds')::text[])
+  END
+  AND CASE
+    WHEN sqlc.arg('status')::text = '' THEN TRUE
+    ELSE status = sqlc.arg('status')::text
+  END;
+
+-- ClaimTasks leases the oldest due pending tasks of the given kinds by pushing
+-- their run after the lease, a worker which dies mid task leaves it to the
+-- next claim once the lease is over
+-- name: ClaimTasks :many
+UPDATE tasks
+SET
+  run_at = sqlc.arg('lease_until')::timestamptz
+WHERE
+  id IN (
+    SELECT
+      id
+    FROM
+      tasks
+    WHERE
+      status = 'pending'
+      AND kind = ANY (sqlc.arg('kinds')::text[])
+      AND run_at <= sqlc.arg('now')::timestamptz
+    ORDER BY
+      run_at,
+      id
+    LIMIT sqlc.arg('limit')::integer
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING
+  *;
+
+-- name: DeleteSucceededTasks :execrows
+DELETE FROM tasks
+WHERE
+  status = 'succeeded'
+  AND finished_at < sqlc.arg('before')::timestamptz;
@@ -0,0 +1,146 @@
+-- name: UpsertWarehouse :exec
+INSERT INTO warehouses (
+  id,
+  name,
+  province,
+  priority,
+  created_at,
+  updated_at,
+  deleted_at
+)
+VALUES (
+  sqlc.arg('id'),
+  sqlc.arg('name'),
+  sqlc.arg('province'),
+  sqlc.arg('priority'),
+  sqlc.arg('created_at'),
+  sqlc.arg('updated_at'),
+  NULLIF(sqlc.arg('deleted_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+)
+ON CONFLICT (id) DO UPDATE SET
+  name = EXCLUDED.name,
+  province = EXCLUDED.province,
+  priority = EXCLUDED.priority,
+  created_at = EXCLUDED.created_at,
+  updated_at = EXCLUDED.updated_at,
+  deleted_at = COALESCE(EXCLUDED.deleted_at, warehouses.deleted_at);
+
+-- name: ListWarehouses :many
+SELECT
+  *
+FROM
+  warehouses
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END
+ORDER BY
+  priority,
+  id
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountWarehouses :one
+SELECT
+  COUNT(*) AS count
+FROM
+  warehouses
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END;
+
+-- name: GetWarehouse :one
+SELECT
+  *
+FROM
+  warehouses
+WHERE
+  id = sqlc.arg('id')
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END;
+
+-- name: CountWarehouseStocks :one
+SELECT
+  COUNT(*) AS count
+FROM
+  warehouse_stocks
+WHERE
+  warehouse_id = sqlc.arg('warehouse_id')
+  AND quantity > 0;
+
+-- name: ListWarehouseStocks :many
+SELECT
+  *
+FROM
+  warehouse_stocks
+WHERE
+  product_variant_id = ANY (sqlc.arg('product_variant_ids')::uuid[])
+ORDER BY
+  product_variant_id,
+  warehouse_id;
+
+-- name: CreateTempTableWarehouseStocks :exec
+CREATE TEMPORARY TABLE temp_warehouse_stocks (
+  warehouse_id UUID NOT NULL,
+  product_variant_id UUID NOT NULL,
+  quantity INTEGER NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (warehouse_id, product_variant_id)
+) ON COMMIT DROP;
+
+-- name: InsertTempTableWarehouseStocks :copyfrom
+INSERT INTO temp_warehouse_stocks (
+  warehouse_id,
+  product_variant_id,
+  quantity,
+  updated_at
+) VALUES (
+  @warehouse_id,
+  @product_variant_id,
+  @quantity,
+  @updated_at
+);
+
+-- name: MergeWarehouseStocksFromTemp :exec
+MERGE INTO warehouse_stocks AS target
+USING temp_warehouse_stocks AS source
+  ON target.warehouse_id = source.warehouse_id
+  AND target.product_variant_id = source.product_variant_id
+WHEN MATCHED THEN
+  UPDATE SET
+    quantity = source.quantity,
+    updated_at = source.updated_at
+WHEN NOT MATCHED THEN
+  INSERT (
+    warehouse_id,
+    product_variant_id,
+    quantity,
+    updated_at
+  )
+  VALUES (
+    source.warehouse_id,
+    source.product_variant_id,
+    source.quantity,
+    source.updated_at
+  );
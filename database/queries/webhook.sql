@@ -0,0 +1,191 @@
+-- name: UpsertWebhook :exec
+INSERT INTO webhooks (
+  id,
+  url,
+  secret,
+  event_types,
+  active,
+  created_at,
+  updated_at,
+  deleted_at
+)
+VALUES (
+  sqlc.arg('id'),
+  sqlc.arg('url'),
+  sqlc.arg('secret'),
+  sqlc.arg('event_types')::text[],
+  sqlc.arg('active'),
+  sqlc.arg('created_at'),
+  sqlc.arg('updated_at'),
+  NULLIF(sqlc.arg('deleted_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+)
+ON CONFLICT (id) DO UPDATE SET
+  url = EXCLUDED.url,
+  secret = EXCLUDED.secret,
+  event_types = EXCLUDED.event_types,
+  active = EXCLUDED.active,
+  created_at = EXCLUDED.created_at,
+  updated_at = EXCLUDED.updated_at,
+  deleted_at = COALESCE(EXCLUDED.deleted_at, webhooks.deleted_at);
+
+-- name: ListWebhooks :many
+SELECT
+  *
+FROM
+  webhooks
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('event_type')::text = '' THEN TRUE
+    ELSE active AND sqlc.arg('event_type')::text = ANY (event_types)
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END
+ORDER BY
+  created_at,
+  id
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountWebhooks :one
+SELECT
+  COUNT(*) AS count
+FROM
+  webhooks
+WHERE
+  CASE
+    WHEN sqlc.arg('ids')::uuid[] IS NULL THEN TRUE
+    WHEN cardinality(sqlc.arg('ids')::uuid[]) = 0 THEN TRUE
+    ELSE id = ANY (sqlc.arg('ids')::uuid[])
+  END
+  AND CASE
+    WHEN sqlc.arg('event_type')::text = '' THEN TRUE
+    ELSE active AND sqlc.arg('event_type')::text = ANY (event_types)
+  END
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END;
+
+-- name: GetWebhook :one
+SELECT
+  *
+FROM
+  webhooks
+WHERE
+  id = sqlc.arg('id')
+  AND CASE
+    WHEN sqlc.arg('deleted')::text = 'exclude' THEN deleted_at IS NULL
+    WHEN sqlc.arg('deleted')::text = 'only' THEN deleted_at IS NOT NULL
+    WHEN sqlc.arg('deleted')::text = 'all' THEN TRUE
+    ELSE deleted_at IS NULL
+  END;
+
+-- A webhook gets at most one delivery per event, the relay publishes events
+-- at least once
+-- name: InsertWebhookDeliveries :exec
+INSERT INTO webhook_deliveries (
+  id,
+  webhook_id,
+  event_id,
+  event_type,
+  payload,
+  next_attempt_at,
+  created_at,
+  updated_at
+)
+SELECT
+  unnest(sqlc.arg('ids')::uuid[]),
+  unnest(sqlc.arg('webhook_ids')::uuid[]),
+  unnest(sqlc.arg('event_ids')::uuid[]),
+  unnest(sqlc.arg('event_types')::text[]),
+  unnest(sqlc.arg('payloads')::text[])::jsonb,
+  unnest(sqlc.arg('next_attempt_ats')::timestamptz[]),
+  unnest(sqlc.arg('created_ats')::timestamptz[]),
+  unnest(sqlc.arg('updated_ats')::timestamptz[])
+ON CONFLICT (webhook_id, event_id) DO NOTHING;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET
+  status = sqlc.arg('status'),
+  attempts = sqlc.arg('attempts'),
+  response_code = sqlc.arg('response_code'),
+  response_body = sqlc.arg('response_body'),
+  last_error = sqlc.arg('last_error'),
+  next_attempt_at = sqlc.arg('next_attempt_at'),
+  updated_at = sqlc.arg('updated_at'),
+  delivered_at = NULLIF(sqlc.arg('delivered_at')::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+WHERE
+  id = sqlc.arg('id');
+
+-- name: ListWebhookDeliveries :many
+SELECT
+  *
+FROM
+  webhook_deliveries
+WHERE
+  webhook_id = sqlc.arg('webhook_id')
+  AND CASE
+    WHEN sqlc.arg('status')::text = '' THEN TRUE
+    ELSE status = sqlc.arg('status')::text
+  END
+ORDER BY
+  created_at DESC,
+  id DESC
+OFFSET sqlc.arg('offset')::integer
+LIMIT NULLIF(sqlc.arg('limit')::integer, 0);
+
+-- name: CountWebhookDeliveries :one
+SELECT
+  COUNT(*) AS count
+FROM
+  webhook_deliveries
+WHERE
+  webhook_id = sqlc.arg('webhook_id')
+  AND CASE
+    WHEN sqlc.arg('status')::text = '' THEN TRUE
+    ELSE status = sqlc.arg('status')::text
+  END;
+
+-- name: GetWebhookDelivery :one
+SELECT
+  *
+FROM
+  webhook_deliveries
+WHERE
+  id = sqlc.arg('id');
+
+-- ClaimWebhookDeliveries leases the oldest due pending deliveries by pushing
+-- their next attempt after the lease, like ClaimOutboxEvents
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET
+  next_attempt_at = sqlc.arg('lease_until')::timestamptz
+WHERE
+  id IN (
+    SELECT
+      id
+    FROM
+      webhook_deliveries
+    WHERE
+      status = 'pending'
+      AND next_attempt_at <= sqlc.arg('now')::timestamptz
+    ORDER BY
+      next_attempt_at,
+      id
+    LIMIT sqlc.arg('limit')::integer
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING
+  *;
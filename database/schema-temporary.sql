@@ -21,10 +21,15 @@ CREATE TABLE temp_product_variants (
   price DECIMAL(12, 0) NOT NULL,
   quantity INTEGER NOT NULL,
   purchase_count INTEGER NOT NULL,
+  low_stock_threshold INTEGER NOT NULL,
   product_id UUID NOT NULL,
   created_at TIMESTAMPTZ NOT NULL,
   updated_at TIMESTAMPTZ NOT NULL,
-  deleted_at TIMESTAMPTZ
+  deleted_at TIMESTAMPTZ,
+  compare_at_price DECIMAL(12, 0) NOT NULL,
+  sale_price DECIMAL(12, 0) NOT NULL,
+  sale_starts_at TIMESTAMPTZ,
+  sale_ends_at TIMESTAMPTZ
 );
 
 -- product_images_temp
@@ -38,6 +43,15 @@ CREATE TABLE temp_product_images (
   deleted_at TIMESTAMPTZ
 );
 
+-- product_image_renditions_temp
+CREATE TABLE temp_product_image_renditions (
+  product_image_id UUID NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  PRIMARY KEY (product_image_id, width)
+);
+
 -- products_attribute_values_temp
 CREATE TABLE temp_products_attribute_values (
   product_id UUID NOT NULL,
@@ -45,6 +59,29 @@ CREATE TABLE temp_products_attribute_values (
   PRIMARY KEY (product_id, attribute_value_id)
 );
 
+-- specs_temp
+CREATE TABLE temp_specs (
+  id UUID PRIMARY KEY,
+  code VARCHAR(100) NOT NULL,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL,
+  unit TEXT NOT NULL,
+  choices TEXT[] NOT NULL,
+  "order" INTEGER NOT NULL,
+  spec_group_id UUID NOT NULL,
+  deleted_at TIMESTAMPTZ
+);
+
+-- product_specs_temp
+CREATE TABLE temp_product_specs (
+  product_id UUID NOT NULL,
+  spec_id UUID NOT NULL,
+  number_value DOUBLE PRECISION,
+  boolean_value BOOLEAN,
+  enum_value TEXT,
+  PRIMARY KEY (product_id, spec_id)
+);
+
 -- name: CreateTempTableOptions :exec
 CREATE TABLE temp_options (
   id UUID PRIMARY KEY,
@@ -68,11 +105,29 @@ CREATE TABLE temp_option_values_product_variants (
   PRIMARY KEY (product_variant_id, option_value_id)
 );
 
+-- product_variant_components_temp
+CREATE TABLE temp_product_variant_components (
+  bundle_variant_id UUID NOT NULL,
+  component_variant_id UUID NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (bundle_variant_id, component_variant_id)
+);
+
 -- name: CreateTempTableOrderItems :exec
 CREATE TABLE temp_order_items (
   id UUID PRIMARY KEY,
   quantity INTEGER NOT NULL,
   order_id UUID NOT NULL,
   price NUMERIC NOT NULL,
-  product_variant_id UUID NOT NULL
+  product_variant_id UUID NOT NULL,
+  warehouse_id UUID
+);
+
+-- warehouse_stocks_temp
+CREATE TABLE temp_warehouse_stocks (
+  warehouse_id UUID NOT NULL,
+  product_variant_id UUID NOT NULL,
+  quantity INTEGER NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (warehouse_id, product_variant_id)
 );
@@ -17,12 +17,22 @@ CREATE TABLE products (
   total_purchase INTEGER NOT NULL DEFAULT 0,
   rating REAL NOT NULL DEFAULT 0,
   trending_score REAL NOT NULL DEFAULT 0,
+  search_skus TEXT NOT NULL DEFAULT '',
+  search_option_values TEXT NOT NULL DEFAULT '',
+  search_attribute_values TEXT NOT NULL DEFAULT '',
   category_id UUID NOT NULL REFERENCES categories (id) ON UPDATE CASCADE,
   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-  deleted_at TIMESTAMPTZ
+  deleted_at TIMESTAMPTZ,
+  status TEXT NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'archived')),
+  publish_at TIMESTAMPTZ,
+  unpublish_at TIMESTAMPTZ,
+  discount INTEGER NOT NULL DEFAULT 0,
+  type TEXT NOT NULL DEFAULT 'standard' CHECK (type IN ('standard', 'bundle'))
 );
 
+CREATE INDEX products_status_idx ON products (status);
+
 -- attributes
 CREATE TABLE attributes (
   id UUID PRIMARY KEY,
@@ -46,6 +56,43 @@ CREATE TABLE products_attribute_values (
   PRIMARY KEY (product_id, attribute_value_id)
 );
 
+-- spec_groups
+CREATE TABLE spec_groups (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  "order" INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  deleted_at TIMESTAMPTZ
+);
+
+-- specs
+CREATE TABLE specs (
+  id UUID PRIMARY KEY,
+  code VARCHAR(100) UNIQUE NOT NULL,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL CHECK (type IN ('number', 'boolean', 'enum')),
+  unit TEXT NOT NULL DEFAULT '',
+  choices TEXT[] NOT NULL DEFAULT '{}',
+  "order" INTEGER NOT NULL DEFAULT 0,
+  spec_group_id UUID NOT NULL REFERENCES spec_groups (id) ON UPDATE CASCADE ON DELETE CASCADE,
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX specs_spec_group_id_idx ON specs (spec_group_id);
+
+-- product_specs
+CREATE TABLE product_specs (
+  product_id UUID NOT NULL REFERENCES products (id) ON UPDATE CASCADE,
+  spec_id UUID NOT NULL REFERENCES specs (id) ON UPDATE CASCADE,
+  number_value DOUBLE PRECISION,
+  boolean_value BOOLEAN,
+  enum_value TEXT,
+  PRIMARY KEY (product_id, spec_id)
+);
+
+CREATE INDEX product_specs_spec_id_idx ON product_specs (spec_id);
+
 -- product_variants
 CREATE TABLE product_variants (
   id UUID PRIMARY KEY,
@@ -53,12 +100,27 @@ CREATE TABLE product_variants (
   price DECIMAL(12, 0) NOT NULL,
   quantity INTEGER NOT NULL,
   purchase_count INTEGER NOT NULL DEFAULT 0,
+  low_stock_threshold INTEGER NOT NULL DEFAULT 0,
   product_id UUID NOT NULL REFERENCES products (id) ON UPDATE CASCADE,
   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-  deleted_at TIMESTAMPTZ
+  deleted_at TIMESTAMPTZ,
+  compare_at_price DECIMAL(12, 0) NOT NULL DEFAULT 0,
+  sale_price DECIMAL(12, 0) NOT NULL DEFAULT 0,
+  sale_starts_at TIMESTAMPTZ,
+  sale_ends_at TIMESTAMPTZ
+);
+
+-- product_variant_components
+CREATE TABLE product_variant_components (
+  bundle_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE,
+  component_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE,
+  quantity INTEGER NOT NULL CHECK (quantity > 0),
+  PRIMARY KEY (bundle_variant_id, component_variant_id)
 );
 
+CREATE INDEX product_variant_components_component_variant_id_idx ON product_variant_components (component_variant_id);
+
 -- product_images
 CREATE TABLE product_images (
   id UUID PRIMARY KEY,
@@ -70,6 +132,15 @@ CREATE TABLE product_images (
   product_variant_id UUID REFERENCES product_variants (id) ON UPDATE CASCADE
 );
 
+-- product_image_renditions
+CREATE TABLE product_image_renditions (
+  product_image_id UUID NOT NULL REFERENCES product_images (id) ON UPDATE CASCADE ON DELETE CASCADE,
+  width INTEGER NOT NULL CHECK (width > 0),
+  height INTEGER NOT NULL CHECK (height > 0),
+  url TEXT NOT NULL,
+  PRIMARY KEY (product_image_id, width)
+);
+
 -- options
 CREATE TABLE options (
   id UUID PRIMARY KEY,
@@ -93,6 +164,46 @@ CREATE TABLE option_values_product_variants (
   PRIMARY KEY (product_variant_id, option_value_id)
 );
 
+-- warehouses
+CREATE TABLE warehouses (
+  id UUID PRIMARY KEY,
+  name TEXT UNIQUE NOT NULL,
+  province TEXT NOT NULL,
+  priority INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  deleted_at TIMESTAMPTZ
+);
+
+-- warehouse_stocks
+CREATE TABLE warehouse_stocks (
+  warehouse_id UUID NOT NULL REFERENCES warehouses (id) ON UPDATE CASCADE,
+  product_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE,
+  quantity INTEGER NOT NULL CHECK (quantity >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (warehouse_id, product_variant_id)
+);
+
+CREATE INDEX warehouse_stocks_product_variant_id_idx ON warehouse_stocks (product_variant_id);
+
+-- product_daily_stats
+CREATE TABLE product_daily_stats (
+  product_id UUID NOT NULL REFERENCES products (id) ON UPDATE CASCADE,
+  day DATE NOT NULL,
+  views INTEGER NOT NULL DEFAULT 0,
+  purchases INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (product_id, day)
+);
+
+-- product_recommendations
+CREATE TABLE product_recommendations (
+  product_id UUID NOT NULL REFERENCES products (id) ON UPDATE CASCADE,
+  recommended_product_id UUID NOT NULL REFERENCES products (id) ON UPDATE CASCADE,
+  kind TEXT NOT NULL,
+  score REAL NOT NULL,
+  PRIMARY KEY (product_id, kind, recommended_product_id)
+);
+
 -- carts
 CREATE TABLE carts (
   id UUID PRIMARY KEY,
@@ -136,15 +247,58 @@ CREATE TABLE orders (
   provider_id UUID NOT NULL REFERENCES order_providers (id) ON UPDATE CASCADE
 );
 
+CREATE INDEX orders_unpaid_created_at_idx ON orders (created_at, id) WHERE NOT is_paid;
+
 -- order_items
 CREATE TABLE order_items (
   id UUID PRIMARY KEY,
   quantity INTEGER NOT NULL,
   order_id UUID NOT NULL REFERENCES orders (id) ON UPDATE CASCADE,
   price DECIMAL(12, 0) NOT NULL,
-  product_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE
+  product_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE,
+  warehouse_id UUID REFERENCES warehouses (id) ON UPDATE CASCADE
+);
+
+-- stock_movements
+CREATE TABLE stock_movements (
+  id UUID PRIMARY KEY,
+  product_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE,
+  kind TEXT NOT NULL,
+  quantity INTEGER NOT NULL,
+  quantity_after INTEGER NOT NULL,
+  actor_id UUID,
+  order_id UUID REFERENCES orders (id) ON UPDATE CASCADE,
+  note TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  warehouse_id UUID REFERENCES warehouses (id) ON UPDATE CASCADE
+);
+
+CREATE INDEX stock_movements_product_variant_id_created_at_idx ON stock_movements (product_variant_id, created_at DESC, id DESC);
+CREATE INDEX stock_movements_order_id_idx ON stock_movements (order_id);
+
+-- stock_subscriptions
+CREATE TABLE stock_subscriptions (
+  product_variant_id UUID NOT NULL REFERENCES product_variants (id) ON UPDATE CASCADE,
+  user_id UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  notified_at TIMESTAMPTZ,
+  PRIMARY KEY (product_variant_id, user_id)
+);
+
+-- notifications
+CREATE TABLE notifications (
+  id UUID PRIMARY KEY,
+  user_id UUID,
+  kind TEXT NOT NULL,
+  product_id UUID REFERENCES products (id) ON UPDATE CASCADE,
+  product_variant_id UUID REFERENCES product_variants (id) ON UPDATE CASCADE,
+  quantity INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  read_at TIMESTAMPTZ
 );
 
+CREATE INDEX notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC, id DESC);
+
 -- reviews
 CREATE TABLE reviews (
   id UUID PRIMARY KEY,
@@ -191,6 +345,102 @@ CREATE TABLE refunds (
   return_request_id UUID NOT NULL REFERENCES return_requests (id) ON UPDATE CASCADE
 );
 
+-- outbox
+CREATE TABLE outbox (
+  id UUID PRIMARY KEY,
+  aggregate_type TEXT NOT NULL,
+  aggregate_id UUID NOT NULL,
+  type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT NOT NULL DEFAULT '',
+  published_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_next_attempt_at_idx ON outbox (next_attempt_at, occurred_at, id) WHERE published_at IS NULL;
+CREATE INDEX outbox_published_at_idx ON outbox (published_at) WHERE published_at IS NOT NULL;
+
+-- webhooks
+CREATE TABLE webhooks (
+  id UUID PRIMARY KEY,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  event_types TEXT[] NOT NULL DEFAULT '{}',
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  deleted_at TIMESTAMPTZ
+);
+
+-- webhook_deliveries
+CREATE TABLE webhook_deliveries (
+  id UUID PRIMARY KEY,
+  webhook_id UUID NOT NULL REFERENCES webhooks (id) ON UPDATE CASCADE ON DELETE CASCADE,
+  event_id UUID NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  response_code INTEGER NOT NULL DEFAULT 0,
+  response_body TEXT NOT NULL DEFAULT '',
+  last_error TEXT NOT NULL DEFAULT '',
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  delivered_at TIMESTAMPTZ,
+  UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_next_attempt_at_idx ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_webhook_id_created_at_idx ON webhook_deliveries (webhook_id, created_at DESC, id DESC);
+
+-- emails
+CREATE TABLE emails (
+  id UUID PRIMARY KEY,
+  kind TEXT NOT NULL,
+  event_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  recipient TEXT NOT NULL,
+  locale TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  text_body TEXT NOT NULL,
+  html_body TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ,
+  UNIQUE (event_id, kind)
+);
+
+CREATE INDEX emails_next_attempt_at_idx ON emails (next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX emails_user_id_created_at_idx ON emails (user_id, created_at DESC, id DESC);
+CREATE INDEX emails_created_at_idx ON emails (created_at DESC, id DESC);
+
+-- tasks
+CREATE TABLE tasks (
+  id UUID PRIMARY KEY,
+  kind TEXT NOT NULL,
+  key TEXT,
+  payload JSONB NOT NULL DEFAULT '{}',
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'dead')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  finished_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX tasks_kind_key_idx ON tasks (kind, key) WHERE key IS NOT NULL;
+CREATE INDEX tasks_run_at_idx ON tasks (run_at, id) WHERE status = 'pending';
+CREATE INDEX tasks_created_at_idx ON tasks (created_at DESC, id DESC);
+CREATE INDEX tasks_finished_at_idx ON tasks (finished_at) WHERE status = 'succeeded';
+
 -- Seed
 
 INSERT INTO order_providers (id, name) VALUES
@@ -1703,6 +1703,40 @@ INSERT INTO product_variants (id, sku, price, quantity, purchase_count, product_
   ('00000000-0000-7000-0000-000191315743', '8229534313215', 2990000, 1000, 100, '00000000-0000-7000-0000-000009847206')
 ON CONFLICT (id) DO NOTHING;
 
+-- Warehouses
+INSERT INTO warehouses (id, name, province, priority) VALUES
+  ('00000000-0000-7000-0000-000000000001', 'Kho Hồ Chí Minh', 'Hồ Chí Minh', 0),
+  ('00000000-0000-7000-0000-000000000002', 'Kho Hà Nội', 'Hà Nội', 1)
+ON CONFLICT (id) DO NOTHING;
+
+-- Warehouse Stocks
+INSERT INTO warehouse_stocks (warehouse_id, product_variant_id, quantity)
+SELECT
+  '00000000-0000-7000-0000-000000000001',
+  product_variants.id,
+  product_variants.quantity
+FROM
+  product_variants
+ON CONFLICT (warehouse_id, product_variant_id) DO NOTHING;
+
+-- Stock Movements
+INSERT INTO stock_movements (id, product_variant_id, kind, quantity, quantity_after, note, warehouse_id)
+SELECT
+  gen_random_uuid(),
+  product_variants.id,
+  'adjustment',
+  product_variants.quantity,
+  product_variants.quantity,
+  'opening balance',
+  '00000000-0000-7000-0000-000000000001'
+FROM
+  product_variants
+WHERE
+  product_variants.quantity > 0
+  AND NOT EXISTS (
+    SELECT 1 FROM stock_movements WHERE stock_movements.product_variant_id = product_variants.id
+  );
+
 -- Option Values
 INSERT INTO option_values (id, value, option_id) VALUES
   ('00000000-0000-7000-0000-000000000001', 'Black/Đen', '00000000-0000-7000-0000-000000000001'),
@@ -4,10 +4,35 @@ CREATE OR REPLACE FUNCTION ele_sync_product_price()
 RETURNS TRIGGER AS $$
 DECLARE
   min_price DECIMAL(12, 0);
+  max_discount INTEGER;
 BEGIN
-  SELECT MIN(price) INTO min_price FROM product_variants WHERE product_id = NEW.product_id AND deleted_at IS NULL;
+  -- The effective price is the sale price while the sale is running, the
+  -- discount is taken against the compare-at price, or the price when unset
+  SELECT
+    MIN(effective_price),
+    MAX(CASE
+      WHEN reference_price > effective_price THEN FLOOR((reference_price - effective_price) * 100 / reference_price)
+      ELSE 0
+    END)
+  INTO min_price, max_discount
+  FROM (
+    SELECT
+      CASE
+        WHEN sale_price > 0
+          AND (sale_starts_at IS NULL OR sale_starts_at <= NOW())
+          AND (sale_ends_at IS NULL OR sale_ends_at > NOW())
+          THEN sale_price
+        ELSE price
+      END AS effective_price,
+      CASE
+        WHEN compare_at_price > 0 THEN compare_at_price
+        ELSE price
+      END AS reference_price
+    FROM product_variants
+    WHERE product_id = NEW.product_id AND deleted_at IS NULL
+  ) AS variant_prices;
   IF min_price IS NOT NULL THEN
-    UPDATE products SET price = min_price WHERE id = NEW.product_id;
+    UPDATE products SET price = min_price, discount = max_discount WHERE id = NEW.product_id;
   END IF;
   RETURN NEW;
 END;
@@ -18,14 +43,121 @@ AFTER INSERT ON product_variants FOR EACH ROW
 EXECUTE FUNCTION ele_sync_product_price();
 
 CREATE OR REPLACE TRIGGER ele_product_price_after_update
-AFTER UPDATE OF price, deleted_at ON product_variants FOR EACH ROW
-WHEN (old.price IS DISTINCT FROM new.price OR old.deleted_at IS DISTINCT FROM new.deleted_at)
+AFTER UPDATE OF price, compare_at_price, sale_price, sale_starts_at, sale_ends_at, deleted_at ON product_variants FOR EACH ROW
+WHEN (
+  old.price IS DISTINCT FROM new.price
+  OR old.compare_at_price IS DISTINCT FROM new.compare_at_price
+  OR old.sale_price IS DISTINCT FROM new.sale_price
+  OR old.sale_starts_at IS DISTINCT FROM new.sale_starts_at
+  OR old.sale_ends_at IS DISTINCT FROM new.sale_ends_at
+  OR old.deleted_at IS DISTINCT FROM new.deleted_at
+)
 EXECUTE FUNCTION ele_sync_product_price();
 
 CREATE OR REPLACE TRIGGER ele_product_price_after_delete
 AFTER DELETE ON product_variants FOR EACH ROW
 EXECUTE FUNCTION ele_sync_product_price();
 
+-- sync products search columns
+
+CREATE OR REPLACE FUNCTION ele_refresh_product_search(target_product_id UUID)
+RETURNS VOID AS $$
+BEGIN
+  UPDATE products SET
+    search_skus = COALESCE((
+      SELECT string_agg(product_variants.sku, ' ')
+      FROM product_variants
+      WHERE product_variants.product_id = target_product_id AND product_variants.deleted_at IS NULL
+    ), ''),
+    search_option_values = COALESCE((
+      SELECT string_agg(option_values.value, ' ')
+      FROM option_values
+      INNER JOIN options
+        ON option_values.option_id = options.id
+      WHERE options.product_id = target_product_id
+        AND options.deleted_at IS NULL
+        AND option_values.deleted_at IS NULL
+    ), ''),
+    search_attribute_values = COALESCE((
+      SELECT string_agg(attribute_values.value, ' ')
+      FROM attribute_values
+      INNER JOIN products_attribute_values
+        ON attribute_values.id = products_attribute_values.attribute_value_id
+      WHERE products_attribute_values.product_id = target_product_id
+        AND attribute_values.deleted_at IS NULL
+    ), '')
+  WHERE id = target_product_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION ele_sync_product_search_from_product()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    PERFORM ele_refresh_product_search(OLD.product_id);
+    RETURN OLD;
+  END IF;
+  PERFORM ele_refresh_product_search(NEW.product_id);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER ele_product_search_after_variant_change
+AFTER INSERT OR DELETE OR UPDATE OF sku, deleted_at ON product_variants FOR EACH ROW
+EXECUTE FUNCTION ele_sync_product_search_from_product();
+
+CREATE OR REPLACE TRIGGER ele_product_search_after_option_change
+AFTER UPDATE OF deleted_at ON options FOR EACH ROW
+WHEN (old.deleted_at IS DISTINCT FROM new.deleted_at)
+EXECUTE FUNCTION ele_sync_product_search_from_product();
+
+CREATE OR REPLACE TRIGGER ele_product_search_after_attribute_link_change
+AFTER INSERT OR DELETE ON products_attribute_values FOR EACH ROW
+EXECUTE FUNCTION ele_sync_product_search_from_product();
+
+CREATE OR REPLACE FUNCTION ele_sync_product_search_from_option_value()
+RETURNS TRIGGER AS $$
+DECLARE
+  target_product_id UUID;
+BEGIN
+  SELECT options.product_id INTO target_product_id
+  FROM options
+  WHERE options.id = COALESCE(NEW.option_id, OLD.option_id);
+  IF target_product_id IS NOT NULL THEN
+    PERFORM ele_refresh_product_search(target_product_id);
+  END IF;
+  IF TG_OP = 'DELETE' THEN
+    RETURN OLD;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER ele_product_search_after_option_value_change
+AFTER INSERT OR DELETE OR UPDATE OF value, deleted_at ON option_values FOR EACH ROW
+EXECUTE FUNCTION ele_sync_product_search_from_option_value();
+
+CREATE OR REPLACE FUNCTION ele_sync_product_search_from_attribute_value()
+RETURNS TRIGGER AS $$
+DECLARE
+  target_product_id UUID;
+BEGIN
+  FOR target_product_id IN
+    SELECT products_attribute_values.product_id
+    FROM products_attribute_values
+    WHERE products_attribute_values.attribute_value_id = NEW.id
+  LOOP
+    PERFORM ele_refresh_product_search(target_product_id);
+  END LOOP;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER ele_product_search_after_attribute_value_change
+AFTER UPDATE OF value, deleted_at ON attribute_values FOR EACH ROW
+WHEN (old.value IS DISTINCT FROM new.value OR old.deleted_at IS DISTINCT FROM new.deleted_at)
+EXECUTE FUNCTION ele_sync_product_search_from_attribute_value();
+
 -- sync products.total_purchase
 
 CREATE OR REPLACE FUNCTION ele_update_product_total_purchase_on_insert()
@@ -70,6 +202,29 @@ CREATE OR REPLACE TRIGGER ele_update_product_total_purchase_on_delete
 AFTER DELETE ON product_variants FOR EACH ROW
 EXECUTE FUNCTION ele_update_product_total_purchase_on_delete();
 
+-- record product_daily_stats.purchases for trending score
+
+CREATE OR REPLACE FUNCTION ele_record_product_daily_purchases()
+RETURNS TRIGGER AS $$
+DECLARE
+  delta INTEGER;
+BEGIN
+  delta := NEW.purchase_count - OLD.purchase_count;
+  IF delta > 0 THEN
+    INSERT INTO product_daily_stats (product_id, day, purchases)
+    VALUES (NEW.product_id, CURRENT_DATE, delta)
+    ON CONFLICT (product_id, day)
+    DO UPDATE SET purchases = product_daily_stats.purchases + EXCLUDED.purchases;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER ele_record_product_daily_purchases
+AFTER UPDATE OF purchase_count ON product_variants FOR EACH ROW
+WHEN (old.purchase_count IS DISTINCT FROM new.purchase_count)
+EXECUTE FUNCTION ele_record_product_daily_purchases();
+
 -- sync product.rating
 
 CREATE OR REPLACE FUNCTION ele_update_product_rating()
@@ -125,3 +280,56 @@ AFTER INSERT OR UPDATE OR DELETE
 ON reviews
 FOR EACH ROW
 EXECUTE FUNCTION ele_update_product_rating();
+
+-- stock_movements
+
+CREATE OR REPLACE FUNCTION ele_reject_stock_movement_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  -- Purging a product is the only path allowed to delete its movements, see
+  -- EnableProductPurge
+  IF TG_OP = 'DELETE' AND current_setting('ele.purging_product', TRUE) = 'on' THEN
+    RETURN OLD;
+  END IF;
+  RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER ele_stock_movement_before_update_or_delete
+BEFORE UPDATE OR DELETE ON stock_movements FOR EACH ROW
+EXECUTE FUNCTION ele_reject_stock_movement_change();
+
+-- notify stock changes
+
+CREATE OR REPLACE FUNCTION ele_notify_stock_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.deleted_at IS NOT NULL THEN
+    RETURN NEW;
+  END IF;
+  -- Staff are told once when the quantity falls to the threshold
+  IF NEW.low_stock_threshold > 0
+    AND NEW.quantity <= NEW.low_stock_threshold
+    AND OLD.quantity > NEW.low_stock_threshold THEN
+    INSERT INTO notifications (id, kind, product_id, product_variant_id, quantity)
+    VALUES (gen_random_uuid(), 'low_stock', NEW.product_id, NEW.id, NEW.quantity);
+  END IF;
+  -- Each subscriber is told once, subscribing again arms it again
+  IF OLD.quantity = 0 AND NEW.quantity > 0 THEN
+    WITH notified AS (
+      UPDATE stock_subscriptions SET notified_at = NOW()
+      WHERE product_variant_id = NEW.id AND notified_at IS NULL
+      RETURNING user_id
+    )
+    INSERT INTO notifications (id, user_id, kind, product_id, product_variant_id, quantity)
+    SELECT gen_random_uuid(), notified.user_id, 'back_in_stock', NEW.product_id, NEW.id, NEW.quantity
+    FROM notified;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER ele_notify_stock_change_after_update
+AFTER UPDATE OF quantity ON product_variants FOR EACH ROW
+WHEN (old.quantity IS DISTINCT FROM new.quantity)
+EXECUTE FUNCTION ele_notify_stock_change();
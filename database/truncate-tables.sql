@@ -5,41 +5,75 @@ BEGIN
   EXECUTE 'ALTER TABLE attributes DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE attribute_values DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE products_attribute_values DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE spec_groups DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE specs DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_specs DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE product_variants DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE product_images DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_image_renditions DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE options DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE option_values DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE option_values_product_variants DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_variant_components DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE warehouses DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE warehouse_stocks DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_daily_stats DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_recommendations DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE carts DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE cart_items DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE order_statuses DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE order_providers DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE orders DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE order_items DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE stock_movements DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE stock_subscriptions DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE notifications DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE reviews DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE return_request_statuses DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE return_requests DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE refund_statuses DISABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE refunds DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE outbox DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE webhooks DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE webhook_deliveries DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE emails DISABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE tasks DISABLE TRIGGER ALL';
 END $$;
 
 TRUNCATE TABLE
+tasks,
+emails,
+webhook_deliveries,
+webhooks,
+outbox,
 refunds,
 refund_statuses,
 return_requests,
 return_request_statuses,
 reviews,
+stock_movements,
+stock_subscriptions,
+notifications,
 order_items,
 orders,
 order_statuses,
 order_providers,
 cart_items,
 carts,
+product_daily_stats,
+product_recommendations,
+warehouse_stocks,
+warehouses,
 option_values_product_variants,
+product_variant_components,
 option_values,
 options,
+product_image_renditions,
 product_images,
 product_variants,
+product_specs,
+specs,
+spec_groups,
 products_attribute_values,
 attribute_values,
 attributes,
@@ -54,20 +88,37 @@ BEGIN
   EXECUTE 'ALTER TABLE attributes ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE attribute_values ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE products_attribute_values ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE spec_groups ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE specs ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_specs ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE product_variants ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE product_images ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_image_renditions ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE options ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE option_values ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE option_values_product_variants ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_variant_components ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE warehouses ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE warehouse_stocks ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_daily_stats ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE product_recommendations ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE carts ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE cart_items ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE order_statuses ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE order_providers ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE orders ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE order_items ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE stock_movements ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE stock_subscriptions ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE notifications ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE reviews ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE return_request_statuses ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE return_requests ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE refund_statuses ENABLE TRIGGER ALL';
   EXECUTE 'ALTER TABLE refunds ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE outbox ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE webhooks ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE webhook_deliveries ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE emails ENABLE TRIGGER ALL';
+  EXECUTE 'ALTER TABLE tasks ENABLE TRIGGER ALL';
 END $$;
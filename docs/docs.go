@@ -15,8 +15,8 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/attributes": {
-            "get": {
+        "/admin/cache/invalidate": {
+            "post": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -25,7 +25,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get all attributes",
+                "description": "Invalidate the cache entries containing the given entities, or every entity of the type when ids is empty, in Redis and in the local cache of every instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,60 +33,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Cache"
                 ],
-                "summary": "List all attributes",
+                "summary": "Invalidate cache entries",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page for pagination",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Invalidate cache request",
+                        "name": "invalidation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InvalidateCacheData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/cache/keys": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Limit for pagination",
-                        "name": "limit",
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Scan the cache keys matching a glob pattern, or the keys tagged with an entity type or entity when entity is set. The limit is a hint of how many keys to scan per page, continue with the returned cursor until it is 0",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cache"
+                ],
+                "summary": "List cache keys",
+                "parameters": [
+                    {
+                        "enum": [
+                            "product",
+                            "product_variant",
+                            "category",
+                            "attribute",
+                            "spec_group"
+                        ],
+                        "type": "string",
+                        "description": "Entity type the keys contain",
+                        "name": "entity",
                         "in": "query"
                     },
                     {
-                        "type": "array",
+                        "type": "string",
                         "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Attribute IDs",
-                        "name": "attribute_ids",
+                        "description": "Entity ID the keys contain, requires entity",
+                        "name": "id",
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Product IDs",
-                        "name": "product_ids",
+                        "type": "string",
+                        "default": "*",
+                        "description": "Glob pattern of the keys",
+                        "name": "match",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Cursor of the scan",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "exclude",
-                            "only",
-                            "all"
-                        ],
-                        "type": "string",
-                        "description": "Filter by deletion status",
-                        "name": "deleted",
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Keys to scan per page",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -94,7 +140,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/PaginationResponseDto-AttributeResponseDto"
+                            "$ref": "#/definitions/CacheKeysResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "500": {
@@ -104,8 +162,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/emails": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -114,7 +174,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new attribute",
+                "description": "Get the email send log, latest first, with the outcome of the last attempt of each email",
                 "consumes": [
                     "application/json"
                 ],
@@ -122,25 +182,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Email"
                 ],
-                "summary": "Create a new attribute",
+                "summary": "List sent emails",
                 "parameters": [
                     {
-                        "description": "Attribute request",
-                        "name": "attribute",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/CreateAttributeData"
-                        }
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by user IDs",
+                        "name": "user_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "sent",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/AttributeResponseDto"
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_EmailResponseDto"
                         }
                     },
                     "400": {
@@ -149,8 +236,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -164,7 +251,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/attributes/{attribute_id}": {
+        "/admin/tasks": {
             "get": {
                 "security": [
                     {
@@ -174,7 +261,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get attribute details by ID",
+                "description": "Get the tasks queued for the worker, latest first. Dead tasks failed all of their attempts and wait to be retried",
                 "consumes": [
                     "application/json"
                 ],
@@ -182,28 +269,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Task"
                 ],
-                "summary": "Get attribute by ID",
+                "summary": "List background tasks",
                 "parameters": [
                     {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by kinds",
+                        "name": "kinds",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "dead"
+                        ],
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/AttributeResponseDto"
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_TaskResponseDto"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -215,8 +335,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/tasks/{task_id}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -225,7 +347,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Delete attribute by ID",
+                "description": "Get a task queued for the worker with the error of its last failed attempt",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,22 +355,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Task"
                 ],
-                "summary": "Delete an attribute",
+                "summary": "Get background task by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
+                        "description": "Task ID",
+                        "name": "task_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TaskResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -263,8 +400,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/admin/tasks/{task_id}/retry": {
+            "post": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -273,7 +412,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update attribute by ID",
+                "description": "Run a dead task again with the same payload and a fresh budget of attempts, it is pending until the next run of the worker",
                 "consumes": [
                     "application/json"
                 ],
@@ -281,33 +420,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Task"
                 ],
-                "summary": "Update an attribute",
+                "summary": "Retry a dead background task",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
+                        "description": "Task ID",
+                        "name": "task_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update attribute request",
-                        "name": "attribute",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateAttributeData"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/AttributeResponseDto"
+                            "$ref": "#/definitions/TaskResponseDto"
                         }
                     },
                     "400": {
@@ -316,6 +446,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -337,7 +473,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/attributes/{attribute_id}/values": {
+        "/admin/webhooks": {
             "get": {
                 "security": [
                     {
@@ -347,7 +483,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get all attribute values",
+                "description": "Get all webhooks ordered by creation",
                 "consumes": [
                     "application/json"
                 ],
@@ -355,9 +491,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Webhook"
                 ],
-                "summary": "List all attribute values",
+                "summary": "List all webhooks",
                 "parameters": [
                     {
                         "type": "integer",
@@ -372,48 +508,19 @@ const docTemplate = `{
                         "description": "Limit for pagination",
                         "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Product ID",
-                        "name": "attribute_value_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "exclude",
-                            "only",
-                            "all"
-                        ],
-                        "type": "string",
-                        "description": "Filter by deletion status",
-                        "name": "deleted",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/PaginationResponseDto-AttributeValueResponseDto"
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_WebhookResponseDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "500": {
@@ -433,7 +540,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new attribute value for a given attribute",
+                "description": "Subscribe a partner endpoint to events, deliveries are POSTed as JSON and signed with the returned secret in the Webhook-Signature header as t=\u003cunix seconds\u003e,v1=\u003chex HMAC-SHA256 of \"\u003cunix seconds\u003e.\u003cbody\u003e\"\u003e",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,25 +548,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Webhook"
                 ],
-                "summary": "Create a new attribute value",
+                "summary": "Create a new webhook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Attribute value request",
-                        "name": "value",
+                        "description": "Webhook request",
+                        "name": "webhook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateAttributeValueData"
+                            "$ref": "#/definitions/CreateWebhookData"
                         }
                     }
                 ],
@@ -467,7 +566,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/AttributeValueResponseDto"
+                            "$ref": "#/definitions/WebhookResponseDto"
                         }
                     },
                     "400": {
@@ -476,14 +575,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -497,8 +590,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/attributes/{attribute_id}/values/{value_id}": {
-            "delete": {
+        "/admin/webhooks/{webhook_id}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -507,7 +600,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Delete attribute value by ID",
+                "description": "Get webhook details by ID, with its signing secret",
                 "consumes": [
                     "application/json"
                 ],
@@ -515,29 +608,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Webhook"
                 ],
-                "summary": "Delete an attribute value",
+                "summary": "Get webhook by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Attribute Value ID",
-                        "name": "value_id",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WebhookResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -553,7 +654,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -562,7 +663,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update a attribute value for a given attribute",
+                "description": "Soft delete a webhook, its delivery log is kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -570,45 +671,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attribute"
+                    "Webhook"
                 ],
-                "summary": "Update a attribute value",
+                "summary": "Delete a webhook",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Attribute ID",
-                        "name": "attribute_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Attribute Value ID",
-                        "name": "value_id",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update attribute values request",
-                        "name": "value",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateAttributeValueData"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/AttributeValueResponseDto"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -616,14 +694,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -635,10 +713,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/carts": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -647,7 +723,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new cart for the user",
+                "description": "Update webhook by ID, an inactive webhook receives no new events and its pending deliveries fail",
                 "consumes": [
                     "application/json"
                 ],
@@ -655,62 +731,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Webhook"
                 ],
-                "summary": "Create cart",
+                "summary": "Update a webhook",
                 "parameters": [
                     {
-                        "description": "Create cart request",
-                        "name": "cart",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update webhook request",
+                        "name": "webhook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateCartData"
+                            "$ref": "#/definitions/UpdateWebhookData"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/CartResponseDto"
+                            "$ref": "#/definitions/WebhookResponseDto"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
-                    }
-                }
-            }
-        },
-        "/carts/me": {
-            "get": {
-                "security": [
-                    {
-                        "OAuth2AccessCode": []
                     },
-                    {
-                        "OAuth2Password": []
-                    }
-                ],
-                "description": "Get cart for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Cart"
-                ],
-                "summary": "Get my cart",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/CartResponseDto"
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "404": {
@@ -728,7 +787,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/carts/users/{user_id}": {
+        "/admin/webhooks/{webhook_id}/deliveries": {
             "get": {
                 "security": [
                     {
@@ -738,7 +797,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get cart for the authenticated user",
+                "description": "Get the delivery log of a webhook, latest first, with the response of the last attempt of each delivery",
                 "consumes": [
                     "application/json"
                 ],
@@ -746,24 +805,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Webhook"
                 ],
-                "summary": "Get cart by user ID",
+                "summary": "List the deliveries of a webhook",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/CartResponseDto"
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_WebhookDeliveryResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "404": {
@@ -781,8 +877,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/carts/{cart_id}": {
-            "get": {
+        "/admin/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -791,7 +887,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get cart by ID",
+                "description": "Send a delivery again with the same body and a fresh budget of attempts, it is pending until the next delivery run",
                 "consumes": [
                     "application/json"
                 ],
@@ -799,24 +895,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Webhook"
                 ],
-                "summary": "Get cart",
+                "summary": "Redeliver a webhook delivery",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Cart ID",
-                        "name": "cart_id",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/CartResponseDto"
+                            "$ref": "#/definitions/WebhookDeliveryResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "404": {
@@ -834,8 +950,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/carts/{cart_id}/item": {
-            "post": {
+        "/attributes": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -844,7 +960,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Add a product item to the cart",
+                "description": "Get all attributes",
                 "consumes": [
                     "application/json"
                 ],
@@ -852,25 +968,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Attribute"
                 ],
-                "summary": "Add item to cart",
+                "summary": "List all attributes",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
                         "format": "uuid",
-                        "description": "Cart ID",
-                        "name": "cart_id",
-                        "in": "path",
-                        "required": true
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Attribute IDs",
+                        "name": "attribute_ids",
+                        "in": "query"
                     },
                     {
-                        "description": "Cart item request",
-                        "name": "item",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Product IDs",
+                        "name": "product_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exclude",
+                            "only",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filter by deletion status",
+                        "name": "deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-AttributeResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new attribute",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attribute"
+                ],
+                "summary": "Create a new attribute",
+                "parameters": [
+                    {
+                        "description": "Attribute request",
+                        "name": "attribute",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateCartItemData"
+                            "$ref": "#/definitions/CreateAttributeData"
                         }
                     }
                 ],
@@ -878,7 +1075,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/CartItemResponseDto"
+                            "$ref": "#/definitions/AttributeResponseDto"
                         }
                     },
                     "400": {
@@ -887,6 +1084,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -896,8 +1099,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/carts/{cart_id}/item/{item_id}": {
-            "delete": {
+        "/attributes/{attribute_id}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -906,7 +1109,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Remove an item from the cart",
+                "description": "Get attribute details by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -914,23 +1117,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Attribute"
                 ],
-                "summary": "Remove cart item",
+                "summary": "Get attribute by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Cart ID",
-                        "name": "cart_id",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AttributeResponseDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
                     },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete attribute by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attribute"
+                ],
+                "summary": "Delete an attribute",
+                "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Cart Item ID",
-                        "name": "item_id",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
                         "in": "path",
                         "required": true
                     }
@@ -962,7 +1208,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update quantity of a cart item",
+                "description": "Update attribute by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -970,33 +1216,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Attribute"
                 ],
-                "summary": "Update cart item",
+                "summary": "Update an attribute",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Cart ID",
-                        "name": "cart_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Cart Item ID",
-                        "name": "item_id",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update cart item request",
-                        "name": "item",
+                        "description": "Update attribute request",
+                        "name": "attribute",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateCartItemData"
+                            "$ref": "#/definitions/UpdateAttributeData"
                         }
                     }
                 ],
@@ -1004,7 +1242,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/CartItemResponseDto"
+                            "$ref": "#/definitions/AttributeResponseDto"
                         }
                     },
                     "400": {
@@ -1019,6 +1257,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1028,9 +1272,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
+        "/attributes/{attribute_id}/values": {
             "get": {
-                "description": "Get all categories",
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get all attribute values",
                 "consumes": [
                     "application/json"
                 ],
@@ -1038,16 +1290,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Attribute"
                 ],
-                "summary": "List all categories",
+                "summary": "List all attribute values",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1061,33 +1307,68 @@ const docTemplate = `{
                         "description": "Limit for pagination",
                         "name": "limit",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_CategoryResponseDto"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
                     {
-                        "OAuth2AccessCode": []
+                        "type": "string",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "OAuth2Password": []
-                    }
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Product ID",
+                        "name": "attribute_value_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exclude",
+                            "only",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filter by deletion status",
+                        "name": "deleted",
+                        "in": "query"
+                    }
                 ],
-                "description": "Create a new category",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-AttributeValueResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new attribute value for a given attribute",
                 "consumes": [
                     "application/json"
                 ],
@@ -1095,17 +1376,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Attribute"
                 ],
-                "summary": "Create a new category",
+                "summary": "Create a new attribute value",
                 "parameters": [
                     {
-                        "description": "Category request",
-                        "name": "category",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attribute value request",
+                        "name": "value",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateCategoryData"
+                            "$ref": "#/definitions/CreateAttributeValueData"
                         }
                     }
                 ],
@@ -1113,7 +1402,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/CategoryResponseDto"
+                            "$ref": "#/definitions/AttributeValueResponseDto"
                         }
                     },
                     "400": {
@@ -1122,6 +1411,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -1137,9 +1432,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{category_id}": {
-            "get": {
-                "description": "Get category details by ID",
+        "/attributes/{attribute_id}/values/{value_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete attribute value by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1147,25 +1450,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Attribute"
                 ],
-                "summary": "Get category by ID",
+                "summary": "Delete an attribute value",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Category ID",
-                        "name": "category_id",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute Value ID",
+                        "name": "value_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/CategoryResponseDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
@@ -1190,7 +1497,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update category by ID",
+                "description": "Update a attribute value for a given attribute",
                 "consumes": [
                     "application/json"
                 ],
@@ -1198,25 +1505,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Attribute"
                 ],
-                "summary": "Update a category",
+                "summary": "Update a attribute value",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Category ID",
-                        "name": "category_id",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update category request",
-                        "name": "category",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Attribute Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update attribute values request",
+                        "name": "value",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateCategoryData"
+                            "$ref": "#/definitions/UpdateAttributeValueData"
                         }
                     }
                 ],
@@ -1224,7 +1539,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/CategoryResponseDto"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AttributeValueResponseDto"
+                            }
                         }
                     },
                     "400": {
@@ -1254,7 +1572,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/dev/flush-cache": {
+        "/carts": {
             "post": {
                 "security": [
                     {
@@ -1264,7 +1582,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Flush all data from Redis cache",
+                "description": "Create a new cart for the user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1272,12 +1590,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dev"
+                    "Cart"
+                ],
+                "summary": "Create cart",
+                "parameters": [
+                    {
+                        "description": "Create cart request",
+                        "name": "cart",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCartData"
+                        }
+                    }
                 ],
-                "summary": "Flush Redis Cache",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CartResponseDto"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1288,7 +1620,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/carts/me": {
             "get": {
                 "security": [
                     {
@@ -1298,7 +1630,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get all orders",
+                "description": "Get cart for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1306,68 +1638,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
-                ],
-                "summary": "List all orders",
-                "parameters": [
-                    {
-                        "type": "array",
-                        "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by order IDs",
-                        "name": "order_ids",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by user IDs",
-                        "name": "user_ids",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "Pending",
-                            "Processing",
-                            "Shipping",
-                            "Delivered",
-                            "Cancelled"
-                        ],
-                        "type": "string",
-                        "description": "Filter by statuses",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page for pagination",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Limit for pagination",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Cart"
                 ],
+                "summary": "Get my cart",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/OrderResponseDto"
-                            }
+                            "$ref": "#/definitions/CartResponseDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "500": {
@@ -1377,8 +1661,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/carts/users/{user_id}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -1387,7 +1673,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new order",
+                "description": "Get cart for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1395,35 +1681,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Create a new order",
+                "summary": "Get cart by user ID",
                 "parameters": [
                     {
-                        "description": "Order request",
-                        "name": "order",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/CreateOrderData"
-                        }
-                    }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/OrderResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Error"
+                            "$ref": "#/definitions/CartResponseDto"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -1437,9 +1716,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/vnpay/ipn": {
+        "/carts/{cart_id}": {
             "get": {
-                "description": "Verify VNPay IPN",
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get cart by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1447,88 +1734,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Verify VNPay IPN",
+                "summary": "Get cart",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "vnp_Amount",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "vnp_BankCode",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "vnp_BankTranNo",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "vnp_CardType",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "vnp_OrderInfo",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "vnp_PayDate",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "vnp_ResponseCode",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Cart ID",
+                        "name": "cart_id",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CartResponseDto"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "name": "vnp_SecureHash",
-                        "in": "query",
-                        "required": true
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts/{cart_id}/item": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "name": "vnp_TmnCode",
-                        "in": "query",
-                        "required": true
+                        "OAuth2AccessCode": []
                     },
                     {
-                        "type": "string",
-                        "name": "vnp_TransactionNo",
-                        "in": "query",
-                        "required": true
-                    },
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Add a product item to the cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Add item to cart",
+                "parameters": [
                     {
                         "type": "string",
-                        "name": "vnp_TransactionStatus",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Cart ID",
+                        "name": "cart_id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "name": "vnp_TxnRef",
-                        "in": "query",
-                        "required": true
+                        "description": "Cart item request",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCartItemData"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/VerifyVNPayIPNResponseDTO"
+                            "$ref": "#/definitions/CartItemResponseDto"
                         }
                     },
                     "400": {
@@ -1546,8 +1831,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{order_id}": {
-            "get": {
+        "/carts/{cart_id}/item/{item_id}": {
+            "delete": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -1556,7 +1841,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get order details by ID",
+                "description": "Remove an item from the cart",
                 "consumes": [
                     "application/json"
                 ],
@@ -1564,25 +1849,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Remove cart item",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
-                        "name": "order_id",
+                        "description": "Cart ID",
+                        "name": "cart_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Cart Item ID",
+                        "name": "item_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/OrderResponseDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
@@ -1598,7 +1888,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "patch": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -1607,7 +1897,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update  order",
+                "description": "Update quantity of a cart item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1615,25 +1905,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Update order",
+                "summary": "Update cart item",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
-                        "name": "order_id",
+                        "description": "Cart ID",
+                        "name": "cart_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update order status request",
-                        "name": "status",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Cart Item ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update cart item request",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateOrderData"
+                            "$ref": "#/definitions/UpdateCartItemData"
                         }
                     }
                 ],
@@ -1641,7 +1939,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/OrderResponseDto"
+                            "$ref": "#/definitions/CartItemResponseDto"
                         }
                     },
                     "400": {
@@ -1656,12 +1954,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1671,9 +1963,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/categories": {
             "get": {
-                "description": "Get all products, used for search and suggestions also",
+                "description": "Get all categories",
                 "consumes": [
                     "application/json"
                 ],
@@ -1681,9 +1973,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "List all products",
+                "summary": "List all categories",
                 "parameters": [
                     {
                         "type": "string",
@@ -1704,96 +1996,25 @@ const docTemplate = `{
                         "description": "Limit for pagination",
                         "name": "limit",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_CategoryResponseDto"
+                        }
                     },
-                    {
-                        "enum": [
-                            "exclude",
-                            "only",
-                            "all"
-                        ],
-                        "type": "string",
-                        "description": "Filter by deleted status",
-                        "name": "deleted",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "Sort by price",
-                        "name": "sort_price",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "Sort by rating",
-                        "name": "sort_rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by category ID",
-                        "name": "category_ids",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by product ID",
-                        "name": "product_ids",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Minimum price filter",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Maximum price filter",
-                        "name": "max_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Filter by minimum rating",
-                        "name": "rating",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/PaginationResponseDto-ProductResponseDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
                         "OAuth2AccessCode": []
                     },
@@ -1801,7 +2022,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create a new product, including allllll",
+                "description": "Create a new category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1809,17 +2030,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create a new category",
                 "parameters": [
                     {
-                        "description": "Product request",
-                        "name": "product",
+                        "description": "Category request",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateProductData"
+                            "$ref": "#/definitions/CreateCategoryData"
                         }
                     }
                 ],
@@ -1827,7 +2048,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/ProductResponseDto"
+                            "$ref": "#/definitions/CategoryResponseDto"
                         }
                     },
                     "400": {
@@ -1851,30 +2072,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/images/delete-url/{image_id}": {
+        "/categories/{category_id}": {
             "get": {
-                "security": [
-                    {
-                        "OAuth2AccessCode": []
-                    },
-                    {
-                        "OAuth2Password": []
-                    }
+                "description": "Get category details by ID",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get a presigned URL to delete product images",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Get presigned URL for image deletion",
+                "summary": "Get category by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product Image ID",
-                        "name": "image_id",
+                        "description": "Category ID",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
                     }
@@ -1883,11 +2099,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/DeleteImageURLResponseDto"
+                            "$ref": "#/definitions/CategoryResponseDto"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -1899,10 +2115,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/images/upload-url": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -1911,33 +2125,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Get a presigned URL to upload product images",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Get presigned URL for image upload",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/UploadImageURLResponseDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/products/{product_id}": {
-            "get": {
-                "description": "Get product details by ID",
+                "description": "Update category by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1945,24 +2133,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Update a category",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Category ID",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update category request",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCategoryData"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ProductResponseDto"
+                            "$ref": "#/definitions/CategoryResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
                         }
                     },
                     "404": {
@@ -1971,6 +2174,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1978,8 +2187,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notifications": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -1988,7 +2199,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Delete product by ID",
+                "description": "List notifications of the current user, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1996,25 +2207,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Notification"
                 ],
-                "summary": "Delete a product",
+                "summary": "List notifications",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_NotificationResponseDto"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -2026,8 +2252,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/notifications/staff": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -2036,7 +2264,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update product by ID",
+                "description": "List notifications shared by admins and staff, such as low stock alerts",
                 "consumes": [
                     "application/json"
                 ],
@@ -2044,49 +2272,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Notification"
                 ],
-                "summary": "Update a product",
+                "summary": "List staff notifications",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Update product request",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateProductData"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/ProductResponseDto"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Error"
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_NotificationResponseDto"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -2100,8 +2325,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{product_id}/images": {
-            "post": {
+        "/notifications/staff/{notification_id}/read": {
+            "patch": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -2110,7 +2335,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Create new images for an existing product",
+                "description": "Mark a notification shared by admins and staff as read",
                 "consumes": [
                     "application/json"
                 ],
@@ -2118,39 +2343,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Notification"
                 ],
-                "summary": "Add product images",
+                "summary": "Mark staff notification as read",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Notification ID",
+                        "name": "notification_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Product images request",
-                        "name": "productImages",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/AddProductImageData"
-                            }
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ProductImageResponseDto"
-                            }
+                            "$ref": "#/definitions/NotificationResponseDto"
                         }
                     },
                     "400": {
@@ -2159,8 +2369,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/Error"
                         }
@@ -2172,8 +2388,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notifications/{notification_id}/read": {
+            "patch": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -2182,7 +2400,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Delete images for an existing product",
+                "description": "Mark a notification of the current user as read",
                 "consumes": [
                     "application/json"
                 ],
@@ -2190,34 +2408,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Notification"
                 ],
-                "summary": "Delete product images",
+                "summary": "Mark notification as read",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Notification ID",
+                        "name": "notification_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "array",
-                        "format": "uuid",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Product Image IDs",
-                        "name": "ids",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/NotificationResponseDto"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2225,6 +2434,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2234,8 +2455,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{product_id}/options": {
-            "patch": {
+        "/orders": {
+            "get": {
                 "security": [
                     {
                         "OAuth2AccessCode": []
@@ -2244,7 +2465,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Update a product options",
+                "description": "Get all orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -2252,29 +2473,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Order"
                 ],
-                "summary": "Update options",
+                "summary": "List all orders",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "array",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by order IDs",
+                        "name": "order_ids",
+                        "in": "query"
                     },
                     {
-                        "description": "Update product option request",
-                        "name": "option",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/UpdateProductOptionsData"
-                            }
-                        }
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by user IDs",
+                        "name": "user_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Pending",
+                            "Processing",
+                            "Shipping",
+                            "Delivered",
+                            "Cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor for keyset pagination, empty for the first page, overrides page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total items in cursor mode",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2283,28 +2545,10 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/ProductOptionResponseDto"
+                                "$ref": "#/definitions/OrderResponseDto"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/Error"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2312,9 +2556,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/{product_id}/variants": {
+            },
             "post": {
                 "security": [
                     {
@@ -2324,7 +2566,7 @@ const docTemplate = `{
                         "OAuth2Password": []
                     }
                 ],
-                "description": "Add a new variant for a existing product",
+                "description": "Create a new order",
                 "consumes": [
                     "application/json"
                 ],
@@ -2332,28 +2574,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Order"
                 ],
-                "summary": "Add a new product variant",
+                "summary": "Create a new order",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Product variant request",
-                        "name": "productVariant",
+                        "description": "Order request",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/AddProductVariantsData"
-                            }
+                            "$ref": "#/definitions/CreateOrderData"
                         }
                     }
                 ],
@@ -2361,10 +2592,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ProductVariantResponseDto"
-                            }
+                            "$ref": "#/definitions/OrderResponseDto"
                         }
                     },
                     "400": {
@@ -2388,17 +2616,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{product_id}/variants/{variant_id}": {
-            "patch": {
-                "security": [
-                    {
-                        "OAuth2AccessCode": []
-                    },
-                    {
-                        "OAuth2Password": []
-                    }
-                ],
-                "description": "Update a product variant by ID",
+        "/orders/vnpay/ipn": {
+            "get": {
+                "description": "Verify VNPay IPN",
                 "consumes": [
                     "application/json"
                 ],
@@ -2406,33 +2626,193 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Order"
                 ],
-                "summary": "Update a product variant",
+                "summary": "Verify VNPay IPN",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
+                        "name": "vnp_Amount",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Product Variant ID",
-                        "name": "variant_id",
-                        "in": "path",
+                        "name": "vnp_BankCode",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Update product variant request",
-                        "name": "productVariant",
+                        "type": "string",
+                        "name": "vnp_BankTranNo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_CardType",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_OrderInfo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_PayDate",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_ResponseCode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_SecureHash",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_TmnCode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_TransactionNo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_TransactionStatus",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "vnp_TxnRef",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/VerifyVNPayIPNResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get order details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/OrderResponseDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update  order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Update order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update order status request",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateProductVariantData"
+                            "$ref": "#/definitions/UpdateOrderData"
                         }
                     }
                 ],
@@ -2440,7 +2820,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ProductVariantResponseDto"
+                            "$ref": "#/definitions/OrderResponseDto"
                         }
                     },
                     "400": {
@@ -2469,99 +2849,4126 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "AddProductImageData": {
-            "type": "object",
-            "required": [
-                "key"
-            ],
-            "properties": {
-                "key": {
-                    "type": "string"
-                },
-                "order": {
-                    "type": "integer"
-                },
-                "productVariantId": {
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get all products, used for search also",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List all products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor for keyset pagination, empty for the first page, overrides page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count total items in cursor mode",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exclude",
+                            "only",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filter by deleted status",
+                        "name": "deleted",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "trending"
+                        ],
+                        "type": "string",
+                        "description": "Sort by trending score",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort by price",
+                        "name": "sort_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort by rating",
+                        "name": "sort_rating",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort by discount",
+                        "name": "sort_discount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by products on sale",
+                        "name": "on_sale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by category ID",
+                        "name": "category_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by product ID",
+                        "name": "product_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price filter",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price filter",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Filter by minimum rating",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "draft",
+                                "published",
+                                "archived"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by status, staff only, others see published products",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-ProductResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new product, including allllll",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product request",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateProductData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ProductResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/compare": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get products side by side with their specs lined up, values follow the order of product_ids",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Compare products",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Product IDs, between 2 and 4",
+                        "name": "product_ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductComparisonResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/images/delete-url/{image_id}": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a presigned URL to delete product images",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get presigned URL for image deletion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DeleteImageURLResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/images/upload-url": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get a presigned URL to upload product images, JPEG, PNG, GIF and WebP are accepted and they are checked and converted to WebP once added to a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get presigned URL for image upload",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UploadImageURLResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/suggestions": {
+            "get": {
+                "description": "Get typo-tolerant, accent-insensitive suggestions of product names, categories and attribute values for a search prefix",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List search suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search prefix",
+                        "name": "search",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Limit per suggestion type",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductSuggestionResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get product details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductResponseDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product request",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProductData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/bought-together": {
+            "get": {
+                "description": "Get products most often ordered together with a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List frequently bought together products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create new images for an existing product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Add product images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product images request",
+                        "name": "productImages",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AddProductImageData"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductImageResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete images for an existing product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete product images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "format": "uuid",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Product Image IDs",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/options": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Add an option to a product, every remaining variant is mapped to one of its values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Add an option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add product option request",
+                        "name": "option",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddProductOptionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ProductOptionResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update a product options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update options",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product option request",
+                        "name": "option",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UpdateProductOptionsData"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductOptionResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/options/{option_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete an option with its values, the remaining variants must still differ by the other options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete an option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/options/{option_id}/values": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Add values to an option of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Add option values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add option values request",
+                        "name": "optionValues",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AddProductOptionValuesData"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductOptionValueResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/options/{option_id}/values/{value_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Delete a value of an option, the variants using it must be deleted first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete an option value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/publishing": {
+            "put": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Replace the status and the publish schedule of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update product publishing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product publishing request",
+                        "name": "publishing",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProductPublishingData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Permanently delete a deleted product which was never ordered, along with its images",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Purge a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/related": {
+            "get": {
+                "description": "Get products sharing the category and attribute values of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List related products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Restore a deleted product with the variants, options and images deleted along with it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Restore a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductResponseDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/specs": {
+            "put": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Replace the spec values of a product, each value must match the type of its spec",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update product specs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product specs request",
+                        "name": "specs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProductSpecsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/variants": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Add a new variant for a existing product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Add a new product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product variant request",
+                        "name": "productVariant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AddProductVariantsData"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProductVariantResponseDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/variants/{variant_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Soft delete a product variant, it stays referenced by carts, orders and the stock ledger",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete a product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update a product variant by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update a product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product variant request",
+                        "name": "productVariant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProductVariantData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductVariantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/variants/{variant_id}/components": {
+            "put": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Replace the components of a bundle variant, its stock is derived from them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update product variant components",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product variant components request",
+                        "name": "components",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProductVariantComponentsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductVariantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/variants/{variant_id}/pricing": {
+            "put": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Replace the compare-at price and the scheduled sale of a product variant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update product variant pricing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update product variant pricing request",
+                        "name": "pricing",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProductVariantPricingData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProductVariantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/variants/{variant_id}/stock-movements": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "List the inventory ledger of a product variant, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List stock movements of a product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "restock",
+                                "sale",
+                                "cancellation",
+                                "return",
+                                "adjustment"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by kind",
+                        "name": "kinds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_StockMovementResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Restock, return or adjust the quantity of a product variant by a signed amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Record a stock movement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock movement request",
+                        "name": "stockMovement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateStockMovementData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/StockMovementResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{product_id}/variants/{variant_id}/stock-subscriptions": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Notify the current user once the product variant is back in stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Subscribe to back in stock notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Stop notifying the current user when the product variant is back in stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Unsubscribe from back in stock notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/spec-groups": {
+            "get": {
+                "description": "Get all spec groups with their specs, ordered by order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "List all spec groups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_SpecGroupResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new spec group with its specs, a number spec may have a unit and an enum spec needs choices",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Create a new spec group",
+                "parameters": [
+                    {
+                        "description": "Spec group request",
+                        "name": "specGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSpecGroupData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SpecGroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/spec-groups/{spec_group_id}": {
+            "get": {
+                "description": "Get spec group details with its specs by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Get spec group by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec Group ID",
+                        "name": "spec_group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SpecGroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Soft delete a spec group and its specs, products keep their values but no longer show them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Delete a spec group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec Group ID",
+                        "name": "spec_group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update spec group by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Update a spec group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec Group ID",
+                        "name": "spec_group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update spec group request",
+                        "name": "specGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSpecGroupData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SpecGroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/spec-groups/{spec_group_id}/specs": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Add new specs to a spec group, codes are unique across groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Add specs to a spec group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec Group ID",
+                        "name": "spec_group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add specs request",
+                        "name": "specs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddSpecsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SpecGroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/spec-groups/{spec_group_id}/specs/{spec_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Soft delete a spec of a spec group, products keep their values but no longer show them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Delete a spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec Group ID",
+                        "name": "spec_group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec ID",
+                        "name": "spec_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update a spec of a spec group, its type cannot change and enum choices can only be added",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Update a spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec Group ID",
+                        "name": "spec_group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Spec ID",
+                        "name": "spec_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update spec request",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSpecData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SpecResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get all warehouses ordered by priority",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "List all warehouses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginationResponseDto-internal_delivery_http_WarehouseResponseDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Create a new warehouse, orders are allocated to warehouses in their province first, then by priority",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Create a new warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse request",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateWarehouseData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/WarehouseResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{warehouse_id}": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Get warehouse details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Get warehouse by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WarehouseResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Soft delete a warehouse, it is no longer chosen for new orders. A warehouse still holding stock can not be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Delete a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2AccessCode": []
+                    },
+                    {
+                        "OAuth2Password": []
+                    }
+                ],
+                "description": "Update warehouse by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Update a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update warehouse request",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateWarehouseData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WarehouseResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AddProductImageData": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "productVariantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "AddProductOptionData": {
+            "type": "object",
+            "required": [
+                "name",
+                "values",
+                "variants"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variants": {
+                    "description": "Variants map every remaining variant to one of the new values",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AddProductOptionVariantData"
+                    }
+                }
+            }
+        },
+        "AddProductOptionValuesData": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "AddProductOptionVariantData": {
+            "type": "object",
+            "required": [
+                "productVariantId",
+                "value"
+            ],
+            "properties": {
+                "productVariantId": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "AddProductVariantsData": {
+            "type": "object",
+            "required": [
+                "price",
+                "sku"
+            ],
+            "properties": {
+                "compareAtPrice": {
+                    "type": "integer"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BundleComponentData"
+                    }
+                },
+                "optionValueIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "warehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "AddSpecsData": {
+            "type": "object",
+            "required": [
+                "specs"
+            ],
+            "properties": {
+                "specs": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/CreateSpecData"
+                    }
+                }
+            }
+        },
+        "AttributeResponseDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "name",
+                "values"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AttributeValueResponseDto"
+                    }
+                }
+            }
+        },
+        "AttributeValueResponseDto": {
+            "type": "object",
+            "required": [
+                "id",
+                "value"
+            ],
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "BundleComponentData": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "variantId"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "variantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "BundleComponentResponseDto": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "variantId"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "variantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CacheKeyResponseDto": {
+            "type": "object",
+            "required": [
+                "key",
+                "ttl"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "TTL is in seconds, -1 when the key does not expire",
+                    "type": "integer"
+                }
+            }
+        },
+        "CacheKeysResponseDto": {
+            "type": "object",
+            "required": [
+                "data",
+                "nextCursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CacheKeyResponseDto"
+                    }
+                },
+                "nextCursor": {
+                    "description": "NextCursor continues the scan, 0 once it is complete",
+                    "type": "integer"
+                }
+            }
+        },
+        "CartItemProductResponseDto": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "price",
+                "rating"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "number"
+                }
+            }
+        },
+        "CartItemProductVariantResponseDto": {
+            "type": "object",
+            "required": [
+                "compareAtPrice",
+                "id",
+                "images",
+                "price",
+                "quantity",
+                "sku"
+            ],
+            "properties": {
+                "compareAtPrice": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductImageResponseDto"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "CartItemResponseDto": {
+            "type": "object",
+            "required": [
+                "id",
+                "product",
+                "productVariant",
+                "quantity"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/CartItemProductResponseDto"
+                },
+                "productVariant": {
+                    "$ref": "#/definitions/CartItemProductVariantResponseDto"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CartResponseDto": {
+            "type": "object",
+            "required": [
+                "id",
+                "items",
+                "updatedAt",
+                "userId"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CartItemResponseDto"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CategoryResponseDto": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "name",
+                "updatedAt"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChangeEntity": {
+            "type": "string",
+            "enum": [
+                "product",
+                "product_variant",
+                "category",
+                "attribute",
+                "spec_group"
+            ],
+            "x-enum-varnames": [
+                "ChangeEntityProduct",
+                "ChangeEntityProductVariant",
+                "ChangeEntityCategory",
+                "ChangeEntityAttribute",
+                "ChangeEntitySpecGroup"
+            ]
+        },
+        "CreateAttributeData": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateAttributeValueData": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateCartData": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateCartItemData": {
+            "type": "object",
+            "required": [
+                "productId",
+                "productVariantId",
+                "quantity"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string"
+                },
+                "productVariantId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateCategoryData": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateOrderData": {
+            "type": "object",
+            "required": [
+                "address",
+                "items",
+                "phoneNumber",
+                "provider",
+                "recipientName",
+                "userId"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateOrderItemData"
+                    }
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "provider": {
+                    "$ref": "#/definitions/OrderProvider"
+                },
+                "recipientName": {
+                    "type": "string"
+                },
+                "returnUrl": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateOrderItemData": {
+            "type": "object",
+            "required": [
+                "productId",
+                "productVariantId",
+                "quantity"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string"
+                },
+                "productVariantId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateProductAttributesData": {
+            "type": "object",
+            "required": [
+                "attributeId",
+                "valueId"
+            ],
+            "properties": {
+                "attributeId": {
+                    "type": "string"
+                },
+                "valueId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateProductData": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "description",
+                "images",
+                "name",
+                "variants"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateProductAttributesData"
+                    }
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateProductImageData"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateProductOptionData"
+                    }
+                },
+                "publishAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status defaults to draft, see UpdateProductPublishingData",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ProductStatus"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "Type defaults to standard, the variants of a bundle are given components\ninstead of a quantity",
+                    "enum": [
+                        "standard",
+                        "bundle"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ProductType"
+                        }
+                    ]
+                },
+                "unpublishAt": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateProductVariantData"
+                    }
+                }
+            }
+        },
+        "CreateProductImageData": {
+            "type": "object",
+            "required": [
+                "key",
+                "order"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateProductOptionData": {
+            "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "CreateProductVariantData": {
+            "type": "object",
+            "required": [
+                "price",
+                "sku"
+            ],
+            "properties": {
+                "compareAtPrice": {
+                    "type": "integer"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BundleComponentData"
+                    }
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateProductVariantImage"
+                    }
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateProductVariantOption"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "warehouseId": {
+                    "description": "WarehouseID is where the initial quantity is stocked, when omitted the\nvariant is not stocked at any warehouse",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateProductVariantImage": {
+            "type": "object",
+            "required": [
+                "key",
+                "order"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateProductVariantOption": {
+            "type": "object",
+            "required": [
+                "name",
+                "value"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateSpecData": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "type": {
+                    "enum": [
+                        "number",
+                        "boolean",
+                        "enum"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/SpecType"
+                        }
+                    ]
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateSpecGroupData": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateSpecData"
+                    }
+                }
+            }
+        },
+        "CreateStockMovementData": {
+            "type": "object",
+            "required": [
+                "kind",
+                "quantity"
+            ],
+            "properties": {
+                "kind": {
+                    "enum": [
+                        "restock",
+                        "return",
+                        "adjustment"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/StockMovementKind"
+                        }
+                    ]
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouseId": {
+                    "description": "WarehouseID defaults to the warehouse holding most of the variant",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateWarehouseData": {
+            "type": "object",
+            "required": [
+                "name",
+                "province"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "province": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateWebhookData": {
+            "type": "object",
+            "required": [
+                "eventTypes",
+                "url"
+            ],
+            "properties": {
+                "eventTypes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/EventType"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "DeleteImageURLResponseDto": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "EmailKind": {
+            "type": "string",
+            "enum": [
+                "order_placed",
+                "order_paid",
+                "order_shipped",
+                "order_delivered",
+                "order_cancelled",
+                "order_payment_expired",
+                "refund_processed"
+            ],
+            "x-enum-varnames": [
+                "EmailKindOrderPlaced",
+                "EmailKindOrderPaid",
+                "EmailKindOrderShipped",
+                "EmailKindOrderDelivered",
+                "EmailKindOrderCancelled",
+                "EmailKindOrderPaymentExpired",
+                "EmailKindRefundProcessed"
+            ]
+        },
+        "EmailResponseDto": {
+            "type": "object",
+            "required": [
+                "attempts",
+                "createdAt",
+                "error",
+                "eventId",
+                "id",
+                "kind",
+                "locale",
+                "nextAttemptAt",
+                "recipient",
+                "status",
+                "subject",
+                "updatedAt",
+                "userId"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/EmailKind"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "sentAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/EmailStatus"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "EmailStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "EmailStatusPending",
+                "EmailStatusSent",
+                "EmailStatusFailed"
+            ]
+        },
+        "Error": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "AddProductVariantsData": {
+        "EventType": {
+            "type": "string",
+            "enum": [
+                "order.created",
+                "order.paid",
+                "order.status_changed",
+                "order.refund_due",
+                "product.created",
+                "product.updated",
+                "product.deleted",
+                "stock.changed",
+                "refund.processed"
+            ],
+            "x-enum-varnames": [
+                "EventTypeOrderCreated",
+                "EventTypeOrderPaid",
+                "EventTypeOrderStatusChanged",
+                "EventTypeOrderRefundDue",
+                "EventTypeProductCreated",
+                "EventTypeProductUpdated",
+                "EventTypeProductDeleted",
+                "EventTypeStockChanged",
+                "EventTypeRefundProcessed"
+            ]
+        },
+        "InvalidateCacheData": {
             "type": "object",
             "required": [
-                "price",
-                "quantity",
-                "sku"
+                "entity"
             ],
             "properties": {
-                "optionValueIds": {
+                "entity": {
+                    "enum": [
+                        "product",
+                        "product_variant",
+                        "category",
+                        "attribute",
+                        "spec_group"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ChangeEntity"
+                        }
+                    ]
+                },
+                "ids": {
+                    "description": "IDs are the entities to invalidate, every entity of the type when empty",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "price": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "sku": {
-                    "type": "string"
+                "listing": {
+                    "description": "Listing also invalidates the listings of the type, as after a create",
+                    "type": "boolean"
                 }
             }
         },
-        "AttributeResponseDto": {
+        "NotificationKind": {
+            "type": "string",
+            "enum": [
+                "low_stock",
+                "back_in_stock"
+            ],
+            "x-enum-varnames": [
+                "NotificationKindLowStock",
+                "NotificationKindBackInStock"
+            ]
+        },
+        "NotificationResponseDto": {
             "type": "object",
             "required": [
-                "code",
+                "createdAt",
                 "id",
-                "name",
-                "values"
+                "kind",
+                "quantity"
             ],
             "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "deletedAt": {
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "kind": {
+                    "$ref": "#/definitions/NotificationKind"
                 },
-                "values": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/AttributeValueResponseDto"
-                    }
-                }
-            }
-        },
-        "AttributeValueResponseDto": {
-            "type": "object",
-            "required": [
-                "id",
-                "value"
-            ],
-            "properties": {
-                "deletedAt": {
+                "productId": {
                     "type": "string"
                 },
-                "id": {
+                "productVariantId": {
                     "type": "string"
                 },
-                "value": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "readAt": {
                     "type": "string"
                 }
             }
         },
-        "CartItemProductResponseDto": {
+        "OrderItemProductResponseDto": {
             "type": "object",
             "required": [
                 "id",
@@ -2584,40 +6991,26 @@ const docTemplate = `{
                 }
             }
         },
-        "CartItemProductVariantResponseDto": {
+        "OrderItemProductVariantResponseDto": {
             "type": "object",
             "required": [
                 "id",
-                "images",
-                "price",
-                "quantity",
                 "sku"
             ],
             "properties": {
                 "id": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductImageResponseDto"
-                    }
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
                 "sku": {
                     "type": "string"
                 }
             }
         },
-        "CartItemResponseDto": {
+        "OrderItemResponseDto": {
             "type": "object",
             "required": [
                 "id",
+                "price",
                 "product",
                 "productVariant",
                 "quantity"
@@ -2626,634 +7019,702 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "price": {
+                    "type": "integer"
+                },
                 "product": {
-                    "$ref": "#/definitions/CartItemProductResponseDto"
+                    "$ref": "#/definitions/OrderItemProductResponseDto"
                 },
                 "productVariant": {
-                    "$ref": "#/definitions/CartItemProductVariantResponseDto"
+                    "$ref": "#/definitions/OrderItemProductVariantResponseDto"
                 },
                 "quantity": {
                     "type": "integer"
+                },
+                "warehouseId": {
+                    "type": "string"
                 }
             }
         },
-        "CartResponseDto": {
+        "OrderProvider": {
+            "type": "string",
+            "enum": [
+                "COD",
+                "VNPAY",
+                "MOMO",
+                "ZALOPAY"
+            ],
+            "x-enum-varnames": [
+                "PaymentProviderCOD",
+                "PaymentProviderVNPAY",
+                "PaymentProviderMOMO",
+                "PaymentProviderZALOPAY"
+            ]
+        },
+        "OrderResponseDto": {
             "type": "object",
             "required": [
+                "address",
+                "created_at",
                 "id",
-                "items",
-                "updatedAt",
-                "userId"
+                "is_paid",
+                "phone_number",
+                "provider",
+                "recipent_name",
+                "status",
+                "total_amount",
+                "updated_at",
+                "user_id"
             ],
             "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "is_paid": {
+                    "type": "boolean"
+                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/CartItemResponseDto"
+                        "$ref": "#/definitions/OrderItemResponseDto"
                     }
                 },
-                "updatedAt": {
+                "payment_url": {
                     "type": "string"
                 },
-                "userId": {
-                    "type": "string"
-                }
-            }
-        },
-        "CategoryResponseDto": {
-            "type": "object",
-            "required": [
-                "createdAt",
-                "id",
-                "name",
-                "updatedAt"
-            ],
-            "properties": {
-                "createdAt": {
+                "phone_number": {
                     "type": "string"
                 },
-                "deletedAt": {
-                    "type": "string"
+                "provider": {
+                    "$ref": "#/definitions/OrderProvider"
                 },
-                "id": {
+                "recipent_name": {
                     "type": "string"
                 },
-                "name": {
+                "status": {
+                    "$ref": "#/definitions/OrderStatus"
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "CreateAttributeData": {
-            "type": "object",
-            "required": [
-                "code",
-                "name"
+        "OrderStatus": {
+            "type": "string",
+            "enum": [
+                "Pending",
+                "Processing",
+                "Shipping",
+                "Delivered",
+                "Cancelled"
             ],
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
+            "x-enum-varnames": [
+                "OrderStatusPending",
+                "OrderStatusProcessing",
+                "OrderStatusShipping",
+                "OrderStatusDelivered",
+                "OrderStatusCancelled"
+            ]
         },
-        "CreateAttributeValueData": {
+        "PaginationMetaResponseDto": {
             "type": "object",
             "required": [
-                "value"
+                "currentPage",
+                "itemsPerPage",
+                "totalItems",
+                "totalPages"
             ],
             "properties": {
-                "value": {
+                "currentPage": {
+                    "type": "integer"
+                },
+                "itemsPerPage": {
+                    "type": "integer"
+                },
+                "nextCursor": {
                     "type": "string"
+                },
+                "pageItems": {
+                    "type": "integer"
+                },
+                "totalItems": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
                 }
             }
         },
-        "CreateCartData": {
+        "PaginationResponseDto-AttributeResponseDto": {
             "type": "object",
             "required": [
-                "userId"
+                "data",
+                "meta"
             ],
             "properties": {
-                "userId": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AttributeResponseDto"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateCartItemData": {
+        "PaginationResponseDto-AttributeValueResponseDto": {
             "type": "object",
             "required": [
-                "productId",
-                "productVariantId",
-                "quantity"
+                "data",
+                "meta"
             ],
             "properties": {
-                "productId": {
-                    "type": "string"
-                },
-                "productVariantId": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AttributeValueResponseDto"
+                    }
                 },
-                "quantity": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateCategoryData": {
+        "PaginationResponseDto-ProductResponseDto": {
             "type": "object",
             "required": [
-                "name"
+                "data",
+                "meta"
             ],
             "properties": {
-                "name": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductResponseDto"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateOrderData": {
+        "PaginationResponseDto-internal_delivery_http_CategoryResponseDto": {
             "type": "object",
             "required": [
-                "address",
-                "items",
-                "phoneNumber",
-                "provider",
-                "recipientName",
-                "userId"
+                "data",
+                "meta"
             ],
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "items": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/CreateOrderItemData"
+                        "$ref": "#/definitions/CategoryResponseDto"
                     }
                 },
-                "phoneNumber": {
-                    "type": "string"
-                },
-                "provider": {
-                    "$ref": "#/definitions/OrderProvider"
-                },
-                "recipientName": {
-                    "type": "string"
-                },
-                "returnUrl": {
-                    "type": "string"
-                },
-                "userId": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateOrderItemData": {
+        "PaginationResponseDto-internal_delivery_http_EmailResponseDto": {
             "type": "object",
             "required": [
-                "productId",
-                "productVariantId",
-                "quantity"
+                "data",
+                "meta"
             ],
             "properties": {
-                "productId": {
-                    "type": "string"
-                },
-                "productVariantId": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/EmailResponseDto"
+                    }
                 },
-                "quantity": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateProductAttributesData": {
+        "PaginationResponseDto-internal_delivery_http_NotificationResponseDto": {
             "type": "object",
             "required": [
-                "attributeId",
-                "valueId"
+                "data",
+                "meta"
             ],
             "properties": {
-                "attributeId": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/NotificationResponseDto"
+                    }
                 },
-                "valueId": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateProductData": {
+        "PaginationResponseDto-internal_delivery_http_SpecGroupResponseDto": {
             "type": "object",
             "required": [
-                "categoryId",
-                "description",
-                "images",
-                "name",
-                "variants"
+                "data",
+                "meta"
             ],
             "properties": {
-                "attributes": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/CreateProductAttributesData"
+                        "$ref": "#/definitions/SpecGroupResponseDto"
                     }
                 },
-                "categoryId": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "images": {
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
+                }
+            }
+        },
+        "PaginationResponseDto-internal_delivery_http_StockMovementResponseDto": {
+            "type": "object",
+            "required": [
+                "data",
+                "meta"
+            ],
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/CreateProductImageData"
+                        "$ref": "#/definitions/StockMovementResponseDto"
                     }
                 },
-                "name": {
-                    "type": "string"
-                },
-                "options": {
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
+                }
+            }
+        },
+        "PaginationResponseDto-internal_delivery_http_TaskResponseDto": {
+            "type": "object",
+            "required": [
+                "data",
+                "meta"
+            ],
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/CreateProductOptionData"
+                        "$ref": "#/definitions/TaskResponseDto"
                     }
                 },
-                "variants": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/CreateProductVariantData"
-                    }
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateProductImageData": {
+        "PaginationResponseDto-internal_delivery_http_WarehouseResponseDto": {
             "type": "object",
             "required": [
-                "key",
-                "order"
+                "data",
+                "meta"
             ],
             "properties": {
-                "key": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/WarehouseResponseDto"
+                    }
                 },
-                "order": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateProductOptionData": {
+        "PaginationResponseDto-internal_delivery_http_WebhookDeliveryResponseDto": {
             "type": "object",
             "required": [
-                "name",
-                "values"
+                "data",
+                "meta"
             ],
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "values": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/WebhookDeliveryResponseDto"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
                 }
             }
         },
-        "CreateProductVariantData": {
+        "PaginationResponseDto-internal_delivery_http_WebhookResponseDto": {
             "type": "object",
             "required": [
-                "price",
-                "quantity",
-                "sku"
+                "data",
+                "meta"
             ],
             "properties": {
-                "images": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/CreateProductVariantImage"
+                        "$ref": "#/definitions/WebhookResponseDto"
                     }
                 },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/CreateProductVariantOption"
-                    }
+                "meta": {
+                    "$ref": "#/definitions/PaginationMetaResponseDto"
+                }
+            }
+        },
+        "ProductAttributeResponseDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "name",
+                "value"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "integer"
+                "deletedAt": {
+                    "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "sku": {
+                "name": {
                     "type": "string"
+                },
+                "value": {
+                    "$ref": "#/definitions/ProductAttributeValueResponseDto"
                 }
             }
         },
-        "CreateProductVariantImage": {
+        "ProductAttributeValueResponseDto": {
             "type": "object",
             "required": [
-                "key",
-                "order"
+                "id",
+                "value"
             ],
             "properties": {
-                "key": {
+                "deletedAt": {
                     "type": "string"
                 },
-                "order": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
                 }
             }
         },
-        "CreateProductVariantOption": {
+        "ProductCategoryResponseDto": {
             "type": "object",
             "required": [
+                "createdAt",
+                "id",
                 "name",
-                "value"
+                "updatedAt"
             ],
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
-                "value": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "DeleteImageURLResponseDto": {
+        "ProductComparisonResponseDto": {
+            "type": "object",
+            "required": [
+                "groups",
+                "products"
+            ],
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SpecComparisonGroupResponseDto"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductResponseDto"
+                    }
+                }
+            }
+        },
+        "ProductImageRenditionResponseDto": {
             "type": "object",
             "required": [
-                "url"
+                "height",
+                "url",
+                "width"
             ],
             "properties": {
+                "height": {
+                    "type": "integer"
+                },
                 "url": {
                     "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "Error": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "OrderItemProductResponseDto": {
+        "ProductImageResponseDto": {
             "type": "object",
             "required": [
+                "createdAt",
                 "id",
-                "name",
-                "price",
-                "rating"
+                "order",
+                "renditions",
+                "url"
             ],
             "properties": {
-                "id": {
+                "createdAt": {
                     "type": "string"
                 },
-                "name": {
+                "deletedAt": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "rating": {
-                    "type": "number"
+                "order": {
+                    "type": "integer"
+                },
+                "renditions": {
+                    "description": "Renditions are WebP copies of the image ordered by width, meant for\nsrcset, an image smaller than a width has no rendition for it",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductImageRenditionResponseDto"
+                    }
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "OrderItemProductVariantResponseDto": {
+        "ProductOptionResponseDto": {
             "type": "object",
             "required": [
                 "id",
-                "sku"
+                "name",
+                "values"
             ],
             "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "sku": {
+                "name": {
                     "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductOptionValueResponseDto"
+                    }
                 }
             }
         },
-        "OrderItemResponseDto": {
+        "ProductOptionValueResponseDto": {
             "type": "object",
             "required": [
                 "id",
-                "price",
-                "product",
-                "productVariant",
-                "quantity"
+                "value"
             ],
             "properties": {
-                "id": {
+                "deletedAt": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "integer"
-                },
-                "product": {
-                    "$ref": "#/definitions/OrderItemProductResponseDto"
-                },
-                "productVariant": {
-                    "$ref": "#/definitions/OrderItemProductVariantResponseDto"
+                "id": {
+                    "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "value": {
+                    "type": "string"
                 }
             }
         },
-        "OrderProvider": {
-            "type": "string",
-            "enum": [
-                "COD",
-                "VNPAY",
-                "MOMO",
-                "ZALOPAY"
-            ],
-            "x-enum-varnames": [
-                "PaymentProviderCOD",
-                "PaymentProviderVNPAY",
-                "PaymentProviderMOMO",
-                "PaymentProviderZALOPAY"
-            ]
-        },
-        "OrderResponseDto": {
+        "ProductResponseDto": {
             "type": "object",
             "required": [
-                "address",
-                "created_at",
+                "attributes",
+                "category",
+                "createdAt",
+                "description",
+                "discount",
                 "id",
-                "is_paid",
-                "phone_number",
-                "provider",
-                "recipent_name",
+                "images",
+                "name",
+                "options",
+                "price",
+                "rating",
+                "specs",
                 "status",
-                "total_amount",
-                "updated_at",
-                "user_id"
+                "totalPurchase",
+                "type",
+                "updatedAt",
+                "variants",
+                "viewsCount"
             ],
             "properties": {
-                "address": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductAttributeResponseDto"
+                    }
+                },
+                "category": {
+                    "$ref": "#/definitions/ProductCategoryResponseDto"
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "created_at": {
+                "deletedAt": {
                     "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "is_paid": {
-                    "type": "boolean"
+                "discount": {
+                    "type": "integer"
                 },
-                "items": {
+                "id": {
+                    "type": "string"
+                },
+                "images": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/OrderItemResponseDto"
+                        "$ref": "#/definitions/ProductImageResponseDto"
                     }
                 },
-                "payment_url": {
+                "name": {
                     "type": "string"
                 },
-                "phone_number": {
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductOptionResponseDto"
+                    }
                 },
-                "provider": {
-                    "$ref": "#/definitions/OrderProvider"
+                "price": {
+                    "type": "number"
                 },
-                "recipent_name": {
+                "publishAt": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/OrderStatus"
-                },
-                "total_amount": {
-                    "type": "integer"
+                "rating": {
+                    "type": "number"
                 },
-                "updated_at": {
-                    "type": "string"
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductSpecResponseDto"
+                    }
                 },
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "OrderStatus": {
-            "type": "string",
-            "enum": [
-                "Pending",
-                "Processing",
-                "Shipping",
-                "Delivered",
-                "Cancelled"
-            ],
-            "x-enum-varnames": [
-                "OrderStatusPending",
-                "OrderStatusProcessing",
-                "OrderStatusShipping",
-                "OrderStatusDelivered",
-                "OrderStatusCancelled"
-            ]
-        },
-        "PaginationMetaResponseDto": {
-            "type": "object",
-            "required": [
-                "currentPage",
-                "itemsPerPage",
-                "totalItems",
-                "totalPages"
-            ],
-            "properties": {
-                "currentPage": {
-                    "type": "integer"
+                "status": {
+                    "$ref": "#/definitions/ProductStatus"
                 },
-                "itemsPerPage": {
+                "totalPurchase": {
                     "type": "integer"
                 },
-                "pageItems": {
-                    "type": "integer"
+                "type": {
+                    "$ref": "#/definitions/ProductType"
                 },
-                "totalItems": {
-                    "type": "integer"
+                "unpublishAt": {
+                    "type": "string"
                 },
-                "totalPages": {
-                    "type": "integer"
-                }
-            }
-        },
-        "PaginationResponseDto-AttributeResponseDto": {
-            "type": "object",
-            "required": [
-                "data",
-                "meta"
-            ],
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/AttributeResponseDto"
-                    }
+                "updatedAt": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/PaginationMetaResponseDto"
-                }
-            }
-        },
-        "PaginationResponseDto-AttributeValueResponseDto": {
-            "type": "object",
-            "required": [
-                "data",
-                "meta"
-            ],
-            "properties": {
-                "data": {
+                "variants": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/AttributeValueResponseDto"
+                        "$ref": "#/definitions/ProductVariantResponseDto"
                     }
                 },
-                "meta": {
-                    "$ref": "#/definitions/PaginationMetaResponseDto"
+                "viewsCount": {
+                    "type": "integer"
                 }
             }
         },
-        "PaginationResponseDto-ProductResponseDto": {
+        "ProductSpecData": {
             "type": "object",
-            "required": [
-                "data",
-                "meta"
+            "required": [
+                "specId"
             ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductResponseDto"
-                    }
+                "boolean": {
+                    "type": "boolean"
                 },
-                "meta": {
-                    "$ref": "#/definitions/PaginationMetaResponseDto"
+                "enum": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "number"
+                },
+                "specId": {
+                    "type": "string"
                 }
             }
         },
-        "PaginationResponseDto-internal_delivery_http_CategoryResponseDto": {
+        "ProductSpecGroupResponseDto": {
             "type": "object",
             "required": [
-                "data",
-                "meta"
+                "id",
+                "name"
             ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/CategoryResponseDto"
-                    }
+                "id": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/PaginationMetaResponseDto"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "ProductAttributeResponseDto": {
+        "ProductSpecResponseDto": {
             "type": "object",
             "required": [
                 "code",
+                "group",
                 "id",
                 "name",
+                "type",
+                "unit",
                 "value"
             ],
             "properties": {
                 "code": {
                     "type": "string"
                 },
-                "deletedAt": {
-                    "type": "string"
+                "group": {
+                    "$ref": "#/definitions/ProductSpecGroupResponseDto"
                 },
                 "id": {
                     "type": "string"
@@ -3261,90 +7722,184 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "type": {
+                    "$ref": "#/definitions/SpecType"
+                },
+                "unit": {
+                    "type": "string"
+                },
                 "value": {
-                    "$ref": "#/definitions/ProductAttributeValueResponseDto"
+                    "$ref": "#/definitions/SpecValueResponseDto"
                 }
             }
         },
-        "ProductAttributeValueResponseDto": {
+        "ProductStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "published",
+                "archived"
+            ],
+            "x-enum-varnames": [
+                "ProductStatusDraft",
+                "ProductStatusPublished",
+                "ProductStatusArchived"
+            ]
+        },
+        "ProductSuggestionResponseDto": {
             "type": "object",
             "required": [
                 "id",
-                "value"
+                "text",
+                "type"
             ],
             "properties": {
-                "deletedAt": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "text": {
                     "type": "string"
                 },
-                "value": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "ProductCategoryResponseDto": {
+        "ProductType": {
+            "type": "string",
+            "enum": [
+                "standard",
+                "bundle"
+            ],
+            "x-enum-varnames": [
+                "ProductTypeStandard",
+                "ProductTypeBundle"
+            ]
+        },
+        "ProductVariantResponseDto": {
             "type": "object",
             "required": [
+                "compareAtPrice",
+                "components",
                 "createdAt",
+                "effectivePrice",
                 "id",
-                "name",
+                "images",
+                "lowStockThreshold",
+                "optionValues",
+                "price",
+                "purchaseCount",
+                "quantity",
+                "salePrice",
+                "sku",
+                "stocks",
                 "updatedAt"
             ],
             "properties": {
+                "compareAtPrice": {
+                    "type": "integer"
+                },
+                "components": {
+                    "description": "Components are only set for the variants of a bundle, its quantity and\nstocks are derived from them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BundleComponentResponseDto"
+                    }
+                },
                 "createdAt": {
                     "type": "string"
                 },
                 "deletedAt": {
                     "type": "string"
                 },
+                "effectivePrice": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductImageResponseDto"
+                    }
+                },
+                "lowStockThreshold": {
+                    "type": "integer"
+                },
+                "optionValues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductOptionValueResponseDto"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "purchaseCount": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "saleEndsAt": {
                     "type": "string"
                 },
+                "salePrice": {
+                    "type": "integer"
+                },
+                "saleStartsAt": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/WarehouseStockResponseDto"
+                    }
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "ProductImageResponseDto": {
+        "SpecComparisonGroupResponseDto": {
             "type": "object",
             "required": [
-                "createdAt",
                 "id",
-                "order",
-                "url"
+                "name",
+                "rows"
             ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
-                "order": {
-                    "type": "integer"
-                },
-                "url": {
+                "name": {
                     "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SpecComparisonRowResponseDto"
+                    }
                 }
             }
         },
-        "ProductOptionResponseDto": {
+        "SpecComparisonRowResponseDto": {
             "type": "object",
             "required": [
+                "code",
                 "id",
                 "name",
+                "same",
+                "type",
+                "unit",
                 "values"
             ],
             "properties": {
-                "deletedAt": {
+                "code": {
                     "type": "string"
                 },
                 "id": {
@@ -3353,162 +7908,252 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "same": {
+                    "description": "Same is set when every product has the same value",
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/SpecType"
+                },
+                "unit": {
+                    "type": "string"
+                },
                 "values": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ProductOptionValueResponseDto"
+                        "$ref": "#/definitions/SpecValueResponseDto"
                     }
                 }
             }
         },
-        "ProductOptionValueResponseDto": {
+        "SpecGroupResponseDto": {
             "type": "object",
             "required": [
+                "createdAt",
                 "id",
-                "value"
+                "name",
+                "order",
+                "specs",
+                "updatedAt"
             ],
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
                 "deletedAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "value": {
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SpecResponseDto"
+                    }
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "ProductResponseDto": {
+        "SpecResponseDto": {
             "type": "object",
             "required": [
-                "attributes",
-                "category",
-                "createdAt",
-                "description",
+                "choices",
+                "code",
                 "id",
-                "images",
                 "name",
-                "options",
-                "price",
-                "rating",
-                "totalPurchase",
-                "updatedAt",
-                "variants",
-                "viewsCount"
+                "order",
+                "type",
+                "unit"
             ],
             "properties": {
-                "attributes": {
+                "choices": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ProductAttributeResponseDto"
+                        "type": "string"
                     }
                 },
-                "category": {
-                    "$ref": "#/definitions/ProductCategoryResponseDto"
-                },
-                "createdAt": {
+                "code": {
                     "type": "string"
                 },
                 "deletedAt": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductImageResponseDto"
-                    }
+                "order": {
+                    "type": "integer"
                 },
-                "name": {
+                "type": {
+                    "$ref": "#/definitions/SpecType"
+                },
+                "unit": {
                     "type": "string"
+                }
+            }
+        },
+        "SpecType": {
+            "type": "string",
+            "enum": [
+                "number",
+                "boolean",
+                "enum"
+            ],
+            "x-enum-varnames": [
+                "SpecTypeNumber",
+                "SpecTypeBoolean",
+                "SpecTypeEnum"
+            ]
+        },
+        "SpecValueResponseDto": {
+            "type": "object",
+            "properties": {
+                "boolean": {
+                    "type": "boolean"
                 },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductOptionResponseDto"
-                    }
+                "enum": {
+                    "type": "string"
                 },
-                "price": {
+                "number": {
                     "type": "number"
+                }
+            }
+        },
+        "StockMovementKind": {
+            "type": "string",
+            "enum": [
+                "restock",
+                "sale",
+                "cancellation",
+                "return",
+                "adjustment"
+            ],
+            "x-enum-varnames": [
+                "StockMovementKindRestock",
+                "StockMovementKindSale",
+                "StockMovementKindCancellation",
+                "StockMovementKindReturn",
+                "StockMovementKindAdjustment"
+            ]
+        },
+        "StockMovementResponseDto": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "kind",
+                "productVariantId",
+                "quantity",
+                "quantityAfter"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "string"
                 },
-                "rating": {
-                    "type": "number"
+                "createdAt": {
+                    "type": "string"
                 },
-                "totalPurchase": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "kind": {
+                    "$ref": "#/definitions/StockMovementKind"
+                },
+                "note": {
                     "type": "string"
                 },
-                "variants": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductVariantResponseDto"
-                    }
+                "orderId": {
+                    "type": "string"
                 },
-                "viewsCount": {
+                "productVariantId": {
+                    "type": "string"
+                },
+                "quantity": {
                     "type": "integer"
+                },
+                "quantityAfter": {
+                    "type": "integer"
+                },
+                "warehouseId": {
+                    "type": "string"
                 }
             }
         },
-        "ProductVariantResponseDto": {
+        "TaskResponseDto": {
             "type": "object",
             "required": [
+                "attempts",
                 "createdAt",
+                "error",
                 "id",
-                "images",
-                "optionValues",
-                "price",
-                "purchaseCount",
-                "quantity",
-                "sku",
+                "key",
+                "kind",
+                "payload",
+                "runAt",
+                "status",
                 "updatedAt"
             ],
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
                 "createdAt": {
                     "type": "string"
                 },
-                "deletedAt": {
+                "error": {
                     "type": "string"
                 },
-                "id": {
+                "finishedAt": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductImageResponseDto"
-                    }
-                },
-                "optionValues": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ProductOptionValueResponseDto"
-                    }
+                "id": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "integer"
+                "key": {
+                    "type": "string"
                 },
-                "purchaseCount": {
-                    "type": "integer"
+                "kind": {
+                    "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "payload": {
+                    "type": "object"
                 },
-                "sku": {
+                "runAt": {
                     "type": "string"
                 },
+                "status": {
+                    "$ref": "#/definitions/TaskStatus"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
+        "TaskStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "TaskStatusPending",
+                "TaskStatusSucceeded",
+                "TaskStatusDead"
+            ]
+        },
         "UpdateAttributeData": {
             "type": "object",
             "properties": {
@@ -3588,9 +8233,68 @@ const docTemplate = `{
                 }
             }
         },
+        "UpdateProductPublishingData": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "publishAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ProductStatus"
+                        }
+                    ]
+                },
+                "unpublishAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "UpdateProductSpecsData": {
+            "type": "object",
+            "required": [
+                "specs"
+            ],
+            "properties": {
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProductSpecData"
+                    }
+                }
+            }
+        },
+        "UpdateProductVariantComponentsData": {
+            "type": "object",
+            "required": [
+                "components"
+            ],
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/BundleComponentData"
+                    }
+                }
+            }
+        },
         "UpdateProductVariantData": {
             "type": "object",
             "properties": {
+                "lowStockThreshold": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "price": {
                     "type": "integer"
                 },
@@ -3599,6 +8303,95 @@ const docTemplate = `{
                 }
             }
         },
+        "UpdateProductVariantPricingData": {
+            "type": "object",
+            "properties": {
+                "compareAtPrice": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "saleEndsAt": {
+                    "type": "string"
+                },
+                "salePrice": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "saleStartsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "UpdateSpecData": {
+            "type": "object",
+            "properties": {
+                "choices": {
+                    "description": "Choices replaces the choices of an enum, existing ones must be kept",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "UpdateSpecGroupData": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "UpdateWarehouseData": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "province": {
+                    "type": "string"
+                }
+            }
+        },
+        "UpdateWebhookData": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/EventType"
+                    }
+                },
+                "rotateSecret": {
+                    "description": "RotateSecret replaces the signing secret, the partner has to switch to\nthe returned one",
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "UploadImageURLResponseDto": {
             "type": "object",
             "required": [
@@ -3628,6 +8421,173 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "WarehouseResponseDto": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "name",
+                "priority",
+                "province",
+                "updatedAt"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "province": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "WarehouseStockResponseDto": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "warehouseId"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "WebhookDeliveryResponseDto": {
+            "type": "object",
+            "required": [
+                "attempts",
+                "createdAt",
+                "error",
+                "eventId",
+                "eventType",
+                "id",
+                "nextAttemptAt",
+                "payload",
+                "responseBody",
+                "responseCode",
+                "status",
+                "updatedAt",
+                "webhookId"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "$ref": "#/definitions/EventType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is the body sent to the webhook",
+                    "type": "object"
+                },
+                "responseBody": {
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/WebhookDeliveryStatus"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookId": {
+                    "type": "string"
+                }
+            }
+        },
+        "WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryStatusPending",
+                "WebhookDeliveryStatusSucceeded",
+                "WebhookDeliveryStatusFailed"
+            ]
+        },
+        "WebhookResponseDto": {
+            "type": "object",
+            "required": [
+                "active",
+                "createdAt",
+                "eventTypes",
+                "id",
+                "secret",
+                "updatedAt",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/EventType"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret signs the deliveries, partners verify the Webhook-Signature\nheader with it. It is only revealed on create and on rotation,\notherwise it is masked down to its last 4 characters",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
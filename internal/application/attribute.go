@@ -11,13 +11,15 @@ type Attribute struct {
 	attributeRepo    domain.AttributeRepository
 	attributeService domain.AttributeService
 	attributeCache   AttributeCache
+	changeHandler    ChangeEventHandler
 }
 
-func ProvideAttribute(attributeRepo domain.AttributeRepository, attributeService domain.AttributeService, attributeCache AttributeCache) *Attribute {
+func ProvideAttribute(attributeRepo domain.AttributeRepository, attributeService domain.AttributeService, attributeCache AttributeCache, changeHandler ChangeEventHandler) *Attribute {
 	return &Attribute{
 		attributeRepo:    attributeRepo,
 		attributeService: attributeService,
 		attributeCache:   attributeCache,
+		changeHandler:    changeHandler,
 	}
 }
 
@@ -36,7 +38,7 @@ func (a *Attribute) Create(ctx context.Context, param http.CreateAttributeReques
 		return nil, err
 	}
 
-	_ = a.attributeCache.InvalidateAlls(ctx)
+	_ = a.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityAttribute, domain.ChangeKindListing, attribute.ID))
 
 	return http.ToAttributeResponseDto(attribute), nil
 }
@@ -59,7 +61,7 @@ func (a *Attribute) CreateValue(ctx context.Context, param http.CreateAttributeV
 		return nil, err
 	}
 
-	_ = a.attributeCache.InvalidateAlls(ctx)
+	_ = a.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityAttribute, domain.ChangeKindListing, attribute.ID))
 
 	return http.ToAttributeValueResponseDto(attributeValue), nil
 }
@@ -199,7 +201,7 @@ func (a *Attribute) Update(ctx context.Context, param http.UpdateAttributeReques
 	if err != nil {
 		return nil, err
 	}
-	_ = a.attributeCache.InvalidateAlls(ctx)
+	_ = a.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityAttribute, domain.ChangeKindListing, attribute.ID))
 	return http.ToAttributeResponseDto(attribute), nil
 }
 
@@ -222,7 +224,7 @@ func (a *Attribute) UpdateValue(ctx context.Context, param http.UpdateAttributeV
 	if attributeValue == nil {
 		return nil, domain.ErrNotFound
 	}
-	_ = a.attributeCache.InvalidateAlls(ctx)
+	_ = a.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityAttribute, domain.ChangeKindListing, attribute.ID))
 	return http.ToAttributeValueResponseDto(attributeValue), nil
 }
 
@@ -239,7 +241,7 @@ func (a *Attribute) Delete(ctx context.Context, param http.DeleteAttributeReques
 	if err != nil {
 		return err
 	}
-	_ = a.attributeCache.InvalidateAlls(ctx)
+	_ = a.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityAttribute, domain.ChangeKindListing, attribute.ID))
 	return nil
 }
 
@@ -258,6 +260,6 @@ func (a *Attribute) DeleteValue(ctx context.Context, param http.DeleteAttributeV
 	if err != nil {
 		return err
 	}
-	_ = a.attributeCache.InvalidateAlls(ctx)
+	_ = a.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityAttribute, domain.ChangeKindListing, attribute.ID))
 	return nil
 }
@@ -19,7 +19,6 @@ type AttributeCache interface {
 	GetValueList(ctx context.Context, param AttributeCacheValueListParam) (*http.PaginationResponseDto[http.AttributeValueResponseDto], error)
 	SetValueList(ctx context.Context, param AttributeCacheValueListParam, pagination *http.PaginationResponseDto[http.AttributeValueResponseDto]) error
 	InvalidateValueList(ctx context.Context, param AttributeCacheValueListParam) error
-	InvalidateAlls(ctx context.Context) error
 }
 
 type AttributeCacheParam struct {
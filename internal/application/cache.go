@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/delivery/http"
+	"backend/internal/domain"
+)
+
+type Cache struct {
+	cacheInspector CacheInspector
+	changeHandler  ChangeEventHandler
+}
+
+func ProvideCache(cacheInspector CacheInspector, changeHandler ChangeEventHandler) *Cache {
+	return &Cache{
+		cacheInspector: cacheInspector,
+		changeHandler:  changeHandler,
+	}
+}
+
+var _ http.CacheApplication = (*Cache)(nil)
+
+func (c *Cache) ListKeys(ctx context.Context, param http.ListCacheKeysRequestDto) (*http.CacheKeysResponseDto, error) {
+	return c.cacheInspector.ListKeys(ctx, CacheInspectorListKeysParam{
+		Entity: param.Entity,
+		ID:     param.ID,
+		Match:  param.Match,
+		Cursor: param.Cursor,
+		Limit:  param.Limit,
+	})
+}
+
+// Invalidate goes through the change events, so the entries are invalidated
+// the same way as after a write of the entities
+func (c *Cache) Invalidate(ctx context.Context, param http.InvalidateCacheRequestDto) error {
+	kind := domain.ChangeKindContent
+	if param.Data.Listing {
+		kind = domain.ChangeKindListing
+	}
+	return c.changeHandler.Handle(ctx, domain.NewChangeEvent(param.Data.Entity, kind, param.Data.IDs...))
+}
@@ -0,0 +1,24 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/delivery/http"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// CacheInspector lists the cached keys for admins to see what is cached
+type CacheInspector interface {
+	ListKeys(ctx context.Context, param CacheInspectorListKeysParam) (*http.CacheKeysResponseDto, error)
+}
+
+type CacheInspectorListKeysParam struct {
+	// Entity and ID list the keys tagged with them instead of every key
+	Entity domain.ChangeEntity
+	ID     *uuid.UUID
+	Match  string
+	Cursor uint64
+	Limit  int
+}
@@ -11,13 +11,15 @@ type Category struct {
 	categoryRepo    domain.CategoryRepository
 	categoryService domain.CategoryService
 	categoryCache   CategoryCache
+	changeHandler   ChangeEventHandler
 }
 
-func ProvideCategory(categoryRepo domain.CategoryRepository, categoryService domain.CategoryService, categoryCache CategoryCache) *Category {
+func ProvideCategory(categoryRepo domain.CategoryRepository, categoryService domain.CategoryService, categoryCache CategoryCache, changeHandler ChangeEventHandler) *Category {
 	return &Category{
 		categoryRepo:    categoryRepo,
 		categoryService: categoryService,
 		categoryCache:   categoryCache,
+		changeHandler:   changeHandler,
 	}
 }
 
@@ -37,7 +39,7 @@ func (c *Category) Create(ctx context.Context, param http.CreateCategoryRequestD
 		return nil, err
 	}
 
-	_ = c.categoryCache.InvalidateAlls(ctx)
+	_ = c.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityCategory, domain.ChangeKindListing, category.ID))
 
 	return http.ToCategoryResponseDto(category), nil
 }
@@ -125,7 +127,7 @@ func (c *Category) Update(ctx context.Context, param http.UpdateCategoryRequestD
 		return nil, err
 	}
 
-	_ = c.categoryCache.InvalidateAlls(ctx)
+	_ = c.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityCategory, domain.ChangeKindListing, category.ID))
 
 	return http.ToCategoryResponseDto(category), nil
 }
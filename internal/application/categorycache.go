@@ -15,7 +15,6 @@ type CategoryCache interface {
 	GetList(ctx context.Context, param CategoryCacheListParam) (*http.PaginationResponseDto[http.CategoryResponseDto], error)
 	SetList(ctx context.Context, param CategoryCacheListParam, pagination *http.PaginationResponseDto[http.CategoryResponseDto]) error
 	InvalidateList(ctx context.Context, param CategoryCacheListParam) error
-	InvalidateAlls(ctx context.Context) error
 }
 
 type CategoryCacheParam struct {
@@ -0,0 +1,13 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/domain"
+)
+
+// ChangeEventHandler reacts to the change events emitted after writes, e.g. by
+// invalidating the cache entries which contain the changed entities
+type ChangeEventHandler interface {
+	Handle(ctx context.Context, events ...domain.ChangeEvent) error
+}
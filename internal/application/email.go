@@ -0,0 +1,279 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Email struct {
+	eventConsumer      EventConsumer
+	emailSender        EmailSender
+	emailRenderer      EmailRenderer
+	userProfileService UserProfileService
+	emailRepo          domain.EmailRepository
+	orderRepo          domain.OrderRepository
+	emailService       domain.EmailService
+	srvCfg             *config.Server
+}
+
+func ProvideEmail(
+	eventConsumer EventConsumer,
+	emailSender EmailSender,
+	emailRenderer EmailRenderer,
+	userProfileService UserProfileService,
+	emailRepo domain.EmailRepository,
+	orderRepo domain.OrderRepository,
+	emailService domain.EmailService,
+	srvCfg *config.Server,
+) *Email {
+	return &Email{
+		eventConsumer:      eventConsumer,
+		emailSender:        emailSender,
+		emailRenderer:      emailRenderer,
+		userProfileService: userProfileService,
+		emailRepo:          emailRepo,
+		orderRepo:          orderRepo,
+		emailService:       emailService,
+		srvCfg:             srvCfg,
+	}
+}
+
+var _ http.EmailApplication = (*Email)(nil)
+
+var _ job.EmailApplication = (*Email)(nil)
+
+func (e *Email) List(ctx context.Context, param http.ListEmailRequestDto) (*http.PaginationResponseDto[http.EmailResponseDto], error) {
+	switch param.Status {
+	case "",
+		domain.EmailStatusPending,
+		domain.EmailStatusSent,
+		domain.EmailStatusFailed:
+	default:
+		return nil, domain.ErrInvalid
+	}
+
+	emails, err := e.emailRepo.List(ctx, domain.EmailRepositoryListParam{
+		UserIDs: param.UserIDs,
+		Status:  param.Status,
+		Limit:   param.Limit,
+		Offset:  (param.Page - 1) * param.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := e.emailRepo.Count(ctx, domain.EmailRepositoryCountParam{
+		UserIDs: param.UserIDs,
+		Status:  param.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginationResponseDto(
+		http.ToEmailResponseDtoList(*emails),
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+// EnqueueEmails renders an email for each consumed event the customer is told
+// about. The email goes to the address of the Keycloak profile of the user at
+// the time of the event, users without one are skipped
+func (e *Email) EnqueueEmails(ctx context.Context) error {
+	return e.eventConsumer.Consume(ctx, e.srvCfg.EmailEventGroup, e.enqueueEmails)
+}
+
+func (e *Email) enqueueEmails(ctx context.Context, events []domain.OutboxEvent) error {
+	profiles := make(map[uuid.UUID]*UserProfile)
+	var emails []domain.Email
+	for _, event := range events {
+		kind, userID, data, err := e.emailTemplateData(ctx, event)
+		if err != nil {
+			return err
+		}
+		if kind == "" {
+			continue
+		}
+
+		profile, ok := profiles[userID]
+		if !ok {
+			profile, err = e.userProfileService.GetProfile(ctx, userID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return err
+			}
+			profiles[userID] = profile
+		}
+		if profile == nil || profile.Email == "" {
+			continue
+		}
+		data.FirstName = profile.FirstName
+
+		locale := profile.Locale
+		if !slices.Contains(domain.EmailLocales, locale) {
+			locale = e.srvCfg.EmailDefaultLocale
+		}
+		rendered, err := e.emailRenderer.Render(EmailRendererRenderParam{
+			Kind:   kind,
+			Locale: locale,
+			Data:   *data,
+		})
+		if err != nil {
+			return err
+		}
+
+		email, err := domain.NewEmail(
+			kind,
+			event.ID,
+			userID,
+			profile.Email,
+			locale,
+			rendered.Subject,
+			rendered.TextBody,
+			rendered.HTMLBody,
+		)
+		if err != nil {
+			return err
+		}
+		if err := e.emailService.Validate(*email); err != nil {
+			return err
+		}
+		emails = append(emails, *email)
+	}
+	return e.emailRepo.Create(ctx, domain.EmailRepositoryCreateParam{Emails: emails})
+}
+
+// emailTemplateData maps an event to the kind of email it is told with, the
+// kind is empty for events no email is sent for. Orders are read as of now
+// for the shipping details, the event only carries what changed
+func (e *Email) emailTemplateData(
+	ctx context.Context,
+	event domain.OutboxEvent,
+) (domain.EmailKind, uuid.UUID, *EmailTemplateData, error) {
+	var kind domain.EmailKind
+	var orderID uuid.UUID
+	switch event.Type {
+	case domain.EventTypeOrderCreated:
+		var payload domain.OrderCreatedEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return "", uuid.Nil, nil, multierror.Append(domain.ErrInternal, err)
+		}
+		kind, orderID = domain.EmailKindOrderPlaced, payload.OrderID
+	case domain.EventTypeOrderPaid:
+		var payload domain.OrderPaidEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return "", uuid.Nil, nil, multierror.Append(domain.ErrInternal, err)
+		}
+		kind, orderID = domain.EmailKindOrderPaid, payload.OrderID
+	case domain.EventTypeOrderStatusChanged:
+		var payload domain.OrderStatusChangedEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return "", uuid.Nil, nil, multierror.Append(domain.ErrInternal, err)
+		}
+		switch payload.To {
+		case domain.OrderStatusShipping:
+			kind = domain.EmailKindOrderShipped
+		case domain.OrderStatusDelivered:
+			kind = domain.EmailKindOrderDelivered
+		case domain.OrderStatusCancelled:
+			kind = domain.EmailKindOrderCancelled
+			if payload.Reason == domain.OrderCancelReasonPaymentExpired {
+				kind = domain.EmailKindOrderPaymentExpired
+			}
+		default:
+			return "", uuid.Nil, nil, nil
+		}
+		orderID = payload.OrderID
+	case domain.EventTypeRefundProcessed:
+		var payload domain.RefundProcessedEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return "", uuid.Nil, nil, multierror.Append(domain.ErrInternal, err)
+		}
+		return domain.EmailKindRefundProcessed, payload.UserID, &EmailTemplateData{
+			OrderID:      payload.OrderID,
+			RefundAmount: payload.Amount,
+		}, nil
+	default:
+		return "", uuid.Nil, nil, nil
+	}
+
+	order, err := e.orderRepo.Get(ctx, domain.OrderRepositoryGetParam{ID: orderID})
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", uuid.Nil, nil, nil
+	}
+	if err != nil {
+		return "", uuid.Nil, nil, err
+	}
+	itemCount := 0
+	for _, item := range order.Items {
+		itemCount += item.Quantity
+	}
+	return kind, order.UserID, &EmailTemplateData{
+		OrderID:       order.ID,
+		Provider:      order.Provider,
+		RecipientName: order.RecipientName,
+		Address:       order.Address,
+		ItemCount:     itemCount,
+		TotalAmount:   order.TotalAmount,
+	}, nil
+}
+
+// SendEmails sends a batch of due emails concurrently. An email sent but not
+// recorded is sent again once its lease expires with the same Message-ID
+func (e *Email) SendEmails(ctx context.Context) error {
+	now := time.Now()
+	emails, err := e.emailRepo.Claim(ctx, domain.EmailRepositoryClaimParam{
+		Now:        now,
+		LeaseUntil: now.Add(e.srvCfg.EmailSendLease),
+		Limit:      e.srvCfg.EmailSendBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(*emails))
+	var wg sync.WaitGroup
+	for i := range *emails {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = e.sendEmail(ctx, &(*emails)[i])
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (e *Email) sendEmail(ctx context.Context, email *domain.Email) error {
+	err := e.emailSender.Send(ctx, EmailSenderSendParam{
+		MessageID: email.ID.String(),
+		To:        email.Recipient,
+		Subject:   email.Subject,
+		TextBody:  email.TextBody,
+		HTMLBody:  email.HTMLBody,
+	})
+	if err != nil {
+		email.RecordFailed(
+			err.Error(),
+			e.srvCfg.EmailRetryBaseDelay,
+			e.srvCfg.EmailRetryMaxDelay,
+			e.srvCfg.EmailMaxAttempts,
+		)
+	} else {
+		email.RecordSent()
+	}
+	return e.emailRepo.Save(ctx, domain.EmailRepositorySaveParam{Email: *email})
+}
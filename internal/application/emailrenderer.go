@@ -0,0 +1,38 @@
+package application
+
+import (
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailRenderer renders the subject and bodies of an email of a kind in a
+// locale of domain.EmailLocales
+type EmailRenderer interface {
+	Render(param EmailRendererRenderParam) (*RenderedEmail, error)
+}
+
+type EmailRendererRenderParam struct {
+	Kind   domain.EmailKind
+	Locale string
+	Data   EmailTemplateData
+}
+
+// EmailTemplateData is what the templates are rendered with, the fields not
+// related to the kind of the email are left empty
+type EmailTemplateData struct {
+	FirstName     string
+	OrderID       uuid.UUID
+	Provider      domain.OrderProvider
+	RecipientName string
+	Address       string
+	ItemCount     int
+	TotalAmount   int64
+	RefundAmount  int64
+}
+
+type RenderedEmail struct {
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
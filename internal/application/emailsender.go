@@ -0,0 +1,21 @@
+package application
+
+import (
+	"context"
+)
+
+// EmailSender hands emails to the mail server. The error describes why the
+// server did not accept the email, it is recorded in the send log
+type EmailSender interface {
+	Send(ctx context.Context, param EmailSenderSendParam) error
+}
+
+type EmailSenderSendParam struct {
+	// MessageID identifies the email in its Message-ID header so mail servers
+	// can drop a copy sent again after an unrecorded attempt
+	MessageID string
+	To        string
+	Subject   string
+	TextBody  string
+	HTMLBody  string
+}
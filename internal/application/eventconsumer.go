@@ -0,0 +1,20 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/domain"
+)
+
+// EventConsumer reads the events published by EventPublisher as a member of a
+// consumer group, every group receives each event. Consume hands a batch of
+// the events not yet handled by the group to handle and acknowledges them when
+// it succeeds, a batch which fails is handed again once it has been idle for a
+// while. Events may be handled more than once, handle must be idempotent
+type EventConsumer interface {
+	Consume(
+		ctx context.Context,
+		group string,
+		handle func(ctx context.Context, events []domain.OutboxEvent) error,
+	) error
+}
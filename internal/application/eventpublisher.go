@@ -0,0 +1,14 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/domain"
+)
+
+// EventPublisher delivers outbox events to the consumers outside of the
+// service. Publishing is at-least-once, an event may be published again when
+// the relay fails to mark it as published, consumers dedupe it by its ID
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.OutboxEvent) error
+}
@@ -1,9 +1,12 @@
 package application
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"math"
 
 	"backend/internal/delivery/http"
+	"backend/internal/domain"
 )
 
 func newPaginationResponseDto[T interface{}](
@@ -25,3 +28,52 @@ func newPaginationResponseDto[T interface{}](
 		},
 	}
 }
+
+// newCursorPaginationResponseDto leaves the totals empty when totalItems is
+// nil, i.e. the count was not requested
+func newCursorPaginationResponseDto[T interface{}](
+	data []T,
+	totalItems *int,
+	itemsPerPage int,
+	nextCursor string,
+) *http.PaginationResponseDto[T] {
+	if itemsPerPage <= 0 {
+		itemsPerPage = 1
+	}
+	meta := http.PaginationMetaResponseDto{
+		ItemsPerPage: itemsPerPage,
+		PageItems:    len(data),
+		NextCursor:   nextCursor,
+	}
+	if totalItems != nil {
+		meta.TotalItems = *totalItems
+		meta.TotalPages = int(math.Ceil(float64(*totalItems) / float64(itemsPerPage)))
+	}
+	return &http.PaginationResponseDto[T]{
+		Data: data,
+		Meta: meta,
+	}
+}
+
+func encodeCursor(cursor any) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor leaves cursor untouched for an empty string, the first page
+func decodeCursor(encoded string, cursor any) error {
+	if encoded == "" {
+		return nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return domain.ErrInvalid
+	}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return domain.ErrInvalid
+	}
+	return nil
+}
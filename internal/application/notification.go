@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/delivery/http"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	notificationRepo domain.NotificationRepository
+}
+
+func ProvideNotification(notificationRepo domain.NotificationRepository) *Notification {
+	return &Notification{
+		notificationRepo: notificationRepo,
+	}
+}
+
+var _ http.NotificationApplication = (*Notification)(nil)
+
+func (n *Notification) List(ctx context.Context, param http.ListNotificationsRequestDto) (*http.PaginationResponseDto[http.NotificationResponseDto], error) {
+	notifications, err := n.notificationRepo.List(ctx, domain.NotificationRepositoryListParam{
+		UserID: param.UserID,
+		Staff:  param.Staff,
+		Unread: param.Unread,
+		Limit:  param.Limit,
+		Offset: (param.Page - 1) * param.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := n.notificationRepo.Count(ctx, domain.NotificationRepositoryCountParam{
+		UserID: param.UserID,
+		Staff:  param.Staff,
+		Unread: param.Unread,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notificationDtos := make([]http.NotificationResponseDto, 0, len(*notifications))
+	for _, notification := range *notifications {
+		notificationDtos = append(notificationDtos, *http.ToNotificationResponseDto(&notification))
+	}
+
+	return newPaginationResponseDto(
+		notificationDtos,
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+// MarkRead marks a notification as read, users may only read their own
+// notifications and staff only the shared ones
+func (n *Notification) MarkRead(ctx context.Context, param http.MarkNotificationReadRequestDto) (*http.NotificationResponseDto, error) {
+	notification, err := n.notificationRepo.Get(ctx, domain.NotificationRepositoryGetParam{
+		NotificationID: param.NotificationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if param.Staff != (notification.UserID == uuid.Nil) ||
+		(!param.Staff && notification.UserID != param.UserID) {
+		return nil, domain.ErrForbidden
+	}
+	notification.MarkRead()
+	err = n.notificationRepo.Save(ctx, domain.NotificationRepositorySaveParam{
+		Notification: *notification,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return http.ToNotificationResponseDto(notification), nil
+}
@@ -2,8 +2,13 @@ package application
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
+	"backend/config"
 	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
 	"backend/internal/domain"
 
 	"github.com/google/uuid"
@@ -16,6 +21,8 @@ type Order struct {
 	productRepo         domain.ProductRepository
 	productService      domain.ProductService
 	cartRepo            domain.CartRepository
+	warehouseRepo       domain.WarehouseRepository
+	srvCfg              *config.Server
 }
 
 func ProvideOrder(
@@ -25,6 +32,8 @@ func ProvideOrder(
 	productRepo domain.ProductRepository,
 	productService domain.ProductService,
 	cartRepo domain.CartRepository,
+	warehouseRepo domain.WarehouseRepository,
+	srvCfg *config.Server,
 ) *Order {
 	return &Order{
 		vnpaypaymentService: vnpaypaymentService,
@@ -33,11 +42,15 @@ func ProvideOrder(
 		productRepo:         productRepo,
 		productService:      productService,
 		cartRepo:            cartRepo,
+		warehouseRepo:       warehouseRepo,
+		srvCfg:              srvCfg,
 	}
 }
 
 var _ http.OrderApplication = (*Order)(nil)
 
+var _ job.OrderApplication = (*Order)(nil)
+
 func (o *Order) Create(ctx context.Context, param http.CreateOrderRequestDto) (*http.OrderResponseDto, error) {
 	productIDs := make([]uuid.UUID, 0, len(param.Data.Items))
 	for _, item := range param.Data.Items {
@@ -47,12 +60,23 @@ func (o *Order) Create(ctx context.Context, param http.CreateOrderRequestDto) (*
 	for _, item := range param.Data.Items {
 		productVariantIDs = append(productVariantIDs, item.ProductVariantID)
 	}
+	// Drafts, archived products and those not published yet can't be bought
 	products, err := o.productRepo.List(ctx, domain.ProductRepositoryListParam{
-		IDs: productIDs,
+		IDs:      productIDs,
+		Statuses: []domain.ProductStatus{domain.ProductStatusPublished},
 	})
 	if err != nil {
 		return nil, err
 	}
+	listedProductIDs := make(map[uuid.UUID]struct{}, len(*products))
+	for _, product := range *products {
+		listedProductIDs[product.ID] = struct{}{}
+	}
+	for _, productID := range productIDs {
+		if _, ok := listedProductIDs[productID]; !ok {
+			return nil, domain.ErrNotFound
+		}
+	}
 	productVariants, err := o.productService.FilterProductVariantsInProducts(
 		*products,
 		productVariantIDs,
@@ -74,7 +98,7 @@ func (o *Order) Create(ctx context.Context, param http.CreateOrderRequestDto) (*
 			itemData.ProductID,
 			itemData.ProductVariantID,
 			itemData.Quantity,
-			variant.Price,
+			variant.EffectivePrice(time.Now()),
 		)
 		if err != nil {
 			return nil, err
@@ -92,6 +116,28 @@ func (o *Order) Create(ctx context.Context, param http.CreateOrderRequestDto) (*
 	if err != nil {
 		return nil, err
 	}
+	// Bundles are allocated by the stock of their components
+	componentProducts, err := o.listComponentProducts(ctx, *products)
+	if err != nil {
+		return nil, err
+	}
+	allocatedVariants := make([]domain.ProductVariant, 0, len(*productVariants))
+	for _, product := range *products {
+		allocatedVariants = append(allocatedVariants, product.Variants...)
+	}
+	for _, product := range *componentProducts {
+		allocatedVariants = append(allocatedVariants, product.Variants...)
+	}
+	warehouses, err := o.warehouseRepo.List(ctx, domain.WarehouseRepositoryListParam{
+		Deleted: domain.DeletedExcludeParam,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = order.AllocateWarehouses(allocatedVariants, *warehouses)
+	if err != nil {
+		return nil, err
+	}
 
 	err = o.orderService.Validate(*order)
 	if err != nil {
@@ -198,32 +244,50 @@ func (o *Order) List(ctx context.Context, param http.ListOrderRequestDto) (*http
 		statusName = string(param.Status)
 	}
 
-	orders, err := o.orderRepo.List(
-		ctx,
-		domain.OrderRepositoryListParam{
-			IDs:        param.IDs,
-			UserIDs:    param.UserIDs,
-			StatusName: statusName,
-			Limit:      param.Limit,
-			Offset:     (param.Page - 1) * param.Limit,
-		},
-	)
-	if err != nil {
-		return nil, err
+	listParam := domain.OrderRepositoryListParam{
+		IDs:        param.IDs,
+		UserIDs:    param.UserIDs,
+		StatusName: statusName,
+		Limit:      param.Limit,
+		Offset:     (param.Page - 1) * param.Limit,
+	}
+	if param.Cursor != nil {
+		var cursor orderListCursor
+		if err := decodeCursor(*param.Cursor, &cursor); err != nil {
+			return nil, err
+		}
+		listParam.AfterID = cursor.ID
+		listParam.Offset = 0
+		// One extra row tells whether there is a next page
+		listParam.Limit = param.Limit + 1
 	}
 
-	count, err := o.orderRepo.Count(
-		ctx,
-		domain.OrderRepositoryCountParam{
-			IDs:        param.IDs,
-			UserIDs:    param.UserIDs,
-			StatusName: statusName,
-		},
-	)
+	orders, err := o.orderRepo.List(ctx, listParam)
 	if err != nil {
 		return nil, err
 	}
 
+	var nextCursor string
+	if param.Cursor != nil && len(*orders) > param.Limit {
+		*orders = (*orders)[:param.Limit]
+		nextCursor = encodeCursor(orderListCursor{ID: (*orders)[param.Limit-1].ID})
+	}
+
+	var count *int
+	if param.Cursor == nil || param.WithCount {
+		count, err = o.orderRepo.Count(
+			ctx,
+			domain.OrderRepositoryCountParam{
+				IDs:        param.IDs,
+				UserIDs:    param.UserIDs,
+				StatusName: statusName,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	orderDtos := make([]http.OrderResponseDto, 0, len(*orders))
 	for i := range *orders {
 		order := &(*orders)[i]
@@ -234,6 +298,14 @@ func (o *Order) List(ctx context.Context, param http.ListOrderRequestDto) (*http
 		orderDtos = append(orderDtos, *orderDto)
 	}
 
+	if param.Cursor != nil {
+		return newCursorPaginationResponseDto(
+			orderDtos,
+			count,
+			param.Limit,
+			nextCursor,
+		), nil
+	}
 	pagination := newPaginationResponseDto(
 		orderDtos,
 		*count,
@@ -243,6 +315,12 @@ func (o *Order) List(ctx context.Context, param http.ListOrderRequestDto) (*http
 	return pagination, nil
 }
 
+// orderListCursor is the opaque cursor of order lists, orders are sorted by
+// their UUIDv7 ID so it is also the creation order
+type orderListCursor struct {
+	ID uuid.UUID `json:"id"`
+}
+
 func (o *Order) Get(ctx context.Context, param http.GetOrderRequestDto) (*http.OrderResponseDto, error) {
 	order, err := o.orderRepo.Get(ctx, domain.OrderRepositoryGetParam{
 		ID: param.OrderID,
@@ -267,6 +345,7 @@ func (o *Order) Update(ctx context.Context, param http.UpdateOrderRequestDto) (*
 		return nil, err
 	}
 
+	previousStatus := order.Status
 	order.Update(
 		param.Data.Address,
 		param.Data.Status,
@@ -278,8 +357,21 @@ func (o *Order) Update(ctx context.Context, param http.UpdateOrderRequestDto) (*
 		return nil, err
 	}
 
+	// Stock is taken once the payment is verified, so only orders past that
+	// point give it back
+	stockTaken := previousStatus == domain.OrderStatusProcessing ||
+		previousStatus == domain.OrderStatusShipping
+	var products []domain.Product
+	if stockTaken && order.Status == domain.OrderStatusCancelled {
+		products, err = o.restock(ctx, order, param.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = o.orderRepo.Save(ctx, domain.OrderRepositorySaveParam{
-		Order: *order,
+		Order:    *order,
+		Products: products,
 	})
 	if err != nil {
 		return nil, err
@@ -293,6 +385,67 @@ func (o *Order) Update(ctx context.Context, param http.UpdateOrderRequestDto) (*
 	return orderDto, nil
 }
 
+// restock gives back the stock taken by the sale of the order as the ledger
+// recorded it, a sale capped by the stock at hand or spread over warehouses
+// is given back the same way. The products are returned to be saved along
+// with the order
+func (o *Order) restock(
+	ctx context.Context,
+	order *domain.Order,
+	actorID uuid.UUID,
+) ([]domain.Product, error) {
+	sales, err := o.productRepo.ListStockMovements(ctx, domain.ProductRepositoryListStockMovementsParam{
+		OrderID: order.ID,
+		Kinds:   []domain.StockMovementKind{domain.StockMovementKindSale},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(*sales) == 0 {
+		return nil, nil
+	}
+
+	productVariantIDs := make([]uuid.UUID, 0, len(*sales))
+	for _, sale := range *sales {
+		if !slices.Contains(productVariantIDs, sale.ProductVariantID) {
+			productVariantIDs = append(productVariantIDs, sale.ProductVariantID)
+		}
+	}
+	products, err := o.productRepo.List(ctx, domain.ProductRepositoryListParam{
+		VariantIDs: productVariantIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	variantIDProductMap := make(map[uuid.UUID]*domain.Product)
+	for i := range *products {
+		for _, variant := range (*products)[i].Variants {
+			variantIDProductMap[variant.ID] = &(*products)[i]
+		}
+	}
+
+	for _, sale := range *sales {
+		product, ok := variantIDProductMap[sale.ProductVariantID]
+		if !ok {
+			return nil, domain.ErrNotFound
+		}
+		_, err := product.RecordStockMovement(
+			sale.ProductVariantID,
+			sale.WarehouseID,
+			domain.StockMovementKindCancellation,
+			-sale.Quantity,
+			actorID,
+			order.ID,
+			"",
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return *products, nil
+}
+
 func (o *Order) VerifyVNPayIPN(ctx context.Context, param http.VerifyVNPayIPNRequestDTO) (*http.VerifyVNPayIPNResponseDTO, error) {
 	verifyParam := VerifyIPNVNPayParam{
 		Amount:            param.QueryParams.Amount,
@@ -314,6 +467,7 @@ func (o *Order) VerifyVNPayIPN(ctx context.Context, param http.VerifyVNPayIPNReq
 		o.getOrder,
 		o.onVerifySuccess,
 		o.onVerifyFailure,
+		o.onVerifyRefundDue,
 	)
 	return &http.VerifyVNPayIPNResponseDTO{
 		RspCode: rspCode,
@@ -321,13 +475,29 @@ func (o *Order) VerifyVNPayIPN(ctx context.Context, param http.VerifyVNPayIPNReq
 	}, err
 }
 
+// getOrder expires the order when it was paid after the deadline, even if the
+// expiry task did not get to it yet. A payment made in time is accepted however
+// late its IPN comes, an order without payment expires by now
 func (o *Order) getOrder(
 	ctx context.Context,
 	orderID uuid.UUID,
+	paidAt time.Time,
 ) (*domain.Order, error) {
-	return o.orderRepo.Get(ctx, domain.OrderRepositoryGetParam{
+	order, err := o.orderRepo.Get(ctx, domain.OrderRepositoryGetParam{
 		ID: orderID,
 	})
+	if err != nil {
+		return nil, err
+	}
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+	if order.IsPaymentExpired(o.paymentDeadline(order.Provider), paidAt) {
+		if err := o.expirePayment(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
 }
 
 func (o *Order) onVerifySuccess(
@@ -340,45 +510,59 @@ func (o *Order) onVerifySuccess(
 		true,
 	)
 	productVariantIDs := make([]uuid.UUID, 0, len(order.Items))
-	productIDproductVariantIDMap := make(map[uuid.UUID]uuid.UUID)
 	for _, item := range order.Items {
 		productVariantIDs = append(productVariantIDs, item.ProductVariantID)
-		productIDproductVariantIDMap[item.ProductID] = item.ProductVariantID
 	}
 	products, err := o.productRepo.List(ctx, domain.ProductRepositoryListParam{
 		VariantIDs: productVariantIDs,
 	})
+	if err != nil {
+		return err
+	}
+	// The components of bundles are decreased in their place
+	componentProducts, err := o.listComponentProducts(ctx, *products)
+	if err != nil {
+		return err
+	}
+	*products = append(*products, *componentProducts...)
 
-	productIDproductVariantMap := make(map[uuid.UUID]*domain.ProductVariant)
-	for _, p := range *products {
-		for i, v := range p.Variants {
-			if _, ok := productIDproductVariantIDMap[p.ID]; ok {
-				if v.ID == productIDproductVariantIDMap[p.ID] {
-					productIDproductVariantMap[p.ID] = &p.Variants[i]
-				}
-			}
+	variantIDVariantMap := make(map[uuid.UUID]*domain.ProductVariant)
+	for i := range *products {
+		p := &(*products)[i]
+		for j := range p.Variants {
+			variantIDVariantMap[p.Variants[j].ID] = &p.Variants[j]
 		}
 	}
 
 	for _, item := range order.Items {
-		variant, ok := productIDproductVariantMap[item.ProductID]
+		variant, ok := variantIDVariantMap[item.ProductVariantID]
 		if !ok {
 			return domain.ErrNotFound
 		}
-		variant.DecreaseQuantity(item.Quantity)
-	}
-	for _, p := range *products {
-		_ = o.productRepo.Save(ctx, domain.ProductRepositorySaveParam{
-			Product: p,
-		})
-		if err != nil {
+		if err := variant.DecreaseQuantity(item.Quantity, item.WarehouseID, order.ID); err != nil {
 			return err
 		}
-
+		for _, component := range variant.Components {
+			componentVariant, ok := variantIDVariantMap[component.VariantID]
+			if !ok {
+				return domain.ErrNotFound
+			}
+			// The whole bundle was allocated to the warehouse of the item
+			if err := componentVariant.DecreaseQuantity(
+				item.Quantity*component.Quantity,
+				item.WarehouseID,
+				order.ID,
+			); err != nil {
+				return err
+			}
+		}
 	}
-
+	// An expiry or another IPN which committed first wins, stock is then not
+	// taken twice
 	return o.orderRepo.Save(ctx, domain.OrderRepositorySaveParam{
-		Order: *order,
+		Order:            *order,
+		Products:         *products,
+		IfPaymentPending: true,
 	})
 }
 
@@ -391,7 +575,129 @@ func (o *Order) onVerifyFailure(
 		domain.OrderStatusCancelled,
 		false,
 	)
+	return o.orderRepo.Save(ctx, domain.OrderRepositorySaveParam{
+		Order:            *order,
+		IfPaymentPending: true,
+	})
+}
+
+// onVerifyRefundDue flags the payment captured for an order which was
+// cancelled meanwhile, the order stays cancelled
+func (o *Order) onVerifyRefundDue(
+	ctx context.Context,
+	order *domain.Order,
+) error {
+	if err := order.FlagRefundDue(); err != nil {
+		return err
+	}
 	return o.orderRepo.Save(ctx, domain.OrderRepositorySaveParam{
 		Order: *order,
 	})
 }
+
+// listComponentProducts lists the products of the bundle components which are
+// not among the products given, components may belong to products which are
+// not ordered themselves
+func (o *Order) listComponentProducts(
+	ctx context.Context,
+	products []domain.Product,
+) (*[]domain.Product, error) {
+	loadedVariantIDs := make(map[uuid.UUID]struct{})
+	for _, p := range products {
+		for _, v := range p.Variants {
+			loadedVariantIDs[v.ID] = struct{}{}
+		}
+	}
+	componentIDs := make([]uuid.UUID, 0)
+	for _, p := range products {
+		for _, v := range p.Variants {
+			for _, component := range v.Components {
+				if _, loaded := loadedVariantIDs[component.VariantID]; !loaded {
+					loadedVariantIDs[component.VariantID] = struct{}{}
+					componentIDs = append(componentIDs, component.VariantID)
+				}
+			}
+		}
+	}
+	if len(componentIDs) == 0 {
+		return &[]domain.Product{}, nil
+	}
+	return o.productRepo.List(ctx, domain.ProductRepositoryListParam{
+		VariantIDs: componentIDs,
+	})
+}
+
+// ExpireUnpaidOrders cancels a batch per provider of the orders still waiting
+// for their online payment after its deadline and the grace period, the IPN
+// of a payment made just in time may come late. The rest is left to the next
+// run
+func (o *Order) ExpireUnpaidOrders(ctx context.Context) error {
+	now := time.Now()
+	providers := []domain.OrderProvider{
+		domain.PaymentProviderVNPAY,
+		domain.PaymentProviderMOMO,
+		domain.PaymentProviderZALOPAY,
+	}
+	for _, provider := range providers {
+		deadline := o.paymentDeadline(provider)
+		if deadline <= 0 {
+			continue
+		}
+		ids, err := o.orderRepo.ListUnpaidIDs(ctx, domain.OrderRepositoryListUnpaidIDsParam{
+			Status:        domain.OrderStatusPending,
+			Provider:      provider,
+			CreatedBefore: now.Add(-deadline - o.srvCfg.OrderExpireGracePeriod),
+			Limit:         o.srvCfg.OrderExpireBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		if len(*ids) == 0 {
+			continue
+		}
+		orders, err := o.orderRepo.List(ctx, domain.OrderRepositoryListParam{
+			IDs: *ids,
+		})
+		if err != nil {
+			return err
+		}
+		for i := range *orders {
+			err := o.expirePayment(ctx, &(*orders)[i])
+			// The order was paid or cancelled since it was listed
+			if errors.Is(err, domain.ErrConflict) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *Order) expirePayment(ctx context.Context, order *domain.Order) error {
+	if err := order.ExpirePayment(); err != nil {
+		return err
+	}
+	// The order listed may be stale, a payment verified meanwhile must not be
+	// overwritten
+	return o.orderRepo.Save(ctx, domain.OrderRepositorySaveParam{
+		Order:            *order,
+		IfPaymentPending: true,
+	})
+}
+
+// paymentDeadline is how long an order of the provider waits for its online
+// payment, zero for orders which are not paid online or never expire
+func (o *Order) paymentDeadline(provider domain.OrderProvider) time.Duration {
+	switch provider {
+	case domain.PaymentProviderVNPAY:
+		return o.srvCfg.OrderPaymentDeadlineVNPay
+	case domain.PaymentProviderMOMO:
+		return o.srvCfg.OrderPaymentDeadlineMoMo
+	case domain.PaymentProviderZALOPAY:
+		return o.srvCfg.OrderPaymentDeadlineZaloPay
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/config"
+	"backend/internal/delivery/job"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type Outbox struct {
+	eventPublisher EventPublisher
+	outboxRepo     domain.OutboxRepository
+	srvCfg         *config.Server
+}
+
+func ProvideOutbox(
+	eventPublisher EventPublisher,
+	outboxRepo domain.OutboxRepository,
+	srvCfg *config.Server,
+) *Outbox {
+	return &Outbox{
+		eventPublisher: eventPublisher,
+		outboxRepo:     outboxRepo,
+		srvCfg:         srvCfg,
+	}
+}
+
+var _ job.OutboxApplication = (*Outbox)(nil)
+
+// RelayEvents publishes a batch of due events. An event which fails is retried
+// with an exponential backoff, the others of the batch are still published.
+// Events published but not marked as such are published again once their
+// lease expires
+func (o *Outbox) RelayEvents(ctx context.Context) error {
+	now := time.Now()
+	events, err := o.outboxRepo.Claim(ctx, domain.OutboxRepositoryClaimParam{
+		Now:        now,
+		LeaseUntil: now.Add(o.srvCfg.OutboxRelayLease),
+		Limit:      o.srvCfg.OutboxRelayBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+	publishedIDs := make([]uuid.UUID, 0, len(*events))
+	var publishErr error
+	for _, event := range *events {
+		err := o.eventPublisher.Publish(ctx, event)
+		if err == nil {
+			publishedIDs = append(publishedIDs, event.ID)
+			continue
+		}
+		publishErr = errors.Join(publishErr, err)
+		err = o.outboxRepo.MarkFailed(ctx, domain.OutboxRepositoryMarkFailedParam{
+			ID:    event.ID,
+			Error: err.Error(),
+			NextAttemptAt: event.NextAttemptAt(
+				time.Now(),
+				o.srvCfg.OutboxRetryBaseDelay,
+				o.srvCfg.OutboxRetryMaxDelay,
+			),
+		})
+		if err != nil {
+			return errors.Join(publishErr, err)
+		}
+	}
+	err = o.outboxRepo.MarkPublished(ctx, domain.OutboxRepositoryMarkPublishedParam{
+		IDs:         publishedIDs,
+		PublishedAt: time.Now(),
+	})
+	if err != nil {
+		return errors.Join(publishErr, err)
+	}
+	return publishErr
+}
+
+// PurgeEvents deletes the events published before the retention period,
+// they are only kept to trace what was published
+func (o *Outbox) PurgeEvents(ctx context.Context) error {
+	_, err := o.outboxRepo.DeletePublished(ctx, domain.OutboxRepositoryDeletePublishedParam{
+		Before: time.Now().Add(-o.srvCfg.OutboxRetention),
+	})
+	return err
+}
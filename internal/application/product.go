@@ -3,9 +3,12 @@ package application
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"backend/config"
 	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
 	"backend/internal/domain"
 
 	"github.com/google/uuid"
@@ -15,10 +18,13 @@ type Product struct {
 	attributeRepo        domain.AttributeRepository
 	attributeService     domain.AttributeService
 	categoryRepo         domain.CategoryRepository
+	changeHandler        ChangeEventHandler
 	productCache         ProductCache
 	productObjectStorage ProductObjectStorage
 	productRepo          domain.ProductRepository
 	productService       domain.ProductService
+	productViewBuffer    ProductViewBuffer
+	specGroupRepo        domain.SpecGroupRepository
 	srvCfg               *config.Server
 }
 
@@ -26,83 +32,163 @@ func ProvideProduct(
 	attributeRepo domain.AttributeRepository,
 	attributeService domain.AttributeService,
 	categoryRepo domain.CategoryRepository,
+	changeHandler ChangeEventHandler,
 	productCache ProductCache,
 	productObjectStorage ProductObjectStorage,
 	productRepo domain.ProductRepository,
 	productService domain.ProductService,
+	productViewBuffer ProductViewBuffer,
+	specGroupRepo domain.SpecGroupRepository,
 	srvCfg *config.Server,
 ) *Product {
 	return &Product{
 		attributeRepo:        attributeRepo,
 		attributeService:     attributeService,
 		categoryRepo:         categoryRepo,
+		changeHandler:        changeHandler,
 		productCache:         productCache,
 		productObjectStorage: productObjectStorage,
 		productRepo:          productRepo,
 		productService:       productService,
+		productViewBuffer:    productViewBuffer,
+		specGroupRepo:        specGroupRepo,
 		srvCfg:               srvCfg,
 	}
 }
 
 var _ http.ProductApplication = (*Product)(nil)
 
+var _ job.ProductApplication = (*Product)(nil)
+
 func (p *Product) List(ctx context.Context, param http.ListProductRequestDto) (*http.PaginationResponseDto[http.ProductResponseDto], error) {
 	cacheParam := ProductCacheListParam{
-		IDs:         param.ProductIDs,
-		Search:      param.Search,
-		MinPrice:    param.MinPrice,
-		MaxPrice:    param.MaxPrice,
-		Rating:      param.Rating,
-		CategoryIDs: param.CategoryIDs,
-		Deleted:     param.Deleted,
-		SortRating:  param.SortRating,
-		SortPrice:   param.SortPrice,
-		Limit:       param.Limit,
-		Page:        param.Page,
+		IDs:          param.ProductIDs,
+		Search:       param.Search,
+		MinPrice:     param.MinPrice,
+		MaxPrice:     param.MaxPrice,
+		Rating:       param.Rating,
+		CategoryIDs:  param.CategoryIDs,
+		Deleted:      param.Deleted,
+		Statuses:     param.Statuses,
+		OnSale:       param.OnSale,
+		SortTrending: param.SortTrending,
+		SortRating:   param.SortRating,
+		SortPrice:    param.SortPrice,
+		SortDiscount: param.SortDiscount,
+		Limit:        param.Limit,
+		Page:         param.Page,
+		Cursor:       param.Cursor,
+		WithCount:    param.WithCount,
 	}
 
-	if cachedPagination, err := p.productCache.GetList(ctx, cacheParam); err == nil {
-		return cachedPagination, nil
+	return p.productCache.GetOrLoadList(ctx, cacheParam, func(ctx context.Context) (*http.PaginationResponseDto[http.ProductResponseDto], error) {
+		return p.listProducts(ctx, param)
+	})
+}
+
+func (p *Product) listProducts(ctx context.Context, param http.ListProductRequestDto) (*http.PaginationResponseDto[http.ProductResponseDto], error) {
+	listParam := domain.ProductRepositoryListParam{
+		IDs:          param.ProductIDs,
+		Search:       param.Search,
+		MinPrice:     param.MinPrice,
+		MaxPrice:     param.MaxPrice,
+		Rating:       param.Rating,
+		CategoryIDs:  param.CategoryIDs,
+		Deleted:      param.Deleted,
+		Statuses:     param.Statuses,
+		OnSale:       param.OnSale,
+		SortTrending: param.SortTrending,
+		SortRating:   param.SortRating,
+		SortPrice:    param.SortPrice,
+		SortDiscount: param.SortDiscount,
+		Limit:        param.Limit,
+		Offset:       (param.Page - 1) * param.Limit,
+	}
+	if param.Cursor != nil {
+		cursor, err := decodeProductListCursor(param)
+		if err != nil {
+			return nil, err
+		}
+		listParam.Offset = cursor.Offset
+		if cursor.ID != uuid.Nil {
+			listParam.After = &domain.ProductRepositoryListCursor{
+				ID:            cursor.ID,
+				TrendingScore: cursor.TrendingScore,
+				Rating:        cursor.Rating,
+				Price:         cursor.Price,
+				Discount:      cursor.Discount,
+			}
+		}
+		// One extra row tells whether there is a next page
+		listParam.Limit = param.Limit + 1
 	}
 
-	products, err := p.productRepo.List(
-		ctx,
-		domain.ProductRepositoryListParam{
-			IDs:         param.ProductIDs,
-			Search:      param.Search,
-			MinPrice:    param.MinPrice,
-			MaxPrice:    param.MaxPrice,
-			Rating:      param.Rating,
-			CategoryIDs: param.CategoryIDs,
-			Deleted:     param.Deleted,
-			SortRating:  param.SortRating,
-			SortPrice:   param.SortPrice,
-			Limit:       param.Limit,
-			Offset:      (param.Page - 1) * param.Limit,
-		},
-	)
+	products, err := p.productRepo.List(ctx, listParam)
 	if err != nil {
 		return nil, err
 	}
 
-	count, err := p.productRepo.Count(
-		ctx,
-		domain.ProductRepositoryCountParam{
-			IDs:         param.ProductIDs,
-			Search:      param.Search,
-			MinPrice:    param.MinPrice,
-			MaxPrice:    param.MaxPrice,
-			Rating:      param.Rating,
-			CategoryIDs: param.CategoryIDs,
-			Deleted:     param.Deleted,
-		},
-	)
+	var nextCursor string
+	if param.Cursor != nil && len(*products) > param.Limit {
+		*products = (*products)[:param.Limit]
+		nextCursor = encodeCursor(newProductListCursor(
+			param,
+			listParam.Offset,
+			&(*products)[param.Limit-1],
+		))
+	}
+
+	var count *int
+	if param.Cursor == nil || param.WithCount {
+		count, err = p.productRepo.Count(
+			ctx,
+			domain.ProductRepositoryCountParam{
+				IDs:         param.ProductIDs,
+				Search:      param.Search,
+				MinPrice:    param.MinPrice,
+				MaxPrice:    param.MaxPrice,
+				Rating:      param.Rating,
+				CategoryIDs: param.CategoryIDs,
+				Deleted:     param.Deleted,
+				Statuses:    param.Statuses,
+				OnSale:      param.OnSale,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	productDtos, err := p.toProductResponseDtos(ctx, *products)
 	if err != nil {
 		return nil, err
 	}
 
-	categoryIDs := make([]uuid.UUID, 0, len(*products))
-	for _, product := range *products {
+	var pagination *http.PaginationResponseDto[http.ProductResponseDto]
+	if param.Cursor != nil {
+		pagination = newCursorPaginationResponseDto(
+			productDtos,
+			count,
+			param.Limit,
+			nextCursor,
+		)
+	} else {
+		pagination = newPaginationResponseDto(
+			productDtos,
+			*count,
+			param.Page,
+			param.Limit,
+		)
+	}
+
+	return pagination, nil
+}
+
+// toProductResponseDtos loads categories and attributes of products in
+// batches for the response
+func (p *Product) toProductResponseDtos(ctx context.Context, products []domain.Product) ([]http.ProductResponseDto, error) {
+	categoryIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
 		categoryIDs = append(categoryIDs, product.CategoryID)
 	}
 
@@ -121,8 +207,8 @@ func (p *Product) List(ctx context.Context, param http.ListProductRequestDto) (*
 		categoryMap[(*categories)[i].ID] = &(*categories)[i]
 	}
 
-	attributeValuesIDs := make([]uuid.UUID, 0, len(*products))
-	for _, product := range *products {
+	attributeValuesIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
 		attributeValuesIDs = append(attributeValuesIDs, product.AttributeValueIDs...)
 	}
 
@@ -137,8 +223,13 @@ func (p *Product) List(ctx context.Context, param http.ListProductRequestDto) (*
 		return nil, err
 	}
 
-	productDtos := make([]http.ProductResponseDto, 0, len(*products))
-	for _, product := range *products {
+	specGroups, err := p.listSpecGroups(ctx, products...)
+	if err != nil {
+		return nil, err
+	}
+
+	productDtos := make([]http.ProductResponseDto, 0, len(products))
+	for _, product := range products {
 		dto := http.ToProductResponseDto(&product)
 		if dto != nil {
 			if category, exists := categoryMap[product.CategoryID]; exists {
@@ -147,30 +238,238 @@ func (p *Product) List(ctx context.Context, param http.ListProductRequestDto) (*
 			if attributes != nil {
 				dto.WithAttributes(*attributes, product.AttributeValueIDs)
 			}
+			dto.WithSpecs(specGroups, product.Specs)
 			productDtos = append(productDtos, *dto)
 		}
 	}
 
-	pagination := newPaginationResponseDto(
-		productDtos,
-		*count,
-		param.Page,
-		param.Limit,
+	return productDtos, nil
+}
+
+// listSpecGroups returns the spec groups holding the specs of the products
+func (p *Product) listSpecGroups(ctx context.Context, products ...domain.Product) ([]domain.SpecGroup, error) {
+	specIDs := make([]uuid.UUID, 0)
+	for _, product := range products {
+		for _, spec := range product.Specs {
+			specIDs = append(specIDs, spec.SpecID)
+		}
+	}
+	if len(specIDs) == 0 {
+		return []domain.SpecGroup{}, nil
+	}
+
+	specGroups, err := p.specGroupRepo.List(
+		ctx,
+		domain.SpecGroupRepositoryListParam{
+			SpecIDs: specIDs,
+			Deleted: domain.DeletedExcludeParam,
+		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	return *specGroups, nil
+}
+
+// productListCursor is the opaque cursor of product lists. Search is ranked by
+// relevance, which has no stable key, so it pages by Offset instead of ID
+type productListCursor struct {
+	ID            uuid.UUID `json:"id"`
+	TrendingScore float64   `json:"trendingScore,omitempty"`
+	Rating        float64   `json:"rating,omitempty"`
+	Price         int64     `json:"price,omitempty"`
+	Discount      int       `json:"discount,omitempty"`
+	Offset        int       `json:"offset,omitempty"`
+}
 
-	_ = p.productCache.SetList(ctx, cacheParam, pagination)
+func decodeProductListCursor(param http.ListProductRequestDto) (*productListCursor, error) {
+	var cursor productListCursor
+	if err := decodeCursor(*param.Cursor, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
 
-	return pagination, nil
+func newProductListCursor(param http.ListProductRequestDto, offset int, last *domain.Product) *productListCursor {
+	if param.Search != "" {
+		return &productListCursor{Offset: offset + param.Limit}
+	}
+	return &productListCursor{
+		ID:            last.ID,
+		TrendingScore: last.TrendingScore,
+		Rating:        last.Rating,
+		Price:         last.Price,
+		Discount:      last.Discount,
+	}
+}
+
+func (p *Product) ListSuggestions(ctx context.Context, param http.ListProductSuggestionsRequestDto) (*[]http.ProductSuggestionResponseDto, error) {
+	cacheParam := ProductCacheSuggestionsParam{
+		Search: param.Search,
+		Limit:  param.Limit,
+	}
+
+	if cachedSuggestions, err := p.productCache.GetSuggestions(ctx, cacheParam); err == nil {
+		return cachedSuggestions, nil
+	}
+
+	suggestions, err := p.productRepo.ListSuggestions(
+		ctx,
+		domain.ProductRepositoryListSuggestionsParam{
+			Search: param.Search,
+			Limit:  param.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestionDtos := http.ToProductSuggestionResponseDtoList(*suggestions)
+
+	_ = p.productCache.SetSuggestions(ctx, cacheParam, &suggestionDtos)
+
+	return &suggestionDtos, nil
+}
+
+func (p *Product) ListRelated(ctx context.Context, param http.ListProductRecommendationsRequestDto) (*[]http.ProductResponseDto, error) {
+	return p.listRecommendations(ctx, param, domain.ProductRecommendationKindRelated)
+}
+
+func (p *Product) ListBoughtTogether(ctx context.Context, param http.ListProductRecommendationsRequestDto) (*[]http.ProductResponseDto, error) {
+	return p.listRecommendations(ctx, param, domain.ProductRecommendationKindBoughtTogether)
+}
+
+func (p *Product) listRecommendations(
+	ctx context.Context,
+	param http.ListProductRecommendationsRequestDto,
+	kind domain.ProductRecommendationKind,
+) (*[]http.ProductResponseDto, error) {
+	cacheParam := ProductCacheRecommendationsParam{
+		ProductID: param.ProductID,
+		Kind:      kind,
+		Limit:     param.Limit,
+	}
+
+	if cachedProducts, err := p.productCache.GetRecommendations(ctx, cacheParam); err == nil {
+		return cachedProducts, nil
+	}
+
+	products, err := p.productRepo.ListRecommendations(
+		ctx,
+		domain.ProductRepositoryListRecommendationsParam{
+			ProductID: param.ProductID,
+			Kind:      kind,
+			Limit:     param.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	productDtos, err := p.toProductResponseDtos(ctx, *products)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = p.productCache.SetRecommendations(ctx, cacheParam, &productDtos)
+
+	return &productDtos, nil
+}
+
+// Compare lines up the specs of products in the requested order, a product
+// that is missing or hidden from the caller fails the whole comparison
+func (p *Product) Compare(ctx context.Context, param http.CompareProductsRequestDto) (*http.ProductComparisonResponseDto, error) {
+	productIDs := make([]uuid.UUID, 0, len(param.ProductIDs))
+	for _, productID := range param.ProductIDs {
+		if !slices.Contains(productIDs, productID) {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	if len(productIDs) < 2 {
+		return nil, domain.ErrInvalid
+	}
+
+	listParam := domain.ProductRepositoryListParam{
+		IDs:     productIDs,
+		Deleted: domain.DeletedExcludeParam,
+		Limit:   len(productIDs),
+	}
+	if param.PublishedOnly {
+		listParam.Statuses = []domain.ProductStatus{domain.ProductStatusPublished}
+	}
+	listedProducts, err := p.productRepo.List(ctx, listParam)
+	if err != nil {
+		return nil, err
+	}
+	if len(*listedProducts) != len(productIDs) {
+		return nil, domain.ErrNotFound
+	}
+
+	products := make([]domain.Product, 0, len(productIDs))
+	for _, productID := range productIDs {
+		for _, product := range *listedProducts {
+			if product.ID == productID {
+				products = append(products, product)
+				break
+			}
+		}
+	}
+
+	specGroups, err := p.listSpecGroups(ctx, products...)
+	if err != nil {
+		return nil, err
+	}
+
+	productDtos, err := p.toProductResponseDtos(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToProductComparisonResponseDto(
+		productDtos,
+		domain.CompareSpecs(specGroups, products),
+	), nil
+}
+
+// RefreshRecommendations recomputes related and bought together products of
+// every product, it is run periodically since co-occurrence is costly
+func (p *Product) RefreshRecommendations(ctx context.Context) error {
+	err := p.productRepo.RefreshRecommendations(
+		ctx,
+		domain.ProductRepositoryRefreshRecommendationsParam{
+			Limit:                p.srvCfg.ProductRecommendationLimit,
+			CategoryWeight:       p.srvCfg.ProductRecommendationCategoryWeight,
+			AttributeValueWeight: p.srvCfg.ProductRecommendationAttributeValueWeight,
+			MinOrders:            p.srvCfg.ProductRecommendationMinOrders,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	_ = p.productCache.InvalidateRecommendations(ctx)
+	return nil
 }
 
 func (p *Product) Get(ctx context.Context, param http.GetProductRequestDto) (*http.ProductResponseDto, error) {
 	cacheParam := ProductCacheParam{ID: param.ProductID}
 
-	if cachedProduct, err := p.productCache.Get(ctx, cacheParam); err == nil {
-		return cachedProduct, nil
+	productDto, err := p.productCache.GetOrLoad(ctx, cacheParam, func(ctx context.Context) (*http.ProductResponseDto, error) {
+		return p.getProduct(ctx, param.ProductID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if param.PublishedOnly && productDto.Status != domain.ProductStatusPublished {
+		return nil, domain.ErrNotFound
 	}
+	p.recordView(ctx, param)
 
-	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	return productDto, nil
+}
+
+func (p *Product) getProduct(ctx context.Context, productID uuid.UUID) (*http.ProductResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: productID})
 	if err != nil {
 		return nil, err
 	}
@@ -191,17 +490,72 @@ func (p *Product) Get(ctx context.Context, param http.GetProductRequestDto) (*ht
 		return nil, err
 	}
 
+	specGroups, err := p.listSpecGroups(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+
 	productDto := http.ToProductResponseDto(product)
 	productDto.WithCategory(category)
 	productDto.WithAttributes(
 		*attributes,
 		product.AttributeValueIDs,
 	)
-	_ = p.productCache.Set(ctx, cacheParam, productDto)
+	productDto.WithSpecs(specGroups, product.Specs)
 
 	return productDto, nil
 }
 
+// recordView buffers a view for the trending score, a failure must not fail
+// the request
+func (p *Product) recordView(ctx context.Context, param http.GetProductRequestDto) {
+	var viewerKey string
+	switch {
+	case param.UserID != uuid.Nil:
+		viewerKey = "user:" + param.UserID.String()
+	case param.ClientIP != "":
+		viewerKey = "ip:" + param.ClientIP
+	default:
+		return
+	}
+	_ = p.productViewBuffer.Record(ctx, ProductViewBufferRecordParam{
+		ProductID: param.ProductID,
+		ViewerKey: viewerKey,
+		DedupTTL:  p.srvCfg.ProductViewDedupTTL,
+	})
+}
+
+func (p *Product) FlushViews(ctx context.Context) error {
+	drain, err := p.productViewBuffer.Drain(ctx)
+	if err != nil || drain == nil {
+		return err
+	}
+	if len(drain.ViewsCounts) > 0 {
+		err = p.productRepo.IncreaseViewsCounts(
+			ctx,
+			domain.ProductRepositoryIncreaseViewsCountsParam{
+				ViewsCounts: drain.ViewsCounts,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return p.productViewBuffer.Ack(ctx, drain.Token)
+}
+
+func (p *Product) UpdateTrendingScores(ctx context.Context) error {
+	return p.productRepo.UpdateTrendingScores(
+		ctx,
+		domain.ProductRepositoryUpdateTrendingScoresParam{
+			Window:         p.srvCfg.ProductTrendingWindow,
+			HalfLife:       p.srvCfg.ProductTrendingHalfLife,
+			ViewWeight:     p.srvCfg.ProductTrendingViewWeight,
+			PurchaseWeight: p.srvCfg.ProductTrendingPurchaseWeight,
+		},
+	)
+}
+
 func (p *Product) Create(ctx context.Context, param http.CreateProductRequestDto) (*http.ProductResponseDto, error) {
 	product, err := domain.NewProduct(
 		param.Data.Name,
@@ -211,6 +565,25 @@ func (p *Product) Create(ctx context.Context, param http.CreateProductRequestDto
 	if err != nil {
 		return nil, err
 	}
+	status := param.Data.Status
+	if status == "" {
+		status = domain.ProductStatusDraft
+	}
+	var publishAt, unpublishAt time.Time
+	if param.Data.PublishAt != nil {
+		publishAt = *param.Data.PublishAt
+	}
+	if param.Data.UnpublishAt != nil {
+		unpublishAt = *param.Data.UnpublishAt
+	}
+	if err := product.UpdatePublishing(status, publishAt, unpublishAt); err != nil {
+		return nil, err
+	}
+	if param.Data.Type != "" {
+		if err := product.UpdateType(param.Data.Type); err != nil {
+			return nil, err
+		}
+	}
 
 	// Get and validate category
 	category, err := p.categoryRepo.Get(ctx, domain.CategoryRepositoryGetParam{ID: param.Data.CategoryID})
@@ -266,17 +639,18 @@ func (p *Product) Create(ctx context.Context, param http.CreateProductRequestDto
 		if err != nil {
 			return nil, err
 		}
-		productImages = append(productImages, *image)
-		if err = p.productObjectStorage.PersistImageFromTemp(
+		renditions, err := p.productObjectStorage.PersistImageFromTemp(
 			ctx,
 			imgData.Key,
 			image.ID,
-		); err != nil {
+		)
+		if err != nil {
 			return nil, err
 		}
+		image.Renditions = *renditions
+		productImages = append(productImages, *image)
 	}
 	product.AddImages(productImages...)
-	imageKeyImageIDMap := make(map[string]uuid.UUID, len(param.Data.Images))
 	for _, variantData := range param.Data.Variants {
 		variant, err := domain.NewVariant(
 			variantData.SKU,
@@ -286,7 +660,25 @@ func (p *Product) Create(ctx context.Context, param http.CreateProductRequestDto
 		if err != nil {
 			return nil, err
 		}
+		if variantData.WarehouseID != nil {
+			if err := variant.AssignWarehouse(*variantData.WarehouseID); err != nil {
+				return nil, err
+			}
+		}
 		product.AddVariants(*variant)
+		err = product.UpdateVariantPricing(variant.ID, variantData.CompareAtPrice, 0, time.Time{}, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		if len(variantData.Components) > 0 {
+			components, err := p.toBundleComponents(ctx, variantData.Components)
+			if err != nil {
+				return nil, err
+			}
+			if err := product.UpdateVariantComponents(variant.ID, components); err != nil {
+				return nil, err
+			}
+		}
 		variantImages := make([]domain.ProductImage, 0, len(variantData.Images))
 		for _, imgData := range variantData.Images {
 			image, err := domain.NewProductImage(
@@ -296,9 +688,16 @@ func (p *Product) Create(ctx context.Context, param http.CreateProductRequestDto
 			if err != nil {
 				return nil, err
 			}
+			renditions, err := p.productObjectStorage.PersistImageFromTemp(
+				ctx,
+				imgData.Key,
+				image.ID,
+			)
+			if err != nil {
+				return nil, err
+			}
+			image.Renditions = *renditions
 			variantImages = append(variantImages, *image)
-			imageKeyImageIDMap[imgData.Key] = image.ID
-
 		}
 		if err := product.AddVariantImages(variant.ID, variantImages...); err != nil {
 			return nil, err
@@ -320,18 +719,7 @@ func (p *Product) Create(ctx context.Context, param http.CreateProductRequestDto
 		return nil, err
 	}
 
-	for key, imageID := range imageKeyImageIDMap {
-		err = p.productObjectStorage.PersistImageFromTemp(
-			ctx,
-			key,
-			imageID,
-		)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
 
 	var attributes *[]domain.Attribute
 	if len(product.AttributeIDs) > 0 {
@@ -384,7 +772,7 @@ func (p *Product) Update(ctx context.Context, param http.UpdateProductRequestDto
 	if err != nil {
 		return nil, err
 	}
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
 
 	// Fetch attributes if any
 	var attributes *[]domain.Attribute
@@ -409,39 +797,256 @@ func (p *Product) Update(ctx context.Context, param http.UpdateProductRequestDto
 	return productDto, nil
 }
 
-func (p *Product) Delete(ctx context.Context, param http.DeleteProductRequestDto) error {
+func (p *Product) UpdatePublishing(ctx context.Context, param http.UpdateProductPublishingRequestDto) (*http.ProductResponseDto, error) {
 	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	category, err := p.categoryRepo.Get(ctx, domain.CategoryRepositoryGetParam{
+		ID: product.CategoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var publishAt, unpublishAt time.Time
+	if param.Data.PublishAt != nil {
+		publishAt = *param.Data.PublishAt
+	}
+	if param.Data.UnpublishAt != nil {
+		unpublishAt = *param.Data.UnpublishAt
+	}
+	if err := product.UpdatePublishing(param.Data.Status, publishAt, unpublishAt); err != nil {
+		return nil, err
 	}
 	err = p.productService.Validate(*product)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	product.Remove()
 	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_ = p.productCache.InvalidateAlls(ctx)
-	return nil
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
+
+	var attributes *[]domain.Attribute
+	if len(product.AttributeIDs) > 0 {
+		attributes, err = p.attributeRepo.List(
+			ctx,
+			domain.AttributeRepositoryListParam{
+				IDs:     product.AttributeIDs,
+				Deleted: domain.DeletedExcludeParam,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	productDto := http.ToProductResponseDto(product)
+	productDto.WithCategory(category)
+	if attributes != nil {
+		productDto.WithAttributes(*attributes, product.AttributeValueIDs)
+	}
+	return productDto, nil
 }
 
-func (p *Product) AddVariants(ctx context.Context, param http.AddProductVariantsRequestDto) (*[]http.ProductVariantResponseDto, error) {
+// UpdateSpecs replaces the spec values of a product, each value is checked
+// against the type of its spec
+func (p *Product) UpdateSpecs(ctx context.Context, param http.UpdateProductSpecsRequestDto) (*http.ProductResponseDto, error) {
 	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
 	if err != nil {
 		return nil, err
 	}
-	variants := make([]domain.ProductVariant, 0, len(param.Data))
-	for _, variantData := range param.Data {
-		variant, err := domain.NewVariant(
-			variantData.SKU,
-			variantData.Price,
-			variantData.Quantity,
-		)
-		if err != nil {
-			return nil, err
-		}
+
+	specIDs := make([]uuid.UUID, 0, len(param.Data.Specs))
+	for _, data := range param.Data.Specs {
+		specIDs = append(specIDs, data.SpecID)
+	}
+	specMap := make(map[uuid.UUID]*domain.Spec, len(specIDs))
+	if len(specIDs) > 0 {
+		specGroups, err := p.specGroupRepo.List(
+			ctx,
+			domain.SpecGroupRepositoryListParam{
+				SpecIDs: specIDs,
+				Deleted: domain.DeletedExcludeParam,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		for i := range *specGroups {
+			for j := range (*specGroups)[i].Specs {
+				spec := &(*specGroups)[i].Specs[j]
+				if spec.DeletedAt.IsZero() {
+					specMap[spec.ID] = spec
+				}
+			}
+		}
+	}
+
+	specs := make([]domain.ProductSpec, 0, len(param.Data.Specs))
+	for _, data := range param.Data.Specs {
+		spec, exists := specMap[data.SpecID]
+		if !exists {
+			return nil, domain.ErrNotFound
+		}
+		value, err := spec.NewValue(data.Number, data.Boolean, data.Enum)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, *value)
+	}
+	if err := product.UpdateSpecs(specs); err != nil {
+		return nil, err
+	}
+
+	err = p.productService.Validate(*product)
+	if err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
+
+	productDtos, err := p.toProductResponseDtos(ctx, []domain.Product{*product})
+	if err != nil {
+		return nil, err
+	}
+	return &productDtos[0], nil
+}
+
+// ApplyPublishSchedules publishes and unpublishes products whose schedule is
+// due
+func (p *Product) ApplyPublishSchedules(ctx context.Context) error {
+	productIDs, err := p.productRepo.ApplyPublishSchedules(ctx, domain.ProductRepositoryApplyPublishSchedulesParam{
+		Now: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(*productIDs) > 0 {
+		_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, *productIDs...))
+	}
+	return nil
+}
+
+func (p *Product) Delete(ctx context.Context, param http.DeleteProductRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return err
+	}
+	err = p.productService.Validate(*product)
+	if err != nil {
+		return err
+	}
+	product.Remove()
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
+	return nil
+}
+
+func (p *Product) Restore(ctx context.Context, param http.RestoreProductRequestDto) (*http.ProductResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+		ProductID: param.ProductID,
+		Deleted:   domain.DeletedOnlyParam,
+	})
+	if err != nil {
+		return nil, err
+	}
+	category, err := p.categoryRepo.Get(ctx, domain.CategoryRepositoryGetParam{
+		ID: product.CategoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := product.Restore(); err != nil {
+		return nil, err
+	}
+	err = p.productService.Validate(*product)
+	if err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
+
+	var attributes *[]domain.Attribute
+	if len(product.AttributeIDs) > 0 {
+		attributes, err = p.attributeRepo.List(
+			ctx,
+			domain.AttributeRepositoryListParam{
+				IDs:     product.AttributeIDs,
+				Deleted: domain.DeletedExcludeParam,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	productDto := http.ToProductResponseDto(product)
+	productDto.WithCategory(category)
+	if attributes != nil {
+		productDto.WithAttributes(*attributes, product.AttributeValueIDs)
+	}
+	return productDto, nil
+}
+
+// Purge permanently deletes a deleted product, products which were ordered are
+// kept for the order history
+func (p *Product) Purge(ctx context.Context, param http.PurgeProductRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+		ProductID: param.ProductID,
+		Deleted:   domain.DeletedOnlyParam,
+	})
+	if err != nil {
+		return err
+	}
+	orderItemsCount, err := p.productRepo.CountOrderItems(ctx, domain.ProductRepositoryCountOrderItemsParam{
+		ProductID: product.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if *orderItemsCount > 0 {
+		return domain.ErrConflict
+	}
+	err = p.productRepo.Purge(ctx, domain.ProductRepositoryPurgeParam{ProductID: product.ID})
+	if err != nil {
+		return err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
+	// Images go last so a failed purge never leaves a product without them
+	return p.productObjectStorage.DeleteImages(ctx, product.ImageIDs())
+}
+
+func (p *Product) AddVariants(ctx context.Context, param http.AddProductVariantsRequestDto) (*[]http.ProductVariantResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	variants := make([]domain.ProductVariant, 0, len(param.Data))
+	for _, variantData := range param.Data {
+		variant, err := domain.NewVariant(
+			variantData.SKU,
+			variantData.Price,
+			variantData.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if variantData.WarehouseID != nil {
+			if err := variant.AssignWarehouse(*variantData.WarehouseID); err != nil {
+				return nil, err
+			}
+		}
 		// If product has options, link variant to option values
 		if len(product.Options) > 0 && len(variantData.OptionValueIDs) > 0 {
 			optionValues := make([]domain.OptionValue, 0, len(variantData.OptionValueIDs))
@@ -469,6 +1074,22 @@ func (p *Product) AddVariants(ctx context.Context, param http.AddProductVariants
 		variants = append(variants, *variant)
 	}
 	product.AddVariants(variants...)
+	for i, variantData := range param.Data {
+		err = product.UpdateVariantPricing(variants[i].ID, variantData.CompareAtPrice, 0, time.Time{}, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		if len(variantData.Components) == 0 {
+			continue
+		}
+		components, err := p.toBundleComponents(ctx, variantData.Components)
+		if err != nil {
+			return nil, err
+		}
+		if err := product.UpdateVariantComponents(variants[i].ID, components); err != nil {
+			return nil, err
+		}
+	}
 	// Validate product structure after adding variants
 	if err := p.productService.Validate(*product); err != nil {
 		return nil, err
@@ -477,7 +1098,7 @@ func (p *Product) AddVariants(ctx context.Context, param http.AddProductVariants
 	if err != nil {
 		return nil, err
 	}
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
 
 	// Reload product to get fresh data with option values populated from DB
 	refreshedProduct, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
@@ -509,9 +1130,18 @@ func (p *Product) UpdateVariant(ctx context.Context, param http.UpdateProductVar
 		param.ProductVariantID,
 		param.Data.Price,
 		param.Data.Quantity,
+		param.UserID,
 	); err != nil {
 		return nil, err
 	}
+	if param.Data.LowStockThreshold != nil {
+		if err := product.UpdateVariantLowStockThreshold(
+			param.ProductVariantID,
+			*param.Data.LowStockThreshold,
+		); err != nil {
+			return nil, err
+		}
+	}
 	variant := product.GetVariantByID(param.ProductVariantID)
 	if variant == nil {
 		return nil, domain.ErrNotFound
@@ -523,18 +1153,321 @@ func (p *Product) UpdateVariant(ctx context.Context, param http.UpdateProductVar
 	if err != nil {
 		return nil, err
 	}
-	// Invalidate product list caches
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(
+		ctx,
+		domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID),
+		domain.NewChangeEvent(domain.ChangeEntityProductVariant, domain.ChangeKindContent, variant.ID),
+	)
 	return http.ToProductVariantResponseDto(variant), nil
 }
 
+func (p *Product) UpdateVariantPricing(ctx context.Context, param http.UpdateProductVariantPricingRequestDto) (*http.ProductVariantResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	var saleStartsAt, saleEndsAt time.Time
+	if param.Data.SaleStartsAt != nil {
+		saleStartsAt = *param.Data.SaleStartsAt
+	}
+	if param.Data.SaleEndsAt != nil {
+		saleEndsAt = *param.Data.SaleEndsAt
+	}
+	if err := product.UpdateVariantPricing(
+		param.ProductVariantID,
+		param.Data.CompareAtPrice,
+		param.Data.SalePrice,
+		saleStartsAt,
+		saleEndsAt,
+	); err != nil {
+		return nil, err
+	}
+	variant := product.GetVariantByID(param.ProductVariantID)
+	if variant == nil {
+		return nil, domain.ErrNotFound
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID))
+	return http.ToProductVariantResponseDto(variant), nil
+}
+
+func (p *Product) UpdateVariantComponents(ctx context.Context, param http.UpdateProductVariantComponentsRequestDto) (*http.ProductVariantResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	components, err := p.toBundleComponents(ctx, param.Data.Components)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.UpdateVariantComponents(param.ProductVariantID, components); err != nil {
+		return nil, err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
+
+	// Reload so the stock of the variant is derived from the new components
+	refreshedProduct, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	variant := refreshedProduct.GetVariantByID(param.ProductVariantID)
+	if variant == nil {
+		return nil, domain.ErrNotFound
+	}
+	return http.ToProductVariantResponseDto(variant), nil
+}
+
+// toBundleComponents checks that every component is a remaining variant of a
+// standard product, bundles can not be nested
+func (p *Product) toBundleComponents(
+	ctx context.Context,
+	data []http.BundleComponentData,
+) ([]domain.BundleComponent, error) {
+	variantIDs := make([]uuid.UUID, 0, len(data))
+	for _, componentData := range data {
+		variantIDs = append(variantIDs, componentData.VariantID)
+	}
+	products, err := p.productRepo.List(ctx, domain.ProductRepositoryListParam{
+		VariantIDs: variantIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	variantIDBundleMap := make(map[uuid.UUID]bool)
+	for _, product := range *products {
+		for _, variant := range product.RemainingVariants() {
+			variantIDBundleMap[variant.ID] = product.IsBundle()
+		}
+	}
+	components := make([]domain.BundleComponent, 0, len(data))
+	for _, componentData := range data {
+		isBundle, ok := variantIDBundleMap[componentData.VariantID]
+		if !ok {
+			return nil, domain.ErrNotFound
+		}
+		if isBundle {
+			return nil, domain.ErrInvalid
+		}
+		components = append(components, domain.BundleComponent{
+			VariantID: componentData.VariantID,
+			Quantity:  componentData.Quantity,
+		})
+	}
+	return components, nil
+}
+
+// SyncPrices refreshes the prices of products whose sale started or ended
+func (p *Product) SyncPrices(ctx context.Context) error {
+	productIDs, err := p.productRepo.SyncPrices(ctx, domain.ProductRepositorySyncPricesParam{
+		Now: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(*productIDs) > 0 {
+		_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, *productIDs...))
+	}
+	return nil
+}
+
+func (p *Product) ListStockMovements(ctx context.Context, param http.ListStockMovementsRequestDto) (*http.PaginationResponseDto[http.StockMovementResponseDto], error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	if product.GetVariantByID(param.ProductVariantID) == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	stockMovements, err := p.productRepo.ListStockMovements(ctx, domain.ProductRepositoryListStockMovementsParam{
+		ProductVariantID: param.ProductVariantID,
+		Kinds:            param.Kinds,
+		Limit:            param.Limit,
+		Offset:           (param.Page - 1) * param.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.productRepo.CountStockMovements(ctx, domain.ProductRepositoryCountStockMovementsParam{
+		ProductVariantID: param.ProductVariantID,
+		Kinds:            param.Kinds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stockMovementDtos := make([]http.StockMovementResponseDto, 0, len(*stockMovements))
+	for _, stockMovement := range *stockMovements {
+		stockMovementDtos = append(stockMovementDtos, *http.ToStockMovementResponseDto(&stockMovement))
+	}
+
+	return newPaginationResponseDto(
+		stockMovementDtos,
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+func (p *Product) CreateStockMovement(ctx context.Context, param http.CreateStockMovementRequestDto) (*http.StockMovementResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	orderID := uuid.Nil
+	if param.Data.OrderID != nil {
+		orderID = *param.Data.OrderID
+	}
+	warehouseID := uuid.Nil
+	if param.Data.WarehouseID != nil {
+		warehouseID = *param.Data.WarehouseID
+	}
+	stockMovement, err := product.RecordStockMovement(
+		param.ProductVariantID,
+		warehouseID,
+		param.Data.Kind,
+		param.Data.Quantity,
+		param.UserID,
+		orderID,
+		param.Data.Note,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(
+		ctx,
+		domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID),
+		domain.NewChangeEvent(domain.ChangeEntityProductVariant, domain.ChangeKindContent, param.ProductVariantID),
+	)
+	return http.ToStockMovementResponseDto(stockMovement), nil
+}
+
+// SubscribeStock asks to be notified once the variant is back in stock,
+// subscribing again re-arms a subscription which was already notified
+func (p *Product) SubscribeStock(ctx context.Context, param http.SubscribeProductVariantStockRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return err
+	}
+	variant := product.GetVariantByID(param.ProductVariantID)
+	if variant == nil || !variant.DeletedAt.IsZero() {
+		return domain.ErrNotFound
+	}
+	return p.productRepo.SaveStockSubscription(ctx, domain.ProductRepositorySaveStockSubscriptionParam{
+		ProductVariantID: param.ProductVariantID,
+		UserID:           param.UserID,
+	})
+}
+
+func (p *Product) UnsubscribeStock(ctx context.Context, param http.UnsubscribeProductVariantStockRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return err
+	}
+	if product.GetVariantByID(param.ProductVariantID) == nil {
+		return domain.ErrNotFound
+	}
+	return p.productRepo.DeleteStockSubscription(ctx, domain.ProductRepositoryDeleteStockSubscriptionParam{
+		ProductVariantID: param.ProductVariantID,
+		UserID:           param.UserID,
+	})
+}
+
+// ReconcileStock resets variant and warehouse quantities which drifted from
+// the ledger
+func (p *Product) ReconcileStock(ctx context.Context) error {
+	count, err := p.productRepo.ReconcileQuantities(ctx)
+	if err != nil {
+		return err
+	}
+	if *count > 0 {
+		_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent))
+	}
+	return nil
+}
+
+// CleanupImages deletes the uploads which were never persisted and the images
+// with their renditions no product shows or gets back when it is restored.
+// Objects younger than the minimum age are kept, an upload may still be on its
+// way to a product and an image is stored before its row is saved
+func (p *Product) CleanupImages(ctx context.Context) (*job.CleanupImagesResult, error) {
+	objects, err := p.productObjectStorage.ListImageObjects(ctx, ListImageObjectsParam{
+		ModifiedBefore: time.Now().Add(-p.srvCfg.ProductImageCleanupMinAge),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// An image is listed once along with each of its renditions
+	seen := make(map[uuid.UUID]struct{})
+	imageIDs := make([]uuid.UUID, 0, len(*objects))
+	for _, object := range *objects {
+		if _, ok := seen[object.ImageID]; object.Temp || ok {
+			continue
+		}
+		seen[object.ImageID] = struct{}{}
+		imageIDs = append(imageIDs, object.ImageID)
+	}
+	inUse := make(map[uuid.UUID]struct{})
+	if len(imageIDs) > 0 {
+		inUseIDs, err := p.productRepo.ListImageIDsInUse(ctx, domain.ProductRepositoryListImageIDsInUseParam{
+			ImageIDs: imageIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range *inUseIDs {
+			inUse[id] = struct{}{}
+		}
+	}
+
+	result := &job.CleanupImagesResult{DryRun: p.srvCfg.ProductImageCleanupDryRun}
+	for _, object := range *objects {
+		if object.Temp {
+			result.TempKeys = append(result.TempKeys, object.Key)
+			continue
+		}
+		if _, ok := inUse[object.ImageID]; !ok {
+			result.OrphanKeys = append(result.OrphanKeys, object.Key)
+		}
+	}
+	if result.DryRun {
+		return result, nil
+	}
+	keys := slices.Concat(result.TempKeys, result.OrphanKeys)
+	if err := p.productObjectStorage.DeleteObjects(ctx, keys); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (p *Product) AddImages(ctx context.Context, param http.AddProductImagesRequestDto) (*[]http.ProductImageResponseDto, error) {
 	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
 	if err != nil {
 		return nil, err
 	}
 	images := make([]domain.ProductImage, 0, len(param.Data))
-	imageKeyImageIDMap := make(map[string]uuid.UUID, len(param.Data))
 	for _, imgData := range param.Data {
 		image, err := domain.NewProductImage(
 			imgData.Order,
@@ -543,13 +1476,21 @@ func (p *Product) AddImages(ctx context.Context, param http.AddProductImagesRequ
 		if err != nil {
 			return nil, err
 		}
+		renditions, err := p.productObjectStorage.PersistImageFromTemp(
+			ctx,
+			imgData.Key,
+			image.ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		image.Renditions = *renditions
 		if imgData.ProductVariantID != uuid.Nil {
 			if err := product.AddVariantImages(imgData.ProductVariantID, *image); err != nil {
 				return nil, err
 			}
 		} else {
 			images = append(images, *image)
-			imageKeyImageIDMap[imgData.Key] = image.ID
 		}
 	}
 	product.AddImages(images...)
@@ -557,18 +1498,7 @@ func (p *Product) AddImages(ctx context.Context, param http.AddProductImagesRequ
 	if err != nil {
 		return nil, err
 	}
-	for key, imageID := range imageKeyImageIDMap {
-		err = p.productObjectStorage.PersistImageFromTemp(
-			ctx,
-			key,
-			imageID,
-		)
-		if err != nil {
-			return nil, err
-		}
-	}
-	// Invalidate product caches
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
 	imageDtos := http.ToProductImageResponseDtoList(images)
 	return &imageDtos, nil
 }
@@ -578,27 +1508,12 @@ func (p *Product) DeleteImages(ctx context.Context, param http.DeleteProductImag
 	if err != nil {
 		return err
 	}
-	imageIDs := make(map[uuid.UUID]struct{}, len(param.ImageIDs))
-	for _, id := range param.ImageIDs {
-		imageIDs[id] = struct{}{}
-	}
-	for i := range product.Images {
-		if _, exists := imageIDs[product.Images[i].ID]; exists {
-			product.Images[i].Remove()
-		}
-	}
-	for i := range product.Variants {
-		for j := range product.Variants[i].Images {
-			if _, exists := imageIDs[product.Variants[i].Images[j].ID]; exists {
-				product.Variants[i].Images[j].Remove()
-			}
-		}
-	}
+	product.RemoveImages(param.ImageIDs...)
 	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
 	if err != nil {
 		return err
 	}
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
 	return nil
 }
 
@@ -625,11 +1540,85 @@ func (p *Product) UpdateOptions(ctx context.Context, param http.UpdateProductOpt
 	if err != nil {
 		return nil, err
 	}
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
 	optionDtos := http.ToProductOptionResponseDtoList(options)
 	return &optionDtos, nil
 }
 
+func (p *Product) DeleteVariant(ctx context.Context, param http.DeleteProductVariantRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return err
+	}
+	if err := product.RemoveVariant(param.ProductVariantID); err != nil {
+		return err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return err
+	}
+	_ = p.changeHandler.Handle(
+		ctx,
+		domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindListing, product.ID),
+		domain.NewChangeEvent(domain.ChangeEntityProductVariant, domain.ChangeKindContent, param.ProductVariantID),
+	)
+	return nil
+}
+
+func (p *Product) AddOption(ctx context.Context, param http.AddProductOptionRequestDto) (*http.ProductOptionResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	option, err := domain.NewProductOption(param.Data.Name)
+	if err != nil {
+		return nil, err
+	}
+	optionValues, err := domain.CreateOptionValues(param.Data.Values)
+	if err != nil {
+		return nil, err
+	}
+	option.AddOptionValues(optionValues...)
+	variantValues := make(map[uuid.UUID]string, len(param.Data.Variants))
+	for _, variantData := range param.Data.Variants {
+		variantValues[variantData.ProductVariantID] = variantData.Value
+	}
+	if err := product.AddOption(*option, variantValues); err != nil {
+		return nil, err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
+	return http.ToProductOptionResponseDto(option), nil
+}
+
+func (p *Product) DeleteOption(ctx context.Context, param http.DeleteProductOptionRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return err
+	}
+	if err := product.RemoveOption(param.OptionID); err != nil {
+		return err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
+	return nil
+}
+
 func (p *Product) UpdateOptionValues(ctx context.Context, param http.UpdateProductOptionValuesRequestDto) (*[]http.ProductOptionValueResponseDto, error) {
 	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
 	if err != nil {
@@ -654,11 +1643,58 @@ func (p *Product) UpdateOptionValues(ctx context.Context, param http.UpdateProdu
 	if err != nil {
 		return nil, err
 	}
-	_ = p.productCache.InvalidateAlls(ctx)
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
 	optionValueDtos := http.ToProductOptionValueResponseDtoList(optionValues)
 	return &optionValueDtos, nil
 }
 
+func (p *Product) AddOptionValues(ctx context.Context, param http.AddProductOptionValuesRequestDto) (*[]http.ProductOptionValueResponseDto, error) {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(param.Data))
+	for _, data := range param.Data {
+		values = append(values, data.Value)
+	}
+	optionValues, err := domain.CreateOptionValues(values)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.AddOptionValues(param.OptionID, optionValues...); err != nil {
+		return nil, err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return nil, err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return nil, err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
+	optionValueDtos := http.ToProductOptionValueResponseDtoList(optionValues)
+	return &optionValueDtos, nil
+}
+
+func (p *Product) DeleteOptionValue(ctx context.Context, param http.DeleteProductOptionValueRequestDto) error {
+	product, err := p.productRepo.Get(ctx, domain.ProductRepositoryGetParam{ProductID: param.ProductID})
+	if err != nil {
+		return err
+	}
+	if err := product.RemoveOptionValue(param.OptionID, param.OptionValueID); err != nil {
+		return err
+	}
+	if err := p.productService.Validate(*product); err != nil {
+		return err
+	}
+	err = p.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *product})
+	if err != nil {
+		return err
+	}
+	_ = p.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntityProduct, domain.ChangeKindContent, product.ID))
+	return nil
+}
+
 func (p *Product) GetUploadImageURL(ctx context.Context) (*http.UploadImageURLResponseDto, error) {
 	url, err := p.productObjectStorage.GetUploadImageURL(ctx)
 	if err != nil {
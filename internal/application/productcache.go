@@ -12,11 +12,20 @@ import (
 type ProductCache interface {
 	Get(ctx context.Context, param ProductCacheParam) (*http.ProductResponseDto, error)
 	Set(ctx context.Context, param ProductCacheParam, product *http.ProductResponseDto) error
+	// GetOrLoad returns the cached product or the one of load, which runs
+	// once at a time per product. A stale product is returned while load
+	// refreshes it in the background
+	GetOrLoad(ctx context.Context, param ProductCacheParam, load func(ctx context.Context) (*http.ProductResponseDto, error)) (*http.ProductResponseDto, error)
 	Invalidate(ctx context.Context, param ProductCacheParam) error
 	GetList(ctx context.Context, param ProductCacheListParam) (*http.PaginationResponseDto[http.ProductResponseDto], error)
 	SetList(ctx context.Context, param ProductCacheListParam, pagination *http.PaginationResponseDto[http.ProductResponseDto]) error
+	GetOrLoadList(ctx context.Context, param ProductCacheListParam, load func(ctx context.Context) (*http.PaginationResponseDto[http.ProductResponseDto], error)) (*http.PaginationResponseDto[http.ProductResponseDto], error)
 	InvalidateList(ctx context.Context, param ProductCacheListParam) error
-	InvalidateAlls(ctx context.Context) error
+	GetSuggestions(ctx context.Context, param ProductCacheSuggestionsParam) (*[]http.ProductSuggestionResponseDto, error)
+	SetSuggestions(ctx context.Context, param ProductCacheSuggestionsParam, suggestions *[]http.ProductSuggestionResponseDto) error
+	GetRecommendations(ctx context.Context, param ProductCacheRecommendationsParam) (*[]http.ProductResponseDto, error)
+	SetRecommendations(ctx context.Context, param ProductCacheRecommendationsParam, products *[]http.ProductResponseDto) error
+	InvalidateRecommendations(ctx context.Context) error
 }
 
 type ProductCacheParam struct {
@@ -24,15 +33,32 @@ type ProductCacheParam struct {
 }
 
 type ProductCacheListParam struct {
-	IDs         []uuid.UUID
-	Search      string
-	MinPrice    int64
-	MaxPrice    int64
-	Rating      float64
-	CategoryIDs []uuid.UUID
-	Deleted     domain.DeletedParam
-	SortRating  string
-	SortPrice   string
-	Limit       int
-	Page        int
+	IDs          []uuid.UUID
+	Search       string
+	MinPrice     int64
+	MaxPrice     int64
+	Rating       float64
+	CategoryIDs  []uuid.UUID
+	Deleted      domain.DeletedParam
+	Statuses     []domain.ProductStatus
+	OnSale       bool
+	SortTrending bool
+	SortRating   string
+	SortPrice    string
+	SortDiscount string
+	Limit        int
+	Page         int
+	Cursor       *string
+	WithCount    bool
+}
+
+type ProductCacheSuggestionsParam struct {
+	Search string
+	Limit  int
+}
+
+type ProductCacheRecommendationsParam struct {
+	ProductID uuid.UUID
+	Kind      domain.ProductRecommendationKind
+	Limit     int
 }
@@ -2,8 +2,10 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/delivery/http"
+	"backend/internal/domain"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +13,21 @@ import (
 type ProductObjectStorage interface {
 	GetUploadImageURL(ctx context.Context) (*http.UploadImageURLResponseDto, error)
 	GetDeleteImageURL(ctx context.Context, imageID uuid.UUID) (*http.DeleteImageURLResponseDto, error)
-	PersistImageFromTemp(ctx context.Context, key string, imageID uuid.UUID) error
+	PersistImageFromTemp(ctx context.Context, key string, imageID uuid.UUID) (*[]domain.ProductImageRendition, error)
+	DeleteImages(ctx context.Context, imageIDs []uuid.UUID) error
 	BuildImageURL(imageID uuid.UUID) string
+	ListImageObjects(ctx context.Context, param ListImageObjectsParam) (*[]ProductImageObject, error)
+	DeleteObjects(ctx context.Context, keys []string) error
+}
+
+type ListImageObjectsParam struct {
+	ModifiedBefore time.Time
+}
+
+// ProductImageObject is a stored image or rendition of ImageID, or an upload
+// waiting in the temporary folder when Temp is set
+type ProductImageObject struct {
+	Key     string
+	ImageID uuid.UUID
+	Temp    bool
 }
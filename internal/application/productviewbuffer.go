@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductViewBuffer collects product views outside of Postgres so reading a
+// product does not cost a write.
+type ProductViewBuffer interface {
+	// Record counts a view unless the same viewer already viewed the product
+	// within DedupTTL.
+	Record(ctx context.Context, param ProductViewBufferRecordParam) error
+	// Drain claims the pending views counts for one flush at a time, it
+	// returns nil while another flush holds them. They are kept until Ack
+	// with the token of the drain so a failed flush is retried on a later
+	// Drain.
+	Drain(ctx context.Context) (*ProductViewDrain, error)
+	Ack(ctx context.Context, token string) error
+}
+
+type ProductViewDrain struct {
+	Token       string
+	ViewsCounts map[uuid.UUID]int
+}
+
+type ProductViewBufferRecordParam struct {
+	ProductID uuid.UUID
+	ViewerKey string
+	DedupTTL  time.Duration
+}
@@ -0,0 +1,212 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/delivery/http"
+	"backend/internal/domain"
+)
+
+type SpecGroup struct {
+	changeHandler    ChangeEventHandler
+	specGroupRepo    domain.SpecGroupRepository
+	specGroupService domain.SpecGroupService
+}
+
+func ProvideSpecGroup(
+	changeHandler ChangeEventHandler,
+	specGroupRepo domain.SpecGroupRepository,
+	specGroupService domain.SpecGroupService,
+) *SpecGroup {
+	return &SpecGroup{
+		changeHandler:    changeHandler,
+		specGroupRepo:    specGroupRepo,
+		specGroupService: specGroupService,
+	}
+}
+
+var _ http.SpecGroupApplication = (*SpecGroup)(nil)
+
+func (s *SpecGroup) List(ctx context.Context, param http.ListSpecGroupsRequestDto) (*http.PaginationResponseDto[http.SpecGroupResponseDto], error) {
+	specGroups, err := s.specGroupRepo.List(
+		ctx,
+		domain.SpecGroupRepositoryListParam{
+			Deleted: domain.DeletedExcludeParam,
+			Limit:   param.Limit,
+			Offset:  (param.Page - 1) * param.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.specGroupRepo.Count(ctx, domain.SpecGroupRepositoryCountParam{
+		Deleted: domain.DeletedExcludeParam,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginationResponseDto(
+		http.ToSpecGroupResponseDtoList(*specGroups),
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+func (s *SpecGroup) Get(ctx context.Context, param http.GetSpecGroupRequestDto) (*http.SpecGroupResponseDto, error) {
+	specGroup, err := s.specGroupRepo.Get(ctx, domain.SpecGroupRepositoryGetParam{ID: param.SpecGroupID})
+	if err != nil {
+		return nil, err
+	}
+	return http.ToSpecGroupResponseDto(specGroup), nil
+}
+
+func (s *SpecGroup) Create(ctx context.Context, param http.CreateSpecGroupRequestDto) (*http.SpecGroupResponseDto, error) {
+	specGroup, err := domain.NewSpecGroup(param.Data.Name, param.Data.Order)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := toSpecs(param.Data.Specs)
+	if err != nil {
+		return nil, err
+	}
+	specGroup.AddSpecs(specs...)
+	if err := s.specGroupService.Validate(*specGroup); err != nil {
+		return nil, err
+	}
+
+	err = s.specGroupRepo.Save(ctx, domain.SpecGroupRepositorySaveParam{SpecGroup: *specGroup})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToSpecGroupResponseDto(specGroup), nil
+}
+
+func (s *SpecGroup) Update(ctx context.Context, param http.UpdateSpecGroupRequestDto) (*http.SpecGroupResponseDto, error) {
+	specGroup, err := s.specGroupRepo.Get(ctx, domain.SpecGroupRepositoryGetParam{ID: param.SpecGroupID})
+	if err != nil {
+		return nil, err
+	}
+
+	specGroup.Update(param.Data.Name, param.Data.Order)
+
+	if err := s.specGroupService.Validate(*specGroup); err != nil {
+		return nil, err
+	}
+
+	err = s.specGroupRepo.Save(ctx, domain.SpecGroupRepositorySaveParam{SpecGroup: *specGroup})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntitySpecGroup, domain.ChangeKindContent, specGroup.ID))
+
+	return http.ToSpecGroupResponseDto(specGroup), nil
+}
+
+func (s *SpecGroup) Delete(ctx context.Context, param http.DeleteSpecGroupRequestDto) error {
+	specGroup, err := s.specGroupRepo.Get(ctx, domain.SpecGroupRepositoryGetParam{ID: param.SpecGroupID})
+	if err != nil {
+		return err
+	}
+
+	specGroup.Remove()
+
+	err = s.specGroupRepo.Save(ctx, domain.SpecGroupRepositorySaveParam{SpecGroup: *specGroup})
+	if err != nil {
+		return err
+	}
+
+	_ = s.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntitySpecGroup, domain.ChangeKindContent, specGroup.ID))
+
+	return nil
+}
+
+func (s *SpecGroup) AddSpecs(ctx context.Context, param http.AddSpecsRequestDto) (*http.SpecGroupResponseDto, error) {
+	specGroup, err := s.specGroupRepo.Get(ctx, domain.SpecGroupRepositoryGetParam{ID: param.SpecGroupID})
+	if err != nil {
+		return nil, err
+	}
+	specs, err := toSpecs(param.Data.Specs)
+	if err != nil {
+		return nil, err
+	}
+	specGroup.AddSpecs(specs...)
+
+	if err := s.specGroupService.Validate(*specGroup); err != nil {
+		return nil, err
+	}
+
+	err = s.specGroupRepo.Save(ctx, domain.SpecGroupRepositorySaveParam{SpecGroup: *specGroup})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToSpecGroupResponseDto(specGroup), nil
+}
+
+func (s *SpecGroup) UpdateSpec(ctx context.Context, param http.UpdateSpecRequestDto) (*http.SpecResponseDto, error) {
+	specGroup, err := s.specGroupRepo.Get(ctx, domain.SpecGroupRepositoryGetParam{ID: param.SpecGroupID})
+	if err != nil {
+		return nil, err
+	}
+
+	err = specGroup.UpdateSpec(
+		param.SpecID,
+		param.Data.Name,
+		param.Data.Unit,
+		param.Data.Choices,
+		param.Data.Order,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.specGroupService.Validate(*specGroup); err != nil {
+		return nil, err
+	}
+
+	err = s.specGroupRepo.Save(ctx, domain.SpecGroupRepositorySaveParam{SpecGroup: *specGroup})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntitySpecGroup, domain.ChangeKindContent, specGroup.ID))
+
+	return http.ToSpecResponseDto(specGroup.GetSpecByID(param.SpecID)), nil
+}
+
+func (s *SpecGroup) DeleteSpec(ctx context.Context, param http.DeleteSpecRequestDto) error {
+	specGroup, err := s.specGroupRepo.Get(ctx, domain.SpecGroupRepositoryGetParam{ID: param.SpecGroupID})
+	if err != nil {
+		return err
+	}
+
+	if err := specGroup.RemoveSpec(param.SpecID); err != nil {
+		return err
+	}
+
+	err = s.specGroupRepo.Save(ctx, domain.SpecGroupRepositorySaveParam{SpecGroup: *specGroup})
+	if err != nil {
+		return err
+	}
+
+	_ = s.changeHandler.Handle(ctx, domain.NewChangeEvent(domain.ChangeEntitySpecGroup, domain.ChangeKindContent, specGroup.ID))
+
+	return nil
+}
+
+func toSpecs(data []http.CreateSpecData) ([]domain.Spec, error) {
+	specs := make([]domain.Spec, 0, len(data))
+	for _, d := range data {
+		spec, err := domain.NewSpec(d.Code, d.Name, d.Type, d.Unit, d.Choices, d.Order)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, *spec)
+	}
+	return specs, nil
+}
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
+	"backend/internal/domain"
+)
+
+type Task struct {
+	taskRepo    domain.TaskRepository
+	taskService domain.TaskService
+	srvCfg      *config.Server
+}
+
+func ProvideTask(
+	taskRepo domain.TaskRepository,
+	taskService domain.TaskService,
+	srvCfg *config.Server,
+) *Task {
+	return &Task{
+		taskRepo:    taskRepo,
+		taskService: taskService,
+		srvCfg:      srvCfg,
+	}
+}
+
+var _ http.TaskApplication = (*Task)(nil)
+
+var _ job.TaskApplication = (*Task)(nil)
+
+func (t *Task) List(ctx context.Context, param http.ListTaskRequestDto) (*http.PaginationResponseDto[http.TaskResponseDto], error) {
+	switch param.Status {
+	case "",
+		domain.TaskStatusPending,
+		domain.TaskStatusSucceeded,
+		domain.TaskStatusDead:
+	default:
+		return nil, domain.ErrInvalid
+	}
+
+	tasks, err := t.taskRepo.List(ctx, domain.TaskRepositoryListParam{
+		Kinds:  param.Kinds,
+		Status: param.Status,
+		Limit:  param.Limit,
+		Offset: (param.Page - 1) * param.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := t.taskRepo.Count(ctx, domain.TaskRepositoryCountParam{
+		Kinds:  param.Kinds,
+		Status: param.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginationResponseDto(
+		http.ToTaskResponseDtoList(*tasks),
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+func (t *Task) Get(ctx context.Context, param http.GetTaskRequestDto) (*http.TaskResponseDto, error) {
+	task, err := t.taskRepo.Get(ctx, domain.TaskRepositoryGetParam{ID: param.TaskID})
+	if err != nil {
+		return nil, err
+	}
+	return http.ToTaskResponseDto(task), nil
+}
+
+func (t *Task) Retry(ctx context.Context, param http.RetryTaskRequestDto) (*http.TaskResponseDto, error) {
+	task, err := t.taskRepo.Get(ctx, domain.TaskRepositoryGetParam{ID: param.TaskID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := task.Retry(); err != nil {
+		return nil, err
+	}
+
+	err = t.taskRepo.Save(ctx, domain.TaskRepositorySaveParam{Task: *task})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToTaskResponseDto(task), nil
+}
+
+// EnqueueTasks queues tasks for the worker, those with the key of a task of
+// the same kind already queued are skipped
+func (t *Task) EnqueueTasks(ctx context.Context, params []job.EnqueueTaskParam) error {
+	tasks := make([]domain.Task, 0, len(params))
+	for _, param := range params {
+		task, err := domain.NewTask(param.Kind, param.Key, param.Payload, param.RunAt)
+		if err != nil {
+			return err
+		}
+		if err := t.taskService.Validate(*task); err != nil {
+			return err
+		}
+		tasks = append(tasks, *task)
+	}
+	return t.taskRepo.Create(ctx, domain.TaskRepositoryCreateParam{Tasks: tasks})
+}
+
+// RunTasks runs a batch of due tasks of the given kinds concurrently, each
+// under a deadline of the lease so it is not claimed again while it runs. A
+// task interrupted by the shutdown of the worker is left to the next claim
+// once its lease expires, it does not use up an attempt
+func (t *Task) RunTasks(ctx context.Context, param job.RunTasksParam) error {
+	if len(param.Kinds) == 0 {
+		return nil
+	}
+	now := time.Now()
+	tasks, err := t.taskRepo.Claim(ctx, domain.TaskRepositoryClaimParam{
+		Kinds:      param.Kinds,
+		Now:        now,
+		LeaseUntil: now.Add(t.srvCfg.TaskLease),
+		Limit:      t.srvCfg.TaskRunBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(*tasks))
+	var wg sync.WaitGroup
+	for i := range *tasks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = t.runTask(ctx, &(*tasks)[i], param.Handle)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (t *Task) runTask(ctx context.Context, task *domain.Task, handle job.TaskHandleFunc) error {
+	runCtx, cancel := context.WithTimeout(ctx, t.srvCfg.TaskLease)
+	err := handle(runCtx, *task)
+	cancel()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		task.RecordFailed(
+			err.Error(),
+			t.srvCfg.TaskRetryBaseDelay,
+			t.srvCfg.TaskRetryMaxDelay,
+			t.srvCfg.TaskMaxAttempts,
+		)
+	} else {
+		task.RecordSucceeded()
+	}
+	return t.taskRepo.Save(ctx, domain.TaskRepositorySaveParam{Task: *task})
+}
+
+// PurgeTasks deletes the tasks which succeeded before the retention period,
+// dead tasks are kept until they are retried
+func (t *Task) PurgeTasks(ctx context.Context) error {
+	_, err := t.taskRepo.DeleteSucceeded(ctx, domain.TaskRepositoryDeleteSucceededParam{
+		Before: time.Now().Add(-t.srvCfg.TaskRetention),
+	})
+	return err
+}
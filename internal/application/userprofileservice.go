@@ -0,0 +1,21 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UserProfileService reads the profile of a user from the identity provider,
+// it fails with domain.ErrNotFound when the user does not exist
+type UserProfileService interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*UserProfile, error)
+}
+
+type UserProfile struct {
+	Email     string
+	FirstName string
+	LastName  string
+	// Locale is the language the user picked, empty when they did not
+	Locale string
+}
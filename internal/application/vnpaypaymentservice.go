@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/domain"
 
@@ -14,12 +15,16 @@ type VNPayPaymentService interface {
 		param GetPaymentURLVNPayParam,
 	) (string, error)
 
+	// VerifyIPN gives getOrder the time the customer paid, zero when the
+	// payment failed. onRefundDue is called instead of onSuccess when the
+	// payment was captured for an order cancelled meanwhile
 	VerifyIPN(
 		ctx context.Context,
 		param VerifyIPNVNPayParam,
-		getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error),
+		getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
 		onSuccess func(ctx context.Context, order *domain.Order) error,
 		onFailure func(ctx context.Context, order *domain.Order) error,
+		onRefundDue func(ctx context.Context, order *domain.Order) error,
 	) (code, message string, err error)
 }
 
@@ -7,6 +7,7 @@ package application
 import (
 	"backend/internal/domain"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
@@ -106,8 +107,8 @@ func (_c *MockVNPayPaymentService_GetPaymentURL_Call) RunAndReturn(run func(ctx
 }
 
 // VerifyIPN provides a mock function for the type MockVNPayPaymentService
-func (_mock *MockVNPayPaymentService) VerifyIPN(ctx context.Context, param VerifyIPNVNPayParam, getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), onSuccess func(ctx context.Context, order *domain.Order) error, onFailure func(ctx context.Context, order *domain.Order) error) (string, string, error) {
-	ret := _mock.Called(ctx, param, getOrder, onSuccess, onFailure)
+func (_mock *MockVNPayPaymentService) VerifyIPN(ctx context.Context, param VerifyIPNVNPayParam, getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), onSuccess func(ctx context.Context, order *domain.Order) error, onFailure func(ctx context.Context, order *domain.Order) error, onRefundDue func(ctx context.Context, order *domain.Order) error) (string, string, error) {
+	ret := _mock.Called(ctx, param, getOrder, onSuccess, onFailure, onRefundDue)
 
 	if len(ret) == 0 {
 		panic("no return value specified for VerifyIPN")
@@ -116,21 +117,21 @@ func (_mock *MockVNPayPaymentService) VerifyIPN(ctx context.Context, param Verif
 	var r0 string
 	var r1 string
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) (string, string, error)); ok {
-		return returnFunc(ctx, param, getOrder, onSuccess, onFailure)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) (string, string, error)); ok {
+		return returnFunc(ctx, param, getOrder, onSuccess, onFailure, onRefundDue)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) string); ok {
-		r0 = returnFunc(ctx, param, getOrder, onSuccess, onFailure)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) string); ok {
+		r0 = returnFunc(ctx, param, getOrder, onSuccess, onFailure, onRefundDue)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) string); ok {
-		r1 = returnFunc(ctx, param, getOrder, onSuccess, onFailure)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) string); ok {
+		r1 = returnFunc(ctx, param, getOrder, onSuccess, onFailure, onRefundDue)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) error); ok {
-		r2 = returnFunc(ctx, param, getOrder, onSuccess, onFailure)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, VerifyIPNVNPayParam, func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error, func(ctx context.Context, order *domain.Order) error) error); ok {
+		r2 = returnFunc(ctx, param, getOrder, onSuccess, onFailure, onRefundDue)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -145,14 +146,15 @@ type MockVNPayPaymentService_VerifyIPN_Call struct {
 // VerifyIPN is a helper method to define mock.On call
 //   - ctx context.Context
 //   - param VerifyIPNVNPayParam
-//   - getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error)
+//   - getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error)
 //   - onSuccess func(ctx context.Context, order *domain.Order) error
 //   - onFailure func(ctx context.Context, order *domain.Order) error
-func (_e *MockVNPayPaymentService_Expecter) VerifyIPN(ctx interface{}, param interface{}, getOrder interface{}, onSuccess interface{}, onFailure interface{}) *MockVNPayPaymentService_VerifyIPN_Call {
-	return &MockVNPayPaymentService_VerifyIPN_Call{Call: _e.mock.On("VerifyIPN", ctx, param, getOrder, onSuccess, onFailure)}
+//   - onRefundDue func(ctx context.Context, order *domain.Order) error
+func (_e *MockVNPayPaymentService_Expecter) VerifyIPN(ctx interface{}, param interface{}, getOrder interface{}, onSuccess interface{}, onFailure interface{}, onRefundDue interface{}) *MockVNPayPaymentService_VerifyIPN_Call {
+	return &MockVNPayPaymentService_VerifyIPN_Call{Call: _e.mock.On("VerifyIPN", ctx, param, getOrder, onSuccess, onFailure, onRefundDue)}
 }
 
-func (_c *MockVNPayPaymentService_VerifyIPN_Call) Run(run func(ctx context.Context, param VerifyIPNVNPayParam, getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), onSuccess func(ctx context.Context, order *domain.Order) error, onFailure func(ctx context.Context, order *domain.Order) error)) *MockVNPayPaymentService_VerifyIPN_Call {
+func (_c *MockVNPayPaymentService_VerifyIPN_Call) Run(run func(ctx context.Context, param VerifyIPNVNPayParam, getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), onSuccess func(ctx context.Context, order *domain.Order) error, onFailure func(ctx context.Context, order *domain.Order) error, onRefundDue func(ctx context.Context, order *domain.Order) error)) *MockVNPayPaymentService_VerifyIPN_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -162,9 +164,9 @@ func (_c *MockVNPayPaymentService_VerifyIPN_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(VerifyIPNVNPayParam)
 		}
-		var arg2 func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error)
+		var arg2 func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error)
 		if args[2] != nil {
-			arg2 = args[2].(func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error))
+			arg2 = args[2].(func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error))
 		}
 		var arg3 func(ctx context.Context, order *domain.Order) error
 		if args[3] != nil {
@@ -174,12 +176,17 @@ func (_c *MockVNPayPaymentService_VerifyIPN_Call) Run(run func(ctx context.Conte
 		if args[4] != nil {
 			arg4 = args[4].(func(ctx context.Context, order *domain.Order) error)
 		}
+		var arg5 func(ctx context.Context, order *domain.Order) error
+		if args[5] != nil {
+			arg5 = args[5].(func(ctx context.Context, order *domain.Order) error)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -190,7 +197,7 @@ func (_c *MockVNPayPaymentService_VerifyIPN_Call) Return(code string, message st
 	return _c
 }
 
-func (_c *MockVNPayPaymentService_VerifyIPN_Call) RunAndReturn(run func(ctx context.Context, param VerifyIPNVNPayParam, getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error), onSuccess func(ctx context.Context, order *domain.Order) error, onFailure func(ctx context.Context, order *domain.Order) error) (string, string, error)) *MockVNPayPaymentService_VerifyIPN_Call {
+func (_c *MockVNPayPaymentService_VerifyIPN_Call) RunAndReturn(run func(ctx context.Context, param VerifyIPNVNPayParam, getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error), onSuccess func(ctx context.Context, order *domain.Order) error, onFailure func(ctx context.Context, order *domain.Order) error, onRefundDue func(ctx context.Context, order *domain.Order) error) (string, string, error)) *MockVNPayPaymentService_VerifyIPN_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+
+	"backend/internal/delivery/http"
+	"backend/internal/domain"
+)
+
+type Warehouse struct {
+	warehouseRepo    domain.WarehouseRepository
+	warehouseService domain.WarehouseService
+}
+
+func ProvideWarehouse(warehouseRepo domain.WarehouseRepository, warehouseService domain.WarehouseService) *Warehouse {
+	return &Warehouse{
+		warehouseRepo:    warehouseRepo,
+		warehouseService: warehouseService,
+	}
+}
+
+var _ http.WarehouseApplication = (*Warehouse)(nil)
+
+func (w *Warehouse) Create(ctx context.Context, param http.CreateWarehouseRequestDto) (*http.WarehouseResponseDto, error) {
+	warehouse, err := domain.NewWarehouse(
+		param.Data.Name,
+		param.Data.Province,
+		param.Data.Priority,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.warehouseService.Validate(*warehouse); err != nil {
+		return nil, err
+	}
+
+	err = w.warehouseRepo.Save(ctx, domain.WarehouseRepositorySaveParam{Warehouse: *warehouse})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToWarehouseResponseDto(warehouse), nil
+}
+
+func (w *Warehouse) List(ctx context.Context, param http.ListWarehouseRequestDto) (*http.PaginationResponseDto[http.WarehouseResponseDto], error) {
+	warehouses, err := w.warehouseRepo.List(
+		ctx,
+		domain.WarehouseRepositoryListParam{
+			Deleted: domain.DeletedExcludeParam,
+			Limit:   param.Limit,
+			Offset:  (param.Page - 1) * param.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := w.warehouseRepo.Count(ctx, domain.WarehouseRepositoryCountParam{
+		Deleted: domain.DeletedExcludeParam,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginationResponseDto(
+		http.ToWarehouseResponseDtoList(*warehouses),
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+func (w *Warehouse) Get(ctx context.Context, param http.GetWarehouseRequestDto) (*http.WarehouseResponseDto, error) {
+	warehouse, err := w.warehouseRepo.Get(ctx, domain.WarehouseRepositoryGetParam{ID: param.WarehouseID})
+	if err != nil {
+		return nil, err
+	}
+	return http.ToWarehouseResponseDto(warehouse), nil
+}
+
+func (w *Warehouse) Update(ctx context.Context, param http.UpdateWarehouseRequestDto) (*http.WarehouseResponseDto, error) {
+	warehouse, err := w.warehouseRepo.Get(ctx, domain.WarehouseRepositoryGetParam{ID: param.WarehouseID})
+	if err != nil {
+		return nil, err
+	}
+
+	warehouse.Update(param.Data.Name, param.Data.Province, param.Data.Priority)
+
+	if err := w.warehouseService.Validate(*warehouse); err != nil {
+		return nil, err
+	}
+
+	err = w.warehouseRepo.Save(ctx, domain.WarehouseRepositorySaveParam{Warehouse: *warehouse})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToWarehouseResponseDto(warehouse), nil
+}
+
+func (w *Warehouse) Delete(ctx context.Context, param http.DeleteWarehouseRequestDto) error {
+	warehouse, err := w.warehouseRepo.Get(ctx, domain.WarehouseRepositoryGetParam{ID: param.WarehouseID})
+	if err != nil {
+		return err
+	}
+
+	// Stock left at a deleted warehouse could still be sold from it
+	count, err := w.warehouseRepo.CountStocks(ctx, domain.WarehouseRepositoryCountStocksParam{
+		WarehouseID: warehouse.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if *count > 0 {
+		return domain.ErrConflict
+	}
+
+	warehouse.Remove()
+
+	return w.warehouseRepo.Save(ctx, domain.WarehouseRepositorySaveParam{Warehouse: *warehouse})
+}
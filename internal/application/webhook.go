@@ -0,0 +1,345 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type Webhook struct {
+	eventConsumer       EventConsumer
+	webhookSender       WebhookSender
+	webhookRepo         domain.WebhookRepository
+	webhookDeliveryRepo domain.WebhookDeliveryRepository
+	webhookService      domain.WebhookService
+	srvCfg              *config.Server
+}
+
+func ProvideWebhook(
+	eventConsumer EventConsumer,
+	webhookSender WebhookSender,
+	webhookRepo domain.WebhookRepository,
+	webhookDeliveryRepo domain.WebhookDeliveryRepository,
+	webhookService domain.WebhookService,
+	srvCfg *config.Server,
+) *Webhook {
+	return &Webhook{
+		eventConsumer:       eventConsumer,
+		webhookSender:       webhookSender,
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		webhookService:      webhookService,
+		srvCfg:              srvCfg,
+	}
+}
+
+var _ http.WebhookApplication = (*Webhook)(nil)
+
+var _ job.WebhookApplication = (*Webhook)(nil)
+
+func (w *Webhook) Create(ctx context.Context, param http.CreateWebhookRequestDto) (*http.WebhookResponseDto, error) {
+	webhook, err := domain.NewWebhook(
+		param.Data.URL,
+		param.Data.EventTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.webhookService.Validate(*webhook); err != nil {
+		return nil, err
+	}
+
+	err = w.webhookRepo.Save(ctx, domain.WebhookRepositorySaveParam{Webhook: *webhook})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToWebhookResponseDtoWithSecret(webhook), nil
+}
+
+func (w *Webhook) List(ctx context.Context, param http.ListWebhookRequestDto) (*http.PaginationResponseDto[http.WebhookResponseDto], error) {
+	webhooks, err := w.webhookRepo.List(
+		ctx,
+		domain.WebhookRepositoryListParam{
+			Deleted: domain.DeletedExcludeParam,
+			Limit:   param.Limit,
+			Offset:  (param.Page - 1) * param.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := w.webhookRepo.Count(ctx, domain.WebhookRepositoryCountParam{
+		Deleted: domain.DeletedExcludeParam,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginationResponseDto(
+		http.ToWebhookResponseDtoList(*webhooks),
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+func (w *Webhook) Get(ctx context.Context, param http.GetWebhookRequestDto) (*http.WebhookResponseDto, error) {
+	webhook, err := w.webhookRepo.Get(ctx, domain.WebhookRepositoryGetParam{ID: param.WebhookID})
+	if err != nil {
+		return nil, err
+	}
+	return http.ToWebhookResponseDto(webhook), nil
+}
+
+func (w *Webhook) Update(ctx context.Context, param http.UpdateWebhookRequestDto) (*http.WebhookResponseDto, error) {
+	webhook, err := w.webhookRepo.Get(ctx, domain.WebhookRepositoryGetParam{ID: param.WebhookID})
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Update(param.Data.URL, param.Data.EventTypes, param.Data.Active)
+	if param.Data.RotateSecret {
+		if err := webhook.RotateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.webhookService.Validate(*webhook); err != nil {
+		return nil, err
+	}
+
+	err = w.webhookRepo.Save(ctx, domain.WebhookRepositorySaveParam{Webhook: *webhook})
+	if err != nil {
+		return nil, err
+	}
+
+	if param.Data.RotateSecret {
+		return http.ToWebhookResponseDtoWithSecret(webhook), nil
+	}
+	return http.ToWebhookResponseDto(webhook), nil
+}
+
+func (w *Webhook) Delete(ctx context.Context, param http.DeleteWebhookRequestDto) error {
+	webhook, err := w.webhookRepo.Get(ctx, domain.WebhookRepositoryGetParam{ID: param.WebhookID})
+	if err != nil {
+		return err
+	}
+
+	webhook.Remove()
+
+	return w.webhookRepo.Save(ctx, domain.WebhookRepositorySaveParam{Webhook: *webhook})
+}
+
+func (w *Webhook) ListDeliveries(
+	ctx context.Context,
+	param http.ListWebhookDeliveryRequestDto,
+) (*http.PaginationResponseDto[http.WebhookDeliveryResponseDto], error) {
+	switch param.Status {
+	case "",
+		domain.WebhookDeliveryStatusPending,
+		domain.WebhookDeliveryStatusSucceeded,
+		domain.WebhookDeliveryStatusFailed:
+	default:
+		return nil, domain.ErrInvalid
+	}
+	if _, err := w.webhookRepo.Get(ctx, domain.WebhookRepositoryGetParam{ID: param.WebhookID}); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := w.webhookDeliveryRepo.List(ctx, domain.WebhookDeliveryRepositoryListParam{
+		WebhookID: param.WebhookID,
+		Status:    param.Status,
+		Limit:     param.Limit,
+		Offset:    (param.Page - 1) * param.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := w.webhookDeliveryRepo.Count(ctx, domain.WebhookDeliveryRepositoryCountParam{
+		WebhookID: param.WebhookID,
+		Status:    param.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginationResponseDto(
+		http.ToWebhookDeliveryResponseDtoList(*deliveries),
+		*count,
+		param.Page,
+		param.Limit,
+	), nil
+}
+
+// Redeliver makes a delivery pending again, the delivery job sends it on its
+// next run
+func (w *Webhook) Redeliver(
+	ctx context.Context,
+	param http.RedeliverWebhookDeliveryRequestDto,
+) (*http.WebhookDeliveryResponseDto, error) {
+	if _, err := w.webhookRepo.Get(ctx, domain.WebhookRepositoryGetParam{ID: param.WebhookID}); err != nil {
+		return nil, err
+	}
+	delivery, err := w.webhookDeliveryRepo.Get(ctx, domain.WebhookDeliveryRepositoryGetParam{ID: param.DeliveryID})
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != param.WebhookID {
+		return nil, domain.ErrNotFound
+	}
+
+	delivery.Redeliver()
+
+	err = w.webhookDeliveryRepo.Save(ctx, domain.WebhookDeliveryRepositorySaveParam{Delivery: *delivery})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ToWebhookDeliveryResponseDto(delivery), nil
+}
+
+// EnqueueDeliveries creates a delivery of each consumed event for every active
+// webhook subscribed to it. Events consumed twice are only delivered once
+func (w *Webhook) EnqueueDeliveries(ctx context.Context) error {
+	return w.eventConsumer.Consume(ctx, w.srvCfg.WebhookEventGroup, w.enqueueDeliveries)
+}
+
+func (w *Webhook) enqueueDeliveries(ctx context.Context, events []domain.OutboxEvent) error {
+	subscribers := make(map[domain.EventType][]domain.Webhook)
+	var deliveries []domain.WebhookDelivery
+	for _, event := range events {
+		if !slices.Contains(domain.WebhookEventTypes, event.Type) {
+			continue
+		}
+		webhooks, ok := subscribers[event.Type]
+		if !ok {
+			list, err := w.webhookRepo.List(ctx, domain.WebhookRepositoryListParam{
+				EventType: event.Type,
+				Deleted:   domain.DeletedExcludeParam,
+			})
+			if err != nil {
+				return err
+			}
+			webhooks = *list
+			subscribers[event.Type] = webhooks
+		}
+		for _, webhook := range webhooks {
+			delivery, err := domain.NewWebhookDelivery(webhook.ID, event)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return w.webhookDeliveryRepo.Create(ctx, domain.WebhookDeliveryRepositoryCreateParam{
+		Deliveries: deliveries,
+	})
+}
+
+// SendDeliveries sends a batch of due deliveries concurrently. A delivery
+// sent but not recorded is sent again once its lease expires, receivers dedupe
+// it by the Webhook-Id header, which is the event ID
+func (w *Webhook) SendDeliveries(ctx context.Context) error {
+	now := time.Now()
+	deliveries, err := w.webhookDeliveryRepo.Claim(ctx, domain.WebhookDeliveryRepositoryClaimParam{
+		Now:        now,
+		LeaseUntil: now.Add(w.srvCfg.WebhookDeliveryLease),
+		Limit:      w.srvCfg.WebhookDeliveryBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+	if len(*deliveries) == 0 {
+		return nil
+	}
+
+	webhookIDs := make([]uuid.UUID, 0, len(*deliveries))
+	for _, delivery := range *deliveries {
+		if !slices.Contains(webhookIDs, delivery.WebhookID) {
+			webhookIDs = append(webhookIDs, delivery.WebhookID)
+		}
+	}
+	webhooks, err := w.webhookRepo.List(ctx, domain.WebhookRepositoryListParam{
+		IDs:     webhookIDs,
+		Deleted: domain.DeletedAllParam,
+	})
+	if err != nil {
+		return err
+	}
+	webhookByID := make(map[uuid.UUID]*domain.Webhook, len(*webhooks))
+	for i := range *webhooks {
+		webhookByID[(*webhooks)[i].ID] = &(*webhooks)[i]
+	}
+
+	errs := make([]error, len(*deliveries))
+	var wg sync.WaitGroup
+	for i := range *deliveries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			delivery := &(*deliveries)[i]
+			errs[i] = w.sendDelivery(ctx, webhookByID[delivery.WebhookID], delivery)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (w *Webhook) sendDelivery(
+	ctx context.Context,
+	webhook *domain.Webhook,
+	delivery *domain.WebhookDelivery,
+) error {
+	if webhook == nil || !webhook.Active || !webhook.DeletedAt.IsZero() {
+		delivery.Abandon("webhook is inactive or deleted")
+		return w.webhookDeliveryRepo.Save(ctx, domain.WebhookDeliveryRepositorySaveParam{Delivery: *delivery})
+	}
+
+	resp, err := w.webhookSender.Send(ctx, WebhookSenderSendParam{
+		URL: webhook.URL,
+		Headers: map[string]string{
+			"Content-Type":      "application/json",
+			"Webhook-Id":        delivery.EventID.String(),
+			"Webhook-Delivery":  delivery.ID.String(),
+			"Webhook-Event":     string(delivery.EventType),
+			"Webhook-Signature": webhook.Sign(time.Now(), delivery.Payload),
+		},
+		Body: delivery.Payload,
+	})
+	switch {
+	case err != nil:
+		delivery.RecordFailed(
+			0,
+			"",
+			err.Error(),
+			w.srvCfg.WebhookRetryBaseDelay,
+			w.srvCfg.WebhookRetryMaxDelay,
+			w.srvCfg.WebhookMaxAttempts,
+		)
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		delivery.RecordSucceeded(resp.StatusCode, resp.Body)
+	default:
+		delivery.RecordFailed(
+			resp.StatusCode,
+			resp.Body,
+			fmt.Sprintf("unexpected response status %d", resp.StatusCode),
+			w.srvCfg.WebhookRetryBaseDelay,
+			w.srvCfg.WebhookRetryMaxDelay,
+			w.srvCfg.WebhookMaxAttempts,
+		)
+	}
+	return w.webhookDeliveryRepo.Save(ctx, domain.WebhookDeliveryRepositorySaveParam{Delivery: *delivery})
+}
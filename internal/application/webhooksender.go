@@ -0,0 +1,24 @@
+package application
+
+import (
+	"context"
+)
+
+// WebhookSender posts webhook deliveries to the partner endpoints. Send only
+// fails when no response was received, a response with any status is
+// returned along with the start of its body. The error then describes why the
+// endpoint could not be reached, it is recorded in the delivery log
+type WebhookSender interface {
+	Send(ctx context.Context, param WebhookSenderSendParam) (*WebhookSenderResponse, error)
+}
+
+type WebhookSenderSendParam struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+type WebhookSenderResponse struct {
+	StatusCode int
+	Body       string
+}
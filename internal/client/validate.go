@@ -16,5 +16,8 @@ func NewValidate() *validator.Validate {
 	if err := domain.RegisterOrderValidates(validate); err != nil {
 		panic(err)
 	}
+	if err := domain.RegisterSpecValidates(validate); err != nil {
+		panic(err)
+	}
 	return validate
 }
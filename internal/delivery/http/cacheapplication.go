@@ -0,0 +1,10 @@
+package http
+
+import (
+	"context"
+)
+
+type CacheApplication interface {
+	ListKeys(ctx context.Context, param ListCacheKeysRequestDto) (*CacheKeysResponseDto, error)
+	Invalidate(ctx context.Context, param InvalidateCacheRequestDto) error
+}
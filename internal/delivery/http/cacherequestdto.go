@@ -0,0 +1,35 @@
+package http
+
+import (
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ListCacheKeysRequestDto struct {
+	Entity domain.ChangeEntity
+	ID     *uuid.UUID
+	Match  string
+	Cursor uint64
+	Limit  int
+}
+
+type ListCacheKeysQuery struct {
+	Entity domain.ChangeEntity `form:"entity" binding:"required_with=ID,omitempty,oneof=product product_variant category attribute spec_group"`
+	ID     string              `form:"id"     binding:"omitempty,uuid"`
+	Match  string              `form:"match"`
+	Cursor uint64              `form:"cursor"`
+	Limit  int                 `form:"limit"  binding:"omitempty,gte=1,lte=1000"`
+}
+
+type InvalidateCacheRequestDto struct {
+	Data InvalidateCacheData
+}
+
+type InvalidateCacheData struct {
+	Entity domain.ChangeEntity `json:"entity"  binding:"required,oneof=product product_variant category attribute spec_group"`
+	// IDs are the entities to invalidate, every entity of the type when empty
+	IDs []uuid.UUID `json:"ids"`
+	// Listing also invalidates the listings of the type, as after a create
+	Listing bool `json:"listing"`
+}
@@ -0,0 +1,13 @@
+package http
+
+type CacheKeyResponseDto struct {
+	Key string `json:"key" binding:"required"`
+	// TTL is in seconds, -1 when the key does not expire
+	TTL int64 `json:"ttl" binding:"required"`
+}
+
+type CacheKeysResponseDto struct {
+	Data []CacheKeyResponseDto `json:"data"       binding:"required"`
+	// NextCursor continues the scan, 0 once it is complete
+	NextCursor uint64 `json:"nextCursor" binding:"required"`
+}
@@ -32,11 +32,12 @@ type CartItemProductResponseDto struct {
 }
 
 type CartItemProductVariantResponseDto struct {
-	ID       uuid.UUID                 `json:"id"       binding:"required"`
-	SKU      string                    `json:"sku"      binding:"required"`
-	Price    int64                     `json:"price"    binding:"required"`
-	Quantity int                       `json:"quantity" binding:"required"`
-	Images   []ProductImageResponseDto `json:"images"   binding:"required"`
+	ID             uuid.UUID                 `json:"id"             binding:"required"`
+	SKU            string                    `json:"sku"            binding:"required"`
+	Price          int64                     `json:"price"          binding:"required"`
+	CompareAtPrice int64                     `json:"compareAtPrice" binding:"required"`
+	Quantity       int                       `json:"quantity"       binding:"required"`
+	Images         []ProductImageResponseDto `json:"images"         binding:"required"`
 }
 
 // ToCartResponseDto maps a domain.Cart to CartResponseDto
@@ -90,11 +91,12 @@ func ToCartItemResponseDto(
 		}
 
 		itemDto.ProductVariant = CartItemProductVariantResponseDto{
-			ID:       variant.ID,
-			SKU:      variant.SKU,
-			Price:    variant.Price,
-			Quantity: variant.Quantity,
-			Images:   images,
+			ID:             variant.ID,
+			SKU:            variant.SKU,
+			Price:          variant.EffectivePrice(time.Now()),
+			CompareAtPrice: variant.CompareAtPrice,
+			Quantity:       variant.Quantity,
+			Images:         images,
 		}
 	}
 
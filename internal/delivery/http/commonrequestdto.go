@@ -3,4 +3,8 @@ package http
 type PaginationRequestDto struct {
 	Page  int `binding:"gte=1,lte=50"`
 	Limit int `binding:"gte=1,lte=100"`
+	// Cursor switches to keyset pagination when set, an empty cursor is the first page
+	Cursor *string
+	// WithCount also counts total items in cursor mode, page mode always counts
+	WithCount bool
 }
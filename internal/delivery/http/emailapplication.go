@@ -0,0 +1,9 @@
+package http
+
+import (
+	"context"
+)
+
+type EmailApplication interface {
+	List(ctx context.Context, param ListEmailRequestDto) (*PaginationResponseDto[EmailResponseDto], error)
+}
@@ -0,0 +1,13 @@
+package http
+
+import (
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ListEmailRequestDto struct {
+	PaginationRequestDto
+	UserIDs []uuid.UUID
+	Status  domain.EmailStatus
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailResponseDto represents an entry of the email send log, the bodies are
+// left out
+type EmailResponseDto struct {
+	ID            uuid.UUID          `json:"id"            binding:"required"`
+	Kind          domain.EmailKind   `json:"kind"          binding:"required"`
+	EventID       uuid.UUID          `json:"eventId"       binding:"required"`
+	UserID        uuid.UUID          `json:"userId"        binding:"required"`
+	Recipient     string             `json:"recipient"     binding:"required"`
+	Locale        string             `json:"locale"        binding:"required"`
+	Subject       string             `json:"subject"       binding:"required"`
+	Status        domain.EmailStatus `json:"status"        binding:"required"`
+	Attempts      int                `json:"attempts"      binding:"required"`
+	Error         string             `json:"error"         binding:"required"`
+	NextAttemptAt time.Time          `json:"nextAttemptAt" binding:"required"`
+	CreatedAt     time.Time          `json:"createdAt"     binding:"required"`
+	UpdatedAt     time.Time          `json:"updatedAt"     binding:"required"`
+	SentAt        *time.Time         `json:"sentAt"`
+}
+
+// ToEmailResponseDto maps a domain.Email to EmailResponseDto
+func ToEmailResponseDto(e *domain.Email) *EmailResponseDto {
+	if e == nil {
+		return nil
+	}
+
+	var sentAt *time.Time
+	if !e.SentAt.IsZero() {
+		sentAt = &e.SentAt
+	}
+	return &EmailResponseDto{
+		ID:            e.ID,
+		Kind:          e.Kind,
+		EventID:       e.EventID,
+		UserID:        e.UserID,
+		Recipient:     e.Recipient,
+		Locale:        e.Locale,
+		Subject:       e.Subject,
+		Status:        e.Status,
+		Attempts:      e.Attempts,
+		Error:         e.Error,
+		NextAttemptAt: e.NextAttemptAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+		SentAt:        sentAt,
+	}
+}
+
+// ToEmailResponseDtoList maps a slice of domain.Email to a slice of EmailResponseDto
+func ToEmailResponseDtoList(emails []domain.Email) []EmailResponseDto {
+	result := make([]EmailResponseDto, 0, len(emails))
+	for _, e := range emails {
+		dto := ToEmailResponseDto(&e)
+		if dto != nil {
+			result = append(result, *dto)
+		}
+	}
+	return result
+}
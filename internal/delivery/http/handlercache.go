@@ -0,0 +1,10 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type CacheHandler interface {
+	ListKeys(*gin.Context)
+	Invalidate(*gin.Context)
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CacheHandlerImpl struct {
+	cacheApp     CacheApplication
+	ErrInvalidID string
+}
+
+var _ CacheHandler = (*CacheHandlerImpl)(nil)
+
+func ProvideCacheHandler(cacheApp CacheApplication) *CacheHandlerImpl {
+	return &CacheHandlerImpl{
+		cacheApp:     cacheApp,
+		ErrInvalidID: "invalid id",
+	}
+}
+
+// ListCacheKeys godoc
+//
+//	@Summary		List cache keys
+//	@Description	Scan the cache keys matching a glob pattern, or the keys tagged with an entity type or entity when entity is set. The limit is a hint of how many keys to scan per page, continue with the returned cursor until it is 0
+//	@Tags			Cache
+//	@Accept			json
+//	@Produce		json
+//	@Param			entity	query		string	false	"Entity type the keys contain"	Enums(product, product_variant, category, attribute, spec_group)
+//	@Param			id		query		string	false	"Entity ID the keys contain, requires entity"	format(uuid)
+//	@Param			match	query		string	false	"Glob pattern of the keys"	default(*)
+//	@Param			cursor	query		int		false	"Cursor of the scan"	default(0)
+//	@Param			limit	query		int		false	"Keys to scan per page"	default(100)
+//	@Success		200		{object}	CacheKeysResponseDto
+//	@Failure		400		{object}	Error
+//	@Failure		403		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/admin/cache/keys [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *CacheHandlerImpl) ListKeys(ctx *gin.Context) {
+	var query ListCacheKeysQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+	param := ListCacheKeysRequestDto{
+		Entity: query.Entity,
+		Match:  query.Match,
+		Cursor: query.Cursor,
+		Limit:  query.Limit,
+	}
+	if param.Match == "" {
+		param.Match = "*"
+	}
+	if param.Limit == 0 {
+		param.Limit = 100
+	}
+	if query.ID != "" {
+		id, err := uuid.Parse(query.ID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidID))
+			return
+		}
+		param.ID = &id
+	}
+
+	keys, err := h.cacheApp.ListKeys(ctx.Request.Context(), param)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, keys)
+}
+
+// InvalidateCache godoc
+//
+//	@Summary		Invalidate cache entries
+//	@Description	Invalidate the cache entries containing the given entities, or every entity of the type when ids is empty, in Redis and in the local cache of every instance
+//	@Tags			Cache
+//	@Accept			json
+//	@Produce		json
+//	@Param			invalidation	body	InvalidateCacheData	true	"Invalidate cache request"
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/admin/cache/invalidate [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *CacheHandlerImpl) Invalidate(ctx *gin.Context) {
+	var data InvalidateCacheData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	err := h.cacheApp.Invalidate(ctx.Request.Context(), InvalidateCacheRequestDto{
+		Data: data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
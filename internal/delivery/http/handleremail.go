@@ -0,0 +1,9 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type EmailHandler interface {
+	List(*gin.Context)
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+
+	"backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailHandlerImpl struct {
+	emailApp EmailApplication
+}
+
+var _ EmailHandler = (*EmailHandlerImpl)(nil)
+
+func ProvideEmailHandler(emailApp EmailApplication) *EmailHandlerImpl {
+	return &EmailHandlerImpl{
+		emailApp: emailApp,
+	}
+}
+
+// ListEmails godoc
+//
+//	@Summary		List sent emails
+//	@Description	Get the email send log, latest first, with the outcome of the last attempt of each email
+//	@Tags			Email
+//	@Accept			json
+//	@Produce		json
+//	@Param			user_ids	query		[]string			false	"Filter by user IDs"	collectionFormat(csv)	format(uuid)
+//	@Param			status		query		domain.EmailStatus	false	"Filter by status"
+//	@Param			page		query		int					false	"Page for pagination"	default(1)
+//	@Param			limit		query		int					false	"Limit for pagination"	default(20)
+//	@Success		200			{object}	PaginationResponseDto[EmailResponseDto]
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/admin/emails [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *EmailHandlerImpl) List(ctx *gin.Context) {
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	userIDs, _ := queryArrayToUUIDSlice(ctx, "user_ids")
+
+	emails, err := h.emailApp.List(ctx, ListEmailRequestDto{
+		PaginationRequestDto: *paginateParam,
+		UserIDs:              userIDs,
+		Status:               domain.EmailStatus(ctx.Query("status")),
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, emails)
+}
@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler interface {
+	List(*gin.Context)
+	ListStaff(*gin.Context)
+	MarkRead(*gin.Context)
+	MarkStaffRead(*gin.Context)
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandlerImpl struct {
+	notificationApp           NotificationApplication
+	ErrInvalidNotificationID  string
+	ErrInvalidUserID          string
+	ErrInvalidUnreadParameter string
+}
+
+var _ NotificationHandler = (*NotificationHandlerImpl)(nil)
+
+func ProvideNotificationHandler(notificationApp NotificationApplication) *NotificationHandlerImpl {
+	return &NotificationHandlerImpl{
+		notificationApp:           notificationApp,
+		ErrInvalidNotificationID:  "invalid notification_id",
+		ErrInvalidUserID:          "invalid user_id",
+		ErrInvalidUnreadParameter: "invalid unread parameter",
+	}
+}
+
+// ListNotifications godoc
+//
+//	@Summary		List notifications
+//	@Description	List notifications of the current user, newest first
+//	@Tags			Notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int		false	"Page for pagination"	default(1)
+//	@Param			limit	query		int		false	"Limit for pagination"	default(20)
+//	@Param			unread	query		bool	false	"Only unread notifications"
+//	@Success		200		{object}	PaginationResponseDto[NotificationResponseDto]
+//	@Failure		400		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/notifications [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *NotificationHandlerImpl) List(ctx *gin.Context) {
+	userID, ok := ctxValueToUUID(ctx, "userID")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidUserID))
+		return
+	}
+	h.list(ctx, ListNotificationsRequestDto{UserID: userID})
+}
+
+// ListStaffNotifications godoc
+//
+//	@Summary		List staff notifications
+//	@Description	List notifications shared by admins and staff, such as low stock alerts
+//	@Tags			Notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int		false	"Page for pagination"	default(1)
+//	@Param			limit	query		int		false	"Limit for pagination"	default(20)
+//	@Param			unread	query		bool	false	"Only unread notifications"
+//	@Success		200		{object}	PaginationResponseDto[NotificationResponseDto]
+//	@Failure		400		{object}	Error
+//	@Failure		403		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/notifications/staff [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *NotificationHandlerImpl) ListStaff(ctx *gin.Context) {
+	h.list(ctx, ListNotificationsRequestDto{Staff: true})
+}
+
+func (h *NotificationHandlerImpl) list(ctx *gin.Context, param ListNotificationsRequestDto) {
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	param.PaginationRequestDto = *paginateParam
+
+	if unreadQuery := ctx.Query("unread"); unreadQuery != "" {
+		unread, err := strconv.ParseBool(unreadQuery)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidUnreadParameter))
+			return
+		}
+		param.Unread = unread
+	}
+
+	notifications, err := h.notificationApp.List(ctx.Request.Context(), param)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead godoc
+//
+//	@Summary		Mark notification as read
+//	@Description	Mark a notification of the current user as read
+//	@Tags			Notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			notification_id	path		string	true	"Notification ID"	format(uuid)
+//	@Success		200				{object}	NotificationResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/notifications/{notification_id}/read [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *NotificationHandlerImpl) MarkRead(ctx *gin.Context) {
+	userID, ok := ctxValueToUUID(ctx, "userID")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidUserID))
+		return
+	}
+	h.markRead(ctx, MarkNotificationReadRequestDto{UserID: userID})
+}
+
+// MarkStaffNotificationRead godoc
+//
+//	@Summary		Mark staff notification as read
+//	@Description	Mark a notification shared by admins and staff as read
+//	@Tags			Notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			notification_id	path		string	true	"Notification ID"	format(uuid)
+//	@Success		200				{object}	NotificationResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/notifications/staff/{notification_id}/read [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *NotificationHandlerImpl) MarkStaffRead(ctx *gin.Context) {
+	h.markRead(ctx, MarkNotificationReadRequestDto{Staff: true})
+}
+
+func (h *NotificationHandlerImpl) markRead(ctx *gin.Context, param MarkNotificationReadRequestDto) {
+	notificationID, ok := pathToUUID(ctx, "notification_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidNotificationID))
+		return
+	}
+	param.NotificationID = notificationID
+
+	notification, err := h.notificationApp.MarkRead(ctx.Request.Context(), param)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, notification)
+}
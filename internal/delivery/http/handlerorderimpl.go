@@ -72,6 +72,8 @@ func (h *OrderHandlerImpl) Get(ctx *gin.Context) {
 //	@Param			status		query		domain.OrderStatus	false	"Filter by statuses"
 //	@Param			page		query		int					false	"Page for pagination"	default(1)
 //	@Param			limit		query		int					false	"Limit for pagination"	default(20)
+//	@Param			cursor		query		string				false	"Cursor for keyset pagination, empty for the first page, overrides page"
+//	@Param			count		query		bool				false	"Count total items in cursor mode"
 //	@Success		200			{array}		OrderResponseDto
 //	@Failure		500			{object}	Error
 //	@Router			/orders [get]
@@ -170,8 +172,10 @@ func (h *OrderHandlerImpl) Update(ctx *gin.Context) {
 		return
 	}
 
+	userID, _ := ctxValueToUUID(ctx, "userID")
 	order, err := h.orderApp.Update(ctx, UpdateOrderRequestDto{
 		OrderID: orderID,
+		UserID:  userID,
 		Data:    data,
 	})
 	if err != nil {
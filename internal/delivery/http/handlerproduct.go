@@ -7,14 +7,33 @@ import (
 type ProductHandler interface {
 	Get(*gin.Context)
 	List(*gin.Context)
+	ListSuggestions(*gin.Context)
+	ListRelated(*gin.Context)
+	ListBoughtTogether(*gin.Context)
+	Compare(*gin.Context)
 	Create(*gin.Context)
 	Update(*gin.Context)
+	UpdatePublishing(*gin.Context)
+	UpdateSpecs(*gin.Context)
 	Delete(*gin.Context)
+	Restore(*gin.Context)
+	Purge(*gin.Context)
 	AddImages(*gin.Context)
 	DeleteImages(*gin.Context)
 	AddVariants(*gin.Context)
 	UpdateVariant(*gin.Context)
+	UpdateVariantPricing(*gin.Context)
+	UpdateVariantComponents(*gin.Context)
+	DeleteVariant(*gin.Context)
+	ListStockMovements(*gin.Context)
+	CreateStockMovement(*gin.Context)
+	SubscribeStock(*gin.Context)
+	UnsubscribeStock(*gin.Context)
 	UpdateOptions(*gin.Context)
+	AddOption(*gin.Context)
+	DeleteOption(*gin.Context)
+	AddOptionValues(*gin.Context)
+	DeleteOptionValue(*gin.Context)
 	GetDeleteImageURL(*gin.Context)
 	GetUploadImageURL(*gin.Context)
 }
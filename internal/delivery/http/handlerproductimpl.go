@@ -3,6 +3,8 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"backend/internal/domain"
 
@@ -13,6 +15,11 @@ type ProductHandlerImpl struct {
 	productApp           ProductApplication
 	ErrRequiredProductID string
 	ErrInvalidProductID  string
+	ErrRequiredSearch    string
+	ErrInvalidLimit      string
+	ErrCursorSort        string
+	ErrInvalidUserID     string
+	ErrInvalidCompare    string
 }
 
 var _ ProductHandler = (*ProductHandlerImpl)(nil)
@@ -22,6 +29,11 @@ func ProvideProductHandler(productApp ProductApplication) *ProductHandlerImpl {
 		productApp:           productApp,
 		ErrRequiredProductID: "product_id is required",
 		ErrInvalidProductID:  "invalid product_id",
+		ErrRequiredSearch:    "search is required",
+		ErrInvalidLimit:      "limit must be between 1 and 20",
+		ErrCursorSort:        "cursor pagination supports only one of sort, sort_price and sort_rating",
+		ErrInvalidUserID:     "invalid user_id",
+		ErrInvalidCompare:    "product_ids must list between 2 and 4 products",
 	}
 }
 
@@ -37,14 +49,20 @@ func ProvideProductHandler(productApp ProductApplication) *ProductHandlerImpl {
 //	@Failure		404			{object}	Error
 //	@Failure		500			{object}	Error
 //	@Router			/products/{product_id} [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
 func (h *ProductHandlerImpl) Get(ctx *gin.Context) {
 	productID, ok := pathToUUID(ctx, "product_id")
 	if !ok {
 		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
 		return
 	}
+	userID, _ := ctxValueToUUID(ctx, "userID")
 	product, err := h.productApp.Get(ctx.Request.Context(), GetProductRequestDto{
-		ProductID: productID,
+		ProductID:     productID,
+		UserID:        userID,
+		ClientIP:      ctx.ClientIP(),
+		PublishedOnly: !ctxHasRole(ctx, RoleAdmin, RoleStaff),
 	})
 	if err != nil {
 		SendError(ctx, err)
@@ -56,24 +74,32 @@ func (h *ProductHandlerImpl) Get(ctx *gin.Context) {
 // ListProducts godoc
 //
 //	@Summary		List all products
-//	@Description	Get all products, used for search and suggestions also
+//	@Description	Get all products, used for search also
 //	@Tags			Product
 //	@Accept			json
 //	@Produce		json
 //	@Param			search			query		string		false	"Search term"
 //	@Param			page			query		int			false	"Page for pagination"		default(1)
 //	@Param			limit			query		int			false	"Limit for pagination"		default(20)
+//	@Param			cursor			query		string		false	"Cursor for keyset pagination, empty for the first page, overrides page"
+//	@Param			count			query		bool		false	"Count total items in cursor mode"
 //	@Param			deleted			query		string		false	"Filter by deleted status"	Enums(exclude, only, all)
+//	@Param			sort			query		string		false	"Sort by trending score"	Enums(trending)
 //	@Param			sort_price		query		string		false	"Sort by price"				Enums(asc, desc)
 //	@Param			sort_rating		query		string		false	"Sort by rating"			Enums(asc, desc)
+//	@Param			sort_discount	query		string		false	"Sort by discount"			Enums(asc, desc)
+//	@Param			on_sale			query		bool		false	"Filter by products on sale"
 //	@Param			category_ids	query		[]string	false	"Filter by category ID"		CollectionFormat(csv)	format(uuid)
 //	@Param			product_ids		query		[]string	false	"Filter by product ID"		CollectionFormat(csv)	format(uuid)
 //	@Param			min_price		query		int			false	"Minimum price filter"
 //	@Param			max_price		query		int			false	"Maximum price filter"
 //	@Param			rating			query		number		false	"Filter by minimum rating"
+//	@Param			status			query		[]string	false	"Filter by status, staff only, others see published products"	CollectionFormat(multi)	Enums(draft, published, archived)
 //	@Success		200				{object}	PaginationResponseDto[ProductResponseDto]
 //	@Failure		500				{object}	Error
 //	@Router			/products [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
 func (h *ProductHandlerImpl) List(ctx *gin.Context) {
 	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
 	if err != nil {
@@ -111,15 +137,42 @@ func (h *ProductHandlerImpl) List(ctx *gin.Context) {
 		}
 	}
 
+	sortTrending := ctx.Query("sort") == "trending"
+
 	sortPrice, _ := ctx.GetQuery("sort_price")
 
 	sortRating, _ := ctx.GetQuery("sort_rating")
 
+	sortDiscount, _ := ctx.GetQuery("sort_discount")
+
+	onSale := ctx.Query("on_sale") == "true"
+
+	if paginateParam.Cursor != nil && search == "" {
+		sorts := 0
+		for _, sorted := range []bool{sortTrending, sortPrice != "", sortRating != "", sortDiscount != ""} {
+			if sorted {
+				sorts++
+			}
+		}
+		if sorts > 1 {
+			ctx.JSON(http.StatusBadRequest, NewError(h.ErrCursorSort))
+			return
+		}
+	}
+
 	deleted := domain.DeletedExcludeParam
 	if deletedQuery, ok := ctx.GetQuery("deleted"); ok {
 		deleted = domain.DeletedParam(deletedQuery)
 	}
 
+	statuses := []domain.ProductStatus{domain.ProductStatusPublished}
+	if ctxHasRole(ctx, RoleAdmin, RoleStaff) {
+		statuses = nil
+		for _, status := range ctx.QueryArray("status") {
+			statuses = append(statuses, domain.ProductStatus(status))
+		}
+	}
+
 	products, err := h.productApp.List(ctx.Request.Context(), ListProductRequestDto{
 		PaginationRequestDto: *paginateParam,
 		ProductIDs:           productIDs,
@@ -127,18 +180,170 @@ func (h *ProductHandlerImpl) List(ctx *gin.Context) {
 		MinPrice:             minPrice,
 		MaxPrice:             maxPrice,
 		Rating:               rating,
+		SortTrending:         sortTrending,
 		SortPrice:            sortPrice,
 		SortRating:           sortRating,
+		SortDiscount:         sortDiscount,
+		OnSale:               onSale,
 		Search:               search,
 		Deleted:              deleted,
+		Statuses:             statuses,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, products)
+}
+
+// ListProductSuggestions godoc
+//
+//	@Summary		List search suggestions
+//	@Description	Get typo-tolerant, accent-insensitive suggestions of product names, categories and attribute values for a search prefix
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query		string	true	"Search prefix"
+//	@Param			limit	query		int		false	"Limit per suggestion type"	default(5)	minimum(1)	maximum(20)
+//	@Success		200		{array}		ProductSuggestionResponseDto
+//	@Failure		400		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/products/suggestions [get]
+func (h *ProductHandlerImpl) ListSuggestions(ctx *gin.Context) {
+	search := strings.TrimSpace(ctx.Query("search"))
+	if search == "" {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrRequiredSearch))
+		return
+	}
+
+	limit := 5
+	if limitQuery, ok := ctx.GetQuery("limit"); ok {
+		l, err := strconv.Atoi(limitQuery)
+		if err != nil || l < 1 || l > 20 {
+			ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidLimit))
+			return
+		}
+		limit = l
+	}
+
+	suggestions, err := h.productApp.ListSuggestions(ctx.Request.Context(), ListProductSuggestionsRequestDto{
+		Search: search,
+		Limit:  limit,
 	})
 	if err != nil {
 		SendError(ctx, err)
 		return
 	}
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+// ListRelatedProducts godoc
+//
+//	@Summary		List related products
+//	@Description	Get products sharing the category and attribute values of a product
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string	true	"Product ID"	format(uuid)
+//	@Param			limit		query		int		false	"Limit"			default(10)	minimum(1)	maximum(20)
+//	@Success		200			{array}		ProductResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/related [get]
+func (h *ProductHandlerImpl) ListRelated(ctx *gin.Context) {
+	param, ok := h.recommendationsParam(ctx)
+	if !ok {
+		return
+	}
+	products, err := h.productApp.ListRelated(ctx.Request.Context(), *param)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, products)
+}
+
+// ListBoughtTogetherProducts godoc
+//
+//	@Summary		List frequently bought together products
+//	@Description	Get products most often ordered together with a product
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string	true	"Product ID"	format(uuid)
+//	@Param			limit		query		int		false	"Limit"			default(10)	minimum(1)	maximum(20)
+//	@Success		200			{array}		ProductResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/bought-together [get]
+func (h *ProductHandlerImpl) ListBoughtTogether(ctx *gin.Context) {
+	param, ok := h.recommendationsParam(ctx)
+	if !ok {
+		return
+	}
+	products, err := h.productApp.ListBoughtTogether(ctx.Request.Context(), *param)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
 	ctx.JSON(http.StatusOK, products)
 }
 
+// CompareProducts godoc
+//
+//	@Summary		Compare products
+//	@Description	Get products side by side with their specs lined up, values follow the order of product_ids
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_ids	query		[]string	true	"Product IDs, between 2 and 4"	CollectionFormat(multi)	format(uuid)
+//	@Success		200			{object}	ProductComparisonResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/compare [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) Compare(ctx *gin.Context) {
+	productIDs, ok := queryArrayToUUIDSlice(ctx, "product_ids")
+	if !ok || len(productIDs) < 2 || len(productIDs) > 4 {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidCompare))
+		return
+	}
+	comparison, err := h.productApp.Compare(ctx.Request.Context(), CompareProductsRequestDto{
+		ProductIDs:    productIDs,
+		PublishedOnly: !ctxHasRole(ctx, RoleAdmin, RoleStaff),
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, comparison)
+}
+
+func (h *ProductHandlerImpl) recommendationsParam(ctx *gin.Context) (*ListProductRecommendationsRequestDto, bool) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return nil, false
+	}
+
+	limit := 10
+	if limitQuery, ok := ctx.GetQuery("limit"); ok {
+		l, err := strconv.Atoi(limitQuery)
+		if err != nil || l < 1 || l > 20 {
+			ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidLimit))
+			return nil, false
+		}
+		limit = l
+	}
+
+	return &ListProductRecommendationsRequestDto{
+		ProductID: productID,
+		Limit:     limit,
+	}, true
+}
+
 // CreateProduct godoc
 //
 //	@Summary		Create a new product
@@ -212,6 +417,86 @@ func (h *ProductHandlerImpl) Update(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, product)
 }
 
+// UpdateProductPublishing godoc
+//
+//	@Summary		Update product publishing
+//	@Description	Replace the status and the publish schedule of a product
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string						true	"Product ID"	format(uuid)
+//	@Param			publishing	body		UpdateProductPublishingData	true	"Update product publishing request"
+//	@Success		200			{object}	ProductResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/publishing [put]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) UpdatePublishing(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	var data UpdateProductPublishingData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	product, err := h.productApp.UpdatePublishing(ctx.Request.Context(), UpdateProductPublishingRequestDto{
+		ProductID: productID,
+		Data:      data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
+
+// UpdateProductSpecs godoc
+//
+//	@Summary		Update product specs
+//	@Description	Replace the spec values of a product, each value must match the type of its spec
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string					true	"Product ID"	format(uuid)
+//	@Param			specs		body		UpdateProductSpecsData	true	"Update product specs request"
+//	@Success		200			{object}	ProductResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/specs [put]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) UpdateSpecs(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	var data UpdateProductSpecsData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	product, err := h.productApp.UpdateSpecs(ctx.Request.Context(), UpdateProductSpecsRequestDto{
+		ProductID: productID,
+		Data:      data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
+
 // DeleteProduct godoc
 //
 //	@Summary		Delete a product
@@ -243,6 +528,70 @@ func (h *ProductHandlerImpl) Delete(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// RestoreProduct godoc
+//
+//	@Summary		Restore a product
+//	@Description	Restore a deleted product with the variants, options and images deleted along with it
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string	true	"Product ID"	format(uuid)
+//	@Success		200			{object}	ProductResponseDto
+//	@Failure		404			{object}	Error
+//	@Failure		409			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/restore [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) Restore(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	product, err := h.productApp.Restore(ctx.Request.Context(), RestoreProductRequestDto{
+		ProductID: productID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
+
+// PurgeProduct godoc
+//
+//	@Summary		Purge a product
+//	@Description	Permanently delete a deleted product which was never ordered, along with its images
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path	string	true	"Product ID"	format(uuid)
+//	@Success		204
+//	@Failure		404	{object}	Error
+//	@Failure		409	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/products/{product_id}/purge [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) Purge(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	err := h.productApp.Purge(ctx.Request.Context(), PurgeProductRequestDto{
+		ProductID: productID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
 // AddImages godoc
 //
 //	@Summary		Add product images
@@ -399,9 +748,11 @@ func (h *ProductHandlerImpl) UpdateVariant(ctx *gin.Context) {
 		return
 	}
 
+	userID, _ := ctxValueToUUID(ctx, "userID")
 	variant, err := h.productApp.UpdateVariant(ctx.Request.Context(), UpdateProductVariantRequestDto{
 		ProductID:        productID,
 		ProductVariantID: variantID,
+		UserID:           userID,
 		Data:             data,
 	})
 	if err != nil {
@@ -411,51 +762,576 @@ func (h *ProductHandlerImpl) UpdateVariant(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, variant)
 }
 
-// UpdateOptions godoc
+// UpdateVariantPricing godoc
 //
-//	@Summary		Update options
-//	@Description	Update a product options
+//	@Summary		Update product variant pricing
+//	@Description	Replace the compare-at price and the scheduled sale of a product variant
 //	@Tags			Product
 //	@Accept			json
 //	@Produce		json
-//	@Param			product_id	path		string						true	"Product ID"	format(uuid)
-//	@Param			option		body		[]UpdateProductOptionsData	true	"Update product option request"
-//	@Success		200			{array}		ProductOptionResponseDto
+//	@Param			product_id	path		string							true	"Product ID"			format(uuid)
+//	@Param			variant_id	path		string							true	"Product Variant ID"	format(uuid)
+//	@Param			pricing		body		UpdateProductVariantPricingData	true	"Update product variant pricing request"
+//	@Success		200			{object}	ProductVariantResponseDto
 //	@Failure		400			{object}	Error
 //	@Failure		404			{object}	Error
-//	@Failure		409			{object}	Error
 //	@Failure		500			{object}	Error
-//	@Router			/products/{product_id}/options [patch]
+//	@Router			/products/{product_id}/variants/{variant_id}/pricing [put]
 //	@Security		OAuth2AccessCode
 //	@Security		OAuth2Password
-func (h *ProductHandlerImpl) UpdateOptions(ctx *gin.Context) {
+func (h *ProductHandlerImpl) UpdateVariantPricing(ctx *gin.Context) {
 	productID, ok := pathToUUID(ctx, "product_id")
 	if !ok {
 		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
 		return
 	}
 
-	var data []UpdateProductOptionsData
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	var data UpdateProductVariantPricingData
 	if err := ctx.ShouldBindJSON(&data); err != nil {
 		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
 		return
 	}
 
-	options, err := h.productApp.UpdateOptions(ctx.Request.Context(), UpdateProductOptionsRequestDto{
-		ProductID: productID,
-		Data:      data,
+	variant, err := h.productApp.UpdateVariantPricing(ctx.Request.Context(), UpdateProductVariantPricingRequestDto{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+		Data:             data,
 	})
 	if err != nil {
 		SendError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, options)
+	ctx.JSON(http.StatusOK, variant)
+}
+
+// UpdateVariantComponents godoc
+//
+//	@Summary		Update product variant components
+//	@Description	Replace the components of a bundle variant, its stock is derived from them
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string								true	"Product ID"			format(uuid)
+//	@Param			variant_id	path		string								true	"Product Variant ID"	format(uuid)
+//	@Param			components	body		UpdateProductVariantComponentsData	true	"Update product variant components request"
+//	@Success		200			{object}	ProductVariantResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/variants/{variant_id}/components [put]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) UpdateVariantComponents(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	var data UpdateProductVariantComponentsData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	variant, err := h.productApp.UpdateVariantComponents(ctx.Request.Context(), UpdateProductVariantComponentsRequestDto{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+		Data:             data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, variant)
+}
+
+// DeleteVariant godoc
+//
+//	@Summary		Delete a product variant
+//	@Description	Soft delete a product variant, it stays referenced by carts, orders and the stock ledger
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path	string	true	"Product ID"			format(uuid)
+//	@Param			variant_id	path	string	true	"Product Variant ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/products/{product_id}/variants/{variant_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) DeleteVariant(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	err := h.productApp.DeleteVariant(ctx.Request.Context(), DeleteProductVariantRequestDto{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListStockMovements godoc
+//
+//	@Summary		List stock movements of a product variant
+//	@Description	List the inventory ledger of a product variant, newest first
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string		true	"Product ID"			format(uuid)
+//	@Param			variant_id	path		string		true	"Product Variant ID"	format(uuid)
+//	@Param			page		query		int			false	"Page for pagination"	default(1)
+//	@Param			limit		query		int			false	"Limit for pagination"	default(20)
+//	@Param			kinds		query		[]string	false	"Filter by kind"		CollectionFormat(csv)	Enums(restock, sale, cancellation, return, adjustment)
+//	@Success		200			{object}	PaginationResponseDto[StockMovementResponseDto]
+//	@Failure		400			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/variants/{variant_id}/stock-movements [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) ListStockMovements(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	var kinds []domain.StockMovementKind
+	for _, kind := range ctx.QueryArray("kinds") {
+		for k := range strings.SplitSeq(kind, ",") {
+			if k != "" {
+				kinds = append(kinds, domain.StockMovementKind(k))
+			}
+		}
+	}
+
+	stockMovements, err := h.productApp.ListStockMovements(ctx.Request.Context(), ListStockMovementsRequestDto{
+		PaginationRequestDto: *paginateParam,
+		ProductID:            productID,
+		ProductVariantID:     variantID,
+		Kinds:                kinds,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stockMovements)
+}
+
+// CreateStockMovement godoc
+//
+//	@Summary		Record a stock movement
+//	@Description	Restock, return or adjust the quantity of a product variant by a signed amount
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id		path		string					true	"Product ID"			format(uuid)
+//	@Param			variant_id		path		string					true	"Product Variant ID"	format(uuid)
+//	@Param			stockMovement	body		CreateStockMovementData	true	"Stock movement request"
+//	@Success		201				{object}	StockMovementResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/products/{product_id}/variants/{variant_id}/stock-movements [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) CreateStockMovement(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	var data CreateStockMovementData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	userID, _ := ctxValueToUUID(ctx, "userID")
+	stockMovement, err := h.productApp.CreateStockMovement(ctx.Request.Context(), CreateStockMovementRequestDto{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+		UserID:           userID,
+		Data:             data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, stockMovement)
+}
+
+// SubscribeStock godoc
+//
+//	@Summary		Subscribe to back in stock notification
+//	@Description	Notify the current user once the product variant is back in stock
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path	string	true	"Product ID"			format(uuid)
+//	@Param			variant_id	path	string	true	"Product Variant ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/products/{product_id}/variants/{variant_id}/stock-subscriptions [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) SubscribeStock(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	userID, ok := ctxValueToUUID(ctx, "userID")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidUserID))
+		return
+	}
+
+	err := h.productApp.SubscribeStock(ctx.Request.Context(), SubscribeProductVariantStockRequestDto{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+		UserID:           userID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnsubscribeStock godoc
+//
+//	@Summary		Unsubscribe from back in stock notification
+//	@Description	Stop notifying the current user when the product variant is back in stock
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path	string	true	"Product ID"			format(uuid)
+//	@Param			variant_id	path	string	true	"Product Variant ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/products/{product_id}/variants/{variant_id}/stock-subscriptions [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) UnsubscribeStock(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	variantID, ok := pathToUUID(ctx, "variant_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid variant_id"))
+		return
+	}
+
+	userID, ok := ctxValueToUUID(ctx, "userID")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidUserID))
+		return
+	}
+
+	err := h.productApp.UnsubscribeStock(ctx.Request.Context(), UnsubscribeProductVariantStockRequestDto{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+		UserID:           userID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// UpdateOptions godoc
+//
+//	@Summary		Update options
+//	@Description	Update a product options
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string						true	"Product ID"	format(uuid)
+//	@Param			option		body		[]UpdateProductOptionsData	true	"Update product option request"
+//	@Success		200			{array}		ProductOptionResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		409			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/options [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) UpdateOptions(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	var data []UpdateProductOptionsData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	options, err := h.productApp.UpdateOptions(ctx.Request.Context(), UpdateProductOptionsRequestDto{
+		ProductID: productID,
+		Data:      data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, options)
+}
+
+// AddOption godoc
+//
+//	@Summary		Add an option
+//	@Description	Add an option to a product, every remaining variant is mapped to one of its values
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path		string					true	"Product ID"	format(uuid)
+//	@Param			option		body		AddProductOptionData	true	"Add product option request"
+//	@Success		201			{object}	ProductOptionResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		409			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/products/{product_id}/options [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) AddOption(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	var data AddProductOptionData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	option, err := h.productApp.AddOption(ctx.Request.Context(), AddProductOptionRequestDto{
+		ProductID: productID,
+		Data:      data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, option)
+}
+
+// DeleteOption godoc
+//
+//	@Summary		Delete an option
+//	@Description	Delete an option with its values, the remaining variants must still differ by the other options
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path	string	true	"Product ID"	format(uuid)
+//	@Param			option_id	path	string	true	"Option ID"		format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		409	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/products/{product_id}/options/{option_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) DeleteOption(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	optionID, ok := pathToUUID(ctx, "option_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid option_id"))
+		return
+	}
+
+	err := h.productApp.DeleteOption(ctx.Request.Context(), DeleteProductOptionRequestDto{
+		ProductID: productID,
+		OptionID:  optionID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddOptionValues godoc
+//
+//	@Summary		Add option values
+//	@Description	Add values to an option of a product
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id		path		string							true	"Product ID"	format(uuid)
+//	@Param			option_id		path		string							true	"Option ID"		format(uuid)
+//	@Param			optionValues	body		[]AddProductOptionValuesData	true	"Add option values request"
+//	@Success		201				{array}		ProductOptionValueResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		409				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/products/{product_id}/options/{option_id}/values [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) AddOptionValues(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	optionID, ok := pathToUUID(ctx, "option_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid option_id"))
+		return
+	}
+
+	var data []AddProductOptionValuesData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	optionValues, err := h.productApp.AddOptionValues(ctx.Request.Context(), AddProductOptionValuesRequestDto{
+		ProductID: productID,
+		OptionID:  optionID,
+		Data:      data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, optionValues)
+}
+
+// DeleteOptionValue godoc
+//
+//	@Summary		Delete an option value
+//	@Description	Delete a value of an option, the variants using it must be deleted first
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		json
+//	@Param			product_id	path	string	true	"Product ID"		format(uuid)
+//	@Param			option_id	path	string	true	"Option ID"			format(uuid)
+//	@Param			value_id	path	string	true	"Option Value ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		409	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/products/{product_id}/options/{option_id}/values/{value_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *ProductHandlerImpl) DeleteOptionValue(ctx *gin.Context) {
+	productID, ok := pathToUUID(ctx, "product_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidProductID))
+		return
+	}
+
+	optionID, ok := pathToUUID(ctx, "option_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid option_id"))
+		return
+	}
+
+	optionValueID, ok := pathToUUID(ctx, "value_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError("invalid value_id"))
+		return
+	}
+
+	err := h.productApp.DeleteOptionValue(ctx.Request.Context(), DeleteProductOptionValueRequestDto{
+		ProductID:     productID,
+		OptionID:      optionID,
+		OptionValueID: optionValueID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
 }
 
 // GetUploadImageURL godoc
 //
 //	@Summary		Get presigned URL for image upload
-//	@Description	Get a presigned URL to upload product images
+//	@Description	Get a presigned URL to upload product images, JPEG, PNG, GIF and WebP are accepted and they are checked and converted to WebP once added to a product
 //	@Tags			Product
 //	@Produce		json
 //	@Success		200	{object}	UploadImageURLResponseDto
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type SpecGroupHandler interface {
+	List(*gin.Context)
+	Get(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	AddSpecs(*gin.Context)
+	UpdateSpec(*gin.Context)
+	DeleteSpec(*gin.Context)
+}
@@ -0,0 +1,320 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SpecGroupHandlerImpl struct {
+	specGroupApp          SpecGroupApplication
+	ErrInvalidSpecGroupID string
+	ErrInvalidSpecID      string
+}
+
+var _ SpecGroupHandler = (*SpecGroupHandlerImpl)(nil)
+
+func ProvideSpecGroupHandler(specGroupApp SpecGroupApplication) *SpecGroupHandlerImpl {
+	return &SpecGroupHandlerImpl{
+		specGroupApp:          specGroupApp,
+		ErrInvalidSpecGroupID: "invalid spec_group_id",
+		ErrInvalidSpecID:      "invalid spec_id",
+	}
+}
+
+// ListSpecGroups godoc
+//
+//	@Summary		List all spec groups
+//	@Description	Get all spec groups with their specs, ordered by order
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page for pagination"	default(1)
+//	@Param			limit	query		int	false	"Limit for pagination"	default(20)
+//	@Success		200		{object}	PaginationResponseDto[SpecGroupResponseDto]
+//	@Failure		500		{object}	Error
+//	@Router			/spec-groups [get]
+func (h *SpecGroupHandlerImpl) List(ctx *gin.Context) {
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	specGroups, err := h.specGroupApp.List(ctx, ListSpecGroupsRequestDto{
+		PaginationRequestDto: *paginateParam,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, specGroups)
+}
+
+// GetSpecGroup godoc
+//
+//	@Summary		Get spec group by ID
+//	@Description	Get spec group details with its specs by ID
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			spec_group_id	path		string	true	"Spec Group ID"	format(uuid)
+//	@Success		200				{object}	SpecGroupResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/spec-groups/{spec_group_id} [get]
+func (h *SpecGroupHandlerImpl) Get(ctx *gin.Context) {
+	specGroupID, ok := pathToUUID(ctx, "spec_group_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecGroupID))
+		return
+	}
+	specGroup, err := h.specGroupApp.Get(ctx, GetSpecGroupRequestDto{
+		SpecGroupID: specGroupID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, specGroup)
+}
+
+// CreateSpecGroup godoc
+//
+//	@Summary		Create a new spec group
+//	@Description	Create a new spec group with its specs, a number spec may have a unit and an enum spec needs choices
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			specGroup	body		CreateSpecGroupData	true	"Spec group request"
+//	@Success		201			{object}	SpecGroupResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		409			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/spec-groups [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *SpecGroupHandlerImpl) Create(ctx *gin.Context) {
+	var data CreateSpecGroupData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	specGroup, err := h.specGroupApp.Create(ctx, CreateSpecGroupRequestDto{
+		Data: data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, specGroup)
+}
+
+// UpdateSpecGroup godoc
+//
+//	@Summary		Update a spec group
+//	@Description	Update spec group by ID
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			spec_group_id	path		string				true	"Spec Group ID"	format(uuid)
+//	@Param			specGroup		body		UpdateSpecGroupData	true	"Update spec group request"
+//	@Success		200				{object}	SpecGroupResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/spec-groups/{spec_group_id} [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *SpecGroupHandlerImpl) Update(ctx *gin.Context) {
+	specGroupID, ok := pathToUUID(ctx, "spec_group_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecGroupID))
+		return
+	}
+
+	var data UpdateSpecGroupData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	specGroup, err := h.specGroupApp.Update(ctx, UpdateSpecGroupRequestDto{
+		SpecGroupID: specGroupID,
+		Data:        data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, specGroup)
+}
+
+// DeleteSpecGroup godoc
+//
+//	@Summary		Delete a spec group
+//	@Description	Soft delete a spec group and its specs, products keep their values but no longer show them
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			spec_group_id	path	string	true	"Spec Group ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/spec-groups/{spec_group_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *SpecGroupHandlerImpl) Delete(ctx *gin.Context) {
+	specGroupID, ok := pathToUUID(ctx, "spec_group_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecGroupID))
+		return
+	}
+	err := h.specGroupApp.Delete(ctx, DeleteSpecGroupRequestDto{
+		SpecGroupID: specGroupID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddSpecs godoc
+//
+//	@Summary		Add specs to a spec group
+//	@Description	Add new specs to a spec group, codes are unique across groups
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			spec_group_id	path		string			true	"Spec Group ID"	format(uuid)
+//	@Param			specs			body		AddSpecsData	true	"Add specs request"
+//	@Success		201				{object}	SpecGroupResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		409				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/spec-groups/{spec_group_id}/specs [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *SpecGroupHandlerImpl) AddSpecs(ctx *gin.Context) {
+	specGroupID, ok := pathToUUID(ctx, "spec_group_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecGroupID))
+		return
+	}
+
+	var data AddSpecsData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	specGroup, err := h.specGroupApp.AddSpecs(ctx, AddSpecsRequestDto{
+		SpecGroupID: specGroupID,
+		Data:        data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, specGroup)
+}
+
+// UpdateSpec godoc
+//
+//	@Summary		Update a spec
+//	@Description	Update a spec of a spec group, its type cannot change and enum choices can only be added
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			spec_group_id	path		string			true	"Spec Group ID"	format(uuid)
+//	@Param			spec_id			path		string			true	"Spec ID"		format(uuid)
+//	@Param			spec			body		UpdateSpecData	true	"Update spec request"
+//	@Success		200				{object}	SpecResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		409				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/spec-groups/{spec_group_id}/specs/{spec_id} [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *SpecGroupHandlerImpl) UpdateSpec(ctx *gin.Context) {
+	specGroupID, ok := pathToUUID(ctx, "spec_group_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecGroupID))
+		return
+	}
+
+	specID, ok := pathToUUID(ctx, "spec_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecID))
+		return
+	}
+
+	var data UpdateSpecData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	spec, err := h.specGroupApp.UpdateSpec(ctx, UpdateSpecRequestDto{
+		SpecGroupID: specGroupID,
+		SpecID:      specID,
+		Data:        data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, spec)
+}
+
+// DeleteSpec godoc
+//
+//	@Summary		Delete a spec
+//	@Description	Soft delete a spec of a spec group, products keep their values but no longer show them
+//	@Tags			Spec
+//	@Accept			json
+//	@Produce		json
+//	@Param			spec_group_id	path	string	true	"Spec Group ID"	format(uuid)
+//	@Param			spec_id			path	string	true	"Spec ID"		format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/spec-groups/{spec_group_id}/specs/{spec_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *SpecGroupHandlerImpl) DeleteSpec(ctx *gin.Context) {
+	specGroupID, ok := pathToUUID(ctx, "spec_group_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecGroupID))
+		return
+	}
+
+	specID, ok := pathToUUID(ctx, "spec_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidSpecID))
+		return
+	}
+
+	err := h.specGroupApp.DeleteSpec(ctx, DeleteSpecRequestDto{
+		SpecGroupID: specGroupID,
+		SpecID:      specID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,11 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type TaskHandler interface {
+	List(*gin.Context)
+	Get(*gin.Context)
+	Retry(*gin.Context)
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskHandlerImpl struct {
+	taskApp          TaskApplication
+	ErrInvalidTaskID string
+}
+
+var _ TaskHandler = (*TaskHandlerImpl)(nil)
+
+func ProvideTaskHandler(taskApp TaskApplication) *TaskHandlerImpl {
+	return &TaskHandlerImpl{
+		taskApp:          taskApp,
+		ErrInvalidTaskID: "invalid task_id",
+	}
+}
+
+// ListTasks godoc
+//
+//	@Summary		List background tasks
+//	@Description	Get the tasks queued for the worker, latest first. Dead tasks failed all of their attempts and wait to be retried
+//	@Tags			Task
+//	@Accept			json
+//	@Produce		json
+//	@Param			kinds	query		[]string			false	"Filter by kinds"	collectionFormat(csv)
+//	@Param			status	query		domain.TaskStatus	false	"Filter by status"
+//	@Param			page	query		int					false	"Page for pagination"	default(1)
+//	@Param			limit	query		int					false	"Limit for pagination"	default(20)
+//	@Success		200		{object}	PaginationResponseDto[TaskResponseDto]
+//	@Failure		400		{object}	Error
+//	@Failure		403		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/admin/tasks [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *TaskHandlerImpl) List(ctx *gin.Context) {
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	var kinds []domain.TaskKind
+	for _, kind := range ctx.QueryArray("kinds") {
+		for k := range strings.SplitSeq(kind, ",") {
+			if k != "" {
+				kinds = append(kinds, domain.TaskKind(k))
+			}
+		}
+	}
+
+	tasks, err := h.taskApp.List(ctx, ListTaskRequestDto{
+		PaginationRequestDto: *paginateParam,
+		Kinds:                kinds,
+		Status:               domain.TaskStatus(ctx.Query("status")),
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+// GetTask godoc
+//
+//	@Summary		Get background task by ID
+//	@Description	Get a task queued for the worker with the error of its last failed attempt
+//	@Tags			Task
+//	@Accept			json
+//	@Produce		json
+//	@Param			task_id	path		string	true	"Task ID"	format(uuid)
+//	@Success		200		{object}	TaskResponseDto
+//	@Failure		400		{object}	Error
+//	@Failure		403		{object}	Error
+//	@Failure		404		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/admin/tasks/{task_id} [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *TaskHandlerImpl) Get(ctx *gin.Context) {
+	taskID, ok := pathToUUID(ctx, "task_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidTaskID))
+		return
+	}
+	task, err := h.taskApp.Get(ctx, GetTaskRequestDto{
+		TaskID: taskID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, task)
+}
+
+// RetryTask godoc
+//
+//	@Summary		Retry a dead background task
+//	@Description	Run a dead task again with the same payload and a fresh budget of attempts, it is pending until the next run of the worker
+//	@Tags			Task
+//	@Accept			json
+//	@Produce		json
+//	@Param			task_id	path		string	true	"Task ID"	format(uuid)
+//	@Success		202		{object}	TaskResponseDto
+//	@Failure		400		{object}	Error
+//	@Failure		403		{object}	Error
+//	@Failure		404		{object}	Error
+//	@Failure		409		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/admin/tasks/{task_id}/retry [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *TaskHandlerImpl) Retry(ctx *gin.Context) {
+	taskID, ok := pathToUUID(ctx, "task_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidTaskID))
+		return
+	}
+	task, err := h.taskApp.Retry(ctx, RetryTaskRequestDto{
+		TaskID: taskID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, task)
+}
@@ -0,0 +1,13 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseHandler interface {
+	List(*gin.Context)
+	Get(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseHandlerImpl struct {
+	warehouseApp          WarehouseApplication
+	ErrInvalidWarehouseID string
+}
+
+var _ WarehouseHandler = (*WarehouseHandlerImpl)(nil)
+
+func ProvideWarehouseHandler(warehouseApp WarehouseApplication) *WarehouseHandlerImpl {
+	return &WarehouseHandlerImpl{
+		warehouseApp:          warehouseApp,
+		ErrInvalidWarehouseID: "invalid warehouse_id",
+	}
+}
+
+// ListWarehouses godoc
+//
+//	@Summary		List all warehouses
+//	@Description	Get all warehouses ordered by priority
+//	@Tags			Warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page for pagination"	default(1)
+//	@Param			limit	query		int	false	"Limit for pagination"	default(20)
+//	@Success		200		{object}	PaginationResponseDto[WarehouseResponseDto]
+//	@Failure		403		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/warehouses [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WarehouseHandlerImpl) List(ctx *gin.Context) {
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	warehouses, err := h.warehouseApp.List(ctx, ListWarehouseRequestDto{
+		PaginationRequestDto: *paginateParam,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, warehouses)
+}
+
+// GetWarehouse godoc
+//
+//	@Summary		Get warehouse by ID
+//	@Description	Get warehouse details by ID
+//	@Tags			Warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Param			warehouse_id	path		string	true	"Warehouse ID"	format(uuid)
+//	@Success		200				{object}	WarehouseResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/warehouses/{warehouse_id} [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WarehouseHandlerImpl) Get(ctx *gin.Context) {
+	warehouseID, ok := pathToUUID(ctx, "warehouse_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWarehouseID))
+		return
+	}
+	warehouse, err := h.warehouseApp.Get(ctx, GetWarehouseRequestDto{
+		WarehouseID: warehouseID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, warehouse)
+}
+
+// CreateWarehouse godoc
+//
+//	@Summary		Create a new warehouse
+//	@Description	Create a new warehouse, orders are allocated to warehouses in their province first, then by priority
+//	@Tags			Warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Param			warehouse	body		CreateWarehouseData	true	"Warehouse request"
+//	@Success		201			{object}	WarehouseResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		409			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/warehouses [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WarehouseHandlerImpl) Create(ctx *gin.Context) {
+	var data CreateWarehouseData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	warehouse, err := h.warehouseApp.Create(ctx, CreateWarehouseRequestDto{
+		Data: data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, warehouse)
+}
+
+// UpdateWarehouse godoc
+//
+//	@Summary		Update a warehouse
+//	@Description	Update warehouse by ID
+//	@Tags			Warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Param			warehouse_id	path		string				true	"Warehouse ID"	format(uuid)
+//	@Param			warehouse		body		UpdateWarehouseData	true	"Update warehouse request"
+//	@Success		200				{object}	WarehouseResponseDto
+//	@Failure		400				{object}	Error
+//	@Failure		403				{object}	Error
+//	@Failure		404				{object}	Error
+//	@Failure		409				{object}	Error
+//	@Failure		500				{object}	Error
+//	@Router			/warehouses/{warehouse_id} [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WarehouseHandlerImpl) Update(ctx *gin.Context) {
+	warehouseID, ok := pathToUUID(ctx, "warehouse_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWarehouseID))
+		return
+	}
+
+	var data UpdateWarehouseData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	warehouse, err := h.warehouseApp.Update(ctx, UpdateWarehouseRequestDto{
+		WarehouseID: warehouseID,
+		Data:        data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, warehouse)
+}
+
+// DeleteWarehouse godoc
+//
+//	@Summary		Delete a warehouse
+//	@Description	Soft delete a warehouse, it is no longer chosen for new orders. A warehouse still holding stock can not be deleted
+//	@Tags			Warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Param			warehouse_id	path	string	true	"Warehouse ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		409	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/warehouses/{warehouse_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WarehouseHandlerImpl) Delete(ctx *gin.Context) {
+	warehouseID, ok := pathToUUID(ctx, "warehouse_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWarehouseID))
+		return
+	}
+	err := h.warehouseApp.Delete(ctx, DeleteWarehouseRequestDto{
+		WarehouseID: warehouseID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
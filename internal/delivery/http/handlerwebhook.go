@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler interface {
+	List(*gin.Context)
+	Get(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	ListDeliveries(*gin.Context)
+	Redeliver(*gin.Context)
+}
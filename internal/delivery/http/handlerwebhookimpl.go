@@ -0,0 +1,276 @@
+package http
+
+import (
+	"net/http"
+
+	"backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandlerImpl struct {
+	webhookApp           WebhookApplication
+	ErrInvalidWebhookID  string
+	ErrInvalidDeliveryID string
+}
+
+var _ WebhookHandler = (*WebhookHandlerImpl)(nil)
+
+func ProvideWebhookHandler(webhookApp WebhookApplication) *WebhookHandlerImpl {
+	return &WebhookHandlerImpl{
+		webhookApp:           webhookApp,
+		ErrInvalidWebhookID:  "invalid webhook_id",
+		ErrInvalidDeliveryID: "invalid delivery_id",
+	}
+}
+
+// ListWebhooks godoc
+//
+//	@Summary		List all webhooks
+//	@Description	Get all webhooks ordered by creation
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page for pagination"	default(1)
+//	@Param			limit	query		int	false	"Limit for pagination"	default(20)
+//	@Success		200		{object}	PaginationResponseDto[WebhookResponseDto]
+//	@Failure		403		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/admin/webhooks [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) List(ctx *gin.Context) {
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	webhooks, err := h.webhookApp.List(ctx, ListWebhookRequestDto{
+		PaginationRequestDto: *paginateParam,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+//
+//	@Summary		Get webhook by ID
+//	@Description	Get webhook details by ID, with its signing secret
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook_id	path		string	true	"Webhook ID"	format(uuid)
+//	@Success		200			{object}	WebhookResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/admin/webhooks/{webhook_id} [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) Get(ctx *gin.Context) {
+	webhookID, ok := pathToUUID(ctx, "webhook_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWebhookID))
+		return
+	}
+	webhook, err := h.webhookApp.Get(ctx, GetWebhookRequestDto{
+		WebhookID: webhookID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+// CreateWebhook godoc
+//
+//	@Summary		Create a new webhook
+//	@Description	Subscribe a partner endpoint to events, deliveries are POSTed as JSON and signed with the returned secret in the Webhook-Signature header as t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body		CreateWebhookData	true	"Webhook request"
+//	@Success		201		{object}	WebhookResponseDto
+//	@Failure		400		{object}	Error
+//	@Failure		403		{object}	Error
+//	@Failure		500		{object}	Error
+//	@Router			/admin/webhooks [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) Create(ctx *gin.Context) {
+	var data CreateWebhookData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	webhook, err := h.webhookApp.Create(ctx, CreateWebhookRequestDto{
+		Data: data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateWebhook godoc
+//
+//	@Summary		Update a webhook
+//	@Description	Update webhook by ID, an inactive webhook receives no new events and its pending deliveries fail
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook_id	path		string				true	"Webhook ID"	format(uuid)
+//	@Param			webhook		body		UpdateWebhookData	true	"Update webhook request"
+//	@Success		200			{object}	WebhookResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/admin/webhooks/{webhook_id} [patch]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) Update(ctx *gin.Context) {
+	webhookID, ok := pathToUUID(ctx, "webhook_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWebhookID))
+		return
+	}
+
+	var data UpdateWebhookData
+	if err := ctx.ShouldBindJSON(&data); err != nil {
+		ctx.JSON(http.StatusBadRequest, NewError(err.Error()))
+		return
+	}
+
+	webhook, err := h.webhookApp.Update(ctx, UpdateWebhookRequestDto{
+		WebhookID: webhookID,
+		Data:      data,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	Soft delete a webhook, its delivery log is kept
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook_id	path	string	true	"Webhook ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	Error
+//	@Failure		403	{object}	Error
+//	@Failure		404	{object}	Error
+//	@Failure		500	{object}	Error
+//	@Router			/admin/webhooks/{webhook_id} [delete]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) Delete(ctx *gin.Context) {
+	webhookID, ok := pathToUUID(ctx, "webhook_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWebhookID))
+		return
+	}
+	err := h.webhookApp.Delete(ctx, DeleteWebhookRequestDto{
+		WebhookID: webhookID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+//
+//	@Summary		List the deliveries of a webhook
+//	@Description	Get the delivery log of a webhook, latest first, with the response of the last attempt of each delivery
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook_id	path		string							true	"Webhook ID"	format(uuid)
+//	@Param			status		query		domain.WebhookDeliveryStatus	false	"Filter by status"
+//	@Param			page		query		int								false	"Page for pagination"	default(1)
+//	@Param			limit		query		int								false	"Limit for pagination"	default(20)
+//	@Success		200			{object}	PaginationResponseDto[WebhookDeliveryResponseDto]
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/admin/webhooks/{webhook_id}/deliveries [get]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) ListDeliveries(ctx *gin.Context) {
+	webhookID, ok := pathToUUID(ctx, "webhook_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWebhookID))
+		return
+	}
+	paginateParam, err := createPaginationRequestDtoFromQuery(ctx)
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+
+	deliveries, err := h.webhookApp.ListDeliveries(ctx, ListWebhookDeliveryRequestDto{
+		PaginationRequestDto: *paginateParam,
+		WebhookID:            webhookID,
+		Status:               domain.WebhookDeliveryStatus(ctx.Query("status")),
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhookDelivery godoc
+//
+//	@Summary		Redeliver a webhook delivery
+//	@Description	Send a delivery again with the same body and a fresh budget of attempts, it is pending until the next delivery run
+//	@Tags			Webhook
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook_id	path		string	true	"Webhook ID"	format(uuid)
+//	@Param			delivery_id	path		string	true	"Delivery ID"	format(uuid)
+//	@Success		202			{object}	WebhookDeliveryResponseDto
+//	@Failure		400			{object}	Error
+//	@Failure		403			{object}	Error
+//	@Failure		404			{object}	Error
+//	@Failure		500			{object}	Error
+//	@Router			/admin/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+//	@Security		OAuth2AccessCode
+//	@Security		OAuth2Password
+func (h *WebhookHandlerImpl) Redeliver(ctx *gin.Context) {
+	webhookID, ok := pathToUUID(ctx, "webhook_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidWebhookID))
+		return
+	}
+	deliveryID, ok := pathToUUID(ctx, "delivery_id")
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewError(h.ErrInvalidDeliveryID))
+		return
+	}
+	delivery, err := h.webhookApp.Redeliver(ctx, RedeliverWebhookDeliveryRequestDto{
+		WebhookID:  webhookID,
+		DeliveryID: deliveryID,
+	})
+	if err != nil {
+		SendError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, delivery)
+}
@@ -1,11 +1,13 @@
 package http
 
 import (
+	"slices"
 	"strconv"
 
 	"backend/internal/domain"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -53,6 +55,24 @@ func ctxValueToUUID(ctx *gin.Context, key string) (uuid.UUID, bool) {
 	return id, true
 }
 
+// ctxHasRole reports whether the authenticated user holds any of the roles
+func ctxHasRole(ctx *gin.Context, roles ...UserRole) bool {
+	claimsVal, exists := ctx.Get("claims")
+	if !exists {
+		return false
+	}
+	claims, ok := claimsVal.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	for _, role := range extractRole(claims) {
+		if slices.Contains(roles, UserRole(role)) {
+			return true
+		}
+	}
+	return false
+}
+
 func pathToUUID(ctx *gin.Context, key string) (uuid.UUID, bool) {
 	idStr := ctx.Param(key)
 	if idStr == "" {
@@ -85,8 +105,18 @@ func createPaginationRequestDtoFromQuery(ctx *gin.Context) (*PaginationRequestDt
 			return nil, domain.ErrInvalid
 		}
 	}
-	return &PaginationRequestDto{
+	pagination := &PaginationRequestDto{
 		Page:  page,
 		Limit: limit,
-	}, nil
+	}
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		pagination.Cursor = &cursor
+		if countQuery := ctx.Query("count"); countQuery != "" {
+			pagination.WithCount, err = strconv.ParseBool(countQuery)
+			if err != nil {
+				return nil, domain.ErrInvalid
+			}
+		}
+	}
+	return pagination, nil
 }
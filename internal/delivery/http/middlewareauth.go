@@ -6,4 +6,5 @@ import (
 
 type AuthMiddleware interface {
 	Handler() gin.HandlerFunc
+	OptionalHandler() gin.HandlerFunc
 }
@@ -95,3 +95,16 @@ func (m *GinAuthMiddleware) Handler() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// OptionalHandler authenticates only the requests which carry an Authorization
+// header, anonymous requests go through untouched
+func (m *GinAuthMiddleware) OptionalHandler() gin.HandlerFunc {
+	handler := m.Handler()
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" {
+			ctx.Next()
+			return
+		}
+		handler(ctx)
+	}
+}
@@ -0,0 +1,10 @@
+package http
+
+import (
+	"context"
+)
+
+type NotificationApplication interface {
+	List(ctx context.Context, param ListNotificationsRequestDto) (*PaginationResponseDto[NotificationResponseDto], error)
+	MarkRead(ctx context.Context, param MarkNotificationReadRequestDto) (*NotificationResponseDto, error)
+}
@@ -0,0 +1,18 @@
+package http
+
+import "github.com/google/uuid"
+
+// ListNotificationsRequestDto lists the notifications of UserID, or the staff
+// notifications when Staff is set
+type ListNotificationsRequestDto struct {
+	PaginationRequestDto
+	UserID uuid.UUID
+	Staff  bool
+	Unread bool
+}
+
+type MarkNotificationReadRequestDto struct {
+	NotificationID uuid.UUID
+	UserID         uuid.UUID
+	Staff          bool
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type NotificationResponseDto struct {
+	ID               uuid.UUID               `json:"id"               binding:"required"`
+	Kind             domain.NotificationKind `json:"kind"             binding:"required"`
+	ProductID        *uuid.UUID              `json:"productId"`
+	ProductVariantID *uuid.UUID              `json:"productVariantId"`
+	Quantity         int                     `json:"quantity"         binding:"required"`
+	CreatedAt        time.Time               `json:"createdAt"        binding:"required"`
+	ReadAt           *time.Time              `json:"readAt"`
+}
+
+func ToNotificationResponseDto(n *domain.Notification) *NotificationResponseDto {
+	if n == nil {
+		return nil
+	}
+
+	var productID *uuid.UUID
+	if n.ProductID != uuid.Nil {
+		productID = &n.ProductID
+	}
+	var productVariantID *uuid.UUID
+	if n.ProductVariantID != uuid.Nil {
+		productVariantID = &n.ProductVariantID
+	}
+	var readAt *time.Time
+	if !n.ReadAt.IsZero() {
+		readAt = &n.ReadAt
+	}
+	return &NotificationResponseDto{
+		ID:               n.ID,
+		Kind:             n.Kind,
+		ProductID:        productID,
+		ProductVariantID: productVariantID,
+		Quantity:         n.Quantity,
+		CreatedAt:        n.CreatedAt,
+		ReadAt:           readAt,
+	}
+}
@@ -35,6 +35,7 @@ type CreateOrderItemData struct {
 
 type UpdateOrderRequestDto struct {
 	OrderID uuid.UUID
+	UserID  uuid.UUID
 	Data    UpdateOrderData
 }
 
@@ -30,6 +30,7 @@ type OrderItemResponseDto struct {
 	ProductVariant OrderItemProductVariantResponseDto `json:"productVariant" binding:"required"`
 	Quantity       int                                `json:"quantity"       binding:"required,gt=0"`
 	Price          int64                              `json:"price"          binding:"required,gt=0"`
+	WarehouseID    *uuid.UUID                         `json:"warehouseId"`
 }
 
 type OrderItemProductResponseDto struct {
@@ -88,6 +89,9 @@ func ToOrderItemResponseDto(
 		Quantity: item.Quantity,
 		Price:    item.Price,
 	}
+	if item.WarehouseID != uuid.Nil {
+		itemDto.WarehouseID = &item.WarehouseID
+	}
 
 	if product != nil {
 		itemDto.Product = OrderItemProductResponseDto{
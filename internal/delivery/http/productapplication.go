@@ -10,14 +10,33 @@ type ProductApplication interface {
 	Create(context.Context, CreateProductRequestDto) (*ProductResponseDto, error)
 	AddVariants(context.Context, AddProductVariantsRequestDto) (*[]ProductVariantResponseDto, error)
 	List(context.Context, ListProductRequestDto) (*PaginationResponseDto[ProductResponseDto], error)
+	ListSuggestions(context.Context, ListProductSuggestionsRequestDto) (*[]ProductSuggestionResponseDto, error)
+	ListRelated(context.Context, ListProductRecommendationsRequestDto) (*[]ProductResponseDto, error)
+	ListBoughtTogether(context.Context, ListProductRecommendationsRequestDto) (*[]ProductResponseDto, error)
+	Compare(context.Context, CompareProductsRequestDto) (*ProductComparisonResponseDto, error)
 	GetDeleteImageURL(context.Context, uuid.UUID) (*DeleteImageURLResponseDto, error)
 	GetUploadImageURL(context.Context) (*UploadImageURLResponseDto, error)
 	Get(context.Context, GetProductRequestDto) (*ProductResponseDto, error)
 	AddImages(context.Context, AddProductImagesRequestDto) (*[]ProductImageResponseDto, error)
 	Update(context.Context, UpdateProductRequestDto) (*ProductResponseDto, error)
+	UpdatePublishing(context.Context, UpdateProductPublishingRequestDto) (*ProductResponseDto, error)
+	UpdateSpecs(context.Context, UpdateProductSpecsRequestDto) (*ProductResponseDto, error)
 	UpdateVariant(context.Context, UpdateProductVariantRequestDto) (*ProductVariantResponseDto, error)
+	UpdateVariantPricing(context.Context, UpdateProductVariantPricingRequestDto) (*ProductVariantResponseDto, error)
+	UpdateVariantComponents(context.Context, UpdateProductVariantComponentsRequestDto) (*ProductVariantResponseDto, error)
+	ListStockMovements(context.Context, ListStockMovementsRequestDto) (*PaginationResponseDto[StockMovementResponseDto], error)
+	CreateStockMovement(context.Context, CreateStockMovementRequestDto) (*StockMovementResponseDto, error)
+	SubscribeStock(context.Context, SubscribeProductVariantStockRequestDto) error
+	UnsubscribeStock(context.Context, UnsubscribeProductVariantStockRequestDto) error
+	DeleteVariant(context.Context, DeleteProductVariantRequestDto) error
 	UpdateOptions(context.Context, UpdateProductOptionsRequestDto) (*[]ProductOptionResponseDto, error)
+	AddOption(context.Context, AddProductOptionRequestDto) (*ProductOptionResponseDto, error)
+	DeleteOption(context.Context, DeleteProductOptionRequestDto) error
 	UpdateOptionValues(context.Context, UpdateProductOptionValuesRequestDto) (*[]ProductOptionValueResponseDto, error)
+	AddOptionValues(context.Context, AddProductOptionValuesRequestDto) (*[]ProductOptionValueResponseDto, error)
+	DeleteOptionValue(context.Context, DeleteProductOptionValueRequestDto) error
 	Delete(context.Context, DeleteProductRequestDto) error
+	Restore(context.Context, RestoreProductRequestDto) (*ProductResponseDto, error)
+	Purge(context.Context, PurgeProductRequestDto) error
 	DeleteImages(context.Context, DeleteProductImagesRequestDto) error
 }
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"time"
+
 	"backend/internal/domain"
 
 	"github.com/google/uuid"
@@ -8,15 +10,30 @@ import (
 
 type ListProductRequestDto struct {
 	PaginationRequestDto
-	ProductIDs  []uuid.UUID
-	CategoryIDs []uuid.UUID
-	MinPrice    int64
-	MaxPrice    int64
-	Rating      float64
-	SortPrice   string
-	SortRating  string
-	Search      string
-	Deleted     domain.DeletedParam
+	ProductIDs   []uuid.UUID
+	CategoryIDs  []uuid.UUID
+	MinPrice     int64
+	MaxPrice     int64
+	Rating       float64
+	SortTrending bool
+	SortPrice    string
+	SortRating   string
+	SortDiscount string
+	OnSale       bool
+	Search       string
+	Deleted      domain.DeletedParam
+	// Statuses empty lists products of any status
+	Statuses []domain.ProductStatus
+}
+
+type ListProductSuggestionsRequestDto struct {
+	Search string
+	Limit  int
+}
+
+type ListProductRecommendationsRequestDto struct {
+	ProductID uuid.UUID
+	Limit     int
 }
 
 type CreateProductRequestDto struct {
@@ -31,6 +48,13 @@ type CreateProductData struct {
 	CategoryID        uuid.UUID                     `json:"categoryId"           binding:"required"`
 	Images            []CreateProductImageData      `json:"images"               binding:"required,dive"`
 	Variants          []CreateProductVariantData    `json:"variants"             binding:"required,dive"`
+	// Status defaults to draft, see UpdateProductPublishingData
+	Status      domain.ProductStatus `json:"status,omitempty"      binding:"omitempty,oneof=draft published archived"`
+	PublishAt   *time.Time           `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time           `json:"unpublishAt,omitempty"`
+	// Type defaults to standard, the variants of a bundle are given components
+	// instead of a quantity
+	Type domain.ProductType `json:"type,omitempty" binding:"omitempty,oneof=standard bundle"`
 }
 
 type CreateProductAttributesData struct {
@@ -49,11 +73,21 @@ type CreateProductImageData struct {
 }
 
 type CreateProductVariantData struct {
-	SKU      string                       `json:"sku"               binding:"required"`
-	Price    int64                        `json:"price"             binding:"required"`
-	Quantity int                          `json:"quantity"          binding:"required"`
-	Options  []CreateProductVariantOption `json:"options,omitempty" binding:"omitempty,dive"`
-	Images   []CreateProductVariantImage  `json:"images,omitempty"  binding:"omitempty,dive"`
+	SKU            string                       `json:"sku"                      binding:"required"`
+	Price          int64                        `json:"price"                    binding:"required"`
+	CompareAtPrice int64                        `json:"compareAtPrice,omitempty" binding:"omitempty,gtfield=Price"`
+	Quantity       int                          `json:"quantity"                 binding:"gte=0"`
+	Options        []CreateProductVariantOption `json:"options,omitempty"        binding:"omitempty,dive"`
+	Images         []CreateProductVariantImage  `json:"images,omitempty"         binding:"omitempty,dive"`
+	// WarehouseID is where the initial quantity is stocked, when omitted the
+	// variant is not stocked at any warehouse
+	WarehouseID *uuid.UUID            `json:"warehouseId,omitempty"`
+	Components  []BundleComponentData `json:"components,omitempty" binding:"omitempty,dive"`
+}
+
+type BundleComponentData struct {
+	VariantID uuid.UUID `json:"variantId" binding:"required"`
+	Quantity  int       `json:"quantity"  binding:"required,gt=0"`
 }
 
 type CreateProductVariantOption struct {
@@ -74,14 +108,66 @@ type UpdateProductData struct {
 	CategoryID  uuid.UUID `json:"categoryId"`
 }
 
+type UpdateProductPublishingRequestDto struct {
+	ProductID uuid.UUID
+	Data      UpdateProductPublishingData
+}
+
+// UpdateProductPublishingData replaces the publishing state, an omitted time
+// removes that schedule. A draft is published at publishAt and a published
+// product is archived at unpublishAt
+type UpdateProductPublishingData struct {
+	Status      domain.ProductStatus `json:"status"                binding:"required,oneof=draft published archived"`
+	PublishAt   *time.Time           `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time           `json:"unpublishAt,omitempty"`
+}
+
+type UpdateProductSpecsRequestDto struct {
+	ProductID uuid.UUID
+	Data      UpdateProductSpecsData
+}
+
+// UpdateProductSpecsData replaces every spec value of a product, an empty list
+// removes them all
+type UpdateProductSpecsData struct {
+	Specs []ProductSpecData `json:"specs" binding:"required,dive"`
+}
+
+// ProductSpecData is the value of a spec, only the field matching the type of
+// the spec is given
+type ProductSpecData struct {
+	SpecID  uuid.UUID `json:"specId"            binding:"required"`
+	Number  *float64  `json:"number,omitempty"`
+	Boolean *bool     `json:"boolean,omitempty"`
+	Enum    string    `json:"enum,omitempty"`
+}
+
+type CompareProductsRequestDto struct {
+	ProductIDs []uuid.UUID
+	// PublishedOnly hides drafts and archived products as not found
+	PublishedOnly bool
+}
+
 type GetProductRequestDto struct {
 	ProductID uuid.UUID
+	UserID    uuid.UUID
+	ClientIP  string
+	// PublishedOnly hides drafts and archived products as not found
+	PublishedOnly bool
 }
 
 type DeleteProductRequestDto struct {
 	ProductID uuid.UUID
 }
 
+type RestoreProductRequestDto struct {
+	ProductID uuid.UUID
+}
+
+type PurgeProductRequestDto struct {
+	ProductID uuid.UUID
+}
+
 type AddProductImagesRequestDto struct {
 	ProductID uuid.UUID
 	Data      []AddProductImageData
@@ -104,21 +190,88 @@ type AddProductVariantsRequestDto struct {
 }
 
 type AddProductVariantsData struct {
-	SKU            string      `json:"sku"                      binding:"required"`
-	Price          int64       `json:"price"                    binding:"required"`
-	Quantity       int         `json:"quantity"                 binding:"required"`
-	OptionValueIDs []uuid.UUID `json:"optionValueIds,omitempty"`
+	SKU            string                `json:"sku"                      binding:"required"`
+	Price          int64                 `json:"price"                    binding:"required"`
+	CompareAtPrice int64                 `json:"compareAtPrice,omitempty" binding:"omitempty,gtfield=Price"`
+	Quantity       int                   `json:"quantity"                 binding:"gte=0"`
+	OptionValueIDs []uuid.UUID           `json:"optionValueIds,omitempty"`
+	WarehouseID    *uuid.UUID            `json:"warehouseId,omitempty"`
+	Components     []BundleComponentData `json:"components,omitempty"     binding:"omitempty,dive"`
 }
 
 type UpdateProductVariantRequestDto struct {
 	ProductID        uuid.UUID
 	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
 	Data             UpdateProductVariantData
 }
 
 type UpdateProductVariantData struct {
-	Price    int64 `json:"price"`
-	Quantity int   `json:"quantity"`
+	Price             int64 `json:"price"`
+	Quantity          int   `json:"quantity"`
+	LowStockThreshold *int  `json:"lowStockThreshold,omitempty" binding:"omitempty,gte=0"`
+}
+
+type UpdateProductVariantPricingRequestDto struct {
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+	Data             UpdateProductVariantPricingData
+}
+
+// UpdateProductVariantPricingData replaces the whole pricing, zero prices and
+// omitted times clear them
+type UpdateProductVariantPricingData struct {
+	CompareAtPrice int64      `json:"compareAtPrice"         binding:"gte=0"`
+	SalePrice      int64      `json:"salePrice"              binding:"gte=0"`
+	SaleStartsAt   *time.Time `json:"saleStartsAt,omitempty"`
+	SaleEndsAt     *time.Time `json:"saleEndsAt,omitempty"`
+}
+
+type UpdateProductVariantComponentsRequestDto struct {
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+	Data             UpdateProductVariantComponentsData
+}
+
+// UpdateProductVariantComponentsData replaces every component of a bundle
+// variant
+type UpdateProductVariantComponentsData struct {
+	Components []BundleComponentData `json:"components" binding:"required,min=1,dive"`
+}
+
+type SubscribeProductVariantStockRequestDto struct {
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+}
+
+type UnsubscribeProductVariantStockRequestDto struct {
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+}
+
+type ListStockMovementsRequestDto struct {
+	PaginationRequestDto
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+	Kinds            []domain.StockMovementKind
+}
+
+type CreateStockMovementRequestDto struct {
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+	Data             CreateStockMovementData
+}
+
+type CreateStockMovementData struct {
+	Kind     domain.StockMovementKind `json:"kind"              binding:"required,oneof=restock return adjustment"`
+	Quantity int                      `json:"quantity"          binding:"required"`
+	OrderID  *uuid.UUID               `json:"orderId,omitempty"`
+	Note     string                   `json:"note,omitempty"    binding:"omitempty,max=500"`
+	// WarehouseID defaults to the warehouse holding most of the variant
+	WarehouseID *uuid.UUID `json:"warehouseId,omitempty"`
 }
 
 type UpdateProductOptionsRequestDto struct {
@@ -131,6 +284,49 @@ type UpdateProductOptionsData struct {
 	Name string    `json:"name"`
 }
 
+type DeleteProductVariantRequestDto struct {
+	ProductID        uuid.UUID
+	ProductVariantID uuid.UUID
+}
+
+type AddProductOptionRequestDto struct {
+	ProductID uuid.UUID
+	Data      AddProductOptionData
+}
+
+type AddProductOptionData struct {
+	Name   string   `json:"name"     binding:"required"`
+	Values []string `json:"values"   binding:"required,min=1,dive,required"`
+	// Variants map every remaining variant to one of the new values
+	Variants []AddProductOptionVariantData `json:"variants" binding:"required,dive"`
+}
+
+type AddProductOptionVariantData struct {
+	ProductVariantID uuid.UUID `json:"productVariantId" binding:"required"`
+	Value            string    `json:"value"            binding:"required"`
+}
+
+type DeleteProductOptionRequestDto struct {
+	ProductID uuid.UUID
+	OptionID  uuid.UUID
+}
+
+type AddProductOptionValuesRequestDto struct {
+	ProductID uuid.UUID
+	OptionID  uuid.UUID
+	Data      []AddProductOptionValuesData
+}
+
+type AddProductOptionValuesData struct {
+	Value string `json:"value" binding:"required"`
+}
+
+type DeleteProductOptionValueRequestDto struct {
+	ProductID     uuid.UUID
+	OptionID      uuid.UUID
+	OptionValueID uuid.UUID
+}
+
 type UpdateProductOptionValuesRequestDto struct {
 	ProductID uuid.UUID
 	OptionID  uuid.UUID
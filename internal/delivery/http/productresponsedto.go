@@ -15,12 +15,18 @@ type ProductResponseDto struct {
 	ViewsCount    int                           `json:"viewsCount"    binding:"required"`
 	TotalPurchase int                           `json:"totalPurchase" binding:"required"`
 	Price         float64                       `json:"price"         binding:"required"`
+	Discount      int                           `json:"discount"      binding:"required"`
 	Rating        float64                       `json:"rating"        binding:"required"`
 	CreatedAt     time.Time                     `json:"createdAt"     binding:"required"`
 	UpdatedAt     time.Time                     `json:"updatedAt"     binding:"required"`
 	DeletedAt     *time.Time                    `json:"deletedAt"`
+	Status        domain.ProductStatus          `json:"status"        binding:"required"`
+	Type          domain.ProductType            `json:"type"          binding:"required"`
+	PublishAt     *time.Time                    `json:"publishAt"`
+	UnpublishAt   *time.Time                    `json:"unpublishAt"`
 	Category      ProductCategoryResponseDto    `json:"category"      binding:"required"`
 	Attributes    []ProductAttributeResponseDto `json:"attributes"    binding:"required"`
+	Specs         []ProductSpecResponseDto      `json:"specs"         binding:"required"`
 	Options       []ProductOptionResponseDto    `json:"options"       binding:"required"`
 	Variants      []ProductVariantResponseDto   `json:"variants"      binding:"required"`
 	Images        []ProductImageResponseDto     `json:"images"        binding:"required"`
@@ -34,6 +40,45 @@ type ProductCategoryResponseDto struct {
 	DeletedAt *time.Time `json:"deletedAt"`
 }
 
+type ProductSpecResponseDto struct {
+	ID    uuid.UUID                   `json:"id"    binding:"required"`
+	Code  string                      `json:"code"  binding:"required"`
+	Name  string                      `json:"name"  binding:"required"`
+	Type  domain.SpecType             `json:"type"  binding:"required"`
+	Unit  string                      `json:"unit"  binding:"required"`
+	Group ProductSpecGroupResponseDto `json:"group" binding:"required"`
+	Value SpecValueResponseDto        `json:"value" binding:"required"`
+}
+
+type ProductSpecGroupResponseDto struct {
+	ID   uuid.UUID `json:"id"   binding:"required"`
+	Name string    `json:"name" binding:"required"`
+}
+
+// ProductComparisonResponseDto lines up the specs of the compared products,
+// the values of every row follow the order of products
+type ProductComparisonResponseDto struct {
+	Products []ProductResponseDto             `json:"products" binding:"required"`
+	Groups   []SpecComparisonGroupResponseDto `json:"groups"   binding:"required"`
+}
+
+type SpecComparisonGroupResponseDto struct {
+	ID   uuid.UUID                      `json:"id"   binding:"required"`
+	Name string                         `json:"name" binding:"required"`
+	Rows []SpecComparisonRowResponseDto `json:"rows" binding:"required"`
+}
+
+type SpecComparisonRowResponseDto struct {
+	ID     uuid.UUID               `json:"id"     binding:"required"`
+	Code   string                  `json:"code"   binding:"required"`
+	Name   string                  `json:"name"   binding:"required"`
+	Type   domain.SpecType         `json:"type"   binding:"required"`
+	Unit   string                  `json:"unit"   binding:"required"`
+	Values []*SpecValueResponseDto `json:"values" binding:"required"`
+	// Same is set when every product has the same value
+	Same bool `json:"same" binding:"required"`
+}
+
 type ProductAttributeResponseDto struct {
 	ID        uuid.UUID                        `json:"id"        binding:"required"`
 	Code      string                           `json:"code"      binding:"required"`
@@ -62,16 +107,49 @@ type ProductOptionValueResponseDto struct {
 }
 
 type ProductVariantResponseDto struct {
-	ID            uuid.UUID                       `json:"id"            binding:"required"`
-	SKU           string                          `json:"sku"           binding:"required"`
-	Price         int64                           `json:"price"         binding:"required"`
-	Quantity      int                             `json:"quantity"      binding:"required"`
-	PurchaseCount int                             `json:"purchaseCount" binding:"required"`
-	CreatedAt     time.Time                       `json:"createdAt"     binding:"required"`
-	UpdatedAt     time.Time                       `json:"updatedAt"     binding:"required"`
-	DeletedAt     *time.Time                      `json:"deletedAt"`
-	OptionValues  []ProductOptionValueResponseDto `json:"optionValues"  binding:"required"`
-	Images        []ProductImageResponseDto       `json:"images"        binding:"required"`
+	ID                uuid.UUID                       `json:"id"            binding:"required"`
+	SKU               string                          `json:"sku"           binding:"required"`
+	Price             int64                           `json:"price"         binding:"required"`
+	CompareAtPrice    int64                           `json:"compareAtPrice" binding:"required"`
+	SalePrice         int64                           `json:"salePrice"     binding:"required"`
+	SaleStartsAt      *time.Time                      `json:"saleStartsAt"`
+	SaleEndsAt        *time.Time                      `json:"saleEndsAt"`
+	EffectivePrice    int64                           `json:"effectivePrice" binding:"required"`
+	Quantity          int                             `json:"quantity"      binding:"required"`
+	PurchaseCount     int                             `json:"purchaseCount" binding:"required"`
+	LowStockThreshold int                             `json:"lowStockThreshold" binding:"required"`
+	CreatedAt         time.Time                       `json:"createdAt"     binding:"required"`
+	UpdatedAt         time.Time                       `json:"updatedAt"     binding:"required"`
+	DeletedAt         *time.Time                      `json:"deletedAt"`
+	OptionValues      []ProductOptionValueResponseDto `json:"optionValues"  binding:"required"`
+	Images            []ProductImageResponseDto       `json:"images"        binding:"required"`
+	Stocks            []WarehouseStockResponseDto     `json:"stocks"        binding:"required"`
+	// Components are only set for the variants of a bundle, its quantity and
+	// stocks are derived from them
+	Components []BundleComponentResponseDto `json:"components" binding:"required"`
+}
+
+type BundleComponentResponseDto struct {
+	VariantID uuid.UUID `json:"variantId" binding:"required"`
+	Quantity  int       `json:"quantity"  binding:"required"`
+}
+
+type WarehouseStockResponseDto struct {
+	WarehouseID uuid.UUID `json:"warehouseId" binding:"required"`
+	Quantity    int       `json:"quantity"    binding:"required"`
+}
+
+type StockMovementResponseDto struct {
+	ID               uuid.UUID                `json:"id"               binding:"required"`
+	ProductVariantID uuid.UUID                `json:"productVariantId" binding:"required"`
+	Kind             domain.StockMovementKind `json:"kind"             binding:"required"`
+	Quantity         int                      `json:"quantity"         binding:"required"`
+	QuantityAfter    int                      `json:"quantityAfter"    binding:"required"`
+	ActorID          *uuid.UUID               `json:"actorId"`
+	OrderID          *uuid.UUID               `json:"orderId"`
+	Note             string                   `json:"note"`
+	WarehouseID      *uuid.UUID               `json:"warehouseId"`
+	CreatedAt        time.Time                `json:"createdAt"        binding:"required"`
 }
 
 type ProductImageResponseDto struct {
@@ -80,6 +158,21 @@ type ProductImageResponseDto struct {
 	Order     int        `json:"order"     binding:"required"`
 	CreatedAt time.Time  `json:"createdAt" binding:"required"`
 	DeletedAt *time.Time `json:"deletedAt"`
+	// Renditions are WebP copies of the image ordered by width, meant for
+	// srcset, an image smaller than a width has no rendition for it
+	Renditions []ProductImageRenditionResponseDto `json:"renditions" binding:"required"`
+}
+
+type ProductImageRenditionResponseDto struct {
+	Width  int    `json:"width"  binding:"required"`
+	Height int    `json:"height" binding:"required"`
+	URL    string `json:"url"    binding:"required"`
+}
+
+type ProductSuggestionResponseDto struct {
+	ID   uuid.UUID `json:"id"   binding:"required"`
+	Type string    `json:"type" binding:"required"`
+	Text string    `json:"text" binding:"required"`
 }
 
 // ToProductResponseDto maps a domain.Product to ProductResponseDto
@@ -108,6 +201,14 @@ func ToProductResponseDto(p *domain.Product) *ProductResponseDto {
 	if !p.DeletedAt.IsZero() {
 		deletedAt = &p.DeletedAt
 	}
+	var publishAt *time.Time
+	if !p.PublishAt.IsZero() {
+		publishAt = &p.PublishAt
+	}
+	var unpublishAt *time.Time
+	if !p.UnpublishAt.IsZero() {
+		unpublishAt = &p.UnpublishAt
+	}
 	return &ProductResponseDto{
 		ID:            p.ID,
 		Name:          p.Name,
@@ -115,12 +216,18 @@ func ToProductResponseDto(p *domain.Product) *ProductResponseDto {
 		ViewsCount:    p.ViewsCount,
 		TotalPurchase: p.TotalPurchase,
 		Price:         float64(p.Price),
+		Discount:      p.Discount,
 		Rating:        p.Rating,
 		CreatedAt:     p.CreatedAt,
 		UpdatedAt:     p.UpdatedAt,
 		DeletedAt:     deletedAt,
+		Status:        p.Status,
+		Type:          p.Type,
+		PublishAt:     publishAt,
+		UnpublishAt:   unpublishAt,
 		Category:      ProductCategoryResponseDto{},    // To be populated separately
 		Attributes:    []ProductAttributeResponseDto{}, // To be populated separately
+		Specs:         []ProductSpecResponseDto{},      // To be populated separately
 		Options:       options,
 		Variants:      variants,
 		Images:        images,
@@ -184,21 +291,85 @@ func ToProductVariantResponseDto(v *domain.ProductVariant) *ProductVariantRespon
 		images = append(images, *ToProductImageResponseDto(&img))
 	}
 
+	stocks := make([]WarehouseStockResponseDto, 0, len(v.Stocks))
+	for _, stock := range v.Stocks {
+		stocks = append(stocks, WarehouseStockResponseDto{
+			WarehouseID: stock.WarehouseID,
+			Quantity:    stock.Quantity,
+		})
+	}
+
+	components := make([]BundleComponentResponseDto, 0, len(v.Components))
+	for _, component := range v.Components {
+		components = append(components, BundleComponentResponseDto{
+			VariantID: component.VariantID,
+			Quantity:  component.Quantity,
+		})
+	}
+
 	var deletedAt *time.Time
 	if !v.DeletedAt.IsZero() {
 		deletedAt = &v.DeletedAt
 	}
+	var saleStartsAt *time.Time
+	if !v.SaleStartsAt.IsZero() {
+		saleStartsAt = &v.SaleStartsAt
+	}
+	var saleEndsAt *time.Time
+	if !v.SaleEndsAt.IsZero() {
+		saleEndsAt = &v.SaleEndsAt
+	}
 	return &ProductVariantResponseDto{
-		ID:            v.ID,
-		SKU:           v.SKU,
-		Price:         v.Price,
-		Quantity:      v.Quantity,
-		PurchaseCount: v.PurchaseCount,
-		CreatedAt:     v.CreatedAt,
-		UpdatedAt:     v.UpdatedAt,
-		DeletedAt:     deletedAt,
-		OptionValues:  optionValues,
-		Images:        images,
+		ID:                v.ID,
+		SKU:               v.SKU,
+		Price:             v.Price,
+		CompareAtPrice:    v.CompareAtPrice,
+		SalePrice:         v.SalePrice,
+		SaleStartsAt:      saleStartsAt,
+		SaleEndsAt:        saleEndsAt,
+		EffectivePrice:    v.EffectivePrice(time.Now()),
+		Quantity:          v.Quantity,
+		PurchaseCount:     v.PurchaseCount,
+		LowStockThreshold: v.LowStockThreshold,
+		CreatedAt:         v.CreatedAt,
+		UpdatedAt:         v.UpdatedAt,
+		DeletedAt:         deletedAt,
+		OptionValues:      optionValues,
+		Images:            images,
+		Stocks:            stocks,
+		Components:        components,
+	}
+}
+
+// ToStockMovementResponseDto maps a domain.StockMovement to StockMovementResponseDto
+func ToStockMovementResponseDto(m *domain.StockMovement) *StockMovementResponseDto {
+	if m == nil {
+		return nil
+	}
+
+	var actorID *uuid.UUID
+	if m.ActorID != uuid.Nil {
+		actorID = &m.ActorID
+	}
+	var orderID *uuid.UUID
+	if m.OrderID != uuid.Nil {
+		orderID = &m.OrderID
+	}
+	var warehouseID *uuid.UUID
+	if m.WarehouseID != uuid.Nil {
+		warehouseID = &m.WarehouseID
+	}
+	return &StockMovementResponseDto{
+		ID:               m.ID,
+		ProductVariantID: m.ProductVariantID,
+		Kind:             m.Kind,
+		Quantity:         m.Quantity,
+		QuantityAfter:    m.QuantityAfter,
+		ActorID:          actorID,
+		OrderID:          orderID,
+		Note:             m.Note,
+		WarehouseID:      warehouseID,
+		CreatedAt:        m.CreatedAt,
 	}
 }
 
@@ -213,12 +384,22 @@ func ToProductImageResponseDto(img *domain.ProductImage) *ProductImageResponseDt
 		deletedAt = &img.DeletedAt
 	}
 
+	renditions := make([]ProductImageRenditionResponseDto, 0, len(img.Renditions))
+	for _, rendition := range img.Renditions {
+		renditions = append(renditions, ProductImageRenditionResponseDto{
+			Width:  rendition.Width,
+			Height: rendition.Height,
+			URL:    rendition.URL,
+		})
+	}
+
 	return &ProductImageResponseDto{
-		ID:        img.ID,
-		URL:       img.URL,
-		Order:     img.Order,
-		CreatedAt: img.CreatedAt,
-		DeletedAt: deletedAt,
+		ID:         img.ID,
+		URL:        img.URL,
+		Order:      img.Order,
+		CreatedAt:  img.CreatedAt,
+		DeletedAt:  deletedAt,
+		Renditions: renditions,
 	}
 }
 
@@ -282,6 +463,19 @@ func ToProductImageResponseDtoList(images []domain.ProductImage) []ProductImageR
 	return result
 }
 
+// ToProductSuggestionResponseDtoList maps a slice of domain.ProductSuggestion to a slice of ProductSuggestionResponseDto
+func ToProductSuggestionResponseDtoList(suggestions []domain.ProductSuggestion) []ProductSuggestionResponseDto {
+	result := make([]ProductSuggestionResponseDto, 0, len(suggestions))
+	for _, s := range suggestions {
+		result = append(result, ProductSuggestionResponseDto{
+			ID:   s.ID,
+			Type: string(s.Type),
+			Text: s.Text,
+		})
+	}
+	return result
+}
+
 // ToProductCategoryResponseDto maps a domain.Category to ProductCategoryResponseDto
 func ToProductCategoryResponseDto(c *domain.Category) *ProductCategoryResponseDto {
 	if c == nil {
@@ -383,3 +577,76 @@ func (p *ProductResponseDto) WithAttributes(
 	p.Attributes = attributeResponses
 	return p
 }
+
+// WithSpecs adds the spec values of the product, ordered by group and spec,
+// values of removed specs are left out
+func (p *ProductResponseDto) WithSpecs(
+	specGroups []domain.SpecGroup,
+	specs []domain.ProductSpec,
+) *ProductResponseDto {
+	valueMap := make(map[uuid.UUID]domain.ProductSpec, len(specs))
+	for _, spec := range specs {
+		valueMap[spec.SpecID] = spec
+	}
+
+	specResponses := make([]ProductSpecResponseDto, 0, len(specs))
+	for _, group := range specGroups {
+		for _, spec := range group.RemainingSpecs() {
+			value, exists := valueMap[spec.ID]
+			if !exists {
+				continue
+			}
+			specResponses = append(specResponses, ProductSpecResponseDto{
+				ID:   spec.ID,
+				Code: spec.Code,
+				Name: spec.Name,
+				Type: spec.Type,
+				Unit: spec.Unit,
+				Group: ProductSpecGroupResponseDto{
+					ID:   group.ID,
+					Name: group.Name,
+				},
+				Value: *ToSpecValueResponseDto(&value),
+			})
+		}
+	}
+
+	p.Specs = specResponses
+	return p
+}
+
+// ToProductComparisonResponseDto maps compared products and their spec
+// comparisons to ProductComparisonResponseDto
+func ToProductComparisonResponseDto(
+	products []ProductResponseDto,
+	comparisons []domain.SpecComparison,
+) *ProductComparisonResponseDto {
+	groups := make([]SpecComparisonGroupResponseDto, 0, len(comparisons))
+	for _, comparison := range comparisons {
+		rows := make([]SpecComparisonRowResponseDto, 0, len(comparison.Rows))
+		for _, row := range comparison.Rows {
+			values := make([]*SpecValueResponseDto, 0, len(row.Values))
+			for _, value := range row.Values {
+				values = append(values, ToSpecValueResponseDto(value))
+			}
+			rows = append(rows, SpecComparisonRowResponseDto{
+				ID:     row.Spec.ID,
+				Code:   row.Spec.Code,
+				Name:   row.Spec.Name,
+				Type:   row.Spec.Type,
+				Unit:   row.Spec.Unit,
+				Values: values,
+				Same:   row.Same,
+			})
+		}
+		groups = append(groups, SpecComparisonGroupResponseDto{
+			ID:   comparison.Group.ID,
+			Name: comparison.Group.Name,
+			Rows: rows,
+		})
+	}
+	return &ProductComparisonResponseDto{
+		Products: products,
+		Groups:   groups,
+	}
+}
@@ -10,17 +10,24 @@ type Router interface {
 }
 
 type GinRouter struct {
-	categoryHandler  CategoryHandler
-	productHandler   ProductHandler
-	attributeHandler AttributeHandler
-	orderHandler     OrderHandler
-	cartHandler      CartHandler
+	categoryHandler     CategoryHandler
+	productHandler      ProductHandler
+	attributeHandler    AttributeHandler
+	orderHandler        OrderHandler
+	cartHandler         CartHandler
+	notificationHandler NotificationHandler
+	warehouseHandler    WarehouseHandler
+	specGroupHandler    SpecGroupHandler
+	cacheHandler        CacheHandler
+	webhookHandler      WebhookHandler
+	emailHandler        EmailHandler
+	taskHandler         TaskHandler
 
 	healthHandler     HealthHandler
 	metricMiddleware  MetricMiddleware
 	loggingMiddleware LoggingMiddleware
 	authMiddleware    AuthMiddleware
-	flushCacheHandler FlushCacheHandler
+	roleMiddleware    RoleMiddleware
 }
 
 func ProvideRouter(
@@ -28,24 +35,38 @@ func ProvideRouter(
 	metricMiddleware MetricMiddleware,
 	loggingMiddleware LoggingMiddleware,
 	authMiddleware AuthMiddleware,
+	roleMiddleware RoleMiddleware,
 	categoryHandler CategoryHandler,
 	productHandler ProductHandler,
 	attributeHandler AttributeHandler,
 	orderHandler OrderHandler,
 	cartHandler CartHandler,
-	flushCacheRedisHandler FlushCacheHandler,
+	notificationHandler NotificationHandler,
+	warehouseHandler WarehouseHandler,
+	specGroupHandler SpecGroupHandler,
+	cacheHandler CacheHandler,
+	webhookHandler WebhookHandler,
+	emailHandler EmailHandler,
+	taskHandler TaskHandler,
 ) *GinRouter {
 	return &GinRouter{
-		healthHandler:     healthCheckHandler,
-		metricMiddleware:  metricMiddleware,
-		loggingMiddleware: loggingMiddleware,
-		authMiddleware:    authMiddleware,
-		categoryHandler:   categoryHandler,
-		productHandler:    productHandler,
-		attributeHandler:  attributeHandler,
-		orderHandler:      orderHandler,
-		cartHandler:       cartHandler,
-		flushCacheHandler: flushCacheRedisHandler,
+		healthHandler:       healthCheckHandler,
+		metricMiddleware:    metricMiddleware,
+		loggingMiddleware:   loggingMiddleware,
+		authMiddleware:      authMiddleware,
+		roleMiddleware:      roleMiddleware,
+		categoryHandler:     categoryHandler,
+		productHandler:      productHandler,
+		attributeHandler:    attributeHandler,
+		orderHandler:        orderHandler,
+		cartHandler:         cartHandler,
+		notificationHandler: notificationHandler,
+		warehouseHandler:    warehouseHandler,
+		specGroupHandler:    specGroupHandler,
+		cacheHandler:        cacheHandler,
+		webhookHandler:      webhookHandler,
+		emailHandler:        emailHandler,
+		taskHandler:         taskHandler,
 	}
 }
 
@@ -82,17 +103,36 @@ func (r *GinRouter) RegisterRoutes(e *gin.Engine) {
 		products := api.Group("/products")
 		{
 			products.POST("", r.authMiddleware.Handler(), r.productHandler.Create)
-			products.GET("", r.productHandler.List)
-			products.GET("/:product_id", r.productHandler.Get)
+			products.GET("", r.authMiddleware.OptionalHandler(), r.productHandler.List)
+			products.GET("/suggestions", r.productHandler.ListSuggestions)
+			products.GET("/compare", r.authMiddleware.OptionalHandler(), r.productHandler.Compare)
+			products.GET("/:product_id", r.authMiddleware.OptionalHandler(), r.productHandler.Get)
+			products.GET("/:product_id/related", r.productHandler.ListRelated)
+			products.GET("/:product_id/bought-together", r.productHandler.ListBoughtTogether)
 			products.DELETE("/:product_id", r.authMiddleware.Handler(), r.productHandler.Delete)
+			products.POST("/:product_id/restore", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.Restore)
+			products.DELETE("/:product_id/purge", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin}), r.productHandler.Purge)
 			products.POST("/:product_id/images", r.authMiddleware.Handler(), r.productHandler.AddImages)
 			products.DELETE("/:product_id/images", r.authMiddleware.Handler(), r.productHandler.DeleteImages)
 			products.PATCH("/:product_id", r.authMiddleware.Handler(), r.productHandler.Update)
+			products.PUT("/:product_id/publishing", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.UpdatePublishing)
+			products.PUT("/:product_id/specs", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.UpdateSpecs)
 			products.GET("/images/upload-url", r.authMiddleware.Handler(), r.productHandler.GetUploadImageURL)
 			products.GET("/images/delete-url/:image_id", r.authMiddleware.Handler(), r.productHandler.GetDeleteImageURL)
 			products.POST("/:product_id/variants", r.authMiddleware.Handler(), r.productHandler.AddVariants)
 			products.PATCH("/:product_id/variants/:variant_id", r.authMiddleware.Handler(), r.productHandler.UpdateVariant)
+			products.PUT("/:product_id/variants/:variant_id/pricing", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.UpdateVariantPricing)
+			products.PUT("/:product_id/variants/:variant_id/components", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.UpdateVariantComponents)
+			products.DELETE("/:product_id/variants/:variant_id", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.DeleteVariant)
+			products.GET("/:product_id/variants/:variant_id/stock-movements", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.ListStockMovements)
+			products.POST("/:product_id/variants/:variant_id/stock-movements", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.CreateStockMovement)
+			products.POST("/:product_id/variants/:variant_id/stock-subscriptions", r.authMiddleware.Handler(), r.productHandler.SubscribeStock)
+			products.DELETE("/:product_id/variants/:variant_id/stock-subscriptions", r.authMiddleware.Handler(), r.productHandler.UnsubscribeStock)
 			products.PATCH("/:product_id/options", r.authMiddleware.Handler(), r.productHandler.UpdateOptions)
+			products.POST("/:product_id/options", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.AddOption)
+			products.DELETE("/:product_id/options/:option_id", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.DeleteOption)
+			products.POST("/:product_id/options/:option_id/values", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.AddOptionValues)
+			products.DELETE("/:product_id/options/:option_id/values/:value_id", r.authMiddleware.Handler(), r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}), r.productHandler.DeleteOptionValue)
 		}
 
 		attributes := api.Group("/attributes")
@@ -109,6 +149,19 @@ func (r *GinRouter) RegisterRoutes(e *gin.Engine) {
 			attributes.PATCH("/:attribute_id/values/:value_id", r.attributeHandler.UpdateValue)
 		}
 
+		specGroups := api.Group("/spec-groups")
+		{
+			staffOnly := r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff})
+			specGroups.GET("", r.specGroupHandler.List)
+			specGroups.GET("/:spec_group_id", r.specGroupHandler.Get)
+			specGroups.POST("", r.authMiddleware.Handler(), staffOnly, r.specGroupHandler.Create)
+			specGroups.PATCH("/:spec_group_id", r.authMiddleware.Handler(), staffOnly, r.specGroupHandler.Update)
+			specGroups.DELETE("/:spec_group_id", r.authMiddleware.Handler(), staffOnly, r.specGroupHandler.Delete)
+			specGroups.POST("/:spec_group_id/specs", r.authMiddleware.Handler(), staffOnly, r.specGroupHandler.AddSpecs)
+			specGroups.PATCH("/:spec_group_id/specs/:spec_id", r.authMiddleware.Handler(), staffOnly, r.specGroupHandler.UpdateSpec)
+			specGroups.DELETE("/:spec_group_id/specs/:spec_id", r.authMiddleware.Handler(), staffOnly, r.specGroupHandler.DeleteSpec)
+		}
+
 		orders := api.Group("/orders")
 		{
 			orders.GET("", r.authMiddleware.Handler(), r.orderHandler.List)
@@ -119,6 +172,27 @@ func (r *GinRouter) RegisterRoutes(e *gin.Engine) {
 
 		}
 
+		notifications := api.Group("/notifications")
+		{
+			notifications.Use(r.authMiddleware.Handler())
+			staffOnly := r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff})
+			notifications.GET("", r.notificationHandler.List)
+			notifications.GET("/staff", staffOnly, r.notificationHandler.ListStaff)
+			notifications.PATCH("/:notification_id/read", r.notificationHandler.MarkRead)
+			notifications.PATCH("/staff/:notification_id/read", staffOnly, r.notificationHandler.MarkStaffRead)
+		}
+
+		warehouses := api.Group("/warehouses")
+		{
+			warehouses.Use(r.authMiddleware.Handler())
+			warehouses.Use(r.roleMiddleware.Handler([]UserRole{RoleAdmin, RoleStaff}))
+			warehouses.GET("", r.warehouseHandler.List)
+			warehouses.GET("/:warehouse_id", r.warehouseHandler.Get)
+			warehouses.POST("", r.warehouseHandler.Create)
+			warehouses.PATCH("/:warehouse_id", r.warehouseHandler.Update)
+			warehouses.DELETE("/:warehouse_id", r.warehouseHandler.Delete)
+		}
+
 		// returnRequests := api.Group("/return-requests")
 		// {
 		// 	returnRequests.GET("", r.returnHandler.List)
@@ -142,9 +216,24 @@ func (r *GinRouter) RegisterRoutes(e *gin.Engine) {
 		// 	reviews.PATCH("/:review_id", r.reviewHandler.Update)
 		// 	reviews.DELETE("/:review_id", r.reviewHandler.Delete)
 		// }
-		dev := api.Group("/dev")
+
+		admin := api.Group("/admin")
 		{
-			dev.POST("/flush-cache", r.flushCacheHandler.Handler())
+			admin.Use(r.authMiddleware.Handler())
+			admin.Use(r.roleMiddleware.Handler([]UserRole{RoleAdmin}))
+			admin.GET("/cache/keys", r.cacheHandler.ListKeys)
+			admin.POST("/cache/invalidate", r.cacheHandler.Invalidate)
+			admin.GET("/webhooks", r.webhookHandler.List)
+			admin.GET("/webhooks/:webhook_id", r.webhookHandler.Get)
+			admin.POST("/webhooks", r.webhookHandler.Create)
+			admin.PATCH("/webhooks/:webhook_id", r.webhookHandler.Update)
+			admin.DELETE("/webhooks/:webhook_id", r.webhookHandler.Delete)
+			admin.GET("/webhooks/:webhook_id/deliveries", r.webhookHandler.ListDeliveries)
+			admin.POST("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", r.webhookHandler.Redeliver)
+			admin.GET("/emails", r.emailHandler.List)
+			admin.GET("/tasks", r.taskHandler.List)
+			admin.GET("/tasks/:task_id", r.taskHandler.Get)
+			admin.POST("/tasks/:task_id/retry", r.taskHandler.Retry)
 		}
 	}
 }
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"context"
+)
+
+type SpecGroupApplication interface {
+	List(ctx context.Context, param ListSpecGroupsRequestDto) (*PaginationResponseDto[SpecGroupResponseDto], error)
+	Get(ctx context.Context, param GetSpecGroupRequestDto) (*SpecGroupResponseDto, error)
+	Create(ctx context.Context, param CreateSpecGroupRequestDto) (*SpecGroupResponseDto, error)
+	Update(ctx context.Context, param UpdateSpecGroupRequestDto) (*SpecGroupResponseDto, error)
+	Delete(ctx context.Context, param DeleteSpecGroupRequestDto) error
+	AddSpecs(ctx context.Context, param AddSpecsRequestDto) (*SpecGroupResponseDto, error)
+	UpdateSpec(ctx context.Context, param UpdateSpecRequestDto) (*SpecResponseDto, error)
+	DeleteSpec(ctx context.Context, param DeleteSpecRequestDto) error
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ListSpecGroupsRequestDto struct {
+	PaginationRequestDto
+}
+
+type GetSpecGroupRequestDto struct {
+	SpecGroupID uuid.UUID
+}
+
+type CreateSpecGroupRequestDto struct {
+	Data CreateSpecGroupData
+}
+
+type CreateSpecGroupData struct {
+	Name  string           `json:"name"  binding:"required"`
+	Order int              `json:"order" binding:"omitempty,gte=0"`
+	Specs []CreateSpecData `json:"specs" binding:"omitempty,dive"`
+}
+
+type CreateSpecData struct {
+	Code    string          `json:"code"    binding:"required"`
+	Name    string          `json:"name"    binding:"required"`
+	Type    domain.SpecType `json:"type"    binding:"required,oneof=number boolean enum"`
+	Unit    string          `json:"unit"`
+	Choices []string        `json:"choices"`
+	Order   int             `json:"order"   binding:"omitempty,gte=0"`
+}
+
+type UpdateSpecGroupRequestDto struct {
+	SpecGroupID uuid.UUID
+	Data        UpdateSpecGroupData
+}
+
+type UpdateSpecGroupData struct {
+	Name  string `json:"name"`
+	Order *int   `json:"order" binding:"omitempty,gte=0"`
+}
+
+type DeleteSpecGroupRequestDto struct {
+	SpecGroupID uuid.UUID
+}
+
+type AddSpecsRequestDto struct {
+	SpecGroupID uuid.UUID
+	Data        AddSpecsData
+}
+
+type AddSpecsData struct {
+	Specs []CreateSpecData `json:"specs" binding:"required,min=1,dive"`
+}
+
+type UpdateSpecRequestDto struct {
+	SpecGroupID uuid.UUID
+	SpecID      uuid.UUID
+	Data        UpdateSpecData
+}
+
+type UpdateSpecData struct {
+	Name string  `json:"name"`
+	Unit *string `json:"unit"`
+	// Choices replaces the choices of an enum, existing ones must be kept
+	Choices []string `json:"choices"`
+	Order   *int     `json:"order"   binding:"omitempty,gte=0"`
+}
+
+type DeleteSpecRequestDto struct {
+	SpecGroupID uuid.UUID
+	SpecID      uuid.UUID
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// SpecGroupResponseDto represents the response structure for a spec group
+type SpecGroupResponseDto struct {
+	ID        uuid.UUID         `json:"id"        binding:"required"`
+	Name      string            `json:"name"      binding:"required"`
+	Order     int               `json:"order"     binding:"required"`
+	Specs     []SpecResponseDto `json:"specs"     binding:"required"`
+	CreatedAt time.Time         `json:"createdAt" binding:"required"`
+	UpdatedAt time.Time         `json:"updatedAt" binding:"required"`
+	DeletedAt *time.Time        `json:"deletedAt"`
+}
+
+// SpecResponseDto represents the response structure for a spec
+type SpecResponseDto struct {
+	ID        uuid.UUID       `json:"id"        binding:"required"`
+	Code      string          `json:"code"      binding:"required"`
+	Name      string          `json:"name"      binding:"required"`
+	Type      domain.SpecType `json:"type"      binding:"required"`
+	Unit      string          `json:"unit"      binding:"required"`
+	Choices   []string        `json:"choices"   binding:"required"`
+	Order     int             `json:"order"     binding:"required"`
+	DeletedAt *time.Time      `json:"deletedAt"`
+}
+
+// SpecValueResponseDto represents a spec value, only the field matching the
+// type of the spec is set
+type SpecValueResponseDto struct {
+	Number  *float64 `json:"number"`
+	Boolean *bool    `json:"boolean"`
+	Enum    *string  `json:"enum"`
+}
+
+// ToSpecGroupResponseDto maps a domain.SpecGroup to SpecGroupResponseDto
+func ToSpecGroupResponseDto(g *domain.SpecGroup) *SpecGroupResponseDto {
+	if g == nil {
+		return nil
+	}
+
+	specs := make([]SpecResponseDto, 0, len(g.Specs))
+	for _, s := range g.RemainingSpecs() {
+		specs = append(specs, *ToSpecResponseDto(&s))
+	}
+
+	var deletedAt *time.Time
+	if !g.DeletedAt.IsZero() {
+		deletedAt = &g.DeletedAt
+	}
+	return &SpecGroupResponseDto{
+		ID:        g.ID,
+		Name:      g.Name,
+		Order:     g.Order,
+		Specs:     specs,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+		DeletedAt: deletedAt,
+	}
+}
+
+// ToSpecResponseDto maps a domain.Spec to SpecResponseDto
+func ToSpecResponseDto(s *domain.Spec) *SpecResponseDto {
+	if s == nil {
+		return nil
+	}
+	choices := s.Choices
+	if choices == nil {
+		choices = []string{}
+	}
+	var deletedAt *time.Time
+	if !s.DeletedAt.IsZero() {
+		deletedAt = &s.DeletedAt
+	}
+	return &SpecResponseDto{
+		ID:        s.ID,
+		Code:      s.Code,
+		Name:      s.Name,
+		Type:      s.Type,
+		Unit:      s.Unit,
+		Choices:   choices,
+		Order:     s.Order,
+		DeletedAt: deletedAt,
+	}
+}
+
+// ToSpecValueResponseDto maps a domain.ProductSpec to SpecValueResponseDto
+func ToSpecValueResponseDto(v *domain.ProductSpec) *SpecValueResponseDto {
+	if v == nil {
+		return nil
+	}
+	var enum *string
+	if v.Enum != "" {
+		enum = &v.Enum
+	}
+	return &SpecValueResponseDto{
+		Number:  v.Number,
+		Boolean: v.Boolean,
+		Enum:    enum,
+	}
+}
+
+// ToSpecGroupResponseDtoList maps a slice of domain.SpecGroup to a slice of SpecGroupResponseDto
+func ToSpecGroupResponseDtoList(groups []domain.SpecGroup) []SpecGroupResponseDto {
+	result := make([]SpecGroupResponseDto, 0, len(groups))
+	for _, g := range groups {
+		dto := ToSpecGroupResponseDto(&g)
+		if dto != nil {
+			result = append(result, *dto)
+		}
+	}
+	return result
+}
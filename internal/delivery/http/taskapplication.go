@@ -0,0 +1,11 @@
+package http
+
+import (
+	"context"
+)
+
+type TaskApplication interface {
+	List(ctx context.Context, param ListTaskRequestDto) (*PaginationResponseDto[TaskResponseDto], error)
+	Get(ctx context.Context, param GetTaskRequestDto) (*TaskResponseDto, error)
+	Retry(ctx context.Context, param RetryTaskRequestDto) (*TaskResponseDto, error)
+}
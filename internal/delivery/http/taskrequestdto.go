@@ -0,0 +1,21 @@
+package http
+
+import (
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ListTaskRequestDto struct {
+	PaginationRequestDto
+	Kinds  []domain.TaskKind
+	Status domain.TaskStatus
+}
+
+type GetTaskRequestDto struct {
+	TaskID uuid.UUID
+}
+
+type RetryTaskRequestDto struct {
+	TaskID uuid.UUID
+}
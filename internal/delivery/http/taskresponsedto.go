@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type TaskResponseDto struct {
+	ID         uuid.UUID         `json:"id"         binding:"required"`
+	Kind       domain.TaskKind   `json:"kind"       binding:"required"`
+	Key        string            `json:"key"        binding:"required"`
+	Payload    json.RawMessage   `json:"payload"    binding:"required" swaggertype:"object"`
+	Status     domain.TaskStatus `json:"status"     binding:"required"`
+	Attempts   int               `json:"attempts"   binding:"required"`
+	Error      string            `json:"error"      binding:"required"`
+	RunAt      time.Time         `json:"runAt"      binding:"required"`
+	CreatedAt  time.Time         `json:"createdAt"  binding:"required"`
+	UpdatedAt  time.Time         `json:"updatedAt"  binding:"required"`
+	FinishedAt *time.Time        `json:"finishedAt"`
+}
+
+// ToTaskResponseDto maps a domain.Task to TaskResponseDto
+func ToTaskResponseDto(t *domain.Task) *TaskResponseDto {
+	if t == nil {
+		return nil
+	}
+
+	var finishedAt *time.Time
+	if !t.FinishedAt.IsZero() {
+		finishedAt = &t.FinishedAt
+	}
+	return &TaskResponseDto{
+		ID:         t.ID,
+		Kind:       t.Kind,
+		Key:        t.Key,
+		Payload:    json.RawMessage(t.Payload),
+		Status:     t.Status,
+		Attempts:   t.Attempts,
+		Error:      t.Error,
+		RunAt:      t.RunAt,
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+		FinishedAt: finishedAt,
+	}
+}
+
+// ToTaskResponseDtoList maps a slice of domain.Task to a slice of TaskResponseDto
+func ToTaskResponseDtoList(tasks []domain.Task) []TaskResponseDto {
+	result := make([]TaskResponseDto, 0, len(tasks))
+	for _, t := range tasks {
+		dto := ToTaskResponseDto(&t)
+		if dto != nil {
+			result = append(result, *dto)
+		}
+	}
+	return result
+}
@@ -0,0 +1,13 @@
+package http
+
+import (
+	"context"
+)
+
+type WarehouseApplication interface {
+	Create(ctx context.Context, param CreateWarehouseRequestDto) (*WarehouseResponseDto, error)
+	List(ctx context.Context, param ListWarehouseRequestDto) (*PaginationResponseDto[WarehouseResponseDto], error)
+	Get(ctx context.Context, param GetWarehouseRequestDto) (*WarehouseResponseDto, error)
+	Update(ctx context.Context, param UpdateWarehouseRequestDto) (*WarehouseResponseDto, error)
+	Delete(ctx context.Context, param DeleteWarehouseRequestDto) error
+}
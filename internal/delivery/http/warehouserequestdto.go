@@ -0,0 +1,36 @@
+package http
+
+import "github.com/google/uuid"
+
+type ListWarehouseRequestDto struct {
+	PaginationRequestDto
+}
+
+type CreateWarehouseRequestDto struct {
+	Data CreateWarehouseData
+}
+
+type CreateWarehouseData struct {
+	Name     string `json:"name"     binding:"required"`
+	Province string `json:"province" binding:"required"`
+	Priority int    `json:"priority" binding:"omitempty,gte=0"`
+}
+
+type GetWarehouseRequestDto struct {
+	WarehouseID uuid.UUID
+}
+
+type UpdateWarehouseRequestDto struct {
+	WarehouseID uuid.UUID
+	Data        UpdateWarehouseData
+}
+
+type UpdateWarehouseData struct {
+	Name     string `json:"name"`
+	Province string `json:"province"`
+	Priority *int   `json:"priority" binding:"omitempty,gte=0"`
+}
+
+type DeleteWarehouseRequestDto struct {
+	WarehouseID uuid.UUID
+}
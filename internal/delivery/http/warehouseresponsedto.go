@@ -0,0 +1,53 @@
+package http
+
+import (
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// WarehouseResponseDto represents the response structure for a warehouse
+type WarehouseResponseDto struct {
+	ID        uuid.UUID  `json:"id"        binding:"required"`
+	Name      string     `json:"name"      binding:"required"`
+	Province  string     `json:"province"  binding:"required"`
+	Priority  int        `json:"priority"  binding:"required"`
+	CreatedAt time.Time  `json:"createdAt" binding:"required"`
+	UpdatedAt time.Time  `json:"updatedAt" binding:"required"`
+	DeletedAt *time.Time `json:"deletedAt"`
+}
+
+// ToWarehouseResponseDto maps a domain.Warehouse to WarehouseResponseDto
+func ToWarehouseResponseDto(w *domain.Warehouse) *WarehouseResponseDto {
+	if w == nil {
+		return nil
+	}
+
+	var deletedAt *time.Time
+	if !w.DeletedAt.IsZero() {
+		deletedAt = &w.DeletedAt
+	}
+	return &WarehouseResponseDto{
+		ID:        w.ID,
+		Name:      w.Name,
+		Province:  w.Province,
+		Priority:  w.Priority,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+		DeletedAt: deletedAt,
+	}
+}
+
+// ToWarehouseResponseDtoList maps a slice of domain.Warehouse to a slice of WarehouseResponseDto
+func ToWarehouseResponseDtoList(warehouses []domain.Warehouse) []WarehouseResponseDto {
+	result := make([]WarehouseResponseDto, 0, len(warehouses))
+	for _, w := range warehouses {
+		dto := ToWarehouseResponseDto(&w)
+		if dto != nil {
+			result = append(result, *dto)
+		}
+	}
+	return result
+}
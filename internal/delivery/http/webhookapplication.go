@@ -0,0 +1,15 @@
+package http
+
+import (
+	"context"
+)
+
+type WebhookApplication interface {
+	Create(ctx context.Context, param CreateWebhookRequestDto) (*WebhookResponseDto, error)
+	List(ctx context.Context, param ListWebhookRequestDto) (*PaginationResponseDto[WebhookResponseDto], error)
+	Get(ctx context.Context, param GetWebhookRequestDto) (*WebhookResponseDto, error)
+	Update(ctx context.Context, param UpdateWebhookRequestDto) (*WebhookResponseDto, error)
+	Delete(ctx context.Context, param DeleteWebhookRequestDto) error
+	ListDeliveries(ctx context.Context, param ListWebhookDeliveryRequestDto) (*PaginationResponseDto[WebhookDeliveryResponseDto], error)
+	Redeliver(ctx context.Context, param RedeliverWebhookDeliveryRequestDto) (*WebhookDeliveryResponseDto, error)
+}
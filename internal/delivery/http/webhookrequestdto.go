@@ -0,0 +1,53 @@
+package http
+
+import (
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ListWebhookRequestDto struct {
+	PaginationRequestDto
+}
+
+type CreateWebhookRequestDto struct {
+	Data CreateWebhookData
+}
+
+type CreateWebhookData struct {
+	URL        string             `json:"url"        binding:"required,http_url"`
+	EventTypes []domain.EventType `json:"eventTypes" binding:"required,min=1"`
+}
+
+type GetWebhookRequestDto struct {
+	WebhookID uuid.UUID
+}
+
+type UpdateWebhookRequestDto struct {
+	WebhookID uuid.UUID
+	Data      UpdateWebhookData
+}
+
+type UpdateWebhookData struct {
+	URL        string             `json:"url"        binding:"omitempty,http_url"`
+	EventTypes []domain.EventType `json:"eventTypes"`
+	Active     *bool              `json:"active"`
+	// RotateSecret replaces the signing secret, the partner has to switch to
+	// the returned one
+	RotateSecret bool `json:"rotateSecret"`
+}
+
+type DeleteWebhookRequestDto struct {
+	WebhookID uuid.UUID
+}
+
+type ListWebhookDeliveryRequestDto struct {
+	PaginationRequestDto
+	WebhookID uuid.UUID
+	Status    domain.WebhookDeliveryStatus
+}
+
+type RedeliverWebhookDeliveryRequestDto struct {
+	WebhookID  uuid.UUID
+	DeliveryID uuid.UUID
+}
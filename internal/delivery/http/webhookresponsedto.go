@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// WebhookResponseDto represents the response structure for a webhook
+type WebhookResponseDto struct {
+	ID  uuid.UUID `json:"id"  binding:"required"`
+	URL string    `json:"url" binding:"required"`
+	// Secret signs the deliveries, partners verify the Webhook-Signature
+	// header with it. It is only revealed on create and on rotation,
+	// otherwise it is masked down to its last 4 characters
+	Secret     string             `json:"secret"     binding:"required"`
+	EventTypes []domain.EventType `json:"eventTypes" binding:"required"`
+	Active     bool               `json:"active"     binding:"required"`
+	CreatedAt  time.Time          `json:"createdAt"  binding:"required"`
+	UpdatedAt  time.Time          `json:"updatedAt"  binding:"required"`
+	DeletedAt  *time.Time         `json:"deletedAt"`
+}
+
+// ToWebhookResponseDto maps a domain.Webhook to WebhookResponseDto with its
+// secret masked
+func ToWebhookResponseDto(w *domain.Webhook) *WebhookResponseDto {
+	dto := ToWebhookResponseDtoWithSecret(w)
+	if dto != nil {
+		dto.Secret = maskWebhookSecret(w.Secret)
+	}
+	return dto
+}
+
+// ToWebhookResponseDtoWithSecret maps a domain.Webhook to WebhookResponseDto
+// revealing its secret, for when it has just been created or rotated
+func ToWebhookResponseDtoWithSecret(w *domain.Webhook) *WebhookResponseDto {
+	if w == nil {
+		return nil
+	}
+
+	var deletedAt *time.Time
+	if !w.DeletedAt.IsZero() {
+		deletedAt = &w.DeletedAt
+	}
+	return &WebhookResponseDto{
+		ID:         w.ID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+		DeletedAt:  deletedAt,
+	}
+}
+
+// maskWebhookSecret keeps the prefix and the last 4 characters of a secret
+func maskWebhookSecret(secret string) string {
+	const visible = 4
+	prefix, _, found := strings.Cut(secret, "_")
+	if !found || len(secret) <= len(prefix)+1+visible {
+		return ""
+	}
+	return prefix + "_…" + secret[len(secret)-visible:]
+}
+
+// ToWebhookResponseDtoList maps a slice of domain.Webhook to a slice of WebhookResponseDto
+func ToWebhookResponseDtoList(webhooks []domain.Webhook) []WebhookResponseDto {
+	result := make([]WebhookResponseDto, 0, len(webhooks))
+	for _, w := range webhooks {
+		dto := ToWebhookResponseDto(&w)
+		if dto != nil {
+			result = append(result, *dto)
+		}
+	}
+	return result
+}
+
+// WebhookDeliveryResponseDto represents an entry of the delivery log of a
+// webhook, with the outcome of its last attempt
+type WebhookDeliveryResponseDto struct {
+	ID        uuid.UUID        `json:"id"        binding:"required"`
+	WebhookID uuid.UUID        `json:"webhookId" binding:"required"`
+	EventID   uuid.UUID        `json:"eventId"   binding:"required"`
+	EventType domain.EventType `json:"eventType" binding:"required"`
+	// Payload is the body sent to the webhook
+	Payload       json.RawMessage              `json:"payload"       binding:"required" swaggertype:"object"`
+	Status        domain.WebhookDeliveryStatus `json:"status"        binding:"required"`
+	Attempts      int                          `json:"attempts"      binding:"required"`
+	ResponseCode  int                          `json:"responseCode"  binding:"required"`
+	ResponseBody  string                       `json:"responseBody"  binding:"required"`
+	Error         string                       `json:"error"         binding:"required"`
+	NextAttemptAt time.Time                    `json:"nextAttemptAt" binding:"required"`
+	CreatedAt     time.Time                    `json:"createdAt"     binding:"required"`
+	UpdatedAt     time.Time                    `json:"updatedAt"     binding:"required"`
+	DeliveredAt   *time.Time                   `json:"deliveredAt"`
+}
+
+// ToWebhookDeliveryResponseDto maps a domain.WebhookDelivery to WebhookDeliveryResponseDto
+func ToWebhookDeliveryResponseDto(d *domain.WebhookDelivery) *WebhookDeliveryResponseDto {
+	if d == nil {
+		return nil
+	}
+
+	var deliveredAt *time.Time
+	if !d.DeliveredAt.IsZero() {
+		deliveredAt = &d.DeliveredAt
+	}
+	return &WebhookDeliveryResponseDto{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Payload:       d.Payload,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		ResponseCode:  d.ResponseCode,
+		ResponseBody:  d.ResponseBody,
+		Error:         d.Error,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+		DeliveredAt:   deliveredAt,
+	}
+}
+
+// ToWebhookDeliveryResponseDtoList maps a slice of domain.WebhookDelivery to a slice of WebhookDeliveryResponseDto
+func ToWebhookDeliveryResponseDtoList(deliveries []domain.WebhookDelivery) []WebhookDeliveryResponseDto {
+	result := make([]WebhookDeliveryResponseDto, 0, len(deliveries))
+	for _, d := range deliveries {
+		dto := ToWebhookDeliveryResponseDto(&d)
+		if dto != nil {
+			result = append(result, *dto)
+		}
+	}
+	return result
+}
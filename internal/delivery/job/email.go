@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"backend/config"
+)
+
+type EmailEnqueueJob struct {
+	emailApp EmailApplication
+	interval time.Duration
+}
+
+var _ Job = (*EmailEnqueueJob)(nil)
+
+func ProvideEmailEnqueueJob(emailApp EmailApplication, srvCfg *config.Server) *EmailEnqueueJob {
+	return &EmailEnqueueJob{
+		emailApp: emailApp,
+		interval: srvCfg.EmailEnqueueInterval,
+	}
+}
+
+func (j *EmailEnqueueJob) Name() string {
+	return "email_enqueue"
+}
+
+func (j *EmailEnqueueJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *EmailEnqueueJob) Run(ctx context.Context) error {
+	return j.emailApp.EnqueueEmails(ctx)
+}
+
+type EmailSendJob struct {
+	emailApp EmailApplication
+	interval time.Duration
+}
+
+var _ Job = (*EmailSendJob)(nil)
+
+func ProvideEmailSendJob(emailApp EmailApplication, srvCfg *config.Server) *EmailSendJob {
+	return &EmailSendJob{
+		emailApp: emailApp,
+		interval: srvCfg.EmailSendInterval,
+	}
+}
+
+func (j *EmailSendJob) Name() string {
+	return "email_send"
+}
+
+func (j *EmailSendJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *EmailSendJob) Run(ctx context.Context) error {
+	return j.emailApp.SendEmails(ctx)
+}
@@ -0,0 +1,8 @@
+package job
+
+import "context"
+
+type EmailApplication interface {
+	EnqueueEmails(ctx context.Context) error
+	SendEmails(ctx context.Context) error
+}
@@ -0,0 +1,12 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,51 @@
+package job
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	runResultSuccess = "success"
+	runResultError   = "error"
+)
+
+var (
+	jobRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_runs_total",
+			Help: "Total number of job runs by job and result",
+		},
+		[]string{"job", "result"},
+	)
+	jobRunDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_run_duration_seconds",
+			Help:    "Job run duration in seconds",
+			Buckets: prometheus.ExponentialBuckets(.005, 4, 10),
+		},
+		[]string{"job"},
+	)
+	jobLastSuccess = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "job_last_success_timestamp_seconds",
+			Help: "Unix time of the last successful run of a job",
+		},
+		[]string{"job"},
+	)
+	taskRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "task_runs_total",
+			Help: "Total number of task runs by kind and result",
+		},
+		[]string{"kind", "result"},
+	)
+	taskRunDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "task_run_duration_seconds",
+			Help:    "Task run duration in seconds",
+			Buckets: prometheus.ExponentialBuckets(.005, 4, 10),
+		},
+		[]string{"kind"},
+	)
+)
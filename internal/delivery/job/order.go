@@ -0,0 +1,41 @@
+package job
+
+import (
+	"context"
+
+	"backend/config"
+	"backend/internal/domain"
+)
+
+const TaskKindOrderExpireUnpaid domain.TaskKind = "order_expire_unpaid"
+
+// OrderExpireTaskHandler cancels the online payment orders which were not paid
+// before the deadline of their provider
+type OrderExpireTaskHandler struct {
+	orderApp OrderApplication
+	schedule string
+}
+
+var _ TaskHandler = (*OrderExpireTaskHandler)(nil)
+
+func ProvideOrderExpireTaskHandler(
+	orderApp OrderApplication,
+	srvCfg *config.Server,
+) *OrderExpireTaskHandler {
+	return &OrderExpireTaskHandler{
+		orderApp: orderApp,
+		schedule: srvCfg.OrderExpireSchedule,
+	}
+}
+
+func (h *OrderExpireTaskHandler) Kind() domain.TaskKind {
+	return TaskKindOrderExpireUnpaid
+}
+
+func (h *OrderExpireTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *OrderExpireTaskHandler) Handle(ctx context.Context, _ domain.Task) error {
+	return h.orderApp.ExpireUnpaidOrders(ctx)
+}
@@ -0,0 +1,7 @@
+package job
+
+import "context"
+
+type OrderApplication interface {
+	ExpireUnpaidOrders(ctx context.Context) error
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"backend/config"
+)
+
+type OutboxRelayJob struct {
+	outboxApp OutboxApplication
+	interval  time.Duration
+}
+
+var _ Job = (*OutboxRelayJob)(nil)
+
+func ProvideOutboxRelayJob(outboxApp OutboxApplication, srvCfg *config.Server) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		outboxApp: outboxApp,
+		interval:  srvCfg.OutboxRelayInterval,
+	}
+}
+
+func (j *OutboxRelayJob) Name() string {
+	return "outbox_relay"
+}
+
+func (j *OutboxRelayJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *OutboxRelayJob) Run(ctx context.Context) error {
+	return j.outboxApp.RelayEvents(ctx)
+}
+
+type OutboxPurgeJob struct {
+	outboxApp OutboxApplication
+	interval  time.Duration
+}
+
+var _ Job = (*OutboxPurgeJob)(nil)
+
+func ProvideOutboxPurgeJob(outboxApp OutboxApplication, srvCfg *config.Server) *OutboxPurgeJob {
+	return &OutboxPurgeJob{
+		outboxApp: outboxApp,
+		interval:  srvCfg.OutboxPurgeInterval,
+	}
+}
+
+func (j *OutboxPurgeJob) Name() string {
+	return "outbox_purge"
+}
+
+func (j *OutboxPurgeJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *OutboxPurgeJob) Run(ctx context.Context) error {
+	return j.outboxApp.PurgeEvents(ctx)
+}
@@ -0,0 +1,8 @@
+package job
+
+import "context"
+
+type OutboxApplication interface {
+	RelayEvents(ctx context.Context) error
+	PurgeEvents(ctx context.Context) error
+}
@@ -0,0 +1,233 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"backend/config"
+	"backend/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+type ProductViewFlushJob struct {
+	productApp ProductApplication
+	interval   time.Duration
+}
+
+var _ Job = (*ProductViewFlushJob)(nil)
+
+func ProvideProductViewFlushJob(productApp ProductApplication, srvCfg *config.Server) *ProductViewFlushJob {
+	return &ProductViewFlushJob{
+		productApp: productApp,
+		interval:   srvCfg.ProductViewFlushInterval,
+	}
+}
+
+func (j *ProductViewFlushJob) Name() string {
+	return "product_view_flush"
+}
+
+func (j *ProductViewFlushJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *ProductViewFlushJob) Run(ctx context.Context) error {
+	return j.productApp.FlushViews(ctx)
+}
+
+const TaskKindProductTrending domain.TaskKind = "product_trending"
+
+// ProductTrendingTaskHandler recomputes the trending score of the products
+type ProductTrendingTaskHandler struct {
+	productApp ProductApplication
+	schedule   string
+}
+
+var _ TaskHandler = (*ProductTrendingTaskHandler)(nil)
+
+func ProvideProductTrendingTaskHandler(productApp ProductApplication, srvCfg *config.Server) *ProductTrendingTaskHandler {
+	return &ProductTrendingTaskHandler{
+		productApp: productApp,
+		schedule:   srvCfg.ProductTrendingSchedule,
+	}
+}
+
+func (h *ProductTrendingTaskHandler) Kind() domain.TaskKind {
+	return TaskKindProductTrending
+}
+
+func (h *ProductTrendingTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *ProductTrendingTaskHandler) Handle(ctx context.Context, _ domain.Task) error {
+	return h.productApp.UpdateTrendingScores(ctx)
+}
+
+const TaskKindProductRecommendation domain.TaskKind = "product_recommendation"
+
+// ProductRecommendationTaskHandler recomputes the related and bought together
+// products of every product
+type ProductRecommendationTaskHandler struct {
+	productApp ProductApplication
+	schedule   string
+}
+
+var _ TaskHandler = (*ProductRecommendationTaskHandler)(nil)
+
+func ProvideProductRecommendationTaskHandler(productApp ProductApplication, srvCfg *config.Server) *ProductRecommendationTaskHandler {
+	return &ProductRecommendationTaskHandler{
+		productApp: productApp,
+		schedule:   srvCfg.ProductRecommendationSchedule,
+	}
+}
+
+func (h *ProductRecommendationTaskHandler) Kind() domain.TaskKind {
+	return TaskKindProductRecommendation
+}
+
+func (h *ProductRecommendationTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *ProductRecommendationTaskHandler) Handle(ctx context.Context, _ domain.Task) error {
+	return h.productApp.RefreshRecommendations(ctx)
+}
+
+const TaskKindProductStockReconciliation domain.TaskKind = "product_stock_reconciliation"
+
+// ProductStockReconciliationTaskHandler resets the quantities which drifted
+// from the stock ledger
+type ProductStockReconciliationTaskHandler struct {
+	productApp ProductApplication
+	schedule   string
+}
+
+var _ TaskHandler = (*ProductStockReconciliationTaskHandler)(nil)
+
+func ProvideProductStockReconciliationTaskHandler(productApp ProductApplication, srvCfg *config.Server) *ProductStockReconciliationTaskHandler {
+	return &ProductStockReconciliationTaskHandler{
+		productApp: productApp,
+		schedule:   srvCfg.ProductStockReconcileSchedule,
+	}
+}
+
+func (h *ProductStockReconciliationTaskHandler) Kind() domain.TaskKind {
+	return TaskKindProductStockReconciliation
+}
+
+func (h *ProductStockReconciliationTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *ProductStockReconciliationTaskHandler) Handle(ctx context.Context, _ domain.Task) error {
+	return h.productApp.ReconcileStock(ctx)
+}
+
+const TaskKindProductPublishSchedule domain.TaskKind = "product_publish_schedule"
+
+// ProductPublishScheduleTaskHandler publishes and archives the products whose
+// schedule is due
+type ProductPublishScheduleTaskHandler struct {
+	productApp ProductApplication
+	schedule   string
+}
+
+var _ TaskHandler = (*ProductPublishScheduleTaskHandler)(nil)
+
+func ProvideProductPublishScheduleTaskHandler(productApp ProductApplication, srvCfg *config.Server) *ProductPublishScheduleTaskHandler {
+	return &ProductPublishScheduleTaskHandler{
+		productApp: productApp,
+		schedule:   srvCfg.ProductPublishApplySchedule,
+	}
+}
+
+func (h *ProductPublishScheduleTaskHandler) Kind() domain.TaskKind {
+	return TaskKindProductPublishSchedule
+}
+
+func (h *ProductPublishScheduleTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *ProductPublishScheduleTaskHandler) Handle(ctx context.Context, _ domain.Task) error {
+	return h.productApp.ApplyPublishSchedules(ctx)
+}
+
+const TaskKindProductPriceSync domain.TaskKind = "product_price_sync"
+
+// ProductPriceSyncTaskHandler refreshes the prices of the products whose sale
+// started or ended
+type ProductPriceSyncTaskHandler struct {
+	productApp ProductApplication
+	schedule   string
+}
+
+var _ TaskHandler = (*ProductPriceSyncTaskHandler)(nil)
+
+func ProvideProductPriceSyncTaskHandler(productApp ProductApplication, srvCfg *config.Server) *ProductPriceSyncTaskHandler {
+	return &ProductPriceSyncTaskHandler{
+		productApp: productApp,
+		schedule:   srvCfg.ProductPriceSyncSchedule,
+	}
+}
+
+func (h *ProductPriceSyncTaskHandler) Kind() domain.TaskKind {
+	return TaskKindProductPriceSync
+}
+
+func (h *ProductPriceSyncTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *ProductPriceSyncTaskHandler) Handle(ctx context.Context, _ domain.Task) error {
+	return h.productApp.SyncPrices(ctx)
+}
+
+const TaskKindProductImageCleanup domain.TaskKind = "product_image_cleanup"
+
+// ProductImageCleanupTaskHandler deletes the image objects nothing refers to
+// anymore and logs their keys, in a dry run the log is the only outcome
+type ProductImageCleanupTaskHandler struct {
+	productApp ProductApplication
+	logger     *zap.Logger
+	schedule   string
+}
+
+var _ TaskHandler = (*ProductImageCleanupTaskHandler)(nil)
+
+func ProvideProductImageCleanupTaskHandler(
+	productApp ProductApplication,
+	logger *zap.Logger,
+	srvCfg *config.Server,
+) *ProductImageCleanupTaskHandler {
+	return &ProductImageCleanupTaskHandler{
+		productApp: productApp,
+		logger:     logger,
+		schedule:   srvCfg.ProductImageCleanupSchedule,
+	}
+}
+
+func (h *ProductImageCleanupTaskHandler) Kind() domain.TaskKind {
+	return TaskKindProductImageCleanup
+}
+
+func (h *ProductImageCleanupTaskHandler) Schedule() string {
+	return h.schedule
+}
+
+func (h *ProductImageCleanupTaskHandler) Handle(ctx context.Context, task domain.Task) error {
+	result, err := h.productApp.CleanupImages(ctx)
+	if err != nil {
+		return err
+	}
+	h.logger.Info(
+		"product images cleaned up",
+		zap.String("task_id", task.ID.String()),
+		zap.Bool("dry_run", result.DryRun),
+		zap.Strings("temp_keys", result.TempKeys),
+		zap.Strings("orphan_keys", result.OrphanKeys),
+	)
+	return nil
+}
@@ -0,0 +1,21 @@
+package job
+
+import "context"
+
+type ProductApplication interface {
+	FlushViews(ctx context.Context) error
+	UpdateTrendingScores(ctx context.Context) error
+	RefreshRecommendations(ctx context.Context) error
+	ReconcileStock(ctx context.Context) error
+	ApplyPublishSchedules(ctx context.Context) error
+	SyncPrices(ctx context.Context) error
+	CleanupImages(ctx context.Context) (*CleanupImagesResult, error)
+}
+
+// CleanupImagesResult reports the object keys a cleanup deleted, or would
+// have deleted in a dry run
+type CleanupImagesResult struct {
+	DryRun     bool
+	TempKeys   []string
+	OrphanKeys []string
+}
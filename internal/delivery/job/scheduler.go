@@ -0,0 +1,99 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Scheduler runs each job on its own ticker until the context is done.
+// A failed run is logged and retried on the next tick. Every worker runs every
+// job, so jobs claim their rows with leases; periodic work which must run once
+// at a time is a scheduled task instead.
+type Scheduler struct {
+	logger *zap.Logger
+	jobs   []Job
+}
+
+func ProvideScheduler(
+	logger *zap.Logger,
+	productViewFlushJob *ProductViewFlushJob,
+	outboxRelayJob *OutboxRelayJob,
+	outboxPurgeJob *OutboxPurgeJob,
+	webhookEnqueueJob *WebhookEnqueueJob,
+	webhookDeliveryJob *WebhookDeliveryJob,
+	emailEnqueueJob *EmailEnqueueJob,
+	emailSendJob *EmailSendJob,
+	taskRunJob *TaskRunJob,
+	taskScheduleJob *TaskScheduleJob,
+	taskPurgeJob *TaskPurgeJob,
+) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		jobs: []Job{
+			productViewFlushJob,
+			outboxRelayJob,
+			outboxPurgeJob,
+			webhookEnqueueJob,
+			webhookDeliveryJob,
+			emailEnqueueJob,
+			emailSendJob,
+			taskRunJob,
+			taskScheduleJob,
+			taskPurgeJob,
+		},
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, j := range s.jobs {
+		if j.Interval() <= 0 {
+			s.logger.Warn("job disabled", zap.String("job", j.Name()))
+			continue
+		}
+		wg.Add(1)
+		go func(j Job) {
+			defer wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j Job) {
+	ticker := time.NewTicker(j.Interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, j)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, j Job) {
+	start := time.Now()
+	err := j.Run(ctx)
+	jobRunDuration.WithLabelValues(j.Name()).Observe(time.Since(start).Seconds())
+	if err != nil {
+		jobRunsTotal.WithLabelValues(j.Name(), runResultError).Inc()
+		s.logger.Error(
+			"job failed",
+			zap.String("job", j.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+	jobRunsTotal.WithLabelValues(j.Name(), runResultSuccess).Inc()
+	jobLastSuccess.WithLabelValues(j.Name()).SetToCurrentTime()
+	s.logger.Debug(
+		"job finished",
+		zap.String("job", j.Name()),
+		zap.Duration("latency", time.Since(start)),
+	)
+}
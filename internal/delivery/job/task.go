@@ -0,0 +1,232 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/config"
+	"backend/internal/domain"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// TaskHandler runs the tasks of a kind. A handler with a Schedule, a cron
+// expression like "*/5 * * * *" optionally prefixed with CRON_TZ=<zone>, gets
+// a task enqueued at each of its fire times
+type TaskHandler interface {
+	Kind() domain.TaskKind
+	Schedule() string
+	Handle(ctx context.Context, task domain.Task) error
+}
+
+// ProvideTaskHandlers lists the handlers the worker runs tasks with, a task
+// of a kind without a handler stays pending
+func ProvideTaskHandlers(
+	orderExpire *OrderExpireTaskHandler,
+	productImageCleanup *ProductImageCleanupTaskHandler,
+	productTrending *ProductTrendingTaskHandler,
+	productRecommendation *ProductRecommendationTaskHandler,
+	productStockReconciliation *ProductStockReconciliationTaskHandler,
+	productPublishSchedule *ProductPublishScheduleTaskHandler,
+	productPriceSync *ProductPriceSyncTaskHandler,
+) []TaskHandler {
+	return []TaskHandler{
+		orderExpire,
+		productImageCleanup,
+		productTrending,
+		productRecommendation,
+		productStockReconciliation,
+		productPublishSchedule,
+		productPriceSync,
+	}
+}
+
+type TaskRunJob struct {
+	taskApp  TaskApplication
+	logger   *zap.Logger
+	handlers map[domain.TaskKind]TaskHandler
+	kinds    []domain.TaskKind
+	interval time.Duration
+}
+
+var _ Job = (*TaskRunJob)(nil)
+
+// ProvideTaskRunJob panics when two handlers run the same kind, the handlers
+// are wired in code so it is a bug
+func ProvideTaskRunJob(
+	taskApp TaskApplication,
+	handlers []TaskHandler,
+	logger *zap.Logger,
+	srvCfg *config.Server,
+) *TaskRunJob {
+	j := &TaskRunJob{
+		taskApp:  taskApp,
+		logger:   logger,
+		handlers: make(map[domain.TaskKind]TaskHandler, len(handlers)),
+		interval: srvCfg.TaskRunInterval,
+	}
+	for _, handler := range handlers {
+		if _, ok := j.handlers[handler.Kind()]; ok {
+			panic(fmt.Sprintf("duplicate handler of task kind %q", handler.Kind()))
+		}
+		j.handlers[handler.Kind()] = handler
+		j.kinds = append(j.kinds, handler.Kind())
+	}
+	return j
+}
+
+func (j *TaskRunJob) Name() string {
+	return "task_run"
+}
+
+func (j *TaskRunJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *TaskRunJob) Run(ctx context.Context) error {
+	return j.taskApp.RunTasks(ctx, RunTasksParam{
+		Kinds:  j.kinds,
+		Handle: j.handle,
+	})
+}
+
+// handle runs a task with the handler of its kind, a panic fails the task
+// instead of the worker
+func (j *TaskRunJob) handle(ctx context.Context, task domain.Task) (err error) {
+	kind := string(task.Kind)
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+		taskRunDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+		if err != nil {
+			taskRunsTotal.WithLabelValues(kind, runResultError).Inc()
+			j.logger.Warn(
+				"task failed",
+				zap.String("kind", kind),
+				zap.String("task_id", task.ID.String()),
+				zap.Int("attempt", task.Attempts+1),
+				zap.Error(err),
+			)
+			return
+		}
+		taskRunsTotal.WithLabelValues(kind, runResultSuccess).Inc()
+	}()
+	return j.handlers[task.Kind].Handle(ctx, task)
+}
+
+type taskSchedule struct {
+	kind     domain.TaskKind
+	schedule cron.Schedule
+	next     time.Time
+}
+
+// TaskScheduleJob enqueues the tasks of the scheduled handlers when their fire
+// time comes. Every worker enqueues them, the key of a task names its fire time
+// so only the first one is queued. Fire times missed while the worker was down
+// are skipped like cron does, only the latest one is enqueued
+type TaskScheduleJob struct {
+	taskApp   TaskApplication
+	schedules []*taskSchedule
+	interval  time.Duration
+}
+
+var _ Job = (*TaskScheduleJob)(nil)
+
+// ProvideTaskScheduleJob panics on an invalid schedule, like a handler wired
+// twice it is a bug which should stop the worker from starting
+func ProvideTaskScheduleJob(
+	taskApp TaskApplication,
+	handlers []TaskHandler,
+	srvCfg *config.Server,
+) *TaskScheduleJob {
+	j := &TaskScheduleJob{
+		taskApp:  taskApp,
+		interval: srvCfg.TaskScheduleInterval,
+	}
+	now := time.Now()
+	for _, handler := range handlers {
+		if handler.Schedule() == "" {
+			continue
+		}
+		schedule, err := cron.ParseStandard(handler.Schedule())
+		if err != nil {
+			panic(fmt.Sprintf("invalid schedule of task kind %q: %v", handler.Kind(), err))
+		}
+		j.schedules = append(j.schedules, &taskSchedule{
+			kind:     handler.Kind(),
+			schedule: schedule,
+			next:     schedule.Next(now),
+		})
+	}
+	return j
+}
+
+func (j *TaskScheduleJob) Name() string {
+	return "task_schedule"
+}
+
+func (j *TaskScheduleJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *TaskScheduleJob) Run(ctx context.Context) error {
+	now := time.Now()
+	var params []EnqueueTaskParam
+	nexts := make([]time.Time, len(j.schedules))
+	for i, s := range j.schedules {
+		nexts[i] = s.next
+		if s.next.After(now) {
+			continue
+		}
+		var fireAt time.Time
+		for !nexts[i].After(now) {
+			fireAt = nexts[i]
+			nexts[i] = s.schedule.Next(fireAt)
+		}
+		params = append(params, EnqueueTaskParam{
+			Kind:  s.kind,
+			Key:   fmt.Sprintf("%s@%s", s.kind, fireAt.UTC().Format(time.RFC3339)),
+			RunAt: fireAt,
+		})
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	if err := j.taskApp.EnqueueTasks(ctx, params); err != nil {
+		return err
+	}
+	for i, s := range j.schedules {
+		s.next = nexts[i]
+	}
+	return nil
+}
+
+type TaskPurgeJob struct {
+	taskApp  TaskApplication
+	interval time.Duration
+}
+
+var _ Job = (*TaskPurgeJob)(nil)
+
+func ProvideTaskPurgeJob(taskApp TaskApplication, srvCfg *config.Server) *TaskPurgeJob {
+	return &TaskPurgeJob{
+		taskApp:  taskApp,
+		interval: srvCfg.TaskPurgeInterval,
+	}
+}
+
+func (j *TaskPurgeJob) Name() string {
+	return "task_purge"
+}
+
+func (j *TaskPurgeJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *TaskPurgeJob) Run(ctx context.Context) error {
+	return j.taskApp.PurgeTasks(ctx)
+}
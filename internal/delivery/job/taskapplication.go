@@ -0,0 +1,30 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+)
+
+type TaskApplication interface {
+	EnqueueTasks(ctx context.Context, params []EnqueueTaskParam) error
+	RunTasks(ctx context.Context, param RunTasksParam) error
+	PurgeTasks(ctx context.Context) error
+}
+
+type EnqueueTaskParam struct {
+	Kind    domain.TaskKind
+	Key     string
+	Payload any
+	RunAt   time.Time
+}
+
+// TaskHandleFunc runs a task, a task is retried until it returns nil or runs
+// out of attempts
+type TaskHandleFunc func(ctx context.Context, task domain.Task) error
+
+type RunTasksParam struct {
+	Kinds  []domain.TaskKind
+	Handle TaskHandleFunc
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"backend/config"
+)
+
+type WebhookEnqueueJob struct {
+	webhookApp WebhookApplication
+	interval   time.Duration
+}
+
+var _ Job = (*WebhookEnqueueJob)(nil)
+
+func ProvideWebhookEnqueueJob(webhookApp WebhookApplication, srvCfg *config.Server) *WebhookEnqueueJob {
+	return &WebhookEnqueueJob{
+		webhookApp: webhookApp,
+		interval:   srvCfg.WebhookEnqueueInterval,
+	}
+}
+
+func (j *WebhookEnqueueJob) Name() string {
+	return "webhook_enqueue"
+}
+
+func (j *WebhookEnqueueJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *WebhookEnqueueJob) Run(ctx context.Context) error {
+	return j.webhookApp.EnqueueDeliveries(ctx)
+}
+
+type WebhookDeliveryJob struct {
+	webhookApp WebhookApplication
+	interval   time.Duration
+}
+
+var _ Job = (*WebhookDeliveryJob)(nil)
+
+func ProvideWebhookDeliveryJob(webhookApp WebhookApplication, srvCfg *config.Server) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{
+		webhookApp: webhookApp,
+		interval:   srvCfg.WebhookDeliveryInterval,
+	}
+}
+
+func (j *WebhookDeliveryJob) Name() string {
+	return "webhook_delivery"
+}
+
+func (j *WebhookDeliveryJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *WebhookDeliveryJob) Run(ctx context.Context) error {
+	return j.webhookApp.SendDeliveries(ctx)
+}
@@ -0,0 +1,8 @@
+package job
+
+import "context"
+
+type WebhookApplication interface {
+	EnqueueDeliveries(ctx context.Context) error
+	SendDeliveries(ctx context.Context) error
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/config"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// workerShutdownTimeout bounds how long the metrics server waits for the
+// scrapes in flight once the worker is stopped
+const workerShutdownTimeout = 5 * time.Second
+
+// Worker is the process running the jobs, apart from the HTTP server so both
+// are scaled and deployed on their own. It serves its metrics on
+// WorkerMetricsPort since it has no router
+type Worker struct {
+	scheduler *Scheduler
+	logger    *zap.Logger
+	srvCfg    *config.Server
+}
+
+func ProvideWorker(scheduler *Scheduler, logger *zap.Logger, srvCfg *config.Server) *Worker {
+	return &Worker{
+		scheduler: scheduler,
+		logger:    logger,
+		srvCfg:    srvCfg,
+	}
+}
+
+// Run runs the jobs until the context is done, then waits for the runs in
+// flight to return
+func (w *Worker) Run(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	metricsServer := &http.Server{
+		Addr:              ":" + strconv.Itoa(w.srvCfg.WorkerMetricsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	// A metrics port already in use only loses the metrics, the jobs keep
+	// running
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			w.logger.Error("worker metrics server failed", zap.Error(err))
+		}
+	}()
+
+	w.logger.Info("worker started", zap.Int("metrics_port", w.srvCfg.WorkerMetricsPort))
+	w.scheduler.Run(ctx)
+	w.logger.Info("worker stopped")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), workerShutdownTimeout)
+	defer cancel()
+	return metricsServer.Shutdown(shutdownCtx)
+}
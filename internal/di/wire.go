@@ -10,11 +10,17 @@ import (
 	"backend/internal/application"
 	"backend/internal/client"
 	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
 	"backend/internal/domain"
 	"backend/internal/infrastructure/cacheredis"
+	"backend/internal/infrastructure/emailsmtp"
+	"backend/internal/infrastructure/emailtemplate"
+	"backend/internal/infrastructure/eventredis"
 	"backend/internal/infrastructure/objectstorages3"
 	"backend/internal/infrastructure/paymentservice"
 	"backend/internal/infrastructure/repositorypostgres"
+	"backend/internal/infrastructure/userkeycloak"
+	"backend/internal/infrastructure/webhookhttp"
 	"backend/internal/service"
 	"backend/pkg/logger"
 
@@ -67,6 +73,31 @@ var ServiceSet = wire.NewSet(
 		new(domain.ProductService),
 		new(*service.Product),
 	),
+	service.ProvideWarehouse,
+	wire.Bind(
+		new(domain.WarehouseService),
+		new(*service.Warehouse),
+	),
+	service.ProvideSpecGroup,
+	wire.Bind(
+		new(domain.SpecGroupService),
+		new(*service.SpecGroup),
+	),
+	service.ProvideWebhook,
+	wire.Bind(
+		new(domain.WebhookService),
+		new(*service.Webhook),
+	),
+	service.ProvideEmail,
+	wire.Bind(
+		new(domain.EmailService),
+		new(*service.Email),
+	),
+	service.ProvideTask,
+	wire.Bind(
+		new(domain.TaskService),
+		new(*service.Task),
+	),
 	// service.ProvideReview,
 	// wire.Bind(
 	// 	new(domain.ReviewService),
@@ -103,11 +134,6 @@ var HandlerSet = wire.NewSet(
 		new(http.AuthHandler),
 		new(*http.AuthHandlerImpl),
 	),
-	http.ProvideFlushCacheRedisHandler,
-	wire.Bind(
-		new(http.FlushCacheHandler),
-		new(*http.FlushCacheRedisHandler),
-	),
 	http.ProvideHealthHandler,
 	wire.Bind(
 		new(http.HealthHandler),
@@ -138,6 +164,41 @@ var HandlerSet = wire.NewSet(
 		new(http.OrderHandler),
 		new(*http.OrderHandlerImpl),
 	),
+	http.ProvideNotificationHandler,
+	wire.Bind(
+		new(http.NotificationHandler),
+		new(*http.NotificationHandlerImpl),
+	),
+	http.ProvideWarehouseHandler,
+	wire.Bind(
+		new(http.WarehouseHandler),
+		new(*http.WarehouseHandlerImpl),
+	),
+	http.ProvideSpecGroupHandler,
+	wire.Bind(
+		new(http.SpecGroupHandler),
+		new(*http.SpecGroupHandlerImpl),
+	),
+	http.ProvideCacheHandler,
+	wire.Bind(
+		new(http.CacheHandler),
+		new(*http.CacheHandlerImpl),
+	),
+	http.ProvideWebhookHandler,
+	wire.Bind(
+		new(http.WebhookHandler),
+		new(*http.WebhookHandlerImpl),
+	),
+	http.ProvideEmailHandler,
+	wire.Bind(
+		new(http.EmailHandler),
+		new(*http.EmailHandlerImpl),
+	),
+	http.ProvideTaskHandler,
+	wire.Bind(
+		new(http.TaskHandler),
+		new(*http.TaskHandlerImpl),
+	),
 	// http.ProvideReviewHandler,
 	// wire.Bind(
 	// 	new(http.ReviewHandler),
@@ -171,6 +232,41 @@ var ApplicationSet = wire.NewSet(
 		new(http.ProductApplication),
 		new(*application.Product),
 	),
+	application.ProvideNotification,
+	wire.Bind(
+		new(http.NotificationApplication),
+		new(*application.Notification),
+	),
+	application.ProvideWarehouse,
+	wire.Bind(
+		new(http.WarehouseApplication),
+		new(*application.Warehouse),
+	),
+	application.ProvideSpecGroup,
+	wire.Bind(
+		new(http.SpecGroupApplication),
+		new(*application.SpecGroup),
+	),
+	application.ProvideCache,
+	wire.Bind(
+		new(http.CacheApplication),
+		new(*application.Cache),
+	),
+	application.ProvideWebhook,
+	wire.Bind(
+		new(http.WebhookApplication),
+		new(*application.Webhook),
+	),
+	application.ProvideEmail,
+	wire.Bind(
+		new(http.EmailApplication),
+		new(*application.Email),
+	),
+	application.ProvideTask,
+	wire.Bind(
+		new(http.TaskApplication),
+		new(*application.Task),
+	),
 	// application.ProvideReview,
 	// wire.Bind(
 	// 	new(http.ReviewApplication),
@@ -204,6 +300,46 @@ var RepositorySet = wire.NewSet(
 		new(domain.ProductRepository),
 		new(*repositorypostgres.Product),
 	),
+	repositorypostgres.ProvideNotification,
+	wire.Bind(
+		new(domain.NotificationRepository),
+		new(*repositorypostgres.Notification),
+	),
+	repositorypostgres.ProvideWarehouse,
+	wire.Bind(
+		new(domain.WarehouseRepository),
+		new(*repositorypostgres.Warehouse),
+	),
+	repositorypostgres.ProvideSpecGroup,
+	wire.Bind(
+		new(domain.SpecGroupRepository),
+		new(*repositorypostgres.SpecGroup),
+	),
+	repositorypostgres.ProvideOutbox,
+	wire.Bind(
+		new(domain.OutboxRepository),
+		new(*repositorypostgres.Outbox),
+	),
+	repositorypostgres.ProvideWebhook,
+	wire.Bind(
+		new(domain.WebhookRepository),
+		new(*repositorypostgres.Webhook),
+	),
+	repositorypostgres.ProvideWebhookDelivery,
+	wire.Bind(
+		new(domain.WebhookDeliveryRepository),
+		new(*repositorypostgres.WebhookDelivery),
+	),
+	repositorypostgres.ProvideEmail,
+	wire.Bind(
+		new(domain.EmailRepository),
+		new(*repositorypostgres.Email),
+	),
+	repositorypostgres.ProvideTask,
+	wire.Bind(
+		new(domain.TaskRepository),
+		new(*repositorypostgres.Task),
+	),
 	// repositorypostgres.ProvideReview,
 	// wire.Bind(
 	// 	new(domain.ReviewRepository),
@@ -211,6 +347,59 @@ var RepositorySet = wire.NewSet(
 	// ),
 )
 
+var JobSet = wire.NewSet(
+	application.ProvideProduct,
+	wire.Bind(
+		new(job.ProductApplication),
+		new(*application.Product),
+	),
+	application.ProvideOrder,
+	wire.Bind(
+		new(job.OrderApplication),
+		new(*application.Order),
+	),
+	application.ProvideOutbox,
+	wire.Bind(
+		new(job.OutboxApplication),
+		new(*application.Outbox),
+	),
+	application.ProvideWebhook,
+	wire.Bind(
+		new(job.WebhookApplication),
+		new(*application.Webhook),
+	),
+	application.ProvideEmail,
+	wire.Bind(
+		new(job.EmailApplication),
+		new(*application.Email),
+	),
+	application.ProvideTask,
+	wire.Bind(
+		new(job.TaskApplication),
+		new(*application.Task),
+	),
+	job.ProvideProductViewFlushJob,
+	job.ProvideOutboxRelayJob,
+	job.ProvideOutboxPurgeJob,
+	job.ProvideWebhookEnqueueJob,
+	job.ProvideWebhookDeliveryJob,
+	job.ProvideEmailEnqueueJob,
+	job.ProvideEmailSendJob,
+	job.ProvideOrderExpireTaskHandler,
+	job.ProvideProductImageCleanupTaskHandler,
+	job.ProvideProductTrendingTaskHandler,
+	job.ProvideProductRecommendationTaskHandler,
+	job.ProvideProductStockReconciliationTaskHandler,
+	job.ProvideProductPublishScheduleTaskHandler,
+	job.ProvideProductPriceSyncTaskHandler,
+	job.ProvideTaskHandlers,
+	job.ProvideTaskRunJob,
+	job.ProvideTaskScheduleJob,
+	job.ProvideTaskPurgeJob,
+	job.ProvideScheduler,
+	job.ProvideWorker,
+)
+
 var RouterSet = wire.NewSet(
 	http.ProvideRouter,
 	wire.Bind(
@@ -254,6 +443,22 @@ var CacheSet = wire.NewSet(
 		new(application.CartCache),
 		new(*cacheredis.Cart),
 	),
+	cacheredis.ProvideProductView,
+	wire.Bind(
+		new(application.ProductViewBuffer),
+		new(*cacheredis.ProductView),
+	),
+	cacheredis.ProvideLocal,
+	cacheredis.ProvideTag,
+	wire.Bind(
+		new(application.ChangeEventHandler),
+		new(*cacheredis.Tag),
+	),
+	cacheredis.ProvideInspector,
+	wire.Bind(
+		new(application.CacheInspector),
+		new(*cacheredis.Inspector),
+	),
 )
 
 var ObjectStorageSet = wire.NewSet(
@@ -264,6 +469,19 @@ var ObjectStorageSet = wire.NewSet(
 	),
 )
 
+var EventSet = wire.NewSet(
+	eventredis.ProvidePublisher,
+	wire.Bind(
+		new(application.EventPublisher),
+		new(*eventredis.Publisher),
+	),
+	eventredis.ProvideConsumer,
+	wire.Bind(
+		new(application.EventConsumer),
+		new(*eventredis.Consumer),
+	),
+)
+
 var PaymentServiceSet = wire.NewSet(
 	paymentservice.ProvideVNPay,
 	wire.Bind(
@@ -272,6 +490,35 @@ var PaymentServiceSet = wire.NewSet(
 	),
 )
 
+var WebhookSenderSet = wire.NewSet(
+	webhookhttp.ProvideSender,
+	wire.Bind(
+		new(application.WebhookSender),
+		new(*webhookhttp.Sender),
+	),
+)
+
+var EmailSet = wire.NewSet(
+	emailsmtp.ProvideSender,
+	wire.Bind(
+		new(application.EmailSender),
+		new(*emailsmtp.Sender),
+	),
+	emailtemplate.ProvideRenderer,
+	wire.Bind(
+		new(application.EmailRenderer),
+		new(*emailtemplate.Renderer),
+	),
+)
+
+var UserProfileServiceSet = wire.NewSet(
+	userkeycloak.ProvideProfileService,
+	wire.Bind(
+		new(application.UserProfileService),
+		new(*userkeycloak.ProfileService),
+	),
+)
+
 func InitializeServer(ctx context.Context) *http.Server {
 	wire.Build(
 		ApplicationSet,
@@ -279,7 +526,9 @@ func InitializeServer(ctx context.Context) *http.Server {
 		ClientSet,
 		ConfigSet,
 		DbSet,
+		EmailSet,
 		EngineSet,
+		EventSet,
 		HandlerSet,
 		LoggerSet,
 		MiddlewareSet,
@@ -288,7 +537,29 @@ func InitializeServer(ctx context.Context) *http.Server {
 		ServiceSet,
 		ObjectStorageSet,
 		PaymentServiceSet,
+		UserProfileServiceSet,
+		WebhookSenderSet,
 		http.NewServer,
 	)
 	return nil
 }
+
+func InitializeWorker(ctx context.Context) *job.Worker {
+	wire.Build(
+		CacheSet,
+		ClientSet,
+		ConfigSet,
+		DbSet,
+		EmailSet,
+		EventSet,
+		JobSet,
+		LoggerSet,
+		RepositorySet,
+		ServiceSet,
+		ObjectStorageSet,
+		PaymentServiceSet,
+		UserProfileServiceSet,
+		WebhookSenderSet,
+	)
+	return nil
+}
@@ -11,11 +11,17 @@ import (
 	"backend/internal/application"
 	"backend/internal/client"
 	"backend/internal/delivery/http"
+	"backend/internal/delivery/job"
 	"backend/internal/domain"
 	"backend/internal/infrastructure/cacheredis"
+	"backend/internal/infrastructure/emailsmtp"
+	"backend/internal/infrastructure/emailtemplate"
+	"backend/internal/infrastructure/eventredis"
 	"backend/internal/infrastructure/objectstorages3"
 	"backend/internal/infrastructure/paymentservice"
 	"backend/internal/infrastructure/repositorypostgres"
+	"backend/internal/infrastructure/userkeycloak"
+	"backend/internal/infrastructure/webhookhttp"
 	"backend/internal/service"
 	"backend/pkg/logger"
 	"context"
@@ -37,43 +43,149 @@ func InitializeServer(ctx context.Context) *http.Server {
 	zapLogger := logger.New(loggerConfig)
 	loggingMiddlewareImpl := http.ProvideLoggingMiddleware(zapLogger)
 	ginAuthMiddleware := http.ProvideAuthMiddleware(goCloak, server)
+	roleMiddlewareImpl := http.ProvideRoleMiddleware(server)
 	queries := client.NewDBQueries(pool)
 	category := repositorypostgres.ProvideCategory(queries)
 	validate := client.NewValidate()
 	serviceCategory := service.ProvideCategory(validate)
-	cacheredisCategory := cacheredis.ProvideCategory(redisClient)
-	applicationCategory := application.ProvideCategory(category, serviceCategory, cacheredisCategory)
+	local := cacheredis.ProvideLocal(ctx, redisClient, server)
+	cacheredisCategory := cacheredis.ProvideCategory(redisClient, local)
+	tag := cacheredis.ProvideTag(redisClient, local)
+	applicationCategory := application.ProvideCategory(category, serviceCategory, cacheredisCategory, tag)
 	categoryHandlerImpl := http.ProvideCategoryHandler(applicationCategory)
 	attribute := repositorypostgres.ProvideAttribute(queries, pool)
 	serviceAttribute := service.ProvideAttribute(validate)
-	product := cacheredis.ProvideProduct(redisClient)
+	product := cacheredis.ProvideProduct(redisClient, local, server)
 	presignClient := client.NewS3Presign(s3Client)
 	s3 := client.ProvideS3(s3Client, presignClient)
 	objectstorages3Product := objectstorages3.ProvideProduct(s3, server)
 	repositorypostgresProduct := repositorypostgres.ProvideProduct(queries, pool)
 	serviceProduct := service.ProvideProduct(validate)
-	applicationProduct := application.ProvideProduct(attribute, serviceAttribute, category, product, objectstorages3Product, repositorypostgresProduct, serviceProduct, server)
+	productView := cacheredis.ProvideProductView(redisClient)
+	specGroup := repositorypostgres.ProvideSpecGroup(queries, pool)
+	applicationProduct := application.ProvideProduct(attribute, serviceAttribute, category, tag, product, objectstorages3Product, repositorypostgresProduct, serviceProduct, productView, specGroup, server)
 	productHandlerImpl := http.ProvideProductHandler(applicationProduct)
-	cacheredisAttribute := cacheredis.ProvideAttribute(redisClient)
-	applicationAttribute := application.ProvideAttribute(attribute, serviceAttribute, cacheredisAttribute)
+	cacheredisAttribute := cacheredis.ProvideAttribute(redisClient, local)
+	applicationAttribute := application.ProvideAttribute(attribute, serviceAttribute, cacheredisAttribute, tag)
 	attributeHandlerImpl := http.ProvideAttributeHandler(applicationAttribute)
 	vnPay := paymentservice.ProvideVNPay(server)
 	order := repositorypostgres.ProvideOrder(queries, pool)
 	serviceOrder := service.ProvideOrder(validate)
 	cart := repositorypostgres.ProvideCart(queries, pool)
-	applicationOrder := application.ProvideOrder(vnPay, order, serviceOrder, repositorypostgresProduct, serviceProduct, cart)
+	warehouse := repositorypostgres.ProvideWarehouse(queries)
+	applicationOrder := application.ProvideOrder(vnPay, order, serviceOrder, repositorypostgresProduct, serviceProduct, cart, warehouse, server)
 	orderHandlerImpl := http.ProvideOrderHandler(applicationOrder)
 	serviceCart := service.ProvideCart(validate)
 	cacheredisCart := cacheredis.ProvideCart(redisClient)
 	applicationCart := application.ProvideCart(cart, serviceCart, cacheredisCart, repositorypostgresProduct)
 	cartHandlerImpl := http.ProvideCartHandler(applicationCart)
-	flushCacheRedisHandler := http.ProvideFlushCacheRedisHandler(redisClient)
-	ginRouter := http.ProvideRouter(healthHandlerImpl, metricMiddlewareImpl, loggingMiddlewareImpl, ginAuthMiddleware, categoryHandlerImpl, productHandlerImpl, attributeHandlerImpl, orderHandlerImpl, cartHandlerImpl, flushCacheRedisHandler)
+	notification := repositorypostgres.ProvideNotification(queries)
+	applicationNotification := application.ProvideNotification(notification)
+	notificationHandlerImpl := http.ProvideNotificationHandler(applicationNotification)
+	serviceWarehouse := service.ProvideWarehouse(validate)
+	applicationWarehouse := application.ProvideWarehouse(warehouse, serviceWarehouse)
+	warehouseHandlerImpl := http.ProvideWarehouseHandler(applicationWarehouse)
+	serviceSpecGroup := service.ProvideSpecGroup(validate)
+	applicationSpecGroup := application.ProvideSpecGroup(tag, specGroup, serviceSpecGroup)
+	specGroupHandlerImpl := http.ProvideSpecGroupHandler(applicationSpecGroup)
+	inspector := cacheredis.ProvideInspector(redisClient)
+	cache := application.ProvideCache(inspector, tag)
+	cacheHandlerImpl := http.ProvideCacheHandler(cache)
+	consumer := eventredis.ProvideConsumer(redisClient, server)
+	sender := webhookhttp.ProvideSender(server)
+	webhook := repositorypostgres.ProvideWebhook(queries)
+	webhookDelivery := repositorypostgres.ProvideWebhookDelivery(queries)
+	serviceWebhook := service.ProvideWebhook(validate)
+	applicationWebhook := application.ProvideWebhook(consumer, sender, webhook, webhookDelivery, serviceWebhook, server)
+	webhookHandlerImpl := http.ProvideWebhookHandler(applicationWebhook)
+	emailsmtpSender := emailsmtp.ProvideSender(server)
+	renderer := emailtemplate.ProvideRenderer()
+	profileService := userkeycloak.ProvideProfileService(goCloak, server)
+	email := repositorypostgres.ProvideEmail(queries)
+	serviceEmail := service.ProvideEmail(validate)
+	applicationEmail := application.ProvideEmail(consumer, emailsmtpSender, renderer, profileService, email, order, serviceEmail, server)
+	emailHandlerImpl := http.ProvideEmailHandler(applicationEmail)
+	task := repositorypostgres.ProvideTask(queries)
+	serviceTask := service.ProvideTask(validate)
+	applicationTask := application.ProvideTask(task, serviceTask, server)
+	taskHandlerImpl := http.ProvideTaskHandler(applicationTask)
+	ginRouter := http.ProvideRouter(healthHandlerImpl, metricMiddlewareImpl, loggingMiddlewareImpl, ginAuthMiddleware, roleMiddlewareImpl, categoryHandlerImpl, productHandlerImpl, attributeHandlerImpl, orderHandlerImpl, cartHandlerImpl, notificationHandlerImpl, warehouseHandlerImpl, specGroupHandlerImpl, cacheHandlerImpl, webhookHandlerImpl, emailHandlerImpl, taskHandlerImpl)
 	authHandlerImpl := http.ProvideAuthHandler(server)
 	httpServer := http.NewServer(engine, ginRouter, server, redisClient, authHandlerImpl)
 	return httpServer
 }
 
+func InitializeWorker(ctx context.Context) *job.Worker {
+	server := config.NewServer()
+	loggerConfig := logger.NewConfig(server)
+	zapLogger := logger.New(loggerConfig)
+	pool := client.NewDBConnection(ctx, server)
+	queries := client.NewDBQueries(pool)
+	attribute := repositorypostgres.ProvideAttribute(queries, pool)
+	validate := client.NewValidate()
+	serviceAttribute := service.ProvideAttribute(validate)
+	category := repositorypostgres.ProvideCategory(queries)
+	redisClient := client.NewRedis(ctx, server)
+	local := cacheredis.ProvideLocal(ctx, redisClient, server)
+	tag := cacheredis.ProvideTag(redisClient, local)
+	product := cacheredis.ProvideProduct(redisClient, local, server)
+	s3Client := client.NewS3(ctx, server)
+	presignClient := client.NewS3Presign(s3Client)
+	s3 := client.ProvideS3(s3Client, presignClient)
+	objectstorages3Product := objectstorages3.ProvideProduct(s3, server)
+	repositorypostgresProduct := repositorypostgres.ProvideProduct(queries, pool)
+	serviceProduct := service.ProvideProduct(validate)
+	productView := cacheredis.ProvideProductView(redisClient)
+	specGroup := repositorypostgres.ProvideSpecGroup(queries, pool)
+	applicationProduct := application.ProvideProduct(attribute, serviceAttribute, category, tag, product, objectstorages3Product, repositorypostgresProduct, serviceProduct, productView, specGroup, server)
+	productViewFlushJob := job.ProvideProductViewFlushJob(applicationProduct, server)
+	publisher := eventredis.ProvidePublisher(redisClient, server)
+	outbox := repositorypostgres.ProvideOutbox(queries)
+	applicationOutbox := application.ProvideOutbox(publisher, outbox, server)
+	outboxRelayJob := job.ProvideOutboxRelayJob(applicationOutbox, server)
+	outboxPurgeJob := job.ProvideOutboxPurgeJob(applicationOutbox, server)
+	consumer := eventredis.ProvideConsumer(redisClient, server)
+	sender := webhookhttp.ProvideSender(server)
+	webhook := repositorypostgres.ProvideWebhook(queries)
+	webhookDelivery := repositorypostgres.ProvideWebhookDelivery(queries)
+	serviceWebhook := service.ProvideWebhook(validate)
+	applicationWebhook := application.ProvideWebhook(consumer, sender, webhook, webhookDelivery, serviceWebhook, server)
+	webhookEnqueueJob := job.ProvideWebhookEnqueueJob(applicationWebhook, server)
+	webhookDeliveryJob := job.ProvideWebhookDeliveryJob(applicationWebhook, server)
+	emailsmtpSender := emailsmtp.ProvideSender(server)
+	renderer := emailtemplate.ProvideRenderer()
+	goCloak := client.NewKeycloak(ctx, server)
+	profileService := userkeycloak.ProvideProfileService(goCloak, server)
+	email := repositorypostgres.ProvideEmail(queries)
+	order := repositorypostgres.ProvideOrder(queries, pool)
+	serviceEmail := service.ProvideEmail(validate)
+	applicationEmail := application.ProvideEmail(consumer, emailsmtpSender, renderer, profileService, email, order, serviceEmail, server)
+	emailEnqueueJob := job.ProvideEmailEnqueueJob(applicationEmail, server)
+	emailSendJob := job.ProvideEmailSendJob(applicationEmail, server)
+	task := repositorypostgres.ProvideTask(queries)
+	serviceTask := service.ProvideTask(validate)
+	applicationTask := application.ProvideTask(task, serviceTask, server)
+	vnPay := paymentservice.ProvideVNPay(server)
+	serviceOrder := service.ProvideOrder(validate)
+	cart := repositorypostgres.ProvideCart(queries, pool)
+	warehouse := repositorypostgres.ProvideWarehouse(queries)
+	applicationOrder := application.ProvideOrder(vnPay, order, serviceOrder, repositorypostgresProduct, serviceProduct, cart, warehouse, server)
+	orderExpireTaskHandler := job.ProvideOrderExpireTaskHandler(applicationOrder, server)
+	productImageCleanupTaskHandler := job.ProvideProductImageCleanupTaskHandler(applicationProduct, zapLogger, server)
+	productTrendingTaskHandler := job.ProvideProductTrendingTaskHandler(applicationProduct, server)
+	productRecommendationTaskHandler := job.ProvideProductRecommendationTaskHandler(applicationProduct, server)
+	productStockReconciliationTaskHandler := job.ProvideProductStockReconciliationTaskHandler(applicationProduct, server)
+	productPublishScheduleTaskHandler := job.ProvideProductPublishScheduleTaskHandler(applicationProduct, server)
+	productPriceSyncTaskHandler := job.ProvideProductPriceSyncTaskHandler(applicationProduct, server)
+	v := job.ProvideTaskHandlers(orderExpireTaskHandler, productImageCleanupTaskHandler, productTrendingTaskHandler, productRecommendationTaskHandler, productStockReconciliationTaskHandler, productPublishScheduleTaskHandler, productPriceSyncTaskHandler)
+	taskRunJob := job.ProvideTaskRunJob(applicationTask, v, zapLogger, server)
+	taskScheduleJob := job.ProvideTaskScheduleJob(applicationTask, v, server)
+	taskPurgeJob := job.ProvideTaskPurgeJob(applicationTask, server)
+	scheduler := job.ProvideScheduler(zapLogger, productViewFlushJob, outboxRelayJob, outboxPurgeJob, webhookEnqueueJob, webhookDeliveryJob, emailEnqueueJob, emailSendJob, taskRunJob, taskScheduleJob, taskPurgeJob)
+	worker := job.ProvideWorker(scheduler, zapLogger, server)
+	return worker
+}
+
 // wire.go:
 
 var ConfigSet = wire.NewSet(config.NewServer, logger.NewConfig)
@@ -99,6 +211,21 @@ var ServiceSet = wire.NewSet(service.ProvideAttribute, wire.Bind(
 ), service.ProvideProduct, wire.Bind(
 	new(domain.ProductService),
 	new(*service.Product),
+), service.ProvideWarehouse, wire.Bind(
+	new(domain.WarehouseService),
+	new(*service.Warehouse),
+), service.ProvideSpecGroup, wire.Bind(
+	new(domain.SpecGroupService),
+	new(*service.SpecGroup),
+), service.ProvideWebhook, wire.Bind(
+	new(domain.WebhookService),
+	new(*service.Webhook),
+), service.ProvideEmail, wire.Bind(
+	new(domain.EmailService),
+	new(*service.Email),
+), service.ProvideTask, wire.Bind(
+	new(domain.TaskService),
+	new(*service.Task),
 ),
 )
 
@@ -120,9 +247,6 @@ var MiddlewareSet = wire.NewSet(http.ProvideAuthMiddleware, wire.Bind(
 var HandlerSet = wire.NewSet(http.ProvideAuthHandler, wire.Bind(
 	new(http.AuthHandler),
 	new(*http.AuthHandlerImpl),
-), http.ProvideFlushCacheRedisHandler, wire.Bind(
-	new(http.FlushCacheHandler),
-	new(*http.FlushCacheRedisHandler),
 ), http.ProvideHealthHandler, wire.Bind(
 	new(http.HealthHandler),
 	new(*http.HealthHandlerImpl),
@@ -141,6 +265,27 @@ var HandlerSet = wire.NewSet(http.ProvideAuthHandler, wire.Bind(
 ), http.ProvideOrderHandler, wire.Bind(
 	new(http.OrderHandler),
 	new(*http.OrderHandlerImpl),
+), http.ProvideNotificationHandler, wire.Bind(
+	new(http.NotificationHandler),
+	new(*http.NotificationHandlerImpl),
+), http.ProvideWarehouseHandler, wire.Bind(
+	new(http.WarehouseHandler),
+	new(*http.WarehouseHandlerImpl),
+), http.ProvideSpecGroupHandler, wire.Bind(
+	new(http.SpecGroupHandler),
+	new(*http.SpecGroupHandlerImpl),
+), http.ProvideCacheHandler, wire.Bind(
+	new(http.CacheHandler),
+	new(*http.CacheHandlerImpl),
+), http.ProvideWebhookHandler, wire.Bind(
+	new(http.WebhookHandler),
+	new(*http.WebhookHandlerImpl),
+), http.ProvideEmailHandler, wire.Bind(
+	new(http.EmailHandler),
+	new(*http.EmailHandlerImpl),
+), http.ProvideTaskHandler, wire.Bind(
+	new(http.TaskHandler),
+	new(*http.TaskHandlerImpl),
 ),
 )
 
@@ -159,6 +304,27 @@ var ApplicationSet = wire.NewSet(application.ProvideAttribute, wire.Bind(
 ), application.ProvideProduct, wire.Bind(
 	new(http.ProductApplication),
 	new(*application.Product),
+), application.ProvideNotification, wire.Bind(
+	new(http.NotificationApplication),
+	new(*application.Notification),
+), application.ProvideWarehouse, wire.Bind(
+	new(http.WarehouseApplication),
+	new(*application.Warehouse),
+), application.ProvideSpecGroup, wire.Bind(
+	new(http.SpecGroupApplication),
+	new(*application.SpecGroup),
+), application.ProvideCache, wire.Bind(
+	new(http.CacheApplication),
+	new(*application.Cache),
+), application.ProvideWebhook, wire.Bind(
+	new(http.WebhookApplication),
+	new(*application.Webhook),
+), application.ProvideEmail, wire.Bind(
+	new(http.EmailApplication),
+	new(*application.Email),
+), application.ProvideTask, wire.Bind(
+	new(http.TaskApplication),
+	new(*application.Task),
 ),
 )
 
@@ -177,9 +343,54 @@ var RepositorySet = wire.NewSet(repositorypostgres.ProvideAttribute, wire.Bind(
 ), repositorypostgres.ProvideProduct, wire.Bind(
 	new(domain.ProductRepository),
 	new(*repositorypostgres.Product),
+), repositorypostgres.ProvideNotification, wire.Bind(
+	new(domain.NotificationRepository),
+	new(*repositorypostgres.Notification),
+), repositorypostgres.ProvideWarehouse, wire.Bind(
+	new(domain.WarehouseRepository),
+	new(*repositorypostgres.Warehouse),
+), repositorypostgres.ProvideSpecGroup, wire.Bind(
+	new(domain.SpecGroupRepository),
+	new(*repositorypostgres.SpecGroup),
+), repositorypostgres.ProvideOutbox, wire.Bind(
+	new(domain.OutboxRepository),
+	new(*repositorypostgres.Outbox),
+), repositorypostgres.ProvideWebhook, wire.Bind(
+	new(domain.WebhookRepository),
+	new(*repositorypostgres.Webhook),
+), repositorypostgres.ProvideWebhookDelivery, wire.Bind(
+	new(domain.WebhookDeliveryRepository),
+	new(*repositorypostgres.WebhookDelivery),
+), repositorypostgres.ProvideEmail, wire.Bind(
+	new(domain.EmailRepository),
+	new(*repositorypostgres.Email),
+), repositorypostgres.ProvideTask, wire.Bind(
+	new(domain.TaskRepository),
+	new(*repositorypostgres.Task),
 ),
 )
 
+var JobSet = wire.NewSet(application.ProvideProduct, wire.Bind(
+	new(job.ProductApplication),
+	new(*application.Product),
+), application.ProvideOrder, wire.Bind(
+	new(job.OrderApplication),
+	new(*application.Order),
+), application.ProvideOutbox, wire.Bind(
+	new(job.OutboxApplication),
+	new(*application.Outbox),
+), application.ProvideWebhook, wire.Bind(
+	new(job.WebhookApplication),
+	new(*application.Webhook),
+), application.ProvideEmail, wire.Bind(
+	new(job.EmailApplication),
+	new(*application.Email),
+), application.ProvideTask, wire.Bind(
+	new(job.TaskApplication),
+	new(*application.Task),
+), job.ProvideProductViewFlushJob, job.ProvideOutboxRelayJob, job.ProvideOutboxPurgeJob, job.ProvideWebhookEnqueueJob, job.ProvideWebhookDeliveryJob, job.ProvideEmailEnqueueJob, job.ProvideEmailSendJob, job.ProvideOrderExpireTaskHandler, job.ProvideProductImageCleanupTaskHandler, job.ProvideProductTrendingTaskHandler, job.ProvideProductRecommendationTaskHandler, job.ProvideProductStockReconciliationTaskHandler, job.ProvideProductPublishScheduleTaskHandler, job.ProvideProductPriceSyncTaskHandler, job.ProvideTaskHandlers, job.ProvideTaskRunJob, job.ProvideTaskScheduleJob, job.ProvideTaskPurgeJob, job.ProvideScheduler, job.ProvideWorker,
+)
+
 var RouterSet = wire.NewSet(http.ProvideRouter, wire.Bind(
 	new(http.Router),
 	new(*http.GinRouter),
@@ -200,6 +411,15 @@ var CacheSet = wire.NewSet(cacheredis.ProvideProduct, wire.Bind(
 ), cacheredis.ProvideCart, wire.Bind(
 	new(application.CartCache),
 	new(*cacheredis.Cart),
+), cacheredis.ProvideProductView, wire.Bind(
+	new(application.ProductViewBuffer),
+	new(*cacheredis.ProductView),
+), cacheredis.ProvideLocal, cacheredis.ProvideTag, wire.Bind(
+	new(application.ChangeEventHandler),
+	new(*cacheredis.Tag),
+), cacheredis.ProvideInspector, wire.Bind(
+	new(application.CacheInspector),
+	new(*cacheredis.Inspector),
 ),
 )
 
@@ -209,8 +429,38 @@ var ObjectStorageSet = wire.NewSet(objectstorages3.ProvideProduct, wire.Bind(
 ),
 )
 
+var EventSet = wire.NewSet(eventredis.ProvidePublisher, wire.Bind(
+	new(application.EventPublisher),
+	new(*eventredis.Publisher),
+), eventredis.ProvideConsumer, wire.Bind(
+	new(application.EventConsumer),
+	new(*eventredis.Consumer),
+),
+)
+
 var PaymentServiceSet = wire.NewSet(paymentservice.ProvideVNPay, wire.Bind(
 	new(application.VNPayPaymentService),
 	new(*paymentservice.VNPay),
 ),
 )
+
+var WebhookSenderSet = wire.NewSet(webhookhttp.ProvideSender, wire.Bind(
+	new(application.WebhookSender),
+	new(*webhookhttp.Sender),
+),
+)
+
+var EmailSet = wire.NewSet(emailsmtp.ProvideSender, wire.Bind(
+	new(application.EmailSender),
+	new(*emailsmtp.Sender),
+), emailtemplate.ProvideRenderer, wire.Bind(
+	new(application.EmailRenderer),
+	new(*emailtemplate.Renderer),
+),
+)
+
+var UserProfileServiceSet = wire.NewSet(userkeycloak.ProvideProfileService, wire.Bind(
+	new(application.UserProfileService),
+	new(*userkeycloak.ProfileService),
+),
+)
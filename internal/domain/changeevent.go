@@ -0,0 +1,40 @@
+package domain
+
+import "github.com/google/uuid"
+
+type ChangeEntity string
+
+const (
+	ChangeEntityProduct        ChangeEntity = "product"
+	ChangeEntityProductVariant ChangeEntity = "product_variant"
+	ChangeEntityCategory       ChangeEntity = "category"
+	ChangeEntityAttribute      ChangeEntity = "attribute"
+	ChangeEntitySpecGroup      ChangeEntity = "spec_group"
+)
+
+type ChangeKind string
+
+const (
+	// ChangeKindContent means only what the entities show has changed
+	ChangeKindContent ChangeKind = "content"
+	// ChangeKindListing means the entities may also have moved in or out of
+	// listings or changed their place in them, e.g. once created, deleted,
+	// renamed or repriced
+	ChangeKindListing ChangeKind = "listing"
+)
+
+// ChangeEvent tells which entities a write has changed, an event without IDs
+// concerns every entity of its kind, e.g. after a batch job
+type ChangeEvent struct {
+	Entity ChangeEntity
+	Kind   ChangeKind
+	IDs    []uuid.UUID
+}
+
+func NewChangeEvent(entity ChangeEntity, kind ChangeKind, ids ...uuid.UUID) ChangeEvent {
+	return ChangeEvent{
+		Entity: entity,
+		Kind:   kind,
+		IDs:    ids,
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+type EmailKind string
+
+const (
+	EmailKindOrderPlaced         EmailKind = "order_placed"
+	EmailKindOrderPaid           EmailKind = "order_paid"
+	EmailKindOrderShipped        EmailKind = "order_shipped"
+	EmailKindOrderDelivered      EmailKind = "order_delivered"
+	EmailKindOrderCancelled      EmailKind = "order_cancelled"
+	EmailKindOrderPaymentExpired EmailKind = "order_payment_expired"
+	EmailKindRefundProcessed     EmailKind = "refund_processed"
+)
+
+// EmailLocales are the languages emails are written in, the first one is
+// used for users without a supported locale
+var EmailLocales = []string{"vi", "en"}
+
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "pending"
+	EmailStatusSent    EmailStatus = "sent"
+	EmailStatusFailed  EmailStatus = "failed"
+)
+
+// Email is a message rendered for a user about an event and the outcome of
+// sending it, the emails make up the send log. A user gets at most one email
+// of a kind per event
+type Email struct {
+	ID            uuid.UUID   `validate:"required"`
+	Kind          EmailKind   `validate:"required,oneof=order_placed order_paid order_shipped order_delivered order_cancelled order_payment_expired refund_processed"`
+	EventID       uuid.UUID   `validate:"required"`
+	UserID        uuid.UUID   `validate:"required"`
+	Recipient     string      `validate:"required,email"`
+	Locale        string      `validate:"required,oneof=vi en"`
+	Subject       string      `validate:"required"`
+	TextBody      string      `validate:"required"`
+	HTMLBody      string      `validate:"required"`
+	Status        EmailStatus `validate:"required,oneof=pending sent failed"`
+	Attempts      int         `validate:"gte=0"`
+	Error         string
+	NextAttemptAt time.Time `validate:"required"`
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required,gtefield=CreatedAt"`
+	SentAt        time.Time `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+func NewEmail(
+	kind EmailKind,
+	eventID uuid.UUID,
+	userID uuid.UUID,
+	recipient string,
+	locale string,
+	subject string,
+	textBody string,
+	htmlBody string,
+) (*Email, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	now := time.Now()
+	email := &Email{
+		ID:            id,
+		Kind:          kind,
+		EventID:       eventID,
+		UserID:        userID,
+		Recipient:     recipient,
+		Locale:        locale,
+		Subject:       subject,
+		TextBody:      textBody,
+		HTMLBody:      htmlBody,
+		Status:        EmailStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	return email, nil
+}
+
+func (e *Email) RecordSent() {
+	now := time.Now()
+	e.Attempts++
+	e.Status = EmailStatusSent
+	e.Error = ""
+	e.SentAt = now
+	e.UpdatedAt = now
+}
+
+// RecordFailed records an attempt the SMTP server did not accept. The email
+// is retried with an exponential backoff until maxAttempts, then it fails
+func (e *Email) RecordFailed(
+	reason string,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+	maxAttempts int,
+) {
+	now := time.Now()
+	e.NextAttemptAt = now.Add(retryDelay(e.Attempts, baseDelay, maxDelay))
+	e.Attempts++
+	e.Error = reason
+	e.UpdatedAt = now
+	if e.Attempts >= maxAttempts {
+		e.Status = EmailStatusFailed
+	}
+}
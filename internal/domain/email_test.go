@@ -0,0 +1,70 @@
+// vim: tabstop=4 shiftwidth=4:
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailTestSuite struct {
+	suite.Suite
+}
+
+func (s *EmailTestSuite) newEmail() *domain.Email {
+	email, err := domain.NewEmail(
+		domain.EmailKindOrderPaid,
+		uuid.New(),
+		uuid.New(),
+		"customer@example.com",
+		"vi",
+		"Subject",
+		"Text",
+		"<p>HTML</p>",
+	)
+	s.Require().NoError(err)
+	return email
+}
+
+func (s *EmailTestSuite) TestNewEmail() {
+	email := s.newEmail()
+	s.Equal(domain.EmailStatusPending, email.Status)
+	s.Equal(0, email.Attempts)
+	s.True(email.SentAt.IsZero())
+	s.False(email.NextAttemptAt.After(time.Now()), "due immediately")
+}
+
+func (s *EmailTestSuite) TestEmailRecordFailed() {
+	email := s.newEmail()
+
+	before := time.Now()
+	email.RecordFailed("451 try again later", time.Second, time.Minute, 2)
+	s.Equal(domain.EmailStatusPending, email.Status)
+	s.Equal(1, email.Attempts)
+	s.Equal("451 try again later", email.Error)
+	s.WithinRange(email.NextAttemptAt, before.Add(time.Second), time.Now().Add(time.Second))
+
+	email.RecordFailed("connection refused", time.Second, time.Minute, 2)
+	s.Equal(domain.EmailStatusFailed, email.Status)
+	s.Equal(2, email.Attempts)
+}
+
+func (s *EmailTestSuite) TestEmailRecordSent() {
+	email := s.newEmail()
+	email.RecordFailed("connection refused", time.Second, time.Minute, 3)
+
+	email.RecordSent()
+	s.Equal(domain.EmailStatusSent, email.Status)
+	s.Equal(2, email.Attempts)
+	s.Empty(email.Error)
+	s.False(email.SentAt.IsZero())
+}
+
+func TestEmail(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(EmailTestSuite))
+}
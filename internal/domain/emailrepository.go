@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailRepository interface {
+	List(
+		ctx context.Context,
+		params EmailRepositoryListParam,
+	) (*[]Email, error)
+
+	Count(
+		ctx context.Context,
+		params EmailRepositoryCountParam,
+	) (*int, error)
+
+	// Create inserts new emails, skipping those of a kind the event already
+	// has an email for
+	Create(
+		ctx context.Context,
+		params EmailRepositoryCreateParam,
+	) error
+
+	Save(
+		ctx context.Context,
+		params EmailRepositorySaveParam,
+	) error
+
+	// Claim leases the oldest due pending emails until LeaseUntil
+	Claim(
+		ctx context.Context,
+		params EmailRepositoryClaimParam,
+	) (*[]Email, error)
+}
+
+type EmailRepositoryListParam struct {
+	UserIDs []uuid.UUID
+	Status  EmailStatus
+	Limit   int
+	Offset  int
+}
+
+type EmailRepositoryCountParam struct {
+	UserIDs []uuid.UUID
+	Status  EmailStatus
+}
+
+type EmailRepositoryCreateParam struct {
+	Emails []Email
+}
+
+type EmailRepositorySaveParam struct {
+	Email Email
+}
+
+type EmailRepositoryClaimParam struct {
+	Now        time.Time
+	LeaseUntil time.Time
+	Limit      int
+}
@@ -0,0 +1,7 @@
+package domain
+
+type EmailService interface {
+	Validate(
+		email Email,
+	) error
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeOrderCreated       EventType = "order.created"
+	EventTypeOrderPaid          EventType = "order.paid"
+	EventTypeOrderStatusChanged EventType = "order.status_changed"
+	EventTypeOrderRefundDue     EventType = "order.refund_due"
+	EventTypeProductCreated     EventType = "product.created"
+	EventTypeProductUpdated     EventType = "product.updated"
+	EventTypeProductDeleted     EventType = "product.deleted"
+	EventTypeStockChanged       EventType = "stock.changed"
+	EventTypeRefundProcessed    EventType = "refund.processed"
+)
+
+type AggregateType string
+
+const (
+	AggregateTypeOrder   AggregateType = "order"
+	AggregateTypeProduct AggregateType = "product"
+	AggregateTypeRefund  AggregateType = "refund"
+)
+
+// Event is something which happened to an aggregate. Aggregates record their
+// events until they are saved, the repository then writes them to the outbox
+// in the same transaction so they are published if and only if the save
+// commits. Payload is serialized as JSON for the consumers
+type Event struct {
+	ID         uuid.UUID
+	Type       EventType
+	Payload    any
+	OccurredAt time.Time
+}
+
+func newEvent(eventType EventType, payload any) Event {
+	// NewV7 only fails when the random source does, the ID then stays unique
+	// but loses its ordering
+	id, err := uuid.NewV7()
+	if err != nil {
+		id = uuid.New()
+	}
+	return Event{
+		ID:         id,
+		Type:       eventType,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}
+}
+
+type OrderCreatedEventPayload struct {
+	OrderID     uuid.UUID                      `json:"orderId"`
+	UserID      uuid.UUID                      `json:"userId"`
+	Provider    OrderProvider                  `json:"provider"`
+	TotalAmount int64                          `json:"totalAmount"`
+	Items       []OrderCreatedEventPayloadItem `json:"items"`
+}
+
+type OrderCreatedEventPayloadItem struct {
+	ProductID        uuid.UUID `json:"productId"`
+	ProductVariantID uuid.UUID `json:"productVariantId"`
+	Quantity         int       `json:"quantity"`
+	Price            int64     `json:"price"`
+}
+
+type OrderPaidEventPayload struct {
+	OrderID     uuid.UUID     `json:"orderId"`
+	UserID      uuid.UUID     `json:"userId"`
+	Provider    OrderProvider `json:"provider"`
+	TotalAmount int64         `json:"totalAmount"`
+}
+
+type OrderStatusChangedEventPayload struct {
+	OrderID uuid.UUID   `json:"orderId"`
+	UserID  uuid.UUID   `json:"userId"`
+	From    OrderStatus `json:"from"`
+	To      OrderStatus `json:"to"`
+	// Reason is set when the order was cancelled by the system rather than
+	// by hand
+	Reason OrderCancelReason `json:"reason,omitempty"`
+}
+
+// OrderRefundDueEventPayload is published when a payment is captured for an
+// order which was cancelled meanwhile, the amount is owed back to the customer
+type OrderRefundDueEventPayload struct {
+	OrderID  uuid.UUID     `json:"orderId"`
+	UserID   uuid.UUID     `json:"userId"`
+	Provider OrderProvider `json:"provider"`
+	Amount   int64         `json:"amount"`
+}
+
+// ProductEventPayload only identifies the product, consumers read its current
+// state instead of a snapshot which may already be outdated
+type ProductEventPayload struct {
+	ProductID uuid.UUID     `json:"productId"`
+	Status    ProductStatus `json:"status"`
+}
+
+type StockChangedEventPayload struct {
+	ProductID        uuid.UUID         `json:"productId"`
+	ProductVariantID uuid.UUID         `json:"productVariantId"`
+	WarehouseID      uuid.UUID         `json:"warehouseId"`
+	Kind             StockMovementKind `json:"kind"`
+	Quantity         int               `json:"quantity"`
+	QuantityAfter    int               `json:"quantityAfter"`
+	OrderID          uuid.UUID         `json:"orderId"`
+}
+
+// RefundProcessedEventPayload is published once the refund of an order is
+// paid back to the customer
+type RefundProcessedEventPayload struct {
+	RefundID uuid.UUID `json:"refundId"`
+	OrderID  uuid.UUID `json:"orderId"`
+	UserID   uuid.UUID `json:"userId"`
+	Amount   int64     `json:"amount"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is created by the database when the stock of a variant changes,
+// notifications without user belong to staff
+type Notification struct {
+	ID               uuid.UUID        `validate:"required"`
+	UserID           uuid.UUID        `validate:"omitempty"`
+	Kind             NotificationKind `validate:"required,oneof=low_stock back_in_stock"`
+	ProductID        uuid.UUID        `validate:"omitempty"`
+	ProductVariantID uuid.UUID        `validate:"omitempty"`
+	Quantity         int              `validate:"gte=0"`
+	CreatedAt        time.Time        `validate:"required"`
+	ReadAt           time.Time        `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+type NotificationKind string
+
+const (
+	NotificationKindLowStock    NotificationKind = "low_stock"
+	NotificationKindBackInStock NotificationKind = "back_in_stock"
+)
+
+func (n *Notification) MarkRead() {
+	if n.ReadAt.IsZero() {
+		n.ReadAt = time.Now()
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	List(
+		ctx context.Context,
+		params NotificationRepositoryListParam,
+	) (*[]Notification, error)
+
+	Count(
+		ctx context.Context,
+		params NotificationRepositoryCountParam,
+	) (*int, error)
+
+	Get(
+		ctx context.Context,
+		params NotificationRepositoryGetParam,
+	) (*Notification, error)
+
+	Save(
+		ctx context.Context,
+		params NotificationRepositorySaveParam,
+	) error
+}
+
+// NotificationRepositoryListParam lists the notifications of UserID, or the
+// staff notifications when Staff is set
+type NotificationRepositoryListParam struct {
+	UserID uuid.UUID
+	Staff  bool
+	Unread bool
+	Limit  int
+	Offset int
+}
+
+type NotificationRepositoryCountParam struct {
+	UserID uuid.UUID
+	Staff  bool
+	Unread bool
+}
+
+type NotificationRepositoryGetParam struct {
+	NotificationID uuid.UUID
+}
+
+type NotificationRepositorySaveParam struct {
+	Notification Notification
+}
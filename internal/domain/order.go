@@ -1,9 +1,12 @@
 package domain
 
 import (
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
 )
 
 type Order struct {
@@ -19,6 +22,9 @@ type Order struct {
 	Items         []OrderItem `validate:"gt=0,orderTotalAmount,dive"`
 	TotalAmount   int64       `validate:"required"`
 	UserID        uuid.UUID   `validate:"required"`
+	// Events are the events recorded since the order was loaded, they are
+	// written to the outbox when the order is saved
+	Events []Event `validate:"-"`
 }
 
 type OrderItem struct {
@@ -27,6 +33,9 @@ type OrderItem struct {
 	ProductVariantID uuid.UUID `validate:"required"`
 	Quantity         int       `validate:"required,gt=0,lt=100"`
 	Price            int64     `validate:"required,gt=0"`
+	// WarehouseID is the warehouse the item ships from, it is nil for variants
+	// which are not stocked at any warehouse
+	WarehouseID uuid.UUID `validate:"omitempty"`
 }
 
 type OrderProvider string
@@ -48,6 +57,13 @@ const (
 	OrderStatusCancelled  OrderStatus = "Cancelled"
 )
 
+// OrderCancelReason tells why the system cancelled an order
+type OrderCancelReason string
+
+const (
+	OrderCancelReasonPaymentExpired OrderCancelReason = "payment_expired"
+)
+
 func NewOrder(
 	userID uuid.UUID,
 	recipentName string,
@@ -65,7 +81,7 @@ func NewOrder(
 		totalAmount += item.Price * int64(item.Quantity)
 	}
 	now := time.Now()
-	return &Order{
+	order := &Order{
 		ID:            id,
 		UserID:        userID,
 		RecipientName: recipentName,
@@ -78,7 +94,24 @@ func NewOrder(
 		UpdatedAt:     now,
 		Items:         items,
 		TotalAmount:   totalAmount,
-	}, nil
+	}
+	payloadItems := make([]OrderCreatedEventPayloadItem, 0, len(items))
+	for _, item := range items {
+		payloadItems = append(payloadItems, OrderCreatedEventPayloadItem{
+			ProductID:        item.ProductID,
+			ProductVariantID: item.ProductVariantID,
+			Quantity:         item.Quantity,
+			Price:            item.Price,
+		})
+	}
+	order.Events = append(order.Events, newEvent(EventTypeOrderCreated, OrderCreatedEventPayload{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		Provider:    order.Provider,
+		TotalAmount: order.TotalAmount,
+		Items:       payloadItems,
+	}))
+	return order, nil
 }
 
 func NewOrderItem(
@@ -111,14 +144,157 @@ func (o *Order) Update(
 		updated = true
 	}
 	if o.Status != status {
+		o.Events = append(o.Events, newEvent(EventTypeOrderStatusChanged, OrderStatusChangedEventPayload{
+			OrderID: o.ID,
+			UserID:  o.UserID,
+			From:    o.Status,
+			To:      status,
+		}))
 		o.Status = status
 		updated = true
 	}
 	if o.IsPaid != isPaid {
 		o.IsPaid = isPaid
 		updated = true
+		if isPaid {
+			o.Events = append(o.Events, newEvent(EventTypeOrderPaid, OrderPaidEventPayload{
+				OrderID:     o.ID,
+				UserID:      o.UserID,
+				Provider:    o.Provider,
+				TotalAmount: o.TotalAmount,
+			}))
+		}
 	}
 	if updated {
 		o.UpdatedAt = time.Now()
 	}
 }
+
+// IsPaymentPending tells whether the order still waits for its payment, the
+// payment of an order which moved on was verified or will not be anymore
+func (o *Order) IsPaymentPending() bool {
+	return o.Status == OrderStatusPending && !o.IsPaid
+}
+
+// IsPaymentExpired tells whether the order still waits for its payment after
+// the deadline given to its provider, a zero deadline never expires
+func (o *Order) IsPaymentExpired(deadline time.Duration, now time.Time) bool {
+	return deadline > 0 &&
+		o.IsPaymentPending() &&
+		!now.Before(o.CreatedAt.Add(deadline))
+}
+
+// ExpirePayment cancels an order whose payment was abandoned. It holds no
+// stock to give back, stock is only taken once the payment is verified
+func (o *Order) ExpirePayment() error {
+	if !o.IsPaymentPending() {
+		return multierror.Append(ErrConflict, errors.New("only unpaid pending orders can expire"))
+	}
+	o.Events = append(o.Events, newEvent(EventTypeOrderStatusChanged, OrderStatusChangedEventPayload{
+		OrderID: o.ID,
+		UserID:  o.UserID,
+		From:    o.Status,
+		To:      OrderStatusCancelled,
+		Reason:  OrderCancelReasonPaymentExpired,
+	}))
+	o.Status = OrderStatusCancelled
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// FlagRefundDue records that a payment was captured for the order after it
+// was cancelled. The order stays cancelled, the amount is owed back
+func (o *Order) FlagRefundDue() error {
+	if o.Status != OrderStatusCancelled || o.IsPaid {
+		return multierror.Append(ErrConflict, errors.New("only unpaid cancelled orders can owe a refund"))
+	}
+	o.Events = append(o.Events, newEvent(EventTypeOrderRefundDue, OrderRefundDueEventPayload{
+		OrderID:  o.ID,
+		UserID:   o.UserID,
+		Provider: o.Provider,
+		Amount:   o.TotalAmount,
+	}))
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// AllocateWarehouses chooses the warehouse each item ships from. Warehouses in
+// the province of the address come first, then by priority; the first holding
+// enough stock wins. A bundle ships whole from one warehouse holding every
+// component, so variants must include the components of the bundles ordered.
+// An item no single warehouse can ship is a conflict.
+func (o *Order) AllocateWarehouses(
+	variants []ProductVariant,
+	warehouses []Warehouse,
+) error {
+	ordered := slices.Clone(warehouses)
+	slices.SortStableFunc(ordered, func(a, b Warehouse) int {
+		aServes, bServes := a.Serves(o.Address), b.Serves(o.Address)
+		if aServes != bServes {
+			if aServes {
+				return -1
+			}
+			return 1
+		}
+		return a.Priority - b.Priority
+	})
+	variantIDVariantMap := make(map[uuid.UUID]*ProductVariant, len(variants))
+	for i := range variants {
+		variantIDVariantMap[variants[i].ID] = &variants[i]
+	}
+	type reservationKey struct {
+		warehouseID uuid.UUID
+		variantID   uuid.UUID
+	}
+	reserved := map[reservationKey]int{}
+	for i := range o.Items {
+		item := &o.Items[i]
+		item.WarehouseID = uuid.Nil
+		variant, ok := variantIDVariantMap[item.ProductVariantID]
+		if !ok {
+			continue
+		}
+		needed := map[uuid.UUID]int{variant.ID: item.Quantity}
+		if variant.IsBundle() {
+			needed = make(map[uuid.UUID]int, len(variant.Components))
+			for _, component := range variant.Components {
+				needed[component.VariantID] += component.Quantity * item.Quantity
+			}
+		}
+		stocked := false
+		for variantID := range needed {
+			if v, ok := variantIDVariantMap[variantID]; ok && len(v.Stocks) > 0 {
+				stocked = true
+			}
+		}
+		if !stocked {
+			continue
+		}
+		for _, warehouse := range ordered {
+			fits := true
+			for variantID, quantity := range needed {
+				v, ok := variantIDVariantMap[variantID]
+				if !ok {
+					fits = false
+					break
+				}
+				available := v.GetStockQuantity(warehouse.ID) - reserved[reservationKey{warehouse.ID, variantID}]
+				if available < quantity {
+					fits = false
+					break
+				}
+			}
+			if fits {
+				item.WarehouseID = warehouse.ID
+				break
+			}
+		}
+		if item.WarehouseID == uuid.Nil {
+			return multierror.Append(ErrConflict, errors.New("no warehouse holds enough stock"))
+		}
+		for variantID, quantity := range needed {
+			reserved[reservationKey{item.WarehouseID, variantID}] += quantity
+		}
+	}
+	return nil
+}
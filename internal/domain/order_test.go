@@ -388,6 +388,346 @@ func (s *OrderTestSuite) TestOrderStatusTransitions() {
 	}
 }
 
+func (s *OrderTestSuite) TestOrderAllocateWarehouses() {
+	hcm, err := domain.NewWarehouse("Kho HCM", "Hồ Chí Minh", 0)
+	s.Require().NoError(err)
+	hn, err := domain.NewWarehouse("Kho HN", "Hà Nội", 1)
+	s.Require().NoError(err)
+	warehouses := []domain.Warehouse{*hcm, *hn}
+
+	testcases := []struct {
+		name       string
+		address    string
+		stocks     []domain.WarehouseStock
+		quantities []int
+		expected   []uuid.UUID
+		expectErr  error
+	}{
+		{
+			name:    "warehouse in province of address",
+			address: "1 Tràng Tiền, Hà Nội",
+			stocks: []domain.WarehouseStock{
+				{WarehouseID: hcm.ID, Quantity: 10},
+				{WarehouseID: hn.ID, Quantity: 10},
+			},
+			quantities: []int{2},
+			expected:   []uuid.UUID{hn.ID},
+		},
+		{
+			name:    "priority when no warehouse in province",
+			address: "1 Bạch Đằng, Đà Nẵng",
+			stocks: []domain.WarehouseStock{
+				{WarehouseID: hcm.ID, Quantity: 10},
+				{WarehouseID: hn.ID, Quantity: 10},
+			},
+			quantities: []int{2},
+			expected:   []uuid.UUID{hcm.ID},
+		},
+		{
+			name:    "skip warehouse without enough stock",
+			address: "1 Tràng Tiền, Hà Nội",
+			stocks: []domain.WarehouseStock{
+				{WarehouseID: hcm.ID, Quantity: 10},
+				{WarehouseID: hn.ID, Quantity: 1},
+			},
+			quantities: []int{2},
+			expected:   []uuid.UUID{hcm.ID},
+		},
+		{
+			name:    "reserved stock is not allocated twice",
+			address: "1 Tràng Tiền, Hà Nội",
+			stocks: []domain.WarehouseStock{
+				{WarehouseID: hcm.ID, Quantity: 10},
+				{WarehouseID: hn.ID, Quantity: 3},
+			},
+			quantities: []int{2, 2},
+			expected:   []uuid.UUID{hn.ID, hcm.ID},
+		},
+		{
+			name:    "conflict when none has enough",
+			address: "1 Tràng Tiền, Hà Nội",
+			stocks: []domain.WarehouseStock{
+				{WarehouseID: hcm.ID, Quantity: 3},
+				{WarehouseID: hn.ID, Quantity: 3},
+			},
+			quantities: []int{5},
+			expectErr:  domain.ErrConflict,
+		},
+		{
+			name:       "variant not stocked at any warehouse",
+			address:    "1 Tràng Tiền, Hà Nội",
+			quantities: []int{2},
+			expected:   []uuid.UUID{uuid.Nil},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			variant, err := domain.NewVariant("SKU-001", 10000, 0)
+			s.Require().NoError(err)
+			variant.Stocks = tc.stocks
+			items := make([]domain.OrderItem, 0, len(tc.quantities))
+			for _, quantity := range tc.quantities {
+				item, err := domain.NewOrderItem(uuid.New(), variant.ID, quantity, 1000)
+				s.Require().NoError(err)
+				items = append(items, *item)
+			}
+			order, err := domain.NewOrder(
+				uuid.New(),
+				"John Doe",
+				"+84901234567",
+				tc.address,
+				domain.PaymentProviderCOD,
+				items,
+			)
+			s.Require().NoError(err)
+
+			err = order.AllocateWarehouses([]domain.ProductVariant{*variant}, warehouses)
+			if tc.expectErr != nil {
+				s.ErrorIs(err, tc.expectErr, tc.name)
+				return
+			}
+			s.Require().NoError(err)
+
+			for i, expected := range tc.expected {
+				s.Equal(expected, order.Items[i].WarehouseID, tc.name)
+			}
+		})
+	}
+}
+
+func (s *OrderTestSuite) TestOrderAllocateWarehousesBundle() {
+	hcm, err := domain.NewWarehouse("Kho HCM", "Hồ Chí Minh", 0)
+	s.Require().NoError(err)
+	hn, err := domain.NewWarehouse("Kho HN", "Hà Nội", 1)
+	s.Require().NoError(err)
+	warehouses := []domain.Warehouse{*hcm, *hn}
+
+	newOrder := func(items ...domain.OrderItem) *domain.Order {
+		order, err := domain.NewOrder(
+			uuid.New(),
+			"John Doe",
+			"+84901234567",
+			"1 Tràng Tiền, Hà Nội",
+			domain.PaymentProviderCOD,
+			items,
+		)
+		s.Require().NoError(err)
+		return order
+	}
+	newItem := func(variantID uuid.UUID, quantity int) domain.OrderItem {
+		item, err := domain.NewOrderItem(uuid.New(), variantID, quantity, 1000)
+		s.Require().NoError(err)
+		return *item
+	}
+
+	first, err := domain.NewVariant("SKU-001", 10000, 0)
+	s.Require().NoError(err)
+	first.Stocks = []domain.WarehouseStock{
+		{WarehouseID: hcm.ID, Quantity: 10},
+		{WarehouseID: hn.ID, Quantity: 1},
+	}
+	second, err := domain.NewVariant("SKU-002", 10000, 0)
+	s.Require().NoError(err)
+	second.Stocks = []domain.WarehouseStock{
+		{WarehouseID: hcm.ID, Quantity: 10},
+		{WarehouseID: hn.ID, Quantity: 10},
+	}
+	bundle, err := domain.NewVariant("SKU-BUNDLE", 10000, 0)
+	s.Require().NoError(err)
+	bundle.Components = []domain.BundleComponent{
+		{VariantID: first.ID, Quantity: 1},
+		{VariantID: second.ID, Quantity: 2},
+	}
+	variants := []domain.ProductVariant{*bundle, *first, *second}
+
+	s.Run("warehouse holding every component", func() {
+		order := newOrder(newItem(bundle.ID, 2))
+		s.Require().NoError(order.AllocateWarehouses(variants, warehouses))
+		s.Equal(hcm.ID, order.Items[0].WarehouseID)
+	})
+
+	s.Run("components reserved for the bundle", func() {
+		order := newOrder(newItem(bundle.ID, 2), newItem(first.ID, 9))
+		s.ErrorIs(order.AllocateWarehouses(variants, warehouses), domain.ErrConflict)
+	})
+
+	s.Run("conflict when no warehouse holds every component", func() {
+		split, err := domain.NewVariant("SKU-003", 10000, 0)
+		s.Require().NoError(err)
+		split.Stocks = []domain.WarehouseStock{{WarehouseID: hn.ID, Quantity: 10}}
+		splitBundle, err := domain.NewVariant("SKU-BUNDLE-SPLIT", 10000, 0)
+		s.Require().NoError(err)
+		splitBundle.Components = []domain.BundleComponent{
+			{VariantID: split.ID, Quantity: 1},
+			{VariantID: first.ID, Quantity: 2},
+		}
+		order := newOrder(newItem(splitBundle.ID, 1))
+		err = order.AllocateWarehouses(
+			[]domain.ProductVariant{*splitBundle, *split, *first},
+			warehouses,
+		)
+		s.ErrorIs(err, domain.ErrConflict)
+	})
+}
+
+func (s *OrderTestSuite) TestOrderEvents() {
+	item, err := domain.NewOrderItem(uuid.New(), uuid.New(), 2, 1000)
+	s.Require().NoError(err)
+	order, err := domain.NewOrder(
+		uuid.New(),
+		"John Doe",
+		"+84901234567",
+		"123 Main St",
+		domain.PaymentProviderCOD,
+		[]domain.OrderItem{*item},
+	)
+	s.Require().NoError(err)
+	s.Require().Len(order.Events, 1)
+	s.Equal(domain.EventTypeOrderCreated, order.Events[0].Type)
+	created, ok := order.Events[0].Payload.(domain.OrderCreatedEventPayload)
+	s.Require().True(ok)
+	s.Equal(order.ID, created.OrderID)
+	s.Equal(int64(2000), created.TotalAmount)
+	s.Len(created.Items, 1)
+
+	order.Update(order.Address, order.Status, order.IsPaid)
+	s.Len(order.Events, 1, "nothing changed")
+
+	order.Update(order.Address, domain.OrderStatusProcessing, true)
+	s.Require().Len(order.Events, 3)
+	s.Equal(domain.EventTypeOrderStatusChanged, order.Events[1].Type)
+	s.Equal(domain.OrderStatusChangedEventPayload{
+		OrderID: order.ID,
+		UserID:  order.UserID,
+		From:    domain.OrderStatusPending,
+		To:      domain.OrderStatusProcessing,
+	}, order.Events[1].Payload)
+	s.Equal(domain.EventTypeOrderPaid, order.Events[2].Type)
+
+	order.Update(order.Address, order.Status, false)
+	s.Len(order.Events, 3, "unpaying is not an event")
+}
+
+func (s *OrderTestSuite) TestOrderIsPaymentExpired() {
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		name     string
+		status   domain.OrderStatus
+		isPaid   bool
+		deadline time.Duration
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "before the deadline",
+			status:   domain.OrderStatusPending,
+			deadline: 15 * time.Minute,
+			now:      createdAt.Add(15*time.Minute - time.Second),
+			expected: false,
+		},
+		{
+			name:     "at the deadline",
+			status:   domain.OrderStatusPending,
+			deadline: 15 * time.Minute,
+			now:      createdAt.Add(15 * time.Minute),
+			expected: true,
+		},
+		{
+			name:     "no deadline",
+			status:   domain.OrderStatusPending,
+			deadline: 0,
+			now:      createdAt.Add(24 * time.Hour),
+			expected: false,
+		},
+		{
+			name:     "paid",
+			status:   domain.OrderStatusPending,
+			isPaid:   true,
+			deadline: 15 * time.Minute,
+			now:      createdAt.Add(time.Hour),
+			expected: false,
+		},
+		{
+			name:     "cancelled",
+			status:   domain.OrderStatusCancelled,
+			deadline: 15 * time.Minute,
+			now:      createdAt.Add(time.Hour),
+			expected: false,
+		},
+	}
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			order := domain.Order{
+				Status:    tc.status,
+				IsPaid:    tc.isPaid,
+				CreatedAt: createdAt,
+			}
+			s.Equal(tc.expected, order.IsPaymentExpired(tc.deadline, tc.now))
+		})
+	}
+}
+
+func (s *OrderTestSuite) TestOrderExpirePayment() {
+	item, err := domain.NewOrderItem(uuid.New(), uuid.New(), 1, 1000)
+	s.Require().NoError(err)
+	order, err := domain.NewOrder(
+		uuid.New(),
+		"John Doe",
+		"+84901234567",
+		"123 Main St",
+		domain.PaymentProviderVNPAY,
+		[]domain.OrderItem{*item},
+	)
+	s.Require().NoError(err)
+
+	s.Require().NoError(order.ExpirePayment())
+	s.Equal(domain.OrderStatusCancelled, order.Status)
+	s.False(order.IsPaid)
+	s.Require().Len(order.Events, 2)
+	s.Equal(domain.OrderStatusChangedEventPayload{
+		OrderID: order.ID,
+		UserID:  order.UserID,
+		From:    domain.OrderStatusPending,
+		To:      domain.OrderStatusCancelled,
+		Reason:  domain.OrderCancelReasonPaymentExpired,
+	}, order.Events[1].Payload)
+
+	s.ErrorIs(order.ExpirePayment(), domain.ErrConflict, "only pending orders expire")
+
+	order.Update(order.Address, domain.OrderStatusPending, true)
+	s.ErrorIs(order.ExpirePayment(), domain.ErrConflict, "paid orders do not expire")
+}
+
+func (s *OrderTestSuite) TestOrderFlagRefundDue() {
+	item, err := domain.NewOrderItem(uuid.New(), uuid.New(), 1, 1000)
+	s.Require().NoError(err)
+	order, err := domain.NewOrder(
+		uuid.New(),
+		"John Doe",
+		"+84901234567",
+		"123 Main St",
+		domain.PaymentProviderVNPAY,
+		[]domain.OrderItem{*item},
+	)
+	s.Require().NoError(err)
+
+	s.ErrorIs(order.FlagRefundDue(), domain.ErrConflict, "pending orders owe nothing")
+
+	s.Require().NoError(order.ExpirePayment())
+	s.Require().NoError(order.FlagRefundDue())
+	s.Equal(domain.OrderStatusCancelled, order.Status)
+	s.False(order.IsPaid)
+	s.Require().Len(order.Events, 3)
+	s.Equal(domain.EventTypeOrderRefundDue, order.Events[2].Type)
+	s.Equal(domain.OrderRefundDueEventPayload{
+		OrderID:  order.ID,
+		UserID:   order.UserID,
+		Provider: domain.PaymentProviderVNPAY,
+		Amount:   1000,
+	}, order.Events[2].Payload)
+}
+
 func TestOrder(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(OrderTestSuite))
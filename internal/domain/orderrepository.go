@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +23,13 @@ type OrderRepository interface {
 		params OrderRepositoryGetParam,
 	) (*Order, error)
 
+	// ListUnpaidIDs lists the unpaid orders of a status and provider created
+	// before a time, oldest first
+	ListUnpaidIDs(
+		ctx context.Context,
+		params OrderRepositoryListUnpaidIDsParam,
+	) (*[]uuid.UUID, error)
+
 	Save(
 		ctx context.Context,
 		params OrderRepositorySaveParam,
@@ -34,6 +42,7 @@ type OrderRepositoryListParam struct {
 	StatusIDs   []uuid.UUID
 	StatusNames []string
 	StatusName  string
+	AfterID     uuid.UUID
 	Limit       int
 	Offset      int
 }
@@ -50,6 +59,19 @@ type OrderRepositoryGetParam struct {
 	ID uuid.UUID
 }
 
+type OrderRepositoryListUnpaidIDsParam struct {
+	Status        OrderStatus
+	Provider      OrderProvider
+	CreatedBefore time.Time
+	Limit         int
+}
+
 type OrderRepositorySaveParam struct {
 	Order Order
+	// Products whose stock the order took or gave back are saved in the same
+	// transaction
+	Products []Product
+	// IfPaymentPending only writes the order while the stored one still waits
+	// for its payment, an order paid or cancelled meanwhile is a conflict
+	IfPaymentPending bool
 }
@@ -7,6 +7,7 @@ package domain
 import (
 	"context"
 
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -241,6 +242,74 @@ func (_c *MockOrderRepository_List_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// ListUnpaidIDs provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) ListUnpaidIDs(ctx context.Context, params OrderRepositoryListUnpaidIDsParam) (*[]uuid.UUID, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnpaidIDs")
+	}
+
+	var r0 *[]uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, OrderRepositoryListUnpaidIDsParam) (*[]uuid.UUID, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, OrderRepositoryListUnpaidIDsParam) *[]uuid.UUID); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, OrderRepositoryListUnpaidIDsParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_ListUnpaidIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnpaidIDs'
+type MockOrderRepository_ListUnpaidIDs_Call struct {
+	*mock.Call
+}
+
+// ListUnpaidIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params OrderRepositoryListUnpaidIDsParam
+func (_e *MockOrderRepository_Expecter) ListUnpaidIDs(ctx interface{}, params interface{}) *MockOrderRepository_ListUnpaidIDs_Call {
+	return &MockOrderRepository_ListUnpaidIDs_Call{Call: _e.mock.On("ListUnpaidIDs", ctx, params)}
+}
+
+func (_c *MockOrderRepository_ListUnpaidIDs_Call) Run(run func(ctx context.Context, params OrderRepositoryListUnpaidIDsParam)) *MockOrderRepository_ListUnpaidIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 OrderRepositoryListUnpaidIDsParam
+		if args[1] != nil {
+			arg1 = args[1].(OrderRepositoryListUnpaidIDsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_ListUnpaidIDs_Call) Return(uuids *[]uuid.UUID, err error) *MockOrderRepository_ListUnpaidIDs_Call {
+	_c.Call.Return(uuids, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_ListUnpaidIDs_Call) RunAndReturn(run func(ctx context.Context, params OrderRepositoryListUnpaidIDsParam) (*[]uuid.UUID, error)) *MockOrderRepository_ListUnpaidIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type MockOrderRepository
 func (_mock *MockOrderRepository) Save(ctx context.Context, params OrderRepositorySaveParam) error {
 	ret := _mock.Called(ctx, params)
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is an event written to the outbox along with its aggregate,
+// waiting to be published. Payload is the JSON of the event payload
+type OutboxEvent struct {
+	ID            uuid.UUID
+	AggregateType AggregateType
+	AggregateID   uuid.UUID
+	Type          EventType
+	Payload       []byte
+	OccurredAt    time.Time
+	// Attempts counts the failed publishes
+	Attempts int
+}
+
+// NextAttemptAt backs off exponentially from baseDelay after the failed
+// attempts of the event, up to maxDelay
+func (e OutboxEvent) NextAttemptAt(now time.Time, baseDelay time.Duration, maxDelay time.Duration) time.Time {
+	return now.Add(retryDelay(e.Attempts, baseDelay, maxDelay))
+}
+
+// retryDelay doubles baseDelay for each failed attempt, up to maxDelay
+func retryDelay(attempts int, baseDelay time.Duration, maxDelay time.Duration) time.Duration {
+	delay := baseDelay
+	for range attempts {
+		if delay >= maxDelay/2 {
+			return maxDelay
+		}
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,57 @@
+// vim: tabstop=4 shiftwidth=4:
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxTestSuite struct {
+	suite.Suite
+}
+
+func (s *OutboxTestSuite) TestOutboxEventNextAttemptAt() {
+	now := time.Now()
+	testcases := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{
+			name:     "first failure waits the base delay",
+			attempts: 0,
+			expected: time.Second,
+		},
+		{
+			name:     "doubles after each failure",
+			attempts: 3,
+			expected: 8 * time.Second,
+		},
+		{
+			name:     "capped at the max delay",
+			attempts: 9,
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "does not overflow",
+			attempts: 1000,
+			expected: 5 * time.Minute,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			event := domain.OutboxEvent{Attempts: tc.attempts}
+			s.Equal(now.Add(tc.expected), event.NextAttemptAt(now, time.Second, 5*time.Minute), tc.name)
+		})
+	}
+}
+
+func TestOutbox(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(OutboxTestSuite))
+}
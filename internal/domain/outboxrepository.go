@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxRepository reads the outbox for publishing, events are written to it
+// by the repositories of their aggregates
+type OutboxRepository interface {
+	// Claim leases the oldest due events until LeaseUntil, events of a relay
+	// which stopped before publishing them are claimed again afterwards
+	Claim(
+		ctx context.Context,
+		params OutboxRepositoryClaimParam,
+	) (*[]OutboxEvent, error)
+
+	MarkPublished(
+		ctx context.Context,
+		params OutboxRepositoryMarkPublishedParam,
+	) error
+
+	MarkFailed(
+		ctx context.Context,
+		params OutboxRepositoryMarkFailedParam,
+	) error
+
+	DeletePublished(
+		ctx context.Context,
+		params OutboxRepositoryDeletePublishedParam,
+	) (*int, error)
+}
+
+type OutboxRepositoryClaimParam struct {
+	Now        time.Time
+	LeaseUntil time.Time
+	Limit      int
+}
+
+type OutboxRepositoryMarkPublishedParam struct {
+	IDs         []uuid.UUID
+	PublishedAt time.Time
+}
+
+type OutboxRepositoryMarkFailedParam struct {
+	ID            uuid.UUID
+	Error         string
+	NextAttemptAt time.Time
+}
+
+type OutboxRepositoryDeletePublishedParam struct {
+	Before time.Time
+}
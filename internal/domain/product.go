@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,7 +15,7 @@ type Product struct {
 	Description       string           `validate:"required,gte=10"`
 	ViewsCount        int              `validate:"gte=0"`
 	TotalPurchase     int              `validate:"gte=0"`
-	TrendingScore     int64            `validate:"gte=0"`
+	TrendingScore     float64          `validate:"gte=0"`
 	Price             int64            `validate:"required,gt=0"`
 	Rating            float64          `validate:"gte=0,lte=5"`
 	Options           []Option         `validate:"omitempty,unique=ID,unique=Name,dive"`
@@ -25,6 +27,21 @@ type Product struct {
 	AttributeIDs      []uuid.UUID      `validate:"omitempty,dive,required"`
 	AttributeValueIDs []uuid.UUID      `validate:"omitempty,dive,required"`
 	Variants          []ProductVariant `validate:"gt=0,unique=ID,unique=SKU,productVariantStructure,dive"`
+	Status            ProductStatus    `validate:"required,oneof=draft published archived"`
+	// PublishAt and UnpublishAt are cleared once the scheduler applies them
+	PublishAt   time.Time `validate:"omitempty"`
+	UnpublishAt time.Time `validate:"omitempty"`
+	// Discount is the highest percentage off among the variants, Price is the
+	// lowest effective price
+	Discount int `validate:"gte=0,lte=100"`
+	// Type is fixed once the product has variants, the variants of a bundle are
+	// made of components and hold no stock of their own
+	Type ProductType `validate:"required,oneof=standard bundle,productTypeStructure"`
+	// Specs are checked against their spec when set, see Spec.NewValue
+	Specs []ProductSpec `validate:"omitempty,unique=SpecID,dive"`
+	// Events are the events recorded since the product was loaded, see
+	// PendingEvents
+	Events []Event `validate:"-"`
 }
 
 type Option struct {
@@ -41,16 +58,34 @@ type OptionValue struct {
 }
 
 type ProductVariant struct {
-	ID            uuid.UUID      `validate:"required"`
-	SKU           string         `validate:"required"`
-	Price         int64          `validate:"required,gt=0"`
-	Quantity      int            `validate:"gte=0"`
-	PurchaseCount int            `validate:"gte=0"`
-	CreatedAt     time.Time      `validate:"required"`
-	UpdatedAt     time.Time      `validate:"required,gtefield=CreatedAt"`
-	DeletedAt     time.Time      `validate:"omitempty,gtefield=CreatedAt"`
-	OptionValues  []OptionValue  `validate:"omitempty,unique=ID,unique=Value,dive"`
-	Images        []ProductImage `validate:"omitempty,unique=ID,unique=URL,unique=Order,dive"`
+	ID                uuid.UUID      `validate:"required"`
+	SKU               string         `validate:"required"`
+	Price             int64          `validate:"required,gt=0"`
+	Quantity          int            `validate:"gte=0"`
+	PurchaseCount     int            `validate:"gte=0"`
+	LowStockThreshold int            `validate:"gte=0"`
+	CreatedAt         time.Time      `validate:"required"`
+	UpdatedAt         time.Time      `validate:"required,gtefield=CreatedAt"`
+	DeletedAt         time.Time      `validate:"omitempty,gtefield=CreatedAt"`
+	OptionValues      []OptionValue  `validate:"omitempty,unique=ID,unique=Value,dive"`
+	Images            []ProductImage `validate:"omitempty,unique=ID,unique=URL,unique=Order,dive"`
+	// CompareAtPrice is the list price shown struck through, zero when unset.
+	// How it relates to Price is checked by validatePricing
+	CompareAtPrice int64 `validate:"gte=0"`
+	// SalePrice replaces Price from SaleStartsAt until SaleEndsAt, a zero time
+	// leaves that side of the sale open, zero price when there is no sale
+	SalePrice    int64     `validate:"gte=0"`
+	SaleStartsAt time.Time `validate:"omitempty"`
+	SaleEndsAt   time.Time `validate:"omitempty,gtfield=SaleStartsAt"`
+	// Stocks are the quantities held per warehouse, once a variant is stocked
+	// at a warehouse its quantity is the sum of them
+	Stocks []WarehouseStock `validate:"omitempty,unique=WarehouseID,dive"`
+	// StockMovements are the movements recorded since the variant was loaded,
+	// they are appended to the ledger when the product is saved
+	StockMovements []StockMovement `validate:"omitempty,dive"`
+	// Components are the variants a bundle variant is made of, its quantity and
+	// stocks are derived from them when the product is loaded
+	Components []BundleComponent `validate:"omitempty,unique=VariantID,dive"`
 }
 
 type ProductImage struct {
@@ -59,8 +94,59 @@ type ProductImage struct {
 	Order     int       `validate:"required,gte=0"`
 	CreatedAt time.Time `validate:"required"`
 	DeletedAt time.Time `validate:"omitempty,gtefield=CreatedAt"`
+	// Renditions are the resized copies made when the image is persisted,
+	// images stored before they existed have none
+	Renditions []ProductImageRendition `validate:"omitempty,unique=Width,dive"`
 }
 
+type ProductImageRendition struct {
+	Width  int    `validate:"gt=0"`
+	Height int    `validate:"gt=0"`
+	URL    string `validate:"required,url"`
+}
+
+type BundleComponent struct {
+	VariantID uuid.UUID `validate:"required"`
+	Quantity  int       `validate:"gt=0"`
+}
+
+type ProductSuggestion struct {
+	ID    uuid.UUID
+	Type  ProductSuggestionType
+	Text  string
+	Score float64
+}
+
+type ProductSuggestionType string
+
+const (
+	ProductSuggestionTypeProduct        ProductSuggestionType = "product"
+	ProductSuggestionTypeCategory       ProductSuggestionType = "category"
+	ProductSuggestionTypeAttributeValue ProductSuggestionType = "attribute_value"
+)
+
+type ProductStatus string
+
+const (
+	ProductStatusDraft     ProductStatus = "draft"
+	ProductStatusPublished ProductStatus = "published"
+	ProductStatusArchived  ProductStatus = "archived"
+)
+
+type ProductType string
+
+const (
+	ProductTypeStandard ProductType = "standard"
+	ProductTypeBundle   ProductType = "bundle"
+)
+
+type ProductRecommendationKind string
+
+const (
+	ProductRecommendationKindRelated        ProductRecommendationKind = "related"
+	ProductRecommendationKindBoughtTogether ProductRecommendationKind = "bought_together"
+)
+
 func NewProduct(
 	name string,
 	description string,
@@ -78,7 +164,10 @@ func NewProduct(
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		CategoryID:  categoryID,
+		Status:      ProductStatusDraft,
+		Type:        ProductTypeStandard,
 	}
+	product.recordEvent(EventTypeProductCreated)
 	return product, nil
 }
 
@@ -128,32 +217,72 @@ func NewVariant(
 		ID:            id,
 		SKU:           sku,
 		Price:         price,
-		Quantity:      quantity,
 		PurchaseCount: 0,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
+	if quantity > 0 {
+		err := productVariant.appendStockMovement(
+			StockMovementKindRestock,
+			quantity,
+			uuid.Nil,
+			uuid.Nil,
+			uuid.Nil,
+			"",
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	productVariant.Quantity = quantity
 	return productVariant, nil
 }
 
 func (p *Product) AddAttributeIDs(attributeIDs ...uuid.UUID) {
 	p.AttributeIDs = append(p.AttributeIDs, attributeIDs...)
+	p.recordUpdated()
 }
 
 func (p *Product) AddAttributeValueIDs(attributeValueIDs ...uuid.UUID) {
 	p.AttributeValueIDs = append(p.AttributeValueIDs, attributeValueIDs...)
+	p.recordUpdated()
 }
 
 func (p *Product) AddOptions(options ...Option) {
 	p.Options = append(p.Options, options...)
+	p.recordUpdated()
 }
 
 func (p *Product) AddVariants(variants ...ProductVariant) {
 	p.Variants = append(p.Variants, variants...)
+	p.recordUpdated()
 }
 
 func (p *Product) AddImages(images ...ProductImage) {
 	p.Images = append(p.Images, images...)
+	p.recordUpdated()
+}
+
+// RemoveImages removes the images of the product and of its variants with the
+// given IDs, unknown IDs are ignored
+func (p *Product) RemoveImages(imageIDs ...uuid.UUID) {
+	ids := make(map[uuid.UUID]struct{}, len(imageIDs))
+	for _, id := range imageIDs {
+		ids[id] = struct{}{}
+	}
+	for i := range p.Images {
+		if _, exists := ids[p.Images[i].ID]; exists {
+			p.Images[i].Remove()
+		}
+	}
+	for i := range p.Variants {
+		for j := range p.Variants[i].Images {
+			if _, exists := ids[p.Variants[i].Images[j].ID]; exists {
+				p.Variants[i].Images[j].Remove()
+			}
+		}
+	}
+	p.recordUpdated()
 }
 
 func (p *Product) AddVariantImages(variantID uuid.UUID, images ...ProductImage) error {
@@ -168,6 +297,7 @@ func (p *Product) AddVariantImages(variantID uuid.UUID, images ...ProductImage)
 		return multierror.Append(ErrNotFound, nil)
 	}
 	variant.Images = append(variant.Images, images...)
+	p.recordUpdated()
 	return nil
 }
 
@@ -191,13 +321,87 @@ func (p *Product) Update(
 	}
 	if updated {
 		p.UpdatedAt = time.Now()
+		p.recordUpdated()
 	}
 }
 
+// UpdatePublishing replaces the status and the schedule, a zero time means no
+// schedule. Only drafts wait for publish and only drafts or published products
+// wait for unpublish
+func (p *Product) UpdatePublishing(
+	status ProductStatus,
+	publishAt time.Time,
+	unpublishAt time.Time,
+) error {
+	switch status {
+	case ProductStatusDraft, ProductStatusPublished, ProductStatusArchived:
+	default:
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if !publishAt.IsZero() && status != ProductStatusDraft {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if !unpublishAt.IsZero() && status == ProductStatusArchived {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if !publishAt.IsZero() && !unpublishAt.IsZero() && !unpublishAt.After(publishAt) {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	p.Status = status
+	p.PublishAt = publishAt
+	p.UnpublishAt = unpublishAt
+	p.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
+func (p *Product) IsPublished() bool {
+	return p.Status == ProductStatusPublished
+}
+
+// UpdateType changes the type of a product which has no variants yet
+func (p *Product) UpdateType(productType ProductType) error {
+	switch productType {
+	case ProductTypeStandard, ProductTypeBundle:
+	default:
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if p.Type == productType {
+		return nil
+	}
+	if len(p.Variants) > 0 {
+		return multierror.Append(ErrConflict, nil)
+	}
+	p.Type = productType
+	p.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
+func (p *Product) IsBundle() bool {
+	return p.Type == ProductTypeBundle
+}
+
+// UpdateSpecs replaces the spec values of the product
+func (p *Product) UpdateSpecs(specs []ProductSpec) error {
+	seen := make(map[uuid.UUID]struct{}, len(specs))
+	for _, spec := range specs {
+		if _, exists := seen[spec.SpecID]; exists {
+			return multierror.Append(ErrInvalid, nil)
+		}
+		seen[spec.SpecID] = struct{}{}
+	}
+	p.Specs = slices.Clone(specs)
+	p.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
 func (p *Product) UpdateVariant(
 	variantID uuid.UUID,
 	price int64,
 	quantity int,
+	actorID uuid.UUID,
 ) error {
 	var variant *ProductVariant
 	for i := range p.Variants {
@@ -211,19 +415,177 @@ func (p *Product) UpdateVariant(
 	}
 	updated := false
 	if price != 0 && variant.Price != price {
-		variant.Price = price
+		pricing := *variant
+		pricing.Price = price
+		// A compare-at price the new price reaches no longer marks a discount
+		if pricing.CompareAtPrice <= price {
+			pricing.CompareAtPrice = 0
+		}
+		if err := pricing.validatePricing(); err != nil {
+			return err
+		}
+		variant.Price = pricing.Price
+		variant.CompareAtPrice = pricing.CompareAtPrice
 		updated = true
 	}
-	if quantity != 0 {
+	if quantity != 0 && variant.Quantity != quantity {
+		// The total of a variant held at several warehouses can not be split
+		if len(variant.Stocks) > 1 || variant.IsBundle() {
+			return multierror.Append(ErrInvalid, nil)
+		}
+		err := variant.appendStockMovement(
+			StockMovementKindAdjustment,
+			quantity-variant.Quantity,
+			actorID,
+			uuid.Nil,
+			variant.resolveWarehouseID(uuid.Nil),
+			"",
+		)
+		if err != nil {
+			return err
+		}
 		variant.Quantity = quantity
 		updated = true
 	}
 	if updated {
 		variant.UpdatedAt = time.Now()
+		p.recordUpdated()
 	}
 	return nil
 }
 
+// UpdateVariantLowStockThreshold sets the quantity at or below which staff are
+// notified, zero disables the alert
+func (p *Product) UpdateVariantLowStockThreshold(
+	variantID uuid.UUID,
+	threshold int,
+) error {
+	var variant *ProductVariant
+	for i := range p.Variants {
+		if p.Variants[i].ID == variantID {
+			variant = &p.Variants[i]
+			break
+		}
+	}
+	if variant == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	if threshold < 0 {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if variant.LowStockThreshold != threshold {
+		variant.LowStockThreshold = threshold
+		variant.UpdatedAt = time.Now()
+		p.recordUpdated()
+	}
+	return nil
+}
+
+// UpdateVariantPricing replaces the compare-at price and the sale of a variant
+func (p *Product) UpdateVariantPricing(
+	variantID uuid.UUID,
+	compareAtPrice int64,
+	salePrice int64,
+	saleStartsAt time.Time,
+	saleEndsAt time.Time,
+) error {
+	var variant *ProductVariant
+	for i := range p.Variants {
+		if p.Variants[i].ID == variantID {
+			variant = &p.Variants[i]
+			break
+		}
+	}
+	if variant == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	pricing := *variant
+	pricing.CompareAtPrice = compareAtPrice
+	pricing.SalePrice = salePrice
+	pricing.SaleStartsAt = saleStartsAt
+	pricing.SaleEndsAt = saleEndsAt
+	if err := pricing.validatePricing(); err != nil {
+		return err
+	}
+	variant.CompareAtPrice = compareAtPrice
+	variant.SalePrice = salePrice
+	variant.SaleStartsAt = saleStartsAt
+	variant.SaleEndsAt = saleEndsAt
+	variant.UpdatedAt = time.Now()
+	p.UpdateMinPrice()
+	p.recordUpdated()
+	return nil
+}
+
+// UpdateVariantComponents replaces the components of a bundle variant, a
+// component can not be a variant of the bundle itself
+func (p *Product) UpdateVariantComponents(
+	variantID uuid.UUID,
+	components []BundleComponent,
+) error {
+	if !p.IsBundle() {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	var variant *ProductVariant
+	for i := range p.Variants {
+		if p.Variants[i].ID == variantID {
+			variant = &p.Variants[i]
+			break
+		}
+	}
+	if variant == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	if len(components) == 0 {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	seen := make(map[uuid.UUID]struct{}, len(components))
+	for _, component := range components {
+		if component.VariantID == uuid.Nil || component.Quantity <= 0 {
+			return multierror.Append(ErrInvalid, nil)
+		}
+		if _, exists := seen[component.VariantID]; exists {
+			return multierror.Append(ErrInvalid, nil)
+		}
+		seen[component.VariantID] = struct{}{}
+		if slices.ContainsFunc(p.Variants, func(v ProductVariant) bool {
+			return v.ID == component.VariantID
+		}) {
+			return multierror.Append(ErrInvalid, nil)
+		}
+	}
+	// Stock already held by the variant would be lost once it is derived
+	if !variant.IsBundle() && variant.Quantity > 0 {
+		return multierror.Append(ErrConflict, nil)
+	}
+	variant.Components = slices.Clone(components)
+	variant.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
+func (p *Product) RecordStockMovement(
+	variantID uuid.UUID,
+	warehouseID uuid.UUID,
+	kind StockMovementKind,
+	quantity int,
+	actorID uuid.UUID,
+	orderID uuid.UUID,
+	note string,
+) (*StockMovement, error) {
+	for i := range p.Variants {
+		if p.Variants[i].ID == variantID {
+			variant := &p.Variants[i]
+			err := variant.RecordStockMovement(warehouseID, kind, quantity, actorID, orderID, note)
+			if err != nil {
+				return nil, err
+			}
+			return &variant.StockMovements[len(variant.StockMovements)-1], nil
+		}
+	}
+	return nil, multierror.Append(ErrNotFound, nil)
+}
+
 func (p *Product) UpdateOption(
 	optionID uuid.UUID,
 	name string,
@@ -240,6 +602,7 @@ func (p *Product) UpdateOption(
 	}
 	if name != "" && option.Name != name {
 		option.Name = name
+		p.recordUpdated()
 	}
 	return nil
 }
@@ -271,6 +634,7 @@ func (p *Product) UpdateOptionValue(
 	}
 	if value != "" && optionValue.Value != value {
 		optionValue.Value = value
+		p.recordUpdated()
 	}
 	return nil
 }
@@ -307,32 +671,344 @@ func (p *Product) GetVariantByID(variantID uuid.UUID) *ProductVariant {
 	return nil
 }
 
+// UpdateMinPrice sets the price to the lowest effective price of the variants
+// and the discount to the highest one
 func (p *Product) UpdateMinPrice() {
-	if len(p.Variants) == 0 {
+	variants := p.RemainingVariants()
+	if len(variants) == 0 {
 		return
 	}
-	minPrice := p.Variants[0].Price
-	for _, variant := range p.Variants {
-		if variant.Price < minPrice {
-			minPrice = variant.Price
+	now := time.Now()
+	minPrice := variants[0].EffectivePrice(now)
+	maxDiscount := 0
+	for _, variant := range variants {
+		if price := variant.EffectivePrice(now); price < minPrice {
+			minPrice = price
+		}
+		if discount := variant.Discount(now); discount > maxDiscount {
+			maxDiscount = discount
 		}
 	}
 	p.Price = minPrice
+	p.Discount = maxDiscount
+}
+
+// RemainingOptions returns the options which are not removed
+func (p *Product) RemainingOptions() []Option {
+	options := make([]Option, 0, len(p.Options))
+	for _, option := range p.Options {
+		if option.DeletedAt.IsZero() {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// RemainingVariants returns the variants which are not removed
+func (p *Product) RemainingVariants() []ProductVariant {
+	variants := make([]ProductVariant, 0, len(p.Variants))
+	for _, variant := range p.Variants {
+		if variant.DeletedAt.IsZero() {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
 }
 
+// RemoveVariant soft deletes a variant, its row is kept since carts, orders and
+// the stock ledger keep referencing it
+func (p *Product) RemoveVariant(variantID uuid.UUID) error {
+	for i := range p.Variants {
+		if p.Variants[i].ID == variantID && p.Variants[i].DeletedAt.IsZero() {
+			p.Variants[i].Remove()
+			p.UpdateMinPrice()
+			p.UpdatedAt = time.Now()
+			p.recordUpdated()
+			return nil
+		}
+	}
+	return multierror.Append(ErrNotFound, nil)
+}
+
+// AddOption adds an option to a product which already has variants, every
+// remaining variant is mapped to the value named in variantValues
+func (p *Product) AddOption(
+	option Option,
+	variantValues map[uuid.UUID]string,
+) error {
+	for _, existing := range p.RemainingOptions() {
+		if strings.EqualFold(existing.Name, option.Name) {
+			return multierror.Append(ErrConflict, nil)
+		}
+	}
+	if len(variantValues) != len(p.RemainingVariants()) {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	variantOptionValues := make(map[uuid.UUID]OptionValue, len(variantValues))
+	for variantID, value := range variantValues {
+		variant := p.GetVariantByID(variantID)
+		if variant == nil || !variant.DeletedAt.IsZero() {
+			return multierror.Append(ErrNotFound, nil)
+		}
+		index := slices.IndexFunc(option.Values, func(ov OptionValue) bool {
+			return ov.Value == value
+		})
+		if index < 0 {
+			return multierror.Append(ErrInvalid, nil)
+		}
+		variantOptionValues[variantID] = option.Values[index]
+	}
+	for i := range p.Variants {
+		if optionValue, ok := variantOptionValues[p.Variants[i].ID]; ok {
+			p.Variants[i].OptionValues = append(p.Variants[i].OptionValues, optionValue)
+			p.Variants[i].UpdatedAt = time.Now()
+		}
+	}
+	p.Options = append(p.Options, option)
+	p.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
+// RemoveOption removes an option with its values and unmaps them from the
+// remaining variants, they must still be told apart by the other options
+func (p *Product) RemoveOption(optionID uuid.UUID) error {
+	option := p.getRemainingOption(optionID)
+	if option == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	valueIDs := make(map[uuid.UUID]struct{}, len(option.Values))
+	for _, value := range option.Values {
+		valueIDs[value.ID] = struct{}{}
+	}
+	keep := func(ov OptionValue) bool {
+		_, ok := valueIDs[ov.ID]
+		return !ok
+	}
+	combinations := make(map[string]struct{}, len(p.Variants))
+	for _, variant := range p.RemainingVariants() {
+		combination := make([]string, 0, len(variant.OptionValues))
+		for _, ov := range variant.OptionValues {
+			if keep(ov) {
+				combination = append(combination, ov.ID.String())
+			}
+		}
+		slices.Sort(combination)
+		key := strings.Join(combination, ",")
+		if _, exists := combinations[key]; exists {
+			return multierror.Append(ErrConflict, nil)
+		}
+		combinations[key] = struct{}{}
+	}
+	now := time.Now()
+	for i := range p.Variants {
+		if !p.Variants[i].DeletedAt.IsZero() {
+			continue
+		}
+		p.Variants[i].OptionValues = slices.DeleteFunc(p.Variants[i].OptionValues, func(ov OptionValue) bool {
+			return !keep(ov)
+		})
+		p.Variants[i].UpdatedAt = now
+	}
+	option.Remove()
+	p.UpdatedAt = now
+	p.recordUpdated()
+	return nil
+}
+
+// AddOptionValues adds values to an option, variants using them are added
+// afterwards
+func (p *Product) AddOptionValues(optionID uuid.UUID, optionValues ...OptionValue) error {
+	option := p.getRemainingOption(optionID)
+	if option == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	for _, optionValue := range optionValues {
+		for _, existing := range option.Values {
+			if existing.DeletedAt.IsZero() && existing.Value == optionValue.Value {
+				return multierror.Append(ErrConflict, nil)
+			}
+		}
+	}
+	option.AddOptionValues(optionValues...)
+	p.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
+// RemoveOptionValue removes a value from an option, the variants using it must
+// be removed first
+func (p *Product) RemoveOptionValue(optionID uuid.UUID, optionValueID uuid.UUID) error {
+	option := p.getRemainingOption(optionID)
+	if option == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	index := slices.IndexFunc(option.Values, func(ov OptionValue) bool {
+		return ov.ID == optionValueID && ov.DeletedAt.IsZero()
+	})
+	if index < 0 {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	for _, variant := range p.RemainingVariants() {
+		for _, ov := range variant.OptionValues {
+			if ov.ID == optionValueID {
+				return multierror.Append(ErrConflict, nil)
+			}
+		}
+	}
+	option.Values[index].Remove()
+	p.UpdatedAt = time.Now()
+	p.recordUpdated()
+	return nil
+}
+
+func (p *Product) getRemainingOption(optionID uuid.UUID) *Option {
+	for i := range p.Options {
+		if p.Options[i].ID == optionID && p.Options[i].DeletedAt.IsZero() {
+			return &p.Options[i]
+		}
+	}
+	return nil
+}
+
+// Remove removes the product with its children, children removed earlier keep
+// their removal time so Restore leaves them removed
 func (p *Product) Remove() {
 	now := time.Now()
 	p.UpdatedAt = now
 	p.DeletedAt = now
+	p.recordEvent(EventTypeProductDeleted)
+	for i := range p.Options {
+		if p.Options[i].DeletedAt.IsZero() {
+			p.Options[i].Remove()
+		}
+	}
+	for i := range p.Variants {
+		if p.Variants[i].DeletedAt.IsZero() {
+			p.Variants[i].Remove()
+		}
+	}
+	for i := range p.Images {
+		if p.Images[i].DeletedAt.IsZero() {
+			p.Images[i].Remove()
+		}
+	}
+}
+
+// Restore revives a removed product along with the options, variants and images
+// removed together with it, children removed before the product stay removed
+func (p *Product) Restore() error {
+	if p.DeletedAt.IsZero() {
+		return multierror.Append(ErrConflict, nil)
+	}
+	removedAt := p.DeletedAt
 	for i := range p.Options {
-		p.Options[i].Remove()
+		if !p.Options[i].DeletedAt.Before(removedAt) {
+			p.Options[i].DeletedAt = time.Time{}
+			for j := range p.Options[i].Values {
+				if !p.Options[i].Values[j].DeletedAt.Before(removedAt) {
+					p.Options[i].Values[j].DeletedAt = time.Time{}
+				}
+			}
+		}
 	}
 	for i := range p.Variants {
-		p.Variants[i].Remove()
+		if !p.Variants[i].DeletedAt.Before(removedAt) {
+			p.Variants[i].DeletedAt = time.Time{}
+			p.Variants[i].UpdatedAt = time.Now()
+		}
+		for j := range p.Variants[i].Images {
+			if !p.Variants[i].Images[j].DeletedAt.Before(removedAt) {
+				p.Variants[i].Images[j].DeletedAt = time.Time{}
+			}
+		}
 	}
 	for i := range p.Images {
-		p.Images[i].Remove()
+		if !p.Images[i].DeletedAt.Before(removedAt) {
+			p.Images[i].DeletedAt = time.Time{}
+		}
+	}
+	p.DeletedAt = time.Time{}
+	p.UpdatedAt = time.Now()
+	p.UpdateMinPrice()
+	p.recordUpdated()
+	return nil
+}
+
+// recordUpdated records that the product has been updated, once per save and
+// only when it has not been created or deleted in the same save. Every mutator
+// calls it, a later mutator refreshes the status the event carries
+func (p *Product) recordUpdated() {
+	for i, event := range p.Events {
+		switch event.Type {
+		case EventTypeProductCreated, EventTypeProductDeleted:
+			return
+		case EventTypeProductUpdated:
+			p.Events[i].Payload = ProductEventPayload{
+				ProductID: p.ID,
+				Status:    p.Status,
+			}
+			return
+		}
 	}
+	p.recordEvent(EventTypeProductUpdated)
+}
+
+// NewProductUpdatedEvent is the event of a product updated in bulk without
+// being loaded
+func NewProductUpdatedEvent(productID uuid.UUID, status ProductStatus) Event {
+	return newEvent(EventTypeProductUpdated, ProductEventPayload{
+		ProductID: productID,
+		Status:    status,
+	})
+}
+
+func (p *Product) recordEvent(eventType EventType) {
+	p.Events = append(p.Events, newEvent(eventType, ProductEventPayload{
+		ProductID: p.ID,
+		Status:    p.Status,
+	}))
+}
+
+// PendingEvents returns the events recorded since the product was loaded and
+// a StockChanged event per stock movement recorded meanwhile, identified by
+// the movement so a movement is never published twice as different events
+func (p *Product) PendingEvents() []Event {
+	events := slices.Clone(p.Events)
+	for _, variant := range p.Variants {
+		for _, movement := range variant.StockMovements {
+			events = append(events, Event{
+				ID:   movement.ID,
+				Type: EventTypeStockChanged,
+				Payload: StockChangedEventPayload{
+					ProductID:        p.ID,
+					ProductVariantID: variant.ID,
+					WarehouseID:      movement.WarehouseID,
+					Kind:             movement.Kind,
+					Quantity:         movement.Quantity,
+					QuantityAfter:    movement.QuantityAfter,
+					OrderID:          movement.OrderID,
+				},
+				OccurredAt: movement.CreatedAt,
+			})
+		}
+	}
+	return events
+}
+
+// ImageIDs returns the IDs of every image of the product and its variants,
+// removed ones included
+func (p *Product) ImageIDs() []uuid.UUID {
+	imageIDs := make([]uuid.UUID, 0, len(p.Images))
+	for _, img := range p.Images {
+		imageIDs = append(imageIDs, img.ID)
+	}
+	for _, variant := range p.Variants {
+		for _, img := range variant.Images {
+			imageIDs = append(imageIDs, img.ID)
+		}
+	}
+	return imageIDs
 }
 
 func (o *Option) AddOptionValues(optionValues ...OptionValue) {
@@ -343,7 +1019,9 @@ func (o *Option) Remove() {
 	now := time.Now()
 	o.DeletedAt = now
 	for i := range o.Values {
-		o.Values[i].Remove()
+		if o.Values[i].DeletedAt.IsZero() {
+			o.Values[i].Remove()
+		}
 	}
 }
 
@@ -352,6 +1030,106 @@ func (ov *OptionValue) Remove() {
 	ov.DeletedAt = now
 }
 
+// IsOnSale tells whether the sale price applies at the given time
+func (v *ProductVariant) IsOnSale(at time.Time) bool {
+	if v.SalePrice == 0 {
+		return false
+	}
+	if !v.SaleStartsAt.IsZero() && at.Before(v.SaleStartsAt) {
+		return false
+	}
+	if !v.SaleEndsAt.IsZero() && !at.Before(v.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// EffectivePrice is the price a customer pays at the given time
+func (v *ProductVariant) EffectivePrice(at time.Time) int64 {
+	if v.IsOnSale(at) {
+		return v.SalePrice
+	}
+	return v.Price
+}
+
+// Discount is the whole percentage the effective price is below the
+// compare-at price, or below the price when the variant has none
+func (v *ProductVariant) Discount(at time.Time) int {
+	referencePrice := v.Price
+	if v.CompareAtPrice > 0 {
+		referencePrice = v.CompareAtPrice
+	}
+	price := v.EffectivePrice(at)
+	if price >= referencePrice {
+		return 0
+	}
+	return int((referencePrice - price) * 100 / referencePrice)
+}
+
+// validatePricing checks the compare-at and sale prices against the price they
+// are shown with
+func (v *ProductVariant) validatePricing() error {
+	if v.CompareAtPrice < 0 || (v.CompareAtPrice > 0 && v.CompareAtPrice <= v.Price) {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if v.SalePrice < 0 || (v.SalePrice > 0 && v.SalePrice >= v.Price) {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if v.SalePrice == 0 && (!v.SaleStartsAt.IsZero() || !v.SaleEndsAt.IsZero()) {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if !v.SaleStartsAt.IsZero() && !v.SaleEndsAt.IsZero() && !v.SaleEndsAt.After(v.SaleStartsAt) {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	return nil
+}
+
+func (v *ProductVariant) IsBundle() bool {
+	return len(v.Components) > 0
+}
+
+// DeriveBundleStock sets the quantity of a bundle variant to the number of
+// complete bundles its components make, in total and at every warehouse
+// holding all of them
+func (v *ProductVariant) DeriveBundleStock(components []ProductVariant) {
+	if !v.IsBundle() {
+		return
+	}
+	variantIDComponentMap := make(map[uuid.UUID]ProductVariant, len(components))
+	for _, component := range components {
+		variantIDComponentMap[component.ID] = component
+	}
+	quantity := -1
+	var stocks []WarehouseStock
+	for i, bundleComponent := range v.Components {
+		component := variantIDComponentMap[bundleComponent.VariantID]
+		available := component.Quantity / bundleComponent.Quantity
+		if quantity < 0 || available < quantity {
+			quantity = available
+		}
+		if i == 0 {
+			for _, stock := range component.Stocks {
+				stocks = append(stocks, WarehouseStock{
+					WarehouseID: stock.WarehouseID,
+					Quantity:    stock.Quantity / bundleComponent.Quantity,
+				})
+			}
+			continue
+		}
+		stocks = slices.DeleteFunc(stocks, func(stock WarehouseStock) bool {
+			return !slices.ContainsFunc(component.Stocks, func(s WarehouseStock) bool {
+				return s.WarehouseID == stock.WarehouseID
+			})
+		})
+		for j := range stocks {
+			available := component.GetStockQuantity(stocks[j].WarehouseID) / bundleComponent.Quantity
+			stocks[j].Quantity = min(stocks[j].Quantity, available)
+		}
+	}
+	v.Quantity = max(quantity, 0)
+	v.Stocks = stocks
+}
+
 func (v *ProductVariant) Remove() {
 	now := time.Now()
 	v.DeletedAt = now
@@ -404,15 +1182,169 @@ func (o *Option) GetValuesByIDs(optionValueIDs []uuid.UUID) []OptionValue {
 	return values
 }
 
-func (pv *ProductVariant) DecreaseQuantity(quantity int) {
+// DecreaseQuantity sells from a warehouse. What the warehouse can not cover is
+// taken from the others holding the most, the sale is capped by the total stock
+func (pv *ProductVariant) DecreaseQuantity(
+	quantity int,
+	warehouseID uuid.UUID,
+	orderID uuid.UUID,
+) error {
 	if quantity <= 0 {
-		return
+		return nil
 	}
-	if pv.Quantity >= quantity {
-		pv.Quantity -= quantity
-	} else {
-		pv.Quantity = 0
+	// The components of a bundle are decreased instead
+	if pv.IsBundle() {
+		pv.PurchaseCount += quantity
+		pv.UpdatedAt = time.Now()
+		return nil
+	}
+	remaining := min(quantity, pv.Quantity)
+	for remaining > 0 {
+		warehouseID = pv.resolveWarehouseID(warehouseID)
+		available := pv.Quantity
+		if len(pv.Stocks) > 0 {
+			available = pv.GetStockQuantity(warehouseID)
+		}
+		sold := min(remaining, available)
+		if sold <= 0 {
+			break
+		}
+		err := pv.appendStockMovement(
+			StockMovementKindSale,
+			-sold,
+			uuid.Nil,
+			orderID,
+			warehouseID,
+			"",
+		)
+		if err != nil {
+			return err
+		}
+		pv.Quantity -= sold
+		remaining -= sold
+		warehouseID = uuid.Nil
 	}
 	pv.PurchaseCount += quantity
 	pv.UpdatedAt = time.Now()
+	return nil
+}
+
+// RecordStockMovement changes the quantity at a warehouse by a signed amount
+// and records why, the quantity can not go below zero. Without warehouse the
+// movement goes to the warehouse holding most of the stock.
+func (pv *ProductVariant) RecordStockMovement(
+	warehouseID uuid.UUID,
+	kind StockMovementKind,
+	quantity int,
+	actorID uuid.UUID,
+	orderID uuid.UUID,
+	note string,
+) error {
+	if pv.IsBundle() {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	warehouseID = pv.resolveWarehouseID(warehouseID)
+	if quantity == 0 || pv.Quantity+quantity < 0 {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if warehouseID != uuid.Nil {
+		// Stock which is not at any warehouse must be assigned first
+		if len(pv.Stocks) == 0 && pv.Quantity > 0 {
+			return multierror.Append(ErrConflict, nil)
+		}
+		if pv.GetStockQuantity(warehouseID)+quantity < 0 {
+			return multierror.Append(ErrInvalid, nil)
+		}
+	}
+	err := pv.appendStockMovement(kind, quantity, actorID, orderID, warehouseID, note)
+	if err != nil {
+		return err
+	}
+	pv.Quantity += quantity
+	pv.UpdatedAt = time.Now()
+	return nil
+}
+
+// AssignWarehouse places the stock of a variant which is not at any warehouse
+// yet at the given warehouse
+func (pv *ProductVariant) AssignWarehouse(warehouseID uuid.UUID) error {
+	if warehouseID == uuid.Nil || pv.IsBundle() {
+		return multierror.Append(ErrInvalid, nil)
+	}
+	if len(pv.Stocks) > 0 {
+		return multierror.Append(ErrConflict, nil)
+	}
+	pv.Stocks = []WarehouseStock{
+		{
+			WarehouseID: warehouseID,
+			Quantity:    pv.Quantity,
+		},
+	}
+	for i := range pv.StockMovements {
+		if pv.StockMovements[i].WarehouseID == uuid.Nil {
+			pv.StockMovements[i].WarehouseID = warehouseID
+		}
+	}
+	pv.UpdatedAt = time.Now()
+	return nil
+}
+
+func (pv *ProductVariant) GetStockQuantity(warehouseID uuid.UUID) int {
+	for _, stock := range pv.Stocks {
+		if stock.WarehouseID == warehouseID {
+			return stock.Quantity
+		}
+	}
+	return 0
+}
+
+func (pv *ProductVariant) resolveWarehouseID(warehouseID uuid.UUID) uuid.UUID {
+	if warehouseID != uuid.Nil || len(pv.Stocks) == 0 {
+		return warehouseID
+	}
+	largest := pv.Stocks[0]
+	for _, stock := range pv.Stocks[1:] {
+		if stock.Quantity > largest.Quantity {
+			largest = stock
+		}
+	}
+	return largest.WarehouseID
+}
+
+func (pv *ProductVariant) appendStockMovement(
+	kind StockMovementKind,
+	quantity int,
+	actorID uuid.UUID,
+	orderID uuid.UUID,
+	warehouseID uuid.UUID,
+	note string,
+) error {
+	stockMovement, err := NewStockMovement(
+		pv.ID,
+		kind,
+		quantity,
+		pv.Quantity+quantity,
+		actorID,
+		orderID,
+		warehouseID,
+		note,
+	)
+	if err != nil {
+		return err
+	}
+	pv.StockMovements = append(pv.StockMovements, *stockMovement)
+	if warehouseID == uuid.Nil {
+		return nil
+	}
+	for i := range pv.Stocks {
+		if pv.Stocks[i].WarehouseID == warehouseID {
+			pv.Stocks[i].Quantity += quantity
+			return nil
+		}
+	}
+	pv.Stocks = append(pv.Stocks, WarehouseStock{
+		WarehouseID: warehouseID,
+		Quantity:    quantity,
+	})
+	return nil
 }
@@ -613,7 +613,7 @@ func (s *ProductTestSuite) TestProductUpdateVariant() {
 
 			time.Sleep(10 * time.Millisecond)
 
-			err = product.UpdateVariant(targetID, tc.updatePrice, tc.updateQuantity)
+			err = product.UpdateVariant(targetID, tc.updatePrice, tc.updateQuantity, uuid.Nil)
 
 			if tc.expectErr {
 				s.Error(err, tc.name)
@@ -989,6 +989,254 @@ func (s *ProductTestSuite) TestProductUpdateMinPrice() {
 	}
 }
 
+func (s *ProductTestSuite) TestProductVariantEffectivePrice() {
+	now := time.Now()
+	testcases := []struct {
+		name             string
+		variant          domain.ProductVariant
+		expectedPrice    int64
+		expectedDiscount int
+	}{
+		{
+			name:          "no sale",
+			variant:       domain.ProductVariant{Price: 10000},
+			expectedPrice: 10000,
+		},
+		{
+			name:             "compare-at price only",
+			variant:          domain.ProductVariant{Price: 10000, CompareAtPrice: 12500},
+			expectedPrice:    10000,
+			expectedDiscount: 20,
+		},
+		{
+			name:             "open sale",
+			variant:          domain.ProductVariant{Price: 10000, SalePrice: 7000},
+			expectedPrice:    7000,
+			expectedDiscount: 30,
+		},
+		{
+			name:             "running sale against compare-at price",
+			variant:          domain.ProductVariant{Price: 10000, CompareAtPrice: 20000, SalePrice: 5000, SaleStartsAt: now.Add(-time.Hour), SaleEndsAt: now.Add(time.Hour)},
+			expectedPrice:    5000,
+			expectedDiscount: 75,
+		},
+		{
+			name:          "sale not started",
+			variant:       domain.ProductVariant{Price: 10000, SalePrice: 5000, SaleStartsAt: now.Add(time.Hour)},
+			expectedPrice: 10000,
+		},
+		{
+			name:          "sale ended",
+			variant:       domain.ProductVariant{Price: 10000, SalePrice: 5000, SaleEndsAt: now},
+			expectedPrice: 10000,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expectedPrice, tc.variant.EffectivePrice(now))
+			s.Equal(tc.expectedPrice != tc.variant.Price, tc.variant.IsOnSale(now))
+			s.Equal(tc.expectedDiscount, tc.variant.Discount(now))
+		})
+	}
+}
+
+func (s *ProductTestSuite) TestProductUpdateVariantPricing() {
+	now := time.Now()
+	testcases := []struct {
+		name           string
+		variantID      uuid.UUID
+		compareAtPrice int64
+		salePrice      int64
+		saleStartsAt   time.Time
+		saleEndsAt     time.Time
+		err            error
+	}{
+		{name: "unknown variant", variantID: uuid.New(), err: domain.ErrNotFound},
+		{name: "compare-at price below price", compareAtPrice: 5000, err: domain.ErrInvalid},
+		{name: "sale price above price", salePrice: 15000, err: domain.ErrInvalid},
+		{name: "sale times without sale price", saleStartsAt: now, err: domain.ErrInvalid},
+		{name: "sale ends before it starts", salePrice: 5000, saleStartsAt: now, saleEndsAt: now.Add(-time.Hour), err: domain.ErrInvalid},
+		{name: "running sale", compareAtPrice: 20000, salePrice: 5000, saleStartsAt: now.Add(-time.Hour), saleEndsAt: now.Add(time.Hour)},
+		{name: "clear pricing"},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
+			s.Require().NoError(err)
+			cheap, err := domain.NewVariant("SKU-A", 10000, 10)
+			s.Require().NoError(err)
+			expensive, err := domain.NewVariant("SKU-B", 30000, 10)
+			s.Require().NoError(err)
+			product.AddVariants(*cheap, *expensive)
+			product.UpdateMinPrice()
+
+			variantID := tc.variantID
+			if variantID == uuid.Nil {
+				variantID = cheap.ID
+			}
+			err = product.UpdateVariantPricing(variantID, tc.compareAtPrice, tc.salePrice, tc.saleStartsAt, tc.saleEndsAt)
+			if tc.err != nil {
+				s.ErrorIs(err, tc.err)
+				return
+			}
+			s.Require().NoError(err)
+			variant := product.GetVariantByID(cheap.ID)
+			s.Equal(tc.compareAtPrice, variant.CompareAtPrice)
+			s.Equal(tc.salePrice, variant.SalePrice)
+			s.Equal(variant.EffectivePrice(time.Now()), product.Price)
+			s.Equal(variant.Discount(time.Now()), product.Discount)
+		})
+	}
+}
+
+func (s *ProductTestSuite) TestProductUpdateVariantPriceKeepsPricing() {
+	product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
+	s.Require().NoError(err)
+	variant, err := domain.NewVariant("SKU-A", 10000, 10)
+	s.Require().NoError(err)
+	product.AddVariants(*variant)
+	s.Require().NoError(product.UpdateVariantPricing(variant.ID, 12000, 8000, time.Time{}, time.Time{}))
+
+	s.Require().NoError(product.UpdateVariant(variant.ID, 11000, 0, uuid.Nil))
+	s.Equal(int64(12000), product.GetVariantByID(variant.ID).CompareAtPrice, "still above the new price")
+
+	s.Require().NoError(product.UpdateVariant(variant.ID, 15000, 0, uuid.Nil))
+	s.Equal(int64(15000), product.GetVariantByID(variant.ID).Price)
+	s.Zero(product.GetVariantByID(variant.ID).CompareAtPrice, "reached by the new price")
+
+	err = product.UpdateVariant(variant.ID, 8000, 0, uuid.Nil)
+	s.ErrorIs(err, domain.ErrInvalid, "price can not go down to the sale price")
+	s.Equal(int64(15000), product.GetVariantByID(variant.ID).Price)
+}
+
+func (s *ProductTestSuite) TestProductUpdateVariantComponents() {
+	componentID := uuid.New()
+	testcases := []struct {
+		name        string
+		productType domain.ProductType
+		quantity    int
+		variantID   uuid.UUID
+		components  []domain.BundleComponent
+		err         error
+	}{
+		{
+			name:        "standard product",
+			productType: domain.ProductTypeStandard,
+			components:  []domain.BundleComponent{{VariantID: componentID, Quantity: 1}},
+			err:         domain.ErrInvalid,
+		},
+		{
+			name:        "unknown variant",
+			productType: domain.ProductTypeBundle,
+			variantID:   uuid.New(),
+			components:  []domain.BundleComponent{{VariantID: componentID, Quantity: 1}},
+			err:         domain.ErrNotFound,
+		},
+		{
+			name:        "no components",
+			productType: domain.ProductTypeBundle,
+			err:         domain.ErrInvalid,
+		},
+		{
+			name:        "zero quantity",
+			productType: domain.ProductTypeBundle,
+			components:  []domain.BundleComponent{{VariantID: componentID}},
+			err:         domain.ErrInvalid,
+		},
+		{
+			name:        "duplicate component",
+			productType: domain.ProductTypeBundle,
+			components: []domain.BundleComponent{
+				{VariantID: componentID, Quantity: 1},
+				{VariantID: componentID, Quantity: 2},
+			},
+			err: domain.ErrInvalid,
+		},
+		{
+			name:        "variant holding stock",
+			productType: domain.ProductTypeBundle,
+			quantity:    5,
+			components:  []domain.BundleComponent{{VariantID: componentID, Quantity: 1}},
+			err:         domain.ErrConflict,
+		},
+		{
+			name:        "bundle of components",
+			productType: domain.ProductTypeBundle,
+			components: []domain.BundleComponent{
+				{VariantID: componentID, Quantity: 2},
+				{VariantID: uuid.New(), Quantity: 1},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
+			s.Require().NoError(err)
+			s.Require().NoError(product.UpdateType(tc.productType))
+			variant, err := domain.NewVariant("SKU-BUNDLE", 10000, tc.quantity)
+			s.Require().NoError(err)
+			product.AddVariants(*variant)
+
+			variantID := tc.variantID
+			if variantID == uuid.Nil {
+				variantID = variant.ID
+			}
+			err = product.UpdateVariantComponents(variantID, tc.components)
+			if tc.err != nil {
+				s.ErrorIs(err, tc.err)
+				return
+			}
+			s.Require().NoError(err)
+			updated := product.GetVariantByID(variant.ID)
+			s.True(updated.IsBundle())
+			s.Equal(tc.components, updated.Components)
+			s.True(domain.ValidateProductTypeStructure(product))
+			s.ErrorIs(product.UpdateType(domain.ProductTypeStandard), domain.ErrConflict)
+		})
+	}
+}
+
+func (s *ProductTestSuite) TestProductVariantDeriveBundleStock() {
+	north, south := uuid.New(), uuid.New()
+	shirt := domain.ProductVariant{
+		ID:       uuid.New(),
+		Quantity: 9,
+		Stocks: []domain.WarehouseStock{
+			{WarehouseID: north, Quantity: 4},
+			{WarehouseID: south, Quantity: 5},
+		},
+	}
+	sock := domain.ProductVariant{
+		ID:       uuid.New(),
+		Quantity: 7,
+		Stocks: []domain.WarehouseStock{
+			{WarehouseID: north, Quantity: 7},
+		},
+	}
+	bundle := domain.ProductVariant{
+		ID: uuid.New(),
+		Components: []domain.BundleComponent{
+			{VariantID: shirt.ID, Quantity: 1},
+			{VariantID: sock.ID, Quantity: 2},
+		},
+	}
+
+	bundle.DeriveBundleStock([]domain.ProductVariant{shirt, sock})
+	s.Equal(3, bundle.Quantity)
+	s.Equal([]domain.WarehouseStock{{WarehouseID: north, Quantity: 3}}, bundle.Stocks)
+
+	s.Require().NoError(bundle.DecreaseQuantity(2, north, uuid.New()))
+	s.Equal(2, bundle.PurchaseCount)
+	s.Empty(bundle.StockMovements)
+	s.ErrorIs(bundle.RecordStockMovement(north, domain.StockMovementKindRestock, 1, uuid.Nil, uuid.Nil, ""), domain.ErrInvalid)
+
+	bundle.DeriveBundleStock([]domain.ProductVariant{shirt})
+	s.Equal(0, bundle.Quantity)
+}
+
 func (s *ProductTestSuite) TestProductAddVariantImages() {
 	buildURL := func(id uuid.UUID) string {
 		return "https://example.com/images/" + id.String()
@@ -1274,7 +1522,8 @@ func (s *ProductTestSuite) TestProductVariantDecreaseQuantity() {
 			initialUpdateTime := variant.UpdatedAt
 			time.Sleep(10 * time.Millisecond)
 
-			variant.DecreaseQuantity(tc.decreaseBy)
+			err = variant.DecreaseQuantity(tc.decreaseBy, uuid.Nil, uuid.Nil)
+			s.Require().NoError(err)
 
 			s.Equal(tc.expectedQuantity, variant.Quantity, tc.name)
 			s.Equal(tc.expectedPurchaseCount, variant.PurchaseCount, tc.name)
@@ -1286,6 +1535,221 @@ func (s *ProductTestSuite) TestProductVariantDecreaseQuantity() {
 	}
 }
 
+func (s *ProductTestSuite) TestProductVariantRecordStockMovement() {
+	testcases := []struct {
+		name             string
+		initialQuantity  int
+		kind             domain.StockMovementKind
+		quantity         int
+		expectErr        bool
+		expectedQuantity int
+	}{
+		{
+			name:             "restock",
+			initialQuantity:  10,
+			kind:             domain.StockMovementKindRestock,
+			quantity:         5,
+			expectedQuantity: 15,
+		},
+		{
+			name:             "return",
+			initialQuantity:  0,
+			kind:             domain.StockMovementKindReturn,
+			quantity:         1,
+			expectedQuantity: 1,
+		},
+		{
+			name:             "adjustment down to zero",
+			initialQuantity:  10,
+			kind:             domain.StockMovementKindAdjustment,
+			quantity:         -10,
+			expectedQuantity: 0,
+		},
+		{
+			name:             "adjustment below zero",
+			initialQuantity:  10,
+			kind:             domain.StockMovementKindAdjustment,
+			quantity:         -11,
+			expectErr:        true,
+			expectedQuantity: 10,
+		},
+		{
+			name:             "zero quantity",
+			initialQuantity:  10,
+			kind:             domain.StockMovementKindRestock,
+			quantity:         0,
+			expectErr:        true,
+			expectedQuantity: 10,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			variant, err := domain.NewVariant("SKU-001", 10000, tc.initialQuantity)
+			s.Require().NoError(err)
+			initialMovements := len(variant.StockMovements)
+			actorID := uuid.New()
+
+			err = variant.RecordStockMovement(uuid.Nil, tc.kind, tc.quantity, actorID, uuid.Nil, "note")
+
+			s.Equal(tc.expectedQuantity, variant.Quantity, tc.name)
+			if tc.expectErr {
+				s.ErrorIs(err, domain.ErrInvalid, tc.name)
+				s.Len(variant.StockMovements, initialMovements, tc.name)
+				return
+			}
+			s.Require().NoError(err, tc.name)
+			s.Require().Len(variant.StockMovements, initialMovements+1, tc.name)
+			movement := variant.StockMovements[initialMovements]
+			s.Equal(variant.ID, movement.ProductVariantID, tc.name)
+			s.Equal(tc.kind, movement.Kind, tc.name)
+			s.Equal(tc.quantity, movement.Quantity, tc.name)
+			s.Equal(tc.expectedQuantity, movement.QuantityAfter, tc.name)
+			s.Equal(actorID, movement.ActorID, tc.name)
+		})
+	}
+}
+
+func (s *ProductTestSuite) TestProductVariantStockMovementsSumToQuantity() {
+	product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
+	s.Require().NoError(err)
+	variant, err := domain.NewVariant("SKU-001", 10000, 10)
+	s.Require().NoError(err)
+	product.AddVariants(*variant)
+	variantID := product.Variants[0].ID
+
+	s.Require().NoError(product.UpdateVariant(variantID, 0, 4, uuid.New()))
+	s.Require().NoError(product.Variants[0].DecreaseQuantity(6, uuid.Nil, uuid.New()))
+	s.Require().NoError(product.Variants[0].RecordStockMovement(
+		uuid.Nil,
+		domain.StockMovementKindCancellation,
+		2,
+		uuid.Nil,
+		uuid.New(),
+		"",
+	))
+
+	kinds := make([]domain.StockMovementKind, 0, len(product.Variants[0].StockMovements))
+	sum := 0
+	for _, movement := range product.Variants[0].StockMovements {
+		kinds = append(kinds, movement.Kind)
+		sum += movement.Quantity
+		s.Equal(sum, movement.QuantityAfter)
+	}
+	s.Equal([]domain.StockMovementKind{
+		domain.StockMovementKindRestock,
+		domain.StockMovementKindAdjustment,
+		domain.StockMovementKindSale,
+		domain.StockMovementKindCancellation,
+	}, kinds)
+	s.Equal(product.Variants[0].Quantity, sum)
+	s.Equal(2, sum)
+}
+
+func (s *ProductTestSuite) TestProductVariantWarehouseStocks() {
+	warehouseA := uuid.New()
+	warehouseB := uuid.New()
+	variant, err := domain.NewVariant("SKU-001", 10000, 10)
+	s.Require().NoError(err)
+
+	err = variant.RecordStockMovement(warehouseA, domain.StockMovementKindRestock, 1, uuid.Nil, uuid.Nil, "")
+	s.ErrorIs(err, domain.ErrConflict, "stock not at any warehouse must be assigned first")
+
+	s.Require().NoError(variant.AssignWarehouse(warehouseA))
+	s.Equal([]domain.WarehouseStock{{WarehouseID: warehouseA, Quantity: 10}}, variant.Stocks)
+	s.Equal(warehouseA, variant.StockMovements[0].WarehouseID)
+	s.ErrorIs(variant.AssignWarehouse(warehouseB), domain.ErrConflict)
+
+	s.Require().NoError(variant.RecordStockMovement(warehouseB, domain.StockMovementKindRestock, 4, uuid.Nil, uuid.Nil, ""))
+	s.Equal(14, variant.Quantity)
+	s.Equal(4, variant.GetStockQuantity(warehouseB))
+
+	err = variant.RecordStockMovement(warehouseB, domain.StockMovementKindAdjustment, -5, uuid.Nil, uuid.Nil, "")
+	s.ErrorIs(err, domain.ErrInvalid, "warehouse stock can not go below zero")
+	s.Equal(4, variant.GetStockQuantity(warehouseB))
+
+	s.Require().NoError(variant.DecreaseQuantity(6, warehouseB, uuid.New()))
+	s.Equal(0, variant.GetStockQuantity(warehouseB))
+	s.Equal(8, variant.GetStockQuantity(warehouseA), "the rest is taken from another warehouse")
+	s.Equal(8, variant.Quantity)
+	s.Equal(6, variant.PurchaseCount)
+	sales := variant.StockMovements[len(variant.StockMovements)-2:]
+	s.Equal(warehouseB, sales[0].WarehouseID)
+	s.Equal(-4, sales[0].Quantity)
+	s.Equal(warehouseA, sales[1].WarehouseID)
+	s.Equal(-2, sales[1].Quantity)
+
+	s.Require().NoError(variant.RecordStockMovement(uuid.Nil, domain.StockMovementKindReturn, 1, uuid.Nil, uuid.Nil, ""))
+	s.Equal(9, variant.GetStockQuantity(warehouseA), "defaults to the warehouse holding most")
+
+	sum := 0
+	for _, stock := range variant.Stocks {
+		sum += stock.Quantity
+	}
+	s.Equal(variant.Quantity, sum)
+}
+
+func (s *ProductTestSuite) TestProductUpdateVariantLowStockThreshold() {
+	testcases := []struct {
+		name              string
+		useValidVariantID bool
+		threshold         int
+		expectErr         error
+		expectedThreshold int
+	}{
+		{
+			name:              "set threshold",
+			useValidVariantID: true,
+			threshold:         5,
+			expectedThreshold: 5,
+		},
+		{
+			name:              "disable threshold",
+			useValidVariantID: true,
+			threshold:         0,
+			expectedThreshold: 0,
+		},
+		{
+			name:              "negative threshold",
+			useValidVariantID: true,
+			threshold:         -1,
+			expectErr:         domain.ErrInvalid,
+			expectedThreshold: 3,
+		},
+		{
+			name:              "variant not found",
+			useValidVariantID: false,
+			threshold:         5,
+			expectErr:         domain.ErrNotFound,
+			expectedThreshold: 3,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
+			s.Require().NoError(err)
+			variant, err := domain.NewVariant("SKU-001", 10000, 10)
+			s.Require().NoError(err)
+			variant.LowStockThreshold = 3
+			product.AddVariants(*variant)
+
+			variantID := uuid.New()
+			if tc.useValidVariantID {
+				variantID = product.Variants[0].ID
+			}
+			err = product.UpdateVariantLowStockThreshold(variantID, tc.threshold)
+
+			if tc.expectErr != nil {
+				s.ErrorIs(err, tc.expectErr, tc.name)
+			} else {
+				s.NoError(err, tc.name)
+			}
+			s.Equal(tc.expectedThreshold, product.Variants[0].LowStockThreshold, tc.name)
+		})
+	}
+}
+
 func (s *ProductTestSuite) TestProductAddAttributeIDs() {
 	product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
 	s.Require().NoError(err)
@@ -1452,6 +1916,325 @@ func (s *ProductTestSuite) TestProductImageRemove() {
 	s.True(image.DeletedAt.Before(afterTime) || image.DeletedAt.Equal(afterTime))
 }
 
+// newConfigurableProduct builds a product with a Size option (S, M) and a
+// Color option (Red, Blue) with one variant per combination
+func (s *ProductTestSuite) newConfigurableProduct() *domain.Product {
+	product, err := domain.NewProduct("Test Product", "Test Description", uuid.New())
+	s.Require().NoError(err)
+	size, err := domain.NewProductOption("Size")
+	s.Require().NoError(err)
+	sizeValues, err := domain.CreateOptionValues([]string{"S", "M"})
+	s.Require().NoError(err)
+	size.AddOptionValues(sizeValues...)
+	color, err := domain.NewProductOption("Color")
+	s.Require().NoError(err)
+	colorValues, err := domain.CreateOptionValues([]string{"Red", "Blue"})
+	s.Require().NoError(err)
+	color.AddOptionValues(colorValues...)
+	product.AddOptions(*size, *color)
+	for i, sizeValue := range sizeValues {
+		for j, colorValue := range colorValues {
+			variant, err := domain.NewVariant("SKU-"+sizeValue.Value+"-"+colorValue.Value, int64(10000*(i+1)+j), 10)
+			s.Require().NoError(err)
+			variant.OptionValues = []domain.OptionValue{sizeValue, colorValue}
+			product.AddVariants(*variant)
+		}
+	}
+	product.UpdateMinPrice()
+	s.Require().True(domain.ValidateProductVariantStructure(product))
+	return product
+}
+
+func (s *ProductTestSuite) TestProductRestore() {
+	product := s.newConfigurableProduct()
+	s.ErrorIs(product.Restore(), domain.ErrConflict, "product is not removed")
+
+	image, err := domain.NewProductImage(0, func(id uuid.UUID) string { return id.String() })
+	s.Require().NoError(err)
+	product.AddImages(*image)
+	s.Require().NoError(product.RemoveVariant(product.Variants[0].ID))
+	product.Variants[0].DeletedAt = time.Now().Add(-time.Hour)
+	product.Remove()
+
+	s.Require().NoError(product.Restore())
+	s.Zero(product.DeletedAt)
+	s.NotZero(product.Variants[0].DeletedAt, "variant removed before the product stays removed")
+	s.Len(product.RemainingVariants(), 3)
+	s.Len(product.RemainingOptions(), 2)
+	for _, option := range product.Options {
+		for _, value := range option.Values {
+			s.Zero(value.DeletedAt)
+		}
+	}
+	s.Zero(product.Images[0].DeletedAt)
+	s.Equal(int64(10001), product.Price)
+	s.True(domain.ValidateProductVariantStructure(product))
+	s.Equal([]uuid.UUID{image.ID}, product.ImageIDs())
+}
+
+func (s *ProductTestSuite) TestProductUpdatePublishing() {
+	product := s.newConfigurableProduct()
+	s.Equal(domain.ProductStatusDraft, product.Status)
+	s.False(product.IsPublished())
+
+	now := time.Now()
+	testcases := []struct {
+		name        string
+		status      domain.ProductStatus
+		publishAt   time.Time
+		unpublishAt time.Time
+		err         error
+	}{
+		{name: "unknown status", status: "hidden", err: domain.ErrInvalid},
+		{name: "publish at for published", status: domain.ProductStatusPublished, publishAt: now, err: domain.ErrInvalid},
+		{name: "unpublish at for archived", status: domain.ProductStatusArchived, unpublishAt: now, err: domain.ErrInvalid},
+		{name: "unpublish before publish", status: domain.ProductStatusDraft, publishAt: now, unpublishAt: now.Add(-time.Hour), err: domain.ErrInvalid},
+		{name: "scheduled draft", status: domain.ProductStatusDraft, publishAt: now, unpublishAt: now.Add(time.Hour)},
+		{name: "published", status: domain.ProductStatusPublished, unpublishAt: now},
+		{name: "archived", status: domain.ProductStatusArchived},
+	}
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			err := product.UpdatePublishing(tc.status, tc.publishAt, tc.unpublishAt)
+			if tc.err != nil {
+				s.ErrorIs(err, tc.err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.status, product.Status)
+			s.Equal(tc.publishAt, product.PublishAt)
+			s.Equal(tc.unpublishAt, product.UnpublishAt)
+			s.Equal(tc.status == domain.ProductStatusPublished, product.IsPublished())
+		})
+	}
+}
+
+func (s *ProductTestSuite) TestProductRemoveVariant() {
+	product := s.newConfigurableProduct()
+	cheapest := product.Variants[0]
+
+	s.Require().NoError(product.RemoveVariant(cheapest.ID))
+	s.NotZero(product.Variants[0].DeletedAt)
+	s.Len(product.RemainingVariants(), 3)
+	s.Equal(int64(10001), product.Price, "min price ignores removed variants")
+	s.True(domain.ValidateProductVariantStructure(product))
+
+	s.ErrorIs(product.RemoveVariant(cheapest.ID), domain.ErrNotFound, "already removed")
+	s.ErrorIs(product.RemoveVariant(uuid.New()), domain.ErrNotFound)
+
+	for _, variant := range product.RemainingVariants() {
+		s.Require().NoError(product.RemoveVariant(variant.ID))
+	}
+	s.False(domain.ValidateProductVariantStructure(product), "a product keeps at least one variant")
+}
+
+func (s *ProductTestSuite) TestProductAddOption() {
+	testcases := []struct {
+		name      string
+		optName   string
+		values    []string
+		assign    func(product *domain.Product) map[uuid.UUID]string
+		expectErr error
+	}{
+		{
+			name:    "every variant mapped",
+			optName: "Material",
+			values:  []string{"Cotton", "Linen"},
+			assign: func(product *domain.Product) map[uuid.UUID]string {
+				result := map[uuid.UUID]string{}
+				for _, v := range product.Variants {
+					result[v.ID] = "Cotton"
+				}
+				return result
+			},
+		},
+		{
+			name:    "variant left unmapped",
+			optName: "Material",
+			values:  []string{"Cotton"},
+			assign: func(product *domain.Product) map[uuid.UUID]string {
+				return map[uuid.UUID]string{product.Variants[0].ID: "Cotton"}
+			},
+			expectErr: domain.ErrInvalid,
+		},
+		{
+			name:    "unknown value",
+			optName: "Material",
+			values:  []string{"Cotton"},
+			assign: func(product *domain.Product) map[uuid.UUID]string {
+				result := map[uuid.UUID]string{}
+				for _, v := range product.Variants {
+					result[v.ID] = "Wool"
+				}
+				return result
+			},
+			expectErr: domain.ErrInvalid,
+		},
+		{
+			name:    "duplicated option name",
+			optName: "size",
+			values:  []string{"XL"},
+			assign: func(product *domain.Product) map[uuid.UUID]string {
+				result := map[uuid.UUID]string{}
+				for _, v := range product.Variants {
+					result[v.ID] = "XL"
+				}
+				return result
+			},
+			expectErr: domain.ErrConflict,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			product := s.newConfigurableProduct()
+			option, err := domain.NewProductOption(tc.optName)
+			s.Require().NoError(err)
+			values, err := domain.CreateOptionValues(tc.values)
+			s.Require().NoError(err)
+			option.AddOptionValues(values...)
+
+			err = product.AddOption(*option, tc.assign(product))
+
+			if tc.expectErr != nil {
+				s.ErrorIs(err, tc.expectErr, tc.name)
+				s.Len(product.Options, 2, tc.name)
+				for _, v := range product.Variants {
+					s.Len(v.OptionValues, 2, tc.name)
+				}
+				return
+			}
+			s.Require().NoError(err, tc.name)
+			s.Len(product.Options, 3, tc.name)
+			s.True(domain.ValidateProductVariantStructure(product), tc.name)
+		})
+	}
+}
+
+func (s *ProductTestSuite) TestProductRemoveOption() {
+	s.Run("variants would no longer differ", func() {
+		product := s.newConfigurableProduct()
+		err := product.RemoveOption(product.Options[1].ID)
+		s.ErrorIs(err, domain.ErrConflict)
+		s.Zero(product.Options[1].DeletedAt)
+	})
+
+	s.Run("variants removed first", func() {
+		product := s.newConfigurableProduct()
+		colorID := product.Options[1].ID
+		blueID := product.Options[1].Values[1].ID
+		for _, v := range product.Variants {
+			if v.OptionValues[1].ID == blueID {
+				s.Require().NoError(product.RemoveVariant(v.ID))
+			}
+		}
+
+		s.Require().NoError(product.RemoveOption(colorID))
+
+		s.NotZero(product.Options[1].DeletedAt)
+		for _, value := range product.Options[1].Values {
+			s.NotZero(value.DeletedAt)
+		}
+		for _, v := range product.Variants {
+			if v.DeletedAt.IsZero() {
+				s.Len(v.OptionValues, 1, "removed option is unmapped from remaining variants")
+			} else {
+				s.Len(v.OptionValues, 2, "removed variants keep their values")
+			}
+		}
+		s.True(domain.ValidateProductVariantStructure(product))
+		s.ErrorIs(product.RemoveOption(colorID), domain.ErrNotFound)
+	})
+}
+
+func (s *ProductTestSuite) TestProductAddRemoveOptionValue() {
+	product := s.newConfigurableProduct()
+	size := product.Options[0]
+	smallID := size.Values[0].ID
+
+	large, err := domain.CreateOptionValues([]string{"L"})
+	s.Require().NoError(err)
+	s.Require().NoError(product.AddOptionValues(size.ID, large...))
+	s.Len(product.Options[0].Values, 3)
+	s.True(domain.ValidateProductVariantStructure(product), "unused values are allowed")
+
+	duplicated, err := domain.CreateOptionValues([]string{"M"})
+	s.Require().NoError(err)
+	s.ErrorIs(product.AddOptionValues(size.ID, duplicated...), domain.ErrConflict)
+	s.ErrorIs(product.AddOptionValues(uuid.New(), duplicated...), domain.ErrNotFound)
+
+	s.ErrorIs(product.RemoveOptionValue(size.ID, smallID), domain.ErrConflict, "value still used by variants")
+	for _, v := range product.Variants {
+		if v.OptionValues[0].ID == smallID {
+			s.Require().NoError(product.RemoveVariant(v.ID))
+		}
+	}
+	s.Require().NoError(product.RemoveOptionValue(size.ID, smallID))
+	s.NotZero(product.Options[0].Values[0].DeletedAt)
+	s.True(domain.ValidateProductVariantStructure(product))
+	s.ErrorIs(product.RemoveOptionValue(size.ID, smallID), domain.ErrNotFound)
+}
+
+func (s *ProductTestSuite) TestProductEvents() {
+	product := s.newConfigurableProduct()
+	s.Require().Len(product.Events, 1)
+	s.Equal(domain.EventTypeProductCreated, product.Events[0].Type)
+	product.Update("Renamed Product", "", uuid.Nil)
+	s.Len(product.Events, 1, "created already covers the update")
+
+	product.Events = nil
+	product.Update("Another Name", "", uuid.Nil)
+	s.Require().NoError(product.UpdatePublishing(domain.ProductStatusPublished, time.Time{}, time.Time{}))
+	s.Require().Len(product.Events, 1, "mutators record the update once per save")
+	s.Equal(domain.EventTypeProductUpdated, product.Events[0].Type)
+	s.Equal(domain.ProductEventPayload{
+		ProductID: product.ID,
+		Status:    domain.ProductStatusPublished,
+	}, product.Events[0].Payload, "carries the status after the last mutator")
+
+	product.Events = nil
+	product.Update("Another Name", "", uuid.Nil)
+	s.Empty(product.Events, "nothing changed")
+
+	product.Remove()
+	product.Update("Removed Product", "", uuid.Nil)
+	s.Require().Len(product.Events, 1)
+	s.Equal(domain.EventTypeProductDeleted, product.Events[0].Type)
+}
+
+func (s *ProductTestSuite) TestProductPendingEvents() {
+	product := s.newConfigurableProduct()
+	for i := range product.Variants {
+		product.Variants[i].StockMovements = nil
+	}
+	product.Events = nil
+	s.Empty(product.PendingEvents())
+
+	variant := product.Variants[0]
+	movement, err := product.RecordStockMovement(
+		variant.ID,
+		uuid.Nil,
+		domain.StockMovementKindAdjustment,
+		-3,
+		uuid.New(),
+		uuid.Nil,
+		"",
+	)
+	s.Require().NoError(err)
+	events := product.PendingEvents()
+	s.Require().Len(events, 1)
+	s.Equal(movement.ID, events[0].ID, "the movement identifies its event")
+	s.Equal(domain.EventTypeStockChanged, events[0].Type)
+	s.Equal(domain.StockChangedEventPayload{
+		ProductID:        product.ID,
+		ProductVariantID: variant.ID,
+		Kind:             domain.StockMovementKindAdjustment,
+		Quantity:         -3,
+		QuantityAfter:    7,
+	}, events[0].Payload)
+	s.Empty(product.Events, "pending events are not recorded twice")
+}
+
 func TestProduct(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(ProductTestSuite))
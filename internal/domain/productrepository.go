@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,25 +23,126 @@ type ProductRepository interface {
 		params ProductRepositoryGetParam,
 	) (*Product, error)
 
+	ListSuggestions(
+		ctx context.Context,
+		params ProductRepositoryListSuggestionsParam,
+	) (*[]ProductSuggestion, error)
+
 	Save(
 		ctx context.Context,
 		params ProductRepositorySaveParam,
 	) error
+
+	IncreaseViewsCounts(
+		ctx context.Context,
+		params ProductRepositoryIncreaseViewsCountsParam,
+	) error
+
+	UpdateTrendingScores(
+		ctx context.Context,
+		params ProductRepositoryUpdateTrendingScoresParam,
+	) error
+
+	// ApplyPublishSchedules publishes and unpublishes the products whose
+	// schedule is due, records ProductUpdated for them and returns their IDs
+	ApplyPublishSchedules(
+		ctx context.Context,
+		params ProductRepositoryApplyPublishSchedulesParam,
+	) (*[]uuid.UUID, error)
+
+	// SyncPrices refreshes the price and the discount of the products whose
+	// sale started or ended, records ProductUpdated for them and returns
+	// their IDs
+	SyncPrices(
+		ctx context.Context,
+		params ProductRepositorySyncPricesParam,
+	) (*[]uuid.UUID, error)
+
+	ListRecommendations(
+		ctx context.Context,
+		params ProductRepositoryListRecommendationsParam,
+	) (*[]Product, error)
+
+	RefreshRecommendations(
+		ctx context.Context,
+		params ProductRepositoryRefreshRecommendationsParam,
+	) error
+
+	ListStockMovements(
+		ctx context.Context,
+		params ProductRepositoryListStockMovementsParam,
+	) (*[]StockMovement, error)
+
+	CountStockMovements(
+		ctx context.Context,
+		params ProductRepositoryCountStockMovementsParam,
+	) (*int, error)
+
+	// ReconcileQuantities sets the quantity of variants and of their warehouse
+	// stocks to the sum of their stock movements and returns the number of
+	// variants which drifted
+	ReconcileQuantities(
+		ctx context.Context,
+	) (*int, error)
+
+	SaveStockSubscription(
+		ctx context.Context,
+		params ProductRepositorySaveStockSubscriptionParam,
+	) error
+
+	DeleteStockSubscription(
+		ctx context.Context,
+		params ProductRepositoryDeleteStockSubscriptionParam,
+	) error
+
+	CountOrderItems(
+		ctx context.Context,
+		params ProductRepositoryCountOrderItemsParam,
+	) (*int, error)
+
+	// Purge permanently deletes a removed product with every row referencing
+	// it, products with order history are never purged
+	Purge(
+		ctx context.Context,
+		params ProductRepositoryPurgeParam,
+	) error
+
+	// ListImageIDsInUse keeps the given images which a product shows or gets
+	// back when it is restored, images removed on their own are not in use
+	ListImageIDsInUse(
+		ctx context.Context,
+		params ProductRepositoryListImageIDsInUseParam,
+	) (*[]uuid.UUID, error)
 }
 
 type ProductRepositoryListParam struct {
-	IDs         []uuid.UUID
-	Search      string
-	MinPrice    int64
-	MaxPrice    int64
-	Rating      float64
-	VariantIDs  []uuid.UUID
-	CategoryIDs []uuid.UUID
-	Deleted     DeletedParam
-	SortRating  string
-	SortPrice   string
-	Limit       int
-	Offset      int
+	IDs          []uuid.UUID
+	Search       string
+	MinPrice     int64
+	MaxPrice     int64
+	Rating       float64
+	VariantIDs   []uuid.UUID
+	CategoryIDs  []uuid.UUID
+	Deleted      DeletedParam
+	Statuses     []ProductStatus
+	OnSale       bool
+	SortTrending bool
+	SortRating   string
+	SortPrice    string
+	SortDiscount string
+	After        *ProductRepositoryListCursor
+	Limit        int
+	Offset       int
+}
+
+// ProductRepositoryListCursor is the last product of the previous page, only
+// the field of the requested sort is compared besides ID
+type ProductRepositoryListCursor struct {
+	ID            uuid.UUID
+	TrendingScore float64
+	Rating        float64
+	Price         int64
+	Discount      int
 }
 
 type ProductRepositoryCountParam struct {
@@ -52,12 +154,90 @@ type ProductRepositoryCountParam struct {
 	VariantIDs  []uuid.UUID
 	CategoryIDs []uuid.UUID
 	Deleted     DeletedParam
+	Statuses    []ProductStatus
+	OnSale      bool
+}
+
+type ProductRepositoryListSuggestionsParam struct {
+	Search string
+	Limit  int
 }
 
 type ProductRepositoryGetParam struct {
 	ProductID uuid.UUID
+	// Deleted other than exclude also loads the removed options, values and
+	// variants of the product
+	Deleted DeletedParam
 }
 
 type ProductRepositorySaveParam struct {
 	Product Product
 }
+
+type ProductRepositoryIncreaseViewsCountsParam struct {
+	ViewsCounts map[uuid.UUID]int
+}
+
+type ProductRepositoryUpdateTrendingScoresParam struct {
+	Window         time.Duration
+	HalfLife       time.Duration
+	ViewWeight     float64
+	PurchaseWeight float64
+}
+
+type ProductRepositoryApplyPublishSchedulesParam struct {
+	Now time.Time
+}
+
+type ProductRepositorySyncPricesParam struct {
+	Now time.Time
+}
+
+type ProductRepositoryListRecommendationsParam struct {
+	ProductID uuid.UUID
+	Kind      ProductRecommendationKind
+	Limit     int
+}
+
+type ProductRepositoryRefreshRecommendationsParam struct {
+	// Limit is the number of recommendations kept per product and kind
+	Limit                int
+	CategoryWeight       float64
+	AttributeValueWeight float64
+	MinOrders            int
+}
+
+type ProductRepositoryListStockMovementsParam struct {
+	ProductVariantID uuid.UUID
+	OrderID          uuid.UUID
+	Kinds            []StockMovementKind
+	Limit            int
+	Offset           int
+}
+
+type ProductRepositoryCountStockMovementsParam struct {
+	ProductVariantID uuid.UUID
+	Kinds            []StockMovementKind
+}
+
+type ProductRepositorySaveStockSubscriptionParam struct {
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+}
+
+type ProductRepositoryDeleteStockSubscriptionParam struct {
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+}
+
+type ProductRepositoryCountOrderItemsParam struct {
+	ProductID uuid.UUID
+}
+
+type ProductRepositoryPurgeParam struct {
+	ProductID uuid.UUID
+}
+
+type ProductRepositoryListImageIDsInUseParam struct {
+	ImageIDs []uuid.UUID
+}
@@ -7,6 +7,7 @@ package domain
 import (
 	"context"
 
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,6 +38,74 @@ func (_m *MockProductRepository) EXPECT() *MockProductRepository_Expecter {
 	return &MockProductRepository_Expecter{mock: &_m.Mock}
 }
 
+// ApplyPublishSchedules provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ApplyPublishSchedules(ctx context.Context, params ProductRepositoryApplyPublishSchedulesParam) (*[]uuid.UUID, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyPublishSchedules")
+	}
+
+	var r0 *[]uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryApplyPublishSchedulesParam) (*[]uuid.UUID, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryApplyPublishSchedulesParam) *[]uuid.UUID); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryApplyPublishSchedulesParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ApplyPublishSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyPublishSchedules'
+type MockProductRepository_ApplyPublishSchedules_Call struct {
+	*mock.Call
+}
+
+// ApplyPublishSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryApplyPublishSchedulesParam
+func (_e *MockProductRepository_Expecter) ApplyPublishSchedules(ctx interface{}, params interface{}) *MockProductRepository_ApplyPublishSchedules_Call {
+	return &MockProductRepository_ApplyPublishSchedules_Call{Call: _e.mock.On("ApplyPublishSchedules", ctx, params)}
+}
+
+func (_c *MockProductRepository_ApplyPublishSchedules_Call) Run(run func(ctx context.Context, params ProductRepositoryApplyPublishSchedulesParam)) *MockProductRepository_ApplyPublishSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryApplyPublishSchedulesParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryApplyPublishSchedulesParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ApplyPublishSchedules_Call) Return(uuids *[]uuid.UUID, err error) *MockProductRepository_ApplyPublishSchedules_Call {
+	_c.Call.Return(uuids, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ApplyPublishSchedules_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryApplyPublishSchedulesParam) (*[]uuid.UUID, error)) *MockProductRepository_ApplyPublishSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function for the type MockProductRepository
 func (_mock *MockProductRepository) Count(ctx context.Context, params ProductRepositoryCountParam) (*int, error) {
 	ret := _mock.Called(ctx, params)
@@ -105,6 +174,199 @@ func (_c *MockProductRepository_Count_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// CountOrderItems provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) CountOrderItems(ctx context.Context, params ProductRepositoryCountOrderItemsParam) (*int, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOrderItems")
+	}
+
+	var r0 *int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryCountOrderItemsParam) (*int, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryCountOrderItemsParam) *int); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryCountOrderItemsParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_CountOrderItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrderItems'
+type MockProductRepository_CountOrderItems_Call struct {
+	*mock.Call
+}
+
+// CountOrderItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryCountOrderItemsParam
+func (_e *MockProductRepository_Expecter) CountOrderItems(ctx interface{}, params interface{}) *MockProductRepository_CountOrderItems_Call {
+	return &MockProductRepository_CountOrderItems_Call{Call: _e.mock.On("CountOrderItems", ctx, params)}
+}
+
+func (_c *MockProductRepository_CountOrderItems_Call) Run(run func(ctx context.Context, params ProductRepositoryCountOrderItemsParam)) *MockProductRepository_CountOrderItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryCountOrderItemsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryCountOrderItemsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_CountOrderItems_Call) Return(n *int, err error) *MockProductRepository_CountOrderItems_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProductRepository_CountOrderItems_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryCountOrderItemsParam) (*int, error)) *MockProductRepository_CountOrderItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountStockMovements provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) CountStockMovements(ctx context.Context, params ProductRepositoryCountStockMovementsParam) (*int, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStockMovements")
+	}
+
+	var r0 *int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryCountStockMovementsParam) (*int, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryCountStockMovementsParam) *int); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryCountStockMovementsParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_CountStockMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountStockMovements'
+type MockProductRepository_CountStockMovements_Call struct {
+	*mock.Call
+}
+
+// CountStockMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryCountStockMovementsParam
+func (_e *MockProductRepository_Expecter) CountStockMovements(ctx interface{}, params interface{}) *MockProductRepository_CountStockMovements_Call {
+	return &MockProductRepository_CountStockMovements_Call{Call: _e.mock.On("CountStockMovements", ctx, params)}
+}
+
+func (_c *MockProductRepository_CountStockMovements_Call) Run(run func(ctx context.Context, params ProductRepositoryCountStockMovementsParam)) *MockProductRepository_CountStockMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryCountStockMovementsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryCountStockMovementsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_CountStockMovements_Call) Return(n *int, err error) *MockProductRepository_CountStockMovements_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProductRepository_CountStockMovements_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryCountStockMovementsParam) (*int, error)) *MockProductRepository_CountStockMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStockSubscription provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) DeleteStockSubscription(ctx context.Context, params ProductRepositoryDeleteStockSubscriptionParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStockSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryDeleteStockSubscriptionParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_DeleteStockSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStockSubscription'
+type MockProductRepository_DeleteStockSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteStockSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryDeleteStockSubscriptionParam
+func (_e *MockProductRepository_Expecter) DeleteStockSubscription(ctx interface{}, params interface{}) *MockProductRepository_DeleteStockSubscription_Call {
+	return &MockProductRepository_DeleteStockSubscription_Call{Call: _e.mock.On("DeleteStockSubscription", ctx, params)}
+}
+
+func (_c *MockProductRepository_DeleteStockSubscription_Call) Run(run func(ctx context.Context, params ProductRepositoryDeleteStockSubscriptionParam)) *MockProductRepository_DeleteStockSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryDeleteStockSubscriptionParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryDeleteStockSubscriptionParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_DeleteStockSubscription_Call) Return(err error) *MockProductRepository_DeleteStockSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_DeleteStockSubscription_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryDeleteStockSubscriptionParam) error) *MockProductRepository_DeleteStockSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type MockProductRepository
 func (_mock *MockProductRepository) Get(ctx context.Context, params ProductRepositoryGetParam) (*Product, error) {
 	ret := _mock.Called(ctx, params)
@@ -173,6 +435,63 @@ func (_c *MockProductRepository_Get_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// IncreaseViewsCounts provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) IncreaseViewsCounts(ctx context.Context, params ProductRepositoryIncreaseViewsCountsParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseViewsCounts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryIncreaseViewsCountsParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_IncreaseViewsCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncreaseViewsCounts'
+type MockProductRepository_IncreaseViewsCounts_Call struct {
+	*mock.Call
+}
+
+// IncreaseViewsCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryIncreaseViewsCountsParam
+func (_e *MockProductRepository_Expecter) IncreaseViewsCounts(ctx interface{}, params interface{}) *MockProductRepository_IncreaseViewsCounts_Call {
+	return &MockProductRepository_IncreaseViewsCounts_Call{Call: _e.mock.On("IncreaseViewsCounts", ctx, params)}
+}
+
+func (_c *MockProductRepository_IncreaseViewsCounts_Call) Run(run func(ctx context.Context, params ProductRepositoryIncreaseViewsCountsParam)) *MockProductRepository_IncreaseViewsCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryIncreaseViewsCountsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryIncreaseViewsCountsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_IncreaseViewsCounts_Call) Return(err error) *MockProductRepository_IncreaseViewsCounts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_IncreaseViewsCounts_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryIncreaseViewsCountsParam) error) *MockProductRepository_IncreaseViewsCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type MockProductRepository
 func (_mock *MockProductRepository) List(ctx context.Context, params ProductRepositoryListParam) (*[]Product, error) {
 	ret := _mock.Called(ctx, params)
@@ -241,32 +560,480 @@ func (_c *MockProductRepository_List_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// Save provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) Save(ctx context.Context, params ProductRepositorySaveParam) error {
+// ListImageIDsInUse provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ListImageIDsInUse(ctx context.Context, params ProductRepositoryListImageIDsInUseParam) (*[]uuid.UUID, error) {
 	ret := _mock.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for ListImageIDsInUse")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositorySaveParam) error); ok {
+	var r0 *[]uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListImageIDsInUseParam) (*[]uuid.UUID, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListImageIDsInUseParam) *[]uuid.UUID); ok {
 		r0 = returnFunc(ctx, params)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]uuid.UUID)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryListImageIDsInUseParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockProductRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type MockProductRepository_Save_Call struct {
+// MockProductRepository_ListImageIDsInUse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListImageIDsInUse'
+type MockProductRepository_ListImageIDsInUse_Call struct {
 	*mock.Call
 }
 
-// Save is a helper method to define mock.On call
+// ListImageIDsInUse is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params ProductRepositorySaveParam
-func (_e *MockProductRepository_Expecter) Save(ctx interface{}, params interface{}) *MockProductRepository_Save_Call {
+//   - params ProductRepositoryListImageIDsInUseParam
+func (_e *MockProductRepository_Expecter) ListImageIDsInUse(ctx interface{}, params interface{}) *MockProductRepository_ListImageIDsInUse_Call {
+	return &MockProductRepository_ListImageIDsInUse_Call{Call: _e.mock.On("ListImageIDsInUse", ctx, params)}
+}
+
+func (_c *MockProductRepository_ListImageIDsInUse_Call) Run(run func(ctx context.Context, params ProductRepositoryListImageIDsInUseParam)) *MockProductRepository_ListImageIDsInUse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryListImageIDsInUseParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryListImageIDsInUseParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ListImageIDsInUse_Call) Return(uuids *[]uuid.UUID, err error) *MockProductRepository_ListImageIDsInUse_Call {
+	_c.Call.Return(uuids, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ListImageIDsInUse_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryListImageIDsInUseParam) (*[]uuid.UUID, error)) *MockProductRepository_ListImageIDsInUse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecommendations provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ListRecommendations(ctx context.Context, params ProductRepositoryListRecommendationsParam) (*[]Product, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecommendations")
+	}
+
+	var r0 *[]Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListRecommendationsParam) (*[]Product, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListRecommendationsParam) *[]Product); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryListRecommendationsParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ListRecommendations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecommendations'
+type MockProductRepository_ListRecommendations_Call struct {
+	*mock.Call
+}
+
+// ListRecommendations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryListRecommendationsParam
+func (_e *MockProductRepository_Expecter) ListRecommendations(ctx interface{}, params interface{}) *MockProductRepository_ListRecommendations_Call {
+	return &MockProductRepository_ListRecommendations_Call{Call: _e.mock.On("ListRecommendations", ctx, params)}
+}
+
+func (_c *MockProductRepository_ListRecommendations_Call) Run(run func(ctx context.Context, params ProductRepositoryListRecommendationsParam)) *MockProductRepository_ListRecommendations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryListRecommendationsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryListRecommendationsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ListRecommendations_Call) Return(products *[]Product, err error) *MockProductRepository_ListRecommendations_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ListRecommendations_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryListRecommendationsParam) (*[]Product, error)) *MockProductRepository_ListRecommendations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStockMovements provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ListStockMovements(ctx context.Context, params ProductRepositoryListStockMovementsParam) (*[]StockMovement, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockMovements")
+	}
+
+	var r0 *[]StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListStockMovementsParam) (*[]StockMovement, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListStockMovementsParam) *[]StockMovement); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryListStockMovementsParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ListStockMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStockMovements'
+type MockProductRepository_ListStockMovements_Call struct {
+	*mock.Call
+}
+
+// ListStockMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryListStockMovementsParam
+func (_e *MockProductRepository_Expecter) ListStockMovements(ctx interface{}, params interface{}) *MockProductRepository_ListStockMovements_Call {
+	return &MockProductRepository_ListStockMovements_Call{Call: _e.mock.On("ListStockMovements", ctx, params)}
+}
+
+func (_c *MockProductRepository_ListStockMovements_Call) Run(run func(ctx context.Context, params ProductRepositoryListStockMovementsParam)) *MockProductRepository_ListStockMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryListStockMovementsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryListStockMovementsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ListStockMovements_Call) Return(stockMovements *[]StockMovement, err error) *MockProductRepository_ListStockMovements_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ListStockMovements_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryListStockMovementsParam) (*[]StockMovement, error)) *MockProductRepository_ListStockMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuggestions provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ListSuggestions(ctx context.Context, params ProductRepositoryListSuggestionsParam) (*[]ProductSuggestion, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuggestions")
+	}
+
+	var r0 *[]ProductSuggestion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListSuggestionsParam) (*[]ProductSuggestion, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryListSuggestionsParam) *[]ProductSuggestion); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]ProductSuggestion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositoryListSuggestionsParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ListSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuggestions'
+type MockProductRepository_ListSuggestions_Call struct {
+	*mock.Call
+}
+
+// ListSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryListSuggestionsParam
+func (_e *MockProductRepository_Expecter) ListSuggestions(ctx interface{}, params interface{}) *MockProductRepository_ListSuggestions_Call {
+	return &MockProductRepository_ListSuggestions_Call{Call: _e.mock.On("ListSuggestions", ctx, params)}
+}
+
+func (_c *MockProductRepository_ListSuggestions_Call) Run(run func(ctx context.Context, params ProductRepositoryListSuggestionsParam)) *MockProductRepository_ListSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryListSuggestionsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryListSuggestionsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ListSuggestions_Call) Return(productSuggestions *[]ProductSuggestion, err error) *MockProductRepository_ListSuggestions_Call {
+	_c.Call.Return(productSuggestions, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ListSuggestions_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryListSuggestionsParam) (*[]ProductSuggestion, error)) *MockProductRepository_ListSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) Purge(ctx context.Context, params ProductRepositoryPurgeParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryPurgeParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockProductRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryPurgeParam
+func (_e *MockProductRepository_Expecter) Purge(ctx interface{}, params interface{}) *MockProductRepository_Purge_Call {
+	return &MockProductRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, params)}
+}
+
+func (_c *MockProductRepository_Purge_Call) Run(run func(ctx context.Context, params ProductRepositoryPurgeParam)) *MockProductRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryPurgeParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryPurgeParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_Purge_Call) Return(err error) *MockProductRepository_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_Purge_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryPurgeParam) error) *MockProductRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconcileQuantities provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) ReconcileQuantities(ctx context.Context) (*int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileQuantities")
+	}
+
+	var r0 *int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_ReconcileQuantities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileQuantities'
+type MockProductRepository_ReconcileQuantities_Call struct {
+	*mock.Call
+}
+
+// ReconcileQuantities is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProductRepository_Expecter) ReconcileQuantities(ctx interface{}) *MockProductRepository_ReconcileQuantities_Call {
+	return &MockProductRepository_ReconcileQuantities_Call{Call: _e.mock.On("ReconcileQuantities", ctx)}
+}
+
+func (_c *MockProductRepository_ReconcileQuantities_Call) Run(run func(ctx context.Context)) *MockProductRepository_ReconcileQuantities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_ReconcileQuantities_Call) Return(n *int, err error) *MockProductRepository_ReconcileQuantities_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProductRepository_ReconcileQuantities_Call) RunAndReturn(run func(ctx context.Context) (*int, error)) *MockProductRepository_ReconcileQuantities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshRecommendations provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) RefreshRecommendations(ctx context.Context, params ProductRepositoryRefreshRecommendationsParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshRecommendations")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryRefreshRecommendationsParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_RefreshRecommendations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshRecommendations'
+type MockProductRepository_RefreshRecommendations_Call struct {
+	*mock.Call
+}
+
+// RefreshRecommendations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryRefreshRecommendationsParam
+func (_e *MockProductRepository_Expecter) RefreshRecommendations(ctx interface{}, params interface{}) *MockProductRepository_RefreshRecommendations_Call {
+	return &MockProductRepository_RefreshRecommendations_Call{Call: _e.mock.On("RefreshRecommendations", ctx, params)}
+}
+
+func (_c *MockProductRepository_RefreshRecommendations_Call) Run(run func(ctx context.Context, params ProductRepositoryRefreshRecommendationsParam)) *MockProductRepository_RefreshRecommendations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryRefreshRecommendationsParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryRefreshRecommendationsParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_RefreshRecommendations_Call) Return(err error) *MockProductRepository_RefreshRecommendations_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_RefreshRecommendations_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryRefreshRecommendationsParam) error) *MockProductRepository_RefreshRecommendations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) Save(ctx context.Context, params ProductRepositorySaveParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositorySaveParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockProductRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositorySaveParam
+func (_e *MockProductRepository_Expecter) Save(ctx interface{}, params interface{}) *MockProductRepository_Save_Call {
 	return &MockProductRepository_Save_Call{Call: _e.mock.On("Save", ctx, params)}
 }
 
@@ -297,3 +1064,185 @@ func (_c *MockProductRepository_Save_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// SaveStockSubscription provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) SaveStockSubscription(ctx context.Context, params ProductRepositorySaveStockSubscriptionParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveStockSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositorySaveStockSubscriptionParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_SaveStockSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveStockSubscription'
+type MockProductRepository_SaveStockSubscription_Call struct {
+	*mock.Call
+}
+
+// SaveStockSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositorySaveStockSubscriptionParam
+func (_e *MockProductRepository_Expecter) SaveStockSubscription(ctx interface{}, params interface{}) *MockProductRepository_SaveStockSubscription_Call {
+	return &MockProductRepository_SaveStockSubscription_Call{Call: _e.mock.On("SaveStockSubscription", ctx, params)}
+}
+
+func (_c *MockProductRepository_SaveStockSubscription_Call) Run(run func(ctx context.Context, params ProductRepositorySaveStockSubscriptionParam)) *MockProductRepository_SaveStockSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositorySaveStockSubscriptionParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositorySaveStockSubscriptionParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_SaveStockSubscription_Call) Return(err error) *MockProductRepository_SaveStockSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_SaveStockSubscription_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositorySaveStockSubscriptionParam) error) *MockProductRepository_SaveStockSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncPrices provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) SyncPrices(ctx context.Context, params ProductRepositorySyncPricesParam) (*[]uuid.UUID, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncPrices")
+	}
+
+	var r0 *[]uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositorySyncPricesParam) (*[]uuid.UUID, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositorySyncPricesParam) *[]uuid.UUID); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ProductRepositorySyncPricesParam) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepository_SyncPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncPrices'
+type MockProductRepository_SyncPrices_Call struct {
+	*mock.Call
+}
+
+// SyncPrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositorySyncPricesParam
+func (_e *MockProductRepository_Expecter) SyncPrices(ctx interface{}, params interface{}) *MockProductRepository_SyncPrices_Call {
+	return &MockProductRepository_SyncPrices_Call{Call: _e.mock.On("SyncPrices", ctx, params)}
+}
+
+func (_c *MockProductRepository_SyncPrices_Call) Run(run func(ctx context.Context, params ProductRepositorySyncPricesParam)) *MockProductRepository_SyncPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositorySyncPricesParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositorySyncPricesParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_SyncPrices_Call) Return(uuids *[]uuid.UUID, err error) *MockProductRepository_SyncPrices_Call {
+	_c.Call.Return(uuids, err)
+	return _c
+}
+
+func (_c *MockProductRepository_SyncPrices_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositorySyncPricesParam) (*[]uuid.UUID, error)) *MockProductRepository_SyncPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTrendingScores provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) UpdateTrendingScores(ctx context.Context, params ProductRepositoryUpdateTrendingScoresParam) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTrendingScores")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ProductRepositoryUpdateTrendingScoresParam) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_UpdateTrendingScores_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTrendingScores'
+type MockProductRepository_UpdateTrendingScores_Call struct {
+	*mock.Call
+}
+
+// UpdateTrendingScores is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ProductRepositoryUpdateTrendingScoresParam
+func (_e *MockProductRepository_Expecter) UpdateTrendingScores(ctx interface{}, params interface{}) *MockProductRepository_UpdateTrendingScores_Call {
+	return &MockProductRepository_UpdateTrendingScores_Call{Call: _e.mock.On("UpdateTrendingScores", ctx, params)}
+}
+
+func (_c *MockProductRepository_UpdateTrendingScores_Call) Run(run func(ctx context.Context, params ProductRepositoryUpdateTrendingScoresParam)) *MockProductRepository_UpdateTrendingScores_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ProductRepositoryUpdateTrendingScoresParam
+		if args[1] != nil {
+			arg1 = args[1].(ProductRepositoryUpdateTrendingScoresParam)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_UpdateTrendingScores_Call) Return(err error) *MockProductRepository_UpdateTrendingScores_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_UpdateTrendingScores_Call) RunAndReturn(run func(ctx context.Context, params ProductRepositoryUpdateTrendingScoresParam) error) *MockProductRepository_UpdateTrendingScores_Call {
+	_c.Call.Return(run)
+	return _c
+}
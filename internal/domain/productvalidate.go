@@ -1,13 +1,20 @@
 package domain
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 func RegisterProductValidates(v *validator.Validate) error {
 	if err := v.RegisterValidation("productVariantStructure", productVariantStructureValidate); err != nil {
 		return err
 	}
+	if err := v.RegisterValidation("productTypeStructure", productTypeStructureValidate); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -19,20 +26,79 @@ func productVariantStructureValidate(fl validator.FieldLevel) bool {
 	return ValidateProductVariantStructure(&product)
 }
 
+// ValidateProductVariantStructure checks that every remaining variant maps
+// exactly one value of every remaining option, removed options, values and
+// variants are ignored
 func ValidateProductVariantStructure(product *Product) bool {
-	if len(product.Options) == 0 { // 1, 2
-		if len(product.Variants) != 1 { // 3, 4
+	options := product.RemainingOptions()
+	variants := product.RemainingVariants()
+	if len(options) == 0 { // 1, 2
+		if len(variants) != 1 { // 3, 4
 			return false // 5
 		}
-		if len(product.Variants[0].OptionValues) != 0 { // 6, 7
+		if len(variants[0].OptionValues) != 0 { // 6, 7
 			return false // 8
 		}
 		return true // 9
 	}
-	for _, variant := range product.Variants { // 10
-		if len(variant.OptionValues) != len(product.Options) { // 11, 12
+	if len(variants) == 0 {
+		return false
+	}
+	valueIDOptionIDMap := make(map[uuid.UUID]uuid.UUID)
+	removedValueIDs := make(map[uuid.UUID]struct{})
+	for _, option := range product.Options {
+		for _, value := range option.Values {
+			if !option.DeletedAt.IsZero() || !value.DeletedAt.IsZero() {
+				removedValueIDs[value.ID] = struct{}{}
+				continue
+			}
+			valueIDOptionIDMap[value.ID] = option.ID
+		}
+	}
+	combinations := make(map[string]struct{}, len(variants))
+	for _, variant := range variants { // 10
+		if len(variant.OptionValues) != len(options) { // 11, 12
 			return false // 13
 		}
+		mappedOptionIDs := make(map[uuid.UUID]struct{}, len(options))
+		valueIDs := make([]string, 0, len(variant.OptionValues))
+		for _, value := range variant.OptionValues {
+			if _, removed := removedValueIDs[value.ID]; removed {
+				return false
+			}
+			if optionID, ok := valueIDOptionIDMap[value.ID]; ok {
+				if _, mapped := mappedOptionIDs[optionID]; mapped {
+					return false
+				}
+				mappedOptionIDs[optionID] = struct{}{}
+			}
+			valueIDs = append(valueIDs, value.ID.String())
+		}
+		slices.Sort(valueIDs)
+		combination := strings.Join(valueIDs, ",")
+		if _, exists := combinations[combination]; exists {
+			return false
+		}
+		combinations[combination] = struct{}{}
 	}
 	return true // 14
 }
+
+func productTypeStructureValidate(fl validator.FieldLevel) bool {
+	product, ok := fl.Parent().Interface().(Product)
+	if !ok {
+		return true
+	}
+	return ValidateProductTypeStructure(&product)
+}
+
+// ValidateProductTypeStructure checks that every remaining variant of a bundle
+// has components and none of a standard product has
+func ValidateProductTypeStructure(product *Product) bool {
+	for _, variant := range product.RemainingVariants() {
+		if variant.IsBundle() != product.IsBundle() {
+			return false
+		}
+	}
+	return true
+}
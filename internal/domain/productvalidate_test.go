@@ -3,6 +3,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"backend/internal/domain"
 
@@ -119,3 +120,70 @@ func TestValidateProductVariantStructure_WithOptions_NoOptionValues(t *testing.T
 	ok := domain.ValidateProductVariantStructure(product)
 	assert.False(t, ok)
 }
+
+func TestValidateProductVariantStructure_WithOptions_RemovedEntitiesIgnored(t *testing.T) {
+	t.Parallel()
+	red := domain.OptionValue{ID: uuid.New(), Value: "Red"}
+	blue := domain.OptionValue{ID: uuid.New(), Value: "Blue", DeletedAt: time.Now()}
+	small := domain.OptionValue{ID: uuid.New(), Value: "Small"}
+	product := &domain.Product{
+		Options: []domain.Option{
+			{ID: uuid.New(), Name: "Color", Values: []domain.OptionValue{red, blue}},
+			{ID: uuid.New(), Name: "Size", Values: []domain.OptionValue{small}, DeletedAt: time.Now()},
+		},
+		Variants: []domain.ProductVariant{
+			{ID: uuid.New(), OptionValues: []domain.OptionValue{red}},
+			{ID: uuid.New(), OptionValues: []domain.OptionValue{blue, small}, DeletedAt: time.Now()},
+		},
+	}
+	ok := domain.ValidateProductVariantStructure(product)
+	assert.True(t, ok)
+}
+
+func TestValidateProductVariantStructure_WithOptions_RemovedOptionValue(t *testing.T) {
+	t.Parallel()
+	red := domain.OptionValue{ID: uuid.New(), Value: "Red", DeletedAt: time.Now()}
+	product := &domain.Product{
+		Options: []domain.Option{
+			{ID: uuid.New(), Name: "Color", Values: []domain.OptionValue{red}},
+		},
+		Variants: []domain.ProductVariant{
+			{ID: uuid.New(), OptionValues: []domain.OptionValue{red}},
+		},
+	}
+	ok := domain.ValidateProductVariantStructure(product)
+	assert.False(t, ok)
+}
+
+func TestValidateProductVariantStructure_WithOptions_TwoValuesOfSameOption(t *testing.T) {
+	t.Parallel()
+	red := domain.OptionValue{ID: uuid.New(), Value: "Red"}
+	blue := domain.OptionValue{ID: uuid.New(), Value: "Blue"}
+	product := &domain.Product{
+		Options: []domain.Option{
+			{ID: uuid.New(), Name: "Color", Values: []domain.OptionValue{red, blue}},
+			{ID: uuid.New(), Name: "Size"},
+		},
+		Variants: []domain.ProductVariant{
+			{ID: uuid.New(), OptionValues: []domain.OptionValue{red, blue}},
+		},
+	}
+	ok := domain.ValidateProductVariantStructure(product)
+	assert.False(t, ok)
+}
+
+func TestValidateProductVariantStructure_WithOptions_DuplicatedCombination(t *testing.T) {
+	t.Parallel()
+	red := domain.OptionValue{ID: uuid.New(), Value: "Red"}
+	product := &domain.Product{
+		Options: []domain.Option{
+			{ID: uuid.New(), Name: "Color", Values: []domain.OptionValue{red}},
+		},
+		Variants: []domain.ProductVariant{
+			{ID: uuid.New(), OptionValues: []domain.OptionValue{red}},
+			{ID: uuid.New(), OptionValues: []domain.OptionValue{red}},
+		},
+	}
+	ok := domain.ValidateProductVariantStructure(product)
+	assert.False(t, ok)
+}
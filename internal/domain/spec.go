@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+type SpecType string
+
+const (
+	SpecTypeNumber  SpecType = "number"
+	SpecTypeBoolean SpecType = "boolean"
+	SpecTypeEnum    SpecType = "enum"
+)
+
+// SpecGroup gathers the specs shown together, such as Display or Battery,
+// groups and their specs are listed by Order
+type SpecGroup struct {
+	ID        uuid.UUID `validate:"required"`
+	Name      string    `validate:"gte=2,lte=100"`
+	Order     int       `validate:"gte=0"`
+	Specs     []Spec    `validate:"omitempty,unique=ID,unique=Code,dive"`
+	CreatedAt time.Time `validate:"required"`
+	UpdatedAt time.Time `validate:"required,gtefield=CreatedAt"`
+	DeletedAt time.Time `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+// Spec is a typed specification, a number may have a unit and an enum takes
+// its values from Choices. The type is fixed once created
+type Spec struct {
+	ID        uuid.UUID `validate:"required"`
+	Code      string    `validate:"gte=2,lte=50"`
+	Name      string    `validate:"gte=2,lte=100"`
+	Type      SpecType  `validate:"required,oneof=number boolean enum,specTypeStructure"`
+	Unit      string    `validate:"lte=20"`
+	Choices   []string  `validate:"omitempty,unique,dive,gte=1,lte=100"`
+	Order     int       `validate:"gte=0"`
+	DeletedAt time.Time
+}
+
+// ProductSpec is the value of a spec for a product, only the field matching
+// the type of the spec is set
+type ProductSpec struct {
+	SpecID  uuid.UUID `validate:"required"`
+	Number  *float64
+	Boolean *bool
+	Enum    string
+}
+
+// SpecComparison lines up the spec values of several products for a group,
+// only specs at least one of the products has are kept
+type SpecComparison struct {
+	Group SpecGroup
+	Rows  []SpecComparisonRow
+}
+
+type SpecComparisonRow struct {
+	Spec Spec
+	// Values follows the order of the compared products, nil when a product
+	// has no value
+	Values []*ProductSpec
+	// Same is set when every product has the same value
+	Same bool
+}
+
+func NewSpecGroup(
+	name string,
+	order int,
+) (*SpecGroup, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	now := time.Now()
+	specGroup := &SpecGroup{
+		ID:        id,
+		Name:      name,
+		Order:     order,
+		Specs:     []Spec{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return specGroup, nil
+}
+
+func NewSpec(
+	code string,
+	name string,
+	specType SpecType,
+	unit string,
+	choices []string,
+	order int,
+) (*Spec, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	if choices == nil {
+		choices = []string{}
+	}
+	spec := &Spec{
+		ID:      id,
+		Code:    code,
+		Name:    name,
+		Type:    specType,
+		Unit:    unit,
+		Choices: choices,
+		Order:   order,
+	}
+	return spec, nil
+}
+
+func (g *SpecGroup) Update(
+	name string,
+	order *int,
+) {
+	updated := false
+	if name != "" && g.Name != name {
+		g.Name = name
+		updated = true
+	}
+	if order != nil && g.Order != *order {
+		g.Order = *order
+		updated = true
+	}
+	if updated {
+		g.UpdatedAt = time.Now()
+	}
+}
+
+func (g *SpecGroup) Remove() {
+	now := time.Now()
+	g.DeletedAt = now
+	g.UpdatedAt = now
+	for i := range g.Specs {
+		g.Specs[i].DeletedAt = now
+	}
+}
+
+// RemainingSpecs returns the specs which are not removed
+func (g *SpecGroup) RemainingSpecs() []Spec {
+	specs := make([]Spec, 0, len(g.Specs))
+	for _, spec := range g.Specs {
+		if spec.DeletedAt.IsZero() {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+func (g *SpecGroup) GetSpecByID(id uuid.UUID) *Spec {
+	for i := range g.Specs {
+		if g.Specs[i].ID == id && g.Specs[i].DeletedAt.IsZero() {
+			return &g.Specs[i]
+		}
+	}
+	return nil
+}
+
+func (g *SpecGroup) AddSpecs(specs ...Spec) {
+	g.Specs = append(g.Specs, specs...)
+	g.UpdatedAt = time.Now()
+}
+
+// UpdateSpec changes how a spec is shown, choices can only be added since
+// products may hold the existing ones
+func (g *SpecGroup) UpdateSpec(
+	specID uuid.UUID,
+	name string,
+	unit *string,
+	choices []string,
+	order *int,
+) error {
+	spec := g.GetSpecByID(specID)
+	if spec == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	if name != "" {
+		spec.Name = name
+	}
+	if unit != nil {
+		spec.Unit = *unit
+	}
+	if choices != nil {
+		for _, choice := range spec.Choices {
+			if !slices.Contains(choices, choice) {
+				return multierror.Append(ErrConflict, nil)
+			}
+		}
+		spec.Choices = choices
+	}
+	if order != nil {
+		spec.Order = *order
+	}
+	g.UpdatedAt = time.Now()
+	return nil
+}
+
+func (g *SpecGroup) RemoveSpec(specID uuid.UUID) error {
+	spec := g.GetSpecByID(specID)
+	if spec == nil {
+		return multierror.Append(ErrNotFound, nil)
+	}
+	now := time.Now()
+	spec.DeletedAt = now
+	g.UpdatedAt = now
+	return nil
+}
+
+// NewValue checks a value against the spec, exactly the field matching the
+// type must be set and an enum value must be one of the choices
+func (s *Spec) NewValue(
+	number *float64,
+	boolean *bool,
+	enum string,
+) (*ProductSpec, error) {
+	value := &ProductSpec{SpecID: s.ID}
+	switch s.Type {
+	case SpecTypeNumber:
+		if number == nil || boolean != nil || enum != "" {
+			return nil, multierror.Append(ErrInvalid, nil)
+		}
+		value.Number = number
+	case SpecTypeBoolean:
+		if boolean == nil || number != nil || enum != "" {
+			return nil, multierror.Append(ErrInvalid, nil)
+		}
+		value.Boolean = boolean
+	case SpecTypeEnum:
+		if !slices.Contains(s.Choices, enum) || number != nil || boolean != nil {
+			return nil, multierror.Append(ErrInvalid, nil)
+		}
+		value.Enum = enum
+	default:
+		return nil, multierror.Append(ErrInvalid, nil)
+	}
+	return value, nil
+}
+
+func (v ProductSpec) Equal(other ProductSpec) bool {
+	if v.SpecID != other.SpecID || v.Enum != other.Enum {
+		return false
+	}
+	if (v.Number == nil) != (other.Number == nil) || (v.Number != nil && *v.Number != *other.Number) {
+		return false
+	}
+	if (v.Boolean == nil) != (other.Boolean == nil) || (v.Boolean != nil && *v.Boolean != *other.Boolean) {
+		return false
+	}
+	return true
+}
+
+// CompareSpecs builds one comparison per group in the given order, groups no
+// product has a value for are left out
+func CompareSpecs(
+	groups []SpecGroup,
+	products []Product,
+) []SpecComparison {
+	productValues := make([]map[uuid.UUID]ProductSpec, 0, len(products))
+	for _, product := range products {
+		values := make(map[uuid.UUID]ProductSpec, len(product.Specs))
+		for _, value := range product.Specs {
+			values[value.SpecID] = value
+		}
+		productValues = append(productValues, values)
+	}
+	comparisons := make([]SpecComparison, 0, len(groups))
+	for _, group := range groups {
+		comparison := SpecComparison{
+			Group: group,
+			Rows:  []SpecComparisonRow{},
+		}
+		for _, spec := range group.RemainingSpecs() {
+			row := SpecComparisonRow{
+				Spec:   spec,
+				Values: make([]*ProductSpec, 0, len(products)),
+				Same:   len(products) > 0,
+			}
+			found := false
+			for i, values := range productValues {
+				value, exists := values[spec.ID]
+				if !exists {
+					row.Values = append(row.Values, nil)
+					row.Same = false
+					continue
+				}
+				found = true
+				if i > 0 && (row.Values[0] == nil || !row.Values[0].Equal(value)) {
+					row.Same = false
+				}
+				row.Values = append(row.Values, &value)
+			}
+			if found {
+				comparison.Rows = append(comparison.Rows, row)
+			}
+		}
+		if len(comparison.Rows) > 0 {
+			comparisons = append(comparisons, comparison)
+		}
+	}
+	return comparisons
+}
@@ -0,0 +1,244 @@
+// vim: tabstop=4 shiftwidth=4:
+package domain_test
+
+import (
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type SpecTestSuite struct {
+	suite.Suite
+	validate *validator.Validate
+}
+
+func (s *SpecTestSuite) SetupSuite() {
+	s.validate = validator.New(validator.WithRequiredStructEnabled())
+	s.Require().NoError(domain.RegisterSpecValidates(s.validate))
+}
+
+func (s *SpecTestSuite) newSpec(specType domain.SpecType, unit string, choices []string) *domain.Spec {
+	spec, err := domain.NewSpec("code", "Name", specType, unit, choices, 0)
+	s.Require().NoError(err)
+	return spec
+}
+
+func (s *SpecTestSuite) TestNewSpecTypeStructure() {
+	testcases := []struct {
+		name      string
+		specType  domain.SpecType
+		unit      string
+		choices   []string
+		expectErr bool
+	}{
+		{
+			name:      "number with unit",
+			specType:  domain.SpecTypeNumber,
+			unit:      "inch",
+			expectErr: false,
+		},
+		{
+			name:      "number with choices",
+			specType:  domain.SpecTypeNumber,
+			choices:   []string{"a"},
+			expectErr: true,
+		},
+		{
+			name:      "boolean",
+			specType:  domain.SpecTypeBoolean,
+			expectErr: false,
+		},
+		{
+			name:      "boolean with unit",
+			specType:  domain.SpecTypeBoolean,
+			unit:      "inch",
+			expectErr: true,
+		},
+		{
+			name:      "enum with choices",
+			specType:  domain.SpecTypeEnum,
+			choices:   []string{"IPS", "OLED"},
+			expectErr: false,
+		},
+		{
+			name:      "enum without choices",
+			specType:  domain.SpecTypeEnum,
+			expectErr: true,
+		},
+		{
+			name:      "enum with duplicated choices",
+			specType:  domain.SpecTypeEnum,
+			choices:   []string{"IPS", "IPS"},
+			expectErr: true,
+		},
+		{
+			name:      "unknown type",
+			specType:  domain.SpecType("text"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			spec := s.newSpec(tc.specType, tc.unit, tc.choices)
+
+			validationErr := s.validate.Struct(spec)
+			if tc.expectErr {
+				s.Error(validationErr, tc.name)
+			} else {
+				s.NoError(validationErr, tc.name)
+			}
+		})
+	}
+}
+
+func (s *SpecTestSuite) TestSpecNewValue() {
+	testcases := []struct {
+		name      string
+		specType  domain.SpecType
+		number    *float64
+		boolean   *bool
+		enum      string
+		expectErr bool
+	}{
+		{
+			name:     "number",
+			specType: domain.SpecTypeNumber,
+			number:   ptr.To(15.6),
+		},
+		{
+			name:      "number without value",
+			specType:  domain.SpecTypeNumber,
+			expectErr: true,
+		},
+		{
+			name:      "number with boolean",
+			specType:  domain.SpecTypeNumber,
+			number:    ptr.To(15.6),
+			boolean:   ptr.To(true),
+			expectErr: true,
+		},
+		{
+			name:     "boolean false",
+			specType: domain.SpecTypeBoolean,
+			boolean:  ptr.To(false),
+		},
+		{
+			name:      "boolean with enum",
+			specType:  domain.SpecTypeBoolean,
+			enum:      "IPS",
+			expectErr: true,
+		},
+		{
+			name:     "enum in choices",
+			specType: domain.SpecTypeEnum,
+			enum:     "IPS",
+		},
+		{
+			name:      "enum not in choices",
+			specType:  domain.SpecTypeEnum,
+			enum:      "LCD",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			var choices []string
+			if tc.specType == domain.SpecTypeEnum {
+				choices = []string{"IPS", "OLED"}
+			}
+			spec := s.newSpec(tc.specType, "", choices)
+
+			value, err := spec.NewValue(tc.number, tc.boolean, tc.enum)
+			if tc.expectErr {
+				s.ErrorIs(err, domain.ErrInvalid, tc.name)
+				return
+			}
+			s.Require().NoError(err, tc.name)
+			s.Equal(spec.ID, value.SpecID, tc.name)
+			s.Equal(tc.number, value.Number, tc.name)
+			s.Equal(tc.boolean, value.Boolean, tc.name)
+			s.Equal(tc.enum, value.Enum, tc.name)
+		})
+	}
+}
+
+func (s *SpecTestSuite) TestSpecGroupUpdateSpec() {
+	specGroup, err := domain.NewSpecGroup("Display", 0)
+	s.Require().NoError(err)
+	spec := s.newSpec(domain.SpecTypeEnum, "", []string{"IPS", "OLED"})
+	specGroup.AddSpecs(*spec)
+
+	err = specGroup.UpdateSpec(spec.ID, "", nil, []string{"IPS", "OLED", "LCD"}, nil)
+	s.Require().NoError(err)
+	s.Equal([]string{"IPS", "OLED", "LCD"}, specGroup.GetSpecByID(spec.ID).Choices)
+
+	err = specGroup.UpdateSpec(spec.ID, "", nil, []string{"IPS"}, nil)
+	s.ErrorIs(err, domain.ErrConflict, "Existing choices cannot be removed")
+
+	err = specGroup.UpdateSpec(uuid.New(), "Panel", nil, nil, nil)
+	s.ErrorIs(err, domain.ErrNotFound)
+
+	s.Require().NoError(specGroup.RemoveSpec(spec.ID))
+	s.Nil(specGroup.GetSpecByID(spec.ID))
+	s.Empty(specGroup.RemainingSpecs())
+	err = specGroup.UpdateSpec(spec.ID, "Panel", nil, nil, nil)
+	s.ErrorIs(err, domain.ErrNotFound, "Removed spec cannot be updated")
+}
+
+func (s *SpecTestSuite) TestCompareSpecs() {
+	specGroup, err := domain.NewSpecGroup("Display", 0)
+	s.Require().NoError(err)
+	size := s.newSpec(domain.SpecTypeNumber, "inch", nil)
+	touch := s.newSpec(domain.SpecTypeBoolean, "", nil)
+	panel := s.newSpec(domain.SpecTypeEnum, "", []string{"IPS", "OLED"})
+	unused := s.newSpec(domain.SpecTypeBoolean, "", nil)
+	specGroup.AddSpecs(*size, *touch, *panel, *unused)
+	emptyGroup, err := domain.NewSpecGroup("Battery", 1)
+	s.Require().NoError(err)
+
+	products := []domain.Product{
+		{
+			ID: uuid.New(),
+			Specs: []domain.ProductSpec{
+				{SpecID: size.ID, Number: ptr.To(14.0)},
+				{SpecID: panel.ID, Enum: "OLED"},
+			},
+		},
+		{
+			ID: uuid.New(),
+			Specs: []domain.ProductSpec{
+				{SpecID: size.ID, Number: ptr.To(15.6)},
+				{SpecID: touch.ID, Boolean: ptr.To(true)},
+				{SpecID: panel.ID, Enum: "OLED"},
+			},
+		},
+	}
+
+	comparisons := domain.CompareSpecs([]domain.SpecGroup{*specGroup, *emptyGroup}, products)
+	s.Require().Len(comparisons, 1, "Group without values is left out")
+	rows := comparisons[0].Rows
+	s.Require().Len(rows, 3, "Spec without values is left out")
+
+	s.Equal(size.ID, rows[0].Spec.ID)
+	s.Equal(14.0, *rows[0].Values[0].Number)
+	s.Equal(15.6, *rows[0].Values[1].Number)
+	s.False(rows[0].Same)
+
+	s.Nil(rows[1].Values[0])
+	s.True(*rows[1].Values[1].Boolean)
+	s.False(rows[1].Same)
+
+	s.True(rows[2].Same)
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SpecTestSuite))
+}
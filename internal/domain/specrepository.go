@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SpecGroupRepository interface {
+	List(
+		ctx context.Context,
+		params SpecGroupRepositoryListParam,
+	) (*[]SpecGroup, error)
+
+	Count(
+		ctx context.Context,
+		params SpecGroupRepositoryCountParam,
+	) (*int, error)
+
+	Get(
+		ctx context.Context,
+		params SpecGroupRepositoryGetParam,
+	) (*SpecGroup, error)
+
+	Save(
+		ctx context.Context,
+		params SpecGroupRepositorySaveParam,
+	) error
+}
+
+type SpecGroupRepositoryListParam struct {
+	IDs     []uuid.UUID
+	SpecIDs []uuid.UUID
+	Deleted DeletedParam
+	Limit   int
+	Offset  int
+}
+
+type SpecGroupRepositoryCountParam struct {
+	IDs     []uuid.UUID
+	SpecIDs []uuid.UUID
+	Deleted DeletedParam
+}
+
+type SpecGroupRepositoryGetParam struct {
+	ID uuid.UUID
+}
+
+type SpecGroupRepositorySaveParam struct {
+	SpecGroup SpecGroup
+}
@@ -0,0 +1,7 @@
+package domain
+
+type SpecGroupService interface {
+	Validate(
+		specGroup SpecGroup,
+	) error
+}
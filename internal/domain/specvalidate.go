@@ -0,0 +1,32 @@
+package domain
+
+import "github.com/go-playground/validator/v10"
+
+func RegisterSpecValidates(v *validator.Validate) error {
+	if err := v.RegisterValidation("specTypeStructure", specTypeStructureValidate); err != nil {
+		return err
+	}
+	return nil
+}
+
+func specTypeStructureValidate(fl validator.FieldLevel) bool {
+	spec, ok := fl.Parent().Interface().(Spec)
+	if !ok {
+		return true
+	}
+	return ValidateSpecTypeStructure(&spec)
+}
+
+// ValidateSpecTypeStructure checks that only a number has a unit and that an
+// enum, and only an enum, has choices
+func ValidateSpecTypeStructure(spec *Spec) bool {
+	switch spec.Type {
+	case SpecTypeNumber:
+		return len(spec.Choices) == 0
+	case SpecTypeBoolean:
+		return spec.Unit == "" && len(spec.Choices) == 0
+	case SpecTypeEnum:
+		return spec.Unit == "" && len(spec.Choices) > 0
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+// StockMovement is an append-only entry of the inventory ledger, the sum of
+// movements of a variant is its quantity
+type StockMovement struct {
+	ID               uuid.UUID         `validate:"required"`
+	ProductVariantID uuid.UUID         `validate:"required"`
+	Kind             StockMovementKind `validate:"required,oneof=restock sale cancellation return adjustment"`
+	Quantity         int               `validate:"ne=0"`
+	QuantityAfter    int               `validate:"gte=0"`
+	ActorID          uuid.UUID         `validate:"omitempty"`
+	OrderID          uuid.UUID         `validate:"omitempty"`
+	Note             string            `validate:"omitempty,lte=500"`
+	WarehouseID      uuid.UUID         `validate:"omitempty"`
+	CreatedAt        time.Time         `validate:"required"`
+}
+
+type StockMovementKind string
+
+const (
+	StockMovementKindRestock      StockMovementKind = "restock"
+	StockMovementKindSale         StockMovementKind = "sale"
+	StockMovementKindCancellation StockMovementKind = "cancellation"
+	StockMovementKindReturn       StockMovementKind = "return"
+	StockMovementKindAdjustment   StockMovementKind = "adjustment"
+)
+
+func NewStockMovement(
+	productVariantID uuid.UUID,
+	kind StockMovementKind,
+	quantity int,
+	quantityAfter int,
+	actorID uuid.UUID,
+	orderID uuid.UUID,
+	warehouseID uuid.UUID,
+	note string,
+) (*StockMovement, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	stockMovement := &StockMovement{
+		ID:               id,
+		ProductVariantID: productVariantID,
+		Kind:             kind,
+		Quantity:         quantity,
+		QuantityAfter:    quantityAfter,
+		ActorID:          actorID,
+		OrderID:          orderID,
+		Note:             note,
+		WarehouseID:      warehouseID,
+		CreatedAt:        time.Now(),
+	}
+	return stockMovement, nil
+}
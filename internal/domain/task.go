@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+// TaskKind names the handler a task is run by
+type TaskKind string
+
+type TaskStatus string
+
+const (
+	TaskStatusPending   TaskStatus = "pending"
+	TaskStatusSucceeded TaskStatus = "succeeded"
+	// TaskStatusDead is a task which failed all of its attempts, it stays in
+	// the queue as a dead letter until an admin retries it
+	TaskStatusDead TaskStatus = "dead"
+)
+
+// Task is a unit of background work queued for the worker. A task with a Key
+// is enqueued at most once per kind, scheduled runs use it so only one of the
+// workers enqueues each of them
+type Task struct {
+	ID         uuid.UUID  `validate:"required"`
+	Kind       TaskKind   `validate:"required"`
+	Key        string     `validate:"omitempty,max=255"`
+	Payload    []byte     `validate:"required,json"`
+	Status     TaskStatus `validate:"required,oneof=pending succeeded dead"`
+	Attempts   int        `validate:"gte=0"`
+	Error      string
+	RunAt      time.Time `validate:"required"`
+	CreatedAt  time.Time `validate:"required"`
+	UpdatedAt  time.Time `validate:"required,gtefield=CreatedAt"`
+	FinishedAt time.Time `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+// NewTask queues a task to run at runAt with payload serialized as JSON, a
+// nil payload is an empty object
+func NewTask(kind TaskKind, key string, payload any, runAt time.Time) (*Task, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	data := []byte("{}")
+	if payload != nil {
+		data, err = json.Marshal(payload)
+		if err != nil {
+			return nil, multierror.Append(ErrInvalid, err)
+		}
+	}
+	now := time.Now()
+	task := &Task{
+		ID:        id,
+		Kind:      kind,
+		Key:       key,
+		Payload:   data,
+		Status:    TaskStatusPending,
+		RunAt:     runAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return task, nil
+}
+
+func (t *Task) RecordSucceeded() {
+	now := time.Now()
+	t.Attempts++
+	t.Status = TaskStatusSucceeded
+	t.Error = ""
+	t.FinishedAt = now
+	t.UpdatedAt = now
+}
+
+// RecordFailed records a failed run. The task is retried with an exponential
+// backoff until maxAttempts, then it is dead
+func (t *Task) RecordFailed(
+	reason string,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+	maxAttempts int,
+) {
+	now := time.Now()
+	t.RunAt = now.Add(retryDelay(t.Attempts, baseDelay, maxDelay))
+	t.Attempts++
+	t.Error = reason
+	t.UpdatedAt = now
+	if t.Attempts >= maxAttempts {
+		t.Status = TaskStatusDead
+		t.FinishedAt = now
+	}
+}
+
+// Retry runs a dead task again as soon as possible with a fresh budget of
+// attempts, the error of its last attempt is kept until then
+func (t *Task) Retry() error {
+	if t.Status != TaskStatusDead {
+		return multierror.Append(ErrConflict, errors.New("only dead tasks can be retried"))
+	}
+	now := time.Now()
+	t.Status = TaskStatusPending
+	t.Attempts = 0
+	t.RunAt = now
+	t.FinishedAt = time.Time{}
+	t.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,66 @@
+// vim: tabstop=4 shiftwidth=4:
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskTestSuite struct {
+	suite.Suite
+}
+
+func (s *TaskTestSuite) TestNewTask() {
+	runAt := time.Now().Add(time.Minute)
+	task, err := domain.NewTask("order.expire", "", map[string]string{"orderId": "x"}, runAt)
+	s.Require().NoError(err)
+	s.Equal(domain.TaskStatusPending, task.Status)
+	s.Equal(runAt, task.RunAt)
+	s.JSONEq(`{"orderId":"x"}`, string(task.Payload))
+
+	task, err = domain.NewTask("order.expire", "order.expire@2026-10-19T00:00:00Z", nil, runAt)
+	s.Require().NoError(err)
+	s.JSONEq(`{}`, string(task.Payload), "no payload")
+}
+
+func (s *TaskTestSuite) TestTaskLifecycle() {
+	task, err := domain.NewTask("order.expire", "", nil, time.Now())
+	s.Require().NoError(err)
+	s.Error(task.Retry(), "pending task")
+
+	s.Run("Retry with backoff until max attempts", func() {
+		before := time.Now()
+		task.RecordFailed("connection refused", time.Second, time.Minute, 2)
+		s.Equal(domain.TaskStatusPending, task.Status)
+		s.Equal(1, task.Attempts)
+		s.WithinRange(task.RunAt, before.Add(time.Second), time.Now().Add(time.Second))
+
+		task.RecordFailed("connection refused", time.Second, time.Minute, 2)
+		s.Equal(domain.TaskStatusDead, task.Status)
+		s.Equal(2, task.Attempts)
+		s.False(task.FinishedAt.IsZero())
+	})
+
+	s.Run("Retry dead task", func() {
+		s.Require().NoError(task.Retry())
+		s.Equal(domain.TaskStatusPending, task.Status)
+		s.Equal(0, task.Attempts)
+		s.True(task.FinishedAt.IsZero())
+		s.Equal("connection refused", task.Error, "last error kept until the next attempt")
+
+		task.RecordSucceeded()
+		s.Equal(domain.TaskStatusSucceeded, task.Status)
+		s.Equal(1, task.Attempts)
+		s.Empty(task.Error)
+		s.Error(task.Retry(), "succeeded task")
+	})
+}
+
+func TestTask(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TaskTestSuite))
+}
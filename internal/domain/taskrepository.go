@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskRepository interface {
+	List(
+		ctx context.Context,
+		params TaskRepositoryListParam,
+	) (*[]Task, error)
+
+	Count(
+		ctx context.Context,
+		params TaskRepositoryCountParam,
+	) (*int, error)
+
+	Get(
+		ctx context.Context,
+		params TaskRepositoryGetParam,
+	) (*Task, error)
+
+	// Create queues new tasks, skipping those with the Key of a task of the
+	// same kind already queued
+	Create(
+		ctx context.Context,
+		params TaskRepositoryCreateParam,
+	) error
+
+	Save(
+		ctx context.Context,
+		params TaskRepositorySaveParam,
+	) error
+
+	// Claim leases the oldest due pending tasks of Kinds until LeaseUntil
+	Claim(
+		ctx context.Context,
+		params TaskRepositoryClaimParam,
+	) (*[]Task, error)
+
+	DeleteSucceeded(
+		ctx context.Context,
+		params TaskRepositoryDeleteSucceededParam,
+	) (*int, error)
+}
+
+type TaskRepositoryListParam struct {
+	Kinds  []TaskKind
+	Status TaskStatus
+	Limit  int
+	Offset int
+}
+
+type TaskRepositoryCountParam struct {
+	Kinds  []TaskKind
+	Status TaskStatus
+}
+
+type TaskRepositoryGetParam struct {
+	ID uuid.UUID
+}
+
+type TaskRepositoryCreateParam struct {
+	Tasks []Task
+}
+
+type TaskRepositorySaveParam struct {
+	Task Task
+}
+
+type TaskRepositoryClaimParam struct {
+	Kinds      []TaskKind
+	Now        time.Time
+	LeaseUntil time.Time
+	Limit      int
+}
+
+type TaskRepositoryDeleteSucceededParam struct {
+	Before time.Time
+}
@@ -0,0 +1,7 @@
+package domain
+
+type TaskService interface {
+	Validate(
+		task Task,
+	) error
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Warehouse struct {
+	ID       uuid.UUID `validate:"required"`
+	Name     string    `validate:"required,gte=2,lte=100"`
+	Province string    `validate:"required,lte=100"`
+	// Priority orders warehouses when none is in the province of an order,
+	// lower is tried first
+	Priority  int       `validate:"gte=0"`
+	CreatedAt time.Time `validate:"required"`
+	UpdatedAt time.Time `validate:"required,gtefield=CreatedAt"`
+	DeletedAt time.Time `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+// WarehouseStock is the quantity of a variant held at a warehouse
+type WarehouseStock struct {
+	WarehouseID uuid.UUID `validate:"required"`
+	Quantity    int       `validate:"gte=0"`
+}
+
+func NewWarehouse(
+	name string,
+	province string,
+	priority int,
+) (*Warehouse, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	now := time.Now()
+	warehouse := &Warehouse{
+		ID:        id,
+		Name:      name,
+		Province:  province,
+		Priority:  priority,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return warehouse, nil
+}
+
+func (w *Warehouse) Update(
+	name string,
+	province string,
+	priority *int,
+) {
+	updated := false
+	if name != "" && w.Name != name {
+		w.Name = name
+		updated = true
+	}
+	if province != "" && w.Province != province {
+		w.Province = province
+		updated = true
+	}
+	if priority != nil && w.Priority != *priority {
+		w.Priority = *priority
+		updated = true
+	}
+	if updated {
+		w.UpdatedAt = time.Now()
+	}
+}
+
+func (w *Warehouse) Remove() {
+	now := time.Now()
+	w.DeletedAt = now
+	w.UpdatedAt = now
+}
+
+// Serves reports whether the province of the warehouse is named in the address
+func (w *Warehouse) Serves(address string) bool {
+	if w.Province == "" {
+		return false
+	}
+	return strings.Contains(strings.ToLower(address), strings.ToLower(w.Province))
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WarehouseRepository interface {
+	List(
+		ctx context.Context,
+		params WarehouseRepositoryListParam,
+	) (*[]Warehouse, error)
+
+	Count(
+		ctx context.Context,
+		params WarehouseRepositoryCountParam,
+	) (*int, error)
+
+	Get(
+		ctx context.Context,
+		params WarehouseRepositoryGetParam,
+	) (*Warehouse, error)
+
+	// CountStocks counts the variants holding stock at a warehouse
+	CountStocks(
+		ctx context.Context,
+		params WarehouseRepositoryCountStocksParam,
+	) (*int, error)
+
+	Save(
+		ctx context.Context,
+		params WarehouseRepositorySaveParam,
+	) error
+}
+
+type WarehouseRepositoryListParam struct {
+	IDs     []uuid.UUID
+	Deleted DeletedParam
+	Limit   int
+	Offset  int
+}
+
+type WarehouseRepositoryCountParam struct {
+	IDs     []uuid.UUID
+	Deleted DeletedParam
+}
+
+type WarehouseRepositoryGetParam struct {
+	ID uuid.UUID
+}
+
+type WarehouseRepositoryCountStocksParam struct {
+	WarehouseID uuid.UUID
+}
+
+type WarehouseRepositorySaveParam struct {
+	Warehouse Warehouse
+}
@@ -0,0 +1,7 @@
+package domain
+
+type WarehouseService interface {
+	Validate(
+		warehouse Warehouse,
+	) error
+}
@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+// WebhookEventTypes are the events partners can subscribe to
+var WebhookEventTypes = []EventType{
+	EventTypeOrderCreated,
+	EventTypeOrderPaid,
+	EventTypeOrderStatusChanged,
+	EventTypeStockChanged,
+}
+
+const webhookSecretPrefix = "whsec_"
+
+// Webhook is a partner endpoint subscribed to some event types. Deliveries are
+// signed with its Secret so the partner can verify they come from us
+type Webhook struct {
+	ID         uuid.UUID   `validate:"required"`
+	URL        string      `validate:"required,http_url,lte=2048"`
+	Secret     string      `validate:"required,startswith=whsec_"`
+	EventTypes []EventType `validate:"required,min=1,unique,dive,oneof=order.created order.paid order.status_changed stock.changed"`
+	// Active webhooks receive new events, deliveries of inactive ones fail
+	// until they are redelivered
+	Active    bool
+	CreatedAt time.Time `validate:"required"`
+	UpdatedAt time.Time `validate:"required,gtefield=CreatedAt"`
+	DeletedAt time.Time `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+func NewWebhook(
+	url string,
+	eventTypes []EventType,
+) (*Webhook, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	webhook := &Webhook{
+		ID:         id,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: slices.Clone(eventTypes),
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	return webhook, nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", multierror.Append(ErrInternal, err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+func (w *Webhook) Update(
+	url string,
+	eventTypes []EventType,
+	active *bool,
+) {
+	updated := false
+	if url != "" && w.URL != url {
+		w.URL = url
+		updated = true
+	}
+	if len(eventTypes) > 0 && !slices.Equal(w.EventTypes, eventTypes) {
+		w.EventTypes = slices.Clone(eventTypes)
+		updated = true
+	}
+	if active != nil && w.Active != *active {
+		w.Active = *active
+		updated = true
+	}
+	if updated {
+		w.UpdatedAt = time.Now()
+	}
+}
+
+// RotateSecret replaces the secret, deliveries are signed with the new one
+// from then on, redeliveries included
+func (w *Webhook) RotateSecret() error {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return err
+	}
+	w.Secret = secret
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+func (w *Webhook) Remove() {
+	now := time.Now()
+	w.DeletedAt = now
+	w.UpdatedAt = now
+}
+
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return w.Active && w.DeletedAt.IsZero() && slices.Contains(w.EventTypes, eventType)
+}
+
+// Sign returns the signature of a delivery body sent at timestamp, as
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">". The
+// timestamp is signed so receivers can reject replayed deliveries
+func (w *Webhook) Sign(timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	_, _ = fmt.Fprintf(mac, "%d.", unix)
+	_, _ = mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event to deliver to a webhook and the outcome of its
+// last attempt. A webhook gets at most one delivery per event
+type WebhookDelivery struct {
+	ID        uuid.UUID `validate:"required"`
+	WebhookID uuid.UUID `validate:"required"`
+	EventID   uuid.UUID `validate:"required"`
+	EventType EventType `validate:"required"`
+	// Payload is the body sent, the same on every attempt
+	Payload       []byte                `validate:"required"`
+	Status        WebhookDeliveryStatus `validate:"required,oneof=pending succeeded failed"`
+	Attempts      int                   `validate:"gte=0"`
+	ResponseCode  int                   `validate:"gte=0"`
+	ResponseBody  string
+	Error         string
+	NextAttemptAt time.Time `validate:"required"`
+	CreatedAt     time.Time `validate:"required"`
+	UpdatedAt     time.Time `validate:"required,gtefield=CreatedAt"`
+	DeliveredAt   time.Time `validate:"omitempty,gtefield=CreatedAt"`
+}
+
+type webhookDeliveryPayload struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          EventType       `json:"type"`
+	AggregateType AggregateType   `json:"aggregateType"`
+	AggregateID   uuid.UUID       `json:"aggregateId"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func NewWebhookDelivery(
+	webhookID uuid.UUID,
+	event OutboxEvent,
+) (*WebhookDelivery, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, multierror.Append(ErrInternal, err)
+	}
+	payload, err := json.Marshal(webhookDeliveryPayload{
+		ID:            event.ID,
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		OccurredAt:    event.OccurredAt,
+		Data:          event.Payload,
+	})
+	if err != nil {
+		return nil, multierror.Append(ErrInvalid, err)
+	}
+	now := time.Now()
+	delivery := &WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	return delivery, nil
+}
+
+// RecordSucceeded records an attempt answered with a 2xx responseCode
+func (d *WebhookDelivery) RecordSucceeded(
+	responseCode int,
+	responseBody string,
+) {
+	now := time.Now()
+	d.Attempts++
+	d.Status = WebhookDeliveryStatusSucceeded
+	d.ResponseCode = responseCode
+	d.ResponseBody = responseBody
+	d.Error = ""
+	d.DeliveredAt = now
+	d.UpdatedAt = now
+}
+
+// RecordFailed records an attempt which failed, responseCode is 0 when no
+// response was received. The delivery is retried with an exponential backoff
+// until maxAttempts, then it fails until redelivered
+func (d *WebhookDelivery) RecordFailed(
+	responseCode int,
+	responseBody string,
+	reason string,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+	maxAttempts int,
+) {
+	now := time.Now()
+	d.NextAttemptAt = now.Add(retryDelay(d.Attempts, baseDelay, maxDelay))
+	d.Attempts++
+	d.ResponseCode = responseCode
+	d.ResponseBody = responseBody
+	d.Error = reason
+	d.UpdatedAt = now
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryStatusFailed
+	}
+}
+
+// Abandon fails the delivery without attempting it, as when its webhook was
+// deactivated or removed since the event
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryStatusFailed
+	d.Error = reason
+	d.UpdatedAt = time.Now()
+}
+
+// Redeliver sends the delivery again as soon as possible with a fresh budget
+// of attempts, the outcome of the last attempt is kept until then
+func (d *WebhookDelivery) Redeliver() {
+	now := time.Now()
+	d.Status = WebhookDeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.DeliveredAt = time.Time{}
+	d.UpdatedAt = now
+}
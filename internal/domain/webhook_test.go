@@ -0,0 +1,117 @@
+// vim: tabstop=4 shiftwidth=4:
+package domain_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+}
+
+func (s *WebhookTestSuite) TestWebhookSign() {
+	webhook, err := domain.NewWebhook("https://erp.example.com/hooks", []domain.EventType{
+		domain.EventTypeOrderPaid,
+	})
+	s.Require().NoError(err)
+	s.Regexp("^whsec_[0-9a-f]{64}$", webhook.Secret)
+
+	timestamp := time.Unix(1_760_000_000, 0)
+	body := []byte(`{"id":"1"}`)
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write([]byte("1760000000.{\"id\":\"1\"}"))
+	expected := fmt.Sprintf("t=1760000000,v1=%s", hex.EncodeToString(mac.Sum(nil)))
+	s.Equal(expected, webhook.Sign(timestamp, body))
+
+	secret := webhook.Secret
+	s.Require().NoError(webhook.RotateSecret())
+	s.NotEqual(secret, webhook.Secret)
+	s.NotEqual(expected, webhook.Sign(timestamp, body), "signed with the rotated secret")
+}
+
+func (s *WebhookTestSuite) TestWebhookSubscribes() {
+	webhook, err := domain.NewWebhook("https://erp.example.com/hooks", []domain.EventType{
+		domain.EventTypeOrderPaid,
+		domain.EventTypeStockChanged,
+	})
+	s.Require().NoError(err)
+	s.True(webhook.Subscribes(domain.EventTypeOrderPaid))
+	s.False(webhook.Subscribes(domain.EventTypeOrderCreated))
+
+	webhook.Update("", nil, new(bool))
+	s.False(webhook.Subscribes(domain.EventTypeOrderPaid), "inactive webhook")
+
+	active := true
+	webhook.Update("", nil, &active)
+	webhook.Remove()
+	s.False(webhook.Subscribes(domain.EventTypeOrderPaid), "removed webhook")
+}
+
+func (s *WebhookTestSuite) TestWebhookDeliveryLifecycle() {
+	event := domain.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: domain.AggregateTypeOrder,
+		AggregateID:   uuid.New(),
+		Type:          domain.EventTypeOrderPaid,
+		Payload:       []byte(`{"orderId":"x"}`),
+		OccurredAt:    time.Now(),
+	}
+	webhookID := uuid.New()
+	delivery, err := domain.NewWebhookDelivery(webhookID, event)
+	s.Require().NoError(err)
+	s.Equal(domain.WebhookDeliveryStatusPending, delivery.Status)
+	s.Equal(event.ID, delivery.EventID)
+
+	var payload map[string]any
+	s.Require().NoError(json.Unmarshal(delivery.Payload, &payload))
+	s.Equal(event.ID.String(), payload["id"])
+	s.Equal(string(domain.EventTypeOrderPaid), payload["type"])
+	s.Equal(map[string]any{"orderId": "x"}, payload["data"])
+
+	s.Run("Retry with backoff until max attempts", func() {
+		before := time.Now()
+		delivery.RecordFailed(500, "oops", "unexpected response status 500", time.Second, time.Minute, 3)
+		s.Equal(domain.WebhookDeliveryStatusPending, delivery.Status)
+		s.Equal(1, delivery.Attempts)
+		s.Equal(500, delivery.ResponseCode)
+		s.WithinRange(delivery.NextAttemptAt, before.Add(time.Second), time.Now().Add(time.Second))
+
+		before = time.Now()
+		delivery.RecordFailed(0, "", "connection refused", time.Second, time.Minute, 3)
+		s.Equal(domain.WebhookDeliveryStatusPending, delivery.Status)
+		s.WithinRange(delivery.NextAttemptAt, before.Add(2*time.Second), time.Now().Add(2*time.Second))
+
+		delivery.RecordFailed(502, "", "unexpected response status 502", time.Second, time.Minute, 3)
+		s.Equal(domain.WebhookDeliveryStatusFailed, delivery.Status)
+		s.Equal(3, delivery.Attempts)
+	})
+
+	s.Run("Redeliver", func() {
+		delivery.Redeliver()
+		s.Equal(domain.WebhookDeliveryStatusPending, delivery.Status)
+		s.Equal(0, delivery.Attempts)
+		s.Equal(502, delivery.ResponseCode, "last outcome kept until the next attempt")
+
+		delivery.RecordSucceeded(204, "")
+		s.Equal(domain.WebhookDeliveryStatusSucceeded, delivery.Status)
+		s.Equal(1, delivery.Attempts)
+		s.Empty(delivery.Error)
+		s.False(delivery.DeliveredAt.IsZero())
+	})
+}
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WebhookTestSuite))
+}
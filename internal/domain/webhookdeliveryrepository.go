@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryRepository interface {
+	List(
+		ctx context.Context,
+		params WebhookDeliveryRepositoryListParam,
+	) (*[]WebhookDelivery, error)
+
+	Count(
+		ctx context.Context,
+		params WebhookDeliveryRepositoryCountParam,
+	) (*int, error)
+
+	Get(
+		ctx context.Context,
+		params WebhookDeliveryRepositoryGetParam,
+	) (*WebhookDelivery, error)
+
+	// Create inserts new deliveries, skipping those of an event the webhook
+	// already has a delivery for
+	Create(
+		ctx context.Context,
+		params WebhookDeliveryRepositoryCreateParam,
+	) error
+
+	Save(
+		ctx context.Context,
+		params WebhookDeliveryRepositorySaveParam,
+	) error
+
+	// Claim leases the oldest due pending deliveries until LeaseUntil
+	Claim(
+		ctx context.Context,
+		params WebhookDeliveryRepositoryClaimParam,
+	) (*[]WebhookDelivery, error)
+}
+
+type WebhookDeliveryRepositoryListParam struct {
+	WebhookID uuid.UUID
+	Status    WebhookDeliveryStatus
+	Limit     int
+	Offset    int
+}
+
+type WebhookDeliveryRepositoryCountParam struct {
+	WebhookID uuid.UUID
+	Status    WebhookDeliveryStatus
+}
+
+type WebhookDeliveryRepositoryGetParam struct {
+	ID uuid.UUID
+}
+
+type WebhookDeliveryRepositoryCreateParam struct {
+	Deliveries []WebhookDelivery
+}
+
+type WebhookDeliveryRepositorySaveParam struct {
+	Delivery WebhookDelivery
+}
+
+type WebhookDeliveryRepositoryClaimParam struct {
+	Now        time.Time
+	LeaseUntil time.Time
+	Limit      int
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRepository interface {
+	List(
+		ctx context.Context,
+		params WebhookRepositoryListParam,
+	) (*[]Webhook, error)
+
+	Count(
+		ctx context.Context,
+		params WebhookRepositoryCountParam,
+	) (*int, error)
+
+	Get(
+		ctx context.Context,
+		params WebhookRepositoryGetParam,
+	) (*Webhook, error)
+
+	Save(
+		ctx context.Context,
+		params WebhookRepositorySaveParam,
+	) error
+}
+
+type WebhookRepositoryListParam struct {
+	IDs []uuid.UUID
+	// EventType only lists the active webhooks subscribed to it
+	EventType EventType
+	Deleted   DeletedParam
+	Limit     int
+	Offset    int
+}
+
+type WebhookRepositoryCountParam struct {
+	IDs       []uuid.UUID
+	EventType EventType
+	Deleted   DeletedParam
+}
+
+type WebhookRepositoryGetParam struct {
+	ID uuid.UUID
+}
+
+type WebhookRepositorySaveParam struct {
+	Webhook Webhook
+}
@@ -0,0 +1,7 @@
+package domain
+
+type WebhookService interface {
+	Validate(
+		webhook Webhook,
+	) error
+}
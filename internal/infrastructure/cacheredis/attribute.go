@@ -10,16 +10,19 @@ import (
 
 	"backend/internal/application"
 	"backend/internal/delivery/http"
+	"backend/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
 type Attribute struct {
 	redisClient *redis.Client
+	local       *localCache
 }
 
-func ProvideAttribute(redisClient *redis.Client) *Attribute {
+func ProvideAttribute(redisClient *redis.Client, local *Local) *Attribute {
 	return &Attribute{
 		redisClient: redisClient,
+		local:       local.newTier(),
 	}
 }
 
@@ -28,141 +31,135 @@ var _ application.AttributeCache = (*Attribute)(nil)
 func (a *Attribute) Get(
 	ctx context.Context,
 	param application.AttributeCacheParam,
-) (*http.AttributeResponseDto, error) {
-	key := a.getKey(param)
-	data, err := a.redisClient.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-	if data == "" {
-		return nil, redis.Nil
-	}
-	var result http.AttributeResponseDto
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+) (_ *http.AttributeResponseDto, err error) {
+	defer observeCacheLookup(cacheNameAttribute, cacheOperationGet, time.Now(), &err)
+	return getTiered(ctx, a.redisClient, a.local, cacheNameAttribute, a.getKey(param), getAttributeTags)
 }
 
 func (a *Attribute) Set(
 	ctx context.Context,
 	param application.AttributeCacheParam,
 	attribute *http.AttributeResponseDto,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameAttribute, cacheOperationSet, time.Now(), &err)
 	key := a.getKey(param)
 	data, err := json.Marshal(attribute)
 	if err != nil {
 		return err
 	}
-	return a.redisClient.Set(ctx, key, data, time.Duration(CacheTTLAttribute)*time.Second).Err()
+	return setTagged(ctx, a.redisClient, key, data, time.Duration(CacheTTLAttribute)*time.Second, getAttributeTags(attribute))
 }
 
 func (a *Attribute) Invalidate(
 	ctx context.Context,
 	param application.AttributeCacheParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameAttribute, cacheOperationInvalidate, time.Now(), &err)
 	key := a.getKey(param)
-	return a.redisClient.Del(ctx, key).Err()
+	if err := a.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return a.local.invalidateKeys(ctx, key)
 }
 
 func (a *Attribute) GetList(
 	ctx context.Context,
 	param application.AttributeCacheListParam,
-) (*http.PaginationResponseDto[http.AttributeResponseDto], error) {
-	key := a.getListKey(param)
-	data, err := a.redisClient.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-	if data == "" {
-		return nil, redis.Nil
-	}
-	var result http.PaginationResponseDto[http.AttributeResponseDto]
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+) (_ *http.PaginationResponseDto[http.AttributeResponseDto], err error) {
+	defer observeCacheLookup(cacheNameAttributeList, cacheOperationGet, time.Now(), &err)
+	return getTiered(ctx, a.redisClient, a.local, cacheNameAttributeList, a.getListKey(param), getAttributeListTags)
 }
 
 func (a *Attribute) SetList(
 	ctx context.Context,
 	param application.AttributeCacheListParam,
 	pagination *http.PaginationResponseDto[http.AttributeResponseDto],
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameAttributeList, cacheOperationSet, time.Now(), &err)
 	key := a.getListKey(param)
 	data, err := json.Marshal(pagination)
 	if err != nil {
 		return err
 	}
-	return a.redisClient.Set(ctx, key, data, time.Duration(CacheTTLAttribute)*time.Second).Err()
+	return setTagged(ctx, a.redisClient, key, data, time.Duration(CacheTTLAttribute)*time.Second, getAttributeListTags(pagination))
 }
 
 func (a *Attribute) InvalidateList(
 	ctx context.Context,
 	param application.AttributeCacheListParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameAttributeList, cacheOperationInvalidate, time.Now(), &err)
 	key := a.getListKey(param)
-	return a.redisClient.Del(ctx, key).Err()
+	if err := a.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return a.local.invalidateKeys(ctx, key)
 }
 
 func (a *Attribute) GetValueList(
 	ctx context.Context,
 	param application.AttributeCacheValueListParam,
-) (*http.PaginationResponseDto[http.AttributeValueResponseDto], error) {
-	key := a.getValueListKey(param)
-	data, err := a.redisClient.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-	if data == "" {
-		return nil, redis.Nil
-	}
-	var result http.PaginationResponseDto[http.AttributeValueResponseDto]
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+) (_ *http.PaginationResponseDto[http.AttributeValueResponseDto], err error) {
+	defer observeCacheLookup(cacheNameAttributeValueList, cacheOperationGet, time.Now(), &err)
+	return getTiered(
+		ctx,
+		a.redisClient,
+		a.local,
+		cacheNameAttributeValueList,
+		a.getValueListKey(param),
+		func(*http.PaginationResponseDto[http.AttributeValueResponseDto]) tagSet {
+			return getAttributeValueListTags(param)
+		},
+	)
 }
 
 func (a *Attribute) SetValueList(
 	ctx context.Context,
 	param application.AttributeCacheValueListParam,
 	pagination *http.PaginationResponseDto[http.AttributeValueResponseDto],
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameAttributeValueList, cacheOperationSet, time.Now(), &err)
 	key := a.getValueListKey(param)
 	data, err := json.Marshal(pagination)
 	if err != nil {
 		return err
 	}
-	return a.redisClient.Set(ctx, key, data, time.Duration(CacheTTLAttributeValue)*time.Second).Err()
+	return setTagged(ctx, a.redisClient, key, data, time.Duration(CacheTTLAttributeValue)*time.Second, getAttributeValueListTags(param))
 }
 
 func (a *Attribute) InvalidateValueList(
 	ctx context.Context,
 	param application.AttributeCacheValueListParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameAttributeValueList, cacheOperationInvalidate, time.Now(), &err)
 	key := a.getValueListKey(param)
-	return a.redisClient.Del(ctx, key).Err()
+	if err := a.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return a.local.invalidateKeys(ctx, key)
 }
 
-func (a *Attribute) InvalidateAlls(
-	ctx context.Context,
-) error {
-	patterns := []string{
-		AttributeGetPrefix + "*",
-		AttributeListPrefix + "*",
-		AttributeValueListPrefix + "*",
-	}
-	for _, pattern := range patterns {
-		iter := a.redisClient.Scan(ctx, 0, pattern, 0).Iterator()
-		for iter.Next(ctx) {
-			a.redisClient.Del(ctx, iter.Val())
-		}
-		if err := iter.Err(); err != nil {
-			return err
-		}
+func getAttributeTags(attribute *http.AttributeResponseDto) tagSet {
+	tags := tagSet{}
+	tags.add(domain.ChangeEntityAttribute, attribute.ID)
+	return tags
+}
+
+func getAttributeListTags(pagination *http.PaginationResponseDto[http.AttributeResponseDto]) tagSet {
+	tags := tagSet{}
+	tags.addListing(domain.ChangeEntityAttribute)
+	for _, attribute := range pagination.Data {
+		tags.add(domain.ChangeEntityAttribute, attribute.ID)
 	}
-	return nil
+	return tags
+}
+
+// getAttributeValueListTags tags values by their attribute, a change of a
+// value is a change of its attribute
+func getAttributeValueListTags(param application.AttributeCacheValueListParam) tagSet {
+	tags := tagSet{}
+	tags.add(domain.ChangeEntityAttribute, param.ID)
+	return tags
 }
 
 func (a *Attribute) getKey(param application.AttributeCacheParam) string {
@@ -27,7 +27,8 @@ var _ application.CartCache = (*Cart)(nil)
 func (c *Cart) Get(
 	ctx context.Context,
 	param application.CartCacheParam,
-) (*http.CartResponseDto, error) {
+) (_ *http.CartResponseDto, err error) {
+	defer observeCacheLookup(cacheNameCart, cacheOperationGet, time.Now(), &err)
 	key := c.getKey(param)
 	data, err := c.redisClient.Get(ctx, key).Result()
 	if err != nil {
@@ -47,7 +48,8 @@ func (c *Cart) Set(
 	ctx context.Context,
 	param application.CartCacheParam,
 	cart *http.CartResponseDto,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCart, cacheOperationSet, time.Now(), &err)
 	key := c.getKey(param)
 	data, err := json.Marshal(cart)
 	if err != nil {
@@ -59,14 +61,16 @@ func (c *Cart) Set(
 func (c *Cart) Invalidate(
 	ctx context.Context,
 	param application.CartCacheParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCart, cacheOperationInvalidate, time.Now(), &err)
 	key := c.getKey(param)
 	return c.redisClient.Del(ctx, key).Err()
 }
 
 func (c *Cart) InvalidateAlls(
 	ctx context.Context,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCart, cacheOperationInvalidateAll, time.Now(), &err)
 	pattern := CartGetPrefix + "*"
 	iter := c.redisClient.Scan(ctx, 0, pattern, 0).Iterator()
 	for iter.Next(ctx) {
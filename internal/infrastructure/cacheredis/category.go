@@ -8,17 +8,20 @@ import (
 
 	"backend/internal/application"
 	"backend/internal/delivery/http"
+	"backend/internal/domain"
 
 	"github.com/redis/go-redis/v9"
 )
 
 type Category struct {
 	redisClient *redis.Client
+	local       *localCache
 }
 
-func ProvideCategory(redisClient *redis.Client) *Category {
+func ProvideCategory(redisClient *redis.Client, local *Local) *Category {
 	return &Category{
 		redisClient: redisClient,
+		local:       local.newTier(),
 	}
 }
 
@@ -27,100 +30,84 @@ var _ application.CategoryCache = (*Category)(nil)
 func (c *Category) Get(
 	ctx context.Context,
 	param application.CategoryCacheParam,
-) (*http.CategoryResponseDto, error) {
-	key := c.getKey(param)
-	data, err := c.redisClient.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-	if data == "" {
-		return nil, redis.Nil
-	}
-	var result http.CategoryResponseDto
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+) (_ *http.CategoryResponseDto, err error) {
+	defer observeCacheLookup(cacheNameCategory, cacheOperationGet, time.Now(), &err)
+	return getTiered(ctx, c.redisClient, c.local, cacheNameCategory, c.getKey(param), getCategoryTags)
 }
 
 func (c *Category) Set(
 	ctx context.Context,
 	param application.CategoryCacheParam,
 	category *http.CategoryResponseDto,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCategory, cacheOperationSet, time.Now(), &err)
 	key := c.getKey(param)
 	data, err := json.Marshal(category)
 	if err != nil {
 		return err
 	}
-	return c.redisClient.Set(ctx, key, data, time.Duration(CacheTTLCategory)*time.Second).Err()
+	return setTagged(ctx, c.redisClient, key, data, time.Duration(CacheTTLCategory)*time.Second, getCategoryTags(category))
 }
 
 func (c *Category) Invalidate(
 	ctx context.Context,
 	param application.CategoryCacheParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCategory, cacheOperationInvalidate, time.Now(), &err)
 	key := c.getKey(param)
-	return c.redisClient.Del(ctx, key).Err()
+	if err := c.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return c.local.invalidateKeys(ctx, key)
 }
 
 func (c *Category) GetList(
 	ctx context.Context,
 	param application.CategoryCacheListParam,
-) (*http.PaginationResponseDto[http.CategoryResponseDto], error) {
-	key := c.getListKey(param)
-	data, err := c.redisClient.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-	if data == "" {
-		return nil, redis.Nil
-	}
-	var result http.PaginationResponseDto[http.CategoryResponseDto]
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+) (_ *http.PaginationResponseDto[http.CategoryResponseDto], err error) {
+	defer observeCacheLookup(cacheNameCategoryList, cacheOperationGet, time.Now(), &err)
+	return getTiered(ctx, c.redisClient, c.local, cacheNameCategoryList, c.getListKey(param), getCategoryListTags)
 }
 
 func (c *Category) SetList(
 	ctx context.Context,
 	param application.CategoryCacheListParam,
 	pagination *http.PaginationResponseDto[http.CategoryResponseDto],
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCategoryList, cacheOperationSet, time.Now(), &err)
 	key := c.getListKey(param)
 	data, err := json.Marshal(pagination)
 	if err != nil {
 		return err
 	}
-	return c.redisClient.Set(ctx, key, data, time.Duration(CacheTTLCategory)*time.Second).Err()
+	return setTagged(ctx, c.redisClient, key, data, time.Duration(CacheTTLCategory)*time.Second, getCategoryListTags(pagination))
 }
 
 func (c *Category) InvalidateList(
 	ctx context.Context,
 	param application.CategoryCacheListParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameCategoryList, cacheOperationInvalidate, time.Now(), &err)
 	key := c.getListKey(param)
-	return c.redisClient.Del(ctx, key).Err()
+	if err := c.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return c.local.invalidateKeys(ctx, key)
 }
 
-func (c *Category) InvalidateAlls(
-	ctx context.Context,
-) error {
-	patterns := []string{
-		CategoryGetPrefix + "*",
-		CategoryListPrefix + "*",
-	}
-	for _, pattern := range patterns {
-		iter := c.redisClient.Scan(ctx, 0, pattern, 0).Iterator()
-		for iter.Next(ctx) {
-			c.redisClient.Del(ctx, iter.Val())
-		}
-		if err := iter.Err(); err != nil {
-			return err
-		}
+func getCategoryTags(category *http.CategoryResponseDto) tagSet {
+	tags := tagSet{}
+	tags.add(domain.ChangeEntityCategory, category.ID)
+	return tags
+}
+
+func getCategoryListTags(pagination *http.PaginationResponseDto[http.CategoryResponseDto]) tagSet {
+	tags := tagSet{}
+	tags.addListing(domain.ChangeEntityCategory)
+	for _, category := range pagination.Data {
+		tags.add(domain.ChangeEntityCategory, category.ID)
 	}
-	return nil
+	return tags
 }
 
 func (c *Category) getKey(param application.CategoryCacheParam) string {
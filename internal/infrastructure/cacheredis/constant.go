@@ -1,10 +1,11 @@
 package cacheredis
 
+import "time"
+
 const (
 	CacheTTLCategory       = 3600 // 1 hour
 	CacheTTLAttribute      = 3600 // 1 hour
 	CacheTTLAttributeValue = 3600 // 1 hour
-	CacheTTLProduct        = 3600 // 1 hour
 	CacheTTLCart           = 1800 // 30 minutes
 )
 
@@ -16,5 +17,28 @@ const (
 	AttributeValueListPrefix = "attribute_value:list:"
 	ProductListPrefix        = "product:list:"
 	ProductGetPrefix         = "product:get:"
+	ProductSuggestPrefix     = "product:suggest:"
+	ProductRecommendPrefix   = "product:recommend:"
 	CartGetPrefix            = "cart:get:"
+	ProductViewDedupPrefix   = "product_view:dedup:"
+	CacheTagPrefix           = "tag:"
+	CacheLockPrefix          = "lock:"
+)
+
+const (
+	// CacheLoadLockTTL bounds how long other instances wait for the one
+	// filling an entry before loading it themselves
+	CacheLoadLockTTL      = 5 * time.Second
+	CacheLoadPollInterval = 50 * time.Millisecond
+	// ProductViewDrainLease bounds how long a flush holds the drained views
+	// counts, those of a flush which failed without Ack are drained again
+	// after it
+	ProductViewDrainLease = time.Minute
+)
+
+const (
+	CacheInvalidationChannel = "cache:invalidation"
+	ProductViewPendingKey    = "product_view:pending"
+	ProductViewDrainingKey   = "product_view:draining"
+	ProductViewDrainLockKey  = "product_view:drain_lock"
 )
@@ -0,0 +1,76 @@
+package cacheredis
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/application"
+	"backend/internal/delivery/http"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Inspector struct {
+	redisClient *redis.Client
+}
+
+func ProvideInspector(redisClient *redis.Client) *Inspector {
+	return &Inspector{
+		redisClient: redisClient,
+	}
+}
+
+var _ application.CacheInspector = (*Inspector)(nil)
+
+// ListKeys scans the keys, or the members of the tag of the entity. Tags
+// outlive some of their members, so the members already gone are skipped
+func (i *Inspector) ListKeys(
+	ctx context.Context,
+	param application.CacheInspectorListKeysParam,
+) (*http.CacheKeysResponseDto, error) {
+	var keys []string
+	var cursor uint64
+	var err error
+	if param.Entity != "" {
+		tag := getEntityKindTag(param.Entity)
+		if param.ID != nil {
+			tag = getEntityTag(param.Entity, *param.ID)
+		}
+		keys, cursor, err = i.redisClient.SScan(ctx, tag, param.Cursor, param.Match, int64(param.Limit)).Result()
+	} else {
+		keys, cursor, err = i.redisClient.Scan(ctx, param.Cursor, param.Match, int64(param.Limit)).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := i.redisClient.Pipeline()
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for j, key := range keys {
+		ttls[j] = pipe.TTL(ctx, key)
+	}
+	if len(keys) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &http.CacheKeysResponseDto{
+		Data:       make([]http.CacheKeyResponseDto, 0, len(keys)),
+		NextCursor: cursor,
+	}
+	for j, key := range keys {
+		ttl := ttls[j].Val()
+		switch {
+		case ttl == -2:
+			continue
+		case ttl < 0:
+			ttl = -time.Second
+		}
+		result.Data = append(result.Data, http.CacheKeyResponseDto{
+			Key: key,
+			TTL: int64(ttl / time.Second),
+		})
+	}
+	return result, nil
+}
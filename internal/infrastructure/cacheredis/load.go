@@ -0,0 +1,192 @@
+package cacheredis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// cacheEntry wraps cached data with the time it stops being fresh, a stale
+// entry is still served while it is refreshed in the background
+type cacheEntry[T any] struct {
+	Data       T         `json:"data"`
+	FreshUntil time.Time `json:"freshUntil"`
+}
+
+// cacheTTL tells how long entries stay fresh and then stale. Fresh is
+// jittered so that entries filled together do not expire together
+type cacheTTL struct {
+	Fresh  time.Duration
+	Stale  time.Duration
+	Jitter float64
+}
+
+func (t cacheTTL) next() (fresh time.Duration, expiration time.Duration) {
+	fresh = jitterTTL(t.Fresh, t.Jitter)
+	return fresh, fresh + t.Stale
+}
+
+func jitterTTL(ttl time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return ttl
+	}
+	return time.Duration(float64(ttl) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+// releaseLockScript deletes a lock only while it is still held by token, so
+// a load outliving its lock does not release the lock of another one
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+func getEntry[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	key string,
+) (*cacheEntry[T], error) {
+	data, err := redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, redis.Nil
+	}
+	var entry cacheEntry[T]
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func setEntry[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	key string,
+	data *T,
+	ttl cacheTTL,
+	tags tagSet,
+) error {
+	fresh, expiration := ttl.next()
+	raw, err := json.Marshal(cacheEntry[T]{
+		Data:       *data,
+		FreshUntil: time.Now().Add(fresh),
+	})
+	if err != nil {
+		return err
+	}
+	return setTagged(ctx, redisClient, key, raw, expiration, tags)
+}
+
+// loadEntry gets key or fills it with load. Concurrent misses of a key share
+// one load within the process through group and across instances through a
+// Redis lock, the instances which do not get the lock wait for the entry. A
+// stale entry is returned at once while one load refreshes it in the
+// background. Redis errors fall back to load, the cache is never required
+func loadEntry[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	group *singleflight.Group,
+	cache string,
+	key string,
+	ttl cacheTTL,
+	tags func(*T) tagSet,
+	load func(context.Context) (*T, error),
+) (*T, error) {
+	entry, err := getEntry[T](ctx, redisClient, key)
+	recordCacheRequest(cache, cacheTierRedis, err == nil)
+	if err == nil {
+		if time.Now().After(entry.FreshUntil) {
+			group.DoChan(key, func() (any, error) {
+				return fillEntry(context.WithoutCancel(ctx), redisClient, key, ttl, tags, load, false)
+			})
+		}
+		return &entry.Data, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return load(ctx)
+	}
+	// The load is shared, so it must not be canceled along with the request
+	// of the first caller
+	result, err, _ := group.Do(key, func() (any, error) {
+		return fillEntry(context.WithoutCancel(ctx), redisClient, key, ttl, tags, load, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// A background refresh which gave up the lock has no result to share
+	data, ok := result.(*T)
+	if !ok || data == nil {
+		return load(ctx)
+	}
+	return data, nil
+}
+
+// fillEntry loads and sets key under its lock. When the lock is held by
+// another instance, it waits for the entry if wait is set and gives up
+// otherwise
+func fillEntry[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	key string,
+	ttl cacheTTL,
+	tags func(*T) tagSet,
+	load func(context.Context) (*T, error),
+	wait bool,
+) (*T, error) {
+	lockKey := CacheLockPrefix + key
+	token := uuid.NewString()
+	locked, err := redisClient.SetNX(ctx, lockKey, token, CacheLoadLockTTL).Result()
+	if err == nil && !locked {
+		if !wait {
+			return nil, nil
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, CacheLoadLockTTL)
+		entry := waitEntry[T](waitCtx, redisClient, key)
+		cancel()
+		if entry != nil {
+			return &entry.Data, nil
+		}
+		return load(ctx)
+	}
+	if locked {
+		defer func() {
+			_ = releaseLockScript.Run(ctx, redisClient, []string{lockKey}, token).Err()
+		}()
+	}
+	data, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_ = setEntry(ctx, redisClient, key, data, ttl, tags(data))
+	return data, nil
+}
+
+// waitEntry polls key until another instance has filled it, it returns nil
+// once ctx is done
+func waitEntry[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	key string,
+) *cacheEntry[T] {
+	ticker := time.NewTicker(CacheLoadPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if entry, err := getEntry[T](ctx, redisClient, key); err == nil {
+				return entry
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package cacheredis
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"backend/config"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// Local is the in-process tier in front of Redis for hot catalog data. An
+// entry is dropped by the tags it carries, at once on the instance which
+// invalidates it and on the others once they receive the message published
+// on CacheInvalidationChannel. Messages lost while reconnecting are bounded
+// by the TTL of the tier
+type Local struct {
+	redisClient *redis.Client
+	size        int
+	ttl         time.Duration
+	mu          sync.Mutex
+	tiers       []*localCache
+}
+
+// ProvideLocal disables the tier when its size is not positive
+func ProvideLocal(ctx context.Context, redisClient *redis.Client, srvCfg *config.Server) *Local {
+	l := &Local{
+		redisClient: redisClient,
+		size:        srvCfg.CacheLocalSize,
+		ttl:         srvCfg.CacheLocalTTL,
+	}
+	if l.size > 0 {
+		go l.subscribe(ctx)
+	}
+	return l
+}
+
+type invalidationMessage struct {
+	Tags []string `json:"tags"`
+	Keys []string `json:"keys"`
+}
+
+// newTier returns nil when the tier is disabled, a nil tier never hits
+func (l *Local) newTier() *localCache {
+	if l.size <= 0 {
+		return nil
+	}
+	tier := &localCache{
+		owner: l,
+		lru:   expirable.NewLRU[string, localEntry](l.size, nil, l.ttl),
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tiers = append(l.tiers, tier)
+	return tier
+}
+
+// invalidate drops the entries with any of tags or keys from every instance
+func (l *Local) invalidate(ctx context.Context, tags []string, keys []string) error {
+	if l.size <= 0 {
+		return nil
+	}
+	l.drop(tags, keys)
+	data, err := json.Marshal(invalidationMessage{Tags: tags, Keys: keys})
+	if err != nil {
+		return err
+	}
+	return l.redisClient.Publish(ctx, CacheInvalidationChannel, data).Err()
+}
+
+func (l *Local) drop(tags []string, keys []string) {
+	l.mu.Lock()
+	tiers := l.tiers
+	l.mu.Unlock()
+	for _, tier := range tiers {
+		tier.drop(tags, keys)
+	}
+}
+
+func (l *Local) subscribe(ctx context.Context) {
+	pubsub := l.redisClient.Subscribe(ctx, CacheInvalidationChannel)
+	defer func() { _ = pubsub.Close() }()
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var message invalidationMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+				continue
+			}
+			l.drop(message.Tags, message.Keys)
+		}
+	}
+}
+
+type localEntry struct {
+	value any
+	tags  tagSet
+}
+
+// localCache is the local tier of one cache. Its entries are shared by the
+// callers, so they must not be modified
+type localCache struct {
+	owner *Local
+	lru   *expirable.LRU[string, localEntry]
+	// generation changes on every drop, a value read from Redis before a drop
+	// may be stale and is not kept
+	mu         sync.Mutex
+	generation uint64
+}
+
+// getLocal returns the entry of key, or on a miss the generation to set the
+// value read from Redis with
+func getLocal[T any](c *localCache, cache string, key string) (*T, uint64, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+	entry, ok := c.lru.Get(key)
+	value, _ := entry.value.(*T)
+	hit := ok && value != nil
+	recordCacheRequest(cache, cacheTierLocal, hit)
+	return value, generation, hit
+}
+
+func (c *localCache) set(key string, value any, tags tagSet, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.lru.Add(key, localEntry{value: value, tags: tags})
+}
+
+func (c *localCache) invalidateKeys(ctx context.Context, keys ...string) error {
+	if c == nil {
+		return nil
+	}
+	return c.owner.invalidate(ctx, nil, keys)
+}
+
+func (c *localCache) drop(tags []string, keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, key := range keys {
+		c.lru.Remove(key)
+	}
+	if len(tags) == 0 {
+		return
+	}
+	for _, key := range c.lru.Keys() {
+		if entry, ok := c.lru.Peek(key); ok && entry.tags.hasAny(tags) {
+			c.lru.Remove(key)
+		}
+	}
+}
+
+// getTiered gets key from tier, then from Redis into tier
+func getTiered[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	tier *localCache,
+	cache string,
+	key string,
+	tags func(*T) tagSet,
+) (*T, error) {
+	value, generation, ok := getLocal[T](tier, cache, key)
+	if ok {
+		return value, nil
+	}
+	data, err := redisClient.Get(ctx, key).Bytes()
+	if err == nil && len(data) == 0 {
+		err = redis.Nil
+	}
+	recordCacheRequest(cache, cacheTierRedis, err == nil)
+	if err != nil {
+		return nil, err
+	}
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	tier.set(key, &result, tags(&result), generation)
+	return &result, nil
+}
+
+// loadTiered gets key from tier, then through loadEntry into tier
+func loadTiered[T any](
+	ctx context.Context,
+	redisClient *redis.Client,
+	group *singleflight.Group,
+	tier *localCache,
+	cache string,
+	key string,
+	ttl cacheTTL,
+	tags func(*T) tagSet,
+	load func(context.Context) (*T, error),
+) (*T, error) {
+	value, generation, ok := getLocal[T](tier, cache, key)
+	if ok {
+		return value, nil
+	}
+	value, err := loadEntry(ctx, redisClient, group, cache, key, ttl, tags, load)
+	if err != nil {
+		return nil, err
+	}
+	tier.set(key, value, tags(value), generation)
+	return value, nil
+}
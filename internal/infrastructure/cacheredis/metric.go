@@ -0,0 +1,106 @@
+package cacheredis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	cacheTierLocal = "local"
+	cacheTierRedis = "redis"
+)
+
+const (
+	cacheResultHit   = "hit"
+	cacheResultMiss  = "miss"
+	cacheResultOK    = "ok"
+	cacheResultError = "error"
+)
+
+// The metrics are registered once per process since the caches are provided
+// by every injector
+var (
+	cacheRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Total number of cache lookups by cache, tier and result",
+		},
+		[]string{"cache", "tier", "result"},
+	)
+	cacheOperationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_operations_total",
+			Help: "Total number of cache operations by cache, operation and result",
+		},
+		[]string{"cache", "operation", "result"},
+	)
+	cacheOperationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cache_operation_duration_seconds",
+			Help:    "Cache operation duration in seconds",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		},
+		[]string{"cache", "operation"},
+	)
+)
+
+func recordCacheRequest(cache string, tier string, hit bool) {
+	result := cacheResultMiss
+	if hit {
+		result = cacheResultHit
+	}
+	cacheRequestsTotal.WithLabelValues(cache, tier, result).Inc()
+}
+
+// observeCacheLookup records a lookup started at start as a hit, a miss on
+// redis.Nil or an error. It is deferred with the named error of the lookup
+func observeCacheLookup(cache string, operation string, start time.Time, err *error) {
+	result := cacheResultHit
+	switch {
+	case errors.Is(*err, redis.Nil):
+		result = cacheResultMiss
+	case *err != nil:
+		result = cacheResultError
+	}
+	observeCacheOperation(cache, operation, start, result)
+}
+
+// observeCacheWrite records a write or an invalidation started at start
+func observeCacheWrite(cache string, operation string, start time.Time, err *error) {
+	result := cacheResultOK
+	if *err != nil {
+		result = cacheResultError
+	}
+	observeCacheOperation(cache, operation, start, result)
+}
+
+func observeCacheOperation(cache string, operation string, start time.Time, result string) {
+	cacheOperationsTotal.WithLabelValues(cache, operation, result).Inc()
+	cacheOperationDuration.WithLabelValues(cache, operation).Observe(time.Since(start).Seconds())
+}
+
+const (
+	cacheNameCategory               = "category"
+	cacheNameCategoryList           = "category_list"
+	cacheNameAttribute              = "attribute"
+	cacheNameAttributeList          = "attribute_list"
+	cacheNameAttributeValueList     = "attribute_value_list"
+	cacheNameProduct                = "product"
+	cacheNameProductList            = "product_list"
+	cacheNameProductSuggestions     = "product_suggestions"
+	cacheNameProductRecommendations = "product_recommendations"
+	cacheNameCart                   = "cart"
+	cacheNameTag                    = "tag"
+)
+
+const (
+	cacheOperationGet           = "get"
+	cacheOperationSet           = "set"
+	cacheOperationGetOrLoad     = "get_or_load"
+	cacheOperationInvalidate    = "invalidate"
+	cacheOperationInvalidateAll = "invalidate_all"
+)
@@ -8,19 +8,36 @@ import (
 	"strings"
 	"time"
 
+	"backend/config"
 	"backend/internal/application"
 	"backend/internal/delivery/http"
+	"backend/internal/domain"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 type Product struct {
 	redisClient *redis.Client
+	local       *localCache
+	group       singleflight.Group
+	ttl         cacheTTL
+	suggestTTL  cacheTTL
 }
 
-func ProvideProduct(redisClient *redis.Client) *Product {
+func ProvideProduct(redisClient *redis.Client, local *Local, srvCfg *config.Server) *Product {
 	return &Product{
 		redisClient: redisClient,
+		local:       local.newTier(),
+		ttl: cacheTTL{
+			Fresh:  srvCfg.ProductCacheTTL,
+			Stale:  srvCfg.ProductCacheStaleTTL,
+			Jitter: srvCfg.ProductCacheTTLJitter,
+		},
+		suggestTTL: cacheTTL{
+			Fresh:  srvCfg.ProductSuggestCacheTTL,
+			Jitter: srvCfg.ProductCacheTTLJitter,
+		},
 	}
 }
 
@@ -29,48 +46,93 @@ var _ application.ProductCache = (*Product)(nil)
 func (p *Product) Get(
 	ctx context.Context,
 	param application.ProductCacheParam,
-) (*http.ProductResponseDto, error) {
-	key := p.getKey(param)
-	data, err := p.redisClient.Get(ctx, key).Result()
+) (_ *http.ProductResponseDto, err error) {
+	defer observeCacheLookup(cacheNameProduct, cacheOperationGet, time.Now(), &err)
+	entry, err := getEntry[http.ProductResponseDto](ctx, p.redisClient, p.getKey(param))
 	if err != nil {
 		return nil, err
 	}
-	if data == "" {
-		return nil, redis.Nil
-	}
-	var result http.ProductResponseDto
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return &entry.Data, nil
 }
 
 func (p *Product) Set(
 	ctx context.Context,
 	param application.ProductCacheParam,
 	product *http.ProductResponseDto,
-) error {
-	key := p.getKey(param)
-	data, err := json.Marshal(product)
-	if err != nil {
-		return err
-	}
-	return p.redisClient.Set(ctx, key, data, time.Duration(CacheTTLProduct)*time.Second).Err()
+) (err error) {
+	defer observeCacheWrite(cacheNameProduct, cacheOperationSet, time.Now(), &err)
+	return setEntry(ctx, p.redisClient, p.getKey(param), product, p.ttl, getProductTags(product))
+}
+
+func (p *Product) GetOrLoad(
+	ctx context.Context,
+	param application.ProductCacheParam,
+	load func(context.Context) (*http.ProductResponseDto, error),
+) (_ *http.ProductResponseDto, err error) {
+	defer observeCacheWrite(cacheNameProduct, cacheOperationGetOrLoad, time.Now(), &err)
+	return loadTiered(ctx, p.redisClient, &p.group, p.local, cacheNameProduct, p.getKey(param), p.ttl, getProductTags, load)
 }
 
 func (p *Product) Invalidate(
 	ctx context.Context,
 	param application.ProductCacheParam,
-) error {
+) (err error) {
+	defer observeCacheWrite(cacheNameProduct, cacheOperationInvalidate, time.Now(), &err)
 	key := p.getKey(param)
-	return p.redisClient.Del(ctx, key).Err()
+	if err := p.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return p.local.invalidateKeys(ctx, key)
 }
 
 func (p *Product) GetList(
 	ctx context.Context,
 	param application.ProductCacheListParam,
-) (*http.PaginationResponseDto[http.ProductResponseDto], error) {
+) (_ *http.PaginationResponseDto[http.ProductResponseDto], err error) {
+	defer observeCacheLookup(cacheNameProductList, cacheOperationGet, time.Now(), &err)
+	entry, err := getEntry[http.PaginationResponseDto[http.ProductResponseDto]](ctx, p.redisClient, p.getListKey(param))
+	if err != nil {
+		return nil, err
+	}
+	return &entry.Data, nil
+}
+
+func (p *Product) SetList(
+	ctx context.Context,
+	param application.ProductCacheListParam,
+	pagination *http.PaginationResponseDto[http.ProductResponseDto],
+) (err error) {
+	defer observeCacheWrite(cacheNameProductList, cacheOperationSet, time.Now(), &err)
+	return setEntry(ctx, p.redisClient, p.getListKey(param), pagination, p.ttl, getProductListTags(pagination))
+}
+
+func (p *Product) GetOrLoadList(
+	ctx context.Context,
+	param application.ProductCacheListParam,
+	load func(context.Context) (*http.PaginationResponseDto[http.ProductResponseDto], error),
+) (_ *http.PaginationResponseDto[http.ProductResponseDto], err error) {
+	defer observeCacheWrite(cacheNameProductList, cacheOperationGetOrLoad, time.Now(), &err)
+	return loadTiered(ctx, p.redisClient, &p.group, p.local, cacheNameProductList, p.getListKey(param), p.ttl, getProductListTags, load)
+}
+
+func (p *Product) InvalidateList(
+	ctx context.Context,
+	param application.ProductCacheListParam,
+) (err error) {
+	defer observeCacheWrite(cacheNameProductList, cacheOperationInvalidate, time.Now(), &err)
 	key := p.getListKey(param)
+	if err := p.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	return p.local.invalidateKeys(ctx, key)
+}
+
+func (p *Product) GetSuggestions(
+	ctx context.Context,
+	param application.ProductCacheSuggestionsParam,
+) (_ *[]http.ProductSuggestionResponseDto, err error) {
+	defer observeCacheLookup(cacheNameProductSuggestions, cacheOperationGet, time.Now(), &err)
+	key := p.getSuggestionsKey(param)
 	data, err := p.redisClient.Get(ctx, key).Result()
 	if err != nil {
 		return nil, err
@@ -78,57 +140,120 @@ func (p *Product) GetList(
 	if data == "" {
 		return nil, redis.Nil
 	}
-	var result http.PaginationResponseDto[http.ProductResponseDto]
+	var result []http.ProductSuggestionResponseDto
 	if err := json.Unmarshal([]byte(data), &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-func (p *Product) SetList(
+func (p *Product) SetSuggestions(
 	ctx context.Context,
-	param application.ProductCacheListParam,
-	pagination *http.PaginationResponseDto[http.ProductResponseDto],
-) error {
-	key := p.getListKey(param)
-	data, err := json.Marshal(pagination)
+	param application.ProductCacheSuggestionsParam,
+	suggestions *[]http.ProductSuggestionResponseDto,
+) (err error) {
+	defer observeCacheWrite(cacheNameProductSuggestions, cacheOperationSet, time.Now(), &err)
+	key := p.getSuggestionsKey(param)
+	data, err := json.Marshal(suggestions)
 	if err != nil {
 		return err
 	}
-	return p.redisClient.Set(ctx, key, data, time.Duration(CacheTTLProduct)*time.Second).Err()
+	// Suggestions match names, so any listing change may alter them
+	tags := tagSet{}
+	tags.addListing(domain.ChangeEntityProduct)
+	tags.addListing(domain.ChangeEntityCategory)
+	tags.addListing(domain.ChangeEntityAttribute)
+	for _, suggestion := range *suggestions {
+		switch domain.ProductSuggestionType(suggestion.Type) {
+		case domain.ProductSuggestionTypeProduct:
+			tags.add(domain.ChangeEntityProduct, suggestion.ID)
+		case domain.ProductSuggestionTypeCategory:
+			tags.add(domain.ChangeEntityCategory, suggestion.ID)
+		}
+	}
+	fresh, _ := p.suggestTTL.next()
+	return setTagged(ctx, p.redisClient, key, data, fresh, tags)
 }
 
-func (p *Product) InvalidateList(
+func (p *Product) GetRecommendations(
 	ctx context.Context,
-	param application.ProductCacheListParam,
-) error {
-	key := p.getListKey(param)
-	return p.redisClient.Del(ctx, key).Err()
+	param application.ProductCacheRecommendationsParam,
+) (_ *[]http.ProductResponseDto, err error) {
+	defer observeCacheLookup(cacheNameProductRecommendations, cacheOperationGet, time.Now(), &err)
+	key := p.getRecommendationsKey(param)
+	data, err := p.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if data == "" {
+		return nil, redis.Nil
+	}
+	var result []http.ProductResponseDto
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
-func (p *Product) InvalidateAlls(
+func (p *Product) SetRecommendations(
 	ctx context.Context,
-) error {
-	patterns := []string{
-		ProductGetPrefix + "*",
-		ProductListPrefix + "*",
-	}
-	for _, pattern := range patterns {
-		iter := p.redisClient.Scan(ctx, 0, pattern, 0).Iterator()
-		for iter.Next(ctx) {
-			p.redisClient.Del(ctx, iter.Val())
-		}
-		if err := iter.Err(); err != nil {
-			return err
-		}
+	param application.ProductCacheRecommendationsParam,
+	products *[]http.ProductResponseDto,
+) (err error) {
+	defer observeCacheWrite(cacheNameProductRecommendations, cacheOperationSet, time.Now(), &err)
+	key := p.getRecommendationsKey(param)
+	data, err := json.Marshal(products)
+	if err != nil {
+		return err
+	}
+	tags := tagSet{}
+	tags.add(domain.ChangeEntityProduct, param.ProductID)
+	for _, product := range *products {
+		tags.addProduct(product)
+	}
+	fresh, _ := p.ttl.next()
+	return setTagged(ctx, p.redisClient, key, data, fresh, tags)
+}
+
+func (p *Product) InvalidateRecommendations(
+	ctx context.Context,
+) (err error) {
+	defer observeCacheWrite(cacheNameProductRecommendations, cacheOperationInvalidateAll, time.Now(), &err)
+	iter := p.redisClient.Scan(ctx, 0, ProductRecommendPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		p.redisClient.Del(ctx, iter.Val())
+	}
+	return iter.Err()
+}
+
+func getProductTags(product *http.ProductResponseDto) tagSet {
+	tags := tagSet{}
+	tags.addProduct(*product)
+	return tags
+}
+
+func getProductListTags(pagination *http.PaginationResponseDto[http.ProductResponseDto]) tagSet {
+	tags := tagSet{}
+	tags.addListing(domain.ChangeEntityProduct)
+	for _, product := range pagination.Data {
+		tags.addProduct(product)
 	}
-	return nil
+	return tags
 }
 
 func (p *Product) getKey(param application.ProductCacheParam) string {
 	return fmt.Sprintf("%s%s", ProductGetPrefix, param.ID.String())
 }
 
+func (p *Product) getRecommendationsKey(param application.ProductCacheRecommendationsParam) string {
+	return fmt.Sprintf("%s%s:%s:limit:%d", ProductRecommendPrefix, param.Kind, param.ProductID, param.Limit)
+}
+
+func (p *Product) getSuggestionsKey(param application.ProductCacheSuggestionsParam) string {
+	search := strings.ToLower(strings.Join(strings.Fields(param.Search), " "))
+	return fmt.Sprintf("%ssearch:%s:limit:%d", ProductSuggestPrefix, search, param.Limit)
+}
+
 func (p *Product) getListKey(param application.ProductCacheListParam) string {
 	var parts []string
 	if len(param.IDs) > 0 {
@@ -160,13 +285,35 @@ func (p *Product) getListKey(param application.ProductCacheListParam) string {
 		parts = append(parts, fmt.Sprintf("category_ids:%s", strings.Join(ids, ",")))
 	}
 	parts = append(parts, fmt.Sprintf("deleted:%s", param.Deleted))
+	if len(param.Statuses) > 0 {
+		statuses := make([]string, len(param.Statuses))
+		for i, status := range param.Statuses {
+			statuses[i] = string(status)
+		}
+		sort.Strings(statuses)
+		parts = append(parts, fmt.Sprintf("statuses:%s", strings.Join(statuses, ",")))
+	}
+	if param.OnSale {
+		parts = append(parts, "on_sale")
+	}
+	if param.SortTrending {
+		parts = append(parts, "sort:trending")
+	}
 	if param.SortRating != "" {
 		parts = append(parts, fmt.Sprintf("sort_rating:%s", param.SortRating))
 	}
 	if param.SortPrice != "" {
 		parts = append(parts, fmt.Sprintf("sort_price:%s", param.SortPrice))
 	}
+	if param.SortDiscount != "" {
+		parts = append(parts, fmt.Sprintf("sort_discount:%s", param.SortDiscount))
+	}
 	parts = append(parts, fmt.Sprintf("limit:%d", param.Limit))
-	parts = append(parts, fmt.Sprintf("page:%d", param.Page))
+	if param.Cursor != nil {
+		parts = append(parts, fmt.Sprintf("cursor:%s", *param.Cursor))
+		parts = append(parts, fmt.Sprintf("count:%t", param.WithCount))
+	} else {
+		parts = append(parts, fmt.Sprintf("page:%d", param.Page))
+	}
 	return fmt.Sprintf("%s%s", ProductListPrefix, strings.Join(parts, ":"))
 }
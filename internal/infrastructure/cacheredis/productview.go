@@ -0,0 +1,105 @@
+package cacheredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"backend/internal/application"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+type ProductView struct {
+	redisClient *redis.Client
+}
+
+func ProvideProductView(redisClient *redis.Client) *ProductView {
+	return &ProductView{
+		redisClient: redisClient,
+	}
+}
+
+var _ application.ProductViewBuffer = (*ProductView)(nil)
+
+func (p *ProductView) Record(
+	ctx context.Context,
+	param application.ProductViewBufferRecordParam,
+) error {
+	dedupKey := fmt.Sprintf("%s%s:%s", ProductViewDedupPrefix, param.ProductID, param.ViewerKey)
+	isNew, err := p.redisClient.SetNX(ctx, dedupKey, 1, param.DedupTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !isNew {
+		return nil
+	}
+	return p.redisClient.HIncrBy(ctx, ProductViewPendingKey, param.ProductID.String(), 1).Err()
+}
+
+// drainScript claims the views counts for the flush holding token. The pending
+// hash is moved to the draining one unless a flush which failed without Ack
+// left it, the pending hash is then picked up by the next drain. It returns
+// nil while another flush holds the lock
+var drainScript = redis.NewScript(`
+if not redis.call('SET', KEYS[3], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return false
+end
+if redis.call('EXISTS', KEYS[2]) == 0 and redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('RENAME', KEYS[1], KEYS[2])
+end
+return redis.call('HGETALL', KEYS[2])
+`)
+
+// ackScript deletes the drained views counts only while the lock is still held
+// by token, a flush outliving its lease leaves them to the one which took over
+var ackScript = redis.NewScript(`
+if redis.call('GET', KEYS[2]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1], KEYS[2])
+end
+return 0
+`)
+
+func (p *ProductView) Drain(ctx context.Context) (*application.ProductViewDrain, error) {
+	token := uuid.NewString()
+	fields, err := drainScript.Run(
+		ctx,
+		p.redisClient,
+		[]string{ProductViewPendingKey, ProductViewDrainingKey, ProductViewDrainLockKey},
+		token,
+		ProductViewDrainLease.Milliseconds(),
+	).StringSlice()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	viewsCounts := make(map[uuid.UUID]int, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		productID, err := uuid.Parse(fields[i])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			continue
+		}
+		viewsCounts[productID] += count
+	}
+	return &application.ProductViewDrain{
+		Token:       token,
+		ViewsCounts: viewsCounts,
+	}, nil
+}
+
+func (p *ProductView) Ack(ctx context.Context, token string) error {
+	return ackScript.Run(
+		ctx,
+		p.redisClient,
+		[]string{ProductViewDrainingKey, ProductViewDrainLockKey},
+		token,
+	).Err()
+}
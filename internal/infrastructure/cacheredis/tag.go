@@ -0,0 +1,156 @@
+package cacheredis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/internal/application"
+	"backend/internal/delivery/http"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Tag invalidates the cache entries tagged with the entities of change
+// events. Every entry is added to a set per entity it contains, per kind of
+// entity it contains and per kind of listing it is, so a change only drops
+// the entries showing the changed entities, from Redis and from the local
+// tier of every instance
+type Tag struct {
+	redisClient *redis.Client
+	local       *Local
+}
+
+func ProvideTag(redisClient *redis.Client, local *Local) *Tag {
+	return &Tag{
+		redisClient: redisClient,
+		local:       local,
+	}
+}
+
+var _ application.ChangeEventHandler = (*Tag)(nil)
+
+// invalidateTagsScript unlinks the members of every tag along with the tag,
+// atomically so an entry tagged meanwhile is not lost from its tag
+var invalidateTagsScript = redis.NewScript(`
+for _, tag in ipairs(KEYS) do
+	local keys = redis.call('SMEMBERS', tag)
+	for i = 1, #keys, 1000 do
+		redis.call('UNLINK', unpack(keys, i, math.min(i + 999, #keys)))
+	end
+	redis.call('UNLINK', tag)
+end
+return 0
+`)
+
+func (t *Tag) Handle(
+	ctx context.Context,
+	events ...domain.ChangeEvent,
+) (err error) {
+	defer observeCacheWrite(cacheNameTag, cacheOperationInvalidate, time.Now(), &err)
+	tags := tagSet{}
+	for _, event := range events {
+		if len(event.IDs) == 0 {
+			tags[getEntityKindTag(event.Entity)] = struct{}{}
+		}
+		for _, id := range event.IDs {
+			tags[getEntityTag(event.Entity, id)] = struct{}{}
+		}
+		if event.Kind == domain.ChangeKindListing {
+			tags.addListing(event.Entity)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	keys := tags.keys()
+	if err := invalidateTagsScript.Run(ctx, t.redisClient, keys).Err(); err != nil {
+		return err
+	}
+	return t.local.invalidate(ctx, keys, nil)
+}
+
+type tagSet map[string]struct{}
+
+func (s tagSet) add(entity domain.ChangeEntity, ids ...uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+	s[getEntityKindTag(entity)] = struct{}{}
+	for _, id := range ids {
+		s[getEntityTag(entity, id)] = struct{}{}
+	}
+}
+
+func (s tagSet) addListing(entity domain.ChangeEntity) {
+	s[getListingTag(entity)] = struct{}{}
+}
+
+func (s tagSet) addProduct(product http.ProductResponseDto) {
+	s.add(domain.ChangeEntityProduct, product.ID)
+	s.add(domain.ChangeEntityCategory, product.Category.ID)
+	for _, attribute := range product.Attributes {
+		s.add(domain.ChangeEntityAttribute, attribute.ID)
+	}
+	for _, spec := range product.Specs {
+		s.add(domain.ChangeEntitySpecGroup, spec.Group.ID)
+	}
+	// The quantity and stocks of a bundle are derived from its components
+	for _, variant := range product.Variants {
+		for _, component := range variant.Components {
+			s.add(domain.ChangeEntityProductVariant, component.VariantID)
+		}
+	}
+}
+
+func (s tagSet) hasAny(tags []string) bool {
+	for _, tag := range tags {
+		if _, ok := s[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s tagSet) keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// setTagged sets key and adds it to tags, a tag lives as long as its longest
+// lived entry
+func setTagged(
+	ctx context.Context,
+	redisClient *redis.Client,
+	key string,
+	data []byte,
+	ttl time.Duration,
+	tags tagSet,
+) error {
+	pipe := redisClient.TxPipeline()
+	pipe.Set(ctx, key, data, ttl)
+	for tag := range tags {
+		pipe.SAdd(ctx, tag, key)
+		pipe.ExpireNX(ctx, tag, ttl)
+		pipe.ExpireGT(ctx, tag, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func getEntityTag(entity domain.ChangeEntity, id uuid.UUID) string {
+	return fmt.Sprintf("%s%s:%s", CacheTagPrefix, entity, id.String())
+}
+
+func getEntityKindTag(entity domain.ChangeEntity) string {
+	return fmt.Sprintf("%s%s", CacheTagPrefix, entity)
+}
+
+func getListingTag(entity domain.ChangeEntity) string {
+	return fmt.Sprintf("%s%s:listing", CacheTagPrefix, entity)
+}
@@ -0,0 +1,145 @@
+package emailsmtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+)
+
+// Sender submits emails to the SMTP server of SMTPHost, upgrading the
+// connection with STARTTLS when the server offers it. It authenticates only
+// when SMTPUsername is set, like a local relay or Mailpit in development
+type Sender struct {
+	srvCfg *config.Server
+}
+
+func ProvideSender(srvCfg *config.Server) *Sender {
+	return &Sender{
+		srvCfg: srvCfg,
+	}
+}
+
+var _ application.EmailSender = (*Sender)(nil)
+
+func (s *Sender) Send(ctx context.Context, param application.EmailSenderSendParam) error {
+	from, err := mail.ParseAddress(s.srvCfg.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	msg, err := buildMessage(from, param, time.Now())
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: s.srvCfg.SMTPTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.srvCfg.SMTPHost, strconv.Itoa(s.srvCfg.SMTPPort)))
+	if err != nil {
+		return err
+	}
+	// The deadline bounds the whole session, a stalled server would
+	// otherwise hold the email until its lease expires
+	if err := conn.SetDeadline(time.Now().Add(s.srvCfg.SMTPTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, s.srvCfg.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.srvCfg.SMTPHost}); err != nil {
+			return err
+		}
+	}
+	if s.srvCfg.SMTPUsername != "" {
+		auth := smtp.PlainAuth("", s.srvCfg.SMTPUsername, s.srvCfg.SMTPPassword, s.srvCfg.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(param.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage writes a multipart/alternative message with the text body
+// first, mail clients show the last part they can display
+func buildMessage(from *mail.Address, param application.EmailSenderSendParam, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", param.TextBody},
+		{"text/html; charset=utf-8", param.HTMLBody},
+	}
+	for _, part := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+	var msg bytes.Buffer
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", param.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", param.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", "<" + param.MessageID + "@" + domain + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", header[0], header[1])
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
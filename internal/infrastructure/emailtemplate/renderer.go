@@ -0,0 +1,139 @@
+package emailtemplate
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"backend/internal/application"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+// templates holds a directory per locale of domain.EmailLocales. Each kind
+// has a .txt file defining "subject" and "text", and a .html file defining
+// the "content" of the "layout" of its locale
+//
+//go:embed templates
+var templates embed.FS
+
+var kinds = []domain.EmailKind{
+	domain.EmailKindOrderPlaced,
+	domain.EmailKindOrderPaid,
+	domain.EmailKindOrderShipped,
+	domain.EmailKindOrderDelivered,
+	domain.EmailKindOrderCancelled,
+	domain.EmailKindOrderPaymentExpired,
+	domain.EmailKindRefundProcessed,
+}
+
+type templateKey struct {
+	locale string
+	kind   domain.EmailKind
+}
+
+type Renderer struct {
+	text map[templateKey]*texttemplate.Template
+	html map[templateKey]*htmltemplate.Template
+}
+
+// ProvideRenderer parses the embedded templates, they ship with the binary
+// so a template which does not parse is a bug and panics
+func ProvideRenderer() *Renderer {
+	r := &Renderer{
+		text: make(map[templateKey]*texttemplate.Template),
+		html: make(map[templateKey]*htmltemplate.Template),
+	}
+	for _, locale := range domain.EmailLocales {
+		funcs := templateFuncs(locale)
+		for _, kind := range kinds {
+			key := templateKey{locale: locale, kind: kind}
+			r.text[key] = texttemplate.Must(
+				texttemplate.New("").Funcs(funcs).ParseFS(
+					templates,
+					fmt.Sprintf("templates/%s/%s.txt", locale, kind),
+				),
+			)
+			r.html[key] = htmltemplate.Must(
+				htmltemplate.New("").Funcs(funcs).ParseFS(
+					templates,
+					fmt.Sprintf("templates/%s/layout.html", locale),
+					fmt.Sprintf("templates/%s/%s.html", locale, kind),
+				),
+			)
+		}
+	}
+	return r
+}
+
+var _ application.EmailRenderer = (*Renderer)(nil)
+
+func (r *Renderer) Render(param application.EmailRendererRenderParam) (*application.RenderedEmail, error) {
+	key := templateKey{locale: param.Locale, kind: param.Kind}
+	text, ok := r.text[key]
+	if !ok {
+		return nil, multierror.Append(
+			domain.ErrInvalid,
+			fmt.Errorf("no template of kind %q in locale %q", param.Kind, param.Locale),
+		)
+	}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", param.Data); err != nil {
+		return nil, multierror.Append(domain.ErrInternal, err)
+	}
+	if err := text.ExecuteTemplate(&textBody, "text", param.Data); err != nil {
+		return nil, multierror.Append(domain.ErrInternal, err)
+	}
+	if err := r.html[key].ExecuteTemplate(&htmlBody, "layout", param.Data); err != nil {
+		return nil, multierror.Append(domain.ErrInternal, err)
+	}
+	return &application.RenderedEmail{
+		Subject:  strings.TrimSpace(subject.String()),
+		TextBody: textBody.String(),
+		HTMLBody: htmlBody.String(),
+	}, nil
+}
+
+func templateFuncs(locale string) map[string]any {
+	return map[string]any{
+		"money":       func(amount int64) string { return formatMoney(locale, amount) },
+		"orderNumber": orderNumber,
+	}
+}
+
+// formatMoney formats an amount of VND the way readers of the locale expect,
+// 1.234.567 ₫ in Vietnamese and 1,234,567 VND in English
+func formatMoney(locale string, amount int64) string {
+	separator, suffix := ",", " VND"
+	if locale == "vi" {
+		separator, suffix = ".", " ₫"
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(separator)
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String() + suffix
+}
+
+// orderNumber is how an order is referred to in emails. It is the end of its
+// ID, the start of a UUIDv7 is a timestamp shared by orders placed together
+func orderNumber(id uuid.UUID) string {
+	s := id.String()
+	return strings.ToUpper(s[len(s)-12:])
+}
@@ -0,0 +1,138 @@
+package eventredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+	"backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Consumer reads the stream of Publisher in consumer groups. Each instance
+// is a consumer of the group, the messages it fails to handle stay pending
+// and are claimed again by any instance once idle for MinIdle
+type Consumer struct {
+	redisClient *redis.Client
+	stream      string
+	name        string
+	batchSize   int64
+	minIdle     time.Duration
+}
+
+func ProvideConsumer(redisClient *redis.Client, srvCfg *config.Server) *Consumer {
+	return &Consumer{
+		redisClient: redisClient,
+		stream:      srvCfg.EventStream,
+		name:        srvCfg.EventConsumerName,
+		batchSize:   int64(srvCfg.EventConsumerBatchSize),
+		minIdle:     srvCfg.EventConsumerMinIdle,
+	}
+}
+
+var _ application.EventConsumer = (*Consumer)(nil)
+
+func (c *Consumer) Consume(
+	ctx context.Context,
+	group string,
+	handle func(ctx context.Context, events []domain.OutboxEvent) error,
+) error {
+	// A new group starts from the oldest event kept in the stream
+	err := c.redisClient.XGroupCreateMkStream(ctx, c.stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return toDomainError(err)
+	}
+
+	// Retry the stale batches before reading new events
+	messages, _, err := c.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   c.stream,
+		Group:    group,
+		MinIdle:  c.minIdle,
+		Start:    "0-0",
+		Count:    c.batchSize,
+		Consumer: c.name,
+	}).Result()
+	if err != nil {
+		return toDomainError(err)
+	}
+	if len(messages) == 0 {
+		streams, err := c.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: c.name,
+			Streams:  []string{c.stream, ">"},
+			Count:    c.batchSize,
+			// Jobs run on a ticker, waiting for new events would only
+			// delay the next run
+			Block: -1,
+		}).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return toDomainError(err)
+		}
+		for _, stream := range streams {
+			messages = append(messages, stream.Messages...)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	// Malformed messages are acknowledged with the batch, they would fail
+	// on every retry
+	events := make([]domain.OutboxEvent, 0, len(messages))
+	ids := make([]string, 0, len(messages))
+	var parseErr error
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+		event, err := toDomainOutboxEvent(message)
+		if err != nil {
+			parseErr = errors.Join(parseErr, err)
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) > 0 {
+		if err := handle(ctx, events); err != nil {
+			return errors.Join(parseErr, err)
+		}
+	}
+	if err := c.redisClient.XAck(ctx, c.stream, group, ids...).Err(); err != nil {
+		return errors.Join(parseErr, toDomainError(err))
+	}
+	if parseErr != nil {
+		return toDomainError(parseErr)
+	}
+	return nil
+}
+
+func toDomainOutboxEvent(message redis.XMessage) (domain.OutboxEvent, error) {
+	value := func(key string) string {
+		v, _ := message.Values[key].(string)
+		return v
+	}
+	id, err := uuid.Parse(value("id"))
+	if err != nil {
+		return domain.OutboxEvent{}, fmt.Errorf("event message %s: id: %w", message.ID, err)
+	}
+	aggregateID, err := uuid.Parse(value("aggregate_id"))
+	if err != nil {
+		return domain.OutboxEvent{}, fmt.Errorf("event message %s: aggregate_id: %w", message.ID, err)
+	}
+	occurredAt, err := time.Parse(time.RFC3339Nano, value("occurred_at"))
+	if err != nil {
+		return domain.OutboxEvent{}, fmt.Errorf("event message %s: occurred_at: %w", message.ID, err)
+	}
+	return domain.OutboxEvent{
+		ID:            id,
+		AggregateType: domain.AggregateType(value("aggregate_type")),
+		AggregateID:   aggregateID,
+		Type:          domain.EventType(value("type")),
+		Payload:       []byte(value("payload")),
+		OccurredAt:    occurredAt,
+	}, nil
+}
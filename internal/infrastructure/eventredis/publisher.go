@@ -0,0 +1,51 @@
+package eventredis
+
+import (
+	"context"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+	"backend/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Publisher appends the events to a Redis Stream, consumers read it with
+// consumer groups. The stream is trimmed to about MaxLen entries so consumers
+// which fall too far behind miss the oldest events
+type Publisher struct {
+	redisClient *redis.Client
+	stream      string
+	maxLen      int64
+}
+
+func ProvidePublisher(redisClient *redis.Client, srvCfg *config.Server) *Publisher {
+	return &Publisher{
+		redisClient: redisClient,
+		stream:      srvCfg.EventStream,
+		maxLen:      srvCfg.EventStreamMaxLen,
+	}
+}
+
+var _ application.EventPublisher = (*Publisher)(nil)
+
+func (p *Publisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	err := p.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: true,
+		Values: []any{
+			"id", event.ID.String(),
+			"type", string(event.Type),
+			"aggregate_type", string(event.AggregateType),
+			"aggregate_id", event.AggregateID.String(),
+			"payload", event.Payload,
+			"occurred_at", event.OccurredAt.Format(time.RFC3339Nano),
+		},
+	}).Err()
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
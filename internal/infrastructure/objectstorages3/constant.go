@@ -3,4 +3,15 @@ package objectstorages3
 const (
 	S3ProductImageFolderTemp = "products/temp/"
 	S3ProductImageFolder     = "products/"
+	// S3ProductImageRenditionFolder holds the resized copies of each image
+	// under a folder named after the image
+	S3ProductImageRenditionFolder = "products/renditions/"
+	// S3DeleteObjectsLimit is the most keys a single DeleteObjects request takes
+	S3DeleteObjectsLimit = 1000
+	// S3ProductImageQuality is the WebP quality images are stored with
+	S3ProductImageQuality = 80
 )
+
+// S3ProductImageRenditionWidths are the widths images are resized to in
+// ascending order, only widths smaller than the image are made
+var S3ProductImageRenditionWidths = []int{160, 320, 640, 1280}
@@ -0,0 +1,209 @@
+package objectstorages3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"slices"
+
+	"backend/internal/domain"
+
+	"github.com/gen2brain/webp"
+	"github.com/hashicorp/go-multierror"
+	xdraw "golang.org/x/image/draw"
+)
+
+var imageFormats = []string{"jpeg", "png", "gif", "webp"}
+
+// decodeImage checks that data is an image of a supported format within
+// maxPixels and decodes it upright. Only pixels are kept, so metadata such as
+// EXIF is gone once the image is encoded again
+func decodeImage(data []byte, maxPixels int) (*image.NRGBA, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || !slices.Contains(imageFormats, format) {
+		return nil, multierror.Append(domain.ErrInvalid, errors.New("unsupported image format"))
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, multierror.Append(domain.ErrInvalid, errors.New("image dimensions out of range"))
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, multierror.Append(domain.ErrInvalid, errors.New("corrupted image"), err)
+	}
+	nrgba := image.NewNRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(nrgba, nrgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return orientImage(nrgba, imageOrientation(data, format)), nil
+}
+
+// resizeImage scales img down to width keeping its aspect ratio
+func resizeImage(img *image.NRGBA, width int) *image.NRGBA {
+	bounds := img.Bounds()
+	height := max(1, int(math.Round(float64(bounds.Dy())*float64(width)/float64(bounds.Dx()))))
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Src, nil)
+	return dst
+}
+
+func encodeWebP(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, webp.Options{Quality: S3ProductImageQuality}); err != nil {
+		return nil, multierror.Append(domain.ErrInternal, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// orientImage turns img upright according to its EXIF orientation, since the
+// tag is dropped along with the rest of the metadata
+func orientImage(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		for x := range dw {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], img.Pix[img.PixOffset(sx, sy):img.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// imageOrientation reads the EXIF orientation of the formats which may carry
+// it, 1 means upright and is returned when there is no such tag
+func imageOrientation(data []byte, format string) int {
+	switch format {
+	case "jpeg":
+		return jpegOrientation(data)
+	case "png":
+		return pngOrientation(data)
+	case "webp":
+		return webpOrientation(data)
+	default:
+		return 1
+	}
+}
+
+// jpegOrientation reads the orientation tag of the EXIF segment of a JPEG
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		// Start of scan, the metadata segments are all before it
+		if marker == 0xDA {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// pngOrientation reads the orientation tag of the eXIf chunk of a PNG, the
+// chunk comes before the image data
+func pngOrientation(data []byte) int {
+	if !bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")) {
+		return 1
+	}
+	for i := 8; i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		if chunkType == "IDAT" || i+12+length > len(data) {
+			return 1
+		}
+		if chunkType == "eXIf" {
+			return exifOrientation(data[i+8 : i+8+length])
+		}
+		// Length, type, data and CRC
+		i += 12 + length
+	}
+	return 1
+}
+
+// webpOrientation reads the orientation tag of the EXIF chunk of an extended
+// WebP, some encoders keep the JPEG prefix of the payload
+func webpOrientation(data []byte) int {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 1
+	}
+	for i := 12; i+8 <= len(data); {
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		if i+8+size > len(data) {
+			return 1
+		}
+		if string(data[i:i+4]) == "EXIF" {
+			return exifOrientation(bytes.TrimPrefix(data[i+8:i+8+size], []byte("Exif\x00\x00")))
+		}
+		// Chunks are padded to an even size
+		i += 8 + size + size%2
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := range entries {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
@@ -1,19 +1,28 @@
 package objectstorages3
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"image"
+	"io"
 	"net/url"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"backend/config"
 	"backend/internal/application"
 	"backend/internal/client"
 	"backend/internal/delivery/http"
+	"backend/internal/domain"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
 )
 
 type Product struct {
@@ -73,27 +82,179 @@ func (p *Product) GetDeleteImageURL(ctx context.Context, imageID uuid.UUID) (*ht
 	}, nil
 }
 
-func (p *Product) PersistImageFromTemp(ctx context.Context, key string, imageID uuid.UUID) error {
-	_, err := p.s3Client.S3Client.CopyObject(ctx, &s3.CopyObjectInput{
-		Bucket:     aws.String(p.cfgSrv.S3Bucket),
-		CopySource: aws.String(p.cfgSrv.S3Bucket + "/" + S3ProductImageFolderTemp + key),
-		Key:        aws.String(S3ProductImageFolder + imageID.String()),
+// PersistImageFromTemp checks the uploaded image, stores it as WebP without
+// its metadata along with a rendition for each smaller width, then removes
+// the upload
+func (p *Product) PersistImageFromTemp(ctx context.Context, key string, imageID uuid.UUID) (*[]domain.ProductImageRendition, error) {
+	object, err := p.s3Client.S3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.cfgSrv.S3Bucket),
+		Key:    aws.String(S3ProductImageFolderTemp + key),
 	})
 	if err != nil {
-		return ToDomainErrorFromS3(err)
+		return nil, ToDomainErrorFromS3(err)
+	}
+	defer func() { _ = object.Body.Close() }()
+	if aws.ToInt64(object.ContentLength) > p.cfgSrv.ProductImageMaxBytes {
+		return nil, multierror.Append(domain.ErrInvalid, errors.New("image is too large"))
+	}
+	data, err := io.ReadAll(io.LimitReader(object.Body, p.cfgSrv.ProductImageMaxBytes+1))
+	if err != nil {
+		return nil, ToDomainErrorFromS3(err)
+	}
+	if int64(len(data)) > p.cfgSrv.ProductImageMaxBytes {
+		return nil, multierror.Append(domain.ErrInvalid, errors.New("image is too large"))
+	}
+
+	img, err := decodeImage(data, p.cfgSrv.ProductImageMaxPixels)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.putImage(ctx, S3ProductImageFolder+imageID.String(), img); err != nil {
+		return nil, err
+	}
+	renditions := make([]domain.ProductImageRendition, 0, len(S3ProductImageRenditionWidths))
+	for _, width := range S3ProductImageRenditionWidths {
+		if width >= img.Bounds().Dx() {
+			break
+		}
+		resized := resizeImage(img, width)
+		renditionKey := buildImageRenditionKey(imageID, width)
+		if err := p.putImage(ctx, renditionKey, resized); err != nil {
+			return nil, err
+		}
+		renditions = append(renditions, domain.ProductImageRendition{
+			Width:  width,
+			Height: resized.Bounds().Dy(),
+			URL:    p.buildObjectURL(renditionKey),
+		})
 	}
+
 	_, err = p.s3Client.S3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(p.cfgSrv.S3Bucket),
 		Key:    aws.String(S3ProductImageFolderTemp + key),
 	})
+	if err != nil {
+		return nil, ToDomainErrorFromS3(err)
+	}
+	return &renditions, nil
+}
+
+func (p *Product) putImage(ctx context.Context, key string, img image.Image) error {
+	data, err := encodeWebP(img)
+	if err != nil {
+		return err
+	}
+	_, err = p.s3Client.S3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(p.cfgSrv.S3Bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("image/webp"),
+	})
 	if err != nil {
 		return ToDomainErrorFromS3(err)
 	}
 	return nil
 }
 
+// DeleteImages removes images with their renditions, renditions which were
+// never made are skipped by S3
+func (p *Product) DeleteImages(ctx context.Context, imageIDs []uuid.UUID) error {
+	keys := make([]string, 0, len(imageIDs)*(1+len(S3ProductImageRenditionWidths)))
+	for _, imageID := range imageIDs {
+		keys = append(keys, S3ProductImageFolder+imageID.String())
+		for _, width := range S3ProductImageRenditionWidths {
+			keys = append(keys, buildImageRenditionKey(imageID, width))
+		}
+	}
+	return p.DeleteObjects(ctx, keys)
+}
+
+// ListImageObjects lists the uploads, images and renditions last modified
+// before a time. Objects under the image folders whose key names none of them
+// are left out so they are never taken for orphans
+func (p *Product) ListImageObjects(
+	ctx context.Context,
+	param application.ListImageObjectsParam,
+) (*[]application.ProductImageObject, error) {
+	paginator := s3.NewListObjectsV2Paginator(p.s3Client.S3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(p.cfgSrv.S3Bucket),
+		Prefix: aws.String(S3ProductImageFolder),
+	})
+	objects := make([]application.ProductImageObject, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, ToDomainErrorFromS3(err)
+		}
+		for _, object := range page.Contents {
+			if !aws.ToTime(object.LastModified).Before(param.ModifiedBefore) {
+				continue
+			}
+			if imageObject, ok := parseImageObjectKey(aws.ToString(object.Key)); ok {
+				objects = append(objects, imageObject)
+			}
+		}
+	}
+	return &objects, nil
+}
+
+// DeleteObjects removes objects in batches of the most keys S3 takes at once,
+// keys which do not exist are skipped by S3
+func (p *Product) DeleteObjects(ctx context.Context, keys []string) error {
+	for start := 0; start < len(keys); start += S3DeleteObjectsLimit {
+		end := min(start+S3DeleteObjectsLimit, len(keys))
+		objects := make([]types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, types.ObjectIdentifier{
+				Key: aws.String(key),
+			})
+		}
+		_, err := p.s3Client.S3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(p.cfgSrv.S3Bucket),
+			Delete: &types.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return ToDomainErrorFromS3(err)
+		}
+	}
+	return nil
+}
+
 func (p *Product) BuildImageURL(imageID uuid.UUID) string {
+	return p.buildObjectURL(S3ProductImageFolder + imageID.String())
+}
+
+func (p *Product) buildObjectURL(key string) string {
 	u, _ := url.Parse(p.cfgSrv.S3Endpoint)
-	u.Path = path.Join(u.Path, p.cfgSrv.S3Bucket, S3ProductImageFolder, imageID.String())
+	u.Path = path.Join(u.Path, p.cfgSrv.S3Bucket, key)
 	return u.String()
 }
+
+func buildImageRenditionKey(imageID uuid.UUID, width int) string {
+	return S3ProductImageRenditionFolder + imageID.String() + "/" + strconv.Itoa(width) + ".webp"
+}
+
+// parseImageObjectKey reads the image an object belongs to from its key, the
+// reverse of the keys uploads, images and renditions are stored under
+func parseImageObjectKey(key string) (application.ProductImageObject, bool) {
+	object := application.ProductImageObject{Key: key}
+	var id string
+	switch {
+	case strings.HasPrefix(key, S3ProductImageFolderTemp):
+		object.Temp = true
+		return object, strings.TrimPrefix(key, S3ProductImageFolderTemp) != ""
+	case strings.HasPrefix(key, S3ProductImageRenditionFolder):
+		id, _, _ = strings.Cut(strings.TrimPrefix(key, S3ProductImageRenditionFolder), "/")
+	default:
+		id = strings.TrimPrefix(key, S3ProductImageFolder)
+	}
+	imageID, err := uuid.Parse(id)
+	if err != nil || imageID.String() != id {
+		return object, false
+	}
+	object.ImageID = imageID
+	return object, true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -31,6 +32,8 @@ func (v *VNPay) GetPaymentURL(
 	ctx context.Context,
 	param application.GetPaymentURLVNPayParam,
 ) (string, error) {
+	// The payment URL expires with the order, without a deadline it keeps the
+	// default TTL of the library
 	url, err := govnpay.GetPaymentURL(&govnpaymodels.GetPaymentURLRequest{
 		Version:        govnpay.Version210,
 		TmnCode:        v.srvCfg.VNPTMNCode,
@@ -39,6 +42,7 @@ func (v *VNPay) GetPaymentURL(
 		OrderInfo:      "",
 		TxnRef:         param.Order.ID.String(),
 		CreateDate:     param.Order.CreatedAt,
+		TTL:            v.srvCfg.OrderPaymentDeadlineVNPay,
 		IpAddr:         "0.0.0.0",
 		HashSecret:     v.srvCfg.VNPSecureSecret,
 		HashAlgo:       govnpayhelper.HashAlgo(v.srvCfg.VNPHashAlgo),
@@ -53,9 +57,10 @@ func (v *VNPay) GetPaymentURL(
 func (v *VNPay) VerifyIPN(
 	ctx context.Context,
 	param application.VerifyIPNVNPayParam,
-	getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error),
+	getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
 	onSuccess func(ctx context.Context, order *domain.Order) error,
 	onFailure func(ctx context.Context, order *domain.Order) error,
+	onRefundDue func(ctx context.Context, order *domain.Order) error,
 ) (code, message string, err error) {
 	codeEnum := govnpayerrors.IPNCodeTransactionSuccess
 	defer func() {
@@ -89,11 +94,38 @@ func (v *VNPay) VerifyIPN(
 		err = multierror.Append(domain.ErrInvalid, errors.New("invalid signature or tmn code"), err)
 		return code, message, err
 	}
-	order, err := getOrder(ctx, tnxRef)
+	paid := param.ResponseCode == govnpayerrors.MerchantRespSuccess.ToString() &&
+		param.TransactionStatus == govnpayerrors.TransactionSuccess.ToString()
+	// The deadline is checked against when the customer paid, the IPN may come
+	// well after it
+	var paidAt time.Time
+	if paid {
+		paidAt, err = parsePayDate(param.PayDate)
+		if err != nil {
+			codeEnum = govnpayerrors.IPNCodeOtherErrors
+			err = multierror.Append(domain.ErrInvalid, err)
+			return code, message, err
+		}
+	}
+	order, err := getOrder(ctx, tnxRef, paidAt)
 	if err != nil {
 		codeEnum = govnpayerrors.IPNCodeOrderNotFound
 		return code, message, err
 	}
+	// The order was already paid, or cancelled once its payment expired. It is
+	// left as is and VNPay is told it is confirmed so it stops notifying, the
+	// money captured for a cancelled order is owed back
+	if !order.IsPaymentPending() {
+		if paid && order.Status == domain.OrderStatusCancelled && !order.IsPaid {
+			if err := onRefundDue(ctx, order); err != nil {
+				codeEnum = govnpayerrors.IPNCodeOtherErrors
+				err = multierror.Append(domain.ErrInternal, err)
+				return code, message, err
+			}
+		}
+		codeEnum = govnpayerrors.IPNCodeOrderAlreadyConfirmed
+		return code, message, nil
+	}
 	amount, err := strconv.ParseInt(param.Amount, 10, 64)
 	if err != nil {
 		codeEnum = govnpayerrors.IPNCodeInvalidAmount
@@ -105,6 +137,16 @@ func (v *VNPay) VerifyIPN(
 		err = multierror.Append(domain.ErrInvalid, onFailure(ctx, order))
 		return code, message, err
 	}
+	// The IPN of a failed payment is confirmed like any other once the order
+	// is cancelled
+	if !paid {
+		if err := onFailure(ctx, order); err != nil {
+			codeEnum = govnpayerrors.IPNCodeOtherErrors
+			err = multierror.Append(domain.ErrInternal, err)
+			return code, message, err
+		}
+		return code, message, nil
+	}
 	if err := onSuccess(ctx, order); err != nil {
 		codeEnum = govnpayerrors.IPNCodeOtherErrors
 		err = multierror.Append(domain.ErrInternal, err)
@@ -112,3 +154,12 @@ func (v *VNPay) VerifyIPN(
 	}
 	return code, message, err
 }
+
+// parsePayDate reads vnp_PayDate, VNPay formats it in its own time zone
+func parsePayDate(payDate string) (time.Time, error) {
+	loc, err := time.LoadLocation(govnpay.DefaultTimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(govnpay.DefaultTimeFormat, payDate, loc)
+}
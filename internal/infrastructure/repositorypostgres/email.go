@@ -0,0 +1,166 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Email struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.EmailRepository = (*Email)(nil)
+
+func ProvideEmail(q *sqlc.Queries) *Email {
+	return &Email{queries: q}
+}
+
+func (r *Email) Count(
+	ctx context.Context,
+	params domain.EmailRepositoryCountParam,
+) (*int, error) {
+	count, err := r.queries.CountEmails(ctx, sqlc.CountEmailsParams{
+		UserIDs: params.UserIDs,
+		Status:  string(params.Status),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Email) List(
+	ctx context.Context,
+	params domain.EmailRepositoryListParam,
+) (*[]domain.Email, error) {
+	emails, err := r.queries.ListEmails(ctx, sqlc.ListEmailsParams{
+		UserIDs: params.UserIDs,
+		Status:  string(params.Status),
+		Limit:   int32(params.Limit),
+		Offset:  int32(params.Offset),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.Email, 0, len(emails))
+	for _, email := range emails {
+		result = append(result, toDomainEmail(email))
+	}
+	return &result, nil
+}
+
+func (r *Email) Create(
+	ctx context.Context,
+	params domain.EmailRepositoryCreateParam,
+) error {
+	if len(params.Emails) == 0 {
+		return nil
+	}
+	var arg sqlc.InsertEmailsParams
+	for _, e := range params.Emails {
+		arg.IDs = append(arg.IDs, e.ID)
+		arg.Kinds = append(arg.Kinds, string(e.Kind))
+		arg.EventIDs = append(arg.EventIDs, e.EventID)
+		arg.UserIDs = append(arg.UserIDs, e.UserID)
+		arg.Recipients = append(arg.Recipients, e.Recipient)
+		arg.Locales = append(arg.Locales, e.Locale)
+		arg.Subjects = append(arg.Subjects, e.Subject)
+		arg.TextBodies = append(arg.TextBodies, e.TextBody)
+		arg.HTMLBodies = append(arg.HTMLBodies, e.HTMLBody)
+		arg.NextAttemptAts = append(arg.NextAttemptAts, pgtype.Timestamptz{
+			Time:  e.NextAttemptAt,
+			Valid: true,
+		})
+		arg.CreatedAts = append(arg.CreatedAts, pgtype.Timestamptz{
+			Time:  e.CreatedAt,
+			Valid: true,
+		})
+		arg.UpdatedAts = append(arg.UpdatedAts, pgtype.Timestamptz{
+			Time:  e.UpdatedAt,
+			Valid: true,
+		})
+	}
+	if err := r.queries.InsertEmails(ctx, arg); err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Email) Save(
+	ctx context.Context,
+	params domain.EmailRepositorySaveParam,
+) error {
+	err := r.queries.UpdateEmail(ctx, sqlc.UpdateEmailParams{
+		Status:    string(params.Email.Status),
+		Attempts:  int32(params.Email.Attempts),
+		LastError: params.Email.Error,
+		NextAttemptAt: pgtype.Timestamptz{
+			Time:  params.Email.NextAttemptAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  params.Email.UpdatedAt,
+			Valid: true,
+		},
+		SentAt: pgtype.Timestamptz{
+			Time:  params.Email.SentAt,
+			Valid: !params.Email.SentAt.IsZero(),
+		},
+		ID: params.Email.ID,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Email) Claim(
+	ctx context.Context,
+	params domain.EmailRepositoryClaimParam,
+) (*[]domain.Email, error) {
+	emails, err := r.queries.ClaimEmails(ctx, sqlc.ClaimEmailsParams{
+		LeaseUntil: pgtype.Timestamptz{
+			Time:  params.LeaseUntil,
+			Valid: true,
+		},
+		Now: pgtype.Timestamptz{
+			Time:  params.Now,
+			Valid: true,
+		},
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.Email, 0, len(emails))
+	for _, email := range emails {
+		result = append(result, toDomainEmail(email))
+	}
+	return &result, nil
+}
+
+func toDomainEmail(e sqlc.Email) domain.Email {
+	return domain.Email{
+		ID:            e.ID,
+		Kind:          domain.EmailKind(e.Kind),
+		EventID:       e.EventID,
+		UserID:        e.UserID,
+		Recipient:     e.Recipient,
+		Locale:        e.Locale,
+		Subject:       e.Subject,
+		TextBody:      e.TextBody,
+		HTMLBody:      e.HTMLBody,
+		Status:        domain.EmailStatus(e.Status),
+		Attempts:      int(e.Attempts),
+		Error:         e.LastError,
+		NextAttemptAt: e.NextAttemptAt.Time,
+		CreatedAt:     e.CreatedAt.Time,
+		UpdatedAt:     e.UpdatedAt.Time,
+		SentAt:        e.SentAt.Time,
+	}
+}
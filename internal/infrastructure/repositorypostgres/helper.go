@@ -3,6 +3,7 @@ package repositorypostgres
 import (
 	"math/big"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -37,3 +38,10 @@ func int64ToNumeric(value int64) pgtype.Numeric {
 		Valid: true,
 	}
 }
+
+func uuidToNullableUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: id != uuid.Nil,
+	}
+}
@@ -0,0 +1,113 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Notification struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.NotificationRepository = (*Notification)(nil)
+
+func ProvideNotification(q *sqlc.Queries) *Notification {
+	return &Notification{
+		queries: q,
+	}
+}
+
+func (r *Notification) List(
+	ctx context.Context,
+	params domain.NotificationRepositoryListParam,
+) (*[]domain.Notification, error) {
+	notificationEntities, err := r.queries.ListNotifications(ctx, sqlc.ListNotificationsParams{
+		Staff:  params.Staff,
+		UserID: params.UserID,
+		Unread: params.Unread,
+		Offset: int32(params.Offset),
+		Limit:  int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	notifications := make([]domain.Notification, 0, len(notificationEntities))
+	for _, n := range notificationEntities {
+		notifications = append(notifications, toDomainNotification(n))
+	}
+	return &notifications, nil
+}
+
+func (r *Notification) Count(
+	ctx context.Context,
+	params domain.NotificationRepositoryCountParam,
+) (*int, error) {
+	count, err := r.queries.CountNotifications(ctx, sqlc.CountNotificationsParams{
+		Staff:  params.Staff,
+		UserID: params.UserID,
+		Unread: params.Unread,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Notification) Get(
+	ctx context.Context,
+	params domain.NotificationRepositoryGetParam,
+) (*domain.Notification, error) {
+	notificationEntity, err := r.queries.GetNotification(ctx, sqlc.GetNotificationParams{
+		ID: params.NotificationID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(toDomainNotification(notificationEntity)), nil
+}
+
+func (r *Notification) Save(
+	ctx context.Context,
+	params domain.NotificationRepositorySaveParam,
+) error {
+	notification := params.Notification
+	err := r.queries.UpsertNotification(ctx, sqlc.UpsertNotificationParams{
+		ID:               notification.ID,
+		UserID:           uuidToNullableUUID(notification.UserID),
+		Kind:             string(notification.Kind),
+		ProductID:        uuidToNullableUUID(notification.ProductID),
+		ProductVariantID: uuidToNullableUUID(notification.ProductVariantID),
+		Quantity:         int32(notification.Quantity),
+		CreatedAt: pgtype.Timestamptz{
+			Time:  notification.CreatedAt,
+			Valid: true,
+		},
+		ReadAt: pgtype.Timestamptz{
+			Time:  notification.ReadAt,
+			Valid: !notification.ReadAt.IsZero(),
+		},
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func toDomainNotification(n sqlc.Notification) domain.Notification {
+	return domain.Notification{
+		ID:               n.ID,
+		UserID:           fromPgValidToNonPtr(uuid.UUID(n.UserID.Bytes), n.UserID.Valid, uuid.Nil),
+		Kind:             domain.NotificationKind(n.Kind),
+		ProductID:        fromPgValidToNonPtr(uuid.UUID(n.ProductID.Bytes), n.ProductID.Valid, uuid.Nil),
+		ProductVariantID: fromPgValidToNonPtr(uuid.UUID(n.ProductVariantID.Bytes), n.ProductVariantID.Valid, uuid.Nil),
+		Quantity:         int(n.Quantity),
+		CreatedAt:        n.CreatedAt.Time,
+		ReadAt:           n.ReadAt.Time,
+	}
+}
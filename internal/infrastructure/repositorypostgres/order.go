@@ -32,6 +32,7 @@ func (r *Order) List(ctx context.Context, params domain.OrderRepositoryListParam
 		StatusIDs:   params.StatusIDs,
 		StatusNames: params.StatusNames,
 		StatusName:  params.StatusName,
+		AfterID:     params.AfterID,
 		Offset:      int32(params.Offset),
 		Limit:       int32(params.Limit),
 	})
@@ -78,6 +79,7 @@ func (r *Order) List(ctx context.Context, params domain.OrderRepositoryListParam
 			ProductVariantID: item.ProductVariantID,
 			Quantity:         int(item.Quantity),
 			Price:            numericToInt64(item.Price),
+			WarehouseID:      fromPgValidToNonPtr(uuid.UUID(item.WarehouseID.Bytes), item.WarehouseID.Valid, uuid.Nil),
 		})
 	}
 
@@ -165,6 +167,7 @@ func (r *Order) Get(ctx context.Context, params domain.OrderRepositoryGetParam)
 			ProductVariantID: item.ProductVariantID,
 			Quantity:         int(item.Quantity),
 			Price:            numericToInt64(item.Price),
+			WarehouseID:      fromPgValidToNonPtr(uuid.UUID(item.WarehouseID.Bytes), item.WarehouseID.Valid, uuid.Nil),
 		})
 	}
 
@@ -200,6 +203,22 @@ func (r *Order) Get(ctx context.Context, params domain.OrderRepositoryGetParam)
 	return order, nil
 }
 
+func (r *Order) ListUnpaidIDs(ctx context.Context, params domain.OrderRepositoryListUnpaidIDsParam) (*[]uuid.UUID, error) {
+	ids, err := r.queries.ListUnpaidOrderIDs(ctx, sqlc.ListUnpaidOrderIDsParams{
+		StatusName:   string(params.Status),
+		ProviderName: string(params.Provider),
+		CreatedBefore: pgtype.Timestamptz{
+			Time:  params.CreatedBefore,
+			Valid: true,
+		},
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return &ids, nil
+}
+
 func (r *Order) Save(ctx context.Context, params domain.OrderRepositorySaveParam) error {
 	tx, err := r.conn.Begin(ctx)
 	if err != nil {
@@ -208,6 +227,18 @@ func (r *Order) Save(ctx context.Context, params domain.OrderRepositorySaveParam
 	qtx := r.queries.WithTx(tx)
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	if params.IfPaymentPending {
+		stored, err := qtx.LockOrderPaymentState(ctx, sqlc.LockOrderPaymentStateParams{
+			ID: params.Order.ID,
+		})
+		if err != nil {
+			return toDomainError(err)
+		}
+		if stored.StatusName != string(domain.OrderStatusPending) || stored.IsPaid {
+			return domain.ErrConflict
+		}
+	}
+
 	status, err := qtx.GetOrderStatus(ctx, sqlc.GetOrderStatusParams{
 		Name: string(params.Order.Status),
 	})
@@ -258,6 +289,7 @@ func (r *Order) Save(ctx context.Context, params domain.OrderRepositorySaveParam
 			ProductVariantID: item.ProductVariantID,
 			Quantity:         int32(item.Quantity),
 			Price:            int64ToNumeric(item.Price),
+			WarehouseID:      uuidToNullableUUID(item.WarehouseID),
 		}
 	}
 	_, err = qtx.InsertTempTableOrderItems(ctx, itemParams)
@@ -270,6 +302,17 @@ func (r *Order) Save(ctx context.Context, params domain.OrderRepositorySaveParam
 		return toDomainError(err)
 	}
 
+	err = insertOutboxEvents(ctx, *qtx, domain.AggregateTypeOrder, params.Order.ID, params.Order.Events)
+	if err != nil {
+		return toDomainError(err)
+	}
+
+	for _, product := range params.Products {
+		if err := saveProduct(ctx, *qtx, product); err != nil {
+			return toDomainError(err)
+		}
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return toDomainError(err)
@@ -0,0 +1,150 @@
+package repositorypostgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Outbox struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.OutboxRepository = (*Outbox)(nil)
+
+func ProvideOutbox(q *sqlc.Queries) *Outbox {
+	return &Outbox{
+		queries: q,
+	}
+}
+
+func (r *Outbox) Claim(
+	ctx context.Context,
+	params domain.OutboxRepositoryClaimParam,
+) (*[]domain.OutboxEvent, error) {
+	outboxEntities, err := r.queries.ClaimOutboxEvents(ctx, sqlc.ClaimOutboxEventsParams{
+		LeaseUntil: pgtype.Timestamptz{
+			Time:  params.LeaseUntil,
+			Valid: true,
+		},
+		Now: pgtype.Timestamptz{
+			Time:  params.Now,
+			Valid: true,
+		},
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	events := make([]domain.OutboxEvent, 0, len(outboxEntities))
+	for _, e := range outboxEntities {
+		events = append(events, toDomainOutboxEvent(e))
+	}
+	return &events, nil
+}
+
+func (r *Outbox) MarkPublished(
+	ctx context.Context,
+	params domain.OutboxRepositoryMarkPublishedParam,
+) error {
+	if len(params.IDs) == 0 {
+		return nil
+	}
+	err := r.queries.MarkOutboxEventsPublished(ctx, sqlc.MarkOutboxEventsPublishedParams{
+		PublishedAt: pgtype.Timestamptz{
+			Time:  params.PublishedAt,
+			Valid: true,
+		},
+		IDs: params.IDs,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Outbox) MarkFailed(
+	ctx context.Context,
+	params domain.OutboxRepositoryMarkFailedParam,
+) error {
+	err := r.queries.MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		NextAttemptAt: pgtype.Timestamptz{
+			Time:  params.NextAttemptAt,
+			Valid: true,
+		},
+		LastError: params.Error,
+		ID:        params.ID,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Outbox) DeletePublished(
+	ctx context.Context,
+	params domain.OutboxRepositoryDeletePublishedParam,
+) (*int, error) {
+	count, err := r.queries.DeletePublishedOutboxEvents(ctx, sqlc.DeletePublishedOutboxEventsParams{
+		Before: pgtype.Timestamptz{
+			Time:  params.Before,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+// insertOutboxEvents writes the events recorded by an aggregate in the
+// transaction saving it, so they are only published if the save commits
+func insertOutboxEvents(
+	ctx context.Context,
+	qtx sqlc.Queries,
+	aggregateType domain.AggregateType,
+	aggregateID uuid.UUID,
+	events []domain.Event,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+	param := make([]sqlc.InsertOutboxEventsParams, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e.Payload)
+		if err != nil {
+			return err
+		}
+		param = append(param, sqlc.InsertOutboxEventsParams{
+			ID:            e.ID,
+			AggregateType: string(aggregateType),
+			AggregateID:   aggregateID,
+			Type:          string(e.Type),
+			Payload:       payload,
+			OccurredAt: pgtype.Timestamptz{
+				Time:  e.OccurredAt,
+				Valid: true,
+			},
+		})
+	}
+	_, err := qtx.InsertOutboxEvents(ctx, param)
+	return err
+}
+
+func toDomainOutboxEvent(e sqlc.Outbox) domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:            e.ID,
+		AggregateType: domain.AggregateType(e.AggregateType),
+		AggregateID:   e.AggregateID,
+		Type:          domain.EventType(e.Type),
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt.Time,
+		Attempts:      int(e.Attempts),
+	}
+}
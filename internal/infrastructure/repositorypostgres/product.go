@@ -2,13 +2,17 @@ package repositorypostgres
 
 import (
 	"context"
+	"math"
 	"math/big"
+	"slices"
+	"time"
 
 	"backend/internal/domain"
 	"backend/internal/helper/ptr"
 	"backend/internal/infrastructure/repositorypostgres/sqlc"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -34,20 +38,32 @@ func (r *Product) List(
 	ctx context.Context,
 	params domain.ProductRepositoryListParam,
 ) (*[]domain.Product, error) {
-	productEntities, err := r.queries.ListProducts(ctx, sqlc.ListProductsParams{
-		IDs:         params.IDs,
-		Search:      params.Search,
-		MinPrice:    int64ToNumeric(params.MinPrice),
-		MaxPrice:    int64ToNumeric(params.MaxPrice),
-		Rating:      float32(params.Rating),
-		VariantIDs:  params.VariantIDs,
-		CategoryIDs: params.CategoryIDs,
-		Deleted:     string(params.Deleted),
-		SortRating:  params.SortRating,
-		SortPrice:   params.SortPrice,
-		Limit:       int32(params.Limit),
-		Offset:      int32(params.Offset),
-	})
+	listParams := sqlc.ListProductsParams{
+		IDs:          params.IDs,
+		Search:       params.Search,
+		MinPrice:     int64ToNumeric(params.MinPrice),
+		MaxPrice:     int64ToNumeric(params.MaxPrice),
+		Rating:       float32(params.Rating),
+		VariantIDs:   params.VariantIDs,
+		CategoryIDs:  params.CategoryIDs,
+		Deleted:      string(params.Deleted),
+		Statuses:     productStatusesToStrings(params.Statuses),
+		OnSale:       params.OnSale,
+		SortTrending: params.SortTrending,
+		SortRating:   params.SortRating,
+		SortPrice:    params.SortPrice,
+		SortDiscount: params.SortDiscount,
+		Limit:        int32(params.Limit),
+		Offset:       int32(params.Offset),
+	}
+	if params.After != nil {
+		listParams.CursorID = params.After.ID
+		listParams.CursorTrendingScore = float32(params.After.TrendingScore)
+		listParams.CursorRating = float32(params.After.Rating)
+		listParams.CursorPrice = int64ToNumeric(params.After.Price)
+		listParams.CursorDiscount = int32(params.After.Discount)
+	}
+	productEntities, err := r.queries.ListProducts(ctx, listParams)
 	if err != nil {
 		return nil, toDomainError(err)
 	}
@@ -75,6 +91,8 @@ func (r *Product) Count(
 		VariantIDs:  params.VariantIDs,
 		CategoryIDs: params.CategoryIDs,
 		Deleted:     string(params.Deleted),
+		Statuses:    productStatusesToStrings(params.Statuses),
+		OnSale:      params.OnSale,
 	})
 	if err != nil {
 		return nil, toDomainError(err)
@@ -82,10 +100,33 @@ func (r *Product) Count(
 	return ptr.To(int(productEntities)), nil
 }
 
-// FIXME: Missing deleted
+func (r *Product) ListSuggestions(
+	ctx context.Context,
+	params domain.ProductRepositoryListSuggestionsParam,
+) (*[]domain.ProductSuggestion, error) {
+	suggestionEntities, err := r.queries.ListProductSuggestions(ctx, sqlc.ListProductSuggestionsParams{
+		Search: params.Search,
+		Limit:  int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	suggestions := make([]domain.ProductSuggestion, 0, len(suggestionEntities))
+	for _, s := range suggestionEntities {
+		suggestions = append(suggestions, domain.ProductSuggestion{
+			ID:    s.ID,
+			Type:  domain.ProductSuggestionType(s.Type),
+			Text:  s.Text,
+			Score: float64(s.Score),
+		})
+	}
+	return &suggestions, nil
+}
+
 func (r *Product) Get(ctx context.Context, params domain.ProductRepositoryGetParam) (*domain.Product, error) {
 	productEntity, err := r.queries.GetProduct(ctx, sqlc.GetProductParams{
-		ID: params.ProductID,
+		ID:      params.ProductID,
+		Deleted: string(params.Deleted),
 	})
 	if err != nil {
 		return nil, toDomainError(err)
@@ -96,21 +137,33 @@ func (r *Product) Get(ctx context.Context, params domain.ProductRepositoryGetPar
 		Description:   productEntity.Description,
 		ViewsCount:    int(productEntity.ViewsCount),
 		TotalPurchase: int(productEntity.TotalPurchase),
-		TrendingScore: int64(productEntity.TrendingScore),
+		TrendingScore: float64(productEntity.TrendingScore),
 		Price:         numericToInt64(productEntity.Price),
 		Rating:        float64(productEntity.Rating),
 		CategoryID:    productEntity.CategoryID,
 		CreatedAt:     productEntity.CreatedAt.Time,
 		UpdatedAt:     productEntity.UpdatedAt.Time,
 		DeletedAt:     productEntity.DeletedAt.Time,
+		Status:        domain.ProductStatus(productEntity.Status),
+		PublishAt:     productEntity.PublishAt.Time,
+		UnpublishAt:   productEntity.UnpublishAt.Time,
+		Discount:      int(productEntity.Discount),
+		Type:          domain.ProductType(productEntity.Type),
 	}
 	if err := getAttributeValueIDs(ctx, *r.queries, product); err != nil {
 		return nil, toDomainError(err)
 	}
-	if err := getOptionsAndValues(ctx, *r.queries, product); err != nil {
+	if err := getSpecs(ctx, *r.queries, product); err != nil {
+		return nil, toDomainError(err)
+	}
+	childrenDeleted := domain.DeletedExcludeParam
+	if params.Deleted == domain.DeletedOnlyParam || params.Deleted == domain.DeletedAllParam {
+		childrenDeleted = domain.DeletedAllParam
+	}
+	if err := getOptionsAndValues(ctx, *r.queries, product, childrenDeleted); err != nil {
 		return nil, toDomainError(err)
 	}
-	if err := getVariants(ctx, *r.queries, product); err != nil {
+	if err := getVariants(ctx, *r.queries, product, childrenDeleted); err != nil {
 		return nil, toDomainError(err)
 	}
 	if err := getImages(ctx, *r.queries, product); err != nil {
@@ -138,12 +191,41 @@ func getAttributeValueIDs(
 	return nil
 }
 
+func getSpecs(
+	ctx context.Context,
+	queries sqlc.Queries,
+	product *domain.Product,
+) error {
+	productSpecEntities, err := queries.ListProductSpecs(ctx, sqlc.ListProductSpecsParams{
+		ProductIDs: []uuid.UUID{product.ID},
+	})
+	if err != nil {
+		return err
+	}
+	specs := make([]domain.ProductSpec, 0, len(productSpecEntities))
+	for _, ps := range productSpecEntities {
+		spec := domain.ProductSpec{
+			SpecID:  ps.SpecID,
+			Number:  ps.NumberValue,
+			Boolean: ps.BooleanValue,
+		}
+		if ps.EnumValue != nil {
+			spec.Enum = *ps.EnumValue
+		}
+		specs = append(specs, spec)
+	}
+	product.Specs = specs
+	return nil
+}
+
 func getOptionsAndValues(ctx context.Context,
 	queries sqlc.Queries,
 	product *domain.Product,
+	deleted domain.DeletedParam,
 ) error {
 	optionEntities, err := queries.ListOptions(ctx, sqlc.ListOptionsParams{
 		ProductID: product.ID,
+		Deleted:   string(deleted),
 	})
 	if err != nil {
 		return err
@@ -154,6 +236,7 @@ func getOptionsAndValues(ctx context.Context,
 	}
 	optionValueEntities, err := queries.ListOptionValues(ctx, sqlc.ListOptionValuesParams{
 		OptionIds: optionIDs,
+		Deleted:   string(deleted),
 	})
 	if err != nil {
 		return err
@@ -183,9 +266,11 @@ func getVariants(
 	ctx context.Context,
 	queries sqlc.Queries,
 	product *domain.Product,
+	deleted domain.DeletedParam,
 ) error {
 	variantEntities, err := queries.ListProductVariants(ctx, sqlc.ListProductVariantsParams{
 		ProductID: product.ID,
+		Deleted:   string(deleted),
 	})
 	if err != nil {
 		return err
@@ -193,14 +278,19 @@ func getVariants(
 	variants := make([]domain.ProductVariant, 0, len(variantEntities))
 	for _, variant := range variantEntities {
 		variants = append(variants, domain.ProductVariant{
-			ID:            variant.ID,
-			SKU:           variant.SKU,
-			Price:         numericToInt64(variant.Price),
-			Quantity:      int(variant.Quantity),
-			PurchaseCount: int(variant.PurchaseCount),
-			CreatedAt:     variant.CreatedAt.Time,
-			UpdatedAt:     variant.UpdatedAt.Time,
-			DeletedAt:     variant.DeletedAt.Time,
+			ID:                variant.ID,
+			SKU:               variant.SKU,
+			Price:             numericToInt64(variant.Price),
+			Quantity:          int(variant.Quantity),
+			PurchaseCount:     int(variant.PurchaseCount),
+			LowStockThreshold: int(variant.LowStockThreshold),
+			CreatedAt:         variant.CreatedAt.Time,
+			UpdatedAt:         variant.UpdatedAt.Time,
+			DeletedAt:         variant.DeletedAt.Time,
+			CompareAtPrice:    numericToInt64(variant.CompareAtPrice),
+			SalePrice:         numericToInt64(variant.SalePrice),
+			SaleStartsAt:      variant.SaleStartsAt.Time,
+			SaleEndsAt:        variant.SaleEndsAt.Time,
 		})
 	}
 	product.Variants = variants
@@ -241,10 +331,86 @@ func getVariants(
 			variants[i].OptionValues = ovs
 		}
 	}
+	warehouseStockEntities, err := queries.ListWarehouseStocks(ctx, sqlc.ListWarehouseStocksParams{
+		ProductVariantIDs: productVariantIDs,
+	})
+	if err != nil {
+		return err
+	}
+	variantIDStocksMap := make(map[uuid.UUID][]domain.WarehouseStock)
+	for _, ws := range warehouseStockEntities {
+		variantIDStocksMap[ws.ProductVariantID] = append(variantIDStocksMap[ws.ProductVariantID], domain.WarehouseStock{
+			WarehouseID: ws.WarehouseID,
+			Quantity:    int(ws.Quantity),
+		})
+	}
+	for i, variant := range variants {
+		variants[i].Stocks = variantIDStocksMap[variant.ID]
+	}
+	if err := getVariantComponents(ctx, queries, variants, productVariantIDs); err != nil {
+		return err
+	}
 	product.Variants = variants
 	return nil
 }
 
+// getVariantComponents loads the components of bundle variants and derives
+// their stock from the components
+func getVariantComponents(
+	ctx context.Context,
+	queries sqlc.Queries,
+	variants []domain.ProductVariant,
+	productVariantIDs []uuid.UUID,
+) error {
+	componentEntities, err := queries.ListProductVariantComponents(ctx, sqlc.ListProductVariantComponentsParams{
+		BundleVariantIDs: productVariantIDs,
+	})
+	if err != nil {
+		return err
+	}
+	if len(componentEntities) == 0 {
+		return nil
+	}
+	variantIDComponentsMap := make(map[uuid.UUID][]domain.BundleComponent)
+	componentIDComponentMap := make(map[uuid.UUID]*domain.ProductVariant)
+	componentIDs := make([]uuid.UUID, 0, len(componentEntities))
+	for _, c := range componentEntities {
+		variantIDComponentsMap[c.BundleVariantID] = append(variantIDComponentsMap[c.BundleVariantID], domain.BundleComponent{
+			VariantID: c.ComponentVariantID,
+			Quantity:  int(c.Quantity),
+		})
+		if _, exists := componentIDComponentMap[c.ComponentVariantID]; !exists {
+			componentIDComponentMap[c.ComponentVariantID] = &domain.ProductVariant{
+				ID:       c.ComponentVariantID,
+				Quantity: int(c.ComponentQuantity),
+			}
+			componentIDs = append(componentIDs, c.ComponentVariantID)
+		}
+	}
+	componentStockEntities, err := queries.ListWarehouseStocks(ctx, sqlc.ListWarehouseStocksParams{
+		ProductVariantIDs: componentIDs,
+	})
+	if err != nil {
+		return err
+	}
+	for _, ws := range componentStockEntities {
+		component := componentIDComponentMap[ws.ProductVariantID]
+		component.Stocks = append(component.Stocks, domain.WarehouseStock{
+			WarehouseID: ws.WarehouseID,
+			Quantity:    int(ws.Quantity),
+		})
+	}
+	components := make([]domain.ProductVariant, 0, len(componentIDs))
+	for _, componentID := range componentIDs {
+		components = append(components, *componentIDComponentMap[componentID])
+	}
+	for i, variant := range variants {
+		variants[i].Components = variantIDComponentsMap[variant.ID]
+		variants[i].DeriveBundleStock(components)
+	}
+	return nil
+}
+
 func getImages(
 	ctx context.Context,
 	queries sqlc.Queries,
@@ -256,17 +422,39 @@ func getImages(
 	if err != nil {
 		return err
 	}
+	imageIDs := make([]uuid.UUID, 0, len(imagesEntities))
+	for _, imgEntity := range imagesEntities {
+		imageIDs = append(imageIDs, imgEntity.ID)
+	}
+	renditionEntities, err := queries.ListProductImageRenditions(ctx, sqlc.ListProductImageRenditionsParams{
+		ProductImageIDs: imageIDs,
+	})
+	if err != nil {
+		return err
+	}
+	imageIDRenditionsMap := make(map[uuid.UUID][]domain.ProductImageRendition, len(imagesEntities))
+	for _, renditionEntity := range renditionEntities {
+		imageIDRenditionsMap[renditionEntity.ProductImageID] = append(
+			imageIDRenditionsMap[renditionEntity.ProductImageID],
+			domain.ProductImageRendition{
+				Width:  int(renditionEntity.Width),
+				Height: int(renditionEntity.Height),
+				URL:    renditionEntity.URL,
+			},
+		)
+	}
 	variantIDvariantMap := make(map[uuid.UUID]*domain.ProductVariant, len(product.Variants))
 	for i, variant := range product.Variants {
 		variantIDvariantMap[variant.ID] = &product.Variants[i]
 	}
 	for _, imgEntity := range imagesEntities {
 		img := domain.ProductImage{
-			ID:        imgEntity.ID,
-			URL:       imgEntity.URL,
-			Order:     int(imgEntity.Order),
-			CreatedAt: imgEntity.CreatedAt.Time,
-			DeletedAt: imgEntity.DeletedAt.Time,
+			ID:         imgEntity.ID,
+			URL:        imgEntity.URL,
+			Order:      int(imgEntity.Order),
+			CreatedAt:  imgEntity.CreatedAt.Time,
+			DeletedAt:  imgEntity.DeletedAt.Time,
+			Renditions: imageIDRenditionsMap[imgEntity.ID],
 		}
 		if !imgEntity.ProductVariantID.Valid {
 			product.Images = append(product.Images, img)
@@ -286,27 +474,381 @@ func (r *Product) Save(ctx context.Context, params domain.ProductRepositorySaveP
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 	qtx := r.queries.WithTx(tx)
-	if err := upsertProduct(ctx, *qtx, params.Product); err != nil {
+	if err := saveProduct(ctx, *qtx, params.Product); err != nil {
 		return toDomainError(err)
 	}
-	if err := mergeAttributeValues(ctx, *qtx, params.Product); err != nil {
+	err = tx.Commit(ctx)
+	if err != nil {
 		return toDomainError(err)
 	}
-	if err := mergeOptions(ctx, *qtx, params.Product); err != nil {
+	return nil
+}
+
+// saveProduct writes a product with everything it holds. It is shared with
+// the repositories saving products in their own transaction, so it drops its
+// temporary tables for the next product saved in the same one
+func saveProduct(ctx context.Context, queries sqlc.Queries, product domain.Product) error {
+	if err := upsertProduct(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeAttributeValues(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeSpecs(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeOptions(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeOptionValues(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeVariants(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeOptionValuesProductVariants(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeWarehouseStocks(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeVariantComponents(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := insertStockMovements(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := mergeImages(ctx, queries, product); err != nil {
+		return err
+	}
+	if err := insertOutboxEvents(
+		ctx,
+		queries,
+		domain.AggregateTypeProduct,
+		product.ID,
+		product.PendingEvents(),
+	); err != nil {
+		return err
+	}
+	return queries.DropTempTablesProduct(ctx)
+}
+
+func (r *Product) IncreaseViewsCounts(
+	ctx context.Context,
+	params domain.ProductRepositoryIncreaseViewsCountsParam,
+) error {
+	if len(params.ViewsCounts) == 0 {
+		return nil
+	}
+	productIDs := make([]uuid.UUID, 0, len(params.ViewsCounts))
+	views := make([]int32, 0, len(params.ViewsCounts))
+	for productID, count := range params.ViewsCounts {
+		productIDs = append(productIDs, productID)
+		views = append(views, int32(count))
+	}
+	err := r.queries.IncreaseProductViewsCounts(ctx, sqlc.IncreaseProductViewsCountsParams{
+		ProductIDs: productIDs,
+		Views:      views,
+	})
+	if err != nil {
 		return toDomainError(err)
 	}
-	if err := mergeOptionValues(ctx, *qtx, params.Product); err != nil {
+	return nil
+}
+
+func (r *Product) UpdateTrendingScores(
+	ctx context.Context,
+	params domain.ProductRepositoryUpdateTrendingScoresParam,
+) error {
+	day := float64(24 * time.Hour)
+	err := r.queries.UpdateProductTrendingScores(ctx, sqlc.UpdateProductTrendingScoresParams{
+		ViewWeight:     float32(params.ViewWeight),
+		PurchaseWeight: float32(params.PurchaseWeight),
+		HalfLifeDays:   float32(float64(params.HalfLife) / day),
+		WindowDays:     int32(math.Ceil(float64(params.Window) / day)),
+	})
+	if err != nil {
 		return toDomainError(err)
 	}
-	if err := mergeVariants(ctx, *qtx, params.Product); err != nil {
+	return nil
+}
+
+func (r *Product) ApplyPublishSchedules(
+	ctx context.Context,
+	params domain.ProductRepositoryApplyPublishSchedulesParam,
+) (*[]uuid.UUID, error) {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	qtx := r.queries.WithTx(tx)
+	rows, err := qtx.ApplyProductPublishSchedules(ctx, sqlc.ApplyProductPublishSchedulesParams{
+		Now: pgtype.Timestamptz{
+			Time:  params.Now,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	productIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		err := insertProductUpdatedEvent(ctx, *qtx, row.ID, row.Status)
+		if err != nil {
+			return nil, toDomainError(err)
+		}
+		productIDs = append(productIDs, row.ID)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return &productIDs, nil
+}
+
+func (r *Product) SyncPrices(
+	ctx context.Context,
+	params domain.ProductRepositorySyncPricesParam,
+) (*[]uuid.UUID, error) {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	qtx := r.queries.WithTx(tx)
+	rows, err := qtx.SyncProductPrices(ctx, sqlc.SyncProductPricesParams{
+		Now: pgtype.Timestamptz{
+			Time:  params.Now,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	productIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		err := insertProductUpdatedEvent(ctx, *qtx, row.ID, row.Status)
+		if err != nil {
+			return nil, toDomainError(err)
+		}
+		productIDs = append(productIDs, row.ID)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return &productIDs, nil
+}
+
+// insertProductUpdatedEvent writes the ProductUpdated event of a product the
+// bulk jobs changed without loading it
+func insertProductUpdatedEvent(
+	ctx context.Context,
+	queries sqlc.Queries,
+	productID uuid.UUID,
+	status string,
+) error {
+	return insertOutboxEvents(ctx, queries, domain.AggregateTypeProduct, productID, []domain.Event{
+		domain.NewProductUpdatedEvent(productID, domain.ProductStatus(status)),
+	})
+}
+
+func (r *Product) ListRecommendations(
+	ctx context.Context,
+	params domain.ProductRepositoryListRecommendationsParam,
+) (*[]domain.Product, error) {
+	productIDs, err := r.queries.ListProductRecommendations(ctx, sqlc.ListProductRecommendationsParams{
+		ProductID: params.ProductID,
+		Kind:      string(params.Kind),
+		Limit:     int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	products := make([]domain.Product, 0, len(productIDs))
+	if len(productIDs) == 0 {
+		return &products, nil
+	}
+	listedProducts, err := r.List(ctx, domain.ProductRepositoryListParam{
+		IDs:     productIDs,
+		Deleted: domain.DeletedExcludeParam,
+		Limit:   len(productIDs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// List orders by its own sort, the recommendations keep their score order
+	productsByID := make(map[uuid.UUID]domain.Product, len(*listedProducts))
+	for _, product := range *listedProducts {
+		productsByID[product.ID] = product
+	}
+	for _, productID := range productIDs {
+		if product, ok := productsByID[productID]; ok {
+			products = append(products, product)
+		}
+	}
+	return &products, nil
+}
+
+func (r *Product) RefreshRecommendations(
+	ctx context.Context,
+	params domain.ProductRepositoryRefreshRecommendationsParam,
+) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return toDomainError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	qtx := r.queries.WithTx(tx)
+	for _, kind := range []domain.ProductRecommendationKind{
+		domain.ProductRecommendationKindRelated,
+		domain.ProductRecommendationKindBoughtTogether,
+	} {
+		err := qtx.DeleteProductRecommendations(ctx, sqlc.DeleteProductRecommendationsParams{
+			Kind: string(kind),
+		})
+		if err != nil {
+			return toDomainError(err)
+		}
+	}
+	err = qtx.InsertRelatedProductRecommendations(ctx, sqlc.InsertRelatedProductRecommendationsParams{
+		Limit:                int32(params.Limit),
+		AttributeValueWeight: float32(params.AttributeValueWeight),
+		CategoryWeight:       float32(params.CategoryWeight),
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	err = qtx.InsertBoughtTogetherProductRecommendations(ctx, sqlc.InsertBoughtTogetherProductRecommendationsParams{
+		Limit:     int32(params.Limit),
+		MinOrders: int32(params.MinOrders),
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Product) ListStockMovements(
+	ctx context.Context,
+	params domain.ProductRepositoryListStockMovementsParam,
+) (*[]domain.StockMovement, error) {
+	stockMovementEntities, err := r.queries.ListStockMovements(ctx, sqlc.ListStockMovementsParams{
+		ProductVariantID: params.ProductVariantID,
+		OrderID:          params.OrderID,
+		Kinds:            stockMovementKindsToStrings(params.Kinds),
+		Offset:           int32(params.Offset),
+		Limit:            int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	stockMovements := make([]domain.StockMovement, 0, len(stockMovementEntities))
+	for _, m := range stockMovementEntities {
+		stockMovements = append(stockMovements, domain.StockMovement{
+			ID:               m.ID,
+			ProductVariantID: m.ProductVariantID,
+			Kind:             domain.StockMovementKind(m.Kind),
+			Quantity:         int(m.Quantity),
+			QuantityAfter:    int(m.QuantityAfter),
+			ActorID:          fromPgValidToNonPtr(uuid.UUID(m.ActorID.Bytes), m.ActorID.Valid, uuid.Nil),
+			OrderID:          fromPgValidToNonPtr(uuid.UUID(m.OrderID.Bytes), m.OrderID.Valid, uuid.Nil),
+			Note:             m.Note,
+			WarehouseID:      fromPgValidToNonPtr(uuid.UUID(m.WarehouseID.Bytes), m.WarehouseID.Valid, uuid.Nil),
+			CreatedAt:        m.CreatedAt.Time,
+		})
+	}
+	return &stockMovements, nil
+}
+
+func (r *Product) CountStockMovements(
+	ctx context.Context,
+	params domain.ProductRepositoryCountStockMovementsParam,
+) (*int, error) {
+	count, err := r.queries.CountStockMovements(ctx, sqlc.CountStockMovementsParams{
+		ProductVariantID: params.ProductVariantID,
+		Kinds:            stockMovementKindsToStrings(params.Kinds),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Product) ReconcileQuantities(ctx context.Context) (*int, error) {
+	count, err := r.queries.ReconcileProductVariantQuantities(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Product) SaveStockSubscription(
+	ctx context.Context,
+	params domain.ProductRepositorySaveStockSubscriptionParam,
+) error {
+	err := r.queries.UpsertStockSubscription(ctx, sqlc.UpsertStockSubscriptionParams{
+		ProductVariantID: params.ProductVariantID,
+		UserID:           params.UserID,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Product) DeleteStockSubscription(
+	ctx context.Context,
+	params domain.ProductRepositoryDeleteStockSubscriptionParam,
+) error {
+	err := r.queries.DeleteStockSubscription(ctx, sqlc.DeleteStockSubscriptionParams{
+		ProductVariantID: params.ProductVariantID,
+		UserID:           params.UserID,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Product) CountOrderItems(
+	ctx context.Context,
+	params domain.ProductRepositoryCountOrderItemsParam,
+) (*int, error) {
+	count, err := r.queries.CountProductOrderItems(ctx, sqlc.CountProductOrderItemsParams{
+		ProductID: params.ProductID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Product) Purge(ctx context.Context, params domain.ProductRepositoryPurgeParam) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
 		return toDomainError(err)
 	}
-	if err := mergeOptionValuesProductVariants(ctx, *qtx, params.Product); err != nil {
+	defer func() { _ = tx.Rollback(ctx) }()
+	qtx := r.queries.WithTx(tx)
+	err = qtx.EnableProductPurge(ctx)
+	if err != nil {
 		return toDomainError(err)
 	}
-	if err := mergeImages(ctx, *qtx, params.Product); err != nil {
+	rows, err := qtx.PurgeProduct(ctx, sqlc.PurgeProductParams{
+		ProductID: params.ProductID,
+	})
+	if err != nil {
 		return toDomainError(err)
 	}
+	if rows == 0 {
+		return toDomainError(pgx.ErrNoRows)
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		return toDomainError(err)
@@ -314,6 +856,35 @@ func (r *Product) Save(ctx context.Context, params domain.ProductRepositorySaveP
 	return nil
 }
 
+func (r *Product) ListImageIDsInUse(
+	ctx context.Context,
+	params domain.ProductRepositoryListImageIDsInUseParam,
+) (*[]uuid.UUID, error) {
+	ids, err := r.queries.ListProductImageIDsInUse(ctx, sqlc.ListProductImageIDsInUseParams{
+		IDs: params.ImageIDs,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return &ids, nil
+}
+
+func productStatusesToStrings(statuses []domain.ProductStatus) []string {
+	result := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, string(status))
+	}
+	return result
+}
+
+func stockMovementKindsToStrings(kinds []domain.StockMovementKind) []string {
+	result := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		result = append(result, string(kind))
+	}
+	return result
+}
+
 func upsertProduct(
 	ctx context.Context,
 	qtx sqlc.Queries,
@@ -344,6 +915,17 @@ func upsertProduct(
 			Valid: !product.DeletedAt.IsZero(),
 		},
 		CategoryID: product.CategoryID,
+		Status:     string(product.Status),
+		PublishAt: pgtype.Timestamptz{
+			Time:  product.PublishAt,
+			Valid: !product.PublishAt.IsZero(),
+		},
+		UnpublishAt: pgtype.Timestamptz{
+			Time:  product.UnpublishAt,
+			Valid: !product.UnpublishAt.IsZero(),
+		},
+		Discount: int32(product.Discount),
+		Type:     string(product.Type),
 	})
 }
 
@@ -369,6 +951,36 @@ func mergeAttributeValues(
 	return qtx.MergeProductsAttributeValuesFromTemp(ctx)
 }
 
+func mergeSpecs(
+	ctx context.Context,
+	qtx sqlc.Queries,
+	product domain.Product,
+) error {
+	if err := qtx.CreateTempTableProductSpecs(ctx); err != nil {
+		return err
+	}
+	param := make([]sqlc.InsertTempTableProductSpecsParams, 0, len(product.Specs))
+	for _, spec := range product.Specs {
+		var enumValue *string
+		if spec.Enum != "" {
+			enumValue = ptr.To(spec.Enum)
+		}
+		param = append(param, sqlc.InsertTempTableProductSpecsParams{
+			ProductID:    product.ID,
+			SpecID:       spec.SpecID,
+			NumberValue:  spec.Number,
+			BooleanValue: spec.Boolean,
+			EnumValue:    enumValue,
+		})
+	}
+	if _, err := qtx.InsertTempTableProductSpecs(ctx, param); err != nil {
+		return err
+	}
+	return qtx.MergeProductSpecsFromTemp(ctx, sqlc.MergeProductSpecsFromTempParams{
+		ProductID: product.ID,
+	})
+}
+
 func mergeOptions(
 	ctx context.Context,
 	qtx sqlc.Queries,
@@ -435,6 +1047,11 @@ func mergeVariants(
 	}
 	param := make([]sqlc.InsertTempTableProductVariantsParams, 0, len(product.Variants))
 	for _, variant := range product.Variants {
+		// The stock of a bundle is derived, it holds none of its own
+		quantity := variant.Quantity
+		if variant.IsBundle() {
+			quantity = 0
+		}
 		param = append(param, sqlc.InsertTempTableProductVariantsParams{
 			ProductID: product.ID,
 			ID:        variant.ID,
@@ -443,7 +1060,7 @@ func mergeVariants(
 				Int:   big.NewInt(variant.Price),
 				Valid: true,
 			},
-			Quantity: int32(variant.Quantity),
+			Quantity: int32(quantity),
 			CreatedAt: pgtype.Timestamptz{
 				Time:  variant.CreatedAt,
 				Valid: true,
@@ -452,11 +1069,22 @@ func mergeVariants(
 				Time:  variant.UpdatedAt,
 				Valid: true,
 			},
-			PurchaseCount: int32(variant.PurchaseCount),
+			PurchaseCount:     int32(variant.PurchaseCount),
+			LowStockThreshold: int32(variant.LowStockThreshold),
 			DeletedAt: pgtype.Timestamptz{
 				Time:  variant.DeletedAt,
 				Valid: !variant.DeletedAt.IsZero(),
 			},
+			CompareAtPrice: int64ToNumeric(variant.CompareAtPrice),
+			SalePrice:      int64ToNumeric(variant.SalePrice),
+			SaleStartsAt: pgtype.Timestamptz{
+				Time:  variant.SaleStartsAt,
+				Valid: !variant.SaleStartsAt.IsZero(),
+			},
+			SaleEndsAt: pgtype.Timestamptz{
+				Time:  variant.SaleEndsAt,
+				Valid: !variant.SaleEndsAt.IsZero(),
+			},
 		})
 	}
 	_, err := qtx.InsertTempTableProductVariants(ctx, param)
@@ -494,6 +1122,105 @@ func mergeOptionValuesProductVariants(
 	return qtx.MergeOptionValuesProductVariantsFromTemp(ctx)
 }
 
+func mergeWarehouseStocks(
+	ctx context.Context,
+	qtx sqlc.Queries,
+	product domain.Product,
+) error {
+	if err := qtx.CreateTempTableWarehouseStocks(ctx); err != nil {
+		return err
+	}
+	length := 0
+	for _, variant := range product.Variants {
+		length += len(variant.Stocks)
+	}
+	param := make([]sqlc.InsertTempTableWarehouseStocksParams, 0, length)
+	for _, variant := range product.Variants {
+		if variant.IsBundle() {
+			continue
+		}
+		for _, stock := range variant.Stocks {
+			param = append(param, sqlc.InsertTempTableWarehouseStocksParams{
+				WarehouseID:      stock.WarehouseID,
+				ProductVariantID: variant.ID,
+				Quantity:         int32(stock.Quantity),
+				UpdatedAt: pgtype.Timestamptz{
+					Time:  variant.UpdatedAt,
+					Valid: true,
+				},
+			})
+		}
+	}
+	_, err := qtx.InsertTempTableWarehouseStocks(ctx, param)
+	if err != nil {
+		return err
+	}
+	return qtx.MergeWarehouseStocksFromTemp(ctx)
+}
+
+func mergeVariantComponents(
+	ctx context.Context,
+	qtx sqlc.Queries,
+	product domain.Product,
+) error {
+	if err := qtx.CreateTempTableProductVariantComponents(ctx); err != nil {
+		return err
+	}
+	length := 0
+	for _, variant := range product.Variants {
+		length += len(variant.Components)
+	}
+	param := make([]sqlc.InsertTempTableProductVariantComponentsParams, 0, length)
+	for _, variant := range product.Variants {
+		for _, component := range variant.Components {
+			param = append(param, sqlc.InsertTempTableProductVariantComponentsParams{
+				BundleVariantID:    variant.ID,
+				ComponentVariantID: component.VariantID,
+				Quantity:           int32(component.Quantity),
+			})
+		}
+	}
+	_, err := qtx.InsertTempTableProductVariantComponents(ctx, param)
+	if err != nil {
+		return err
+	}
+	return qtx.MergeProductVariantComponentsFromTemp(ctx)
+}
+
+// insertStockMovements appends movements recorded since the product was
+// loaded, the ledger is never merged since it is append-only
+func insertStockMovements(
+	ctx context.Context,
+	qtx sqlc.Queries,
+	product domain.Product,
+) error {
+	param := make([]sqlc.InsertStockMovementsParams, 0)
+	for _, variant := range product.Variants {
+		for _, m := range variant.StockMovements {
+			param = append(param, sqlc.InsertStockMovementsParams{
+				ID:               m.ID,
+				ProductVariantID: m.ProductVariantID,
+				Kind:             string(m.Kind),
+				Quantity:         int32(m.Quantity),
+				QuantityAfter:    int32(m.QuantityAfter),
+				ActorID:          uuidToNullableUUID(m.ActorID),
+				OrderID:          uuidToNullableUUID(m.OrderID),
+				Note:             m.Note,
+				WarehouseID:      uuidToNullableUUID(m.WarehouseID),
+				CreatedAt: pgtype.Timestamptz{
+					Time:  m.CreatedAt,
+					Valid: true,
+				},
+			})
+		}
+	}
+	if len(param) == 0 {
+		return nil
+	}
+	_, err := qtx.InsertStockMovements(ctx, param)
+	return err
+}
+
 func mergeImages(
 	ctx context.Context,
 	qtx sqlc.Queries,
@@ -549,5 +1276,38 @@ func mergeImages(
 	if err != nil {
 		return err
 	}
-	return qtx.MergeProductImagesFromTemp(ctx)
+	if err := qtx.MergeProductImagesFromTemp(ctx); err != nil {
+		return err
+	}
+	return mergeImageRenditions(ctx, qtx, product)
+}
+
+func mergeImageRenditions(
+	ctx context.Context,
+	qtx sqlc.Queries,
+	product domain.Product,
+) error {
+	if err := qtx.CreateTempTableProductImageRenditions(ctx); err != nil {
+		return err
+	}
+	images := slices.Clone(product.Images)
+	for _, variant := range product.Variants {
+		images = append(images, variant.Images...)
+	}
+	param := make([]sqlc.InsertTempTableProductImageRenditionsParams, 0)
+	for _, img := range images {
+		for _, rendition := range img.Renditions {
+			param = append(param, sqlc.InsertTempTableProductImageRenditionsParams{
+				ProductImageID: img.ID,
+				Width:          int32(rendition.Width),
+				Height:         int32(rendition.Height),
+				URL:            rendition.URL,
+			})
+		}
+	}
+	_, err := qtx.InsertTempTableProductImageRenditions(ctx, param)
+	if err != nil {
+		return err
+	}
+	return qtx.MergeProductImageRenditionsFromTemp(ctx)
 }
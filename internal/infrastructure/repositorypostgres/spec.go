@@ -0,0 +1,186 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SpecGroup struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+var _ domain.SpecGroupRepository = (*SpecGroup)(nil)
+
+func ProvideSpecGroup(q *sqlc.Queries, conn *pgxpool.Pool) *SpecGroup {
+	return &SpecGroup{
+		queries: q,
+		conn:    conn,
+	}
+}
+
+func (r *SpecGroup) Count(ctx context.Context, params domain.SpecGroupRepositoryCountParam) (*int, error) {
+	count, err := r.queries.CountSpecGroups(ctx, sqlc.CountSpecGroupsParams{
+		IDs:     params.IDs,
+		SpecIDs: params.SpecIDs,
+		Deleted: string(params.Deleted),
+	})
+	return ptr.To(int(count)), err
+}
+
+func (r *SpecGroup) List(
+	ctx context.Context,
+	params domain.SpecGroupRepositoryListParam,
+) (*[]domain.SpecGroup, error) {
+	specGroups, err := r.queries.ListSpecGroups(ctx, sqlc.ListSpecGroupsParams{
+		IDs:     params.IDs,
+		SpecIDs: params.SpecIDs,
+		Deleted: string(params.Deleted),
+		Limit:   int32(params.Limit),
+		Offset:  int32(params.Offset),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	specGroupIDs := make([]uuid.UUID, 0, len(specGroups))
+	for _, specGroup := range specGroups {
+		specGroupIDs = append(specGroupIDs, specGroup.ID)
+	}
+	specs, err := r.queries.ListSpecs(ctx, sqlc.ListSpecsParams{
+		SpecGroupIDs: specGroupIDs,
+		Deleted:      string(domain.DeletedExcludeParam),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.SpecGroup, 0, len(specGroups))
+	for _, specGroup := range specGroups {
+		result = append(result, toDomainSpecGroup(specGroup, specs))
+	}
+	return &result, nil
+}
+
+func (r *SpecGroup) Get(ctx context.Context, params domain.SpecGroupRepositoryGetParam) (*domain.SpecGroup, error) {
+	specGroup, err := r.queries.GetSpecGroup(ctx, sqlc.GetSpecGroupParams{
+		ID:      params.ID,
+		Deleted: string(domain.DeletedExcludeParam),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	specs, err := r.queries.ListSpecs(ctx, sqlc.ListSpecsParams{
+		SpecGroupIDs: []uuid.UUID{specGroup.ID},
+		Deleted:      string(domain.DeletedExcludeParam),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := toDomainSpecGroup(specGroup, specs)
+	return &result, nil
+}
+
+func (r *SpecGroup) Save(ctx context.Context, params domain.SpecGroupRepositorySaveParam) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return toDomainError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	qtx := r.queries.WithTx(tx)
+	err = qtx.UpsertSpecGroup(ctx, sqlc.UpsertSpecGroupParams{
+		ID:    params.SpecGroup.ID,
+		Name:  params.SpecGroup.Name,
+		Order: int32(params.SpecGroup.Order),
+		CreatedAt: pgtype.Timestamptz{
+			Time:  params.SpecGroup.CreatedAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  params.SpecGroup.UpdatedAt,
+			Valid: true,
+		},
+		DeletedAt: pgtype.Timestamptz{
+			Time:  params.SpecGroup.DeletedAt,
+			Valid: !params.SpecGroup.DeletedAt.IsZero(),
+		},
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	err = qtx.CreateTempTableSpecs(ctx)
+	if err != nil {
+		return toDomainError(err)
+	}
+	_, err = qtx.InsertTempTableSpecs(ctx, buildInsertTempTableSpecsParams(params.SpecGroup))
+	if err != nil {
+		return toDomainError(err)
+	}
+	err = qtx.MergeSpecsFromTemp(ctx)
+	if err != nil {
+		return toDomainError(err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func toDomainSpecGroup(specGroup sqlc.SpecGroup, specs []sqlc.Spec) domain.SpecGroup {
+	result := domain.SpecGroup{
+		ID:        specGroup.ID,
+		Name:      specGroup.Name,
+		Order:     int(specGroup.Order),
+		Specs:     make([]domain.Spec, 0),
+		CreatedAt: specGroup.CreatedAt.Time,
+		UpdatedAt: specGroup.UpdatedAt.Time,
+		DeletedAt: specGroup.DeletedAt.Time,
+	}
+	for _, spec := range specs {
+		if spec.SpecGroupID != specGroup.ID {
+			continue
+		}
+		result.Specs = append(result.Specs, domain.Spec{
+			ID:        spec.ID,
+			Code:      spec.Code,
+			Name:      spec.Name,
+			Type:      domain.SpecType(spec.Type),
+			Unit:      spec.Unit,
+			Choices:   spec.Choices,
+			Order:     int(spec.Order),
+			DeletedAt: spec.DeletedAt.Time,
+		})
+	}
+	return result
+}
+
+func buildInsertTempTableSpecsParams(specGroup domain.SpecGroup) []sqlc.InsertTempTableSpecsParams {
+	params := make([]sqlc.InsertTempTableSpecsParams, 0, len(specGroup.Specs))
+	for _, spec := range specGroup.Specs {
+		choices := spec.Choices
+		if choices == nil {
+			choices = []string{}
+		}
+		params = append(params, sqlc.InsertTempTableSpecsParams{
+			ID:          spec.ID,
+			Code:        spec.Code,
+			Name:        spec.Name,
+			Type:        string(spec.Type),
+			Unit:        spec.Unit,
+			Choices:     choices,
+			Order:       int32(spec.Order),
+			SpecGroupID: specGroup.ID,
+			DeletedAt: pgtype.Timestamptz{
+				Time:  spec.DeletedAt,
+				Valid: !spec.DeletedAt.IsZero(),
+			},
+		})
+	}
+	return params
+}
@@ -9,6 +9,84 @@ import (
 	"context"
 )
 
+// iteratorForInsertOutboxEvents implements pgx.CopyFromSource.
+type iteratorForInsertOutboxEvents struct {
+	rows                 []InsertOutboxEventsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertOutboxEvents) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertOutboxEvents) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].AggregateType,
+		r.rows[0].AggregateID,
+		r.rows[0].Type,
+		r.rows[0].Payload,
+		r.rows[0].OccurredAt,
+	}, nil
+}
+
+func (r iteratorForInsertOutboxEvents) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertOutboxEvents(ctx context.Context, arg []InsertOutboxEventsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"outbox"}, []string{"id", "aggregate_type", "aggregate_id", "type", "payload", "occurred_at"}, &iteratorForInsertOutboxEvents{rows: arg})
+}
+
+// iteratorForInsertStockMovements implements pgx.CopyFromSource.
+type iteratorForInsertStockMovements struct {
+	rows                 []InsertStockMovementsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertStockMovements) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertStockMovements) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].ProductVariantID,
+		r.rows[0].Kind,
+		r.rows[0].Quantity,
+		r.rows[0].QuantityAfter,
+		r.rows[0].ActorID,
+		r.rows[0].OrderID,
+		r.rows[0].Note,
+		r.rows[0].WarehouseID,
+		r.rows[0].CreatedAt,
+	}, nil
+}
+
+func (r iteratorForInsertStockMovements) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertStockMovements(ctx context.Context, arg []InsertStockMovementsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"stock_movements"}, []string{"id", "product_variant_id", "kind", "quantity", "quantity_after", "actor_id", "order_id", "note", "warehouse_id", "created_at"}, &iteratorForInsertStockMovements{rows: arg})
+}
+
 // iteratorForInsertTempTableAttributeValues implements pgx.CopyFromSource.
 type iteratorForInsertTempTableAttributeValues struct {
 	rows                 []InsertTempTableAttributeValuesParams
@@ -207,6 +285,7 @@ func (r iteratorForInsertTempTableOrderItems) Values() ([]interface{}, error) {
 		r.rows[0].OrderID,
 		r.rows[0].Price,
 		r.rows[0].ProductVariantID,
+		r.rows[0].WarehouseID,
 	}, nil
 }
 
@@ -215,7 +294,42 @@ func (r iteratorForInsertTempTableOrderItems) Err() error {
 }
 
 func (q *Queries) InsertTempTableOrderItems(ctx context.Context, arg []InsertTempTableOrderItemsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"temp_order_items"}, []string{"id", "quantity", "order_id", "price", "product_variant_id"}, &iteratorForInsertTempTableOrderItems{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"temp_order_items"}, []string{"id", "quantity", "order_id", "price", "product_variant_id", "warehouse_id"}, &iteratorForInsertTempTableOrderItems{rows: arg})
+}
+
+// iteratorForInsertTempTableProductImageRenditions implements pgx.CopyFromSource.
+type iteratorForInsertTempTableProductImageRenditions struct {
+	rows                 []InsertTempTableProductImageRenditionsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertTempTableProductImageRenditions) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertTempTableProductImageRenditions) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ProductImageID,
+		r.rows[0].Width,
+		r.rows[0].Height,
+		r.rows[0].URL,
+	}, nil
+}
+
+func (r iteratorForInsertTempTableProductImageRenditions) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertTempTableProductImageRenditions(ctx context.Context, arg []InsertTempTableProductImageRenditionsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"temp_product_image_renditions"}, []string{"product_image_id", "width", "height", "url"}, &iteratorForInsertTempTableProductImageRenditions{rows: arg})
 }
 
 // iteratorForInsertTempTableProductImages implements pgx.CopyFromSource.
@@ -256,6 +370,76 @@ func (q *Queries) InsertTempTableProductImages(ctx context.Context, arg []Insert
 	return q.db.CopyFrom(ctx, []string{"temp_product_images"}, []string{"id", "url", "order", "product_id", "product_variant_id", "created_at", "deleted_at"}, &iteratorForInsertTempTableProductImages{rows: arg})
 }
 
+// iteratorForInsertTempTableProductSpecs implements pgx.CopyFromSource.
+type iteratorForInsertTempTableProductSpecs struct {
+	rows                 []InsertTempTableProductSpecsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertTempTableProductSpecs) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertTempTableProductSpecs) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ProductID,
+		r.rows[0].SpecID,
+		r.rows[0].NumberValue,
+		r.rows[0].BooleanValue,
+		r.rows[0].EnumValue,
+	}, nil
+}
+
+func (r iteratorForInsertTempTableProductSpecs) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertTempTableProductSpecs(ctx context.Context, arg []InsertTempTableProductSpecsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"temp_product_specs"}, []string{"product_id", "spec_id", "number_value", "boolean_value", "enum_value"}, &iteratorForInsertTempTableProductSpecs{rows: arg})
+}
+
+// iteratorForInsertTempTableProductVariantComponents implements pgx.CopyFromSource.
+type iteratorForInsertTempTableProductVariantComponents struct {
+	rows                 []InsertTempTableProductVariantComponentsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertTempTableProductVariantComponents) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertTempTableProductVariantComponents) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].BundleVariantID,
+		r.rows[0].ComponentVariantID,
+		r.rows[0].Quantity,
+	}, nil
+}
+
+func (r iteratorForInsertTempTableProductVariantComponents) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertTempTableProductVariantComponents(ctx context.Context, arg []InsertTempTableProductVariantComponentsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"temp_product_variant_components"}, []string{"bundle_variant_id", "component_variant_id", "quantity"}, &iteratorForInsertTempTableProductVariantComponents{rows: arg})
+}
+
 // iteratorForInsertTempTableProductVariants implements pgx.CopyFromSource.
 type iteratorForInsertTempTableProductVariants struct {
 	rows                 []InsertTempTableProductVariantsParams
@@ -281,10 +465,15 @@ func (r iteratorForInsertTempTableProductVariants) Values() ([]interface{}, erro
 		r.rows[0].Price,
 		r.rows[0].Quantity,
 		r.rows[0].PurchaseCount,
+		r.rows[0].LowStockThreshold,
 		r.rows[0].ProductID,
 		r.rows[0].CreatedAt,
 		r.rows[0].UpdatedAt,
 		r.rows[0].DeletedAt,
+		r.rows[0].CompareAtPrice,
+		r.rows[0].SalePrice,
+		r.rows[0].SaleStartsAt,
+		r.rows[0].SaleEndsAt,
 	}, nil
 }
 
@@ -293,7 +482,7 @@ func (r iteratorForInsertTempTableProductVariants) Err() error {
 }
 
 func (q *Queries) InsertTempTableProductVariants(ctx context.Context, arg []InsertTempTableProductVariantsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"temp_product_variants"}, []string{"id", "sku", "price", "quantity", "purchase_count", "product_id", "created_at", "updated_at", "deleted_at"}, &iteratorForInsertTempTableProductVariants{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"temp_product_variants"}, []string{"id", "sku", "price", "quantity", "purchase_count", "low_stock_threshold", "product_id", "created_at", "updated_at", "deleted_at", "compare_at_price", "sale_price", "sale_starts_at", "sale_ends_at"}, &iteratorForInsertTempTableProductVariants{rows: arg})
 }
 
 // iteratorForInsertTempTableProductsAttributeValues implements pgx.CopyFromSource.
@@ -328,3 +517,78 @@ func (r iteratorForInsertTempTableProductsAttributeValues) Err() error {
 func (q *Queries) InsertTempTableProductsAttributeValues(ctx context.Context, arg []InsertTempTableProductsAttributeValuesParams) (int64, error) {
 	return q.db.CopyFrom(ctx, []string{"temp_products_attribute_values"}, []string{"product_id", "attribute_value_id"}, &iteratorForInsertTempTableProductsAttributeValues{rows: arg})
 }
+
+// iteratorForInsertTempTableSpecs implements pgx.CopyFromSource.
+type iteratorForInsertTempTableSpecs struct {
+	rows                 []InsertTempTableSpecsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertTempTableSpecs) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertTempTableSpecs) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].Code,
+		r.rows[0].Name,
+		r.rows[0].Type,
+		r.rows[0].Unit,
+		r.rows[0].Choices,
+		r.rows[0].Order,
+		r.rows[0].SpecGroupID,
+		r.rows[0].DeletedAt,
+	}, nil
+}
+
+func (r iteratorForInsertTempTableSpecs) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertTempTableSpecs(ctx context.Context, arg []InsertTempTableSpecsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"temp_specs"}, []string{"id", "code", "name", "type", "unit", "choices", "order", "spec_group_id", "deleted_at"}, &iteratorForInsertTempTableSpecs{rows: arg})
+}
+
+// iteratorForInsertTempTableWarehouseStocks implements pgx.CopyFromSource.
+type iteratorForInsertTempTableWarehouseStocks struct {
+	rows                 []InsertTempTableWarehouseStocksParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertTempTableWarehouseStocks) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertTempTableWarehouseStocks) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].WarehouseID,
+		r.rows[0].ProductVariantID,
+		r.rows[0].Quantity,
+		r.rows[0].UpdatedAt,
+	}, nil
+}
+
+func (r iteratorForInsertTempTableWarehouseStocks) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertTempTableWarehouseStocks(ctx context.Context, arg []InsertTempTableWarehouseStocksParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"temp_warehouse_stocks"}, []string{"warehouse_id", "product_variant_id", "quantity", "updated_at"}, &iteratorForInsertTempTableWarehouseStocks{rows: arg})
+}
@@ -44,6 +44,36 @@ type Category struct {
 	DeletedAt pgtype.Timestamptz
 }
 
+type Email struct {
+	ID            uuid.UUID
+	Kind          string
+	EventID       uuid.UUID
+	UserID        uuid.UUID
+	Recipient     string
+	Locale        string
+	Subject       string
+	TextBody      string
+	HTMLBody      string
+	Status        string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	SentAt        pgtype.Timestamptz
+}
+
+type Notification struct {
+	ID               uuid.UUID
+	UserID           pgtype.UUID
+	Kind             string
+	ProductID        pgtype.UUID
+	ProductVariantID pgtype.UUID
+	Quantity         int32
+	CreatedAt        pgtype.Timestamptz
+	ReadAt           pgtype.Timestamptz
+}
+
 type Option struct {
 	ID        uuid.UUID
 	Name      string
@@ -83,6 +113,7 @@ type OrderItem struct {
 	OrderID          uuid.UUID
 	Price            pgtype.Numeric
 	ProductVariantID uuid.UUID
+	WarehouseID      pgtype.UUID
 }
 
 type OrderProvider struct {
@@ -95,19 +126,54 @@ type OrderStatus struct {
 	Name string
 }
 
-type Product struct {
+type Outbox struct {
 	ID            uuid.UUID
-	Name          string
-	Description   string
-	Price         pgtype.Numeric
-	ViewsCount    int32
-	TotalPurchase int32
-	Rating        float32
-	TrendingScore float32
-	CategoryID    uuid.UUID
-	CreatedAt     pgtype.Timestamptz
-	UpdatedAt     pgtype.Timestamptz
-	DeletedAt     pgtype.Timestamptz
+	AggregateType string
+	AggregateID   uuid.UUID
+	Type          string
+	Payload       []byte
+	OccurredAt    pgtype.Timestamptz
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	LastError     string
+	PublishedAt   pgtype.Timestamptz
+}
+
+type Product struct {
+	ID                    uuid.UUID
+	Name                  string
+	Description           string
+	Price                 pgtype.Numeric
+	ViewsCount            int32
+	TotalPurchase         int32
+	Rating                float32
+	TrendingScore         float32
+	SearchSkus            string
+	SearchOptionValues    string
+	SearchAttributeValues string
+	CategoryID            uuid.UUID
+	CreatedAt             pgtype.Timestamptz
+	UpdatedAt             pgtype.Timestamptz
+	DeletedAt             pgtype.Timestamptz
+	Status                string
+	PublishAt             pgtype.Timestamptz
+	UnpublishAt           pgtype.Timestamptz
+	Discount              int32
+	Type                  string
+}
+
+type ProductDailyStat struct {
+	ProductID uuid.UUID
+	Day       pgtype.Date
+	Views     int32
+	Purchases int32
+}
+
+type ProductImageRendition struct {
+	ProductImageID uuid.UUID
+	Width          int32
+	Height         int32
+	URL            string
 }
 
 type ProductImage struct {
@@ -120,16 +186,42 @@ type ProductImage struct {
 	ProductVariantID pgtype.UUID
 }
 
+type ProductRecommendation struct {
+	ProductID            uuid.UUID
+	RecommendedProductID uuid.UUID
+	Kind                 string
+	Score                float32
+}
+
+type ProductSpec struct {
+	ProductID    uuid.UUID
+	SpecID       uuid.UUID
+	NumberValue  *float64
+	BooleanValue *bool
+	EnumValue    *string
+}
+
 type ProductVariant struct {
-	ID            uuid.UUID
-	SKU           string
-	Price         pgtype.Numeric
-	Quantity      int32
-	PurchaseCount int32
-	ProductID     uuid.UUID
-	CreatedAt     pgtype.Timestamptz
-	UpdatedAt     pgtype.Timestamptz
-	DeletedAt     pgtype.Timestamptz
+	ID                uuid.UUID
+	SKU               string
+	Price             pgtype.Numeric
+	Quantity          int32
+	PurchaseCount     int32
+	LowStockThreshold int32
+	ProductID         uuid.UUID
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	DeletedAt         pgtype.Timestamptz
+	CompareAtPrice    pgtype.Numeric
+	SalePrice         pgtype.Numeric
+	SaleStartsAt      pgtype.Timestamptz
+	SaleEndsAt        pgtype.Timestamptz
+}
+
+type ProductVariantComponent struct {
+	BundleVariantID    uuid.UUID
+	ComponentVariantID uuid.UUID
+	Quantity           int32
 }
 
 type ProductsAttributeValue struct {
@@ -178,6 +270,61 @@ type Review struct {
 	OrderItemID uuid.UUID
 }
 
+type SpecGroup struct {
+	ID        uuid.UUID
+	Name      string
+	Order     int32
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+}
+
+type Spec struct {
+	ID          uuid.UUID
+	Code        string
+	Name        string
+	Type        string
+	Unit        string
+	Choices     []string
+	Order       int32
+	SpecGroupID uuid.UUID
+	DeletedAt   pgtype.Timestamptz
+}
+
+type StockMovement struct {
+	ID               uuid.UUID
+	ProductVariantID uuid.UUID
+	Kind             string
+	Quantity         int32
+	QuantityAfter    int32
+	ActorID          pgtype.UUID
+	OrderID          pgtype.UUID
+	Note             string
+	CreatedAt        pgtype.Timestamptz
+	WarehouseID      pgtype.UUID
+}
+
+type StockSubscription struct {
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+	CreatedAt        pgtype.Timestamptz
+	NotifiedAt       pgtype.Timestamptz
+}
+
+type Task struct {
+	ID         uuid.UUID
+	Kind       string
+	Key        *string
+	Payload    []byte
+	Status     string
+	Attempts   int32
+	LastError  string
+	RunAt      pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+	FinishedAt pgtype.Timestamptz
+}
+
 type TempAttributeValue struct {
 	ID          uuid.UUID
 	AttributeID uuid.UUID
@@ -217,6 +364,14 @@ type TempOrderItem struct {
 	OrderID          uuid.UUID
 	Price            pgtype.Numeric
 	ProductVariantID uuid.UUID
+	WarehouseID      pgtype.UUID
+}
+
+type TempProductImageRendition struct {
+	ProductImageID uuid.UUID
+	Width          int32
+	Height         int32
+	URL            string
 }
 
 type TempProductImage struct {
@@ -229,19 +384,102 @@ type TempProductImage struct {
 	DeletedAt        pgtype.Timestamptz
 }
 
+type TempProductSpec struct {
+	ProductID    uuid.UUID
+	SpecID       uuid.UUID
+	NumberValue  *float64
+	BooleanValue *bool
+	EnumValue    *string
+}
+
+type TempProductVariantComponent struct {
+	BundleVariantID    uuid.UUID
+	ComponentVariantID uuid.UUID
+	Quantity           int32
+}
+
 type TempProductVariant struct {
-	ID            uuid.UUID
-	SKU           string
-	Price         pgtype.Numeric
-	Quantity      int32
-	PurchaseCount int32
-	ProductID     uuid.UUID
-	CreatedAt     pgtype.Timestamptz
-	UpdatedAt     pgtype.Timestamptz
-	DeletedAt     pgtype.Timestamptz
+	ID                uuid.UUID
+	SKU               string
+	Price             pgtype.Numeric
+	Quantity          int32
+	PurchaseCount     int32
+	LowStockThreshold int32
+	ProductID         uuid.UUID
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	DeletedAt         pgtype.Timestamptz
+	CompareAtPrice    pgtype.Numeric
+	SalePrice         pgtype.Numeric
+	SaleStartsAt      pgtype.Timestamptz
+	SaleEndsAt        pgtype.Timestamptz
 }
 
 type TempProductsAttributeValue struct {
 	ProductID        uuid.UUID
 	AttributeValueID uuid.UUID
 }
+
+type TempSpec struct {
+	ID          uuid.UUID
+	Code        string
+	Name        string
+	Type        string
+	Unit        string
+	Choices     []string
+	Order       int32
+	SpecGroupID uuid.UUID
+	DeletedAt   pgtype.Timestamptz
+}
+
+type TempWarehouseStock struct {
+	WarehouseID      uuid.UUID
+	ProductVariantID uuid.UUID
+	Quantity         int32
+	UpdatedAt        pgtype.Timestamptz
+}
+
+type Warehouse struct {
+	ID        uuid.UUID
+	Name      string
+	Province  string
+	Priority  int32
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+}
+
+type WarehouseStock struct {
+	WarehouseID      uuid.UUID
+	ProductVariantID uuid.UUID
+	Quantity         int32
+	UpdatedAt        pgtype.Timestamptz
+}
+
+type WebhookDelivery struct {
+	ID            uuid.UUID
+	WebhookID     uuid.UUID
+	EventID       uuid.UUID
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int32
+	ResponseCode  int32
+	ResponseBody  string
+	LastError     string
+	NextAttemptAt pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	DeliveredAt   pgtype.Timestamptz
+}
+
+type Webhook struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+	DeletedAt  pgtype.Timestamptz
+}
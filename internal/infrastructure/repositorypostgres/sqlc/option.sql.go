@@ -250,7 +250,7 @@ WHEN MATCHED THEN
   UPDATE SET
     value = source.value,
     option_id = source.option_id,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -265,7 +265,8 @@ WHEN NOT MATCHED THEN
     NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
   )
 WHEN NOT MATCHED BY SOURCE
-  AND target.option_id = ANY (SELECT DISTINCT option_id FROM temp_option_values) THEN
+  AND target.option_id = ANY (SELECT DISTINCT option_id FROM temp_option_values)
+  AND target.deleted_at IS NULL THEN
   DELETE
 `
 
@@ -282,7 +283,7 @@ WHEN MATCHED THEN
   UPDATE SET
     name = source.name,
     product_id = source.product_id,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -297,7 +298,8 @@ WHEN NOT MATCHED THEN
     NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
   )
 WHEN NOT MATCHED BY SOURCE
-  AND target.product_id = ANY (SELECT DISTINCT product_id FROM temp_options) THEN
+  AND target.product_id = ANY (SELECT DISTINCT product_id FROM temp_options)
+  AND target.deleted_at IS NULL THEN
   DELETE
 `
 
@@ -93,7 +93,8 @@ CREATE TEMPORARY TABLE temp_order_items (
   quantity INTEGER NOT NULL,
   order_id UUID NOT NULL,
   price NUMERIC NOT NULL,
-  product_variant_id UUID NOT NULL
+  product_variant_id UUID NOT NULL,
+  warehouse_id UUID
 ) ON COMMIT DROP
 `
 
@@ -136,7 +137,7 @@ func (q *Queries) GetOrder(ctx context.Context, arg GetOrderParams) (Order, erro
 
 const getOrderItem = `-- name: GetOrderItem :one
 SELECT
-  id, quantity, order_id, price, product_variant_id
+  id, quantity, order_id, price, product_variant_id, warehouse_id
 FROM
   order_items
 WHERE
@@ -156,6 +157,7 @@ func (q *Queries) GetOrderItem(ctx context.Context, arg GetOrderItemParams) (Ord
 		&i.OrderID,
 		&i.Price,
 		&i.ProductVariantID,
+		&i.WarehouseID,
 	)
 	return i, err
 }
@@ -222,11 +224,12 @@ type InsertTempTableOrderItemsParams struct {
 	OrderID          uuid.UUID
 	Price            pgtype.Numeric
 	ProductVariantID uuid.UUID
+	WarehouseID      pgtype.UUID
 }
 
 const listOrderItems = `-- name: ListOrderItems :many
 SELECT
-  id, quantity, order_id, price, product_variant_id
+  id, quantity, order_id, price, product_variant_id, warehouse_id
 FROM
   order_items
 WHERE
@@ -270,6 +273,7 @@ func (q *Queries) ListOrderItems(ctx context.Context, arg ListOrderItemsParams)
 			&i.OrderID,
 			&i.Price,
 			&i.ProductVariantID,
+			&i.WarehouseID,
 		); err != nil {
 			return nil, err
 		}
@@ -383,10 +387,15 @@ WHERE
     WHEN $5::text = '' THEN TRUE
     ELSE orders_with_statuses.status_name IS NOT NULL
   END
+  AND CASE
+    WHEN $6::uuid IS NULL THEN TRUE
+    WHEN $6::uuid = '00000000-0000-0000-0000-000000000000'::uuid THEN TRUE
+    ELSE orders.id > $6::uuid
+  END
 ORDER BY
   orders.id ASC
-OFFSET $6::integer
-LIMIT NULLIF($7::integer, 0)
+OFFSET $7::integer
+LIMIT NULLIF($8::integer, 0)
 `
 
 type ListOrdersParams struct {
@@ -395,6 +404,7 @@ type ListOrdersParams struct {
 	StatusIDs   []uuid.UUID
 	StatusNames []string
 	StatusName  string
+	AfterID     uuid.UUID
 	Offset      int32
 	Limit       int32
 }
@@ -406,6 +416,7 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order
 		arg.StatusIDs,
 		arg.StatusNames,
 		arg.StatusName,
+		arg.AfterID,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -439,6 +450,89 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order
 	return items, nil
 }
 
+const listUnpaidOrderIDs = `-- name: ListUnpaidOrderIDs :many
+SELECT
+  orders.id
+FROM
+  orders
+INNER JOIN
+  order_statuses ON orders.status_id = order_statuses.id
+INNER JOIN
+  order_providers ON orders.provider_id = order_providers.id
+WHERE
+  NOT orders.is_paid
+  AND order_statuses.name = $1::text
+  AND order_providers.name = $2::text
+  AND orders.created_at < $3::timestamptz
+ORDER BY
+  orders.created_at ASC,
+  orders.id ASC
+LIMIT $4::integer
+`
+
+type ListUnpaidOrderIDsParams struct {
+	StatusName    string
+	ProviderName  string
+	CreatedBefore pgtype.Timestamptz
+	Limit         int32
+}
+
+func (q *Queries) ListUnpaidOrderIDs(ctx context.Context, arg ListUnpaidOrderIDsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listUnpaidOrderIDs,
+		arg.StatusName,
+		arg.ProviderName,
+		arg.CreatedBefore,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockOrderPaymentState = `-- name: LockOrderPaymentState :one
+SELECT
+  order_statuses.name AS status_name,
+  orders.is_paid
+FROM
+  orders
+INNER JOIN
+  order_statuses ON orders.status_id = order_statuses.id
+WHERE
+  orders.id = $1
+FOR UPDATE OF orders
+`
+
+type LockOrderPaymentStateParams struct {
+	ID uuid.UUID
+}
+
+type LockOrderPaymentStateRow struct {
+	StatusName string
+	IsPaid     bool
+}
+
+// LockOrderPaymentState locks an order until the end of the transaction, the
+// state read can not change before it is written
+func (q *Queries) LockOrderPaymentState(ctx context.Context, arg LockOrderPaymentStateParams) (LockOrderPaymentStateRow, error) {
+	row := q.db.QueryRow(ctx, lockOrderPaymentState, arg.ID)
+	var i LockOrderPaymentStateRow
+	err := row.Scan(&i.StatusName, &i.IsPaid)
+	return i, err
+}
+
 const mergeOrderItemsFromTemp = `-- name: MergeOrderItemsFromTemp :exec
 MERGE INTO order_items AS target
 USING temp_order_items AS source
@@ -448,21 +542,24 @@ WHEN MATCHED THEN
     quantity = source.quantity,
     order_id = source.order_id,
     price = source.price,
-    product_variant_id = source.product_variant_id
+    product_variant_id = source.product_variant_id,
+    warehouse_id = source.warehouse_id
 WHEN NOT MATCHED THEN
   INSERT (
     id,
     quantity,
     order_id,
     price,
-    product_variant_id
+    product_variant_id,
+    warehouse_id
   )
   VALUES (
     source.id,
     source.quantity,
     source.order_id,
     source.price,
-    source.product_variant_id
+    source.product_variant_id,
+    source.warehouse_id
   )
 WHEN NOT MATCHED BY SOURCE
   AND target.order_id = ANY (SELECT DISTINCT order_id FROM temp_order_items) THEN
@@ -12,6 +12,86 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const applyProductPublishSchedules = `-- name: ApplyProductPublishSchedules :many
+UPDATE products
+SET
+  status = CASE
+    WHEN products.status = 'draft' THEN 'published'
+    ELSE 'archived'
+  END,
+  publish_at = CASE
+    WHEN products.status = 'draft' THEN NULL
+    ELSE products.publish_at
+  END,
+  unpublish_at = CASE
+    WHEN products.status = 'published' THEN NULL
+    ELSE products.unpublish_at
+  END,
+  updated_at = $1::timestamptz
+WHERE
+  products.deleted_at IS NULL
+  AND (
+    (products.status = 'draft' AND products.publish_at <= $1::timestamptz)
+    OR (products.status = 'published' AND products.unpublish_at <= $1::timestamptz)
+  )
+RETURNING
+  products.id,
+  products.status
+`
+
+type ApplyProductPublishSchedulesParams struct {
+	Now pgtype.Timestamptz
+}
+
+type ApplyProductPublishSchedulesRow struct {
+	ID     uuid.UUID
+	Status string
+}
+
+// Drafts due are published and published products due are archived, the
+// schedule which fired is cleared. The products changed are returned
+func (q *Queries) ApplyProductPublishSchedules(ctx context.Context, arg ApplyProductPublishSchedulesParams) ([]ApplyProductPublishSchedulesRow, error) {
+	rows, err := q.db.Query(ctx, applyProductPublishSchedules, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplyProductPublishSchedulesRow
+	for rows.Next() {
+		var i ApplyProductPublishSchedulesRow
+		if err := rows.Scan(&i.ID, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countProductOrderItems = `-- name: CountProductOrderItems :one
+SELECT
+  COUNT(*)
+FROM
+  order_items
+INNER JOIN product_variants
+  ON order_items.product_variant_id = product_variants.id
+WHERE
+  product_variants.product_id = $1
+`
+
+type CountProductOrderItemsParams struct {
+	ProductID uuid.UUID
+}
+
+func (q *Queries) CountProductOrderItems(ctx context.Context, arg CountProductOrderItemsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductOrderItems, arg.ProductID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countProducts = `-- name: CountProducts :one
 SELECT
   COUNT(*) AS count
@@ -33,8 +113,12 @@ WHERE
   AND CASE
     WHEN $3::text = '' THEN TRUE
     ELSE (
-      products.name ||| $3::text
-      OR categories.name ||| $3::text
+      products.name ||| $3::text::pdb.boost(3)
+      OR products.description ||| $3::text
+      OR products.search_skus ||| $3::text::pdb.boost(4)
+      OR products.search_option_values ||| $3::text
+      OR products.search_attribute_values ||| $3::text::pdb.boost(2)
+      OR categories.name ||| $3::text::pdb.boost(2)
     )
   END
   AND CASE
@@ -70,6 +154,24 @@ WHERE
     WHEN $9::text = 'all' THEN TRUE
     ELSE products.deleted_at IS NULL
   END
+  AND CASE
+    WHEN $10::text[] IS NULL THEN TRUE
+    WHEN cardinality($10::text[]) = 0 THEN TRUE
+    ELSE products.status = ANY ($10::text[])
+  END
+  AND CASE
+    WHEN $11::boolean THEN EXISTS (
+      SELECT 1
+      FROM product_variants
+      WHERE product_variants.product_id = products.id
+        AND product_variants.deleted_at IS NULL
+        AND product_variants.sale_price > 0
+        AND product_variants.sale_price < GREATEST(product_variants.price, product_variants.compare_at_price)
+        AND (product_variants.sale_starts_at IS NULL OR product_variants.sale_starts_at <= NOW())
+        AND (product_variants.sale_ends_at IS NULL OR product_variants.sale_ends_at > NOW())
+    )
+    ELSE TRUE
+  END
 `
 
 type CountProductsParams struct {
@@ -82,6 +184,8 @@ type CountProductsParams struct {
 	CategoryIDs []uuid.UUID
 	VariantIDs  []uuid.UUID
 	Deleted     string
+	Statuses    []string
+	OnSale      bool
 }
 
 func (q *Queries) CountProducts(ctx context.Context, arg CountProductsParams) (int64, error) {
@@ -95,6 +199,8 @@ func (q *Queries) CountProducts(ctx context.Context, arg CountProductsParams) (i
 		arg.CategoryIDs,
 		arg.VariantIDs,
 		arg.Deleted,
+		arg.Statuses,
+		arg.OnSale,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -114,6 +220,21 @@ func (q *Queries) CreateTempTableOptionValuesProductVariants(ctx context.Context
 	return err
 }
 
+const createTempTableProductImageRenditions = `-- name: CreateTempTableProductImageRenditions :exec
+CREATE TEMPORARY TABLE temp_product_image_renditions (
+  product_image_id UUID NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  PRIMARY KEY (product_image_id, width)
+) ON COMMIT DROP
+`
+
+func (q *Queries) CreateTempTableProductImageRenditions(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, createTempTableProductImageRenditions)
+	return err
+}
+
 const createTempTableProductImages = `-- name: CreateTempTableProductImages :exec
 CREATE TEMPORARY TABLE temp_product_images (
   id UUID PRIMARY KEY,
@@ -131,6 +252,36 @@ func (q *Queries) CreateTempTableProductImages(ctx context.Context) error {
 	return err
 }
 
+const createTempTableProductSpecs = `-- name: CreateTempTableProductSpecs :exec
+CREATE TEMPORARY TABLE temp_product_specs (
+  product_id UUID NOT NULL,
+  spec_id UUID NOT NULL,
+  number_value DOUBLE PRECISION,
+  boolean_value BOOLEAN,
+  enum_value TEXT,
+  PRIMARY KEY (product_id, spec_id)
+) ON COMMIT DROP
+`
+
+func (q *Queries) CreateTempTableProductSpecs(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, createTempTableProductSpecs)
+	return err
+}
+
+const createTempTableProductVariantComponents = `-- name: CreateTempTableProductVariantComponents :exec
+CREATE TEMPORARY TABLE temp_product_variant_components (
+  bundle_variant_id UUID NOT NULL,
+  component_variant_id UUID NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (bundle_variant_id, component_variant_id)
+) ON COMMIT DROP
+`
+
+func (q *Queries) CreateTempTableProductVariantComponents(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, createTempTableProductVariantComponents)
+	return err
+}
+
 const createTempTableProductVariants = `-- name: CreateTempTableProductVariants :exec
 CREATE TEMPORARY TABLE temp_product_variants (
   id UUID PRIMARY KEY,
@@ -138,10 +289,15 @@ CREATE TEMPORARY TABLE temp_product_variants (
   price DECIMAL(12, 0) NOT NULL,
   quantity INTEGER NOT NULL,
   purchase_count INTEGER NOT NULL,
+  low_stock_threshold INTEGER NOT NULL,
   product_id UUID NOT NULL,
   created_at TIMESTAMPTZ NOT NULL,
   updated_at TIMESTAMPTZ NOT NULL,
-  deleted_at TIMESTAMPTZ
+  deleted_at TIMESTAMPTZ,
+  compare_at_price DECIMAL(12, 0) NOT NULL,
+  sale_price DECIMAL(12, 0) NOT NULL,
+  sale_starts_at TIMESTAMPTZ,
+  sale_ends_at TIMESTAMPTZ
 ) ON COMMIT DROP
 `
 
@@ -163,9 +319,73 @@ func (q *Queries) CreateTempTableProductsAttributeValues(ctx context.Context) er
 	return err
 }
 
+const deleteProductRecommendations = `-- name: DeleteProductRecommendations :exec
+DELETE FROM product_recommendations
+WHERE kind = $1::text
+`
+
+type DeleteProductRecommendationsParams struct {
+	Kind string
+}
+
+func (q *Queries) DeleteProductRecommendations(ctx context.Context, arg DeleteProductRecommendationsParams) error {
+	_, err := q.db.Exec(ctx, deleteProductRecommendations, arg.Kind)
+	return err
+}
+
+const deleteStockSubscription = `-- name: DeleteStockSubscription :exec
+DELETE FROM stock_subscriptions
+WHERE
+  product_variant_id = $1
+  AND user_id = $2
+`
+
+type DeleteStockSubscriptionParams struct {
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+}
+
+func (q *Queries) DeleteStockSubscription(ctx context.Context, arg DeleteStockSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, deleteStockSubscription, arg.ProductVariantID, arg.UserID)
+	return err
+}
+
+const dropTempTablesProduct = `-- name: DropTempTablesProduct :exec
+DROP TABLE IF EXISTS
+  temp_products_attribute_values,
+  temp_product_specs,
+  temp_options,
+  temp_option_values,
+  temp_product_variants,
+  temp_option_values_product_variants,
+  temp_warehouse_stocks,
+  temp_product_variant_components,
+  temp_product_images,
+  temp_product_image_renditions
+`
+
+// DropTempTablesProduct lets several products be saved in one transaction,
+// the temporary tables are otherwise only dropped on commit
+func (q *Queries) DropTempTablesProduct(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, dropTempTablesProduct)
+	return err
+}
+
+const enableProductPurge = `-- name: EnableProductPurge :exec
+SELECT set_config('ele.purging_product', 'on', TRUE)
+`
+
+// The stock_movements ledger is append-only, its trigger only lets the
+// movements of a purged product be deleted once this is set. The setting is
+// local to the transaction, so it must run in the one of PurgeProduct
+func (q *Queries) EnableProductPurge(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, enableProductPurge)
+	return err
+}
+
 const getProduct = `-- name: GetProduct :one
 SELECT
-  id, name, description, price, views_count, total_purchase, rating, trending_score, category_id, created_at, updated_at, deleted_at
+  id, name, description, price, views_count, total_purchase, rating, trending_score, search_skus, search_option_values, search_attribute_values, category_id, created_at, updated_at, deleted_at, status, publish_at, unpublish_at, discount, type
 FROM
   products
 WHERE
@@ -195,10 +415,18 @@ func (q *Queries) GetProduct(ctx context.Context, arg GetProductParams) (Product
 		&i.TotalPurchase,
 		&i.Rating,
 		&i.TrendingScore,
+		&i.SearchSkus,
+		&i.SearchOptionValues,
+		&i.SearchAttributeValues,
 		&i.CategoryID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.UnpublishAt,
+		&i.Discount,
+		&i.Type,
 	)
 	return i, err
 }
@@ -233,7 +461,7 @@ func (q *Queries) GetProductImage(ctx context.Context, arg GetProductImageParams
 
 const getProductVariant = `-- name: GetProductVariant :one
 SELECT
-  id, sku, price, quantity, purchase_count, product_id, created_at, updated_at, deleted_at
+  id, sku, price, quantity, purchase_count, low_stock_threshold, product_id, created_at, updated_at, deleted_at, compare_at_price, sale_price, sale_starts_at, sale_ends_at
 FROM
   product_variants
 WHERE
@@ -260,10 +488,15 @@ func (q *Queries) GetProductVariant(ctx context.Context, arg GetProductVariantPa
 		&i.Price,
 		&i.Quantity,
 		&i.PurchaseCount,
+		&i.LowStockThreshold,
 		&i.ProductID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CompareAtPrice,
+		&i.SalePrice,
+		&i.SaleStartsAt,
+		&i.SaleEndsAt,
 	)
 	return i, err
 }
@@ -273,6 +506,13 @@ type InsertTempTableOptionValuesProductVariantsParams struct {
 	OptionValueID    uuid.UUID
 }
 
+type InsertTempTableProductImageRenditionsParams struct {
+	ProductImageID uuid.UUID
+	Width          int32
+	Height         int32
+	URL            string
+}
+
 type InsertTempTableProductImagesParams struct {
 	ID               uuid.UUID
 	URL              string
@@ -283,16 +523,35 @@ type InsertTempTableProductImagesParams struct {
 	DeletedAt        pgtype.Timestamptz
 }
 
+type InsertTempTableProductSpecsParams struct {
+	ProductID    uuid.UUID
+	SpecID       uuid.UUID
+	NumberValue  *float64
+	BooleanValue *bool
+	EnumValue    *string
+}
+
+type InsertTempTableProductVariantComponentsParams struct {
+	BundleVariantID    uuid.UUID
+	ComponentVariantID uuid.UUID
+	Quantity           int32
+}
+
 type InsertTempTableProductVariantsParams struct {
-	ID            uuid.UUID
-	SKU           string
-	Price         pgtype.Numeric
-	Quantity      int32
-	PurchaseCount int32
-	ProductID     uuid.UUID
-	CreatedAt     pgtype.Timestamptz
-	UpdatedAt     pgtype.Timestamptz
-	DeletedAt     pgtype.Timestamptz
+	ID                uuid.UUID
+	SKU               string
+	Price             pgtype.Numeric
+	Quantity          int32
+	PurchaseCount     int32
+	LowStockThreshold int32
+	ProductID         uuid.UUID
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	DeletedAt         pgtype.Timestamptz
+	CompareAtPrice    pgtype.Numeric
+	SalePrice         pgtype.Numeric
+	SaleStartsAt      pgtype.Timestamptz
+	SaleEndsAt        pgtype.Timestamptz
 }
 
 type InsertTempTableProductsAttributeValuesParams struct {
@@ -300,6 +559,276 @@ type InsertTempTableProductsAttributeValuesParams struct {
 	AttributeValueID uuid.UUID
 }
 
+const increaseProductViewsCounts = `-- name: IncreaseProductViewsCounts :exec
+WITH views AS (
+  SELECT
+    unnest($1::uuid[]) AS product_id,
+    unnest($2::integer[]) AS views
+), updated_products AS (
+  UPDATE products
+  SET views_count = products.views_count + views.views
+  FROM views
+  WHERE products.id = views.product_id
+  RETURNING products.id, views.views
+)
+INSERT INTO product_daily_stats (
+  product_id,
+  day,
+  views
+)
+SELECT
+  updated_products.id,
+  CURRENT_DATE,
+  updated_products.views
+FROM
+  updated_products
+ON CONFLICT (product_id, day) DO UPDATE SET
+  views = product_daily_stats.views + EXCLUDED.views
+`
+
+type IncreaseProductViewsCountsParams struct {
+	ProductIDs []uuid.UUID
+	Views      []int32
+}
+
+func (q *Queries) IncreaseProductViewsCounts(ctx context.Context, arg IncreaseProductViewsCountsParams) error {
+	_, err := q.db.Exec(ctx, increaseProductViewsCounts, arg.ProductIDs, arg.Views)
+	return err
+}
+
+const insertBoughtTogetherProductRecommendations = `-- name: InsertBoughtTogetherProductRecommendations :exec
+WITH order_products AS (
+  SELECT DISTINCT
+    order_items.order_id,
+    product_variants.product_id
+  FROM
+    order_items
+  INNER JOIN product_variants
+    ON order_items.product_variant_id = product_variants.id
+  INNER JOIN products
+    ON product_variants.product_id = products.id
+  INNER JOIN orders
+    ON order_items.order_id = orders.id
+  INNER JOIN order_statuses
+    ON orders.status_id = order_statuses.id
+  WHERE
+    order_statuses.name <> 'Cancelled'
+    AND products.deleted_at IS NULL
+), ranked AS (
+  SELECT
+    source.product_id,
+    target.product_id AS recommended_product_id,
+    COUNT(*)::real AS score,
+    row_number() OVER (
+      PARTITION BY source.product_id
+      ORDER BY COUNT(*) DESC, target.product_id DESC
+    ) AS rank
+  FROM
+    order_products AS source
+  INNER JOIN order_products AS target
+    ON source.order_id = target.order_id
+    AND source.product_id <> target.product_id
+  GROUP BY
+    source.product_id,
+    target.product_id
+  HAVING
+    COUNT(*) >= $2::integer
+)
+INSERT INTO product_recommendations (
+  product_id,
+  recommended_product_id,
+  kind,
+  score
+)
+SELECT
+  ranked.product_id,
+  ranked.recommended_product_id,
+  'bought_together',
+  ranked.score
+FROM
+  ranked
+WHERE
+  ranked.rank <= $1::integer
+`
+
+type InsertBoughtTogetherProductRecommendationsParams struct {
+	Limit     int32
+	MinOrders int32
+}
+
+// Bought together products appear in the same non cancelled orders at least
+// min_orders times, scored by the number of such orders
+func (q *Queries) InsertBoughtTogetherProductRecommendations(ctx context.Context, arg InsertBoughtTogetherProductRecommendationsParams) error {
+	_, err := q.db.Exec(ctx, insertBoughtTogetherProductRecommendations, arg.Limit, arg.MinOrders)
+	return err
+}
+
+const insertRelatedProductRecommendations = `-- name: InsertRelatedProductRecommendations :exec
+WITH active_products AS (
+  SELECT
+    products.id,
+    products.category_id
+  FROM
+    products
+  WHERE
+    products.deleted_at IS NULL
+), shared_attribute_values AS (
+  SELECT
+    source.product_id,
+    target.product_id AS recommended_product_id,
+    COUNT(*) * $2::real AS score
+  FROM
+    products_attribute_values AS source
+  INNER JOIN products_attribute_values AS target
+    ON source.attribute_value_id = target.attribute_value_id
+    AND source.product_id <> target.product_id
+  GROUP BY
+    source.product_id,
+    target.product_id
+), same_category AS (
+  SELECT
+    source.id AS product_id,
+    target.id AS recommended_product_id,
+    $3::real AS score
+  FROM
+    active_products AS source
+  INNER JOIN active_products AS target
+    ON source.category_id = target.category_id
+    AND source.id <> target.id
+), ranked AS (
+  SELECT
+    candidates.product_id,
+    candidates.recommended_product_id,
+    SUM(candidates.score)::real AS score,
+    row_number() OVER (
+      PARTITION BY candidates.product_id
+      ORDER BY SUM(candidates.score) DESC, candidates.recommended_product_id DESC
+    ) AS rank
+  FROM (
+    SELECT * FROM shared_attribute_values
+    UNION ALL
+    SELECT * FROM same_category
+  ) AS candidates
+  INNER JOIN active_products AS source
+    ON candidates.product_id = source.id
+  INNER JOIN active_products AS target
+    ON candidates.recommended_product_id = target.id
+  GROUP BY
+    candidates.product_id,
+    candidates.recommended_product_id
+)
+INSERT INTO product_recommendations (
+  product_id,
+  recommended_product_id,
+  kind,
+  score
+)
+SELECT
+  ranked.product_id,
+  ranked.recommended_product_id,
+  'related',
+  ranked.score
+FROM
+  ranked
+WHERE
+  ranked.rank <= $1::integer
+`
+
+type InsertRelatedProductRecommendationsParams struct {
+	Limit                int32
+	AttributeValueWeight float32
+	CategoryWeight       float32
+}
+
+// Related products share the category and attribute values, each pair is
+// scored by weight and only the top limit per product are kept
+func (q *Queries) InsertRelatedProductRecommendations(ctx context.Context, arg InsertRelatedProductRecommendationsParams) error {
+	_, err := q.db.Exec(ctx, insertRelatedProductRecommendations, arg.Limit, arg.AttributeValueWeight, arg.CategoryWeight)
+	return err
+}
+
+const listProductImageIDsInUse = `-- name: ListProductImageIDsInUse :many
+SELECT
+  product_images.id
+FROM
+  product_images
+INNER JOIN
+  products ON product_images.product_id = products.id
+WHERE
+  product_images.id = ANY ($1::uuid[])
+  AND (
+    product_images.deleted_at IS NULL
+    OR product_images.deleted_at >= products.deleted_at
+  )
+`
+
+type ListProductImageIDsInUseParams struct {
+	IDs []uuid.UUID
+}
+
+// ListProductImageIDsInUse keeps the images a product shows or gets back when
+// it is restored, those removed along with the product
+func (q *Queries) ListProductImageIDsInUse(ctx context.Context, arg ListProductImageIDsInUseParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listProductImageIDsInUse, arg.IDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductImageRenditions = `-- name: ListProductImageRenditions :many
+SELECT
+  product_image_id, width, height, url
+FROM
+  product_image_renditions
+WHERE
+  product_image_id = ANY ($1::uuid[])
+ORDER BY
+  product_image_id ASC,
+  width ASC
+`
+
+type ListProductImageRenditionsParams struct {
+	ProductImageIDs []uuid.UUID
+}
+
+func (q *Queries) ListProductImageRenditions(ctx context.Context, arg ListProductImageRenditionsParams) ([]ProductImageRendition, error) {
+	rows, err := q.db.Query(ctx, listProductImageRenditions, arg.ProductImageIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductImageRendition
+	for rows.Next() {
+		var i ProductImageRendition
+		if err := rows.Scan(
+			&i.ProductImageID,
+			&i.Width,
+			&i.Height,
+			&i.URL,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductImages = `-- name: ListProductImages :many
 SELECT
   id, url, "order", created_at, deleted_at, product_id, product_variant_id
@@ -369,9 +898,245 @@ func (q *Queries) ListProductImages(ctx context.Context, arg ListProductImagesPa
 	return items, nil
 }
 
+const listProductRecommendations = `-- name: ListProductRecommendations :many
+SELECT
+  product_recommendations.recommended_product_id
+FROM
+  product_recommendations
+INNER JOIN products
+  ON product_recommendations.recommended_product_id = products.id
+WHERE
+  product_recommendations.product_id = $1
+  AND product_recommendations.kind = $2::text
+  AND products.deleted_at IS NULL
+  AND products.status = 'published'
+ORDER BY
+  product_recommendations.score DESC,
+  product_recommendations.recommended_product_id DESC
+LIMIT $3::integer
+`
+
+type ListProductRecommendationsParams struct {
+	ProductID uuid.UUID
+	Kind      string
+	Limit     int32
+}
+
+func (q *Queries) ListProductRecommendations(ctx context.Context, arg ListProductRecommendationsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listProductRecommendations, arg.ProductID, arg.Kind, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var recommended_product_id uuid.UUID
+		if err := rows.Scan(&recommended_product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, recommended_product_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductSpecs = `-- name: ListProductSpecs :many
+SELECT
+  product_id, spec_id, number_value, boolean_value, enum_value
+FROM
+  product_specs
+WHERE
+  product_id = ANY ($1::uuid[])
+ORDER BY
+  product_id ASC,
+  spec_id ASC
+`
+
+type ListProductSpecsParams struct {
+	ProductIDs []uuid.UUID
+}
+
+func (q *Queries) ListProductSpecs(ctx context.Context, arg ListProductSpecsParams) ([]ProductSpec, error) {
+	rows, err := q.db.Query(ctx, listProductSpecs, arg.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductSpec
+	for rows.Next() {
+		var i ProductSpec
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.SpecID,
+			&i.NumberValue,
+			&i.BooleanValue,
+			&i.EnumValue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductSuggestions = `-- name: ListProductSuggestions :many
+(
+  SELECT
+    'product'::text AS type,
+    products.id,
+    products.name AS text,
+    pdb.score(products.id) AS score
+  FROM
+    products
+  WHERE
+    products.name ||| $1::text::pdb.fuzzy(1, t)
+    AND products.deleted_at IS NULL
+    AND products.status = 'published'
+  ORDER BY
+    score DESC,
+    products.trending_score DESC
+  LIMIT $2::integer
+)
+UNION ALL
+(
+  SELECT
+    'category'::text AS type,
+    categories.id,
+    categories.name AS text,
+    pdb.score(categories.id) AS score
+  FROM
+    categories
+  WHERE
+    categories.name ||| $1::text::pdb.fuzzy(1, t)
+    AND categories.deleted_at IS NULL
+  ORDER BY
+    score DESC
+  LIMIT $2::integer
+)
+UNION ALL
+(
+  SELECT
+    'attribute_value'::text AS type,
+    attribute_values.id,
+    attribute_values.value AS text,
+    pdb.score(attribute_values.id) AS score
+  FROM
+    attribute_values
+  INNER JOIN attributes
+    ON attribute_values.attribute_id = attributes.id
+  WHERE
+    attribute_values.value ||| $1::text::pdb.fuzzy(1, t)
+    AND attribute_values.deleted_at IS NULL
+    AND attributes.deleted_at IS NULL
+  ORDER BY
+    score DESC
+  LIMIT $2::integer
+)
+`
+
+type ListProductSuggestionsParams struct {
+	Search string
+	Limit  int32
+}
+
+type ListProductSuggestionsRow struct {
+	Type  string
+	ID    uuid.UUID
+	Text  string
+	Score float32
+}
+
+func (q *Queries) ListProductSuggestions(ctx context.Context, arg ListProductSuggestionsParams) ([]ListProductSuggestionsRow, error) {
+	rows, err := q.db.Query(ctx, listProductSuggestions, arg.Search, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductSuggestionsRow
+	for rows.Next() {
+		var i ListProductSuggestionsRow
+		if err := rows.Scan(
+			&i.Type,
+			&i.ID,
+			&i.Text,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductVariantComponents = `-- name: ListProductVariantComponents :many
+SELECT
+  product_variant_components.bundle_variant_id,
+  product_variant_components.component_variant_id,
+  product_variant_components.quantity,
+  CASE
+    WHEN product_variants.deleted_at IS NULL THEN product_variants.quantity
+    ELSE 0
+  END::integer AS component_quantity
+FROM
+  product_variant_components
+INNER JOIN product_variants
+  ON product_variant_components.component_variant_id = product_variants.id
+WHERE
+  product_variant_components.bundle_variant_id = ANY ($1::uuid[])
+ORDER BY
+  product_variant_components.bundle_variant_id,
+  product_variant_components.component_variant_id
+`
+
+type ListProductVariantComponentsParams struct {
+	BundleVariantIDs []uuid.UUID
+}
+
+type ListProductVariantComponentsRow struct {
+	BundleVariantID    uuid.UUID
+	ComponentVariantID uuid.UUID
+	Quantity           int32
+	ComponentQuantity  int32
+}
+
+// Components come with their own quantity so the stock of a bundle can be
+// derived, a removed component holds nothing
+func (q *Queries) ListProductVariantComponents(ctx context.Context, arg ListProductVariantComponentsParams) ([]ListProductVariantComponentsRow, error) {
+	rows, err := q.db.Query(ctx, listProductVariantComponents, arg.BundleVariantIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductVariantComponentsRow
+	for rows.Next() {
+		var i ListProductVariantComponentsRow
+		if err := rows.Scan(
+			&i.BundleVariantID,
+			&i.ComponentVariantID,
+			&i.Quantity,
+			&i.ComponentQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductVariants = `-- name: ListProductVariants :many
 SELECT
-  id, sku, price, quantity, purchase_count, product_id, created_at, updated_at, deleted_at
+  id, sku, price, quantity, purchase_count, low_stock_threshold, product_id, created_at, updated_at, deleted_at, compare_at_price, sale_price, sale_starts_at, sale_ends_at
 FROM
   product_variants
 WHERE
@@ -444,10 +1209,15 @@ func (q *Queries) ListProductVariants(ctx context.Context, arg ListProductVarian
 			&i.Price,
 			&i.Quantity,
 			&i.PurchaseCount,
+			&i.LowStockThreshold,
 			&i.ProductID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.CompareAtPrice,
+			&i.SalePrice,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
 		); err != nil {
 			return nil, err
 		}
@@ -461,7 +1231,7 @@ func (q *Queries) ListProductVariants(ctx context.Context, arg ListProductVarian
 
 const listProducts = `-- name: ListProducts :many
 SELECT
-  products.id, products.name, products.description, products.price, products.views_count, products.total_purchase, products.rating, products.trending_score, products.category_id, products.created_at, products.updated_at, products.deleted_at
+  products.id, products.name, products.description, products.price, products.views_count, products.total_purchase, products.rating, products.trending_score, products.search_skus, products.search_option_values, products.search_attribute_values, products.category_id, products.created_at, products.updated_at, products.deleted_at, products.status, products.publish_at, products.unpublish_at, products.discount, products.type
 FROM
   products
 INNER JOIN categories
@@ -480,8 +1250,12 @@ WHERE
   AND CASE
     WHEN $3::text = '' THEN TRUE
     ELSE (
-      products.name ||| $3::text
-      OR categories.name ||| $3::text
+      products.name ||| $3::text::pdb.boost(3)
+      OR products.description ||| $3::text
+      OR products.search_skus ||| $3::text::pdb.boost(4)
+      OR products.search_option_values ||| $3::text
+      OR products.search_attribute_values ||| $3::text::pdb.boost(2)
+      OR categories.name ||| $3::text::pdb.boost(2)
     )
   END
   AND CASE
@@ -517,43 +1291,124 @@ WHERE
     WHEN $9::text = 'all' THEN TRUE
     ELSE products.deleted_at IS NULL
   END
+  AND CASE
+    WHEN $10::text[] IS NULL THEN TRUE
+    WHEN cardinality($10::text[]) = 0 THEN TRUE
+    ELSE products.status = ANY ($10::text[])
+  END
+  AND CASE
+    WHEN $11::boolean THEN EXISTS (
+      SELECT 1
+      FROM product_variants
+      WHERE product_variants.product_id = products.id
+        AND product_variants.deleted_at IS NULL
+        AND product_variants.sale_price > 0
+        AND product_variants.sale_price < GREATEST(product_variants.price, product_variants.compare_at_price)
+        AND (product_variants.sale_starts_at IS NULL OR product_variants.sale_starts_at <= NOW())
+        AND (product_variants.sale_ends_at IS NULL OR product_variants.sale_ends_at > NOW())
+    )
+    ELSE TRUE
+  END
+  AND CASE
+    WHEN $12::uuid IS NULL THEN TRUE
+    WHEN $12::uuid = '00000000-0000-0000-0000-000000000000'::uuid THEN TRUE
+    WHEN $13::boolean THEN (
+      products.trending_score < $14::real
+      OR (products.trending_score = $14::real AND products.id < $12::uuid)
+    )
+    WHEN $15::text = 'asc' THEN (
+      products.rating > $16::real
+      OR (products.rating = $16::real AND products.id < $12::uuid)
+    )
+    WHEN $15::text = 'desc' THEN (
+      products.rating < $16::real
+      OR (products.rating = $16::real AND products.id < $12::uuid)
+    )
+    WHEN $17::text = 'asc' THEN (
+      products.price > $18::decimal
+      OR (products.price = $18::decimal AND products.id < $12::uuid)
+    )
+    WHEN $17::text = 'desc' THEN (
+      products.price < $18::decimal
+      OR (products.price = $18::decimal AND products.id < $12::uuid)
+    )
+    WHEN $19::text = 'asc' THEN (
+      products.discount > $20::integer
+      OR (products.discount = $20::integer AND products.id < $12::uuid)
+    )
+    WHEN $19::text = 'desc' THEN (
+      products.discount < $20::integer
+      OR (products.discount = $20::integer AND products.id < $12::uuid)
+    )
+    ELSE products.id < $12::uuid
+  END
 ORDER BY
+  CASE WHEN
+    $3::text <> '' THEN EXISTS (
+      SELECT 1
+      FROM product_variants
+      WHERE product_variants.product_id = products.id
+        AND product_variants.deleted_at IS NULL
+        AND lower(product_variants.sku) = lower($3::text)
+    )
+  END DESC,
   CASE WHEN
     $3::text <> '' THEN pdb.score(products.id) + pdb.score(categories.id) + products.trending_score
   END DESC,
   CASE WHEN
-    $10::text = 'asc' THEN products.rating
+    $13::boolean THEN products.trending_score
+  END DESC,
+  CASE WHEN
+    $15::text = 'asc' THEN products.rating
   END ASC,
   CASE WHEN
-    $10::text = 'desc' THEN products.rating
+    $15::text = 'desc' THEN products.rating
   END DESC,
   CASE WHEN
-    $11::text = 'asc' THEN products.price
+    $17::text = 'asc' THEN products.price
   END ASC,
   CASE WHEN
-    $11::text = 'desc' THEN products.price
-  END DESC
-OFFSET $12::integer
-LIMIT NULLIF($13::integer, 0)
+    $17::text = 'desc' THEN products.price
+  END DESC,
+  CASE WHEN
+    $19::text = 'asc' THEN products.discount
+  END ASC,
+  CASE WHEN
+    $19::text = 'desc' THEN products.discount
+  END DESC,
+  products.id DESC
+OFFSET $21::integer
+LIMIT NULLIF($22::integer, 0)
 `
 
 type ListProductsParams struct {
-	ID          uuid.UUID
-	IDs         []uuid.UUID
-	Search      string
-	MinPrice    pgtype.Numeric
-	MaxPrice    pgtype.Numeric
-	Rating      float32
-	CategoryIDs []uuid.UUID
-	VariantIDs  []uuid.UUID
-	Deleted     string
-	SortRating  string
-	SortPrice   string
-	Offset      int32
-	Limit       int32
+	ID                  uuid.UUID
+	IDs                 []uuid.UUID
+	Search              string
+	MinPrice            pgtype.Numeric
+	MaxPrice            pgtype.Numeric
+	Rating              float32
+	CategoryIDs         []uuid.UUID
+	VariantIDs          []uuid.UUID
+	Deleted             string
+	Statuses            []string
+	OnSale              bool
+	CursorID            uuid.UUID
+	SortTrending        bool
+	CursorTrendingScore float32
+	SortRating          string
+	CursorRating        float32
+	SortPrice           string
+	CursorPrice         pgtype.Numeric
+	SortDiscount        string
+	CursorDiscount      int32
+	Offset              int32
+	Limit               int32
 }
 
-// This is used for list, search (with filter, order), suggest
+// This is used for list, search (with filter, order)
+// cursor_* is the last row of the previous page for keyset pagination,
+// products.id breaks ties so the order is total
 func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error) {
 	rows, err := q.db.Query(ctx, listProducts,
 		arg.ID,
@@ -565,8 +1420,17 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 		arg.CategoryIDs,
 		arg.VariantIDs,
 		arg.Deleted,
+		arg.Statuses,
+		arg.OnSale,
+		arg.CursorID,
+		arg.SortTrending,
+		arg.CursorTrendingScore,
 		arg.SortRating,
+		arg.CursorRating,
 		arg.SortPrice,
+		arg.CursorPrice,
+		arg.SortDiscount,
+		arg.CursorDiscount,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -586,10 +1450,18 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.TotalPurchase,
 			&i.Rating,
 			&i.TrendingScore,
+			&i.SearchSkus,
+			&i.SearchOptionValues,
+			&i.SearchAttributeValues,
 			&i.CategoryID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.UnpublishAt,
+			&i.Discount,
+			&i.Type,
 		); err != nil {
 			return nil, err
 		}
@@ -667,7 +1539,8 @@ WHEN NOT MATCHED THEN
     source.option_value_id
   )
 WHEN NOT MATCHED BY SOURCE
-  AND target.option_value_id = ANY (SELECT id FROM temp_option_values) THEN
+  AND target.option_value_id = ANY (SELECT id FROM temp_option_values)
+  AND target.product_variant_id = ANY (SELECT id FROM temp_product_variants) THEN
   DELETE
 `
 
@@ -676,6 +1549,37 @@ func (q *Queries) MergeOptionValuesProductVariantsFromTemp(ctx context.Context)
 	return err
 }
 
+const mergeProductImageRenditionsFromTemp = `-- name: MergeProductImageRenditionsFromTemp :exec
+MERGE INTO product_image_renditions AS target
+USING temp_product_image_renditions AS source
+  ON target.product_image_id = source.product_image_id
+  AND target.width = source.width
+WHEN MATCHED THEN
+  UPDATE SET
+    height = source.height,
+    url = source.url
+WHEN NOT MATCHED THEN
+  INSERT (
+    product_image_id,
+    width,
+    height,
+    url
+  )
+  VALUES (
+    source.product_image_id,
+    source.width,
+    source.height,
+    source.url
+  )
+`
+
+// Renditions are made once when an image is persisted, they go away with
+// their image
+func (q *Queries) MergeProductImageRenditionsFromTemp(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, mergeProductImageRenditionsFromTemp)
+	return err
+}
+
 const mergeProductImagesFromTemp = `-- name: MergeProductImagesFromTemp :exec
 MERGE INTO product_images AS target
 USING temp_product_images AS source
@@ -687,7 +1591,7 @@ WHEN MATCHED THEN
     product_id = source.product_id,
     product_variant_id = source.product_variant_id,
     created_at = source.created_at,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -717,6 +1621,75 @@ func (q *Queries) MergeProductImagesFromTemp(ctx context.Context) error {
 	return err
 }
 
+const mergeProductSpecsFromTemp = `-- name: MergeProductSpecsFromTemp :exec
+MERGE INTO product_specs AS target
+USING temp_product_specs AS source
+  ON target.product_id = source.product_id
+    AND target.spec_id = source.spec_id
+WHEN MATCHED THEN
+  UPDATE SET
+    number_value = source.number_value,
+    boolean_value = source.boolean_value,
+    enum_value = source.enum_value
+WHEN NOT MATCHED THEN
+  INSERT (
+    product_id,
+    spec_id,
+    number_value,
+    boolean_value,
+    enum_value
+  )
+  VALUES (
+    source.product_id,
+    source.spec_id,
+    source.number_value,
+    source.boolean_value,
+    source.enum_value
+  )
+WHEN NOT MATCHED BY SOURCE
+  AND target.product_id = $1 THEN
+  DELETE
+`
+
+type MergeProductSpecsFromTempParams struct {
+	ProductID uuid.UUID
+}
+
+// The product is passed on its own so clearing every spec value also works
+func (q *Queries) MergeProductSpecsFromTemp(ctx context.Context, arg MergeProductSpecsFromTempParams) error {
+	_, err := q.db.Exec(ctx, mergeProductSpecsFromTemp, arg.ProductID)
+	return err
+}
+
+const mergeProductVariantComponentsFromTemp = `-- name: MergeProductVariantComponentsFromTemp :exec
+MERGE INTO product_variant_components AS target
+USING temp_product_variant_components AS source
+  ON target.bundle_variant_id = source.bundle_variant_id
+  AND target.component_variant_id = source.component_variant_id
+WHEN MATCHED THEN
+  UPDATE SET
+    quantity = source.quantity
+WHEN NOT MATCHED THEN
+  INSERT (
+    bundle_variant_id,
+    component_variant_id,
+    quantity
+  )
+  VALUES (
+    source.bundle_variant_id,
+    source.component_variant_id,
+    source.quantity
+  )
+WHEN NOT MATCHED BY SOURCE
+  AND target.bundle_variant_id = ANY (SELECT id FROM temp_product_variants) THEN
+  DELETE
+`
+
+func (q *Queries) MergeProductVariantComponentsFromTemp(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, mergeProductVariantComponentsFromTemp)
+	return err
+}
+
 const mergeProductVariantsFromTemp = `-- name: MergeProductVariantsFromTemp :exec
 MERGE INTO product_variants AS target
 USING temp_product_variants AS source
@@ -727,10 +1700,15 @@ WHEN MATCHED THEN
     price = source.price,
     quantity = source.quantity,
     purchase_count = source.purchase_count,
+    low_stock_threshold = source.low_stock_threshold,
     product_id = source.product_id,
     created_at = source.created_at,
     updated_at = source.updated_at,
-    deleted_at = COALESCE(NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz), target.deleted_at)
+    deleted_at = NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz),
+    compare_at_price = source.compare_at_price,
+    sale_price = source.sale_price,
+    sale_starts_at = source.sale_starts_at,
+    sale_ends_at = source.sale_ends_at
 WHEN NOT MATCHED THEN
   INSERT (
     id,
@@ -738,10 +1716,15 @@ WHEN NOT MATCHED THEN
     price,
     quantity,
     purchase_count,
+    low_stock_threshold,
     product_id,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    compare_at_price,
+    sale_price,
+    sale_starts_at,
+    sale_ends_at
   )
   VALUES (
     source.id,
@@ -749,10 +1732,15 @@ WHEN NOT MATCHED THEN
     source.price,
     source.quantity,
     source.purchase_count,
+    source.low_stock_threshold,
     source.product_id,
     source.created_at,
     source.updated_at,
-    NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz)
+    NULLIF(source.deleted_at, '0001-01-01T00:00:00Z'::timestamptz),
+    source.compare_at_price,
+    source.sale_price,
+    source.sale_starts_at,
+    source.sale_ends_at
   )
 WHEN NOT MATCHED BY SOURCE
   AND target.product_id = ANY (SELECT DISTINCT id FROM temp_product_variants) THEN
@@ -788,6 +1776,250 @@ func (q *Queries) MergeProductsAttributeValuesFromTemp(ctx context.Context) erro
 	return err
 }
 
+const purgeProduct = `-- name: PurgeProduct :execrows
+WITH purged_products AS (
+  SELECT
+    products.id
+  FROM
+    products
+  WHERE
+    products.id = $1
+    AND products.deleted_at IS NOT NULL
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        order_items
+      INNER JOIN product_variants
+        ON order_items.product_variant_id = product_variants.id
+      WHERE
+        product_variants.product_id = products.id
+    )
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        product_variant_components
+      INNER JOIN product_variants AS component_variants
+        ON product_variant_components.component_variant_id = component_variants.id
+      INNER JOIN product_variants AS bundle_variants
+        ON product_variant_components.bundle_variant_id = bundle_variants.id
+      WHERE
+        component_variants.product_id = products.id
+        AND bundle_variants.product_id <> products.id
+    )
+), purged_variants AS (
+  SELECT
+    product_variants.id
+  FROM
+    product_variants
+  WHERE
+    product_variants.product_id IN (SELECT id FROM purged_products)
+), purged_options AS (
+  SELECT
+    options.id
+  FROM
+    options
+  WHERE
+    options.product_id IN (SELECT id FROM purged_products)
+), deleted_stock_subscriptions AS (
+  DELETE FROM stock_subscriptions
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_stock_movements AS (
+  DELETE FROM stock_movements
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_warehouse_stocks AS (
+  DELETE FROM warehouse_stocks
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_cart_items AS (
+  DELETE FROM cart_items
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_notifications AS (
+  DELETE FROM notifications
+  WHERE
+    product_id IN (SELECT id FROM purged_products)
+    OR product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_product_variant_components AS (
+  DELETE FROM product_variant_components
+  WHERE bundle_variant_id IN (SELECT id FROM purged_variants)
+), deleted_option_values_product_variants AS (
+  DELETE FROM option_values_product_variants
+  WHERE product_variant_id IN (SELECT id FROM purged_variants)
+), deleted_option_values AS (
+  DELETE FROM option_values
+  WHERE option_id IN (SELECT id FROM purged_options)
+), deleted_options AS (
+  DELETE FROM options
+  WHERE id IN (SELECT id FROM purged_options)
+), deleted_product_images AS (
+  DELETE FROM product_images
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_products_attribute_values AS (
+  DELETE FROM products_attribute_values
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_product_specs AS (
+  DELETE FROM product_specs
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_product_daily_stats AS (
+  DELETE FROM product_daily_stats
+  WHERE product_id IN (SELECT id FROM purged_products)
+), deleted_product_recommendations AS (
+  DELETE FROM product_recommendations
+  WHERE
+    product_id IN (SELECT id FROM purged_products)
+    OR recommended_product_id IN (SELECT id FROM purged_products)
+), deleted_product_variants AS (
+  DELETE FROM product_variants
+  WHERE id IN (SELECT id FROM purged_variants)
+)
+DELETE FROM products
+WHERE id IN (SELECT id FROM purged_products)
+`
+
+type PurgeProductParams struct {
+	ProductID uuid.UUID
+}
+
+// Every row referencing the product is deleted in the same statement, the
+// foreign keys are only checked once it ends. Nothing is deleted unless the
+// product is removed, none of its variants were ordered and none of them is a
+// component of another bundle
+func (q *Queries) PurgeProduct(ctx context.Context, arg PurgeProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeProduct, arg.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const syncProductPrices = `-- name: SyncProductPrices :many
+WITH variant_prices AS (
+  SELECT
+    product_variants.product_id,
+    CASE
+      WHEN product_variants.sale_price > 0
+        AND (product_variants.sale_starts_at IS NULL OR product_variants.sale_starts_at <= $1::timestamptz)
+        AND (product_variants.sale_ends_at IS NULL OR product_variants.sale_ends_at > $1::timestamptz)
+        THEN product_variants.sale_price
+      ELSE product_variants.price
+    END AS effective_price,
+    CASE
+      WHEN product_variants.compare_at_price > 0 THEN product_variants.compare_at_price
+      ELSE product_variants.price
+    END AS reference_price
+  FROM
+    product_variants
+  WHERE
+    product_variants.deleted_at IS NULL
+),
+product_prices AS (
+  SELECT
+    variant_prices.product_id,
+    MIN(variant_prices.effective_price) AS price,
+    MAX(
+      CASE
+        WHEN variant_prices.reference_price > variant_prices.effective_price
+          THEN FLOOR((variant_prices.reference_price - variant_prices.effective_price) * 100 / variant_prices.reference_price)
+        ELSE 0
+      END
+    )::integer AS discount
+  FROM
+    variant_prices
+  GROUP BY
+    variant_prices.product_id
+)
+UPDATE products
+SET
+  price = product_prices.price,
+  discount = product_prices.discount
+FROM
+  product_prices
+WHERE
+  products.id = product_prices.product_id
+  AND (products.price <> product_prices.price OR products.discount <> product_prices.discount)
+RETURNING
+  products.id,
+  products.status
+`
+
+type SyncProductPricesParams struct {
+	Now pgtype.Timestamptz
+}
+
+type SyncProductPricesRow struct {
+	ID     uuid.UUID
+	Status string
+}
+
+// SyncProductPrices refreshes the min price and the discount of products whose
+// sale started or ended since their variants were last written, the products
+// changed are returned
+func (q *Queries) SyncProductPrices(ctx context.Context, arg SyncProductPricesParams) ([]SyncProductPricesRow, error) {
+	rows, err := q.db.Query(ctx, syncProductPrices, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SyncProductPricesRow
+	for rows.Next() {
+		var i SyncProductPricesRow
+		if err := rows.Scan(&i.ID, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProductTrendingScores = `-- name: UpdateProductTrendingScores :exec
+WITH scores AS (
+  SELECT
+    products.id,
+    COALESCE(SUM(
+      (
+        product_daily_stats.views * $1::real
+        + product_daily_stats.purchases * $2::real
+      ) * power(0.5::real, (CURRENT_DATE - product_daily_stats.day) / $3::real)
+    ), 0)::real AS trending_score
+  FROM
+    products
+  LEFT JOIN product_daily_stats
+    ON products.id = product_daily_stats.product_id
+    AND product_daily_stats.day > CURRENT_DATE - $4::integer
+  WHERE
+    products.deleted_at IS NULL
+  GROUP BY
+    products.id
+)
+UPDATE products
+SET trending_score = scores.trending_score
+FROM scores
+WHERE products.id = scores.id
+  AND products.trending_score IS DISTINCT FROM scores.trending_score
+`
+
+type UpdateProductTrendingScoresParams struct {
+	ViewWeight     float32
+	PurchaseWeight float32
+	HalfLifeDays   float32
+	WindowDays     int32
+}
+
+// Each day in the window weighs 0.5 ^ (age / half_life), so a view today counts
+// twice as much as a view half_life days ago
+func (q *Queries) UpdateProductTrendingScores(ctx context.Context, arg UpdateProductTrendingScoresParams) error {
+	_, err := q.db.Exec(ctx, updateProductTrendingScores,
+		arg.ViewWeight,
+		arg.PurchaseWeight,
+		arg.HalfLifeDays,
+		arg.WindowDays,
+	)
+	return err
+}
+
 const upsertProduct = `-- name: UpsertProduct :exec
 INSERT INTO products (
   id,
@@ -801,7 +2033,12 @@ INSERT INTO products (
   category_id,
   created_at,
   updated_at,
-  deleted_at
+  deleted_at,
+  status,
+  publish_at,
+  unpublish_at,
+  discount,
+  type
 )
 VALUES (
   $1,
@@ -815,7 +2052,12 @@ VALUES (
   $9,
   $10,
   $11,
-  NULLIF($12::timestamptz, '0001-01-01T00:00:00Z'::timestamptz)
+  NULLIF($12::timestamptz, '0001-01-01T00:00:00Z'::timestamptz),
+  $13,
+  NULLIF($14::timestamptz, '0001-01-01T00:00:00Z'::timestamptz),
+  NULLIF($15::timestamptz, '0001-01-01T00:00:00Z'::timestamptz),
+  $16,
+  $17
 )
 ON CONFLICT (id) DO UPDATE SET
   name = EXCLUDED.name,
@@ -828,7 +2070,12 @@ ON CONFLICT (id) DO UPDATE SET
   category_id = EXCLUDED.category_id,
   created_at = EXCLUDED.created_at,
   updated_at = EXCLUDED.updated_at,
-  deleted_at = COALESCE(EXCLUDED.deleted_at, products.deleted_at)
+  deleted_at = EXCLUDED.deleted_at,
+  status = EXCLUDED.status,
+  publish_at = EXCLUDED.publish_at,
+  unpublish_at = EXCLUDED.unpublish_at,
+  discount = EXCLUDED.discount,
+  type = EXCLUDED.type
 `
 
 type UpsertProductParams struct {
@@ -844,6 +2091,11 @@ type UpsertProductParams struct {
 	CreatedAt     pgtype.Timestamptz
 	UpdatedAt     pgtype.Timestamptz
 	DeletedAt     pgtype.Timestamptz
+	Status        string
+	PublishAt     pgtype.Timestamptz
+	UnpublishAt   pgtype.Timestamptz
+	Discount      int32
+	Type          string
 }
 
 func (q *Queries) UpsertProduct(ctx context.Context, arg UpsertProductParams) error {
@@ -860,6 +2112,34 @@ func (q *Queries) UpsertProduct(ctx context.Context, arg UpsertProductParams) er
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.DeletedAt,
+		arg.Status,
+		arg.PublishAt,
+		arg.UnpublishAt,
+		arg.Discount,
+		arg.Type,
 	)
 	return err
 }
+
+const upsertStockSubscription = `-- name: UpsertStockSubscription :exec
+INSERT INTO stock_subscriptions (
+  product_variant_id,
+  user_id
+) VALUES (
+  $1,
+  $2
+)
+ON CONFLICT (product_variant_id, user_id) DO UPDATE SET
+  created_at = NOW(),
+  notified_at = NULL
+`
+
+type UpsertStockSubscriptionParams struct {
+	ProductVariantID uuid.UUID
+	UserID           uuid.UUID
+}
+
+func (q *Queries) UpsertStockSubscription(ctx context.Context, arg UpsertStockSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, upsertStockSubscription, arg.ProductVariantID, arg.UserID)
+	return err
+}
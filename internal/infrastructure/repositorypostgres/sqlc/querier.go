@@ -6,27 +6,72 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/google/uuid"
 )
 
 type Querier interface {
+	// Drafts due are published and published products due are archived, the
+	// schedule which fired is cleared. The products changed are returned
+	ApplyProductPublishSchedules(ctx context.Context, arg ApplyProductPublishSchedulesParams) ([]ApplyProductPublishSchedulesRow, error)
+	// ClaimEmails leases the oldest due pending emails by pushing their next
+	// attempt after the lease, like ClaimWebhookDeliveries
+	ClaimEmails(ctx context.Context, arg ClaimEmailsParams) ([]Email, error)
+	// ClaimOutboxEvents leases the oldest due events by pushing their next attempt
+	// after the lease, concurrent relays skip the locked rows instead of waiting
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]Outbox, error)
+	// ClaimTasks leases the oldest due pending tasks of the given kinds by pushing
+	// their run after the lease, a worker which dies mid task leaves it to the
+	// next claim once the lease is over
+	ClaimTasks(ctx context.Context, arg ClaimTasksParams) ([]Task, error)
+	// ClaimWebhookDeliveries leases the oldest due pending deliveries by pushing
+	// their next attempt after the lease, like ClaimOutboxEvents
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
 	CountAttributeValues(ctx context.Context, arg CountAttributeValuesParams) (int64, error)
 	CountAttributes(ctx context.Context, arg CountAttributesParams) (int64, error)
 	CountCategories(ctx context.Context, arg CountCategoriesParams) (int64, error)
+	CountEmails(ctx context.Context, arg CountEmailsParams) (int64, error)
+	CountNotifications(ctx context.Context, arg CountNotificationsParams) (int64, error)
 	CountOrders(ctx context.Context, arg CountOrdersParams) (int64, error)
+	CountProductOrderItems(ctx context.Context, arg CountProductOrderItemsParams) (int64, error)
 	CountProducts(ctx context.Context, arg CountProductsParams) (int64, error)
 	CountReviews(ctx context.Context, arg CountReviewsParams) (int64, error)
+	CountSpecGroups(ctx context.Context, arg CountSpecGroupsParams) (int64, error)
+	CountStockMovements(ctx context.Context, arg CountStockMovementsParams) (int64, error)
+	CountTasks(ctx context.Context, arg CountTasksParams) (int64, error)
+	CountWarehouseStocks(ctx context.Context, arg CountWarehouseStocksParams) (int64, error)
+	CountWarehouses(ctx context.Context, arg CountWarehousesParams) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error)
+	CountWebhooks(ctx context.Context, arg CountWebhooksParams) (int64, error)
 	CreateTempTableAttributeValues(ctx context.Context) error
 	CreateTempTableCartItems(ctx context.Context) error
 	CreateTempTableOptionValues(ctx context.Context) error
 	CreateTempTableOptionValuesProductVariants(ctx context.Context) error
 	CreateTempTableOptions(ctx context.Context) error
 	CreateTempTableOrderItems(ctx context.Context) error
+	CreateTempTableProductImageRenditions(ctx context.Context) error
 	CreateTempTableProductImages(ctx context.Context) error
+	CreateTempTableProductSpecs(ctx context.Context) error
+	CreateTempTableProductVariantComponents(ctx context.Context) error
 	CreateTempTableProductVariants(ctx context.Context) error
 	CreateTempTableProductsAttributeValues(ctx context.Context) error
+	CreateTempTableSpecs(ctx context.Context) error
+	CreateTempTableWarehouseStocks(ctx context.Context) error
+	DeleteProductRecommendations(ctx context.Context, arg DeleteProductRecommendationsParams) error
+	DeletePublishedOutboxEvents(ctx context.Context, arg DeletePublishedOutboxEventsParams) (int64, error)
+	DeleteStockSubscription(ctx context.Context, arg DeleteStockSubscriptionParams) error
+	DeleteSucceededTasks(ctx context.Context, arg DeleteSucceededTasksParams) (int64, error)
+	// DropTempTablesProduct lets several products be saved in one transaction,
+	// the temporary tables are otherwise only dropped on commit
+	DropTempTablesProduct(ctx context.Context) error
+	// The stock_movements ledger is append-only, its trigger only lets the
+	// movements of a purged product be deleted once this is set. The setting is
+	// local to the transaction, so it must run in the one of PurgeProduct
+	EnableProductPurge(ctx context.Context) error
 	GetAttribute(ctx context.Context, arg GetAttributeParams) (Attribute, error)
 	GetCart(ctx context.Context, arg GetCartParams) (Cart, error)
 	GetCategory(ctx context.Context, arg GetCategoryParams) (Category, error)
+	GetNotification(ctx context.Context, arg GetNotificationParams) (Notification, error)
 	GetOption(ctx context.Context, arg GetOptionParams) (Option, error)
 	GetOrder(ctx context.Context, arg GetOrderParams) (Order, error)
 	GetOrderItem(ctx context.Context, arg GetOrderItemParams) (OrderItem, error)
@@ -36,48 +81,140 @@ type Querier interface {
 	GetProductImage(ctx context.Context, arg GetProductImageParams) (ProductImage, error)
 	GetProductVariant(ctx context.Context, arg GetProductVariantParams) (ProductVariant, error)
 	GetReview(ctx context.Context, arg GetReviewParams) (Review, error)
+	GetSpecGroup(ctx context.Context, arg GetSpecGroupParams) (SpecGroup, error)
+	GetTask(ctx context.Context, arg GetTaskParams) (Task, error)
+	GetWarehouse(ctx context.Context, arg GetWarehouseParams) (Warehouse, error)
+	GetWebhook(ctx context.Context, arg GetWebhookParams) (Webhook, error)
+	GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error)
+	IncreaseProductViewsCounts(ctx context.Context, arg IncreaseProductViewsCountsParams) error
+	// Bought together products appear in the same non cancelled orders at least
+	// min_orders times, scored by the number of such orders
+	InsertBoughtTogetherProductRecommendations(ctx context.Context, arg InsertBoughtTogetherProductRecommendationsParams) error
+	// An event gets at most one email of a kind, the relay publishes events at
+	// least once
+	InsertEmails(ctx context.Context, arg InsertEmailsParams) error
+	InsertOutboxEvents(ctx context.Context, arg []InsertOutboxEventsParams) (int64, error)
+	// Related products share the category and attribute values, each pair is
+	// scored by weight and only the top limit per product are kept
+	InsertRelatedProductRecommendations(ctx context.Context, arg InsertRelatedProductRecommendationsParams) error
+	InsertStockMovements(ctx context.Context, arg []InsertStockMovementsParams) (int64, error)
+	// A task with a key is queued at most once per kind, the key of a scheduled
+	// run names its fire time so the workers enqueue it only once
+	InsertTasks(ctx context.Context, arg InsertTasksParams) error
 	InsertTempTableAttributeValues(ctx context.Context, arg []InsertTempTableAttributeValuesParams) (int64, error)
 	InsertTempTableCartItems(ctx context.Context, arg []InsertTempTableCartItemsParams) (int64, error)
 	InsertTempTableOptionValues(ctx context.Context, arg []InsertTempTableOptionValuesParams) (int64, error)
 	InsertTempTableOptionValuesProductVariants(ctx context.Context, arg []InsertTempTableOptionValuesProductVariantsParams) (int64, error)
 	InsertTempTableOptions(ctx context.Context, arg []InsertTempTableOptionsParams) (int64, error)
 	InsertTempTableOrderItems(ctx context.Context, arg []InsertTempTableOrderItemsParams) (int64, error)
+	InsertTempTableProductImageRenditions(ctx context.Context, arg []InsertTempTableProductImageRenditionsParams) (int64, error)
 	InsertTempTableProductImages(ctx context.Context, arg []InsertTempTableProductImagesParams) (int64, error)
+	InsertTempTableProductSpecs(ctx context.Context, arg []InsertTempTableProductSpecsParams) (int64, error)
+	InsertTempTableProductVariantComponents(ctx context.Context, arg []InsertTempTableProductVariantComponentsParams) (int64, error)
 	InsertTempTableProductVariants(ctx context.Context, arg []InsertTempTableProductVariantsParams) (int64, error)
 	InsertTempTableProductsAttributeValues(ctx context.Context, arg []InsertTempTableProductsAttributeValuesParams) (int64, error)
+	InsertTempTableSpecs(ctx context.Context, arg []InsertTempTableSpecsParams) (int64, error)
+	InsertTempTableWarehouseStocks(ctx context.Context, arg []InsertTempTableWarehouseStocksParams) (int64, error)
+	// A webhook gets at most one delivery per event, the relay publishes events
+	// at least once
+	InsertWebhookDeliveries(ctx context.Context, arg InsertWebhookDeliveriesParams) error
 	ListAttributeByAttributeValues(ctx context.Context, arg ListAttributeByAttributeValuesParams) ([]Attribute, error)
 	ListAttributeValues(ctx context.Context, arg ListAttributeValuesParams) ([]AttributeValue, error)
 	ListAttributes(ctx context.Context, arg ListAttributesParams) ([]Attribute, error)
 	ListCartItems(ctx context.Context, arg ListCartItemsParams) ([]CartItem, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListEmails(ctx context.Context, arg ListEmailsParams) ([]Email, error)
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
 	ListOptionValues(ctx context.Context, arg ListOptionValuesParams) ([]OptionValue, error)
 	ListOptionValuesProductVariants(ctx context.Context, arg ListOptionValuesProductVariantsParams) ([]OptionValuesProductVariant, error)
 	ListOptions(ctx context.Context, arg ListOptionsParams) ([]Option, error)
 	ListOrderItems(ctx context.Context, arg ListOrderItemsParams) ([]OrderItem, error)
 	ListOrderStatuses(ctx context.Context, arg ListOrderStatusesParams) ([]OrderStatus, error)
 	ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error)
+	// ListProductImageIDsInUse keeps the images a product shows or gets back when
+	// it is restored, those removed along with the product
+	ListProductImageIDsInUse(ctx context.Context, arg ListProductImageIDsInUseParams) ([]uuid.UUID, error)
+	ListProductImageRenditions(ctx context.Context, arg ListProductImageRenditionsParams) ([]ProductImageRendition, error)
 	ListProductImages(ctx context.Context, arg ListProductImagesParams) ([]ProductImage, error)
+	ListProductRecommendations(ctx context.Context, arg ListProductRecommendationsParams) ([]uuid.UUID, error)
+	ListProductSpecs(ctx context.Context, arg ListProductSpecsParams) ([]ProductSpec, error)
+	ListProductSuggestions(ctx context.Context, arg ListProductSuggestionsParams) ([]ListProductSuggestionsRow, error)
+	// Components come with their own quantity so the stock of a bundle can be
+	// derived, a removed component holds nothing
+	ListProductVariantComponents(ctx context.Context, arg ListProductVariantComponentsParams) ([]ListProductVariantComponentsRow, error)
 	ListProductVariants(ctx context.Context, arg ListProductVariantsParams) ([]ProductVariant, error)
-	// This is used for list, search (with filter, order), suggest
+	// This is used for list, search (with filter, order)
+	// cursor_* is the last row of the previous page for keyset pagination,
+	// products.id breaks ties so the order is total
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListProductsAttributeValues(ctx context.Context, arg ListProductsAttributeValuesParams) ([]ProductsAttributeValue, error)
 	ListReviews(ctx context.Context, arg ListReviewsParams) ([]Review, error)
+	ListSpecGroups(ctx context.Context, arg ListSpecGroupsParams) ([]SpecGroup, error)
+	ListSpecs(ctx context.Context, arg ListSpecsParams) ([]Spec, error)
+	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, error)
+	ListUnpaidOrderIDs(ctx context.Context, arg ListUnpaidOrderIDsParams) ([]uuid.UUID, error)
+	ListWarehouseStocks(ctx context.Context, arg ListWarehouseStocksParams) ([]WarehouseStock, error)
+	ListWarehouses(ctx context.Context, arg ListWarehousesParams) ([]Warehouse, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context, arg ListWebhooksParams) ([]Webhook, error)
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkOutboxEventsPublished(ctx context.Context, arg MarkOutboxEventsPublishedParams) error
 	MergeAttributeValuesFromTemp(ctx context.Context) error
 	MergeCartItemsFromTemp(ctx context.Context) error
 	MergeOptionValuesFromTemp(ctx context.Context) error
 	MergeOptionValuesProductVariantsFromTemp(ctx context.Context) error
 	MergeOptionsFromTemp(ctx context.Context) error
+	// LockOrderPaymentState locks an order until the end of the transaction, the
+	// state read can not change before it is written
+	LockOrderPaymentState(ctx context.Context, arg LockOrderPaymentStateParams) (LockOrderPaymentStateRow, error)
 	MergeOrderItemsFromTemp(ctx context.Context) error
+	// Renditions are made once when an image is persisted, they go away with
+	// their image
+	MergeProductImageRenditionsFromTemp(ctx context.Context) error
 	MergeProductImagesFromTemp(ctx context.Context) error
+	// The product is passed on its own so clearing every spec value also works
+	MergeProductSpecsFromTemp(ctx context.Context, arg MergeProductSpecsFromTempParams) error
+	MergeProductVariantComponentsFromTemp(ctx context.Context) error
 	MergeProductVariantsFromTemp(ctx context.Context) error
 	MergeProductsAttributeValuesFromTemp(ctx context.Context) error
+	// Specs are only ever soft deleted, products keep the values they had
+	MergeSpecsFromTemp(ctx context.Context) error
+	MergeWarehouseStocksFromTemp(ctx context.Context) error
+	// Every row referencing the product is deleted in the same statement, the
+	// foreign keys are only checked once it ends. Nothing is deleted unless the
+	// product is removed, none of its variants were ordered and none of them is a
+	// component of another bundle
+	PurgeProduct(ctx context.Context, arg PurgeProductParams) (int64, error)
+	// Products are saved with the quantity they were loaded with, so concurrent
+	// writers can lose each other's changes. The ledger is append-only and is the
+	// source of truth, variants without any movement are left alone. The stock of
+	// each warehouse is reset in the same statement, only for variants whose every
+	// movement names its warehouse; the others hold stock not at any warehouse.
+	// The variants which drifted are counted
+	ReconcileProductVariantQuantities(ctx context.Context) (int64, error)
+	// SyncProductPrices refreshes the min price and the discount of products whose
+	// sale started or ended since their variants were last written, the products
+	// changed are returned
+	SyncProductPrices(ctx context.Context, arg SyncProductPricesParams) ([]SyncProductPricesRow, error)
+	UpdateEmail(ctx context.Context, arg UpdateEmailParams) error
+	// Each day in the window weighs 0.5 ^ (age / half_life), so a view today counts
+	// twice as much as a view half_life days ago
+	UpdateProductTrendingScores(ctx context.Context, arg UpdateProductTrendingScoresParams) error
+	UpdateTask(ctx context.Context, arg UpdateTaskParams) error
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
 	UpsertAttribute(ctx context.Context, arg UpsertAttributeParams) error
 	UpsertCart(ctx context.Context, arg UpsertCartParams) error
 	UpsertCategory(ctx context.Context, arg UpsertCategoryParams) error
+	UpsertNotification(ctx context.Context, arg UpsertNotificationParams) error
 	UpsertOption(ctx context.Context, arg UpsertOptionParams) error
 	UpsertOrder(ctx context.Context, arg UpsertOrderParams) error
 	UpsertProduct(ctx context.Context, arg UpsertProductParams) error
 	UpsertReview(ctx context.Context, arg UpsertReviewParams) error
+	UpsertSpecGroup(ctx context.Context, arg UpsertSpecGroupParams) error
+	UpsertStockSubscription(ctx context.Context, arg UpsertStockSubscriptionParams) error
+	UpsertWarehouse(ctx context.Context, arg UpsertWarehouseParams) error
+	UpsertWebhook(ctx context.Context, arg UpsertWebhookParams) error
 }
 
 var _ Querier = (*Queries)(nil)
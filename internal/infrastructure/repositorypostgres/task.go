@@ -0,0 +1,198 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Task struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.TaskRepository = (*Task)(nil)
+
+func ProvideTask(q *sqlc.Queries) *Task {
+	return &Task{queries: q}
+}
+
+func (r *Task) Count(
+	ctx context.Context,
+	params domain.TaskRepositoryCountParam,
+) (*int, error) {
+	count, err := r.queries.CountTasks(ctx, sqlc.CountTasksParams{
+		Kinds:  toTaskKindStrings(params.Kinds),
+		Status: string(params.Status),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Task) List(
+	ctx context.Context,
+	params domain.TaskRepositoryListParam,
+) (*[]domain.Task, error) {
+	tasks, err := r.queries.ListTasks(ctx, sqlc.ListTasksParams{
+		Kinds:  toTaskKindStrings(params.Kinds),
+		Status: string(params.Status),
+		Limit:  int32(params.Limit),
+		Offset: int32(params.Offset),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, toDomainTask(task))
+	}
+	return &result, nil
+}
+
+func (r *Task) Get(
+	ctx context.Context,
+	params domain.TaskRepositoryGetParam,
+) (*domain.Task, error) {
+	task, err := r.queries.GetTask(ctx, sqlc.GetTaskParams{
+		ID: params.ID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(toDomainTask(task)), nil
+}
+
+func (r *Task) Create(
+	ctx context.Context,
+	params domain.TaskRepositoryCreateParam,
+) error {
+	if len(params.Tasks) == 0 {
+		return nil
+	}
+	var arg sqlc.InsertTasksParams
+	for _, t := range params.Tasks {
+		arg.IDs = append(arg.IDs, t.ID)
+		arg.Kinds = append(arg.Kinds, string(t.Kind))
+		arg.Keys = append(arg.Keys, t.Key)
+		arg.Payloads = append(arg.Payloads, t.Payload)
+		arg.RunAts = append(arg.RunAts, pgtype.Timestamptz{
+			Time:  t.RunAt,
+			Valid: true,
+		})
+		arg.CreatedAts = append(arg.CreatedAts, pgtype.Timestamptz{
+			Time:  t.CreatedAt,
+			Valid: true,
+		})
+		arg.UpdatedAts = append(arg.UpdatedAts, pgtype.Timestamptz{
+			Time:  t.UpdatedAt,
+			Valid: true,
+		})
+	}
+	if err := r.queries.InsertTasks(ctx, arg); err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Task) Save(
+	ctx context.Context,
+	params domain.TaskRepositorySaveParam,
+) error {
+	err := r.queries.UpdateTask(ctx, sqlc.UpdateTaskParams{
+		Status:    string(params.Task.Status),
+		Attempts:  int32(params.Task.Attempts),
+		LastError: params.Task.Error,
+		RunAt: pgtype.Timestamptz{
+			Time:  params.Task.RunAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  params.Task.UpdatedAt,
+			Valid: true,
+		},
+		FinishedAt: pgtype.Timestamptz{
+			Time:  params.Task.FinishedAt,
+			Valid: !params.Task.FinishedAt.IsZero(),
+		},
+		ID: params.Task.ID,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *Task) Claim(
+	ctx context.Context,
+	params domain.TaskRepositoryClaimParam,
+) (*[]domain.Task, error) {
+	tasks, err := r.queries.ClaimTasks(ctx, sqlc.ClaimTasksParams{
+		LeaseUntil: pgtype.Timestamptz{
+			Time:  params.LeaseUntil,
+			Valid: true,
+		},
+		Kinds: toTaskKindStrings(params.Kinds),
+		Now: pgtype.Timestamptz{
+			Time:  params.Now,
+			Valid: true,
+		},
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, toDomainTask(task))
+	}
+	return &result, nil
+}
+
+func (r *Task) DeleteSucceeded(
+	ctx context.Context,
+	params domain.TaskRepositoryDeleteSucceededParam,
+) (*int, error) {
+	count, err := r.queries.DeleteSucceededTasks(ctx, sqlc.DeleteSucceededTasksParams{
+		Before: pgtype.Timestamptz{
+			Time:  params.Before,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+// toTaskKindStrings keeps a nil filter nil, the queries read it as no filter
+func toTaskKindStrings(kinds []domain.TaskKind) []string {
+	if kinds == nil {
+		return nil
+	}
+	result := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		result = append(result, string(kind))
+	}
+	return result
+}
+
+func toDomainTask(t sqlc.Task) domain.Task {
+	return domain.Task{
+		ID:         t.ID,
+		Kind:       domain.TaskKind(t.Kind),
+		Key:        ptr.Deref(t.Key, ""),
+		Payload:    t.Payload,
+		Status:     domain.TaskStatus(t.Status),
+		Attempts:   int(t.Attempts),
+		Error:      t.LastError,
+		RunAt:      t.RunAt.Time,
+		CreatedAt:  t.CreatedAt.Time,
+		UpdatedAt:  t.UpdatedAt.Time,
+		FinishedAt: t.FinishedAt.Time,
+	}
+}
@@ -0,0 +1,104 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Warehouse struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.WarehouseRepository = (*Warehouse)(nil)
+
+func ProvideWarehouse(q *sqlc.Queries) *Warehouse {
+	return &Warehouse{queries: q}
+}
+
+func (r *Warehouse) Count(ctx context.Context, params domain.WarehouseRepositoryCountParam) (*int, error) {
+	count, err := r.queries.CountWarehouses(ctx, sqlc.CountWarehousesParams{
+		IDs:     params.IDs,
+		Deleted: string(params.Deleted),
+	})
+	return ptr.To(int(count)), err
+}
+
+func (r *Warehouse) List(
+	ctx context.Context,
+	params domain.WarehouseRepositoryListParam,
+) (*[]domain.Warehouse, error) {
+	warehouses, err := r.queries.ListWarehouses(ctx, sqlc.ListWarehousesParams{
+		IDs:     params.IDs,
+		Deleted: string(params.Deleted),
+		Limit:   int32(params.Limit),
+		Offset:  int32(params.Offset),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.Warehouse, 0, len(warehouses))
+	for _, warehouse := range warehouses {
+		result = append(result, toDomainWarehouse(warehouse))
+	}
+	return &result, nil
+}
+
+func (r *Warehouse) Get(ctx context.Context, params domain.WarehouseRepositoryGetParam) (*domain.Warehouse, error) {
+	warehouse, err := r.queries.GetWarehouse(ctx, sqlc.GetWarehouseParams{
+		ID:      params.ID,
+		Deleted: string(domain.DeletedExcludeParam),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := toDomainWarehouse(warehouse)
+	return &result, nil
+}
+
+func (r *Warehouse) CountStocks(ctx context.Context, params domain.WarehouseRepositoryCountStocksParam) (*int, error) {
+	count, err := r.queries.CountWarehouseStocks(ctx, sqlc.CountWarehouseStocksParams{
+		WarehouseID: params.WarehouseID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Warehouse) Save(ctx context.Context, params domain.WarehouseRepositorySaveParam) error {
+	return r.queries.UpsertWarehouse(ctx, sqlc.UpsertWarehouseParams{
+		ID:       params.Warehouse.ID,
+		Name:     params.Warehouse.Name,
+		Province: params.Warehouse.Province,
+		Priority: int32(params.Warehouse.Priority),
+		CreatedAt: pgtype.Timestamptz{
+			Time:  params.Warehouse.CreatedAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  params.Warehouse.UpdatedAt,
+			Valid: true,
+		},
+		DeletedAt: pgtype.Timestamptz{
+			Time:  params.Warehouse.DeletedAt,
+			Valid: !params.Warehouse.DeletedAt.IsZero(),
+		},
+	})
+}
+
+func toDomainWarehouse(w sqlc.Warehouse) domain.Warehouse {
+	return domain.Warehouse{
+		ID:        w.ID,
+		Name:      w.Name,
+		Province:  w.Province,
+		Priority:  int(w.Priority),
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+		DeletedAt: w.DeletedAt.Time,
+	}
+}
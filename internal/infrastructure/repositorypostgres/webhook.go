@@ -0,0 +1,113 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Webhook struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.WebhookRepository = (*Webhook)(nil)
+
+func ProvideWebhook(q *sqlc.Queries) *Webhook {
+	return &Webhook{queries: q}
+}
+
+func (r *Webhook) Count(ctx context.Context, params domain.WebhookRepositoryCountParam) (*int, error) {
+	count, err := r.queries.CountWebhooks(ctx, sqlc.CountWebhooksParams{
+		IDs:       params.IDs,
+		EventType: string(params.EventType),
+		Deleted:   string(params.Deleted),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *Webhook) List(
+	ctx context.Context,
+	params domain.WebhookRepositoryListParam,
+) (*[]domain.Webhook, error) {
+	webhooks, err := r.queries.ListWebhooks(ctx, sqlc.ListWebhooksParams{
+		IDs:       params.IDs,
+		EventType: string(params.EventType),
+		Deleted:   string(params.Deleted),
+		Limit:     int32(params.Limit),
+		Offset:    int32(params.Offset),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, toDomainWebhook(webhook))
+	}
+	return &result, nil
+}
+
+func (r *Webhook) Get(ctx context.Context, params domain.WebhookRepositoryGetParam) (*domain.Webhook, error) {
+	webhook, err := r.queries.GetWebhook(ctx, sqlc.GetWebhookParams{
+		ID:      params.ID,
+		Deleted: string(domain.DeletedExcludeParam),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := toDomainWebhook(webhook)
+	return &result, nil
+}
+
+func (r *Webhook) Save(ctx context.Context, params domain.WebhookRepositorySaveParam) error {
+	eventTypes := make([]string, 0, len(params.Webhook.EventTypes))
+	for _, eventType := range params.Webhook.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	err := r.queries.UpsertWebhook(ctx, sqlc.UpsertWebhookParams{
+		ID:         params.Webhook.ID,
+		URL:        params.Webhook.URL,
+		Secret:     params.Webhook.Secret,
+		EventTypes: eventTypes,
+		Active:     params.Webhook.Active,
+		CreatedAt: pgtype.Timestamptz{
+			Time:  params.Webhook.CreatedAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  params.Webhook.UpdatedAt,
+			Valid: true,
+		},
+		DeletedAt: pgtype.Timestamptz{
+			Time:  params.Webhook.DeletedAt,
+			Valid: !params.Webhook.DeletedAt.IsZero(),
+		},
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func toDomainWebhook(w sqlc.Webhook) domain.Webhook {
+	eventTypes := make([]domain.EventType, 0, len(w.EventTypes))
+	for _, eventType := range w.EventTypes {
+		eventTypes = append(eventTypes, domain.EventType(eventType))
+	}
+	return domain.Webhook{
+		ID:         w.ID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: eventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+		DeletedAt:  w.DeletedAt.Time,
+	}
+}
@@ -0,0 +1,176 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/helper/ptr"
+	"backend/internal/infrastructure/repositorypostgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WebhookDelivery struct {
+	queries *sqlc.Queries
+}
+
+var _ domain.WebhookDeliveryRepository = (*WebhookDelivery)(nil)
+
+func ProvideWebhookDelivery(q *sqlc.Queries) *WebhookDelivery {
+	return &WebhookDelivery{queries: q}
+}
+
+func (r *WebhookDelivery) Count(
+	ctx context.Context,
+	params domain.WebhookDeliveryRepositoryCountParam,
+) (*int, error) {
+	count, err := r.queries.CountWebhookDeliveries(ctx, sqlc.CountWebhookDeliveriesParams{
+		WebhookID: params.WebhookID,
+		Status:    string(params.Status),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return ptr.To(int(count)), nil
+}
+
+func (r *WebhookDelivery) List(
+	ctx context.Context,
+	params domain.WebhookDeliveryRepositoryListParam,
+) (*[]domain.WebhookDelivery, error) {
+	deliveries, err := r.queries.ListWebhookDeliveries(ctx, sqlc.ListWebhookDeliveriesParams{
+		WebhookID: params.WebhookID,
+		Status:    string(params.Status),
+		Limit:     int32(params.Limit),
+		Offset:    int32(params.Offset),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toDomainWebhookDelivery(delivery))
+	}
+	return &result, nil
+}
+
+func (r *WebhookDelivery) Get(
+	ctx context.Context,
+	params domain.WebhookDeliveryRepositoryGetParam,
+) (*domain.WebhookDelivery, error) {
+	delivery, err := r.queries.GetWebhookDelivery(ctx, sqlc.GetWebhookDeliveryParams{
+		ID: params.ID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := toDomainWebhookDelivery(delivery)
+	return &result, nil
+}
+
+func (r *WebhookDelivery) Create(
+	ctx context.Context,
+	params domain.WebhookDeliveryRepositoryCreateParam,
+) error {
+	if len(params.Deliveries) == 0 {
+		return nil
+	}
+	var arg sqlc.InsertWebhookDeliveriesParams
+	for _, d := range params.Deliveries {
+		arg.IDs = append(arg.IDs, d.ID)
+		arg.WebhookIDs = append(arg.WebhookIDs, d.WebhookID)
+		arg.EventIDs = append(arg.EventIDs, d.EventID)
+		arg.EventTypes = append(arg.EventTypes, string(d.EventType))
+		arg.Payloads = append(arg.Payloads, string(d.Payload))
+		arg.NextAttemptAts = append(arg.NextAttemptAts, pgtype.Timestamptz{
+			Time:  d.NextAttemptAt,
+			Valid: true,
+		})
+		arg.CreatedAts = append(arg.CreatedAts, pgtype.Timestamptz{
+			Time:  d.CreatedAt,
+			Valid: true,
+		})
+		arg.UpdatedAts = append(arg.UpdatedAts, pgtype.Timestamptz{
+			Time:  d.UpdatedAt,
+			Valid: true,
+		})
+	}
+	if err := r.queries.InsertWebhookDeliveries(ctx, arg); err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *WebhookDelivery) Save(
+	ctx context.Context,
+	params domain.WebhookDeliveryRepositorySaveParam,
+) error {
+	err := r.queries.UpdateWebhookDelivery(ctx, sqlc.UpdateWebhookDeliveryParams{
+		Status:       string(params.Delivery.Status),
+		Attempts:     int32(params.Delivery.Attempts),
+		ResponseCode: int32(params.Delivery.ResponseCode),
+		ResponseBody: params.Delivery.ResponseBody,
+		LastError:    params.Delivery.Error,
+		NextAttemptAt: pgtype.Timestamptz{
+			Time:  params.Delivery.NextAttemptAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  params.Delivery.UpdatedAt,
+			Valid: true,
+		},
+		DeliveredAt: pgtype.Timestamptz{
+			Time:  params.Delivery.DeliveredAt,
+			Valid: !params.Delivery.DeliveredAt.IsZero(),
+		},
+		ID: params.Delivery.ID,
+	})
+	if err != nil {
+		return toDomainError(err)
+	}
+	return nil
+}
+
+func (r *WebhookDelivery) Claim(
+	ctx context.Context,
+	params domain.WebhookDeliveryRepositoryClaimParam,
+) (*[]domain.WebhookDelivery, error) {
+	deliveries, err := r.queries.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+		LeaseUntil: pgtype.Timestamptz{
+			Time:  params.LeaseUntil,
+			Valid: true,
+		},
+		Now: pgtype.Timestamptz{
+			Time:  params.Now,
+			Valid: true,
+		},
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	result := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toDomainWebhookDelivery(delivery))
+	}
+	return &result, nil
+}
+
+func toDomainWebhookDelivery(d sqlc.WebhookDelivery) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     domain.EventType(d.EventType),
+		Payload:       d.Payload,
+		Status:        domain.WebhookDeliveryStatus(d.Status),
+		Attempts:      int(d.Attempts),
+		ResponseCode:  int(d.ResponseCode),
+		ResponseBody:  d.ResponseBody,
+		Error:         d.LastError,
+		NextAttemptAt: d.NextAttemptAt.Time,
+		CreatedAt:     d.CreatedAt.Time,
+		UpdatedAt:     d.UpdatedAt.Time,
+		DeliveredAt:   d.DeliveredAt.Time,
+	}
+}
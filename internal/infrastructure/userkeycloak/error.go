@@ -0,0 +1,57 @@
+package userkeycloak
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"backend/internal/domain"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/hashicorp/go-multierror"
+)
+
+func toDomainError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr gocloak.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+
+		case http.StatusBadRequest:
+			return multierror.Append(domain.ErrInvalid, errors.New(apiErr.Message), err)
+
+		case http.StatusForbidden:
+			return multierror.Append(domain.ErrForbidden, errors.New("access denied"), err)
+
+		case http.StatusNotFound:
+			return multierror.Append(domain.ErrNotFound, errors.New("resource not found"), err)
+
+		case http.StatusConflict:
+			return multierror.Append(domain.ErrExists, errors.New("resource conflict"), err)
+
+		case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return multierror.Append(domain.ErrUnavailable, errors.New("keycloak unavailable"), err)
+
+		default:
+			return multierror.Append(domain.ErrServiceError, errors.New("unknown keycloak error"), err)
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return multierror.Append(domain.ErrTimeout, errors.New("keycloak timeout"), err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return multierror.Append(domain.ErrTimeout, errors.New("keycloak network timeout"), err)
+		}
+		return multierror.Append(domain.ErrUnavailable, errors.New("keycloak network error"), err)
+	}
+
+	return multierror.Append(domain.ErrUnknown, errors.New("unexpected keycloak error"), err)
+}
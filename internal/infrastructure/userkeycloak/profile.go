@@ -0,0 +1,79 @@
+package userkeycloak
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/google/uuid"
+)
+
+// tokenExpiryLeeway renews the service account token before Keycloak expires
+// it, so a request never goes out with a token about to expire
+const tokenExpiryLeeway = 30 * time.Second
+
+// localeAttribute is the user attribute Keycloak keeps the picked language in
+const localeAttribute = "locale"
+
+// ProfileService reads users through the admin API as the service account of
+// the backend client, which needs the view-users role of realm-management
+type ProfileService struct {
+	keycloakClient *gocloak.GoCloak
+	srvCfg         *config.Server
+
+	mu             sync.Mutex
+	token          string
+	tokenExpiresAt time.Time
+}
+
+func ProvideProfileService(keycloakClient *gocloak.GoCloak, srvCfg *config.Server) *ProfileService {
+	return &ProfileService{
+		keycloakClient: keycloakClient,
+		srvCfg:         srvCfg,
+	}
+}
+
+var _ application.UserProfileService = (*ProfileService)(nil)
+
+func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (*application.UserProfile, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	user, err := s.keycloakClient.GetUserByID(ctx, token, s.srvCfg.KCRealm, userID.String())
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+
+	profile := &application.UserProfile{
+		Email:     gocloak.PString(user.Email),
+		FirstName: gocloak.PString(user.FirstName),
+		LastName:  gocloak.PString(user.LastName),
+	}
+	if user.Attributes != nil {
+		if locales := (*user.Attributes)[localeAttribute]; len(locales) > 0 {
+			profile.Locale = locales[0]
+		}
+	}
+	return profile, nil
+}
+
+func (s *ProfileService) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.tokenExpiresAt) {
+		return s.token, nil
+	}
+	jwt, err := s.keycloakClient.LoginClient(ctx, s.srvCfg.KCClientId, s.srvCfg.KCClientSecret, s.srvCfg.KCRealm)
+	if err != nil {
+		return "", err
+	}
+	s.token = jwt.AccessToken
+	s.tokenExpiresAt = time.Now().Add(time.Duration(jwt.ExpiresIn)*time.Second - tokenExpiryLeeway)
+	return s.token, nil
+}
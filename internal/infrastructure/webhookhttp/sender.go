@@ -0,0 +1,66 @@
+package webhookhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"backend/config"
+	"backend/internal/application"
+)
+
+// maxResponseBodyBytes is how much of a response body is kept in the
+// delivery log, partners answer with an acknowledgement or an error message
+const maxResponseBodyBytes = 4 << 10
+
+type Sender struct {
+	httpClient *http.Client
+}
+
+func ProvideSender(srvCfg *config.Server) *Sender {
+	return &Sender{
+		httpClient: &http.Client{
+			Timeout: srvCfg.WebhookTimeout,
+			// A redirect would resend the delivery somewhere the partner
+			// did not register, it counts as a failed attempt instead
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+var _ application.WebhookSender = (*Sender)(nil)
+
+func (s *Sender) Send(
+	ctx context.Context,
+	param application.WebhookSenderSendParam,
+) (*application.WebhookSenderResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, param.URL, bytes.NewReader(param.Body))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range param.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// The body is only informative, a truncated one is still recorded
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
+	return &application.WebhookSenderResponse{
+		StatusCode: resp.StatusCode,
+		Body:       toText(body),
+	}, nil
+}
+
+// toText makes a response body storable as text, which can hold neither
+// invalid UTF-8 nor NUL
+func toText(body []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", "")
+}
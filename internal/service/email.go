@@ -0,0 +1,31 @@
+package service
+
+import (
+	"backend/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Email struct {
+	validate *validator.Validate
+}
+
+func ProvideEmail(
+	validate *validator.Validate,
+) *Email {
+	return &Email{
+		validate: validate,
+	}
+}
+
+var _ domain.EmailService = (*Email)(nil)
+
+func (e *Email) Validate(
+	email domain.Email,
+) error {
+	if err := e.validate.Struct(email); err != nil {
+		return multierror.Append(domain.ErrInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"backend/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+)
+
+type SpecGroup struct {
+	validate *validator.Validate
+}
+
+func ProvideSpecGroup(
+	validate *validator.Validate,
+) *SpecGroup {
+	return &SpecGroup{
+		validate: validate,
+	}
+}
+
+var _ domain.SpecGroupService = (*SpecGroup)(nil)
+
+func (s *SpecGroup) Validate(
+	specGroup domain.SpecGroup,
+) error {
+	if err := s.validate.Struct(specGroup); err != nil {
+		return multierror.Append(domain.ErrInvalid, err)
+	}
+	return nil
+}
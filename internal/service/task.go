@@ -0,0 +1,31 @@
+package service
+
+import (
+	"backend/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Task struct {
+	validate *validator.Validate
+}
+
+func ProvideTask(
+	validate *validator.Validate,
+) *Task {
+	return &Task{
+		validate: validate,
+	}
+}
+
+var _ domain.TaskService = (*Task)(nil)
+
+func (t *Task) Validate(
+	task domain.Task,
+) error {
+	if err := t.validate.Struct(task); err != nil {
+		return multierror.Append(domain.ErrInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"backend/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Warehouse struct {
+	validate *validator.Validate
+}
+
+func ProvideWarehouse(
+	validate *validator.Validate,
+) *Warehouse {
+	return &Warehouse{
+		validate: validate,
+	}
+}
+
+var _ domain.WarehouseService = (*Warehouse)(nil)
+
+func (w *Warehouse) Validate(
+	warehouse domain.Warehouse,
+) error {
+	if err := w.validate.Struct(warehouse); err != nil {
+		return multierror.Append(domain.ErrInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"backend/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Webhook struct {
+	validate *validator.Validate
+}
+
+func ProvideWebhook(
+	validate *validator.Validate,
+) *Webhook {
+	return &Webhook{
+		validate: validate,
+	}
+}
+
+var _ domain.WebhookService = (*Webhook)(nil)
+
+func (w *Webhook) Validate(
+	webhook domain.Webhook,
+) error {
+	if err := w.validate.Struct(webhook); err != nil {
+		return multierror.Append(domain.ErrInvalid, err)
+	}
+	return nil
+}
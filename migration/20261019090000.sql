@@ -0,0 +1,7 @@
+-- Modify "products" table
+ALTER TABLE "public"."products" ADD COLUMN "search_skus" text NOT NULL DEFAULT '', ADD COLUMN "search_option_values" text NOT NULL DEFAULT '', ADD COLUMN "search_attribute_values" text NOT NULL DEFAULT '';
+-- Backfill search columns, later kept in sync by triggers
+UPDATE "public"."products" SET
+  "search_skus" = COALESCE((SELECT string_agg("sku", ' ') FROM "public"."product_variants" WHERE "product_id" = "products"."id" AND "deleted_at" IS NULL), ''),
+  "search_option_values" = COALESCE((SELECT string_agg("option_values"."value", ' ') FROM "public"."option_values" INNER JOIN "public"."options" ON "option_values"."option_id" = "options"."id" WHERE "options"."product_id" = "products"."id" AND "options"."deleted_at" IS NULL AND "option_values"."deleted_at" IS NULL), ''),
+  "search_attribute_values" = COALESCE((SELECT string_agg("attribute_values"."value", ' ') FROM "public"."attribute_values" INNER JOIN "public"."products_attribute_values" ON "attribute_values"."id" = "products_attribute_values"."attribute_value_id" WHERE "products_attribute_values"."product_id" = "products"."id" AND "attribute_values"."deleted_at" IS NULL), '');
@@ -0,0 +1,9 @@
+-- Create "product_daily_stats" table
+CREATE TABLE "public"."product_daily_stats" (
+  "product_id" uuid NOT NULL,
+  "day" date NOT NULL,
+  "views" integer NOT NULL DEFAULT 0,
+  "purchases" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("product_id", "day"),
+  CONSTRAINT "product_daily_stats_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "public"."products" ("id") ON UPDATE CASCADE ON DELETE NO ACTION
+);
@@ -0,0 +1,10 @@
+-- Create "product_recommendations" table
+CREATE TABLE "public"."product_recommendations" (
+  "product_id" uuid NOT NULL,
+  "recommended_product_id" uuid NOT NULL,
+  "kind" text NOT NULL,
+  "score" real NOT NULL,
+  PRIMARY KEY ("product_id", "kind", "recommended_product_id"),
+  CONSTRAINT "product_recommendations_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "public"."products" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "product_recommendations_recommended_product_id_fkey" FOREIGN KEY ("recommended_product_id") REFERENCES "public"."products" ("id") ON UPDATE CASCADE ON DELETE NO ACTION
+);
@@ -0,0 +1,22 @@
+-- Create "stock_movements" table
+CREATE TABLE "public"."stock_movements" (
+  "id" uuid NOT NULL,
+  "product_variant_id" uuid NOT NULL,
+  "kind" text NOT NULL,
+  "quantity" integer NOT NULL,
+  "quantity_after" integer NOT NULL,
+  "actor_id" uuid NULL,
+  "order_id" uuid NULL,
+  "note" text NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "stock_movements_order_id_fkey" FOREIGN KEY ("order_id") REFERENCES "public"."orders" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "stock_movements_product_variant_id_fkey" FOREIGN KEY ("product_variant_id") REFERENCES "public"."product_variants" ("id") ON UPDATE CASCADE ON DELETE NO ACTION
+);
+-- Create index "stock_movements_product_variant_id_created_at_idx" to table: "stock_movements"
+CREATE INDEX "stock_movements_product_variant_id_created_at_idx" ON "public"."stock_movements" ("product_variant_id", "created_at" DESC, "id" DESC);
+-- Record the current quantity of every variant as its opening balance
+INSERT INTO "public"."stock_movements" ("id", "product_variant_id", "kind", "quantity", "quantity_after", "note")
+SELECT gen_random_uuid(), "id", 'adjustment', "quantity", "quantity", 'opening balance'
+FROM "public"."product_variants"
+WHERE "quantity" > 0;
@@ -0,0 +1,27 @@
+-- Modify "product_variants" table
+ALTER TABLE "public"."product_variants" ADD COLUMN "low_stock_threshold" integer NOT NULL DEFAULT 0;
+-- Create "notifications" table
+CREATE TABLE "public"."notifications" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NULL,
+  "kind" text NOT NULL,
+  "product_id" uuid NULL,
+  "product_variant_id" uuid NULL,
+  "quantity" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "read_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "notifications_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "public"."products" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "notifications_product_variant_id_fkey" FOREIGN KEY ("product_variant_id") REFERENCES "public"."product_variants" ("id") ON UPDATE CASCADE ON DELETE NO ACTION
+);
+-- Create index "notifications_user_id_created_at_idx" to table: "notifications"
+CREATE INDEX "notifications_user_id_created_at_idx" ON "public"."notifications" ("user_id", "created_at" DESC, "id" DESC);
+-- Create "stock_subscriptions" table
+CREATE TABLE "public"."stock_subscriptions" (
+  "product_variant_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "notified_at" timestamptz NULL,
+  PRIMARY KEY ("product_variant_id", "user_id"),
+  CONSTRAINT "stock_subscriptions_product_variant_id_fkey" FOREIGN KEY ("product_variant_id") REFERENCES "public"."product_variants" ("id") ON UPDATE CASCADE ON DELETE NO ACTION
+);
@@ -0,0 +1,33 @@
+-- Create "warehouses" table
+CREATE TABLE "public"."warehouses" (
+  "id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "province" text NOT NULL,
+  "priority" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "deleted_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "warehouses_name_key" UNIQUE ("name")
+);
+-- Create "warehouse_stocks" table
+CREATE TABLE "public"."warehouse_stocks" (
+  "warehouse_id" uuid NOT NULL,
+  "product_variant_id" uuid NOT NULL,
+  "quantity" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY ("warehouse_id", "product_variant_id"),
+  CONSTRAINT "warehouse_stocks_product_variant_id_fkey" FOREIGN KEY ("product_variant_id") REFERENCES "public"."product_variants" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "warehouse_stocks_warehouse_id_fkey" FOREIGN KEY ("warehouse_id") REFERENCES "public"."warehouses" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "warehouse_stocks_quantity_check" CHECK (quantity >= 0)
+);
+-- Create index "warehouse_stocks_product_variant_id_idx" to table: "warehouse_stocks"
+CREATE INDEX "warehouse_stocks_product_variant_id_idx" ON "public"."warehouse_stocks" ("product_variant_id");
+-- Modify "order_items" table
+ALTER TABLE "public"."order_items" ADD COLUMN "warehouse_id" uuid NULL, ADD CONSTRAINT "order_items_warehouse_id_fkey" FOREIGN KEY ("warehouse_id") REFERENCES "public"."warehouses" ("id") ON UPDATE CASCADE ON DELETE NO ACTION;
+-- Modify "stock_movements" table
+ALTER TABLE "public"."stock_movements" ADD COLUMN "warehouse_id" uuid NULL, ADD CONSTRAINT "stock_movements_warehouse_id_fkey" FOREIGN KEY ("warehouse_id") REFERENCES "public"."warehouses" ("id") ON UPDATE CASCADE ON DELETE NO ACTION;
+-- Move the existing stock into a default warehouse
+INSERT INTO "public"."warehouses" ("id", "name", "province") VALUES ('00000000-0000-7000-0000-000000000001', 'Kho Hồ Chí Minh', 'Hồ Chí Minh');
+INSERT INTO "public"."warehouse_stocks" ("warehouse_id", "product_variant_id", "quantity")
+SELECT '00000000-0000-7000-0000-000000000001', "id", GREATEST("quantity", 0) FROM "public"."product_variants";
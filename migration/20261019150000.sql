@@ -0,0 +1,4 @@
+-- Modify "products" table
+ALTER TABLE "public"."products" ADD COLUMN "status" text NOT NULL DEFAULT 'published', ADD COLUMN "publish_at" timestamptz NULL, ADD COLUMN "unpublish_at" timestamptz NULL, ADD CONSTRAINT "products_status_check" CHECK (status = ANY (ARRAY['draft'::text, 'published'::text, 'archived'::text]));
+-- Create index "products_status_idx" to table: "products"
+CREATE INDEX "products_status_idx" ON "public"."products" ("status");
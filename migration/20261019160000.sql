@@ -0,0 +1,4 @@
+-- Modify "products" table
+ALTER TABLE "public"."products" ADD COLUMN "discount" integer NOT NULL DEFAULT 0;
+-- Modify "product_variants" table
+ALTER TABLE "public"."product_variants" ADD COLUMN "compare_at_price" numeric(12) NOT NULL DEFAULT 0, ADD COLUMN "sale_price" numeric(12) NOT NULL DEFAULT 0, ADD COLUMN "sale_starts_at" timestamptz NULL, ADD COLUMN "sale_ends_at" timestamptz NULL;
@@ -0,0 +1,14 @@
+-- Modify "products" table
+ALTER TABLE "public"."products" ADD COLUMN "type" text NOT NULL DEFAULT 'standard', ADD CONSTRAINT "products_type_check" CHECK (type = ANY (ARRAY['standard'::text, 'bundle'::text]));
+-- Create "product_variant_components" table
+CREATE TABLE "public"."product_variant_components" (
+  "bundle_variant_id" uuid NOT NULL,
+  "component_variant_id" uuid NOT NULL,
+  "quantity" integer NOT NULL,
+  PRIMARY KEY ("bundle_variant_id", "component_variant_id"),
+  CONSTRAINT "product_variant_components_bundle_variant_id_fkey" FOREIGN KEY ("bundle_variant_id") REFERENCES "public"."product_variants" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "product_variant_components_component_variant_id_fkey" FOREIGN KEY ("component_variant_id") REFERENCES "public"."product_variants" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "product_variant_components_quantity_check" CHECK (quantity > 0)
+);
+-- Create index "product_variant_components_component_variant_id_idx" to table: "product_variant_components"
+CREATE INDEX "product_variant_components_component_variant_id_idx" ON "public"."product_variant_components" ("component_variant_id");
@@ -0,0 +1,41 @@
+-- Create "spec_groups" table
+CREATE TABLE "public"."spec_groups" (
+  "id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "order" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "deleted_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create "specs" table
+CREATE TABLE "public"."specs" (
+  "id" uuid NOT NULL,
+  "code" character varying(100) NOT NULL,
+  "name" text NOT NULL,
+  "type" text NOT NULL,
+  "unit" text NOT NULL DEFAULT '',
+  "choices" text[] NOT NULL DEFAULT '{}',
+  "order" integer NOT NULL DEFAULT 0,
+  "spec_group_id" uuid NOT NULL,
+  "deleted_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "specs_code_key" UNIQUE ("code"),
+  CONSTRAINT "specs_spec_group_id_fkey" FOREIGN KEY ("spec_group_id") REFERENCES "public"."spec_groups" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "specs_type_check" CHECK (type = ANY (ARRAY['number'::text, 'boolean'::text, 'enum'::text]))
+);
+-- Create index "specs_spec_group_id_idx" to table: "specs"
+CREATE INDEX "specs_spec_group_id_idx" ON "public"."specs" ("spec_group_id");
+-- Create "product_specs" table
+CREATE TABLE "public"."product_specs" (
+  "product_id" uuid NOT NULL,
+  "spec_id" uuid NOT NULL,
+  "number_value" double precision NULL,
+  "boolean_value" boolean NULL,
+  "enum_value" text NULL,
+  PRIMARY KEY ("product_id", "spec_id"),
+  CONSTRAINT "product_specs_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "public"."products" ("id") ON UPDATE CASCADE ON DELETE NO ACTION,
+  CONSTRAINT "product_specs_spec_id_fkey" FOREIGN KEY ("spec_id") REFERENCES "public"."specs" ("id") ON UPDATE CASCADE ON DELETE NO ACTION
+);
+-- Create index "product_specs_spec_id_idx" to table: "product_specs"
+CREATE INDEX "product_specs_spec_id_idx" ON "public"."product_specs" ("spec_id");
@@ -0,0 +1,11 @@
+-- Create "product_image_renditions" table
+CREATE TABLE "public"."product_image_renditions" (
+  "product_image_id" uuid NOT NULL,
+  "width" integer NOT NULL,
+  "height" integer NOT NULL,
+  "url" text NOT NULL,
+  PRIMARY KEY ("product_image_id", "width"),
+  CONSTRAINT "product_image_renditions_product_image_id_fkey" FOREIGN KEY ("product_image_id") REFERENCES "public"."product_images" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "product_image_renditions_height_check" CHECK (height > 0),
+  CONSTRAINT "product_image_renditions_width_check" CHECK (width > 0)
+);
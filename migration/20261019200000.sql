@@ -0,0 +1,18 @@
+-- Create "outbox" table
+CREATE TABLE "public"."outbox" (
+  "id" uuid NOT NULL,
+  "aggregate_type" text NOT NULL,
+  "aggregate_id" uuid NOT NULL,
+  "type" text NOT NULL,
+  "payload" jsonb NOT NULL,
+  "occurred_at" timestamptz NOT NULL DEFAULT now(),
+  "attempts" integer NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT now(),
+  "last_error" text NOT NULL DEFAULT '',
+  "published_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "outbox_next_attempt_at_idx" to table: "outbox"
+CREATE INDEX "outbox_next_attempt_at_idx" ON "public"."outbox" ("next_attempt_at", "occurred_at", "id") WHERE (published_at IS NULL);
+-- Create index "outbox_published_at_idx" to table: "outbox"
+CREATE INDEX "outbox_published_at_idx" ON "public"."outbox" ("published_at") WHERE (published_at IS NOT NULL);
@@ -0,0 +1,37 @@
+-- Create "webhooks" table
+CREATE TABLE "public"."webhooks" (
+  "id" uuid NOT NULL,
+  "url" text NOT NULL,
+  "secret" text NOT NULL,
+  "event_types" text[] NOT NULL DEFAULT '{}',
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "deleted_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create "webhook_deliveries" table
+CREATE TABLE "public"."webhook_deliveries" (
+  "id" uuid NOT NULL,
+  "webhook_id" uuid NOT NULL,
+  "event_id" uuid NOT NULL,
+  "event_type" text NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" text NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "response_code" integer NOT NULL DEFAULT 0,
+  "response_body" text NOT NULL DEFAULT '',
+  "last_error" text NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL DEFAULT now(),
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "delivered_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "webhook_deliveries_webhook_id_event_id_key" UNIQUE ("webhook_id", "event_id"),
+  CONSTRAINT "webhook_deliveries_webhook_id_fkey" FOREIGN KEY ("webhook_id") REFERENCES "public"."webhooks" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "webhook_deliveries_status_check" CHECK (status = ANY (ARRAY['pending'::text, 'succeeded'::text, 'failed'::text]))
+);
+-- Create index "webhook_deliveries_next_attempt_at_idx" to table: "webhook_deliveries"
+CREATE INDEX "webhook_deliveries_next_attempt_at_idx" ON "public"."webhook_deliveries" ("next_attempt_at", "id") WHERE (status = 'pending'::text);
+-- Create index "webhook_deliveries_webhook_id_created_at_idx" to table: "webhook_deliveries"
+CREATE INDEX "webhook_deliveries_webhook_id_created_at_idx" ON "public"."webhook_deliveries" ("webhook_id", "created_at" DESC, "id" DESC);
@@ -0,0 +1,28 @@
+-- Create "emails" table
+CREATE TABLE "public"."emails" (
+  "id" uuid NOT NULL,
+  "kind" text NOT NULL,
+  "event_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "recipient" text NOT NULL,
+  "locale" text NOT NULL,
+  "subject" text NOT NULL,
+  "text_body" text NOT NULL,
+  "html_body" text NOT NULL,
+  "status" text NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL DEFAULT now(),
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "sent_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "emails_event_id_kind_key" UNIQUE ("event_id", "kind"),
+  CONSTRAINT "emails_status_check" CHECK (status = ANY (ARRAY['pending'::text, 'sent'::text, 'failed'::text]))
+);
+-- Create index "emails_created_at_idx" to table: "emails"
+CREATE INDEX "emails_created_at_idx" ON "public"."emails" ("created_at" DESC, "id" DESC);
+-- Create index "emails_next_attempt_at_idx" to table: "emails"
+CREATE INDEX "emails_next_attempt_at_idx" ON "public"."emails" ("next_attempt_at", "id") WHERE (status = 'pending'::text);
+-- Create index "emails_user_id_created_at_idx" to table: "emails"
+CREATE INDEX "emails_user_id_created_at_idx" ON "public"."emails" ("user_id", "created_at" DESC, "id" DESC);
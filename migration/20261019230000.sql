@@ -0,0 +1,24 @@
+-- Create "tasks" table
+CREATE TABLE "public"."tasks" (
+  "id" uuid NOT NULL,
+  "kind" text NOT NULL,
+  "key" text NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "status" text NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text NOT NULL DEFAULT '',
+  "run_at" timestamptz NOT NULL DEFAULT now(),
+  "created_at" timestamptz NOT NULL DEFAULT now(),
+  "updated_at" timestamptz NOT NULL DEFAULT now(),
+  "finished_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "tasks_status_check" CHECK (status = ANY (ARRAY['pending'::text, 'succeeded'::text, 'dead'::text]))
+);
+-- Create index "tasks_created_at_idx" to table: "tasks"
+CREATE INDEX "tasks_created_at_idx" ON "public"."tasks" ("created_at" DESC, "id" DESC);
+-- Create index "tasks_finished_at_idx" to table: "tasks"
+CREATE INDEX "tasks_finished_at_idx" ON "public"."tasks" ("finished_at") WHERE (status = 'succeeded'::text);
+-- Create index "tasks_kind_key_idx" to table: "tasks"
+CREATE UNIQUE INDEX "tasks_kind_key_idx" ON "public"."tasks" ("kind", "key") WHERE (key IS NOT NULL);
+-- Create index "tasks_run_at_idx" to table: "tasks"
+CREATE INDEX "tasks_run_at_idx" ON "public"."tasks" ("run_at", "id") WHERE (status = 'pending'::text);
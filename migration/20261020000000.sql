@@ -0,0 +1,2 @@
+-- Create index "orders_unpaid_created_at_idx" to table: "orders"
+CREATE INDEX "orders_unpaid_created_at_idx" ON "public"."orders" ("created_at", "id") WHERE (NOT is_paid);
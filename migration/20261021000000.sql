@@ -0,0 +1,2 @@
+-- Create index "stock_movements_order_id_idx" to table: "stock_movements"
+CREATE INDEX "stock_movements_order_id_idx" ON "public"."stock_movements" ("order_id");
@@ -7,6 +7,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -54,6 +55,9 @@ func (s *AttributeTestSuite) newConfig(
 	return &config.Server{
 		DBURL:     dbConnStr,
 		RedisAddr: strings.TrimPrefix(redisConnStr, "redis://"),
+
+		CacheLocalSize: 1000,
+		CacheLocalTTL:  30 * time.Second,
 	}
 }
 
@@ -78,8 +82,10 @@ func (s *AttributeTestSuite) SetupSuite() {
 	attributeService := service.ProvideAttribute(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	attributeCache := cacheredis.ProvideAttribute(redisClient)
-	s.app = application.ProvideAttribute(attributeRepo, attributeService, attributeCache)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	attributeCache := cacheredis.ProvideAttribute(redisClient, localCache)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	s.app = application.ProvideAttribute(attributeRepo, attributeService, attributeCache, changeHandler)
 }
 
 func (s *AttributeTestSuite) TearDownSuite() {
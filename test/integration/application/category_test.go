@@ -7,6 +7,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -54,6 +55,9 @@ func (s *CategoryTestSuite) newConfig(
 	return &config.Server{
 		DBURL:     dbConnStr,
 		RedisAddr: strings.TrimPrefix(redisConnStr, "redis://"),
+
+		CacheLocalSize: 1000,
+		CacheLocalTTL:  30 * time.Second,
 	}
 }
 
@@ -78,8 +82,10 @@ func (s *CategoryTestSuite) SetupSuite() {
 	categoryService := service.ProvideCategory(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	categoryCache := cacheredis.ProvideCategory(redisClient)
-	s.app = application.ProvideCategory(categoryRepo, categoryService, categoryCache)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	categoryCache := cacheredis.ProvideCategory(redisClient, localCache)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	s.app = application.ProvideCategory(categoryRepo, categoryService, categoryCache, changeHandler)
 }
 
 func (s *CategoryTestSuite) TearDownSuite() {
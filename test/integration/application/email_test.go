@@ -0,0 +1,350 @@
+// vim: tabstop=4 shiftwidth=4:
+//go:build integration
+
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+	"backend/internal/client"
+	http_dto "backend/internal/delivery/http"
+	"backend/internal/domain"
+	"backend/internal/infrastructure/emailsmtp"
+	"backend/internal/infrastructure/emailtemplate"
+	"backend/internal/infrastructure/eventredis"
+	"backend/internal/infrastructure/repositorypostgres"
+	"backend/internal/service"
+	"backend/test/integration/component"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailTestSuite struct {
+	suite.Suite
+	containers         *component.Containers
+	app                *application.Email
+	eventPublisher     *eventredis.Publisher
+	userProfileService *application.MockUserProfileService
+	sink               *component.SMTPSink
+
+	// Seed data IDs from .rules/011-integrationtest.md
+
+	seededOrderID uuid.UUID
+	seededUserID  uuid.UUID
+}
+
+// emailMessage is a message received by the sink with its decoded parts
+type emailMessage struct {
+	to        string
+	subject   string
+	messageID string
+	text      string
+	html      string
+}
+
+func TestEmailSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(EmailTestSuite))
+}
+
+func (s *EmailTestSuite) newContainersConfig() *component.ContainersConfig {
+	containersConfig := component.NewContainersConfig(&component.NewContainersConfigParam{
+		DBEnabled:    true,
+		RedisEnabled: true,
+	})
+	containersConfig.DB.Seed = true
+	return containersConfig
+}
+
+func (s *EmailTestSuite) newConfig(
+	ctx context.Context,
+) *config.Server {
+	s.T().Helper()
+
+	dbConnStr, err := s.containers.DB.ConnectionString(ctx, "sslmode=disable")
+	s.Require().NoError(err, "failed to get db connection string")
+	redisConnStr, err := s.containers.Redis.ConnectionString(ctx)
+	s.Require().NoError(err, "failed to get redis connection string")
+
+	return &config.Server{
+		DBURL:                  dbConnStr,
+		RedisAddr:              strings.TrimPrefix(redisConnStr, "redis://"),
+		EventStream:            "events",
+		EventStreamMaxLen:      1000,
+		EventConsumerName:      "test",
+		EventConsumerBatchSize: 100,
+		EventConsumerMinIdle:   time.Minute,
+		EmailEventGroup:        "emails",
+		EmailDefaultLocale:     "vi",
+		EmailSendBatchSize:     100,
+		EmailSendLease:         time.Minute,
+		EmailRetryBaseDelay:    time.Millisecond,
+		EmailRetryMaxDelay:     time.Millisecond,
+		EmailMaxAttempts:       3,
+		SMTPHost:               s.sink.Host(),
+		SMTPPort:               s.sink.Port(),
+		SMTPFrom:               "Electricilies <no-reply@electricilies.local>",
+		SMTPTimeout:            5 * time.Second,
+	}
+}
+
+func (s *EmailTestSuite) SetupSuite() {
+	ctx := s.T().Context()
+
+	var err error
+	s.containers, err = component.NewContainers(ctx, s.newContainersConfig())
+	s.Require().NoError(err, "failed to start containers")
+	s.sink, err = component.NewSMTPSink()
+	s.Require().NoError(err, "failed to start smtp sink")
+
+	cfg := s.newConfig(ctx)
+
+	validate := validator.New(
+		validator.WithRequiredStructEnabled(),
+	)
+
+	conn := client.NewDBConnection(ctx, cfg)
+	queries := client.NewDBQueries(conn)
+	redisClient := client.NewRedis(ctx, cfg)
+
+	s.eventPublisher = eventredis.ProvidePublisher(redisClient, cfg)
+	s.userProfileService = application.NewMockUserProfileService(s.T())
+	s.app = application.ProvideEmail(
+		eventredis.ProvideConsumer(redisClient, cfg),
+		emailsmtp.ProvideSender(cfg),
+		emailtemplate.ProvideRenderer(),
+		s.userProfileService,
+		repositorypostgres.ProvideEmail(queries),
+		repositorypostgres.ProvideOrder(queries, conn),
+		service.ProvideEmail(validate),
+		cfg,
+	)
+
+	s.seededOrderID = uuid.MustParse("00000000-0000-7000-0000-000000000001")
+	s.seededUserID = uuid.MustParse("00000000-0000-7000-0000-000000000003")
+}
+
+func (s *EmailTestSuite) TearDownSuite() {
+	s.Require().NoError(s.sink.Close())
+	s.containers.Cleanup(s.T())
+}
+
+func (s *EmailTestSuite) publish(ctx context.Context, eventType domain.EventType, payload any) domain.OutboxEvent {
+	s.T().Helper()
+
+	data, err := json.Marshal(payload)
+	s.Require().NoError(err)
+	event := domain.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: domain.AggregateTypeOrder,
+		AggregateID:   s.seededOrderID,
+		Type:          eventType,
+		Payload:       data,
+		OccurredAt:    time.Now(),
+	}
+	s.Require().NoError(s.eventPublisher.Publish(ctx, event))
+	return event
+}
+
+func (s *EmailTestSuite) list(ctx context.Context, status domain.EmailStatus) []http_dto.EmailResponseDto {
+	s.T().Helper()
+
+	emails, err := s.app.List(ctx, http_dto.ListEmailRequestDto{
+		PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+		UserIDs:              []uuid.UUID{s.seededUserID},
+		Status:               status,
+	})
+	s.Require().NoError(err)
+	return emails.Data
+}
+
+func (s *EmailTestSuite) decode(msg component.SMTPMessage) emailMessage {
+	s.T().Helper()
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg.Data))
+	s.Require().NoError(err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	s.Require().NoError(err)
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	s.Require().NoError(err)
+	s.Require().Equal("multipart/alternative", mediaType)
+
+	decoded := emailMessage{
+		to:        parsed.Header.Get("To"),
+		subject:   subject,
+		messageID: parsed.Header.Get("Message-ID"),
+	}
+	// The multipart reader decodes quoted-printable parts
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		s.Require().NoError(err)
+		body, err := io.ReadAll(part)
+		s.Require().NoError(err)
+		switch {
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/plain"):
+			decoded.text = string(body)
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/html"):
+			decoded.html = string(body)
+		}
+	}
+	return decoded
+}
+
+func (s *EmailTestSuite) TestEmailLifecycle() {
+	ctx := s.T().Context()
+
+	s.Run("Enqueue order emails once", func() {
+		s.userProfileService.EXPECT().
+			GetProfile(mock.Anything, s.seededUserID).
+			Return(&application.UserProfile{
+				Email:     "customer@example.com",
+				FirstName: "Lan",
+				Locale:    "vi",
+			}, nil).
+			Once()
+
+		paid := s.publish(ctx, domain.EventTypeOrderPaid, domain.OrderPaidEventPayload{
+			OrderID:     s.seededOrderID,
+			UserID:      s.seededUserID,
+			Provider:    domain.PaymentProviderVNPAY,
+			TotalAmount: 8328000,
+		})
+		s.publish(ctx, domain.EventTypeOrderStatusChanged, domain.OrderStatusChangedEventPayload{
+			OrderID: s.seededOrderID,
+			UserID:  s.seededUserID,
+			From:    domain.OrderStatusPending,
+			To:      domain.OrderStatusProcessing,
+		})
+		s.publish(ctx, domain.EventTypeOrderStatusChanged, domain.OrderStatusChangedEventPayload{
+			OrderID: s.seededOrderID,
+			UserID:  s.seededUserID,
+			From:    domain.OrderStatusProcessing,
+			To:      domain.OrderStatusShipping,
+		})
+		s.publish(ctx, domain.EventTypeOrderStatusChanged, domain.OrderStatusChangedEventPayload{
+			OrderID: uuid.New(),
+			UserID:  s.seededUserID,
+			From:    domain.OrderStatusShipping,
+			To:      domain.OrderStatusDelivered,
+		})
+		// The relay publishes at least once
+		s.Require().NoError(s.eventPublisher.Publish(ctx, paid))
+		s.Require().NoError(s.app.EnqueueEmails(ctx))
+		s.Require().NoError(s.app.EnqueueEmails(ctx))
+
+		emails := s.list(ctx, "")
+		s.Require().Len(emails, 2, "no email for processing orders nor unknown orders")
+		kinds := []domain.EmailKind{emails[0].Kind, emails[1].Kind}
+		s.ElementsMatch([]domain.EmailKind{domain.EmailKindOrderPaid, domain.EmailKindOrderShipped}, kinds)
+		for _, email := range emails {
+			s.Equal(domain.EmailStatusPending, email.Status)
+			s.Equal("customer@example.com", email.Recipient)
+			s.Equal("vi", email.Locale)
+		}
+	})
+
+	s.Run("Retry emails the server did not accept", func() {
+		s.sink.Reset("451 4.3.0 try again later")
+
+		s.Require().NoError(s.app.SendEmails(ctx))
+		failed := s.list(ctx, domain.EmailStatusPending)
+		s.Require().Len(failed, 1)
+		s.Equal(1, failed[0].Attempts)
+		s.Contains(failed[0].Error, "try again later")
+		s.Len(s.list(ctx, domain.EmailStatusSent), 1)
+
+		time.Sleep(10 * time.Millisecond)
+		s.Require().NoError(s.app.SendEmails(ctx))
+		sent := s.list(ctx, domain.EmailStatusSent)
+		s.Require().Len(sent, 2)
+		for _, email := range sent {
+			s.NotNil(email.SentAt)
+			s.Empty(email.Error)
+		}
+
+		messages := s.sink.Messages()
+		s.Require().Len(messages, 2)
+		for _, msg := range messages {
+			s.Equal("no-reply@electricilies.local", msg.From)
+			s.Equal([]string{"customer@example.com"}, msg.To)
+		}
+
+		s.Require().NoError(s.app.SendEmails(ctx))
+		s.Len(s.sink.Messages(), 2, "sent emails are not sent again")
+	})
+
+	s.Run("Render localized templates", func() {
+		var paid *emailMessage
+		for _, msg := range s.sink.Messages() {
+			decoded := s.decode(msg)
+			if strings.Contains(decoded.subject, "thanh toán") {
+				paid = &decoded
+			}
+		}
+		s.Require().NotNil(paid, "order paid email received")
+		s.Equal("customer@example.com", paid.to)
+		s.Equal("Đơn hàng #000000000001 đã được thanh toán", paid.subject)
+		s.True(strings.HasSuffix(paid.messageID, "@electricilies.local>"))
+		s.Contains(paid.text, "Xin chào Lan,")
+		s.Contains(paid.text, "8.328.000 ₫")
+		s.Contains(paid.text, "123 Nguyen Hue St, District 1, HCMC")
+		s.Contains(paid.html, `<html lang="vi">`)
+		s.Contains(paid.html, "8.328.000 ₫")
+	})
+
+	s.Run("Fall back to the default locale and skip users without email", func() {
+		s.sink.Reset()
+		otherUserID := uuid.New()
+		s.userProfileService.EXPECT().
+			GetProfile(mock.Anything, s.seededUserID).
+			Return(&application.UserProfile{
+				Email:     "customer@example.com",
+				FirstName: "Lan",
+				Locale:    "fr",
+			}, nil).
+			Once()
+		s.userProfileService.EXPECT().
+			GetProfile(mock.Anything, otherUserID).
+			Return(nil, domain.ErrNotFound).
+			Once()
+
+		s.publish(ctx, domain.EventTypeRefundProcessed, domain.RefundProcessedEventPayload{
+			RefundID: uuid.New(),
+			OrderID:  s.seededOrderID,
+			UserID:   s.seededUserID,
+			Amount:   1500000,
+		})
+		s.publish(ctx, domain.EventTypeRefundProcessed, domain.RefundProcessedEventPayload{
+			RefundID: uuid.New(),
+			OrderID:  uuid.New(),
+			UserID:   otherUserID,
+			Amount:   1000,
+		})
+		s.Require().NoError(s.app.EnqueueEmails(ctx))
+		s.Require().NoError(s.app.SendEmails(ctx))
+
+		messages := s.sink.Messages()
+		s.Require().Len(messages, 1)
+		refund := s.decode(messages[0])
+		s.Equal("Đã hoàn tiền cho đơn hàng #000000000001", refund.subject)
+		s.Contains(refund.text, "1.500.000 ₫")
+	})
+}
@@ -5,7 +5,9 @@ package application_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -25,10 +27,12 @@ import (
 type OrderTestSuite struct {
 	suite.Suite
 	containers          *component.Containers
-	app                 http.OrderApplication
+	app                 *application.Order
 	productRepo         domain.ProductRepository
-	orderRepo           domain.OrderRepository
+	orderRepo           *afterListOrderRepository
 	vnpayPaymentService *application.MockVNPayPaymentService
+	eventPublisher      *application.MockEventPublisher
+	outboxApp           *application.Outbox
 
 	// Seed data IDs from .rules/011-integrationtest.md
 
@@ -37,6 +41,26 @@ type OrderTestSuite struct {
 	seededSecondProductID uuid.UUID
 	seededSecondVariantID uuid.UUID
 	seededUserID          uuid.UUID
+	seededWarehouseID     uuid.UUID
+}
+
+// afterListOrderRepository runs afterList once after the next List, so a test
+// can change the orders a caller listed before it writes them back
+type afterListOrderRepository struct {
+	domain.OrderRepository
+	afterList func()
+}
+
+func (r *afterListOrderRepository) List(
+	ctx context.Context,
+	params domain.OrderRepositoryListParam,
+) (*[]domain.Order, error) {
+	orders, err := r.OrderRepository.List(ctx, params)
+	if afterList := r.afterList; afterList != nil {
+		r.afterList = nil
+		afterList()
+	}
+	return orders, err
 }
 
 func TestOrderSuite(t *testing.T) {
@@ -61,7 +85,16 @@ func (s *OrderTestSuite) newConfig(
 	s.Require().NoError(err, "failed to get db connection string")
 
 	return &config.Server{
-		DBURL: dbConnStr,
+		DBURL:                dbConnStr,
+		OutboxRelayBatchSize: 1000,
+		OutboxRelayLease:     time.Minute,
+		OutboxRetryBaseDelay: time.Second,
+		OutboxRetryMaxDelay:  time.Minute,
+		// Orders of the tests are paid right away, those testing the expiry
+		// are created in the past
+		OrderPaymentDeadlineVNPay: time.Hour,
+		OrderExpireBatchSize:      100,
+		OrderExpireGracePeriod:    30 * time.Minute,
 	}
 }
 
@@ -84,14 +117,23 @@ func (s *OrderTestSuite) SetupSuite() {
 	conn := client.NewDBConnection(ctx, cfg)
 	queries := client.NewDBQueries(conn)
 
-	s.orderRepo = repositorypostgres.ProvideOrder(queries, conn)
+	s.orderRepo = &afterListOrderRepository{
+		OrderRepository: repositorypostgres.ProvideOrder(queries, conn),
+	}
 	s.productRepo = repositorypostgres.ProvideProduct(queries, conn)
 	cartRepo := repositorypostgres.ProvideCart(queries, conn)
+	warehouseRepo := repositorypostgres.ProvideWarehouse(queries)
 
 	orderService := service.ProvideOrder(validate)
 	productService := service.ProvideProduct(validate)
 
 	s.vnpayPaymentService = application.NewMockVNPayPaymentService(s.T())
+	s.eventPublisher = application.NewMockEventPublisher(s.T())
+	s.outboxApp = application.ProvideOutbox(
+		s.eventPublisher,
+		repositorypostgres.ProvideOutbox(queries),
+		cfg,
+	)
 
 	s.app = application.ProvideOrder(
 		s.vnpayPaymentService,
@@ -100,6 +142,8 @@ func (s *OrderTestSuite) SetupSuite() {
 		s.productRepo,
 		productService,
 		cartRepo,
+		warehouseRepo,
+		cfg,
 	)
 
 	// Seed data from .rules/011-integrationtest.md
@@ -109,6 +153,7 @@ func (s *OrderTestSuite) SetupSuite() {
 	s.seededSecondProductID = uuid.MustParse("00000000-0000-7000-0000-000278469345")
 	s.seededSecondVariantID = uuid.MustParse("00000000-0000-7000-0000-000278469347")
 	s.seededUserID = uuid.MustParse("00000000-0000-7000-0000-000000000003")
+	s.seededWarehouseID = uuid.MustParse("00000000-0000-7000-0000-000000000001")
 }
 
 func (s *OrderTestSuite) TearDownSuite() {
@@ -117,6 +162,7 @@ func (s *OrderTestSuite) TearDownSuite() {
 
 func (s *OrderTestSuite) SetupTest() {
 	(*s.vnpayPaymentService) = *application.NewMockVNPayPaymentService(s.T())
+	(*s.eventPublisher) = *application.NewMockEventPublisher(s.T())
 }
 
 func (s *OrderTestSuite) TestCODOrderLifecycle() {
@@ -150,6 +196,8 @@ func (s *OrderTestSuite) TestCODOrderLifecycle() {
 		s.Equal("+84123456789", result.PhoneNumber)
 		s.Len(result.Items, 1)
 		s.Equal(2, result.Items[0].Quantity)
+		s.Require().NotNil(result.Items[0].WarehouseID, "seeded stock is at a warehouse")
+		s.Equal(s.seededWarehouseID, *result.Items[0].WarehouseID)
 		s.Positive(result.TotalAmount)
 		codOrderID = result.ID
 	})
@@ -291,20 +339,21 @@ func (s *OrderTestSuite) TestVNPayOrderLifecycle() {
 		orderedQuantity := order.Items[0].Quantity
 
 		s.vnpayPaymentService.EXPECT().
-			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			RunAndReturn(func(
 				ctx context.Context,
 				param application.VerifyIPNVNPayParam,
-				getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error),
+				getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
 				onSuccess func(ctx context.Context, order *domain.Order) error,
 				onFailure func(ctx context.Context, order *domain.Order) error,
+				onRefundDue func(ctx context.Context, order *domain.Order) error,
 			) (string, string, error) {
 				orderID, err := uuid.Parse(param.TxnRef)
 				if err != nil {
 					return "99", "Invalid Order ID", err
 				}
 
-				order, err := getOrder(ctx, orderID)
+				order, err := getOrder(ctx, orderID, time.Now())
 				if err != nil {
 					return "01", "Order not found", err
 				}
@@ -357,6 +406,224 @@ func (s *OrderTestSuite) TestVNPayOrderLifecycle() {
 		s.Require().NotNil(variantAfter)
 		s.Equal(initialQuantity-orderedQuantity, variantAfter.Quantity, "Inventory should decrease by ordered quantity")
 	})
+
+	s.Run("Relay VNPAY order events", func() {
+		var published []domain.OutboxEvent
+		s.eventPublisher.EXPECT().
+			Publish(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, event domain.OutboxEvent) {
+				published = append(published, event)
+			}).
+			Return(nil)
+		s.Require().NoError(s.outboxApp.RelayEvents(ctx))
+
+		var orderEvents []domain.EventType
+		stockChanged := false
+		for _, event := range published {
+			if event.AggregateType == domain.AggregateTypeOrder && event.AggregateID == vnpayOrderID {
+				orderEvents = append(orderEvents, event.Type)
+			}
+			if event.Type == domain.EventTypeStockChanged {
+				var payload domain.StockChangedEventPayload
+				s.Require().NoError(json.Unmarshal(event.Payload, &payload))
+				if payload.OrderID == vnpayOrderID {
+					s.Equal(s.seededProductID, event.AggregateID)
+					s.Equal(domain.StockMovementKindSale, payload.Kind)
+					stockChanged = true
+				}
+			}
+		}
+		s.Equal([]domain.EventType{
+			domain.EventTypeOrderCreated,
+			domain.EventTypeOrderStatusChanged,
+			domain.EventTypeOrderPaid,
+		}, orderEvents)
+		s.True(stockChanged, "stock sold for the order is published")
+
+		published = nil
+		s.Require().NoError(s.outboxApp.RelayEvents(ctx))
+		s.Empty(published, "published events are not relayed again")
+	})
+
+	s.Run("Cancel paid VNPAY order gives back its stock", func() {
+		product, err := s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+			ProductID: s.seededProductID,
+		})
+		s.Require().NoError(err)
+		initialQuantity := product.GetVariantByID(s.seededVariantID).Quantity
+
+		sales, err := s.productRepo.ListStockMovements(ctx, domain.ProductRepositoryListStockMovementsParam{
+			OrderID: vnpayOrderID,
+			Kinds:   []domain.StockMovementKind{domain.StockMovementKindSale},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(*sales, 1)
+
+		result, err := s.app.Update(ctx, http.UpdateOrderRequestDto{
+			OrderID: vnpayOrderID,
+			Data: http.UpdateOrderData{
+				Address: "456 Updated Address, Hanoi",
+				Status:  domain.OrderStatusCancelled,
+				IsPaid:  true,
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusCancelled, result.Status)
+
+		productAfter, err := s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+			ProductID: s.seededProductID,
+		})
+		s.Require().NoError(err)
+		s.Equal(
+			initialQuantity-(*sales)[0].Quantity,
+			productAfter.GetVariantByID(s.seededVariantID).Quantity,
+			"the quantity sold is given back",
+		)
+
+		cancellations, err := s.productRepo.ListStockMovements(ctx, domain.ProductRepositoryListStockMovementsParam{
+			OrderID: vnpayOrderID,
+			Kinds:   []domain.StockMovementKind{domain.StockMovementKindCancellation},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(*cancellations, 1)
+		s.Equal(-(*sales)[0].Quantity, (*cancellations)[0].Quantity)
+		s.Equal((*sales)[0].WarehouseID, (*cancellations)[0].WarehouseID)
+	})
+}
+
+func (s *OrderTestSuite) TestCancelPaidBundleOrder() {
+	ctx := s.T().Context()
+	seededCategoryID := uuid.MustParse("00000000-0000-7000-0000-000000001796")
+	seededOtherVariantID := uuid.MustParse("00000000-0000-7000-0000-000278469310")
+	var bundleOrderID, bundleWarehouseID uuid.UUID
+
+	bundle, err := domain.NewProduct("Order Bundle", "A bundle of seeded variants", seededCategoryID)
+	s.Require().NoError(err)
+	s.Require().NoError(bundle.UpdateType(domain.ProductTypeBundle))
+	bundleVariant, err := domain.NewVariant("ORDER-BUNDLE-001", 900000, 0)
+	s.Require().NoError(err)
+	bundle.AddVariants(*bundleVariant)
+	s.Require().NoError(bundle.UpdateVariantComponents(bundleVariant.ID, []domain.BundleComponent{
+		{VariantID: s.seededVariantID, Quantity: 1},
+		{VariantID: seededOtherVariantID, Quantity: 2},
+	}))
+	s.Require().NoError(bundle.UpdatePublishing(domain.ProductStatusPublished, time.Time{}, time.Time{}))
+	s.Require().NoError(s.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *bundle}))
+
+	componentQuantities := func() map[uuid.UUID]int {
+		product, err := s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+			ProductID: s.seededProductID,
+		})
+		s.Require().NoError(err)
+		return map[uuid.UUID]int{
+			s.seededVariantID:    product.GetVariantByID(s.seededVariantID).Quantity,
+			seededOtherVariantID: product.GetVariantByID(seededOtherVariantID).Quantity,
+		}
+	}
+	initialQuantities := componentQuantities()
+
+	s.Run("Pay VNPAY order of a bundle", func() {
+		s.vnpayPaymentService.EXPECT().
+			GetPaymentURL(mock.Anything, mock.Anything).
+			Return("https://sandbox.vnpayment.vn/paymentv2/vpcpay.html", nil).
+			Once()
+		result, err := s.app.Create(ctx, http.CreateOrderRequestDto{
+			Data: http.CreateOrderData{
+				RecipientName: "Bundle Customer",
+				PhoneNumber:   "+84922333444",
+				Address:       "1 Bundle Street, Ho Chi Minh City",
+				Provider:      domain.PaymentProviderVNPAY,
+				Items: []http.CreateOrderItemData{
+					{
+						ProductID:        bundle.ID,
+						ProductVariantID: bundleVariant.ID,
+						Quantity:         1,
+					},
+				},
+				UserID:    s.seededUserID,
+				ReturnURL: "https://example.com/return",
+			},
+		})
+		s.Require().NoError(err)
+		bundleOrderID = result.ID
+		s.Require().Len(result.Items, 1)
+		s.Require().NotNil(result.Items[0].WarehouseID, "the bundle ships from one warehouse")
+		bundleWarehouseID = *result.Items[0].WarehouseID
+
+		s.vnpayPaymentService.EXPECT().
+			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(
+				ctx context.Context,
+				param application.VerifyIPNVNPayParam,
+				getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
+				onSuccess func(ctx context.Context, order *domain.Order) error,
+				onFailure func(ctx context.Context, order *domain.Order) error,
+				onRefundDue func(ctx context.Context, order *domain.Order) error,
+			) (string, string, error) {
+				order, err := getOrder(ctx, uuid.MustParse(param.TxnRef), time.Now())
+				if err != nil {
+					return "01", "Order not found", err
+				}
+				if err := onSuccess(ctx, order); err != nil {
+					return "99", "Error processing payment", err
+				}
+				return "00", "Success", nil
+			}).Once()
+		ipn, err := s.app.VerifyVNPayIPN(ctx, http.VerifyVNPayIPNRequestDTO{
+			QueryParams: &http.VerifyVNPayIPNQueryParams{
+				Amount:            "90000000",
+				ResponseCode:      "00",
+				TransactionStatus: "00",
+				TxnRef:            bundleOrderID.String(),
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal("00", ipn.RspCode)
+
+		s.Equal(map[uuid.UUID]int{
+			s.seededVariantID:    initialQuantities[s.seededVariantID] - 1,
+			seededOtherVariantID: initialQuantities[seededOtherVariantID] - 2,
+		}, componentQuantities(), "the components of the bundle are sold")
+
+		sales, err := s.productRepo.ListStockMovements(ctx, domain.ProductRepositoryListStockMovementsParam{
+			OrderID: bundleOrderID,
+			Kinds:   []domain.StockMovementKind{domain.StockMovementKindSale},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(*sales, 2)
+		for _, movement := range *sales {
+			s.Equal(bundleWarehouseID, movement.WarehouseID, "the components are sold from the warehouse of the bundle")
+		}
+	})
+
+	s.Run("Cancel paid VNPAY order of a bundle gives back its components", func() {
+		result, err := s.app.Update(ctx, http.UpdateOrderRequestDto{
+			OrderID: bundleOrderID,
+			Data: http.UpdateOrderData{
+				Address: "1 Bundle Street, Ho Chi Minh City",
+				Status:  domain.OrderStatusCancelled,
+				IsPaid:  true,
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusCancelled, result.Status)
+
+		s.Equal(initialQuantities, componentQuantities())
+
+		cancellations, err := s.productRepo.ListStockMovements(ctx, domain.ProductRepositoryListStockMovementsParam{
+			OrderID: bundleOrderID,
+			Kinds:   []domain.StockMovementKind{domain.StockMovementKindCancellation},
+		})
+		s.Require().NoError(err)
+		returned := make(map[uuid.UUID]int)
+		for _, movement := range *cancellations {
+			returned[movement.ProductVariantID] += movement.Quantity
+		}
+		s.Equal(map[uuid.UUID]int{
+			s.seededVariantID:    1,
+			seededOtherVariantID: 2,
+		}, returned)
+	})
 }
 
 func (s *OrderTestSuite) TestVNPayOrderWithMultipleItems() {
@@ -441,20 +708,21 @@ func (s *OrderTestSuite) TestVNPayIPNFailure() {
 		initialQuantity := variant.Quantity
 
 		s.vnpayPaymentService.EXPECT().
-			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			RunAndReturn(func(
 				ctx context.Context,
 				param application.VerifyIPNVNPayParam,
-				getOrder func(ctx context.Context, orderID uuid.UUID) (*domain.Order, error),
+				getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
 				onSuccess func(ctx context.Context, order *domain.Order) error,
 				onFailure func(ctx context.Context, order *domain.Order) error,
+				onRefundDue func(ctx context.Context, order *domain.Order) error,
 			) (string, string, error) {
 				orderID, err := uuid.Parse(param.TxnRef)
 				if err != nil {
 					return "99", "Invalid Order ID", err
 				}
 
-				order, err := getOrder(ctx, orderID)
+				order, err := getOrder(ctx, orderID, time.Time{})
 				if err != nil {
 					return "01", "Order not found", err
 				}
@@ -550,6 +818,36 @@ func (s *OrderTestSuite) TestCreateOrderWithNonExistentProduct() {
 	s.Error(err)
 }
 
+func (s *OrderTestSuite) TestCreateOrderWithDraftProduct() {
+	ctx := s.T().Context()
+	seededCategoryID := uuid.MustParse("00000000-0000-7000-0000-000000001796")
+
+	draft, err := domain.NewProduct("Order Draft", "A product not published yet", seededCategoryID)
+	s.Require().NoError(err)
+	draftVariant, err := domain.NewVariant("ORDER-DRAFT-001", 500000, 10)
+	s.Require().NoError(err)
+	draft.AddVariants(*draftVariant)
+	s.Require().NoError(s.productRepo.Save(ctx, domain.ProductRepositorySaveParam{Product: *draft}))
+
+	_, err = s.app.Create(ctx, http.CreateOrderRequestDto{
+		Data: http.CreateOrderData{
+			RecipientName: "Draft Customer",
+			PhoneNumber:   "+84912345678",
+			Address:       "1 Draft Street, Ho Chi Minh City",
+			Provider:      domain.PaymentProviderCOD,
+			Items: []http.CreateOrderItemData{
+				{
+					ProductID:        draft.ID,
+					ProductVariantID: draftVariant.ID,
+					Quantity:         1,
+				},
+			},
+			UserID: s.seededUserID,
+		},
+	})
+	s.ErrorIs(err, domain.ErrNotFound)
+}
+
 func (s *OrderTestSuite) TestOrderValidationDefectEmptyItems() {
 	ctx := s.T().Context()
 
@@ -591,3 +889,247 @@ func (s *OrderTestSuite) TestOrderValidationDefectInvalidPhoneNumber() {
 	s.Require().Error(err)
 	s.Nil(result)
 }
+
+// newUnpaidVNPayOrder saves a VNPAY order created at createdAt, orders created
+// through the application are always created now
+func (s *OrderTestSuite) newUnpaidVNPayOrder(createdAt time.Time) *domain.Order {
+	s.T().Helper()
+	item, err := domain.NewOrderItem(s.seededSecondProductID, s.seededSecondVariantID, 1, 1000)
+	s.Require().NoError(err)
+	order, err := domain.NewOrder(
+		s.seededUserID,
+		"Late Payer",
+		"+84911111111",
+		"12 Late Street",
+		domain.PaymentProviderVNPAY,
+		[]domain.OrderItem{*item},
+	)
+	s.Require().NoError(err)
+	order.CreatedAt = createdAt
+	order.UpdatedAt = createdAt
+	s.Require().NoError(s.orderRepo.Save(s.T().Context(), domain.OrderRepositorySaveParam{
+		Order: *order,
+	}))
+	return order
+}
+
+func (s *OrderTestSuite) TestVNPayPaymentExpiry() {
+	ctx := s.T().Context()
+	expired := s.newUnpaidVNPayOrder(time.Now().Add(-2 * time.Hour))
+	lateIPN := s.newUnpaidVNPayOrder(time.Now().Add(-2 * time.Hour))
+	recent := s.newUnpaidVNPayOrder(time.Now().Add(-time.Minute))
+
+	product, err := s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+		ProductID: s.seededSecondProductID,
+	})
+	s.Require().NoError(err)
+	initialQuantity := product.GetVariantByID(s.seededSecondVariantID).Quantity
+
+	s.Run("Late IPN for an expired order", func() {
+		s.vnpayPaymentService.EXPECT().
+			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(
+				ctx context.Context,
+				param application.VerifyIPNVNPayParam,
+				getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
+				onSuccess func(ctx context.Context, order *domain.Order) error,
+				onFailure func(ctx context.Context, order *domain.Order) error,
+				onRefundDue func(ctx context.Context, order *domain.Order) error,
+			) (string, string, error) {
+				order, err := getOrder(ctx, uuid.MustParse(param.TxnRef), time.Now())
+				if err != nil {
+					return "01", "Order not found", err
+				}
+				if !order.IsPaymentPending() {
+					if err := onRefundDue(ctx, order); err != nil {
+						return "99", "Error flagging refund", err
+					}
+					return "02", "Order already confirmed", nil
+				}
+				if err := onSuccess(ctx, order); err != nil {
+					return "99", "Error processing payment", err
+				}
+				return "00", "Success", nil
+			}).Once()
+
+		result, err := s.app.VerifyVNPayIPN(ctx, http.VerifyVNPayIPNRequestDTO{
+			QueryParams: &http.VerifyVNPayIPNQueryParams{
+				Amount:            "100000",
+				ResponseCode:      "00",
+				TransactionStatus: "00",
+				TxnRef:            lateIPN.ID.String(),
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal("02", result.RspCode)
+
+		order, err := s.app.Get(ctx, http.GetOrderRequestDto{OrderID: lateIPN.ID})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusCancelled, order.Status)
+		s.False(order.IsPaid)
+	})
+
+	s.Run("Expire unpaid orders", func() {
+		s.Require().NoError(s.app.ExpireUnpaidOrders(ctx))
+
+		order, err := s.app.Get(ctx, http.GetOrderRequestDto{OrderID: expired.ID})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusCancelled, order.Status)
+		s.False(order.IsPaid)
+
+		order, err = s.app.Get(ctx, http.GetOrderRequestDto{OrderID: recent.ID})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusPending, order.Status, "orders before the deadline are kept")
+
+		product, err := s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+			ProductID: s.seededSecondProductID,
+		})
+		s.Require().NoError(err)
+		s.Equal(
+			initialQuantity,
+			product.GetVariantByID(s.seededSecondVariantID).Quantity,
+			"unpaid orders hold no stock",
+		)
+	})
+
+	s.Run("Relay expiry events", func() {
+		reasons := map[uuid.UUID]domain.OrderCancelReason{}
+		refundsDue := map[uuid.UUID]int64{}
+		s.eventPublisher.EXPECT().
+			Publish(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, event domain.OutboxEvent) {
+				switch event.Type {
+				case domain.EventTypeOrderStatusChanged:
+					var payload domain.OrderStatusChangedEventPayload
+					s.Require().NoError(json.Unmarshal(event.Payload, &payload))
+					reasons[payload.OrderID] = payload.Reason
+				case domain.EventTypeOrderRefundDue:
+					var payload domain.OrderRefundDueEventPayload
+					s.Require().NoError(json.Unmarshal(event.Payload, &payload))
+					refundsDue[payload.OrderID] = payload.Amount
+				}
+			}).
+			Return(nil)
+		s.Require().NoError(s.outboxApp.RelayEvents(ctx))
+
+		s.Equal(domain.OrderCancelReasonPaymentExpired, reasons[expired.ID])
+		s.Equal(domain.OrderCancelReasonPaymentExpired, reasons[lateIPN.ID])
+		s.NotContains(reasons, recent.ID)
+		s.Equal(lateIPN.TotalAmount, refundsDue[lateIPN.ID], "the payment after the deadline is owed back")
+		s.NotContains(refundsDue, expired.ID)
+	})
+}
+
+func (s *OrderTestSuite) TestVNPayPaymentExpiryAfterIPN() {
+	ctx := s.T().Context()
+	order := s.newUnpaidVNPayOrder(time.Now().Add(-2 * time.Hour))
+
+	product, err := s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+		ProductID: s.seededSecondProductID,
+	})
+	s.Require().NoError(err)
+	initialQuantity := product.GetVariantByID(s.seededSecondVariantID).Quantity
+	// The customer paid in time, only the IPN comes after the deadline
+	paidAt := order.CreatedAt.Add(30 * time.Minute)
+
+	s.vnpayPaymentService.EXPECT().
+		VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			ctx context.Context,
+			param application.VerifyIPNVNPayParam,
+			getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
+			onSuccess func(ctx context.Context, order *domain.Order) error,
+			onFailure func(ctx context.Context, order *domain.Order) error,
+			onRefundDue func(ctx context.Context, order *domain.Order) error,
+		) (string, string, error) {
+			order, err := getOrder(ctx, uuid.MustParse(param.TxnRef), paidAt)
+			if err != nil {
+				return "01", "Order not found", err
+			}
+			if err := onSuccess(ctx, order); err != nil {
+				return "99", "Error processing payment", err
+			}
+			return "00", "Success", nil
+		}).Once()
+	// The IPN commits after the expiry listed the order as still pending
+	s.orderRepo.afterList = func() {
+		result, err := s.app.VerifyVNPayIPN(ctx, http.VerifyVNPayIPNRequestDTO{
+			QueryParams: &http.VerifyVNPayIPNQueryParams{
+				Amount:            "100000",
+				ResponseCode:      "00",
+				TransactionStatus: "00",
+				TxnRef:            order.ID.String(),
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal("00", result.RspCode)
+	}
+
+	s.Require().NoError(s.app.ExpireUnpaidOrders(ctx))
+	s.Nil(s.orderRepo.afterList, "the expiry listed the order")
+
+	result, err := s.app.Get(ctx, http.GetOrderRequestDto{OrderID: order.ID})
+	s.Require().NoError(err)
+	s.Equal(domain.OrderStatusProcessing, result.Status, "the stale copy is not written back")
+	s.True(result.IsPaid)
+
+	product, err = s.productRepo.Get(ctx, domain.ProductRepositoryGetParam{
+		ProductID: s.seededSecondProductID,
+	})
+	s.Require().NoError(err)
+	s.Equal(initialQuantity-1, product.GetVariantByID(s.seededSecondVariantID).Quantity)
+}
+
+func (s *OrderTestSuite) TestVNPayPaymentInTimeWithLateIPN() {
+	ctx := s.T().Context()
+	order := s.newUnpaidVNPayOrder(time.Now().Add(-70 * time.Minute))
+
+	s.Run("Keep orders within the grace period", func() {
+		s.Require().NoError(s.app.ExpireUnpaidOrders(ctx))
+
+		result, err := s.app.Get(ctx, http.GetOrderRequestDto{OrderID: order.ID})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusPending, result.Status)
+	})
+
+	s.Run("Accept the payment made before the deadline", func() {
+		s.vnpayPaymentService.EXPECT().
+			VerifyIPN(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(
+				ctx context.Context,
+				param application.VerifyIPNVNPayParam,
+				getOrder func(ctx context.Context, orderID uuid.UUID, paidAt time.Time) (*domain.Order, error),
+				onSuccess func(ctx context.Context, order *domain.Order) error,
+				onFailure func(ctx context.Context, order *domain.Order) error,
+				onRefundDue func(ctx context.Context, order *domain.Order) error,
+			) (string, string, error) {
+				order, err := getOrder(ctx, uuid.MustParse(param.TxnRef), time.Now().Add(-15*time.Minute))
+				if err != nil {
+					return "01", "Order not found", err
+				}
+				if !order.IsPaymentPending() {
+					return "02", "Order already confirmed", nil
+				}
+				if err := onSuccess(ctx, order); err != nil {
+					return "99", "Error processing payment", err
+				}
+				return "00", "Success", nil
+			}).Once()
+
+		result, err := s.app.VerifyVNPayIPN(ctx, http.VerifyVNPayIPNRequestDTO{
+			QueryParams: &http.VerifyVNPayIPNQueryParams{
+				Amount:            "100000",
+				ResponseCode:      "00",
+				TransactionStatus: "00",
+				TxnRef:            order.ID.String(),
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal("00", result.RspCode)
+
+		got, err := s.app.Get(ctx, http.GetOrderRequestDto{OrderID: order.ID})
+		s.Require().NoError(err)
+		s.Equal(domain.OrderStatusProcessing, got.Status)
+		s.True(got.IsPaid)
+	})
+}
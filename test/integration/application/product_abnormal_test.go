@@ -4,10 +4,12 @@
 package application_test
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -69,6 +71,13 @@ func (s *ProductAbnormalCasesTestSuite) newConfig(
 		S3AccessKey:  "electricilies",
 		S3SecretKey:  "electricilies",
 		S3RegionName: "us-east-1",
+
+		ProductImageMaxBytes:  10 << 20,
+		ProductImageMaxPixels: 40_000_000,
+
+		ProductCacheTTL:        time.Hour,
+		ProductCacheStaleTTL:   5 * time.Minute,
+		ProductSuggestCacheTTL: 5 * time.Minute,
 	}
 }
 
@@ -92,6 +101,7 @@ func (s *ProductAbnormalCasesTestSuite) SetupTest() {
 	queries := client.NewDBQueries(conn)
 
 	productRepo := repositorypostgres.ProvideProduct(queries, conn)
+	specGroupRepo := repositorypostgres.ProvideSpecGroup(queries, conn)
 	categoryRepo := repositorypostgres.ProvideCategory(queries)
 	attributeRepo := repositorypostgres.ProvideAttribute(queries, conn)
 
@@ -99,7 +109,10 @@ func (s *ProductAbnormalCasesTestSuite) SetupTest() {
 	attributeService := service.ProvideAttribute(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	productCache := cacheredis.ProvideProduct(redisClient)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	productCache := cacheredis.ProvideProduct(redisClient, localCache, cfg)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	productViewBuffer := cacheredis.ProvideProductView(redisClient)
 
 	s3Client := client.NewS3(ctx, cfg)
 	s3PresignClient := client.NewS3Presign(s3Client)
@@ -120,10 +133,13 @@ func (s *ProductAbnormalCasesTestSuite) SetupTest() {
 		attributeRepo,
 		attributeService,
 		categoryRepo,
+		changeHandler,
 		productCache,
 		productObjectStorage,
 		productRepo,
 		productService,
+		productViewBuffer,
+		specGroupRepo,
 		cfg,
 	)
 }
@@ -134,9 +150,9 @@ func (s *ProductAbnormalCasesTestSuite) TearDownTest() {
 
 func (s *ProductAbnormalCasesTestSuite) uploadDummyImage(url string) {
 	s.T().Helper()
-	req, err := http.NewRequest("PUT", url, strings.NewReader("dummy image data"))
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(component.NewImage(400, 300)))
 	s.Require().NoError(err)
-	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Content-Type", "image/png")
 
 	resp, err := http.DefaultClient.Do(req)
 	s.Require().NoError(err)
@@ -405,6 +421,26 @@ func (s *ProductAbnormalCasesTestSuite) TestProductAbnormalCases() {
 		s.Require().Error(err, "Should fail to add images to non-existent variant")
 	})
 
+	s.Run("Add images which are not images", func() {
+		seededProductID := uuid.MustParse("00000000-0000-7000-0000-000278469304")
+		uploadURL, err := s.app.GetUploadImageURL(ctx)
+		s.Require().NoError(err)
+		req, err := http.NewRequest("PUT", uploadURL.URL, strings.NewReader("dummy image data"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "image/jpeg")
+		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		_ = resp.Body.Close()
+
+		_, err = s.app.AddImages(ctx, http_dto.AddProductImagesRequestDto{
+			ProductID: seededProductID,
+			Data: []http_dto.AddProductImageData{
+				{Key: uploadURL.Key, Order: 1},
+			},
+		})
+		s.ErrorIs(err, domain.ErrInvalid, "Upload must be a real image")
+	})
+
 	s.Run("Delete non-existent images", func() {
 		seededProductID := uuid.MustParse("00000000-0000-7000-0000-000278469304")
 
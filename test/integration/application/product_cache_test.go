@@ -4,10 +4,13 @@
 package application_test
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -23,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -31,6 +35,8 @@ type ProductCacheInvalidationTestSuite struct {
 	suite.Suite
 	containers *component.Containers
 	app        http_dto.ProductApplication
+	cache      application.ProductCache
+	cacheApp   http_dto.CacheApplication
 
 	// Track created product
 	productID uuid.UUID
@@ -73,6 +79,15 @@ func (s *ProductCacheInvalidationTestSuite) newConfig(
 		S3AccessKey:  "electricilies",
 		S3SecretKey:  "electricilies",
 		S3RegionName: "us-east-1",
+
+		ProductImageMaxBytes:  10 << 20,
+		ProductImageMaxPixels: 40_000_000,
+
+		ProductCacheTTL:        time.Hour,
+		ProductCacheStaleTTL:   5 * time.Minute,
+		ProductSuggestCacheTTL: 5 * time.Minute,
+		CacheLocalSize:         1000,
+		CacheLocalTTL:          30 * time.Second,
 	}
 }
 
@@ -96,6 +111,7 @@ func (s *ProductCacheInvalidationTestSuite) SetupTest() {
 	queries := client.NewDBQueries(conn)
 
 	productRepo := repositorypostgres.ProvideProduct(queries, conn)
+	specGroupRepo := repositorypostgres.ProvideSpecGroup(queries, conn)
 	categoryRepo := repositorypostgres.ProvideCategory(queries)
 	attributeRepo := repositorypostgres.ProvideAttribute(queries, conn)
 
@@ -103,7 +119,10 @@ func (s *ProductCacheInvalidationTestSuite) SetupTest() {
 	attributeService := service.ProvideAttribute(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	productCache := cacheredis.ProvideProduct(redisClient)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	productCache := cacheredis.ProvideProduct(redisClient, localCache, cfg)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	productViewBuffer := cacheredis.ProvideProductView(redisClient)
 
 	s3Client := client.NewS3(ctx, cfg)
 	s3PresignClient := client.NewS3Presign(s3Client)
@@ -120,14 +139,19 @@ func (s *ProductCacheInvalidationTestSuite) SetupTest() {
 
 	productObjectStorage := objectstorages3.ProvideProduct(s3ClientWrapper, cfg)
 
+	s.cache = productCache
+	s.cacheApp = application.ProvideCache(cacheredis.ProvideInspector(redisClient), changeHandler)
 	s.app = application.ProvideProduct(
 		attributeRepo,
 		attributeService,
 		categoryRepo,
+		changeHandler,
 		productCache,
 		productObjectStorage,
 		productRepo,
 		productService,
+		productViewBuffer,
+		specGroupRepo,
 		cfg,
 	)
 }
@@ -138,9 +162,9 @@ func (s *ProductCacheInvalidationTestSuite) TearDownTest() {
 
 func (s *ProductCacheInvalidationTestSuite) uploadDummyImage(url string) {
 	s.T().Helper()
-	req, err := http.NewRequest("PUT", url, strings.NewReader("dummy image data"))
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(component.NewImage(400, 300)))
 	s.Require().NoError(err)
-	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Content-Type", "image/png")
 
 	resp, err := http.DefaultClient.Do(req)
 	s.Require().NoError(err)
@@ -152,6 +176,7 @@ func (s *ProductCacheInvalidationTestSuite) TestCacheInvalidation() {
 	ctx := s.T().Context()
 
 	seededCategoryID := uuid.MustParse("00000000-0000-7000-0000-000000001796")
+	seededProductID := uuid.MustParse("00000000-0000-7000-0000-000278469304")
 
 	s.Run("Create product and verify cache population", func() {
 		// Get upload URL for product image
@@ -216,6 +241,75 @@ func (s *ProductCacheInvalidationTestSuite) TestCacheInvalidation() {
 		s.NotEqual(result.UpdatedAt, freshProduct.UpdatedAt.Add(0), "Should fetch fresh data after update")
 	})
 
+	s.Run("Update product and verify other products stay cached", func() {
+		_, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: seededProductID,
+		})
+		s.Require().NoError(err)
+
+		_, err = s.app.Update(ctx, http_dto.UpdateProductRequestDto{
+			ProductID: s.productID,
+			Data: http_dto.UpdateProductData{
+				Name: "Cache Test Product",
+			},
+		})
+		s.Require().NoError(err)
+
+		_, err = s.cache.Get(ctx, application.ProductCacheParam{ID: seededProductID})
+		s.NoError(err, "Unrelated product should stay cached")
+		_, err = s.cache.Get(ctx, application.ProductCacheParam{ID: s.productID})
+		s.ErrorIs(err, redis.Nil, "Updated product should be invalidated")
+	})
+
+	s.Run("Concurrent gets of an expired product share the fresh data", func() {
+		err := s.cache.Invalidate(ctx, application.ProductCacheParam{ID: s.productID})
+		s.Require().NoError(err)
+
+		var wg sync.WaitGroup
+		results := make([]*http_dto.ProductResponseDto, 20)
+		errs := make([]error, len(results))
+		for i := range results {
+			wg.Go(func() {
+				results[i], errs[i] = s.app.Get(ctx, http_dto.GetProductRequestDto{
+					ProductID: s.productID,
+				})
+			})
+		}
+		wg.Wait()
+
+		for i := range results {
+			s.Require().NoError(errs[i])
+			s.Equal(s.productID, results[i].ID)
+			s.Equal(results[0].UpdatedAt, results[i].UpdatedAt)
+		}
+		_, err = s.cache.Get(ctx, application.ProductCacheParam{ID: s.productID})
+		s.NoError(err, "Product should be cached again")
+	})
+
+	s.Run("Inspect and invalidate product through cache admin", func() {
+		keys, err := s.cacheApp.ListKeys(ctx, http_dto.ListCacheKeysRequestDto{
+			Entity: domain.ChangeEntityProduct,
+			ID:     &s.productID,
+			Match:  "*",
+			Limit:  100,
+		})
+		s.Require().NoError(err)
+		s.Require().NotEmpty(keys.Data, "Cached product should be tagged")
+
+		err = s.cacheApp.Invalidate(ctx, http_dto.InvalidateCacheRequestDto{
+			Data: http_dto.InvalidateCacheData{
+				Entity: domain.ChangeEntityProduct,
+				IDs:    []uuid.UUID{s.productID},
+			},
+		})
+		s.Require().NoError(err)
+
+		_, err = s.cache.Get(ctx, application.ProductCacheParam{ID: s.productID})
+		s.ErrorIs(err, redis.Nil, "Product should be invalidated")
+		_, err = s.cache.Get(ctx, application.ProductCacheParam{ID: seededProductID})
+		s.NoError(err, "Unrelated product should stay cached")
+	})
+
 	s.Run("List products and verify cache", func() {
 		// First list - cache miss
 		result1, err := s.app.List(ctx, http_dto.ListProductRequestDto{
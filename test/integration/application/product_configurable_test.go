@@ -4,10 +4,12 @@
 package application_test
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -72,6 +74,13 @@ func (s *ProductWithOptionsLifecycleTestSuite) newConfig(
 		S3AccessKey:  "electricilies",
 		S3SecretKey:  "electricilies",
 		S3RegionName: "us-east-1",
+
+		ProductImageMaxBytes:  10 << 20,
+		ProductImageMaxPixels: 40_000_000,
+
+		ProductCacheTTL:        time.Hour,
+		ProductCacheStaleTTL:   5 * time.Minute,
+		ProductSuggestCacheTTL: 5 * time.Minute,
 	}
 }
 
@@ -95,6 +104,7 @@ func (s *ProductWithOptionsLifecycleTestSuite) SetupTest() {
 	queries := client.NewDBQueries(conn)
 
 	productRepo := repositorypostgres.ProvideProduct(queries, conn)
+	specGroupRepo := repositorypostgres.ProvideSpecGroup(queries, conn)
 	categoryRepo := repositorypostgres.ProvideCategory(queries)
 	attributeRepo := repositorypostgres.ProvideAttribute(queries, conn)
 
@@ -102,7 +112,10 @@ func (s *ProductWithOptionsLifecycleTestSuite) SetupTest() {
 	attributeService := service.ProvideAttribute(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	productCache := cacheredis.ProvideProduct(redisClient)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	productCache := cacheredis.ProvideProduct(redisClient, localCache, cfg)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	productViewBuffer := cacheredis.ProvideProductView(redisClient)
 
 	s3Client := client.NewS3(ctx, cfg)
 	s3PresignClient := client.NewS3Presign(s3Client)
@@ -123,10 +136,13 @@ func (s *ProductWithOptionsLifecycleTestSuite) SetupTest() {
 		attributeRepo,
 		attributeService,
 		categoryRepo,
+		changeHandler,
 		productCache,
 		productObjectStorage,
 		productRepo,
 		productService,
+		productViewBuffer,
+		specGroupRepo,
 		cfg,
 	)
 }
@@ -137,9 +153,9 @@ func (s *ProductWithOptionsLifecycleTestSuite) TearDownTest() {
 
 func (s *ProductWithOptionsLifecycleTestSuite) uploadDummyImage(url string) {
 	s.T().Helper()
-	req, err := http.NewRequest("PUT", url, strings.NewReader("dummy image data"))
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(component.NewImage(400, 300)))
 	s.Require().NoError(err)
-	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Content-Type", "image/png")
 
 	resp, err := http.DefaultClient.Do(req)
 	s.Require().NoError(err)
@@ -464,6 +480,143 @@ func (s *ProductWithOptionsLifecycleTestSuite) TestProductWithOptionsLifecycle()
 		s.Equal(100, result.Quantity)
 	})
 
+	s.Run("Add and delete option value with its variant", func() {
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		var sizeOptionID, redValueID uuid.UUID
+		for _, opt := range product.Options {
+			switch opt.Name {
+			case "Kích thước":
+				sizeOptionID = opt.ID
+			case "Color":
+				for _, val := range opt.Values {
+					if val.Value == "Red" {
+						redValueID = val.ID
+					}
+				}
+			}
+		}
+		s.Require().NotEqual(uuid.Nil, sizeOptionID)
+		s.Require().NotEqual(uuid.Nil, redValueID)
+
+		values, err := s.app.AddOptionValues(ctx, http_dto.AddProductOptionValuesRequestDto{
+			ProductID: s.firstProductID,
+			OptionID:  sizeOptionID,
+			Data:      []http_dto.AddProductOptionValuesData{{Value: "XL"}},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(*values, 1)
+		xlValueID := (*values)[0].ID
+
+		variants, err := s.app.AddVariants(ctx, http_dto.AddProductVariantsRequestDto{
+			ProductID: s.firstProductID,
+			Data: []http_dto.AddProductVariantsData{
+				{
+					SKU:            "CONFIG-TEST-XL-RED",
+					Price:          130000,
+					Quantity:       5,
+					OptionValueIDs: []uuid.UUID{xlValueID, redValueID},
+				},
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(*variants, 1)
+
+		err = s.app.DeleteOptionValue(ctx, http_dto.DeleteProductOptionValueRequestDto{
+			ProductID:     s.firstProductID,
+			OptionID:      sizeOptionID,
+			OptionValueID: xlValueID,
+		})
+		s.Require().ErrorIs(err, domain.ErrConflict, "value is still used by a variant")
+
+		err = s.app.DeleteVariant(ctx, http_dto.DeleteProductVariantRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: (*variants)[0].ID,
+		})
+		s.Require().NoError(err)
+
+		err = s.app.DeleteOptionValue(ctx, http_dto.DeleteProductOptionValueRequestDto{
+			ProductID:     s.firstProductID,
+			OptionID:      sizeOptionID,
+			OptionValueID: xlValueID,
+		})
+		s.Require().NoError(err)
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Len(product.Variants, 6)
+		for _, opt := range product.Options {
+			if opt.ID == sizeOptionID {
+				s.Len(opt.Values, 3)
+			}
+		}
+	})
+
+	s.Run("Add and delete option", func() {
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		variantsData := make([]http_dto.AddProductOptionVariantData, 0, len(product.Variants))
+		for _, v := range product.Variants {
+			variantsData = append(variantsData, http_dto.AddProductOptionVariantData{
+				ProductVariantID: v.ID,
+				Value:            "Cotton",
+			})
+		}
+
+		option, err := s.app.AddOption(ctx, http_dto.AddProductOptionRequestDto{
+			ProductID: s.firstProductID,
+			Data: http_dto.AddProductOptionData{
+				Name:     "Material",
+				Values:   []string{"Cotton", "Linen"},
+				Variants: variantsData,
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal("Material", option.Name)
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Len(product.Options, 3)
+		var colorOptionID uuid.UUID
+		for _, opt := range product.Options {
+			if opt.Name == "Color" {
+				colorOptionID = opt.ID
+			}
+		}
+		for _, v := range product.Variants {
+			s.Len(v.OptionValues, 3)
+		}
+
+		err = s.app.DeleteOption(ctx, http_dto.DeleteProductOptionRequestDto{
+			ProductID: s.firstProductID,
+			OptionID:  colorOptionID,
+		})
+		s.Require().ErrorIs(err, domain.ErrConflict, "variants would no longer differ")
+
+		err = s.app.DeleteOption(ctx, http_dto.DeleteProductOptionRequestDto{
+			ProductID: s.firstProductID,
+			OptionID:  option.ID,
+		})
+		s.Require().NoError(err)
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Len(product.Options, 2)
+		for _, v := range product.Variants {
+			s.Len(v.OptionValues, 2)
+		}
+	})
+
 	s.Run("List products with filters", func() {
 		// Filter by category ID
 		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
@@ -4,10 +4,14 @@
 package application_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
@@ -70,6 +74,24 @@ func (s *ProductWithSeededDataTestSuite) newConfig(
 		S3AccessKey:  "electricilies",
 		S3SecretKey:  "electricilies",
 		S3RegionName: "us-east-1",
+
+		ProductImageMaxBytes:  10 << 20,
+		ProductImageMaxPixels: 40_000_000,
+
+		ProductCacheTTL:        time.Hour,
+		ProductCacheStaleTTL:   5 * time.Minute,
+		ProductSuggestCacheTTL: 5 * time.Minute,
+
+		ProductViewDedupTTL:           time.Minute,
+		ProductTrendingWindow:         30 * 24 * time.Hour,
+		ProductTrendingHalfLife:       3 * 24 * time.Hour,
+		ProductTrendingViewWeight:     1,
+		ProductTrendingPurchaseWeight: 10,
+
+		ProductRecommendationLimit:                20,
+		ProductRecommendationCategoryWeight:       1,
+		ProductRecommendationAttributeValueWeight: 2,
+		ProductRecommendationMinOrders:            1,
 	}
 }
 
@@ -93,6 +115,7 @@ func (s *ProductWithSeededDataTestSuite) SetupTest() {
 	queries := client.NewDBQueries(conn)
 
 	productRepo := repositorypostgres.ProvideProduct(queries, conn)
+	specGroupRepo := repositorypostgres.ProvideSpecGroup(queries, conn)
 	categoryRepo := repositorypostgres.ProvideCategory(queries)
 	attributeRepo := repositorypostgres.ProvideAttribute(queries, conn)
 
@@ -100,7 +123,10 @@ func (s *ProductWithSeededDataTestSuite) SetupTest() {
 	attributeService := service.ProvideAttribute(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	productCache := cacheredis.ProvideProduct(redisClient)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	productCache := cacheredis.ProvideProduct(redisClient, localCache, cfg)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	productViewBuffer := cacheredis.ProvideProductView(redisClient)
 
 	s3Client := client.NewS3(ctx, cfg)
 	s3PresignClient := client.NewS3Presign(s3Client)
@@ -121,10 +147,13 @@ func (s *ProductWithSeededDataTestSuite) SetupTest() {
 		attributeRepo,
 		attributeService,
 		categoryRepo,
+		changeHandler,
 		productCache,
 		productObjectStorage,
 		productRepo,
 		productService,
+		productViewBuffer,
+		specGroupRepo,
 		cfg,
 	)
 }
@@ -135,9 +164,9 @@ func (s *ProductWithSeededDataTestSuite) TearDownTest() {
 
 func (s *ProductWithSeededDataTestSuite) uploadDummyImage(url string) {
 	s.T().Helper()
-	req, err := http.NewRequest("PUT", url, strings.NewReader("dummy image data"))
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(component.NewImage(400, 300)))
 	s.Require().NoError(err)
-	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Content-Type", "image/png")
 
 	resp, err := http.DefaultClient.Do(req)
 	s.Require().NoError(err)
@@ -288,6 +317,75 @@ func (s *ProductWithSeededDataTestSuite) TestProductWithSeededData() {
 		s.GreaterOrEqual(result.Meta.TotalItems, 1, "Should find products with 'Điện thoại' in name")
 	})
 
+	s.Run("Search products by exact SKU", func() {
+		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 10,
+			},
+			Search: "4868714459472",
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(result)
+		s.Require().NotEmpty(result.Data)
+		s.Equal(seededProductID1, result.Data[0].ID, "Exact SKU match should be ranked first")
+	})
+
+	s.Run("Search products by description", func() {
+		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 100,
+			},
+			Search: "VoLTE",
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(result)
+
+		found := false
+		for _, p := range result.Data {
+			if p.ID == seededProductID1 {
+				found = true
+				break
+			}
+		}
+		s.True(found, "Should find product by a term only present in its description")
+	})
+
+	s.Run("Suggest without accents", func() {
+		result, err := s.app.ListSuggestions(ctx, http_dto.ListProductSuggestionsRequestDto{
+			Search: "dien thoai",
+			Limit:  5,
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(result)
+
+		foundCategory := false
+		for _, suggestion := range *result {
+			if suggestion.Type == string(domain.ProductSuggestionTypeCategory) && suggestion.ID == seededCategoryID {
+				foundCategory = true
+			}
+		}
+		s.True(foundCategory, "Should suggest 'Điện thoại phổ thông' for 'dien thoai'")
+	})
+
+	s.Run("Suggest with typo and prefix", func() {
+		result, err := s.app.ListSuggestions(ctx, http_dto.ListProductSuggestionsRequestDto{
+			Search: "masstl",
+			Limit:  5,
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(result)
+
+		foundProduct := false
+		for _, suggestion := range *result {
+			if suggestion.Type == string(domain.ProductSuggestionTypeProduct) && suggestion.ID == seededProductID1 {
+				foundProduct = true
+			}
+		}
+		s.True(foundProduct, "Should suggest the Masstel product for 'masstl'")
+	})
+
 	s.Run("Sort products by price ascending", func() {
 		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
 			PaginationRequestDto: http_dto.PaginationRequestDto{
@@ -382,5 +480,165 @@ func (s *ProductWithSeededDataTestSuite) TestProductWithSeededData() {
 		}
 	})
 
+	s.Run("Rank products by trending views", func() {
+		productApp, ok := s.app.(*application.Product)
+		s.Require().True(ok)
+
+		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 2,
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 2)
+		lessViewed := result.Data[0]
+		mostViewed := result.Data[1]
+
+		// A repeated view from the same IP is counted once
+		for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.1"} {
+			_, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+				ProductID: mostViewed.ID,
+				ClientIP:  ip,
+			})
+			s.Require().NoError(err)
+		}
+		_, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: lessViewed.ID,
+			ClientIP:  "10.0.0.3",
+		})
+		s.Require().NoError(err)
+
+		// Flushes running at once do not count the same views twice
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = productApp.FlushViews(ctx)
+			}(i)
+		}
+		wg.Wait()
+		s.Require().NoError(errors.Join(errs...))
+		s.Require().NoError(productApp.UpdateTrendingScores(ctx))
+
+		trending, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 2,
+			},
+			SortTrending: true,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(trending.Data, 2)
+		s.Equal(mostViewed.ID, trending.Data[0].ID)
+		s.Equal(mostViewed.ViewsCount+2, trending.Data[0].ViewsCount)
+		s.Equal(lessViewed.ID, trending.Data[1].ID)
+		s.Equal(lessViewed.ViewsCount+1, trending.Data[1].ViewsCount)
+	})
+
+	s.Run("Cursor pagination matches page order", func() {
+		for _, sortPrice := range []string{"", "asc"} {
+			paged, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+				PaginationRequestDto: http_dto.PaginationRequestDto{
+					Page:  1,
+					Limit: 100,
+				},
+				SortPrice: sortPrice,
+			})
+			s.Require().NoError(err)
+			s.Require().Greater(len(paged.Data), 3)
+
+			var cursored []uuid.UUID
+			cursor := ""
+			for {
+				result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+					PaginationRequestDto: http_dto.PaginationRequestDto{
+						Limit:  15,
+						Cursor: &cursor,
+					},
+					SortPrice: sortPrice,
+				})
+				s.Require().NoError(err)
+				s.Zero(result.Meta.TotalItems)
+				for _, p := range result.Data {
+					cursored = append(cursored, p.ID)
+				}
+				if result.Meta.NextCursor == "" || len(cursored) >= len(paged.Data) {
+					break
+				}
+				cursor = result.Meta.NextCursor
+			}
+
+			s.Require().GreaterOrEqual(len(cursored), len(paged.Data))
+			for i, p := range paged.Data {
+				s.Equal(p.ID, cursored[i])
+			}
+		}
+	})
+
+	s.Run("Cursor pagination with count", func() {
+		cursor := ""
+		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Limit:     3,
+				Cursor:    &cursor,
+				WithCount: true,
+			},
+		})
+		s.Require().NoError(err)
+		s.Len(result.Data, 3)
+		s.NotEmpty(result.Meta.NextCursor)
+		s.Greater(result.Meta.TotalItems, 3)
+	})
+	s.Run("List related products after refreshing recommendations", func() {
+		productApp, ok := s.app.(*application.Product)
+		s.Require().True(ok)
+
+		s.Require().NoError(productApp.RefreshRecommendations(ctx))
+
+		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 1,
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 1)
+		product := result.Data[0]
+
+		related, err := s.app.ListRelated(ctx, http_dto.ListProductRecommendationsRequestDto{
+			ProductID: product.ID,
+			Limit:     5,
+		})
+		s.Require().NoError(err)
+		s.Require().NotEmpty(*related)
+		s.LessOrEqual(len(*related), 5)
+		for _, p := range *related {
+			s.NotEqual(product.ID, p.ID)
+		}
+	})
+
+	s.Run("List products bought together after refreshing recommendations", func() {
+		// Seeded order 4 holds a variant of both products
+		productID := uuid.MustParse("00000000-0000-7000-0000-000278505394")
+		boughtWithID := uuid.MustParse("00000000-0000-7000-0000-000278580531")
+
+		boughtTogether, err := s.app.ListBoughtTogether(ctx, http_dto.ListProductRecommendationsRequestDto{
+			ProductID: productID,
+			Limit:     5,
+		})
+		s.Require().NoError(err)
+		s.Require().NotEmpty(*boughtTogether)
+		s.LessOrEqual(len(*boughtTogether), 5)
+		ids := make([]uuid.UUID, 0, len(*boughtTogether))
+		for _, p := range *boughtTogether {
+			ids = append(ids, p.ID)
+		}
+		s.Contains(ids, boughtWithID)
+		s.NotContains(ids, productID)
+	})
+
 	// NOTE: Skip delete test as seeded data has validation errors that prevent modification
 }
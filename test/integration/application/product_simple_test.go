@@ -4,16 +4,21 @@
 package application_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"backend/config"
 	"backend/internal/application"
 	"backend/internal/client"
 	http_dto "backend/internal/delivery/http"
+	"backend/internal/delivery/job"
 	"backend/internal/domain"
+	"backend/internal/helper/ptr"
 	"backend/internal/infrastructure/cacheredis"
 	"backend/internal/infrastructure/objectstorages3"
 	"backend/internal/infrastructure/repositorypostgres"
@@ -29,7 +34,14 @@ import (
 type ProductLifecycleTestSuite struct {
 	suite.Suite
 	containers *component.Containers
+	cfg        *config.Server
+	s3Client   *s3.Client
 	app        http_dto.ProductApplication
+	jobApp     job.ProductApplication
+	outboxRepo domain.OutboxRepository
+
+	notificationApp http_dto.NotificationApplication
+	specGroupApp    http_dto.SpecGroupApplication
 
 	// For tracking created resources
 	firstProductID uuid.UUID
@@ -38,9 +50,9 @@ type ProductLifecycleTestSuite struct {
 // uploadDummyImage uploads a dummy image to S3 for testing
 func (s *ProductLifecycleTestSuite) uploadDummyImage(url string) {
 	s.T().Helper()
-	req, err := http.NewRequest("PUT", url, strings.NewReader("dummy image data"))
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(component.NewImage(400, 300)))
 	s.Require().NoError(err)
-	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Content-Type", "image/png")
 
 	resp, err := http.DefaultClient.Do(req)
 	s.Require().NoError(err)
@@ -48,6 +60,14 @@ func (s *ProductLifecycleTestSuite) uploadDummyImage(url string) {
 	s.Require().Equal(http.StatusOK, resp.StatusCode)
 }
 
+func renditionWidths(image http_dto.ProductImageResponseDto) []int {
+	widths := make([]int, 0, len(image.Renditions))
+	for _, rendition := range image.Renditions {
+		widths = append(widths, rendition.Width)
+	}
+	return widths
+}
+
 func TestProductLifecycleSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(ProductLifecycleTestSuite))
@@ -85,6 +105,13 @@ func (s *ProductLifecycleTestSuite) newConfig(
 		S3AccessKey:  "electricilies",
 		S3SecretKey:  "electricilies",
 		S3RegionName: "us-east-1",
+
+		ProductImageMaxBytes:  10 << 20,
+		ProductImageMaxPixels: 40_000_000,
+
+		ProductCacheTTL:        time.Hour,
+		ProductCacheStaleTTL:   5 * time.Minute,
+		ProductSuggestCacheTTL: 5 * time.Minute,
 	}
 }
 
@@ -97,27 +124,36 @@ func (s *ProductLifecycleTestSuite) SetupTest() {
 	s.Require().NoError(err, "failed to start containers")
 
 	cfg := s.newConfig(ctx)
+	s.cfg = cfg
 
 	validate := validator.New(
 		validator.WithRequiredStructEnabled(),
 	)
 	err = domain.RegisterProductValidates(validate)
 	s.Require().NoError(err)
+	err = domain.RegisterSpecValidates(validate)
+	s.Require().NoError(err)
 
 	conn := client.NewDBConnection(ctx, cfg)
 	queries := client.NewDBQueries(conn)
 
 	productRepo := repositorypostgres.ProvideProduct(queries, conn)
+	specGroupRepo := repositorypostgres.ProvideSpecGroup(queries, conn)
 	categoryRepo := repositorypostgres.ProvideCategory(queries)
 	attributeRepo := repositorypostgres.ProvideAttribute(queries, conn)
+	s.outboxRepo = repositorypostgres.ProvideOutbox(queries)
 
 	productService := service.ProvideProduct(validate)
 	attributeService := service.ProvideAttribute(validate)
 
 	redisClient := client.NewRedis(ctx, cfg)
-	productCache := cacheredis.ProvideProduct(redisClient)
+	localCache := cacheredis.ProvideLocal(ctx, redisClient, cfg)
+	productCache := cacheredis.ProvideProduct(redisClient, localCache, cfg)
+	changeHandler := cacheredis.ProvideTag(redisClient, localCache)
+	productViewBuffer := cacheredis.ProvideProductView(redisClient)
 
 	s3Client := client.NewS3(ctx, cfg)
+	s.s3Client = s3Client
 	s3PresignClient := client.NewS3Presign(s3Client)
 	s3ClientWrapper := client.ProvideS3(s3Client, s3PresignClient)
 
@@ -132,16 +168,29 @@ func (s *ProductLifecycleTestSuite) SetupTest() {
 
 	productObjectStorage := objectstorages3.ProvideProduct(s3ClientWrapper, cfg)
 
-	s.app = application.ProvideProduct(
+	productApp := application.ProvideProduct(
 		attributeRepo,
 		attributeService,
 		categoryRepo,
+		changeHandler,
 		productCache,
 		productObjectStorage,
 		productRepo,
 		productService,
+		productViewBuffer,
+		specGroupRepo,
 		cfg,
 	)
+	s.app = productApp
+	s.jobApp = productApp
+	s.notificationApp = application.ProvideNotification(
+		repositorypostgres.ProvideNotification(queries),
+	)
+	s.specGroupApp = application.ProvideSpecGroup(
+		changeHandler,
+		specGroupRepo,
+		service.ProvideSpecGroup(validate),
+	)
 }
 
 func (s *ProductLifecycleTestSuite) TearDownTest() {
@@ -212,6 +261,7 @@ func (s *ProductLifecycleTestSuite) TestSimpleProductLifecycle() {
 		s.Equal(int64(500000), result.Variants[0].Price)
 		s.Equal(100, result.Variants[0].Quantity)
 		s.Len(result.Images, 2)
+		s.Equal([]int{160, 320}, renditionWidths(result.Images[0]), "Renditions are only made for widths smaller than the image")
 		s.NotNil(result.Category)
 		s.Equal(seededCategoryID, result.Category.ID)
 		s.Len(result.Attributes, 1, "Attributes should be returned in response")
@@ -228,7 +278,9 @@ func (s *ProductLifecycleTestSuite) TestSimpleProductLifecycle() {
 		s.Equal("Simple Test Product", result.Name)
 		s.NotNil(result.Category)
 		s.Len(result.Attributes, 1, "Attributes should be returned in Get response")
-		s.Len(result.Images, 2)
+		s.Require().Len(result.Images, 2)
+		s.Equal([]int{160, 320}, renditionWidths(result.Images[0]))
+		s.Equal(120, result.Images[0].Renditions[0].Height)
 	})
 
 	s.Run("Get product again to test Redis cache hit", func() {
@@ -298,6 +350,75 @@ func (s *ProductLifecycleTestSuite) TestSimpleProductLifecycle() {
 		s.Require().NotNil(result)
 		s.Equal("Updated Simple Product", result.Name)
 		s.Equal("Updated description for test product", result.Description)
+		s.Equal(domain.ProductStatusDraft, result.Status)
+	})
+
+	s.Run("Get draft product publicly fails", func() {
+		_, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID:     s.firstProductID,
+			PublishedOnly: true,
+		})
+		s.Require().ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("List published products excludes draft", func() {
+		result, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 100,
+			},
+			ProductIDs: []uuid.UUID{s.firstProductID},
+			Statuses:   []domain.ProductStatus{domain.ProductStatusPublished},
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(result)
+		s.Empty(result.Data)
+	})
+
+	s.Run("Publish product by schedule", func() {
+		publishAt := time.Now().Add(-time.Minute)
+		result, err := s.app.UpdatePublishing(ctx, http_dto.UpdateProductPublishingRequestDto{
+			ProductID: s.firstProductID,
+			Data: http_dto.UpdateProductPublishingData{
+				Status:    domain.ProductStatusDraft,
+				PublishAt: &publishAt,
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(result.PublishAt)
+
+		claim := func() []domain.OutboxEvent {
+			events, err := s.outboxRepo.Claim(ctx, domain.OutboxRepositoryClaimParam{
+				Now:        time.Now(),
+				LeaseUntil: time.Now().Add(time.Hour),
+				Limit:      1000,
+			})
+			s.Require().NoError(err)
+			return *events
+		}
+		// Events recorded so far are claimed so only those of the schedule
+		// are left
+		claim()
+
+		productApp, ok := s.app.(*application.Product)
+		s.Require().True(ok)
+		s.Require().NoError(productApp.ApplyPublishSchedules(ctx))
+
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID:     s.firstProductID,
+			PublishedOnly: true,
+		})
+		s.Require().NoError(err)
+		s.Equal(domain.ProductStatusPublished, product.Status)
+		s.Nil(product.PublishAt)
+
+		events := claim()
+		s.Require().Len(events, 1, "the scheduled publish reaches the outbox")
+		s.Equal(domain.EventTypeProductUpdated, events[0].Type)
+		s.Equal(s.firstProductID, events[0].AggregateID)
+		var payload domain.ProductEventPayload
+		s.Require().NoError(json.Unmarshal(events[0].Payload, &payload))
+		s.Equal(domain.ProductStatusPublished, payload.Status)
 	})
 
 	s.Run("Update product variant", func() {
@@ -322,6 +443,486 @@ func (s *ProductLifecycleTestSuite) TestSimpleProductLifecycle() {
 		s.Equal(150, result.Quantity)
 	})
 
+	s.Run("Put product variant on sale", func() {
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		variantID := product.Variants[0].ID
+
+		saleEndsAt := time.Now().Add(time.Hour)
+		result, err := s.app.UpdateVariantPricing(ctx, http_dto.UpdateProductVariantPricingRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			Data: http_dto.UpdateProductVariantPricingData{
+				CompareAtPrice: 800000,
+				SalePrice:      400000,
+				SaleEndsAt:     &saleEndsAt,
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(int64(400000), result.EffectivePrice)
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.InDelta(float64(400000), product.Price, 0.001)
+		s.Equal(50, product.Discount)
+
+		onSale, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 100,
+			},
+			ProductIDs: []uuid.UUID{s.firstProductID},
+			OnSale:     true,
+		})
+		s.Require().NoError(err)
+		s.Len(onSale.Data, 1)
+	})
+
+	s.Run("Remove sale of product variant", func() {
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		variantID := product.Variants[0].ID
+
+		_, err = s.app.UpdateVariantPricing(ctx, http_dto.UpdateProductVariantPricingRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			Data: http_dto.UpdateProductVariantPricingData{
+				CompareAtPrice: 800000,
+			},
+		})
+		s.Require().NoError(err)
+
+		productApp, ok := s.app.(*application.Product)
+		s.Require().True(ok)
+		s.Require().NoError(productApp.SyncPrices(ctx))
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.InDelta(float64(600000), product.Price, 0.001)
+		s.Equal(25, product.Discount)
+
+		onSale, err := s.app.List(ctx, http_dto.ListProductRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 100,
+			},
+			ProductIDs: []uuid.UUID{s.firstProductID},
+			OnSale:     true,
+		})
+		s.Require().NoError(err)
+		s.Empty(onSale.Data, "a compare-at price alone is not a sale")
+
+		_, err = s.app.UpdateVariantPricing(ctx, http_dto.UpdateProductVariantPricingRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			Data:             http_dto.UpdateProductVariantPricingData{},
+		})
+		s.Require().NoError(err)
+		s.Require().NoError(productApp.SyncPrices(ctx))
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Zero(product.Discount)
+	})
+
+	s.Run("Record stock movements of product variant", func() {
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		variantID := product.Variants[0].ID
+		actorID := uuid.New()
+
+		movement, err := s.app.CreateStockMovement(ctx, http_dto.CreateStockMovementRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			UserID:           actorID,
+			Data: http_dto.CreateStockMovementData{
+				Kind:     domain.StockMovementKindRestock,
+				Quantity: 10,
+				Note:     "supplier delivery",
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(160, movement.QuantityAfter)
+		s.Require().NotNil(movement.ActorID)
+		s.Equal(actorID, *movement.ActorID)
+
+		_, err = s.app.CreateStockMovement(ctx, http_dto.CreateStockMovementRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			Data: http_dto.CreateStockMovementData{
+				Kind:     domain.StockMovementKindAdjustment,
+				Quantity: -200,
+			},
+		})
+		s.Require().ErrorIs(err, domain.ErrInvalid)
+
+		movements, err := s.app.ListStockMovements(ctx, http_dto.ListStockMovementsRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 20,
+			},
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(movements.Data, 3)
+		s.Equal(3, movements.Meta.TotalItems)
+		s.Equal(domain.StockMovementKindRestock, movements.Data[0].Kind)
+		s.Equal(10, movements.Data[0].Quantity)
+		s.Equal(domain.StockMovementKindAdjustment, movements.Data[1].Kind)
+		s.Equal(50, movements.Data[1].Quantity)
+		s.Equal(domain.StockMovementKindRestock, movements.Data[2].Kind)
+		s.Equal(100, movements.Data[2].Quantity)
+
+		productApp, ok := s.app.(*application.Product)
+		s.Require().True(ok)
+		s.Require().NoError(productApp.ReconcileStock(ctx))
+
+		product, err = s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Equal(160, product.Variants[0].Quantity)
+	})
+
+	s.Run("Notify low stock and back in stock of product variant", func() {
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		variantID := product.Variants[0].ID
+		userID := uuid.New()
+		threshold := 20
+
+		variant, err := s.app.UpdateVariant(ctx, http_dto.UpdateProductVariantRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			Data: http_dto.UpdateProductVariantData{
+				LowStockThreshold: &threshold,
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(threshold, variant.LowStockThreshold)
+
+		err = s.app.SubscribeStock(ctx, http_dto.SubscribeProductVariantStockRequestDto{
+			ProductID:        s.firstProductID,
+			ProductVariantID: variantID,
+			UserID:           userID,
+		})
+		s.Require().NoError(err)
+
+		for _, quantity := range []int{-145, -15, 5} {
+			_, err = s.app.CreateStockMovement(ctx, http_dto.CreateStockMovementRequestDto{
+				ProductID:        s.firstProductID,
+				ProductVariantID: variantID,
+				Data: http_dto.CreateStockMovementData{
+					Kind:     domain.StockMovementKindAdjustment,
+					Quantity: quantity,
+				},
+			})
+			s.Require().NoError(err)
+		}
+
+		staffNotifications, err := s.notificationApp.List(ctx, http_dto.ListNotificationsRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 20,
+			},
+			Staff: true,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(staffNotifications.Data, 1)
+		s.Equal(domain.NotificationKindLowStock, staffNotifications.Data[0].Kind)
+		s.Equal(15, staffNotifications.Data[0].Quantity)
+
+		userNotifications, err := s.notificationApp.List(ctx, http_dto.ListNotificationsRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 20,
+			},
+			UserID: userID,
+			Unread: true,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(userNotifications.Data, 1)
+		s.Equal(domain.NotificationKindBackInStock, userNotifications.Data[0].Kind)
+		s.Require().NotNil(userNotifications.Data[0].ProductVariantID)
+		s.Equal(variantID, *userNotifications.Data[0].ProductVariantID)
+
+		_, err = s.notificationApp.MarkRead(ctx, http_dto.MarkNotificationReadRequestDto{
+			NotificationID: userNotifications.Data[0].ID,
+			Staff:          true,
+		})
+		s.Require().ErrorIs(err, domain.ErrForbidden)
+
+		notification, err := s.notificationApp.MarkRead(ctx, http_dto.MarkNotificationReadRequestDto{
+			NotificationID: userNotifications.Data[0].ID,
+			UserID:         userID,
+		})
+		s.Require().NoError(err)
+		s.NotNil(notification.ReadAt)
+
+		userNotifications, err = s.notificationApp.List(ctx, http_dto.ListNotificationsRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{
+				Page:  1,
+				Limit: 20,
+			},
+			UserID: userID,
+			Unread: true,
+		})
+		s.Require().NoError(err)
+		s.Empty(userNotifications.Data)
+	})
+
+	s.Run("Stock product variant at warehouses", func() {
+		seededProductID := uuid.MustParse("00000000-0000-7000-0000-000278469304")
+		seededVariantID := uuid.MustParse("00000000-0000-7000-0000-000278469308")
+		seededHCMWarehouseID := uuid.MustParse("00000000-0000-7000-0000-000000000001")
+		seededHNWarehouseID := uuid.MustParse("00000000-0000-7000-0000-000000000002")
+
+		before, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: seededProductID,
+		})
+		s.Require().NoError(err)
+		var quantityBefore int
+		for _, v := range before.Variants {
+			if v.ID == seededVariantID {
+				quantityBefore = v.Quantity
+			}
+		}
+
+		movement, err := s.app.CreateStockMovement(ctx, http_dto.CreateStockMovementRequestDto{
+			ProductID:        seededProductID,
+			ProductVariantID: seededVariantID,
+			Data: http_dto.CreateStockMovementData{
+				Kind:        domain.StockMovementKindRestock,
+				Quantity:    3,
+				WarehouseID: &seededHNWarehouseID,
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(movement.WarehouseID)
+		s.Equal(seededHNWarehouseID, *movement.WarehouseID)
+
+		after, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: seededProductID,
+		})
+		s.Require().NoError(err)
+		for _, v := range after.Variants {
+			if v.ID != seededVariantID {
+				continue
+			}
+			s.Equal(quantityBefore+3, v.Quantity)
+			s.ElementsMatch([]http_dto.WarehouseStockResponseDto{
+				{WarehouseID: seededHCMWarehouseID, Quantity: quantityBefore},
+				{WarehouseID: seededHNWarehouseID, Quantity: 3},
+			}, v.Stocks)
+		}
+	})
+
+	s.Run("Create bundle of product variants", func() {
+		seededProductID := uuid.MustParse("00000000-0000-7000-0000-000278469304")
+		seededVariantID := uuid.MustParse("00000000-0000-7000-0000-000278469308")
+		seededOtherVariantID := uuid.MustParse("00000000-0000-7000-0000-000278469310")
+		seededHCMWarehouseID := uuid.MustParse("00000000-0000-7000-0000-000000000001")
+
+		seeded, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: seededProductID,
+		})
+		s.Require().NoError(err)
+		componentStocks := make(map[uuid.UUID]http_dto.ProductVariantResponseDto)
+		for _, v := range seeded.Variants {
+			componentStocks[v.ID] = v
+		}
+		stockAt := func(v http_dto.ProductVariantResponseDto, warehouseID uuid.UUID) int {
+			for _, stock := range v.Stocks {
+				if stock.WarehouseID == warehouseID {
+					return stock.Quantity
+				}
+			}
+			return 0
+		}
+
+		uploadURL, err := s.app.GetUploadImageURL(ctx)
+		s.Require().NoError(err)
+		s.uploadDummyImage(uploadURL.URL)
+
+		created, err := s.app.Create(ctx, http_dto.CreateProductRequestDto{
+			Data: http_dto.CreateProductData{
+				Name:        "Bundle Test Product",
+				Description: "This is a bundle of seeded product variants",
+				CategoryID:  seededCategoryID,
+				Type:        domain.ProductTypeBundle,
+				Images: []http_dto.CreateProductImageData{
+					{
+						Key:   uploadURL.Key,
+						Order: 1,
+					},
+				},
+				Variants: []http_dto.CreateProductVariantData{
+					{
+						SKU:   "BUNDLE-TEST-001",
+						Price: 900000,
+						Components: []http_dto.BundleComponentData{
+							{VariantID: seededVariantID, Quantity: 1},
+							{VariantID: seededOtherVariantID, Quantity: 2},
+						},
+					},
+				},
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(domain.ProductTypeBundle, created.Type)
+
+		bundle, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: created.ID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(bundle.Variants, 1)
+		variant := bundle.Variants[0]
+		s.Len(variant.Components, 2)
+		s.Equal(int64(900000), variant.Price)
+		s.Equal(
+			min(componentStocks[seededVariantID].Quantity, componentStocks[seededOtherVariantID].Quantity/2),
+			variant.Quantity,
+		)
+		s.Contains(variant.Stocks, http_dto.WarehouseStockResponseDto{
+			WarehouseID: seededHCMWarehouseID,
+			Quantity: min(
+				stockAt(componentStocks[seededVariantID], seededHCMWarehouseID),
+				stockAt(componentStocks[seededOtherVariantID], seededHCMWarehouseID)/2,
+			),
+		})
+
+		_, err = s.app.CreateStockMovement(ctx, http_dto.CreateStockMovementRequestDto{
+			ProductID:        created.ID,
+			ProductVariantID: variant.ID,
+			Data: http_dto.CreateStockMovementData{
+				Kind:     domain.StockMovementKindRestock,
+				Quantity: 1,
+			},
+		})
+		s.ErrorIs(err, domain.ErrInvalid, "Bundles hold no stock of their own")
+
+		_, err = s.app.UpdateVariantComponents(ctx, http_dto.UpdateProductVariantComponentsRequestDto{
+			ProductID:        seededProductID,
+			ProductVariantID: seededVariantID,
+			Data: http_dto.UpdateProductVariantComponentsData{
+				Components: []http_dto.BundleComponentData{
+					{VariantID: seededOtherVariantID, Quantity: 1},
+				},
+			},
+		})
+		s.ErrorIs(err, domain.ErrInvalid, "Only variants of bundles have components")
+
+		updated, err := s.app.UpdateVariantComponents(ctx, http_dto.UpdateProductVariantComponentsRequestDto{
+			ProductID:        created.ID,
+			ProductVariantID: variant.ID,
+			Data: http_dto.UpdateProductVariantComponentsData{
+				Components: []http_dto.BundleComponentData{
+					{VariantID: seededOtherVariantID, Quantity: 4},
+				},
+			},
+		})
+		s.Require().NoError(err)
+		s.Len(updated.Components, 1)
+		s.Equal(componentStocks[seededOtherVariantID].Quantity/4, updated.Quantity)
+	})
+
+	s.Run("Compare products by specs", func() {
+		seededProductID := uuid.MustParse("00000000-0000-7000-0000-000278469304")
+
+		specGroup, err := s.specGroupApp.Create(ctx, http_dto.CreateSpecGroupRequestDto{
+			Data: http_dto.CreateSpecGroupData{
+				Name:  "Display",
+				Order: 1,
+				Specs: []http_dto.CreateSpecData{
+					{Code: "screen-size", Name: "Screen size", Type: domain.SpecTypeNumber, Unit: "inch", Order: 1},
+					{Code: "touchscreen", Name: "Touchscreen", Type: domain.SpecTypeBoolean, Order: 2},
+					{Code: "panel", Name: "Panel", Type: domain.SpecTypeEnum, Choices: []string{"IPS", "OLED"}, Order: 3},
+				},
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(specGroup.Specs, 3)
+		screenSizeID := specGroup.Specs[0].ID
+		touchscreenID := specGroup.Specs[1].ID
+		panelID := specGroup.Specs[2].ID
+
+		_, err = s.app.UpdateSpecs(ctx, http_dto.UpdateProductSpecsRequestDto{
+			ProductID: s.firstProductID,
+			Data: http_dto.UpdateProductSpecsData{
+				Specs: []http_dto.ProductSpecData{
+					{SpecID: panelID, Enum: "LCD"},
+				},
+			},
+		})
+		s.ErrorIs(err, domain.ErrInvalid, "Enum value must be one of the choices")
+
+		first, err := s.app.UpdateSpecs(ctx, http_dto.UpdateProductSpecsRequestDto{
+			ProductID: s.firstProductID,
+			Data: http_dto.UpdateProductSpecsData{
+				Specs: []http_dto.ProductSpecData{
+					{SpecID: screenSizeID, Number: ptr.To(15.6)},
+					{SpecID: touchscreenID, Boolean: ptr.To(true)},
+					{SpecID: panelID, Enum: "OLED"},
+				},
+			},
+		})
+		s.Require().NoError(err)
+		s.Len(first.Specs, 3)
+
+		_, err = s.app.UpdateSpecs(ctx, http_dto.UpdateProductSpecsRequestDto{
+			ProductID: seededProductID,
+			Data: http_dto.UpdateProductSpecsData{
+				Specs: []http_dto.ProductSpecData{
+					{SpecID: screenSizeID, Number: ptr.To(14.0)},
+					{SpecID: panelID, Enum: "OLED"},
+				},
+			},
+		})
+		s.Require().NoError(err)
+
+		comparison, err := s.app.Compare(ctx, http_dto.CompareProductsRequestDto{
+			ProductIDs: []uuid.UUID{seededProductID, s.firstProductID},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(comparison.Products, 2)
+		s.Equal(seededProductID, comparison.Products[0].ID)
+		s.Require().Len(comparison.Groups, 1)
+		rows := comparison.Groups[0].Rows
+		s.Require().Len(rows, 3)
+		s.Equal(14.0, *rows[0].Values[0].Number)
+		s.Equal(15.6, *rows[0].Values[1].Number)
+		s.False(rows[0].Same)
+		s.Nil(rows[1].Values[0], "Seeded product has no touchscreen value")
+		s.True(rows[2].Same)
+
+		err = s.specGroupApp.DeleteSpec(ctx, http_dto.DeleteSpecRequestDto{
+			SpecGroupID: specGroup.ID,
+			SpecID:      touchscreenID,
+		})
+		s.Require().NoError(err)
+
+		product, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Len(product.Specs, 2, "Deleted spec is no longer shown")
+	})
+
 	s.Run("Add new images to product", func() {
 		uploadURL3, err := s.app.GetUploadImageURL(ctx)
 		s.Require().NoError(err)
@@ -436,4 +1037,141 @@ func (s *ProductLifecycleTestSuite) TestSimpleProductLifecycle() {
 			s.NotEqual(s.firstProductID, p.ID, "Deleted product should not be in list")
 		}
 	})
+	s.Run("Restore deleted product", func() {
+		product, err := s.app.Restore(ctx, http_dto.RestoreProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		s.Nil(product.DeletedAt)
+		for _, variant := range product.Variants {
+			s.Nil(variant.DeletedAt)
+		}
+
+		restored, err := s.app.Get(ctx, http_dto.GetProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		deletedImagesCount := 0
+		for _, img := range restored.Images {
+			if img.DeletedAt != nil {
+				deletedImagesCount++
+			}
+		}
+		s.Equal(1, deletedImagesCount, "Image deleted before the product should stay deleted")
+
+		_, err = s.app.Restore(ctx, http_dto.RestoreProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.ErrorIs(err, domain.ErrNotFound, "Only deleted products can be restored")
+	})
+
+	s.Run("Purge product which is not deleted fails", func() {
+		err := s.app.Purge(ctx, http_dto.PurgeProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("Purge deleted product", func() {
+		err := s.app.Delete(ctx, http_dto.DeleteProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+		err = s.app.Purge(ctx, http_dto.PurgeProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.Require().NoError(err)
+
+		_, err = s.app.Restore(ctx, http_dto.RestoreProductRequestDto{
+			ProductID: s.firstProductID,
+		})
+		s.ErrorIs(err, domain.ErrNotFound, "Purged product is gone")
+	})
+}
+
+// objectExists tells whether an object is still stored under the key
+func (s *ProductLifecycleTestSuite) objectExists(key string) bool {
+	s.T().Helper()
+	_, err := s.s3Client.HeadObject(s.T().Context(), &s3.HeadObjectInput{
+		Bucket: &s.cfg.S3Bucket,
+		Key:    &key,
+	})
+	return err == nil
+}
+
+func (s *ProductLifecycleTestSuite) TestCleanupImages() {
+	ctx := s.T().Context()
+	seededCategoryID := uuid.MustParse("00000000-0000-7000-0000-000000001796")
+
+	var tempKey, orphanKey, keptKey string
+
+	s.Run("Leave an upload, a deleted image and an image of a deleted product", func() {
+		uploadURL, err := s.app.GetUploadImageURL(ctx)
+		s.Require().NoError(err)
+		s.uploadDummyImage(uploadURL.URL)
+		tempKey = uploadURL.Key
+
+		images := make([]http_dto.CreateProductImageData, 0, 2)
+		for i := range 2 {
+			uploadURL, err := s.app.GetUploadImageURL(ctx)
+			s.Require().NoError(err)
+			s.uploadDummyImage(uploadURL.URL)
+			images = append(images, http_dto.CreateProductImageData{Key: uploadURL.Key, Order: i + 1})
+		}
+		product, err := s.app.Create(ctx, http_dto.CreateProductRequestDto{
+			Data: http_dto.CreateProductData{
+				Name:        "Cleanup Test Product",
+				Description: "Product with images to clean up",
+				CategoryID:  seededCategoryID,
+				Images:      images,
+				Variants: []http_dto.CreateProductVariantData{
+					{SKU: "CLEANUP-TEST-001", Price: 100000, Quantity: 1},
+				},
+			},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(product.Images, 2)
+		orphanKey = objectstorages3.S3ProductImageFolder + product.Images[0].ID.String()
+		keptKey = objectstorages3.S3ProductImageFolder + product.Images[1].ID.String()
+
+		s.Require().NoError(s.app.DeleteImages(ctx, http_dto.DeleteProductImagesRequestDto{
+			ProductID: product.ID,
+			ImageIDs:  []uuid.UUID{product.Images[0].ID},
+		}))
+		s.Require().NoError(s.app.Delete(ctx, http_dto.DeleteProductRequestDto{
+			ProductID: product.ID,
+		}))
+	})
+
+	s.Run("Report objects to delete in dry run", func() {
+		s.cfg.ProductImageCleanupDryRun = true
+		result, err := s.jobApp.CleanupImages(ctx)
+		s.Require().NoError(err)
+		s.True(result.DryRun)
+		s.Equal([]string{tempKey}, result.TempKeys)
+		s.Contains(result.OrphanKeys, orphanKey)
+		s.NotContains(result.OrphanKeys, keptKey, "images deleted with their product are restored with it")
+		s.Len(result.OrphanKeys, 3, "the image and its renditions")
+
+		s.True(s.objectExists(tempKey))
+		s.True(s.objectExists(orphanKey))
+	})
+
+	s.Run("Delete uploads and orphaned images", func() {
+		s.cfg.ProductImageCleanupDryRun = false
+		result, err := s.jobApp.CleanupImages(ctx)
+		s.Require().NoError(err)
+		s.False(result.DryRun)
+
+		s.False(s.objectExists(tempKey))
+		for _, key := range result.OrphanKeys {
+			s.False(s.objectExists(key))
+		}
+		s.True(s.objectExists(keptKey))
+
+		result, err = s.jobApp.CleanupImages(ctx)
+		s.Require().NoError(err)
+		s.Empty(result.TempKeys)
+		s.Empty(result.OrphanKeys)
+	})
 }
@@ -0,0 +1,257 @@
+// vim: tabstop=4 shiftwidth=4:
+//go:build integration
+
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+	"backend/internal/client"
+	http_dto "backend/internal/delivery/http"
+	"backend/internal/delivery/job"
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repositorypostgres"
+	"backend/internal/service"
+	"backend/test/integration/component"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	taskKindTest  domain.TaskKind = "test"
+	taskKindOther domain.TaskKind = "other"
+)
+
+type TaskTestSuite struct {
+	suite.Suite
+	containers *component.Containers
+	app        *application.Task
+}
+
+type taskTestPayload struct {
+	Value string `json:"value"`
+}
+
+// taskRecorder is a task handler failing each run with the next error of
+// errs, then succeeding
+type taskRecorder struct {
+	mu   sync.Mutex
+	errs []error
+	runs []domain.Task
+}
+
+func (r *taskRecorder) reset(errs ...error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = errs
+	r.runs = nil
+}
+
+func (r *taskRecorder) handle(_ context.Context, task domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, task)
+	if len(r.errs) > 0 {
+		var err error
+		err, r.errs = r.errs[0], r.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func (r *taskRecorder) ran() []domain.Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.Task(nil), r.runs...)
+}
+
+func TestTaskSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TaskTestSuite))
+}
+
+func (s *TaskTestSuite) newContainersConfig() *component.ContainersConfig {
+	return component.NewContainersConfig(&component.NewContainersConfigParam{
+		DBEnabled: true,
+	})
+}
+
+func (s *TaskTestSuite) newConfig(
+	ctx context.Context,
+) *config.Server {
+	s.T().Helper()
+
+	dbConnStr, err := s.containers.DB.ConnectionString(ctx, "sslmode=disable")
+	s.Require().NoError(err, "failed to get db connection string")
+
+	return &config.Server{
+		DBURL:              dbConnStr,
+		TaskRunBatchSize:   10,
+		TaskLease:          time.Minute,
+		TaskRetryBaseDelay: time.Millisecond,
+		TaskRetryMaxDelay:  time.Millisecond,
+		TaskMaxAttempts:    2,
+	}
+}
+
+func (s *TaskTestSuite) SetupSuite() {
+	ctx := s.T().Context()
+
+	var err error
+	s.containers, err = component.NewContainers(ctx, s.newContainersConfig())
+	s.Require().NoError(err, "failed to start containers")
+
+	cfg := s.newConfig(ctx)
+
+	validate := validator.New(
+		validator.WithRequiredStructEnabled(),
+	)
+
+	conn := client.NewDBConnection(ctx, cfg)
+	queries := client.NewDBQueries(conn)
+
+	s.app = application.ProvideTask(
+		repositorypostgres.ProvideTask(queries),
+		service.ProvideTask(validate),
+		cfg,
+	)
+}
+
+func (s *TaskTestSuite) TearDownSuite() {
+	s.containers.Cleanup(s.T())
+}
+
+func (s *TaskTestSuite) listTasks(status domain.TaskStatus) []http_dto.TaskResponseDto {
+	s.T().Helper()
+	tasks, err := s.app.List(s.T().Context(), http_dto.ListTaskRequestDto{
+		PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+		Kinds:                []domain.TaskKind{taskKindTest},
+		Status:               status,
+	})
+	s.Require().NoError(err)
+	return tasks.Data
+}
+
+func (s *TaskTestSuite) TestTaskLifecycle() {
+	ctx := s.T().Context()
+	recorder := &taskRecorder{}
+	run := job.RunTasksParam{
+		Kinds:  []domain.TaskKind{taskKindTest},
+		Handle: recorder.handle,
+	}
+	var deadTaskID uuid.UUID
+
+	s.Run("Enqueue tasks with a key once", func() {
+		scheduled := job.EnqueueTaskParam{
+			Kind:    taskKindTest,
+			Key:     "test@2026-10-19T00:00:00Z",
+			Payload: taskTestPayload{Value: "scheduled"},
+			RunAt:   time.Now(),
+		}
+		s.Require().NoError(s.app.EnqueueTasks(ctx, []job.EnqueueTaskParam{scheduled}))
+		// Every worker enqueues the scheduled runs
+		s.Require().NoError(s.app.EnqueueTasks(ctx, []job.EnqueueTaskParam{scheduled}))
+		s.Require().NoError(s.app.EnqueueTasks(ctx, []job.EnqueueTaskParam{
+			{Kind: taskKindOther, Key: scheduled.Key, RunAt: time.Now()},
+		}))
+
+		tasks := s.listTasks(domain.TaskStatusPending)
+		s.Require().Len(tasks, 1, "one task of the key")
+		s.Equal(scheduled.Key, tasks[0].Key)
+		s.JSONEq(`{"value":"scheduled"}`, string(tasks[0].Payload))
+	})
+
+	s.Run("Run due tasks of the handled kinds", func() {
+		recorder.reset()
+		s.Require().NoError(s.app.EnqueueTasks(ctx, []job.EnqueueTaskParam{
+			{Kind: taskKindTest, RunAt: time.Now().Add(time.Hour)},
+		}))
+
+		s.Require().NoError(s.app.RunTasks(ctx, run))
+		runs := recorder.ran()
+		s.Require().Len(runs, 1, "tasks not due and of other kinds are left")
+		var payload taskTestPayload
+		s.Require().NoError(json.Unmarshal(runs[0].Payload, &payload))
+		s.Equal("scheduled", payload.Value)
+
+		tasks := s.listTasks(domain.TaskStatusSucceeded)
+		s.Require().Len(tasks, 1)
+		s.Equal(1, tasks[0].Attempts)
+		s.NotNil(tasks[0].FinishedAt)
+
+		s.Require().NoError(s.app.RunTasks(ctx, run))
+		s.Len(recorder.ran(), 1, "succeeded tasks are not run again")
+	})
+
+	s.Run("Retry failed task until dead", func() {
+		recorder.reset(errors.New("first"), errors.New("second"))
+		s.Require().NoError(s.app.EnqueueTasks(ctx, []job.EnqueueTaskParam{
+			{Kind: taskKindTest, RunAt: time.Now()},
+		}))
+
+		s.Require().NoError(s.app.RunTasks(ctx, run))
+		tasks := s.listTasks(domain.TaskStatusPending)
+		failed := tasks[0]
+		s.Equal(1, failed.Attempts)
+		s.Equal("first", failed.Error)
+
+		time.Sleep(10 * time.Millisecond)
+		s.Require().NoError(s.app.RunTasks(ctx, run))
+		dead, err := s.app.Get(ctx, http_dto.GetTaskRequestDto{TaskID: failed.ID})
+		s.Require().NoError(err)
+		s.Equal(domain.TaskStatusDead, dead.Status)
+		s.Equal(2, dead.Attempts)
+		s.Equal("second", dead.Error)
+		s.NotNil(dead.FinishedAt)
+		deadTaskID = dead.ID
+
+		time.Sleep(10 * time.Millisecond)
+		s.Require().NoError(s.app.RunTasks(ctx, run))
+		s.Len(recorder.ran(), 2, "dead tasks are not run again")
+	})
+
+	s.Run("Retry dead task", func() {
+		recorder.reset()
+
+		task, err := s.app.Retry(ctx, http_dto.RetryTaskRequestDto{TaskID: deadTaskID})
+		s.Require().NoError(err)
+		s.Equal(domain.TaskStatusPending, task.Status)
+		s.Equal(0, task.Attempts)
+		s.Nil(task.FinishedAt)
+
+		_, err = s.app.Retry(ctx, http_dto.RetryTaskRequestDto{TaskID: deadTaskID})
+		s.ErrorIs(err, domain.ErrConflict, "only dead tasks are retried")
+		_, err = s.app.Retry(ctx, http_dto.RetryTaskRequestDto{TaskID: uuid.New()})
+		s.ErrorIs(err, domain.ErrNotFound)
+
+		s.Require().NoError(s.app.RunTasks(ctx, run))
+		s.Require().Len(recorder.ran(), 1)
+		task, err = s.app.Get(ctx, http_dto.GetTaskRequestDto{TaskID: deadTaskID})
+		s.Require().NoError(err)
+		s.Equal(domain.TaskStatusSucceeded, task.Status)
+		s.Empty(task.Error)
+	})
+
+	s.Run("List with invalid status", func() {
+		_, err := s.app.List(ctx, http_dto.ListTaskRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+			Status:               "unknown",
+		})
+		s.ErrorIs(err, domain.ErrInvalid)
+	})
+
+	s.Run("Purge succeeded tasks", func() {
+		s.Require().NoError(s.app.PurgeTasks(ctx))
+		s.Empty(s.listTasks(domain.TaskStatusSucceeded), "succeeded before the retention period")
+		s.Len(s.listTasks(domain.TaskStatusPending), 1, "pending tasks are kept")
+	})
+}
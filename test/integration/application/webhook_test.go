@@ -0,0 +1,359 @@
+// vim: tabstop=4 shiftwidth=4:
+//go:build integration
+
+package application_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/config"
+	"backend/internal/application"
+	"backend/internal/client"
+	http_dto "backend/internal/delivery/http"
+	"backend/internal/domain"
+	"backend/internal/infrastructure/eventredis"
+	"backend/internal/infrastructure/repositorypostgres"
+	"backend/internal/infrastructure/webhookhttp"
+	"backend/internal/service"
+	"backend/test/integration/component"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+	containers     *component.Containers
+	app            *application.Webhook
+	eventPublisher *eventredis.Publisher
+	receiver       *webhookReceiver
+}
+
+// webhookReceiver is a partner endpoint answering each delivery with the next
+// status of statuses, then 200
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []webhookReceiverRequest
+}
+
+type webhookReceiverRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver() *webhookReceiver {
+	r := &webhookReceiver{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, webhookReceiverRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, "status %d", status)
+	}))
+	return r
+}
+
+func (r *webhookReceiver) reset(statuses ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses = statuses
+	r.requests = nil
+}
+
+func (r *webhookReceiver) received() []webhookReceiverRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]webhookReceiverRequest(nil), r.requests...)
+}
+
+func TestWebhookSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WebhookTestSuite))
+}
+
+func (s *WebhookTestSuite) newContainersConfig() *component.ContainersConfig {
+	return component.NewContainersConfig(&component.NewContainersConfigParam{
+		DBEnabled:    true,
+		RedisEnabled: true,
+	})
+}
+
+func (s *WebhookTestSuite) newConfig(
+	ctx context.Context,
+) *config.Server {
+	s.T().Helper()
+
+	dbConnStr, err := s.containers.DB.ConnectionString(ctx, "sslmode=disable")
+	s.Require().NoError(err, "failed to get db connection string")
+	redisConnStr, err := s.containers.Redis.ConnectionString(ctx)
+	s.Require().NoError(err, "failed to get redis connection string")
+
+	return &config.Server{
+		DBURL:                    dbConnStr,
+		RedisAddr:                strings.TrimPrefix(redisConnStr, "redis://"),
+		EventStream:              "events",
+		EventStreamMaxLen:        1000,
+		EventConsumerName:        "test",
+		EventConsumerBatchSize:   100,
+		EventConsumerMinIdle:     time.Minute,
+		WebhookEventGroup:        "webhooks",
+		WebhookDeliveryBatchSize: 100,
+		WebhookDeliveryLease:     time.Minute,
+		WebhookTimeout:           5 * time.Second,
+		WebhookRetryBaseDelay:    time.Millisecond,
+		WebhookRetryMaxDelay:     time.Millisecond,
+		WebhookMaxAttempts:       3,
+	}
+}
+
+func (s *WebhookTestSuite) SetupSuite() {
+	ctx := s.T().Context()
+
+	var err error
+	s.containers, err = component.NewContainers(ctx, s.newContainersConfig())
+	s.Require().NoError(err, "failed to start containers")
+
+	cfg := s.newConfig(ctx)
+
+	validate := validator.New(
+		validator.WithRequiredStructEnabled(),
+	)
+
+	conn := client.NewDBConnection(ctx, cfg)
+	queries := client.NewDBQueries(conn)
+	redisClient := client.NewRedis(ctx, cfg)
+
+	s.eventPublisher = eventredis.ProvidePublisher(redisClient, cfg)
+	s.app = application.ProvideWebhook(
+		eventredis.ProvideConsumer(redisClient, cfg),
+		webhookhttp.ProvideSender(cfg),
+		repositorypostgres.ProvideWebhook(queries),
+		repositorypostgres.ProvideWebhookDelivery(queries),
+		service.ProvideWebhook(validate),
+		cfg,
+	)
+	s.receiver = newWebhookReceiver()
+}
+
+func (s *WebhookTestSuite) TearDownSuite() {
+	s.receiver.Close()
+	s.containers.Cleanup(s.T())
+}
+
+func (s *WebhookTestSuite) TestWebhookLifecycle() {
+	ctx := s.T().Context()
+	var webhook *http_dto.WebhookResponseDto
+	var deliveryID uuid.UUID
+	event := domain.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: domain.AggregateTypeOrder,
+		AggregateID:   uuid.New(),
+		Type:          domain.EventTypeOrderPaid,
+		Payload:       []byte(`{"orderId":"00000000-0000-7000-0000-000000000001"}`),
+		OccurredAt:    time.Now(),
+	}
+
+	verify := func(req webhookReceiverRequest) {
+		s.Equal("application/json", req.header.Get("Content-Type"))
+		s.Equal(event.ID.String(), req.header.Get("Webhook-Id"))
+		s.Equal(string(domain.EventTypeOrderPaid), req.header.Get("Webhook-Event"))
+
+		var timestamp int64
+		var signature string
+		_, err := fmt.Sscanf(req.header.Get("Webhook-Signature"), "t=%d,v1=%s", &timestamp, &signature)
+		s.Require().NoError(err)
+		mac := hmac.New(sha256.New, []byte(webhook.Secret))
+		_, _ = fmt.Fprintf(mac, "%d.%s", timestamp, req.body)
+		s.Equal(hex.EncodeToString(mac.Sum(nil)), signature, "signed with the webhook secret")
+	}
+
+	s.Run("Create webhook", func() {
+		var err error
+		webhook, err = s.app.Create(ctx, http_dto.CreateWebhookRequestDto{
+			Data: http_dto.CreateWebhookData{
+				URL:        s.receiver.URL,
+				EventTypes: []domain.EventType{domain.EventTypeOrderPaid, domain.EventTypeStockChanged},
+			},
+		})
+		s.Require().NoError(err)
+		s.True(webhook.Active)
+		s.True(strings.HasPrefix(webhook.Secret, "whsec_"))
+	})
+
+	s.Run("Mask secret outside of create and rotation", func() {
+		masked := "whsec_…" + webhook.Secret[len(webhook.Secret)-4:]
+
+		got, err := s.app.Get(ctx, http_dto.GetWebhookRequestDto{WebhookID: webhook.ID})
+		s.Require().NoError(err)
+		s.Equal(masked, got.Secret)
+
+		list, err := s.app.List(ctx, http_dto.ListWebhookRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(list.Data, 1)
+		s.Equal(masked, list.Data[0].Secret)
+
+		updated, err := s.app.Update(ctx, http_dto.UpdateWebhookRequestDto{
+			WebhookID: webhook.ID,
+			Data:      http_dto.UpdateWebhookData{},
+		})
+		s.Require().NoError(err)
+		s.Equal(masked, updated.Secret)
+
+		rotated, err := s.app.Update(ctx, http_dto.UpdateWebhookRequestDto{
+			WebhookID: webhook.ID,
+			Data:      http_dto.UpdateWebhookData{RotateSecret: true},
+		})
+		s.Require().NoError(err)
+		s.Regexp("^whsec_[0-9a-f]{64}$", rotated.Secret)
+		s.NotEqual(webhook.Secret, rotated.Secret)
+		webhook = rotated
+	})
+
+	s.Run("Create webhook with unknown event type", func() {
+		_, err := s.app.Create(ctx, http_dto.CreateWebhookRequestDto{
+			Data: http_dto.CreateWebhookData{
+				URL:        s.receiver.URL,
+				EventTypes: []domain.EventType{domain.EventTypeProductCreated},
+			},
+		})
+		s.ErrorIs(err, domain.ErrInvalid)
+	})
+
+	s.Run("Enqueue subscribed events once", func() {
+		s.Require().NoError(s.eventPublisher.Publish(ctx, event))
+		s.Require().NoError(s.eventPublisher.Publish(ctx, domain.OutboxEvent{
+			ID:            uuid.New(),
+			AggregateType: domain.AggregateTypeOrder,
+			AggregateID:   event.AggregateID,
+			Type:          domain.EventTypeOrderCreated,
+			Payload:       []byte(`{}`),
+			OccurredAt:    time.Now(),
+		}))
+		// The relay publishes at least once
+		s.Require().NoError(s.eventPublisher.Publish(ctx, event))
+		s.Require().NoError(s.app.EnqueueDeliveries(ctx))
+		s.Require().NoError(s.app.EnqueueDeliveries(ctx))
+
+		deliveries, err := s.app.ListDeliveries(ctx, http_dto.ListWebhookDeliveryRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+			WebhookID:            webhook.ID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(deliveries.Data, 1, "one delivery of the subscribed event")
+		delivery := deliveries.Data[0]
+		s.Equal(event.ID, delivery.EventID)
+		s.Equal(domain.WebhookDeliveryStatusPending, delivery.Status)
+		deliveryID = delivery.ID
+	})
+
+	s.Run("Retry failed delivery", func() {
+		s.receiver.reset(http.StatusInternalServerError)
+
+		s.Require().NoError(s.app.SendDeliveries(ctx))
+		deliveries, err := s.app.ListDeliveries(ctx, http_dto.ListWebhookDeliveryRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+			WebhookID:            webhook.ID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(deliveries.Data, 1)
+		s.Equal(domain.WebhookDeliveryStatusPending, deliveries.Data[0].Status)
+		s.Equal(1, deliveries.Data[0].Attempts)
+		s.Equal(http.StatusInternalServerError, deliveries.Data[0].ResponseCode)
+		s.Equal("status 500", deliveries.Data[0].ResponseBody)
+
+		time.Sleep(10 * time.Millisecond)
+		s.Require().NoError(s.app.SendDeliveries(ctx))
+		deliveries, err = s.app.ListDeliveries(ctx, http_dto.ListWebhookDeliveryRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+			WebhookID:            webhook.ID,
+			Status:               domain.WebhookDeliveryStatusSucceeded,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(deliveries.Data, 1)
+		s.Equal(2, deliveries.Data[0].Attempts)
+		s.Equal(http.StatusOK, deliveries.Data[0].ResponseCode)
+		s.NotNil(deliveries.Data[0].DeliveredAt)
+
+		requests := s.receiver.received()
+		s.Require().Len(requests, 2)
+		for _, req := range requests {
+			verify(req)
+		}
+		s.Equal(requests[0].body, requests[1].body, "retries send the same body")
+		s.Equal(
+			requests[0].header.Get("Webhook-Delivery"),
+			requests[1].header.Get("Webhook-Delivery"),
+		)
+
+		s.Require().NoError(s.app.SendDeliveries(ctx))
+		s.Len(s.receiver.received(), 2, "succeeded deliveries are not sent again")
+	})
+
+	s.Run("Redeliver", func() {
+		s.receiver.reset()
+
+		delivery, err := s.app.Redeliver(ctx, http_dto.RedeliverWebhookDeliveryRequestDto{
+			WebhookID:  webhook.ID,
+			DeliveryID: deliveryID,
+		})
+		s.Require().NoError(err)
+		s.Equal(domain.WebhookDeliveryStatusPending, delivery.Status)
+
+		_, err = s.app.Redeliver(ctx, http_dto.RedeliverWebhookDeliveryRequestDto{
+			WebhookID:  uuid.New(),
+			DeliveryID: deliveryID,
+		})
+		s.ErrorIs(err, domain.ErrNotFound)
+
+		s.Require().NoError(s.app.SendDeliveries(ctx))
+		requests := s.receiver.received()
+		s.Require().Len(requests, 1)
+		verify(requests[0])
+	})
+
+	s.Run("Abandon deliveries of deactivated webhook", func() {
+		s.receiver.reset()
+
+		_, err := s.app.Redeliver(ctx, http_dto.RedeliverWebhookDeliveryRequestDto{
+			WebhookID:  webhook.ID,
+			DeliveryID: deliveryID,
+		})
+		s.Require().NoError(err)
+		_, err = s.app.Update(ctx, http_dto.UpdateWebhookRequestDto{
+			WebhookID: webhook.ID,
+			Data:      http_dto.UpdateWebhookData{Active: new(bool)},
+		})
+		s.Require().NoError(err)
+
+		s.Require().NoError(s.app.SendDeliveries(ctx))
+		s.Empty(s.receiver.received())
+		deliveries, err := s.app.ListDeliveries(ctx, http_dto.ListWebhookDeliveryRequestDto{
+			PaginationRequestDto: http_dto.PaginationRequestDto{Page: 1, Limit: 20},
+			WebhookID:            webhook.ID,
+			Status:               domain.WebhookDeliveryStatusFailed,
+		})
+		s.Require().NoError(err)
+		s.Len(deliveries.Data, 1)
+	})
+}
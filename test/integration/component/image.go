@@ -0,0 +1,27 @@
+package component
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// NewImage encodes a PNG of the given size filled with a gradient, uploads
+// must be real images since they are decoded when persisted
+func NewImage(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(x * 255 / width),
+				G: uint8(y * 255 / height),
+				B: 128,
+				A: 255,
+			})
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
@@ -0,0 +1,165 @@
+//go:build integration
+
+package component
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// SMTPSink is a local SMTP server keeping the messages it receives instead of
+// relaying them. It answers the DATA of each message with the next reply of
+// the ones queued by Reset, then accepts the rest
+type SMTPSink struct {
+	listener net.Listener
+	mu       sync.Mutex
+	replies  []string
+	messages []SMTPMessage
+	wg       sync.WaitGroup
+}
+
+type SMTPMessage struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+func NewSMTPSink() (*SMTPSink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &SMTPSink{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+func (s *SMTPSink) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *SMTPSink) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Reset forgets the received messages and queues replies such as
+// "451 4.3.0 try again later" for the next messages
+func (s *SMTPSink) Reset(replies ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies = replies
+	s.messages = nil
+}
+
+func (s *SMTPSink) Messages() []SMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SMTPMessage(nil), s.messages...)
+}
+
+func (s *SMTPSink) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPSink) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *SMTPSink) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := fmt.Fprintf(conn, "%s\r\n", line)
+		return err == nil
+	}
+	if !reply("220 localhost SMTP sink") {
+		return
+	}
+
+	var msg SMTPMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case "HELO", "NOOP":
+			reply("250 OK")
+		case "RSET":
+			msg = SMTPMessage{}
+			reply("250 OK")
+		case "MAIL":
+			msg = SMTPMessage{From: smtpPath(line)}
+			reply("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, smtpPath(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data bytes.Buffer
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			msg.Data = data.Bytes()
+			reply(s.receive(msg))
+			msg = SMTPMessage{}
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *SMTPSink) receive(msg SMTPMessage) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.replies) > 0 {
+		var reply string
+		reply, s.replies = s.replies[0], s.replies[1:]
+		return reply
+	}
+	s.messages = append(s.messages, msg)
+	return "250 OK"
+}
+
+// smtpPath reads the address of a MAIL FROM:<a@b> or RCPT TO:<a@b> command
+func smtpPath(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}